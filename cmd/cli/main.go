@@ -156,7 +156,7 @@ func main() {
 				Errs:      asyncErrs,
 			},
 		}
-		mp := manage.NewManagedProducer(mcp, mpCfg)
+		mp := manage.NewManagedProducer(ctx, mcp, mpCfg)
 		fmt.Printf("Created producer on topic %q...\n", args.topic)
 
 		// messages to produce are sent to this
@@ -226,7 +226,7 @@ func main() {
 			},
 		}
 
-		mc := manage.NewManagedConsumer(mcp, mcCfg)
+		mc := manage.NewManagedConsumer(ctx, mcp, mcCfg)
 		go mc.ReceiveAsync(ctx, queue)
 		fmt.Printf("Created consumer %q on topic %q...\n", args.name, args.topic)
 
@@ -0,0 +1,312 @@
+// Copyright 2018 Comcast Cable Communications Management, LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// This program generates load on a Pulsar topic using the `manage`
+// package, and reports throughput and latency percentiles.
+//
+// It's main goal is to quantify performance regressions of the
+// `manage` package from one release to the next.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"math/rand"
+	"os"
+	"os/signal"
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"time"
+
+	"github.com/pepper-iot/pulsar-client-go/core/manage"
+	"github.com/pepper-iot/pulsar-client-go/core/msg"
+	"github.com/pepper-iot/pulsar-client-go/utils"
+)
+
+var args = struct {
+	pulsar      string
+	topic       string
+	consume     bool
+	parallelism int
+	concurrency int
+	rate        float64
+	size        int
+	count       uint64
+	duration    time.Duration
+	interval    time.Duration
+	sub         string
+	mode        string
+}{
+	pulsar:      "localhost:6650",
+	topic:       "persistent://sample/standalone/ns1/bench",
+	parallelism: 1,
+	concurrency: 1,
+	size:        1024,
+	interval:    10 * time.Second,
+	sub:         "bench",
+	mode:        "shared",
+}
+
+// quantiles are the latency percentiles reported.
+var quantiles = []float64{0.5, 0.95, 0.99, 0.999}
+
+// bench counts the messages and bytes sent or received,
+// and their latencies.
+type bench struct {
+	msgs    uint64 // accessed atomically
+	bytes   uint64 // accessed atomically
+	errs    uint64 // accessed atomically
+	latency utils.Histogram
+}
+
+func (b *bench) observe(size int, latency time.Duration) uint64 {
+	atomic.AddUint64(&b.bytes, uint64(size))
+	b.latency.Observe(latency)
+	return atomic.AddUint64(&b.msgs, 1)
+}
+
+// report is a snapshot of a bench.
+type report struct {
+	at      time.Time
+	msgs    uint64
+	bytes   uint64
+	errs    uint64
+	latency utils.HistogramSnapshot
+}
+
+func (b *bench) report() report {
+	return report{
+		at:      time.Now(),
+		msgs:    atomic.LoadUint64(&b.msgs),
+		bytes:   atomic.LoadUint64(&b.bytes),
+		errs:    atomic.LoadUint64(&b.errs),
+		latency: b.latency.Snapshot(),
+	}
+}
+
+// since returns the report of what happened between prev and r.
+func (r report) since(prev report) report {
+	d := report{
+		at:    r.at,
+		msgs:  r.msgs - prev.msgs,
+		bytes: r.bytes - prev.bytes,
+		errs:  r.errs - prev.errs,
+		latency: utils.HistogramSnapshot{
+			Count:   r.latency.Count - prev.latency.Count,
+			Sum:     r.latency.Sum - prev.latency.Sum,
+			Buckets: make([]utils.Bucket, len(r.latency.Buckets)),
+		},
+	}
+	for i, b := range r.latency.Buckets {
+		d.latency.Buckets[i] = utils.Bucket{
+			UpperBound: b.UpperBound,
+			Count:      b.Count - prev.latency.Buckets[i].Count,
+		}
+	}
+	return d
+}
+
+// print prints r, covering the period of time elapsed.
+func (r report) print(label string, elapsed time.Duration) {
+	secs := elapsed.Seconds()
+	fmt.Printf("%s: %.1f msg/s, %.3f MB/s, %d errors, latency mean %v",
+		label, float64(r.msgs)/secs, float64(r.bytes)/secs/1e6, r.errs, r.latency.Mean())
+	for _, q := range quantiles {
+		fmt.Printf(", p%g %v", q*100, r.latency.Quantile(q))
+	}
+	fmt.Println()
+}
+
+func main() {
+	flag.StringVar(&args.pulsar, "pulsar", args.pulsar, "pulsar address")
+	flag.StringVar(&args.topic, "topic", args.topic, "topic to produce to or consume from")
+	flag.BoolVar(&args.consume, "consume", args.consume, "if true, consume messages, otherwise produce")
+	flag.IntVar(&args.parallelism, "parallelism", args.parallelism, "number of producers or consumers")
+	flag.IntVar(&args.concurrency, "concurrency", args.concurrency, "number of concurrent sends per producer")
+	flag.Float64Var(&args.rate, "rate", args.rate, "total messages per second to produce. Unlimited if 0")
+	flag.IntVar(&args.size, "size", args.size, "size in bytes of produced messages")
+	flag.Uint64Var(&args.count, "n", args.count, "(optional) number of messages to produce or consume before exiting")
+	flag.DurationVar(&args.duration, "duration", args.duration, "(optional) duration to run for before exiting")
+	flag.DurationVar(&args.interval, "interval", args.interval, "interval between reports")
+	flag.StringVar(&args.sub, "sub", args.sub, "subscription name of consumers")
+	flag.StringVar(&args.mode, "sub-mode", args.mode, "shared, exclusive, failover")
+	flag.Parse()
+
+	asyncErrs := make(chan error, 8)
+	go func() {
+		for err := range asyncErrs {
+			fmt.Fprintln(os.Stderr, "error:", err)
+		}
+	}()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	if args.duration > 0 {
+		ctx, cancel = context.WithTimeout(context.Background(), args.duration)
+	}
+	defer cancel()
+
+	sigs := make(chan os.Signal, 1)
+	signal.Notify(sigs, syscall.SIGINT, syscall.SIGTERM)
+	go func() {
+		<-sigs
+		cancel()
+	}()
+
+	cfg := manage.ClientConfig{
+		Addr: args.pulsar,
+		Errs: asyncErrs,
+	}
+
+	var (
+		b     bench
+		label = "produced"
+		wg    sync.WaitGroup
+	)
+	run := produce
+	if args.consume {
+		label, run = "consumed", consume
+	}
+
+	start := time.Now()
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		run(ctx, cancel, manage.NewClientPool(), cfg, &b)
+	}()
+
+	ticker := time.NewTicker(args.interval)
+	defer ticker.Stop()
+	prev := b.report()
+loop:
+	for {
+		select {
+		case <-ctx.Done():
+			break loop
+		case <-ticker.C:
+			r := b.report()
+			r.since(prev).print(label, r.at.Sub(prev.at))
+			prev = r
+		}
+	}
+	wg.Wait()
+
+	r := b.report()
+	r.print("total "+label, r.at.Sub(start))
+}
+
+// produce sends messages from args.parallelism producers until ctx is
+// done or args.count messages are sent, then calls done.
+func produce(ctx context.Context, done func(), cp *manage.ClientPool, cfg manage.ClientConfig, b *bench) {
+	payload := make([]byte, args.size)
+	rand.Read(payload)
+
+	var tokens <-chan time.Time
+	if args.rate > 0 {
+		ticker := time.NewTicker(time.Duration(float64(time.Second) / args.rate))
+		defer ticker.Stop()
+		tokens = ticker.C
+	}
+
+	var (
+		wg      sync.WaitGroup
+		claimed uint64 // number of messages claimed by senders; accessed atomically
+	)
+	for i := 0; i < args.parallelism; i++ {
+		mp := manage.NewManagedProducer(ctx, cp, manage.ProducerConfig{
+			ClientConfig: cfg,
+			Topic:        args.topic,
+		})
+
+		for j := 0; j < args.concurrency; j++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for {
+					if tokens != nil {
+						select {
+						case <-tokens:
+						case <-ctx.Done():
+							return
+						}
+					}
+					if args.count > 0 && atomic.AddUint64(&claimed, 1) > args.count {
+						return
+					}
+
+					start := time.Now()
+					if _, err := mp.Send(ctx, payload); err != nil {
+						if ctx.Err() != nil {
+							return
+						}
+						atomic.AddUint64(&b.errs, 1)
+						continue
+					}
+					b.observe(len(payload), time.Since(start))
+				}
+			}()
+		}
+	}
+	wg.Wait()
+	done()
+}
+
+// consume receives messages with args.parallelism consumers until ctx
+// is done or args.count messages are received, then calls done. The
+// latency of a message is the time elapsed since it was published.
+func consume(ctx context.Context, done func(), cp *manage.ClientPool, cfg manage.ClientConfig, b *bench) {
+	mode := manage.SubscriptionModeShard
+	switch args.mode {
+	case "exclusive":
+		mode = manage.SubscriptionModeExclusive
+	case "failover":
+		mode = manage.SubscriptionModeFailover
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < args.parallelism; i++ {
+		mc := manage.NewManagedConsumer(ctx, cp, manage.ConsumerConfig{
+			ClientConfig: cfg,
+			Topic:        args.topic,
+			Name:         args.sub,
+			SubMode:      mode,
+			QueueSize:    1000,
+		})
+		queue := make(chan msg.Message, 1000)
+		go mc.ReceiveAsync(ctx, queue)
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-ctx.Done():
+					return
+
+				case m := <-queue:
+					published := time.Unix(0, int64(m.Meta.GetPublishTime())*int64(time.Millisecond))
+					n := b.observe(len(m.Payload), m.ReceivedAt.Sub(published))
+					if err := mc.Ack(ctx, m); err != nil && ctx.Err() == nil {
+						atomic.AddUint64(&b.errs, 1)
+					}
+					if args.count > 0 && n >= args.count {
+						done()
+						return
+					}
+				}
+			}
+		}()
+	}
+	wg.Wait()
+}
@@ -0,0 +1,577 @@
+// Copyright 2018 Comcast Cable Communications Management, LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// This program is an operator CLI for looking up topics, producing
+// messages, tailing and seeking subscriptions, and dumping subscription
+// stats, using the `manage` package.
+//
+// It's main goal is to debug Pulsar topics without switching to the
+// Java tooling.
+package main
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/signal"
+	"sort"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/pepper-iot/pulsar-client-go/core/manage"
+	"github.com/pepper-iot/pulsar-client-go/core/msg"
+	"github.com/pepper-iot/pulsar-client-go/core/sub"
+	"github.com/pepper-iot/pulsar-client-go/pkg/api"
+)
+
+var args = struct {
+	pulsar        string
+	tlsCert       string
+	tlsKey        string
+	tlsCA         string
+	tlsSkipVerify bool
+	timeout       time.Duration
+}{
+	pulsar:  "localhost:6650",
+	timeout: 10 * time.Second,
+}
+
+var commands = []struct {
+	name  string
+	usage string
+	run   func(ctx context.Context, cfg manage.ClientConfig, argv []string) error
+}{
+	{"lookup", "lookup <topic>: print the broker serving a topic, and its partitions", lookup},
+	{"produce", "produce [flags] <topic> [message...]: send messages, or lines from STDIN", produce},
+	{"tail", "tail [flags] <topic>: print messages received on a subscription", tail},
+	{"seek", "seek [flags] <topic> [ledger:entry]: reset a subscription's cursor", seek},
+	{"stats", "stats [flags] <topic>: print the broker's stats for a subscription", stats},
+}
+
+// errUsage is returned by commands when their arguments are invalid,
+// after printing their usage.
+var errUsage = errors.New("invalid arguments")
+
+func main() {
+	flag.StringVar(&args.pulsar, "pulsar", args.pulsar, "pulsar address")
+	flag.StringVar(&args.tlsCert, "tls-cert", args.tlsCert, "(optional) path to TLS certificate")
+	flag.StringVar(&args.tlsKey, "tls-key", args.tlsKey, "(optional) path to TLS key")
+	flag.StringVar(&args.tlsCA, "tls-ca", args.tlsCA, "(optional) path to root certificate")
+	flag.BoolVar(&args.tlsSkipVerify, "tls-insecure", args.tlsSkipVerify, "if true, do not verify server certificate chain when using TLS")
+	flag.DurationVar(&args.timeout, "timeout", args.timeout, "timeout of each request to the broker")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "Usage: %s [flags] <command> [command flags] [args]\n\nCommands:\n", os.Args[0])
+		for _, c := range commands {
+			fmt.Fprintf(flag.CommandLine.Output(), "  %s\n", c.usage)
+		}
+		fmt.Fprintln(flag.CommandLine.Output(), "\nFlags:")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	if flag.NArg() == 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	for _, c := range commands {
+		if c.name != flag.Arg(0) {
+			continue
+		}
+
+		tlsCfg, err := loadTLS()
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+
+		asyncErrs := make(chan error, 8)
+		go func() {
+			for err := range asyncErrs {
+				fmt.Fprintln(os.Stderr, "error:", err)
+			}
+		}()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		sigs := make(chan os.Signal, 1)
+		signal.Notify(sigs, syscall.SIGINT, syscall.SIGTERM)
+		go func() {
+			<-sigs
+			cancel()
+		}()
+
+		cfg := manage.ClientConfig{
+			Addr:        args.pulsar,
+			TLSConfig:   tlsCfg,
+			DialTimeout: args.timeout,
+			Errs:        asyncErrs,
+		}
+		err = c.run(ctx, cfg, flag.Args()[1:])
+		cancel()
+		switch {
+		case err == errUsage:
+			os.Exit(2)
+		case err != nil && ctx.Err() == nil:
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	fmt.Fprintf(os.Stderr, "unknown command %q\n", flag.Arg(0))
+	flag.Usage()
+	os.Exit(2)
+}
+
+// loadTLS returns the TLS configuration set by flags,
+// or nil if TLS isn't used.
+func loadTLS() (*tls.Config, error) {
+	if args.tlsCert == "" || args.tlsKey == "" {
+		return nil, nil
+	}
+
+	cert, err := tls.LoadX509KeyPair(args.tlsCert, args.tlsKey)
+	if err != nil {
+		return nil, fmt.Errorf("error loading certificates: %v", err)
+	}
+	tlsCfg := &tls.Config{
+		InsecureSkipVerify: args.tlsSkipVerify,
+		Certificates:       []tls.Certificate{cert},
+	}
+
+	if args.tlsCA != "" {
+		rootCA, err := ioutil.ReadFile(args.tlsCA)
+		if err != nil {
+			return nil, fmt.Errorf("error loading certificate authority: %v", err)
+		}
+		tlsCfg.RootCAs = x509.NewCertPool()
+		tlsCfg.RootCAs.AppendCertsFromPEM(rootCA)
+	}
+	return tlsCfg, nil
+}
+
+// newFlagSet returns the flag set of the named command, which
+// expects a topic followed by the arguments described by usage.
+func newFlagSet(name, usage string) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage: %s %s [flags] <topic> %s\n", os.Args[0], name, usage)
+		fs.PrintDefaults()
+	}
+	return fs
+}
+
+// parseArgs parses argv into fs, and returns the topic
+// and remaining arguments.
+func parseArgs(fs *flag.FlagSet, argv []string) (string, []string, error) {
+	if err := fs.Parse(argv); err != nil {
+		return "", nil, errUsage
+	}
+	if fs.NArg() == 0 {
+		fs.Usage()
+		return "", nil, errUsage
+	}
+	return fs.Arg(0), fs.Args()[1:], nil
+}
+
+// subFlags are the flags of commands attaching to a subscription.
+type subFlags struct {
+	name string
+	mode string
+}
+
+func (s *subFlags) register(fs *flag.FlagSet, mode string) {
+	s.name = "pulsar-cli"
+	s.mode = mode
+	fs.StringVar(&s.name, "sub", s.name, "subscription name")
+	fs.StringVar(&s.mode, "sub-mode", s.mode, "shared, exclusive, failover")
+}
+
+func (s *subFlags) subType() (api.CommandSubscribe_SubType, error) {
+	switch s.mode {
+	case "shared":
+		return api.CommandSubscribe_Shared, nil
+	case "exclusive":
+		return api.CommandSubscribe_Exclusive, nil
+	case "failover":
+		return api.CommandSubscribe_Failover, nil
+	default:
+		return 0, fmt.Errorf("unknown subscription mode %q", s.mode)
+	}
+}
+
+func (s *subFlags) manageMode() (manage.SubscriptionMode, error) {
+	t, err := s.subType()
+	switch t {
+	case api.CommandSubscribe_Shared:
+		return manage.SubscriptionModeShard, err
+	case api.CommandSubscribe_Failover:
+		return manage.SubscriptionModeFailover, err
+	default:
+		return manage.SubscriptionModeExclusive, err
+	}
+}
+
+// subscribe attaches a consumer to the subscription on the broker serving
+// topic. It doesn't request messages, so none are delivered to it.
+func (s *subFlags) subscribe(ctx context.Context, cp *manage.ClientPool, cfg manage.ClientConfig, topic string) (*manage.Client, *sub.Consumer, error) {
+	subType, err := s.subType()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, args.timeout)
+	defer cancel()
+
+	mc, err := cp.ForTopic(ctx, cfg, topic)
+	if err != nil {
+		return nil, nil, err
+	}
+	client, err := mc.Get(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	c, err := client.NewConsumerWithSchema(ctx, topic, s.name, subType, sub.Latest, make(chan msg.Message, 1), nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	return client, c, nil
+}
+
+func lookup(ctx context.Context, cfg manage.ClientConfig, argv []string) error {
+	fs := newFlagSet("lookup", "")
+	topic, _, err := parseArgs(fs, argv)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, args.timeout)
+	defer cancel()
+
+	cp := manage.NewClientPool()
+	meta, err := cp.Partitions(ctx, cfg, topic)
+	if err != nil {
+		return err
+	}
+
+	topics := []string{topic}
+	if n := meta.GetPartitions(); n > 0 {
+		topics = topics[:0]
+		for i := uint32(0); i < n; i++ {
+			topics = append(topics, fmt.Sprintf("%s-partition-%d", topic, i))
+		}
+	}
+
+	for _, t := range topics {
+		mc, err := cp.ForTopic(ctx, cfg, t)
+		if err != nil {
+			return fmt.Errorf("lookup %q: %v", t, err)
+		}
+		client, err := mc.Get(ctx)
+		if err != nil {
+			return fmt.Errorf("lookup %q: %v", t, err)
+		}
+		// Ask the serving broker for its own address
+		resp, err := client.LookupTopic(ctx, t, true)
+		if err != nil {
+			return fmt.Errorf("lookup %q: %v", t, err)
+		}
+		addr := resp.GetBrokerServiceUrl()
+		if cfg.TLSConfig != nil {
+			addr = resp.GetBrokerServiceUrlTls()
+		}
+		fmt.Printf("%s\t%s\n", t, addr)
+	}
+	return nil
+}
+
+func produce(ctx context.Context, cfg manage.ClientConfig, argv []string) error {
+	var (
+		name  string
+		key   string
+		props = make(properties)
+	)
+	fs := newFlagSet("produce", "[message...]")
+	fs.StringVar(&name, "name", name, "(optional) producer name, assigned by the broker if empty")
+	fs.StringVar(&key, "key", key, "(optional) partition key of the messages")
+	fs.Var(props, "property", "(optional) message property as key=value. May be repeated")
+	topic, messages, err := parseArgs(fs, argv)
+	if err != nil {
+		return err
+	}
+
+	cctx, cancel := context.WithTimeout(ctx, args.timeout)
+	defer cancel()
+
+	cp := manage.NewClientPool()
+	mc, err := cp.ForTopic(cctx, cfg, topic)
+	if err != nil {
+		return err
+	}
+	client, err := mc.Get(cctx)
+	if err != nil {
+		return err
+	}
+	p, err := client.NewProducer(cctx, topic, name)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		ctx, cancel := context.WithTimeout(context.Background(), args.timeout)
+		defer cancel()
+		_ = p.Close(ctx)
+	}()
+
+	send := func(payload []byte) error {
+		b := msg.NewMetadata().Properties(props)
+		if key != "" {
+			b.Key(key)
+		}
+		ctx, cancel := context.WithTimeout(ctx, args.timeout)
+		defer cancel()
+		receipt, err := p.SendWithMetadata(ctx, b.Build(), payload)
+		if err != nil {
+			return err
+		}
+		fmt.Println(formatMessageID(receipt.GetMessageId()))
+		return nil
+	}
+
+	if len(messages) > 0 {
+		for _, m := range messages {
+			if err := send([]byte(m)); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	scanner := bufio.NewScanner(os.Stdin)
+	for scanner.Scan() {
+		if err := send(scanner.Bytes()); err != nil {
+			return err
+		}
+	}
+	return scanner.Err()
+}
+
+func tail(ctx context.Context, cfg manage.ClientConfig, argv []string) error {
+	var (
+		s        subFlags
+		earliest bool
+		ack      = true
+		count    int
+	)
+	fs := newFlagSet("tail", "")
+	s.register(fs, "exclusive")
+	fs.BoolVar(&earliest, "earliest", earliest, "if true, a new subscription starts at the beginning of the topic")
+	fs.BoolVar(&ack, "ack", ack, "if true, acknowledge received messages")
+	fs.IntVar(&count, "n", count, "(optional) number of messages to receive before exiting")
+	topic, _, err := parseArgs(fs, argv)
+	if err != nil {
+		return err
+	}
+	mode, err := s.manageMode()
+	if err != nil {
+		return err
+	}
+
+	start := sub.Latest
+	if earliest {
+		start = sub.Earliest
+	}
+
+	mc := manage.NewManagedConsumer(ctx, manage.NewClientPool(), manage.ConsumerConfig{
+		ClientConfig:       cfg,
+		Topic:              topic,
+		Name:               s.name,
+		SubMode:            mode,
+		InitialPosition:    start,
+		NewConsumerTimeout: args.timeout,
+	})
+	defer func() {
+		ctx, cancel := context.WithTimeout(context.Background(), args.timeout)
+		defer cancel()
+		_ = mc.Close(ctx)
+	}()
+
+	queue := make(chan msg.Message, 8)
+	go mc.ReceiveAsync(ctx, queue)
+
+	for received := 0; count <= 0 || received < count; received++ {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+
+		case m := <-queue:
+			printMessage(m)
+			if ack {
+				if err := mc.Ack(ctx, m); err != nil {
+					fmt.Fprintln(os.Stderr, "error acking message:", err)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func seek(ctx context.Context, cfg manage.ClientConfig, argv []string) error {
+	var (
+		s  subFlags
+		at string
+	)
+	fs := newFlagSet("seek", "[ledger:entry]")
+	s.register(fs, "shared")
+	fs.StringVar(&at, "time", at, "(optional) RFC 3339 publish time to seek to, instead of a message ID")
+	topic, rest, err := parseArgs(fs, argv)
+	if err != nil {
+		return err
+	}
+	if (at == "") == (len(rest) == 0) {
+		fs.Usage()
+		return errUsage
+	}
+
+	cp := manage.NewClientPool()
+	_, c, err := s.subscribe(ctx, cp, cfg, topic)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, args.timeout)
+	defer cancel()
+
+	// The broker disconnects the subscription's consumers
+	// once it has seeked, so c isn't closed
+	if at != "" {
+		t, err := time.Parse(time.RFC3339, at)
+		if err != nil {
+			return err
+		}
+		return c.SeekTime(ctx, t)
+	}
+	id, err := msg.ParseMessageID(rest[0])
+	if err != nil {
+		return err
+	}
+	return c.Seek(ctx, id.Data())
+}
+
+func stats(ctx context.Context, cfg manage.ClientConfig, argv []string) error {
+	var s subFlags
+	fs := newFlagSet("stats", "")
+	s.register(fs, "shared")
+	topic, _, err := parseArgs(fs, argv)
+	if err != nil {
+		return err
+	}
+
+	cp := manage.NewClientPool()
+	client, c, err := s.subscribe(ctx, cp, cfg, topic)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, args.timeout)
+	defer cancel()
+	defer func() { _ = c.Close(ctx) }()
+
+	reqID := client.NewRequestID()
+	f, err := client.RequestRaw(ctx, api.BaseCommand{
+		Type: api.BaseCommand_CONSUMER_STATS.Enum(),
+		ConsumerStats: &api.CommandConsumerStats{
+			RequestId:  proto.Uint64(reqID),
+			ConsumerId: proto.Uint64(c.ConsumerID),
+		},
+	}, reqID)
+	if err != nil {
+		return err
+	}
+	resp := f.BaseCmd.GetConsumerStatsResponse()
+	if resp.ErrorCode != nil {
+		return fmt.Errorf("%s: %s", resp.GetErrorCode(), resp.GetErrorMessage())
+	}
+
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	return enc.Encode(resp)
+}
+
+// printMessage prints the ID, key, properties and payload of m, or of
+// each message of its batch.
+func printMessage(m msg.Message) {
+	id := formatMessageID(m.Msg.GetMessageId())
+	if m.Meta.NumMessagesInBatch == nil {
+		printSingle(id, m.Meta.GetPartitionKey(), m.Meta.GetProperties(), m.Payload)
+		return
+	}
+
+	batch, err := msg.DecodeBatchMessage(&m)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error decoding batch %s: %v\n", id, err)
+		return
+	}
+	for i, sm := range batch {
+		printSingle(fmt.Sprintf("%s:%d", id, i), sm.SingleMeta.GetPartitionKey(), sm.SingleMeta.GetProperties(), sm.SinglePayload)
+	}
+}
+
+func printSingle(id, key string, props []*api.KeyValue, payload []byte) {
+	var b strings.Builder
+	b.WriteString(id)
+	if key != "" {
+		fmt.Fprintf(&b, " key=%q", key)
+	}
+	for _, kv := range props {
+		fmt.Fprintf(&b, " %s=%q", kv.GetKey(), kv.GetValue())
+	}
+	fmt.Printf("%s\n%s\n", b.String(), payload)
+}
+
+// formatMessageID formats id as ledger:entry.
+func formatMessageID(id *api.MessageIdData) string {
+	return fmt.Sprintf("%d:%d", id.GetLedgerId(), id.GetEntryId())
+}
+
+// properties is a flag.Value of repeated key=value flags.
+type properties map[string]string
+
+func (p properties) String() string {
+	keys := make([]string, 0, len(p))
+	for k := range p {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for i, k := range keys {
+		keys[i] = k + "=" + p[k]
+	}
+	return strings.Join(keys, ",")
+}
+
+func (p properties) Set(s string) error {
+	i := strings.IndexByte(s, '=')
+	if i < 0 {
+		return fmt.Errorf("expected key=value, got %q", s)
+	}
+	p[s[:i]] = s[i+1:]
+	return nil
+}
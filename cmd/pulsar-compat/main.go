@@ -0,0 +1,223 @@
+// Copyright 2018 Comcast Cable Communications Management, LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// This program runs the compatibility matrix of the client: it starts
+// each version of Pulsar in a Docker container, checks which features
+// of the client work or degrade gracefully against it, and runs the
+// integration suite against it.
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"os/signal"
+	"strings"
+	"syscall"
+	"text/tabwriter"
+	"time"
+
+	"github.com/pepper-iot/pulsar-client-go/pkg/pulsartest"
+)
+
+var args = struct {
+	versions       string
+	packages       string
+	run            string
+	featureTimeout time.Duration
+	suite          bool
+	out            string
+}{
+	versions:       strings.Join(pulsartest.DefaultVersions, ","),
+	packages:       "./core/conn,./core/manage",
+	run:            "_Int_",
+	featureTimeout: 30 * time.Second,
+	suite:          true,
+}
+
+func main() {
+	flag.StringVar(&args.versions, "versions", args.versions, "comma separated versions of Pulsar")
+	flag.StringVar(&args.packages, "packages", args.packages, "comma separated packages of the integration suite")
+	flag.StringVar(&args.run, "run", args.run, "regexp of the tests of the integration suite")
+	flag.DurationVar(&args.featureTimeout, "feature-timeout", args.featureTimeout, "timeout of each feature check")
+	flag.BoolVar(&args.suite, "suite", args.suite, "if false, only check features, without running the integration suite")
+	flag.StringVar(&args.out, "out", args.out, "(optional) file to write the results to, as JSON")
+	flag.Parse()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	sigs := make(chan os.Signal, 1)
+	signal.Notify(sigs, syscall.SIGINT, syscall.SIGTERM)
+	go func() {
+		<-sigs
+		cancel()
+	}()
+
+	cfg := pulsartest.MatrixConfig{
+		Versions:       strings.Split(args.versions, ","),
+		FeatureTimeout: args.featureTimeout,
+	}
+	if args.suite {
+		cfg.Suite = goTest
+	}
+	results := pulsartest.RunMatrix(ctx, cfg)
+
+	if args.out != "" {
+		b, err := json.MarshalIndent(results, "", "  ")
+		if err == nil {
+			err = ioutil.WriteFile(args.out, append(b, '\n'), 0644)
+		}
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+	}
+
+	if !printMatrix(os.Stdout, results) {
+		os.Exit(1)
+	}
+}
+
+// printMatrix prints a table of the results, with a column per
+// version, and returns false if a version couldn't be checked, a
+// feature didn't degrade gracefully, or a test failed.
+func printMatrix(w io.Writer, results []pulsartest.VersionResult) bool {
+	ok := true
+	tw := tabwriter.NewWriter(w, 0, 8, 2, ' ', 0)
+
+	fmt.Fprint(tw, "feature")
+	for _, r := range results {
+		fmt.Fprintf(tw, "\t%s", r.Version)
+	}
+	fmt.Fprintln(tw)
+
+	var features []string
+	for _, r := range results {
+		for i, f := range r.Features {
+			if i >= len(features) {
+				features = append(features, f.Feature)
+			}
+		}
+	}
+	for i, feature := range features {
+		fmt.Fprint(tw, feature)
+		for _, r := range results {
+			outcome := "-"
+			if i < len(r.Features) {
+				outcome = string(r.Features[i].Outcome)
+				if o := r.Features[i].Outcome; o == pulsartest.Hung || o == pulsartest.Panicked {
+					ok = false
+				}
+			}
+			fmt.Fprintf(tw, "\t%s", outcome)
+		}
+		fmt.Fprintln(tw)
+	}
+
+	if args.suite {
+		fmt.Fprint(tw, "integration suite")
+		for _, r := range results {
+			var passed, run int
+			for _, t := range r.Tests {
+				if t.Skipped {
+					continue
+				}
+				run++
+				if t.Passed {
+					passed++
+				}
+			}
+			if passed < run {
+				ok = false
+			}
+			fmt.Fprintf(tw, "\t%d/%d", passed, run)
+		}
+		fmt.Fprintln(tw)
+	}
+	_ = tw.Flush()
+
+	// details of what went wrong
+	for _, r := range results {
+		if r.Err != "" {
+			ok = false
+			fmt.Fprintf(w, "%s: %s\n", r.Version, r.Err)
+		}
+		for _, f := range r.Features {
+			if f.Err != "" {
+				fmt.Fprintf(w, "%s: %s %s: %s\n", r.Version, f.Feature, f.Outcome, f.Err)
+			}
+		}
+		for _, t := range r.Tests {
+			if !t.Passed && !t.Skipped {
+				fmt.Fprintf(w, "%s: %s %s failed\n", r.Version, t.Package, t.Test)
+			}
+		}
+	}
+	return ok
+}
+
+// testEvent is an event printed by "go test -json".
+type testEvent struct {
+	Action  string
+	Package string
+	Test    string
+	Elapsed float64 // seconds
+}
+
+// goTest runs the integration suite against b with "go test",
+// and returns the results of its tests.
+func goTest(ctx context.Context, b *pulsartest.Broker) ([]pulsartest.TestResult, error) {
+	cmdArgs := append([]string{"test", "-json", "-count=1", "-run", args.run}, strings.Split(args.packages, ",")...)
+	cmdArgs = append(cmdArgs, "-args", "-pulsar-test", b.Addr)
+	cmd := exec.CommandContext(ctx, "go", cmdArgs...)
+	cmd.Stderr = os.Stderr
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+	if err = cmd.Start(); err != nil {
+		return nil, err
+	}
+
+	var results []pulsartest.TestResult
+	s := bufio.NewScanner(stdout)
+	for s.Scan() {
+		var e testEvent
+		if err := json.Unmarshal(s.Bytes(), &e); err != nil || e.Test == "" {
+			continue
+		}
+		if e.Action != "pass" && e.Action != "fail" && e.Action != "skip" {
+			continue
+		}
+		results = append(results, pulsartest.TestResult{
+			Package: e.Package,
+			Test:    e.Test,
+			Passed:  e.Action == "pass",
+			Skipped: e.Action == "skip",
+			Elapsed: time.Duration(e.Elapsed * float64(time.Second)),
+		})
+	}
+
+	// go test exits with an error if a test failed, which results report
+	if err := cmd.Wait(); err != nil && len(results) == 0 {
+		return nil, err
+	}
+	return results, s.Err()
+}
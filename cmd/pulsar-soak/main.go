@@ -0,0 +1,303 @@
+// Copyright 2018 Comcast Cable Communications Management, LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// This program soaks the `manage` package: it cycles broker restarts
+// and topic churn for a long time, and after each cycle asserts that
+// goroutines, Dispatcher registrations and the heap don't grow.
+//
+// It's main goal is to catch leaks before they reach production.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"os/exec"
+	"os/signal"
+	"runtime"
+	"runtime/pprof"
+	"syscall"
+	"time"
+
+	"github.com/pepper-iot/pulsar-client-go/core/manage"
+	"github.com/pepper-iot/pulsar-client-go/core/srv"
+)
+
+var args = struct {
+	pulsar         string
+	restartCmd     string
+	topic          string
+	topics         int
+	msgs           int
+	cycles         int
+	duration       time.Duration
+	restartEvery   int
+	cycleTimeout   time.Duration
+	settle         time.Duration
+	warmup         int
+	maxGoroutines  int
+	maxHeapGrowthM uint64
+}{
+	topic:          "persistent://sample/standalone/ns1/soak",
+	topics:         4,
+	msgs:           10,
+	restartEvery:   1,
+	cycleTimeout:   30 * time.Second,
+	settle:         10 * time.Second,
+	warmup:         3,
+	maxGoroutines:  10,
+	maxHeapGrowthM: 32,
+}
+
+// sample is a measure of the resources held by the process.
+type sample struct {
+	goroutines int
+	heap       uint64 // bytes of live heap objects
+	pending    int    // outstanding Dispatcher registrations
+	entities   int    // live ManagedProducers and ManagedConsumers
+}
+
+func measure(cp *manage.ClientPool) sample {
+	runtime.GC()
+	var ms runtime.MemStats
+	runtime.ReadMemStats(&ms)
+
+	s := sample{
+		goroutines: runtime.NumGoroutine(),
+		heap:       ms.HeapAlloc,
+		entities:   len(cp.Inspect()),
+	}
+	for _, c := range cp.Stats().Connections {
+		s.pending += c.Dispatcher.PendingGlobal + c.Dispatcher.PendingReqIDs + c.Dispatcher.PendingProdSeqIDs
+	}
+	return s
+}
+
+// check returns an error if s leaked resources compared to baseline.
+func (s sample) check(baseline sample) error {
+	switch {
+	case s.entities > 0:
+		return fmt.Errorf("%d producers or consumers still live after being closed", s.entities)
+	case s.pending > 0:
+		return fmt.Errorf("%d Dispatcher registrations still pending", s.pending)
+	case s.goroutines > baseline.goroutines+args.maxGoroutines:
+		return fmt.Errorf("%d goroutines; baseline was %d", s.goroutines, baseline.goroutines)
+	case s.heap > baseline.heap+args.maxHeapGrowthM<<20:
+		return fmt.Errorf("%.1f MB of heap; baseline was %.1f MB", float64(s.heap)/1e6, float64(baseline.heap)/1e6)
+	}
+	return nil
+}
+
+// broker restarts the broker. Against the embedded broker,
+// restarts drop every connection.
+type broker struct {
+	addr     string
+	embedded *srv.Server
+}
+
+func (b broker) restart(ctx context.Context) error {
+	if b.embedded != nil {
+		return b.embedded.CloseAll()
+	}
+	if args.restartCmd == "" {
+		return nil
+	}
+	cmd := exec.CommandContext(ctx, "sh", "-c", args.restartCmd)
+	cmd.Stdout, cmd.Stderr = os.Stderr, os.Stderr
+	return cmd.Run()
+}
+
+func main() {
+	flag.StringVar(&args.pulsar, "pulsar", args.pulsar, "pulsar address. If empty, an embedded mock broker is used")
+	flag.StringVar(&args.restartCmd, "restart-cmd", args.restartCmd, "shell command restarting the broker at -pulsar, e.g. \"docker restart pulsar\"")
+	flag.StringVar(&args.topic, "topic", args.topic, "prefix of the topics created and deleted each cycle")
+	flag.IntVar(&args.topics, "topics", args.topics, "number of topics churned each cycle")
+	flag.IntVar(&args.msgs, "msgs", args.msgs, "number of messages sent to each topic each cycle")
+	flag.IntVar(&args.cycles, "cycles", args.cycles, "(optional) number of cycles to run before exiting")
+	flag.DurationVar(&args.duration, "duration", args.duration, "(optional) duration to run for before exiting")
+	flag.IntVar(&args.restartEvery, "restart-every", args.restartEvery, "number of cycles between broker restarts. Never if 0")
+	flag.DurationVar(&args.cycleTimeout, "cycle-timeout", args.cycleTimeout, "timeout of a cycle's sends and receives")
+	flag.DurationVar(&args.settle, "settle", args.settle, "time given to resources to be released after a cycle")
+	flag.IntVar(&args.warmup, "warmup", args.warmup, "number of cycles run before measuring the baseline")
+	flag.IntVar(&args.maxGoroutines, "max-goroutines", args.maxGoroutines, "number of goroutines allowed above the baseline")
+	flag.Uint64Var(&args.maxHeapGrowthM, "max-heap-growth", args.maxHeapGrowthM, "MB of heap allowed above the baseline")
+	flag.Parse()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	if args.duration > 0 {
+		ctx, cancel = context.WithTimeout(context.Background(), args.duration)
+	}
+	defer cancel()
+
+	sigs := make(chan os.Signal, 1)
+	signal.Notify(sigs, syscall.SIGINT, syscall.SIGTERM)
+	go func() {
+		<-sigs
+		cancel()
+	}()
+
+	b := broker{addr: args.pulsar}
+	if b.addr == "" {
+		s, err := srv.NewServer(context.Background())
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		// the embedded broker panics if its received frames aren't drained
+		go func() {
+			for range s.Received {
+			}
+		}()
+		b.addr, b.embedded = s.Addr, s
+	}
+
+	asyncErrs := make(chan error, 8)
+	go func() {
+		for range asyncErrs {
+			// errors are expected while the broker restarts
+		}
+	}()
+
+	cp := manage.NewClientPool()
+	cfg := manage.ClientConfig{
+		Addr: b.addr,
+		Errs: asyncErrs,
+	}
+
+	var baseline sample
+	for cycle := 1; args.cycles == 0 || cycle <= args.cycles; cycle++ {
+		if ctx.Err() != nil {
+			break
+		}
+
+		if args.restartEvery > 0 && cycle%args.restartEvery == 0 {
+			if err := b.restart(ctx); err != nil && ctx.Err() == nil {
+				fmt.Fprintln(os.Stderr, "restart:", err)
+				os.Exit(1)
+			}
+		}
+
+		start := time.Now()
+		sent, received, errs := churn(ctx, cp, cfg, b.embedded == nil, cycle)
+		if ctx.Err() != nil {
+			break
+		}
+
+		s, err := settle(ctx, cp, baseline, cycle > args.warmup)
+		fmt.Printf("cycle %d: %d sent, %d received, %d errors in %v; %d goroutines, %.1f MB of heap\n",
+			cycle, sent, received, errs, time.Since(start).Round(time.Millisecond), s.goroutines, float64(s.heap)/1e6)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "leak after cycle %d: %v\n", cycle, err)
+			_ = pprof.Lookup("goroutine").WriteTo(os.Stderr, 1)
+			os.Exit(1)
+		}
+		if cycle == args.warmup {
+			baseline = s
+		}
+	}
+}
+
+// settle measures the resources held by the process until they don't
+// leak compared to baseline, or args.settle elapsed. If check is false,
+// the first measure is returned.
+func settle(ctx context.Context, cp *manage.ClientPool, baseline sample, check bool) (sample, error) {
+	deadline := time.Now().Add(args.settle)
+	for {
+		s := measure(cp)
+		if !check {
+			return s, nil
+		}
+		err := s.check(baseline)
+		if err == nil || time.Now().After(deadline) {
+			return s, err
+		}
+
+		select {
+		case <-time.After(100 * time.Millisecond):
+		case <-ctx.Done():
+			return s, nil
+		}
+	}
+}
+
+// churn creates a producer and a consumer on args.topics new topics,
+// sends args.msgs messages to each, then closes them. Messages are only
+// received if receive is true, since the embedded broker doesn't
+// deliver them.
+func churn(ctx context.Context, cp *manage.ClientPool, cfg manage.ClientConfig, receive bool, cycle int) (sent, received, errs int) {
+	ctx, cancel := context.WithTimeout(ctx, args.cycleTimeout)
+	defer cancel()
+
+	type result struct{ sent, received, errs int }
+	results := make(chan result, args.topics)
+	for i := 0; i < args.topics; i++ {
+		topic := fmt.Sprintf("%s-%d-%d", args.topic, cycle, i)
+		go func() {
+			var r result
+			defer func() { results <- r }()
+
+			mc := manage.NewManagedConsumer(ctx, cp, manage.ConsumerConfig{
+				ClientConfig: cfg,
+				Topic:        topic,
+				Name:         "soak",
+				SubMode:      manage.SubscriptionModeExclusive,
+				QueueSize:    args.msgs,
+			})
+			mp := manage.NewManagedProducer(ctx, cp, manage.ProducerConfig{
+				ClientConfig: cfg,
+				Topic:        topic,
+			})
+
+			for j := 0; j < args.msgs; j++ {
+				if _, err := mp.Send(ctx, []byte(fmt.Sprintf("soak-%d-%d", cycle, j))); err != nil {
+					r.errs++
+					continue
+				}
+				r.sent++
+			}
+			for receive && r.received < r.sent {
+				m, err := mc.Receive(ctx)
+				if err != nil {
+					r.errs++
+					break
+				}
+				r.received++
+				if err := mc.Ack(ctx, m); err != nil {
+					r.errs++
+				}
+			}
+
+			// closing must succeed even once the cycle timed out
+			closeCtx, cancel := context.WithTimeout(context.Background(), args.cycleTimeout)
+			defer cancel()
+			if receive {
+				if err := mc.Unsubscribe(closeCtx); err != nil {
+					r.errs++
+				}
+			}
+			for _, close := range []func(context.Context) error{mp.Close, mc.Close} {
+				if err := close(closeCtx); err != nil && !errors.Is(err, context.Canceled) {
+					r.errs++
+				}
+			}
+		}()
+	}
+
+	for i := 0; i < args.topics; i++ {
+		r := <-results
+		sent, received, errs = sent+r.sent, received+r.received, errs+r.errs
+	}
+	return sent, received, errs
+}
@@ -0,0 +1,138 @@
+// Copyright 2018 Comcast Cable Communications Management, LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// This program proxies Pulsar connections to a broker while recording
+// their frames to a file, and replays the broker's side of recordings
+// to clients, using the `wireproxy` package.
+//
+// It's main goal is to reproduce wire level issues deterministically.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"net"
+	"os"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/pepper-iot/pulsar-client-go/core/wireproxy"
+)
+
+var args = struct {
+	listen string
+	pulsar string
+	record string
+	replay string
+	dump   string
+	quiet  bool
+}{
+	listen: "localhost:6651",
+	pulsar: "localhost:6650",
+}
+
+func main() {
+	flag.StringVar(&args.listen, "listen", args.listen, "address to accept client connections on")
+	flag.StringVar(&args.pulsar, "pulsar", args.pulsar, "pulsar address to proxy connections to")
+	flag.StringVar(&args.record, "record", args.record, "(optional) path of the file to record frames to")
+	flag.StringVar(&args.replay, "replay", args.replay, "(optional) path of a recording to replay to clients, instead of proxying")
+	flag.StringVar(&args.dump, "dump", args.dump, "(optional) path of a recording to print, instead of proxying")
+	flag.BoolVar(&args.quiet, "quiet", args.quiet, "if true, do not print frames as they are proxied")
+	flag.Parse()
+
+	if err := run(); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func run() error {
+	if args.dump != "" {
+		return dump(args.dump)
+	}
+
+	l, err := net.Listen("tcp", args.listen)
+	if err != nil {
+		return err
+	}
+	onError := func(connID uint32, err error) {
+		fmt.Fprintf(os.Stderr, "connection %d: %v\n", connID, err)
+	}
+
+	if args.replay != "" {
+		f, err := os.Open(args.replay)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		rp, err := wireproxy.NewReplayer(f)
+		if err != nil {
+			return err
+		}
+		rp.OnError = onError
+		fmt.Fprintf(os.Stderr, "replaying %s on %s\n", args.replay, l.Addr())
+		return rp.Serve(l)
+	}
+
+	p := wireproxy.Proxy{
+		BrokerAddr: args.pulsar,
+		OnError:    onError,
+	}
+	if args.record != "" {
+		f, err := os.Create(args.record)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		p.Recorder = wireproxy.NewRecorder(f)
+	}
+	if !args.quiet {
+		p.OnRecord = printRecord
+	}
+	fmt.Fprintf(os.Stderr, "proxying %s to %s\n", l.Addr(), args.pulsar)
+	return p.Serve(l)
+}
+
+// dump prints the records of the recording at path.
+func dump(path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	r, err := wireproxy.NewReader(f)
+	if err != nil {
+		return err
+	}
+	for {
+		rec, err := r.Next()
+		if err != nil {
+			if err == io.EOF {
+				return nil
+			}
+			return err
+		}
+		printRecord(rec)
+	}
+}
+
+// printRecord prints a summary of the record.
+func printRecord(rec wireproxy.Record) {
+	f, err := rec.Frame()
+	if err != nil {
+		fmt.Printf("%d %s %s %d bytes: %v\n", rec.ConnID, rec.Dir, rec.Time.Format("15:04:05.000000"), len(rec.Raw), err)
+		return
+	}
+	fmt.Printf("%d %s %s %s %s\n", rec.ConnID, rec.Dir, rec.Time.Format("15:04:05.000000"), f.BaseCmd.GetType(), proto.CompactTextString(f.BaseCmd))
+}
@@ -0,0 +1,271 @@
+// Copyright 2018 Comcast Cable Communications Management, LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package cloudevents maps Pulsar messages to and from CloudEvents
+// (https://cloudevents.io), version 1.0.
+//
+// In binary mode, the event attributes are carried in message properties
+// prefixed with "ce_", and the event data is the message payload. In
+// structured mode, the whole event is JSON-encoded in the payload, and
+// the "content-type" property is "application/cloudevents+json".
+package cloudevents
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/pepper-iot/pulsar-client-go/core/msg"
+)
+
+// SpecVersion is the version of the CloudEvents specification implemented.
+const SpecVersion = "1.0"
+
+// Message properties used by both modes.
+const (
+	PropContentType = "content-type"
+	PropPrefix      = "ce_"
+
+	// StructuredContentType is the content type of structured mode messages.
+	StructuredContentType = "application/cloudevents+json"
+)
+
+// ErrNotCloudEvent is returned by FromMessage when
+// a message doesn't carry a CloudEvent.
+var ErrNotCloudEvent = errors.New("message is not a cloud event")
+
+// Event is a CloudEvent.
+type Event struct {
+	// Required attributes
+	ID          string
+	Source      string
+	SpecVersion string // defaults to SpecVersion
+	Type        string
+
+	// Optional attributes
+	DataContentType string
+	DataSchema      string
+	Subject         string
+	Time            time.Time
+
+	Extensions map[string]string // extension attributes, by name
+	Data       []byte
+}
+
+// Validate returns an error if a required attribute is missing.
+func (e Event) Validate() error {
+	switch {
+	case e.ID == "":
+		return errors.New("cloud event id is required")
+	case e.Source == "":
+		return errors.New("cloud event source is required")
+	case e.Type == "":
+		return errors.New("cloud event type is required")
+	case e.specVersion() != SpecVersion:
+		return fmt.Errorf("unsupported cloud event specversion %q", e.SpecVersion)
+	}
+	return nil
+}
+
+func (e Event) specVersion() string {
+	if e.SpecVersion == "" {
+		return SpecVersion
+	}
+	return e.SpecVersion
+}
+
+// ToBinary returns the message properties and payload
+// of e in binary mode.
+func ToBinary(e Event) (map[string]string, []byte, error) {
+	if err := e.Validate(); err != nil {
+		return nil, nil, err
+	}
+
+	props := map[string]string{
+		PropPrefix + "id":          e.ID,
+		PropPrefix + "source":      e.Source,
+		PropPrefix + "specversion": e.specVersion(),
+		PropPrefix + "type":        e.Type,
+	}
+	if e.DataContentType != "" {
+		props[PropContentType] = e.DataContentType
+	}
+	if e.DataSchema != "" {
+		props[PropPrefix+"dataschema"] = e.DataSchema
+	}
+	if e.Subject != "" {
+		props[PropPrefix+"subject"] = e.Subject
+	}
+	if !e.Time.IsZero() {
+		props[PropPrefix+"time"] = e.Time.Format(time.RFC3339Nano)
+	}
+	for k, v := range e.Extensions {
+		props[PropPrefix+k] = v
+	}
+	return props, e.Data, nil
+}
+
+// ToStructured returns the message properties and payload
+// of e in structured mode.
+func ToStructured(e Event) (map[string]string, []byte, error) {
+	if err := e.Validate(); err != nil {
+		return nil, nil, err
+	}
+
+	doc := make(map[string]interface{}, 8+len(e.Extensions))
+	for k, v := range e.Extensions {
+		doc[k] = v
+	}
+	doc["id"] = e.ID
+	doc["source"] = e.Source
+	doc["specversion"] = e.specVersion()
+	doc["type"] = e.Type
+	if e.DataContentType != "" {
+		doc["datacontenttype"] = e.DataContentType
+	}
+	if e.DataSchema != "" {
+		doc["dataschema"] = e.DataSchema
+	}
+	if e.Subject != "" {
+		doc["subject"] = e.Subject
+	}
+	if !e.Time.IsZero() {
+		doc["time"] = e.Time.Format(time.RFC3339Nano)
+	}
+	if e.Data != nil {
+		if isJSON(e.DataContentType) && json.Valid(e.Data) {
+			doc["data"] = json.RawMessage(e.Data)
+		} else {
+			// encoding/json encodes []byte as base64
+			doc["data_base64"] = e.Data
+		}
+	}
+
+	payload, err := json.Marshal(doc)
+	if err != nil {
+		return nil, nil, err
+	}
+	props := map[string]string{
+		PropContentType: StructuredContentType,
+	}
+	return props, payload, nil
+}
+
+// FromMessage returns the CloudEvent carried by m, in either mode.
+// ErrNotCloudEvent is returned if m carries none.
+func FromMessage(m msg.Message) (Event, error) {
+	props := make(map[string]string, len(m.Meta.GetProperties()))
+	for _, kv := range m.Meta.GetProperties() {
+		props[kv.GetKey()] = kv.GetValue()
+	}
+
+	if strings.HasPrefix(props[PropContentType], StructuredContentType) {
+		return fromStructured(m.Payload)
+	}
+	if _, ok := props[PropPrefix+"specversion"]; ok {
+		return fromBinary(props, m.Payload)
+	}
+	return Event{}, ErrNotCloudEvent
+}
+
+// fromBinary returns the CloudEvent of a binary mode message.
+func fromBinary(props map[string]string, payload []byte) (Event, error) {
+	e := Event{
+		DataContentType: props[PropContentType],
+		Data:            payload,
+	}
+	for k, v := range props {
+		if !strings.HasPrefix(k, PropPrefix) {
+			continue
+		}
+		if err := e.set(strings.TrimPrefix(k, PropPrefix), v); err != nil {
+			return Event{}, err
+		}
+	}
+	return e, e.Validate()
+}
+
+// fromStructured returns the CloudEvent of a structured mode message.
+func fromStructured(payload []byte) (Event, error) {
+	var doc map[string]json.RawMessage
+	if err := json.Unmarshal(payload, &doc); err != nil {
+		return Event{}, err
+	}
+
+	var e Event
+	for k, raw := range doc {
+		switch k {
+		case "data":
+			e.Data = []byte(raw)
+		case "data_base64":
+			if err := json.Unmarshal(raw, &e.Data); err != nil {
+				return Event{}, fmt.Errorf("cloud event data_base64: %w", err)
+			}
+		default:
+			var v string
+			if err := json.Unmarshal(raw, &v); err != nil {
+				// non-string extensions keep their JSON encoding
+				v = string(raw)
+			}
+			if err := e.set(k, v); err != nil {
+				return Event{}, err
+			}
+		}
+	}
+	return e, e.Validate()
+}
+
+// set sets the attribute with the given name.
+func (e *Event) set(name, v string) error {
+	switch name {
+	case "id":
+		e.ID = v
+	case "source":
+		e.Source = v
+	case "specversion":
+		e.SpecVersion = v
+	case "type":
+		e.Type = v
+	case "datacontenttype":
+		e.DataContentType = v
+	case "dataschema":
+		e.DataSchema = v
+	case "subject":
+		e.Subject = v
+	case "time":
+		t, err := time.Parse(time.RFC3339Nano, v)
+		if err != nil {
+			return fmt.Errorf("cloud event time: %w", err)
+		}
+		e.Time = t
+	default:
+		if e.Extensions == nil {
+			e.Extensions = make(map[string]string)
+		}
+		e.Extensions[name] = v
+	}
+	return nil
+}
+
+// isJSON returns true if the content type denotes JSON data.
+// Data without content type is assumed to be JSON, as per the spec.
+func isJSON(contentType string) bool {
+	if contentType == "" {
+		return true
+	}
+	mediaType := strings.TrimSpace(strings.SplitN(contentType, ";", 2)[0])
+	return mediaType == "application/json" || mediaType == "text/json" ||
+		strings.HasSuffix(mediaType, "+json")
+}
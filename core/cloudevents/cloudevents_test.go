@@ -0,0 +1,128 @@
+// Copyright 2018 Comcast Cable Communications Management, LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cloudevents
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/pepper-iot/pulsar-client-go/core/msg"
+	"github.com/pepper-iot/pulsar-client-go/pkg/api"
+)
+
+// message returns a received message with the given properties and payload.
+func message(props map[string]string, payload []byte) msg.Message {
+	keys := make([]string, 0, len(props))
+	for k := range props {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	meta := new(api.MessageMetadata)
+	for _, k := range keys {
+		meta.Properties = append(meta.Properties, &api.KeyValue{
+			Key:   proto.String(k),
+			Value: proto.String(props[k]),
+		})
+	}
+	return msg.Message{Meta: meta, Payload: payload}
+}
+
+func TestRoundTrip(t *testing.T) {
+	events := map[string]Event{
+		"json data": {
+			ID:              "1",
+			Source:          "/devices/42",
+			SpecVersion:     SpecVersion,
+			Type:            "com.example.reading",
+			DataContentType: "application/json",
+			Subject:         "temperature",
+			Time:            time.Date(2018, 7, 1, 12, 0, 0, 0, time.UTC),
+			Extensions:      map[string]string{"traceparent": "00-abc-def-01"},
+			Data:            []byte(`{"value":21.5}`),
+		},
+		"binary data": {
+			ID:              "2",
+			Source:          "/devices/42",
+			SpecVersion:     SpecVersion,
+			Type:            "com.example.blob",
+			DataContentType: "application/octet-stream",
+			DataSchema:      "https://example.com/blob",
+			Data:            []byte{0, 1, 2},
+		},
+	}
+
+	modes := map[string]func(Event) (map[string]string, []byte, error){
+		"binary":     ToBinary,
+		"structured": ToStructured,
+	}
+
+	for name, e := range events {
+		for mode, to := range modes {
+			props, payload, err := to(e)
+			if err != nil {
+				t.Fatalf("%s %s: err = %v; expected nil", name, mode, err)
+			}
+			got, err := FromMessage(message(props, payload))
+			if err != nil {
+				t.Fatalf("%s %s: FromMessage() err = %v; expected nil", name, mode, err)
+			}
+			if !reflect.DeepEqual(got, e) {
+				t.Fatalf("%s %s: FromMessage() =\n%+v\nexpected\n%+v", name, mode, got, e)
+			}
+		}
+	}
+}
+
+func TestToStructured_Data(t *testing.T) {
+	e := Event{
+		ID:     "1",
+		Source: "/devices/42",
+		Type:   "com.example.reading",
+		Data:   []byte(`{"value":21.5}`),
+	}
+	_, payload, err := ToStructured(e)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := `{"data":{"value":21.5},"id":"1","source":"/devices/42","specversion":"1.0","type":"com.example.reading"}`
+	if got := string(payload); got != expected {
+		t.Fatalf("ToStructured() payload = %s; expected %s", got, expected)
+	}
+}
+
+func TestFromMessage_Errors(t *testing.T) {
+	if _, err := FromMessage(message(nil, []byte("hola"))); err != ErrNotCloudEvent {
+		t.Fatalf("FromMessage() err = %v; expected %v", err, ErrNotCloudEvent)
+	}
+
+	props := map[string]string{PropPrefix + "specversion": SpecVersion}
+	if _, err := FromMessage(message(props, nil)); err == nil {
+		t.Fatal("FromMessage() err = nil; expected error for missing attributes")
+	}
+
+	props = map[string]string{PropContentType: StructuredContentType}
+	if _, err := FromMessage(message(props, []byte("{"))); err == nil {
+		t.Fatal("FromMessage() err = nil; expected error for invalid payload")
+	}
+}
+
+func TestToBinary_Invalid(t *testing.T) {
+	if _, _, err := ToBinary(Event{ID: "1", Source: "s"}); err == nil {
+		t.Fatal("ToBinary() err = nil; expected error for missing type")
+	}
+}
@@ -0,0 +1,104 @@
+// Copyright 2018 Comcast Cable Communications Management, LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package compression compresses and decompresses message payloads
+// according to their MessageMetadata.Compression. Codecs for ZLIB and
+// SNAPPY are registered by default; others, such as LZ4 or ZSTD, may
+// be plugged in with Register.
+package compression
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/pepper-iot/pulsar-client-go/pkg/api"
+)
+
+// Codec compresses and decompresses payloads for one compression type.
+// Implementations must be safe for concurrent use.
+type Codec interface {
+	// Type is the compression type set in the metadata
+	// of the messages the codec compresses.
+	Type() api.CompressionType
+	// Compress returns the compressed form of src.
+	Compress(src []byte) ([]byte, error)
+	// Decompress returns the decompressed form of src, whose size
+	// is size, as set in MessageMetadata.UncompressedSize.
+	Decompress(src []byte, size int) ([]byte, error)
+}
+
+// ErrUnsupported is returned when no Codec is
+// registered for a compression type.
+var ErrUnsupported = errors.New("unsupported compression type")
+
+// ErrCorrupt is returned when decompressing an invalid payload,
+// or one whose decompressed size isn't as expected.
+var ErrCorrupt = errors.New("corrupt compressed payload")
+
+var (
+	mu     sync.RWMutex // protects codecs
+	codecs = map[api.CompressionType]Codec{
+		api.CompressionType_NONE:   None{},
+		api.CompressionType_ZLIB:   Zlib{},
+		api.CompressionType_SNAPPY: Snappy{},
+	}
+)
+
+// Register makes c the Codec of its compression type, replacing any
+// previously registered one, eg with a faster implementation. It is
+// typically called from an init function.
+func Register(c Codec) {
+	mu.Lock()
+	codecs[c.Type()] = c
+	mu.Unlock()
+}
+
+// Lookup returns the Codec registered for t, if any.
+func Lookup(t api.CompressionType) (Codec, bool) {
+	mu.RLock()
+	c, ok := codecs[t]
+	mu.RUnlock()
+	return c, ok
+}
+
+// Compress compresses src with the Codec registered for t.
+func Compress(t api.CompressionType, src []byte) ([]byte, error) {
+	c, ok := Lookup(t)
+	if !ok {
+		return nil, fmt.Errorf("%w: %v", ErrUnsupported, t)
+	}
+	return c.Compress(src)
+}
+
+// Decompress decompresses src, whose decompressed size is
+// size, with the Codec registered for t.
+func Decompress(t api.CompressionType, src []byte, size int) ([]byte, error) {
+	c, ok := Lookup(t)
+	if !ok {
+		return nil, fmt.Errorf("%w: %v", ErrUnsupported, t)
+	}
+	return c.Decompress(src, size)
+}
+
+// None is the Codec of uncompressed payloads, which it returns as is.
+type None struct{}
+
+// Type implements Codec.
+func (None) Type() api.CompressionType { return api.CompressionType_NONE }
+
+// Compress implements Codec.
+func (None) Compress(src []byte) ([]byte, error) { return src, nil }
+
+// Decompress implements Codec.
+func (None) Decompress(src []byte, size int) ([]byte, error) { return src, nil }
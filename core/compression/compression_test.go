@@ -0,0 +1,154 @@
+// Copyright 2018 Comcast Cable Communications Management, LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package compression
+
+import (
+	"bytes"
+	"errors"
+	"math/rand"
+	"testing"
+
+	"github.com/pepper-iot/pulsar-client-go/pkg/api"
+)
+
+func payloads() map[string][]byte {
+	random := make([]byte, 100*1024)
+	rand.New(rand.NewSource(1)).Read(random)
+
+	return map[string][]byte{
+		"empty":      {},
+		"short":      []byte("hola"),
+		"text":       []byte("hola mundo, hola mundo, hola mundo, hello world"),
+		"repetitive": bytes.Repeat([]byte("a"), 100*1024),
+		"random":     random,
+		"mixed":      append(bytes.Repeat([]byte("abcdefgh"), 10*1024), random...),
+	}
+}
+
+func TestCodecs(t *testing.T) {
+	for _, typ := range []api.CompressionType{api.CompressionType_NONE, api.CompressionType_ZLIB, api.CompressionType_SNAPPY} {
+		for name, payload := range payloads() {
+			compressed, err := Compress(typ, payload)
+			if err != nil {
+				t.Fatalf("%v: Compress(%s) err = %v; expected nil", typ, name, err)
+			}
+			got, err := Decompress(typ, compressed, len(payload))
+			if err != nil {
+				t.Fatalf("%v: Decompress(%s) err = %v; expected nil", typ, name, err)
+			}
+			if !bytes.Equal(got, payload) {
+				t.Fatalf("%v: Decompress(%s) = %d bytes; expected the %d bytes compressed", typ, name, len(got), len(payload))
+			}
+		}
+	}
+}
+
+func TestSnappy_Compress(t *testing.T) {
+	payload := bytes.Repeat([]byte("hola mundo "), 1000)
+	compressed, err := Snappy{}.Compress(payload)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, max := len(compressed), len(payload)/10; got > max {
+		t.Fatalf("Compress() = %d bytes; expected at most %d", got, max)
+	}
+}
+
+func TestSnappy_Decompress(t *testing.T) {
+	// length 12, literal "abcd", then an overlapping
+	// copy of 8 bytes at offset 4
+	src := []byte{12, 3 << 2, 'a', 'b', 'c', 'd', (8-4)<<2 | snappyCopy1, 4}
+	got, err := Snappy{}.Decompress(src, 12)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := "abcdabcdabcd"; string(got) != expected {
+		t.Fatalf("Decompress() = %q; expected %q", got, expected)
+	}
+
+	corrupt := map[string]struct {
+		src  []byte
+		size int
+	}{
+		"wrong size":      {src, 13},
+		"truncated":       {src[:len(src)-1], 12},
+		"offset too far":  {[]byte{12, 3 << 2, 'a', 'b', 'c', 'd', (8-4)<<2 | snappyCopy1, 5}, 12},
+		"literal too big": {[]byte{2, 3 << 2, 'a', 'b', 'c', 'd'}, 2},
+		"no header":       {nil, 0},
+	}
+	for name, c := range corrupt {
+		if _, err := (Snappy{}).Decompress(c.src, c.size); err != ErrCorrupt {
+			t.Fatalf("Decompress(%s) err = %v; expected %v", name, err, ErrCorrupt)
+		}
+	}
+}
+
+func TestZlib_Decompress(t *testing.T) {
+	compressed, err := Zlib{}.Compress([]byte("hola mundo"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := (Zlib{}).Decompress(compressed, 9); err != ErrCorrupt {
+		t.Fatalf("Decompress() of a shorter size err = %v; expected %v", err, ErrCorrupt)
+	}
+	if _, err := (Zlib{}).Decompress(compressed, 11); err != ErrCorrupt {
+		t.Fatalf("Decompress() of a longer size err = %v; expected %v", err, ErrCorrupt)
+	}
+	compressed[len(compressed)-1]++ // checksum
+	if _, err := (Zlib{}).Decompress(compressed, 10); err != ErrCorrupt {
+		t.Fatalf("Decompress() with a bad checksum err = %v; expected %v", err, ErrCorrupt)
+	}
+}
+
+// reverse is a toy codec reversing payloads.
+type reverse struct{}
+
+func (reverse) Type() api.CompressionType { return api.CompressionType_LZ4 }
+
+func (reverse) Compress(src []byte) ([]byte, error) {
+	dst := make([]byte, len(src))
+	for i, b := range src {
+		dst[len(src)-1-i] = b
+	}
+	return dst, nil
+}
+
+func (r reverse) Decompress(src []byte, size int) ([]byte, error) {
+	return r.Compress(src)
+}
+
+func TestRegister(t *testing.T) {
+	if _, err := Decompress(api.CompressionType_LZ4, []byte("odnum aloh"), 10); !errors.Is(err, ErrUnsupported) {
+		t.Fatalf("Decompress() err = %v; expected %v", err, ErrUnsupported)
+	}
+
+	Register(reverse{})
+	defer func() {
+		mu.Lock()
+		delete(codecs, api.CompressionType_LZ4)
+		mu.Unlock()
+	}()
+
+	if _, ok := Lookup(api.CompressionType_LZ4); !ok {
+		t.Fatal("Lookup() ok = false; expected true")
+	}
+	got, err := Decompress(api.CompressionType_LZ4, []byte("odnum aloh"), 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := "hola mundo"; string(got) != expected {
+		t.Fatalf("Decompress() = %q; expected %q", got, expected)
+	}
+}
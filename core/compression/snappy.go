@@ -0,0 +1,186 @@
+// Copyright 2018 Comcast Cable Communications Management, LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package compression
+
+import (
+	"encoding/binary"
+
+	"github.com/pepper-iot/pulsar-client-go/pkg/api"
+)
+
+// Snappy is the Codec of SNAPPY compressed payloads, which use the
+// Snappy block format, without the framing format:
+// https://github.com/google/snappy/blob/main/format_description.txt
+type Snappy struct{}
+
+// Type implements Codec.
+func (Snappy) Type() api.CompressionType { return api.CompressionType_SNAPPY }
+
+// elements of the block format are
+// identified by the low 2 bits of their tag
+const (
+	snappyLiteral = 0x00
+	snappyCopy1   = 0x01 // copy with a 1 byte offset
+	snappyCopy2   = 0x02 // copy with a 2 byte offset
+	snappyCopy4   = 0x03 // copy with a 4 byte offset
+)
+
+const (
+	snappyHashBits  = 14
+	snappyMaxOffset = 1<<16 - 1 // the encoder only emits copies with up to 2 byte offsets
+)
+
+// Compress implements Codec. Matches are found with a hash table of
+// the latest position of each 4 byte sequence, which is fast and
+// compresses about as well as the reference implementation.
+func (Snappy) Compress(src []byte) ([]byte, error) {
+	dst := make([]byte, binary.MaxVarintLen64, binary.MaxVarintLen64+len(src)+len(src)/6+8)
+	dst = dst[:binary.PutUvarint(dst, uint64(len(src)))]
+
+	table := make([]int32, 1<<snappyHashBits) // latest position+1 of each hash
+	lit := 0                                  // start of the pending literal
+	for i := 0; i+4 <= len(src); {
+		v := binary.LittleEndian.Uint32(src[i:])
+		h := (v * 0x1e35a7bd) >> (32 - snappyHashBits)
+		prev := int(table[h]) - 1
+		table[h] = int32(i + 1)
+		if prev < 0 || i-prev > snappyMaxOffset || binary.LittleEndian.Uint32(src[prev:]) != v {
+			i++
+			continue
+		}
+
+		n := 4
+		for i+n < len(src) && src[prev+n] == src[i+n] {
+			n++
+		}
+		dst = appendSnappyLiteral(dst, src[lit:i])
+		dst = appendSnappyCopy(dst, i-prev, n)
+		i += n
+		lit = i
+	}
+	return appendSnappyLiteral(dst, src[lit:]), nil
+}
+
+func appendSnappyLiteral(dst, lit []byte) []byte {
+	if len(lit) == 0 {
+		return dst
+	}
+	// lengths under 61 fit in the tag, longer
+	// ones follow it in 1 to 4 bytes
+	n := len(lit) - 1
+	switch {
+	case n < 60:
+		dst = append(dst, byte(n)<<2|snappyLiteral)
+	case n < 1<<8:
+		dst = append(dst, 60<<2|snappyLiteral, byte(n))
+	case n < 1<<16:
+		dst = append(dst, 61<<2|snappyLiteral, byte(n), byte(n>>8))
+	case n < 1<<24:
+		dst = append(dst, 62<<2|snappyLiteral, byte(n), byte(n>>8), byte(n>>16))
+	default:
+		dst = append(dst, 63<<2|snappyLiteral, byte(n), byte(n>>8), byte(n>>16), byte(n>>24))
+	}
+	return append(dst, lit...)
+}
+
+func appendSnappyCopy(dst []byte, offset, length int) []byte {
+	for length > 0 {
+		n := length
+		if n > 64 {
+			n = 64
+		}
+		if n >= 4 && n <= 11 && offset < 1<<11 {
+			dst = append(dst, byte(offset>>8)<<5|byte(n-4)<<2|snappyCopy1, byte(offset))
+		} else {
+			dst = append(dst, byte(n-1)<<2|snappyCopy2, byte(offset), byte(offset>>8))
+		}
+		length -= n
+	}
+	return dst
+}
+
+// Decompress implements Codec.
+func (Snappy) Decompress(src []byte, size int) ([]byte, error) {
+	n, k := binary.Uvarint(src)
+	if k <= 0 || size < 0 || n != uint64(size) {
+		return nil, ErrCorrupt
+	}
+	src = src[k:]
+
+	dst := make([]byte, 0, size)
+	for len(src) > 0 {
+		tag := src[0]
+		var length, offset int
+		switch tag & 0x03 {
+		case snappyLiteral:
+			length = int(tag >> 2)
+			src = src[1:]
+			if length >= 60 {
+				k := length - 59 // bytes of the length
+				if len(src) < k {
+					return nil, ErrCorrupt
+				}
+				length = 0
+				for j := k - 1; j >= 0; j-- {
+					length = length<<8 | int(src[j])
+				}
+				src = src[k:]
+			}
+			length++
+			if length <= 0 || length > len(src) || length > size-len(dst) {
+				return nil, ErrCorrupt
+			}
+			dst = append(dst, src[:length]...)
+			src = src[length:]
+			continue
+
+		case snappyCopy1:
+			if len(src) < 2 {
+				return nil, ErrCorrupt
+			}
+			length = 4 + int(tag>>2&0x07)
+			offset = int(tag&0xe0)<<3 | int(src[1])
+			src = src[2:]
+
+		case snappyCopy2:
+			if len(src) < 3 {
+				return nil, ErrCorrupt
+			}
+			length = 1 + int(tag>>2)
+			offset = int(binary.LittleEndian.Uint16(src[1:]))
+			src = src[3:]
+
+		case snappyCopy4:
+			if len(src) < 5 {
+				return nil, ErrCorrupt
+			}
+			length = 1 + int(tag>>2)
+			offset = int(binary.LittleEndian.Uint32(src[1:]))
+			src = src[5:]
+		}
+
+		if offset <= 0 || offset > len(dst) || length > size-len(dst) {
+			return nil, ErrCorrupt
+		}
+		// copies may overlap the bytes they produce
+		for j := 0; j < length; j++ {
+			dst = append(dst, dst[len(dst)-offset])
+		}
+	}
+
+	if len(dst) != size {
+		return nil, ErrCorrupt
+	}
+	return dst, nil
+}
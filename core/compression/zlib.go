@@ -0,0 +1,63 @@
+// Copyright 2018 Comcast Cable Communications Management, LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package compression
+
+import (
+	"bytes"
+	"compress/zlib"
+	"io"
+
+	"github.com/pepper-iot/pulsar-client-go/pkg/api"
+)
+
+// Zlib is the Codec of ZLIB compressed payloads.
+type Zlib struct{}
+
+// Type implements Codec.
+func (Zlib) Type() api.CompressionType { return api.CompressionType_ZLIB }
+
+// Compress implements Codec.
+func (Zlib) Compress(src []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	w := zlib.NewWriter(&buf)
+	if _, err := w.Write(src); err != nil {
+		return nil, err
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// Decompress implements Codec.
+func (Zlib) Decompress(src []byte, size int) ([]byte, error) {
+	if size < 0 {
+		return nil, ErrCorrupt
+	}
+	r, err := zlib.NewReader(bytes.NewReader(src))
+	if err != nil {
+		return nil, ErrCorrupt
+	}
+	dst := make([]byte, size)
+	if _, err = io.ReadFull(r, dst); err != nil {
+		return nil, ErrCorrupt
+	}
+	// reading past the end verifies the checksum,
+	// and that there is nothing left
+	var extra [1]byte
+	if _, err = io.ReadFull(r, extra[:]); err != io.EOF {
+		return nil, ErrCorrupt
+	}
+	return dst, nil
+}
@@ -14,6 +14,7 @@
 package conn
 
 import (
+	"bufio"
 	"bytes"
 	"crypto/tls"
 	"io"
@@ -27,10 +28,25 @@ import (
 	"github.com/pepper-iot/pulsar-client-go/pkg/log"
 )
 
+// readBufferSize is the size of the buffer frames are read through.
+// Frames that fit in it are decoded without reading each of their
+// sections from the connection separately.
+const readBufferSize = 64 * 1024
+
+// HostPort returns addr without its pulsar:// or pulsar+ssl:// scheme.
+func HostPort(addr string) string {
+	for _, scheme := range []string{"pulsar://", "pulsar+ssl://"} {
+		if strings.HasPrefix(addr, scheme) {
+			return strings.TrimPrefix(addr, scheme)
+		}
+	}
+	return addr
+}
+
 // NewTCPConn creates a core using a TCPv4 connection to the given
 // (pulsar server) address.
 func NewTCPConn(addr string, timeout time.Duration) (*Conn, error) {
-	addr = strings.TrimPrefix(addr, "pulsar://")
+	addr = HostPort(addr)
 
 	d := net.Dialer{
 		DualStack: false,
@@ -51,7 +67,7 @@ func NewTCPConn(addr string, timeout time.Duration) (*Conn, error) {
 // NewTLSConn creates a core using a TCPv4+TLS connection to the given
 // (pulsar server) address.
 func NewTLSConn(addr string, tlsCfg *tls.Config, timeout time.Duration) (*Conn, error) {
-	addr = strings.TrimPrefix(addr, "pulsar://")
+	addr = HostPort(addr)
 
 	d := net.Dialer{
 		DualStack: false,
@@ -80,6 +96,50 @@ type Conn struct {
 	Cmu      sync.Mutex // protects following
 	IsClosed bool
 	Closedc  chan struct{}
+
+	tmu sync.RWMutex // protects following
+	tap *Tap         // if set, frames are written to it
+
+	imu      sync.RWMutex // protects following
+	inbound  Interceptors
+	outbound Interceptors
+
+	pmu        sync.RWMutex // protects following
+	checksum   frame.ChecksumPolicy
+	onMismatch func(f frame.Frame, err error)
+	pooled     bool // whether payloads are taken from the frame payload pool
+}
+
+// SetChecksumPolicy sets how the checksums of received frames are
+// verified. With frame.ChecksumReport, frames whose checksum doesn't
+// match are passed to onMismatch, which may be nil, instead of failing
+// Read; onMismatch is called from the goroutine reading the Conn.
+func (c *Conn) SetChecksumPolicy(policy frame.ChecksumPolicy, onMismatch func(f frame.Frame, err error)) {
+	c.pmu.Lock()
+	c.checksum, c.onMismatch = policy, onMismatch
+	c.pmu.Unlock()
+}
+
+// getChecksumPolicy returns the checksum policy of the Conn.
+func (c *Conn) getChecksumPolicy() (frame.ChecksumPolicy, func(f frame.Frame, err error)) {
+	c.pmu.RLock()
+	defer c.pmu.RUnlock()
+	return c.checksum, c.onMismatch
+}
+
+// SetPooledPayloads sets whether the payloads of received frames
+// are taken from the pool of frame.NewPayload, see Frame.DecodePooled.
+func (c *Conn) SetPooledPayloads(pooled bool) {
+	c.pmu.Lock()
+	c.pooled = pooled
+	c.pmu.Unlock()
+}
+
+// getPooledPayloads returns whether payloads are pooled.
+func (c *Conn) getPooledPayloads() bool {
+	c.pmu.RLock()
+	defer c.pmu.RUnlock()
+	return c.pooled
 }
 
 // Close closes the underlaying connection.
@@ -115,9 +175,25 @@ func (c *Conn) Closed() <-chan struct{} {
 // read() will unblock. Once read returns, the core should
 // be considered unusable.
 func (c *Conn) Read(frameHandler func(f frame.Frame)) error {
+	r := bufio.NewReaderSize(c.Rc, readBufferSize)
 	for {
 		var f frame.Frame
-		if err := f.Decode(c.Rc); err != nil {
+		policy, onMismatch := c.getChecksumPolicy()
+		decode := f.DecodeWithPolicy
+		if c.getPooledPayloads() {
+			decode = f.DecodePooled
+		}
+		if err := decode(r, policy); err != nil {
+			if _, ok := err.(*frame.ChecksumError); ok && policy == frame.ChecksumReport {
+				// the frame was decoded completely,
+				// so the next one can be read
+				log.Frames.Debugf("receive frame %v: %v", f, err)
+				if onMismatch != nil {
+					onMismatch(f, err)
+				}
+				continue
+			}
+
 			// It's very possible that the connection is already closed at this
 			// point, since any connection closed errors would bubble up
 			// from Decode. But just in case it's a decode error (bad data for example),
@@ -126,7 +202,19 @@ func (c *Conn) Read(frameHandler func(f frame.Frame)) error {
 			_ = c.Close()
 			return err
 		}
-		log.Debugf("receive frame %v", f)
+		log.Frames.Debugf("receive frame %v", f)
+		if tap := c.getTap(); tap != nil {
+			tap.write(tapRecv, &f, nil, nil)
+		}
+		if inbound, _ := c.getInterceptors(); len(inbound) > 0 {
+			var err error
+			if f, err = inbound.intercept(f); err == ErrSkipFrame {
+				continue
+			} else if err != nil {
+				_ = c.Close()
+				return err
+			}
+		}
 		frameHandler(f)
 	}
 }
@@ -206,9 +294,22 @@ func smallCmdType(t api.BaseCommand_Type) bool {
 }
 
 // writeFrame encodes the given frame and writes
-// it to the wire in a thread-safe manner.
+// it to the wire in a thread-safe manner. The payload
+// of payload frames is written directly from the frame,
+// after the rest of the frame, rather than copied into
+// the buffer, so that large payloads aren't held twice.
 func (c *Conn) writeFrame(f *frame.Frame) error {
-	log.Debugf("send frame %v", f)
+	if _, outbound := c.getInterceptors(); len(outbound) > 0 {
+		intercepted, err := outbound.intercept(*f)
+		if err == ErrSkipFrame {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		f = &intercepted
+	}
+
+	log.Frames.Debugf("send frame %v", f)
 	var b *bytes.Buffer
 	if smallCmdType(f.BaseCmd.GetType()) {
 		b = getSmallBuf()
@@ -218,13 +319,22 @@ func (c *Conn) writeFrame(f *frame.Frame) error {
 		defer putBuf(b)
 	}
 
-	if err := f.Encode(b); err != nil {
+	payload, err := f.EncodeHeader(b)
+	if err != nil {
 		return err
 	}
+	if tap := c.getTap(); tap != nil {
+		tap.write(tapSend, f, b.Bytes(), payload)
+	}
 
 	c.Wmu.Lock()
-	_, err := b.WriteTo(c.W)
-	c.Wmu.Unlock()
-
+	defer c.Wmu.Unlock()
+	if len(payload) == 0 {
+		_, err = b.WriteTo(c.W)
+		return err
+	}
+	// a single writev(2) on TCP connections
+	bufs := net.Buffers{b.Bytes(), payload}
+	_, err = bufs.WriteTo(c.W)
 	return err
 }
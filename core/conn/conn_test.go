@@ -159,9 +159,10 @@ func TestConn_TimeoutReader(t *testing.T) {
 	}
 
 	// the Reader here will return ErrTimeout
-	// on the second call to Read.
+	// on the second call to Read, which only
+	// reads one byte so the frame is incomplete.
 	mrc := &mockReadCloser{
-		Reader: iotest.TimeoutReader(&b),
+		Reader: iotest.TimeoutReader(iotest.OneByteReader(&b)),
 	}
 	c := Conn{
 		Rc:      mrc,
@@ -372,6 +373,49 @@ func TestConn_writeFrame(t *testing.T) {
 	}
 }
 
+func TestConn_writeFrame_LargePayload(t *testing.T) {
+	f := frame.Frame{
+		BaseCmd: &api.BaseCommand{
+			Type: api.BaseCommand_SEND.Enum(),
+			Send: &api.CommandSend{
+				ProducerId: proto.Uint64(1),
+				SequenceId: proto.Uint64(2),
+			},
+		},
+		Metadata: &api.MessageMetadata{
+			ProducerName: proto.String("large"),
+			SequenceId:   proto.Uint64(2),
+			PublishTime:  proto.Uint64(1513027321000),
+		},
+		Payload: bytes.Repeat([]byte("large payload "), 256*1024),
+	}
+
+	var expected bytes.Buffer
+	if err := f.Encode(&expected); err != nil {
+		t.Fatal(err)
+	}
+
+	var out bytes.Buffer
+	c := Conn{
+		Rc:      &mockReadCloser{Reader: &bytes.Buffer{}},
+		W:       &out,
+		Closedc: make(chan struct{}),
+	}
+	if err := c.writeFrame(&f); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(out.Bytes(), expected.Bytes()) {
+		t.Fatalf("wrote %d bytes different from the %d encoded bytes", out.Len(), expected.Len())
+	}
+
+	// the payload wasn't copied into the pooled buffer
+	b := getBuf()
+	defer putBuf(b)
+	if cap(b.Bytes()) >= len(f.Payload) {
+		t.Fatalf("pooled buffer grew to %d bytes; expected less than the payload", cap(b.Bytes()))
+	}
+}
+
 func TestConn_TCP_Read(t *testing.T) {
 	testFrames := map[string]frame.Frame{
 		"ping": {
@@ -718,3 +762,215 @@ func TestConn_TCP_SendOnClosed(t *testing.T) {
 		t.Logf("sendSimpleCmd() err (expected for a closed core) = %v", err)
 	}
 }
+
+func TestConn_ChecksumPolicy(t *testing.T) {
+	var wire bytes.Buffer
+	for i := 0; i < 2; i++ {
+		f := frame.Frame{
+			BaseCmd: &api.BaseCommand{
+				Type: api.BaseCommand_MESSAGE.Enum(),
+				Message: &api.CommandMessage{
+					ConsumerId: proto.Uint64(uint64(i)),
+					MessageId: &api.MessageIdData{
+						LedgerId: proto.Uint64(1),
+						EntryId:  proto.Uint64(uint64(i)),
+					},
+				},
+			},
+			Metadata: &api.MessageMetadata{
+				ProducerName: proto.String("test"),
+				SequenceId:   proto.Uint64(uint64(i)),
+				PublishTime:  proto.Uint64(1513027321000),
+			},
+			Payload: []byte("hola mundo"),
+		}
+		if err := f.Encode(&wire); err != nil {
+			t.Fatal(err)
+		}
+		if i == 0 {
+			// corrupt the payload of the first frame
+			wire.Bytes()[wire.Len()-1] ^= 0xff
+		}
+	}
+
+	read := func(policy frame.ChecksumPolicy) (handled, corrupt []frame.Frame, err error) {
+		c := Conn{
+			Rc:      &mockReadCloser{Reader: bytes.NewReader(wire.Bytes())},
+			W:       io.Discard,
+			Closedc: make(chan struct{}),
+		}
+		c.SetChecksumPolicy(policy, func(f frame.Frame, err error) {
+			if _, ok := err.(*frame.ChecksumError); !ok {
+				t.Errorf("onMismatch err = %v; expected a ChecksumError", err)
+			}
+			corrupt = append(corrupt, f)
+		})
+		err = c.Read(func(f frame.Frame) {
+			handled = append(handled, f)
+		})
+		return handled, corrupt, err
+	}
+
+	// the mismatch fails Read
+	handled, _, err := read(frame.ChecksumVerify)
+	if _, ok := err.(*frame.ChecksumError); !ok || len(handled) != 0 {
+		t.Fatalf("ChecksumVerify: Read() err = %v, handled %d frames; expected a ChecksumError", err, len(handled))
+	}
+
+	// the corrupt frame is reported, and the next one handled
+	handled, corrupt, err := read(frame.ChecksumReport)
+	if err != io.EOF {
+		t.Fatalf("ChecksumReport: Read() err = %v; expected EOF", err)
+	}
+	if len(corrupt) != 1 || corrupt[0].BaseCmd.GetMessage().GetConsumerId() != 0 {
+		t.Fatalf("ChecksumReport: got corrupt frames %v; expected the first one", corrupt)
+	}
+	if len(handled) != 1 || handled[0].BaseCmd.GetMessage().GetConsumerId() != 1 {
+		t.Fatalf("ChecksumReport: handled frames %v; expected the second one", handled)
+	}
+
+	// checksums aren't verified
+	handled, corrupt, err = read(frame.ChecksumSkip)
+	if err != io.EOF || len(handled) != 2 || len(corrupt) != 0 {
+		t.Fatalf("ChecksumSkip: Read() err = %v, handled %d and %d corrupt frames; expected EOF and 2 handled", err, len(handled), len(corrupt))
+	}
+}
+
+func TestConn_PooledPayloads(t *testing.T) {
+	f := frame.Frame{
+		BaseCmd: &api.BaseCommand{
+			Type: api.BaseCommand_MESSAGE.Enum(),
+			Message: &api.CommandMessage{
+				ConsumerId: proto.Uint64(1),
+				MessageId: &api.MessageIdData{
+					LedgerId: proto.Uint64(1),
+					EntryId:  proto.Uint64(1),
+				},
+			},
+		},
+		Metadata: &api.MessageMetadata{
+			ProducerName: proto.String("test"),
+			SequenceId:   proto.Uint64(1),
+			PublishTime:  proto.Uint64(1513027321000),
+		},
+		Payload: []byte("hola mundo"),
+	}
+	var wire bytes.Buffer
+	if err := f.Encode(&wire); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, pooled := range []bool{false, true} {
+		c := Conn{
+			Rc:      &mockReadCloser{Reader: bytes.NewReader(wire.Bytes())},
+			W:       io.Discard,
+			Closedc: make(chan struct{}),
+		}
+		c.SetPooledPayloads(pooled)
+		var handled []frame.Frame
+		if err := c.Read(func(f frame.Frame) {
+			handled = append(handled, f)
+		}); err != io.EOF {
+			t.Fatalf("Read() err = %v; expected EOF", err)
+		}
+		if len(handled) != 1 || !handled[0].Equal(f) {
+			t.Fatalf("handled frames %v; expected %v", handled, f)
+		}
+		if got := handled[0].PooledPayload; got != pooled {
+			t.Fatalf("PooledPayload = %t; expected %t", got, pooled)
+		}
+	}
+}
+
+func TestHostPort(t *testing.T) {
+	for addr, expected := range map[string]string{
+		"localhost:6650":              "localhost:6650",
+		"pulsar://localhost:6650":     "localhost:6650",
+		"pulsar+ssl://localhost:6651": "localhost:6651",
+	} {
+		if got := HostPort(addr); got != expected {
+			t.Errorf("HostPort(%q) = %q; expected %q", addr, got, expected)
+		}
+	}
+}
+
+// repeatReader reads the encoding of a frame n times.
+type repeatReader struct {
+	encoded []byte
+	n       int
+	off     int
+}
+
+func (r *repeatReader) Read(p []byte) (int, error) {
+	if r.n == 0 {
+		return 0, io.EOF
+	}
+	n := copy(p, r.encoded[r.off:])
+	if r.off += n; r.off == len(r.encoded) {
+		r.off = 0
+		r.n--
+	}
+	return n, nil
+}
+
+// BenchmarkConn_Read compares the allocations of reading frames which
+// are released once handled, as the Client and msg.Message.Release do,
+// to those of frames that aren't.
+func BenchmarkConn_Read(b *testing.B) {
+	frames := map[string]frame.Frame{
+		"receipt": {
+			BaseCmd: &api.BaseCommand{
+				Type: api.BaseCommand_SEND_RECEIPT.Enum(),
+				SendReceipt: &api.CommandSendReceipt{
+					ProducerId: proto.Uint64(1),
+					SequenceId: proto.Uint64(2),
+				},
+			},
+		},
+		"message": {
+			BaseCmd: &api.BaseCommand{
+				Type: api.BaseCommand_MESSAGE.Enum(),
+				Message: &api.CommandMessage{
+					ConsumerId: proto.Uint64(1),
+					MessageId: &api.MessageIdData{
+						LedgerId: proto.Uint64(1),
+						EntryId:  proto.Uint64(1),
+					},
+				},
+			},
+			Metadata: &api.MessageMetadata{
+				ProducerName: proto.String("bench"),
+				SequenceId:   proto.Uint64(1),
+				PublishTime:  proto.Uint64(1513027321000),
+			},
+			Payload: make([]byte, 1024),
+		},
+	}
+
+	for name, f := range frames {
+		var wire bytes.Buffer
+		if err := f.Encode(&wire); err != nil {
+			b.Fatal(err)
+		}
+		for _, release := range []bool{false, true} {
+			b.Run(fmt.Sprintf("%s/release=%t", name, release), func(b *testing.B) {
+				c := Conn{
+					Rc:      &mockReadCloser{Reader: &repeatReader{encoded: wire.Bytes(), n: b.N}},
+					W:       io.Discard,
+					Closedc: make(chan struct{}),
+				}
+				c.SetPooledPayloads(true)
+				b.ReportAllocs()
+				b.ResetTimer()
+				err := c.Read(func(f frame.Frame) {
+					if release {
+						f.Release()
+					}
+				})
+				if err != io.EOF {
+					b.Fatalf("Read() err = %v; expected EOF", err)
+				}
+			})
+		}
+	}
+}
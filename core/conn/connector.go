@@ -15,8 +15,6 @@ package conn
 
 import (
 	"context"
-	"fmt"
-	"strings"
 
 	"github.com/golang/protobuf/proto"
 	"github.com/pepper-iot/pulsar-client-go/core/frame"
@@ -56,7 +54,8 @@ type Connector struct {
 //
 // It's required to have completed Connect/Connected before using the client.
 func (c *Connector) Connect(ctx context.Context, authMethod, proxyBrokerURL string) (*api.CommandConnected, error) {
-	resp, cancel, err := c.Dispatcher.RegisterGlobal()
+	deadline, _ := ctx.Deadline()
+	resp, cancel, err := c.Dispatcher.RegisterGlobalDeadline(deadline)
 	if err != nil {
 		return nil, err
 	}
@@ -66,7 +65,7 @@ func (c *Connector) Connect(ctx context.Context, authMethod, proxyBrokerURL stri
 	// RequestID will be -1 (ie UndefRequestID) in the case that it's
 	// associated with a CONNECT request.
 	// https://github.com/apache/incubator-pulsar/blob/fdc7b8426d8253c9437777ae51a4639239550f00/pulsar-broker/src/main/java/org/apache/pulsar/broker/service/ServerCnx.java#L325
-	errResp, cancel, err := c.Dispatcher.RegisterReqID(utils.UndefRequestID)
+	errResp, cancel, err := c.Dispatcher.RegisterReqIDDeadline(utils.UndefRequestID, deadline)
 	if err != nil {
 		return nil, err
 	}
@@ -77,13 +76,15 @@ func (c *Connector) Connect(ctx context.Context, authMethod, proxyBrokerURL stri
 	connect := api.CommandConnect{
 		ClientVersion:   proto.String(utils.ClientVersion),
 		ProtocolVersion: proto.Int32(utils.ProtoVersion),
+		FeatureFlags: &api.FeatureFlags{
+			SupportsBrokerEntryMetadata: proto.Bool(true),
+		},
 	}
 	if authMethod != "" {
 		connect.AuthMethodName = proto.String(authMethod)
 	}
 	if proxyBrokerURL != "" {
-		addr := strings.TrimPrefix(proxyBrokerURL, "pulsar://")
-		connect.ProxyToBrokerUrl = proto.String(addr)
+		connect.ProxyToBrokerUrl = proto.String(HostPort(proxyBrokerURL))
 	}
 
 	if c.AuthConfig.AuthMethod != "" {
@@ -114,6 +115,6 @@ func (c *Connector) Connect(ctx context.Context, authMethod, proxyBrokerURL stri
 
 	case errFrame := <-errResp:
 		err := errFrame.BaseCmd.GetError()
-		return nil, fmt.Errorf("%s: %s", err.GetError().String(), err.GetMessage())
+		return nil, utils.NewServerError(err.GetError(), err.GetMessage())
 	}
 }
@@ -0,0 +1,66 @@
+// Copyright 2018 Comcast Cable Communications Management, LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conn
+
+import (
+	"errors"
+
+	"github.com/pepper-iot/pulsar-client-go/core/frame"
+)
+
+// ErrSkipFrame is returned by an Interceptor to drop
+// a frame without failing the Conn or the send.
+var ErrSkipFrame = errors.New("skip frame")
+
+// Interceptor is called with each frame sent or received on a Conn,
+// e.g. for auditing, and returns the frame to pass on, which it may
+// have modified or replaced. Returning ErrSkipFrame drops the frame.
+// Any other error fails the send of an outbound frame, and closes
+// the Conn for an inbound frame.
+//
+// Outbound interceptors are called concurrently from the goroutines
+// sending frames, inbound interceptors from the goroutine reading
+// the Conn.
+type Interceptor func(f frame.Frame) (frame.Frame, error)
+
+// Interceptors is a chain of Interceptors, called in order.
+type Interceptors []Interceptor
+
+// intercept passes f through the chain.
+func (is Interceptors) intercept(f frame.Frame) (frame.Frame, error) {
+	for _, i := range is {
+		var err error
+		if f, err = i(f); err != nil {
+			return f, err
+		}
+	}
+	return f, nil
+}
+
+// SetInterceptors makes the Conn pass all frames received and sent from
+// now on through the inbound and outbound chains. Inbound frames are
+// intercepted after being written to the Tap, and outbound ones before,
+// so that the Tap shows the frames as they are on the wire.
+func (c *Conn) SetInterceptors(inbound, outbound Interceptors) {
+	c.imu.Lock()
+	c.inbound, c.outbound = inbound, outbound
+	c.imu.Unlock()
+}
+
+// getInterceptors returns the chains of the Conn.
+func (c *Conn) getInterceptors() (inbound, outbound Interceptors) {
+	c.imu.RLock()
+	defer c.imu.RUnlock()
+	return c.inbound, c.outbound
+}
@@ -0,0 +1,147 @@
+// Copyright 2018 Comcast Cable Communications Management, LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conn
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/pepper-iot/pulsar-client-go/core/frame"
+	"github.com/pepper-iot/pulsar-client-go/pkg/api"
+)
+
+func TestConn_Interceptors(t *testing.T) {
+	var in bytes.Buffer
+	for _, typ := range []api.BaseCommand_Type{api.BaseCommand_PING, api.BaseCommand_PONG, api.BaseCommand_PING} {
+		f := frame.Frame{BaseCmd: &api.BaseCommand{Type: typ.Enum()}}
+		if err := f.Encode(&in); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var out bytes.Buffer
+	c := Conn{
+		Rc:      &mockReadCloser{Reader: &in},
+		W:       &out,
+		Closedc: make(chan struct{}),
+	}
+
+	var audited []api.BaseCommand_Type
+	audit := func(f frame.Frame) (frame.Frame, error) {
+		audited = append(audited, f.BaseCmd.GetType())
+		return f, nil
+	}
+	skipPongs := func(f frame.Frame) (frame.Frame, error) {
+		if f.BaseCmd.GetType() == api.BaseCommand_PONG {
+			return f, ErrSkipFrame
+		}
+		return f, nil
+	}
+	setProducerName := func(f frame.Frame) (frame.Frame, error) {
+		if f.Metadata != nil {
+			meta := *f.Metadata
+			meta.ProducerName = proto.String("intercepted")
+			f.Metadata = &meta
+		}
+		return f, nil
+	}
+	c.SetInterceptors(
+		Interceptors{audit, skipPongs},
+		Interceptors{skipPongs, setProducerName},
+	)
+
+	// inbound frames are audited, and PONGs not handled
+	var handled []api.BaseCommand_Type
+	_ = c.Read(func(f frame.Frame) {
+		handled = append(handled, f.BaseCmd.GetType())
+	})
+	if len(audited) != 3 {
+		t.Fatalf("audited %v; expected 3 frames", audited)
+	}
+	if len(handled) != 2 || handled[0] != api.BaseCommand_PING || handled[1] != api.BaseCommand_PING {
+		t.Fatalf("handled %v; expected 2 PINGs", handled)
+	}
+
+	// outbound PONGs are skipped
+	if err := c.SendSimpleCmd(api.BaseCommand{Type: api.BaseCommand_PONG.Enum(), Pong: &api.CommandPong{}}); err != nil {
+		t.Fatal(err)
+	}
+	if out.Len() != 0 {
+		t.Fatalf("wrote %d bytes; expected the PONG to be skipped", out.Len())
+	}
+
+	// outbound metadata is mutated
+	err := c.SendPayloadCmd(api.BaseCommand{
+		Type: api.BaseCommand_SEND.Enum(),
+		Send: &api.CommandSend{
+			ProducerId: proto.Uint64(1),
+			SequenceId: proto.Uint64(2),
+		},
+	}, api.MessageMetadata{
+		ProducerName: proto.String("producer"),
+		SequenceId:   proto.Uint64(2),
+		PublishTime:  proto.Uint64(3),
+	}, []byte("hola mundo"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var sent frame.Frame
+	if err := sent.Decode(&out); err != nil {
+		t.Fatal(err)
+	}
+	if got := sent.Metadata.GetProducerName(); got != "intercepted" {
+		t.Fatalf("sent producer name %q; expected %q", got, "intercepted")
+	}
+}
+
+func TestConn_Interceptors_Error(t *testing.T) {
+	expected := errors.New("rejected")
+	reject := func(f frame.Frame) (frame.Frame, error) {
+		return f, expected
+	}
+
+	var in bytes.Buffer
+	ping := frame.Frame{BaseCmd: &api.BaseCommand{Type: api.BaseCommand_PING.Enum()}}
+	if err := ping.Encode(&in); err != nil {
+		t.Fatal(err)
+	}
+
+	var out bytes.Buffer
+	c := Conn{
+		Rc:      &mockReadCloser{Reader: &in},
+		W:       &out,
+		Closedc: make(chan struct{}),
+	}
+	c.SetInterceptors(Interceptors{reject}, Interceptors{reject})
+
+	// the send fails
+	if err := c.SendSimpleCmd(*ping.BaseCmd); err != expected {
+		t.Fatalf("SendSimpleCmd() err = %v; expected %v", err, expected)
+	}
+	if out.Len() != 0 {
+		t.Fatalf("wrote %d bytes; expected none", out.Len())
+	}
+
+	// the Conn is closed
+	if err := c.Read(func(frame.Frame) { t.Fatal("frame handled") }); err != expected {
+		t.Fatalf("Read() err = %v; expected %v", err, expected)
+	}
+	select {
+	case <-c.Closed():
+	default:
+		t.Fatal("Conn not closed")
+	}
+}
@@ -0,0 +1,110 @@
+// Copyright 2018 Comcast Cable Communications Management, LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conn
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/pepper-iot/pulsar-client-go/core/frame"
+	"github.com/pepper-iot/pulsar-client-go/pkg/api"
+)
+
+// Tap directions.
+const (
+	tapSend = ">>>"
+	tapRecv = "<<<"
+)
+
+// NewTap returns a Tap writing to w. If types are given,
+// only frames of these command types are written.
+func NewTap(w io.Writer, types ...api.BaseCommand_Type) *Tap {
+	t := Tap{
+		w: w,
+	}
+	if len(types) > 0 {
+		t.types = make(map[api.BaseCommand_Type]bool, len(types))
+		for _, typ := range types {
+			t.types[typ] = true
+		}
+	}
+	return &t
+}
+
+// Tap writes the frames sent and received on a Conn, as a
+// summary of the decoded command followed by a hex dump of
+// the encoded frame, to debug at the wire level. Received
+// frames are dumped as re-encoded after decoding.
+type Tap struct {
+	types map[api.BaseCommand_Type]bool // nil to write all frames
+
+	mu sync.Mutex // protects following
+	w  io.Writer
+}
+
+// SetTap makes the Conn write all frames sent
+// and received from now on to t. nil removes it.
+func (c *Conn) SetTap(t *Tap) {
+	c.tmu.Lock()
+	c.tap = t
+	c.tmu.Unlock()
+}
+
+// getTap returns the Tap of the Conn, or nil.
+func (c *Conn) getTap() *Tap {
+	c.tmu.RLock()
+	defer c.tmu.RUnlock()
+	return c.tap
+}
+
+// match returns true if frames of the given type are written.
+func (t *Tap) match(typ api.BaseCommand_Type) bool {
+	return t.types == nil || t.types[typ]
+}
+
+// write writes the frame and its encoding, as its encoded header
+// followed by its payload, which are dumped without being copied
+// together. If header is nil, the frame is encoded first.
+func (t *Tap) write(dir string, f *frame.Frame, header, payload []byte) {
+	if !t.match(f.BaseCmd.GetType()) {
+		return
+	}
+	if header == nil {
+		var b bytes.Buffer
+		if err := f.Encode(&b); err != nil {
+			return
+		}
+		header, payload = b.Bytes(), nil
+	}
+
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "%s %s %s %d bytes\n", dir, time.Now().Format(time.RFC3339Nano), f.BaseCmd.GetType(), len(header)+len(payload))
+	fmt.Fprintf(&b, "command: %s\n", proto.CompactTextString(f.BaseCmd))
+	if f.Metadata != nil {
+		fmt.Fprintf(&b, "metadata: %s\n", proto.CompactTextString(f.Metadata))
+	}
+	dumper := hex.Dumper(&b)
+	_, _ = dumper.Write(header)
+	_, _ = dumper.Write(payload)
+	_ = dumper.Close()
+
+	t.mu.Lock()
+	_, _ = b.WriteTo(t.w)
+	t.mu.Unlock()
+}
@@ -0,0 +1,99 @@
+// Copyright 2018 Comcast Cable Communications Management, LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conn
+
+import (
+	"bytes"
+	"encoding/hex"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/pepper-iot/pulsar-client-go/core/frame"
+	"github.com/pepper-iot/pulsar-client-go/pkg/api"
+)
+
+func TestConn_Tap(t *testing.T) {
+	connected := frame.Frame{
+		BaseCmd: &api.BaseCommand{
+			Type: api.BaseCommand_CONNECTED.Enum(),
+			Connected: &api.CommandConnected{
+				ServerVersion: proto.String("Pulsar Server"),
+			},
+		},
+	}
+	var in bytes.Buffer
+	if err := connected.Encode(&in); err != nil {
+		t.Fatal(err)
+	}
+	encodedIn := in.Bytes()
+
+	var out, tapped bytes.Buffer
+	c := Conn{
+		Rc:      &mockReadCloser{Reader: bytes.NewReader(encodedIn)},
+		W:       &out,
+		Closedc: make(chan struct{}),
+	}
+	c.SetTap(NewTap(&tapped, api.BaseCommand_CONNECTED, api.BaseCommand_SEND))
+
+	if err := c.Read(func(frame.Frame) {}); err != io.EOF {
+		t.Fatalf("Read() err = %v; expected EOF", err)
+	}
+	// filtered out
+	if err := c.SendSimpleCmd(api.BaseCommand{Type: api.BaseCommand_PING.Enum(), Ping: &api.CommandPing{}}); err != nil {
+		t.Fatal(err)
+	}
+	pinged := out.Len()
+	err := c.SendPayloadCmd(api.BaseCommand{
+		Type: api.BaseCommand_SEND.Enum(),
+		Send: &api.CommandSend{
+			ProducerId: proto.Uint64(1),
+			SequenceId: proto.Uint64(2),
+		},
+	}, api.MessageMetadata{
+		ProducerName: proto.String("tapped-producer"),
+		SequenceId:   proto.Uint64(2),
+		PublishTime:  proto.Uint64(3),
+	}, []byte("hola mundo"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the header and payload of the SEND are dumped as written
+	encodedOut := out.Bytes()[pinged:]
+
+	got := tapped.String()
+	t.Log(got)
+	for _, expected := range []string{
+		"<<< ", "CONNECTED", `server_version:"Pulsar Server"`, hex.Dump(encodedIn),
+		">>> ", "SEND", `producer_name:"tapped-producer"`, hex.Dump(encodedOut),
+	} {
+		if !strings.Contains(got, expected) {
+			t.Errorf("tap output doesn't contain %q", expected)
+		}
+	}
+	if strings.Contains(got, "PING") {
+		t.Error("tap output contains filtered out PING frame")
+	}
+
+	c.SetTap(nil)
+	tapped.Reset()
+	if err := c.SendSimpleCmd(api.BaseCommand{Type: api.BaseCommand_PING.Enum(), Ping: &api.CommandPing{}}); err != nil {
+		t.Fatal(err)
+	}
+	if tapped.Len() != 0 {
+		t.Fatalf("tap output = %q after removing the tap; expected none", tapped.String())
+	}
+}
@@ -0,0 +1,238 @@
+// Copyright 2018 Comcast Cable Communications Management, LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package encryption encrypts message payloads so that only the
+// consumers holding one of the private keys they are encrypted for may
+// read them, and brokers can't. Each payload is encrypted with
+// AES-GCM using a symmetric data key, which is itself encrypted with
+// each public key and sent in the MessageMetadata.EncryptionKeys.
+package encryption
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/pepper-iot/pulsar-client-go/pkg/api"
+)
+
+// KeyRotation is how long an Encryptor uses a data key
+// before generating a new one.
+const KeyRotation = 4 * time.Hour
+
+const (
+	dataKeySize = 32 // AES-256
+	nonceSize   = 12 // standard GCM nonce
+)
+
+// ErrNoKey is returned when decrypting a payload none of whose
+// encrypted data keys may be decrypted with the available private keys.
+var ErrNoKey = errors.New("no private key to decrypt the data key")
+
+// KeyInfo is a PEM encoded key, and the metadata sent along the data
+// key it encrypts, eg the version of the key.
+type KeyInfo struct {
+	Key      []byte
+	Metadata map[string]string
+}
+
+// CryptoKeyReader returns the RSA or ECDSA keys encrypting data keys,
+// by name. Producers use public keys, and consumers private keys,
+// along with the metadata of the encrypted data key. Implementations
+// must be safe for concurrent use.
+type CryptoKeyReader interface {
+	PublicKey(name string, metadata map[string]string) (KeyInfo, error)
+	PrivateKey(name string, metadata map[string]string) (KeyInfo, error)
+}
+
+// FileKeyReader is a CryptoKeyReader reading the same
+// PEM encoded key pair from files for every key name.
+type FileKeyReader struct {
+	PublicKeyPath  string
+	PrivateKeyPath string
+}
+
+// PublicKey implements CryptoKeyReader.
+func (r FileKeyReader) PublicKey(name string, metadata map[string]string) (KeyInfo, error) {
+	key, err := os.ReadFile(r.PublicKeyPath)
+	return KeyInfo{Key: key}, err
+}
+
+// PrivateKey implements CryptoKeyReader.
+func (r FileKeyReader) PrivateKey(name string, metadata map[string]string) (KeyInfo, error) {
+	key, err := os.ReadFile(r.PrivateKeyPath)
+	return KeyInfo{Key: key}, err
+}
+
+// Encryptor encrypts the payloads of a producer for a set of keys. Its
+// data key is generated when it is created, then every KeyRotation.
+// It is safe for concurrent use, and may be shared by the producers of
+// a topic's partitions.
+type Encryptor struct {
+	reader CryptoKeyReader
+	names  []string
+
+	mu      sync.Mutex // protects following
+	aead    cipher.AEAD
+	keys    []*api.EncryptionKeys // data key, encrypted with each public key
+	created time.Time             // when the data key was generated
+}
+
+// NewEncryptor returns an Encryptor encrypting payloads for the named
+// keys, whose public keys are read with reader. It fails if any of
+// them can't be read, or isn't an RSA or ECDSA key.
+func NewEncryptor(reader CryptoKeyReader, names ...string) (*Encryptor, error) {
+	if len(names) == 0 {
+		return nil, errors.New("no encryption key names")
+	}
+	e := Encryptor{
+		reader: reader,
+		names:  names,
+	}
+	if err := e.rotate(); err != nil {
+		return nil, err
+	}
+	return &e, nil
+}
+
+// rotate generates a new data key, and encrypts it with the
+// public keys, which are read again. e.mu must be held.
+func (e *Encryptor) rotate() error {
+	dataKey := make([]byte, dataKeySize)
+	if _, err := rand.Read(dataKey); err != nil {
+		return err
+	}
+	aead, err := newAEAD(dataKey)
+	if err != nil {
+		return err
+	}
+
+	keys := make([]*api.EncryptionKeys, 0, len(e.names))
+	for _, name := range e.names {
+		info, err := e.reader.PublicKey(name, nil)
+		if err != nil {
+			return fmt.Errorf("reading public key %q: %w", name, err)
+		}
+		pub, err := parsePublicKey(info.Key)
+		if err != nil {
+			return fmt.Errorf("parsing public key %q: %w", name, err)
+		}
+		wrapped, err := wrapKey(pub, dataKey)
+		if err != nil {
+			return fmt.Errorf("encrypting data key with %q: %w", name, err)
+		}
+		keys = append(keys, &api.EncryptionKeys{
+			Key:      proto.String(name),
+			Value:    wrapped,
+			Metadata: keyValues(info.Metadata),
+		})
+	}
+
+	e.aead, e.keys, e.created = aead, keys, time.Now()
+	return nil
+}
+
+// Encrypt returns the payload encrypted with the data key, and sets
+// the encrypted data keys and the nonce of the payload in meta. Each
+// payload has its own random nonce.
+func (e *Encryptor) Encrypt(meta *api.MessageMetadata, payload []byte) ([]byte, error) {
+	e.mu.Lock()
+	if time.Since(e.created) >= KeyRotation {
+		if err := e.rotate(); err != nil {
+			e.mu.Unlock()
+			return nil, err
+		}
+	}
+	aead, keys := e.aead, e.keys
+	e.mu.Unlock()
+
+	nonce := make([]byte, nonceSize)
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	meta.EncryptionKeys = keys
+	meta.EncryptionAlgo = nil
+	meta.EncryptionParam = nonce
+	return aead.Seal(nil, nonce, payload, nil), nil
+}
+
+// Decrypt returns the payload of a message decrypted with its data
+// key, which is decrypted with the first of its keys whose private key
+// reader returns. It returns ErrNoKey if there is none.
+func Decrypt(reader CryptoKeyReader, meta *api.MessageMetadata, payload []byte) ([]byte, error) {
+	if algo := meta.GetEncryptionAlgo(); algo != "" {
+		return nil, fmt.Errorf("unsupported encryption algorithm %q", algo)
+	}
+
+	for _, k := range meta.GetEncryptionKeys() {
+		info, err := reader.PrivateKey(k.GetKey(), keyValueMap(k.GetMetadata()))
+		if err != nil {
+			// another key may be available
+			continue
+		}
+		priv, err := parsePrivateKey(info.Key)
+		if err != nil {
+			return nil, fmt.Errorf("parsing private key %q: %w", k.GetKey(), err)
+		}
+		dataKey, err := unwrapKey(priv, k.GetValue())
+		if err != nil {
+			return nil, fmt.Errorf("decrypting data key with %q: %w", k.GetKey(), err)
+		}
+		aead, err := newAEAD(dataKey)
+		if err != nil {
+			return nil, err
+		}
+		nonce := meta.GetEncryptionParam()
+		if len(nonce) != aead.NonceSize() {
+			return nil, fmt.Errorf("invalid nonce size: %d", len(nonce))
+		}
+		return aead.Open(nil, nonce, payload, nil)
+	}
+	return nil, ErrNoKey
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+func keyValues(m map[string]string) []*api.KeyValue {
+	if len(m) == 0 {
+		return nil
+	}
+	kvs := make([]*api.KeyValue, 0, len(m))
+	for k, v := range m {
+		kvs = append(kvs, &api.KeyValue{Key: proto.String(k), Value: proto.String(v)})
+	}
+	return kvs
+}
+
+func keyValueMap(kvs []*api.KeyValue) map[string]string {
+	if len(kvs) == 0 {
+		return nil
+	}
+	m := make(map[string]string, len(kvs))
+	for _, kv := range kvs {
+		m[kv.GetKey()] = kv.GetValue()
+	}
+	return m
+}
@@ -0,0 +1,190 @@
+// Copyright 2018 Comcast Cable Communications Management, LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package encryption
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/pepper-iot/pulsar-client-go/pkg/api"
+)
+
+// memoryKeyReader is a CryptoKeyReader of PEM encoded keys, by name.
+type memoryKeyReader struct {
+	public, private map[string][]byte
+}
+
+func (r memoryKeyReader) PublicKey(name string, metadata map[string]string) (KeyInfo, error) {
+	key, ok := r.public[name]
+	if !ok {
+		return KeyInfo{}, errors.New("no public key " + name)
+	}
+	return KeyInfo{Key: key, Metadata: map[string]string{"version": "1"}}, nil
+}
+
+func (r memoryKeyReader) PrivateKey(name string, metadata map[string]string) (KeyInfo, error) {
+	key, ok := r.private[name]
+	if !ok {
+		return KeyInfo{}, errors.New("no private key " + name)
+	}
+	if metadata["version"] != "1" {
+		return KeyInfo{}, errors.New("no private key version " + metadata["version"])
+	}
+	return KeyInfo{Key: key}, nil
+}
+
+// keyPair returns a new PEM encoded RSA or ECDSA key pair.
+func keyPair(t *testing.T, ec bool) (public, private []byte) {
+	t.Helper()
+
+	var pub, priv interface{}
+	if ec {
+		key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		if err != nil {
+			t.Fatal(err)
+		}
+		pub, priv = &key.PublicKey, key
+	} else {
+		key, err := rsa.GenerateKey(rand.Reader, 2048)
+		if err != nil {
+			t.Fatal(err)
+		}
+		pub, priv = &key.PublicKey, key
+	}
+
+	pubDER, err := x509.MarshalPKIXPublicKey(pub)
+	if err != nil {
+		t.Fatal(err)
+	}
+	privDER, err := x509.MarshalPKCS8PrivateKey(priv)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: pubDER}),
+		pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: privDER})
+}
+
+func TestEncryptor(t *testing.T) {
+	rsaPub, rsaPriv := keyPair(t, false)
+	ecPub, ecPriv := keyPair(t, true)
+	producer := memoryKeyReader{public: map[string][]byte{"rsa": rsaPub, "ec": ecPub}}
+
+	e, err := NewEncryptor(producer, "rsa", "ec")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	payload := []byte("hola mundo")
+	var meta api.MessageMetadata
+	encrypted, err := e.Encrypt(&meta, payload)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bytes.Contains(encrypted, payload) {
+		t.Fatalf("Encrypt() = %q; expected it not to contain the payload", encrypted)
+	}
+	if got, expected := len(meta.GetEncryptionKeys()), 2; got != expected {
+		t.Fatalf("encryption keys = %d; expected %d", got, expected)
+	}
+	if got, expected := len(meta.GetEncryptionParam()), nonceSize; got != expected {
+		t.Fatalf("encryption param size = %d; expected %d", got, expected)
+	}
+
+	// each consumer decrypts with its own private key
+	for name, priv := range map[string][]byte{"rsa": rsaPriv, "ec": ecPriv} {
+		consumer := memoryKeyReader{private: map[string][]byte{name: priv}}
+		got, err := Decrypt(consumer, &meta, encrypted)
+		if err != nil {
+			t.Fatalf("Decrypt() with %s key err = %v; expected nil", name, err)
+		}
+		if !bytes.Equal(got, payload) {
+			t.Fatalf("Decrypt() with %s key = %q; expected %q", name, got, payload)
+		}
+	}
+
+	if _, err := Decrypt(memoryKeyReader{}, &meta, encrypted); err != ErrNoKey {
+		t.Fatalf("Decrypt() without keys err = %v; expected %v", err, ErrNoKey)
+	}
+
+	// payloads have their own nonce
+	var other api.MessageMetadata
+	if _, err := e.Encrypt(&other, payload); err != nil {
+		t.Fatal(err)
+	}
+	if bytes.Equal(other.GetEncryptionParam(), meta.GetEncryptionParam()) {
+		t.Fatal("Encrypt() reused a nonce")
+	}
+
+	// tampered payloads are rejected
+	encrypted[0] ^= 1
+	consumer := memoryKeyReader{private: map[string][]byte{"rsa": rsaPriv}}
+	if _, err := Decrypt(consumer, &meta, encrypted); err == nil {
+		t.Fatal("Decrypt() of tampered payload err = nil; expected an error")
+	}
+}
+
+func TestNewEncryptor_Errors(t *testing.T) {
+	if _, err := NewEncryptor(memoryKeyReader{}); err == nil {
+		t.Fatal("NewEncryptor() without names err = nil; expected an error")
+	}
+	if _, err := NewEncryptor(memoryKeyReader{}, "missing"); err == nil {
+		t.Fatal("NewEncryptor() with missing key err = nil; expected an error")
+	}
+	_, priv := keyPair(t, false)
+	reader := memoryKeyReader{public: map[string][]byte{"private": priv}}
+	if _, err := NewEncryptor(reader, "private"); err == nil {
+		t.Fatal("NewEncryptor() with private key err = nil; expected an error")
+	}
+}
+
+func TestFileKeyReader(t *testing.T) {
+	pub, priv := keyPair(t, true)
+	dir := t.TempDir()
+	r := FileKeyReader{
+		PublicKeyPath:  filepath.Join(dir, "public.pem"),
+		PrivateKeyPath: filepath.Join(dir, "private.pem"),
+	}
+	if err := os.WriteFile(r.PublicKeyPath, pub, 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(r.PrivateKeyPath, priv, 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	e, err := NewEncryptor(r, "app")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var meta api.MessageMetadata
+	encrypted, err := e.Encrypt(&meta, []byte("hola"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := Decrypt(r, &meta, encrypted)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != "hola" {
+		t.Fatalf("Decrypt() = %q; expected %q", got, "hola")
+	}
+}
@@ -0,0 +1,153 @@
+// Copyright 2018 Comcast Cable Communications Management, LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package encryption
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+)
+
+// parsePublicKey returns the first RSA or ECDSA public key of a PEM
+// encoded key, which may be in PKIX or PKCS #1 form.
+func parsePublicKey(data []byte) (interface{}, error) {
+	for {
+		var block *pem.Block
+		block, data = pem.Decode(data)
+		if block == nil {
+			return nil, errors.New("no PEM encoded public key")
+		}
+		switch block.Type {
+		case "PUBLIC KEY":
+			key, err := x509.ParsePKIXPublicKey(block.Bytes)
+			if err != nil {
+				return nil, err
+			}
+			switch key.(type) {
+			case *rsa.PublicKey, *ecdsa.PublicKey:
+				return key, nil
+			}
+			return nil, fmt.Errorf("unsupported public key type %T", key)
+		case "RSA PUBLIC KEY":
+			return x509.ParsePKCS1PublicKey(block.Bytes)
+		}
+		// skip other blocks, eg EC PARAMETERS
+	}
+}
+
+// parsePrivateKey returns the first RSA or ECDSA private key of a PEM
+// encoded key, which may be in PKCS #8, PKCS #1 or SEC 1 form.
+func parsePrivateKey(data []byte) (interface{}, error) {
+	for {
+		var block *pem.Block
+		block, data = pem.Decode(data)
+		if block == nil {
+			return nil, errors.New("no PEM encoded private key")
+		}
+		switch block.Type {
+		case "PRIVATE KEY":
+			key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+			if err != nil {
+				return nil, err
+			}
+			switch key.(type) {
+			case *rsa.PrivateKey, *ecdsa.PrivateKey:
+				return key, nil
+			}
+			return nil, fmt.Errorf("unsupported private key type %T", key)
+		case "RSA PRIVATE KEY":
+			return x509.ParsePKCS1PrivateKey(block.Bytes)
+		case "EC PRIVATE KEY":
+			return x509.ParseECPrivateKey(block.Bytes)
+		}
+	}
+}
+
+// wrapKey encrypts a data key with a public key. RSA keys use OAEP
+// with SHA-1, as the Java client does. ECDSA keys use ECIES: the data
+// key is encrypted with AES-GCM using the SHA-256 of the secret shared
+// with an ephemeral key, which is prepended uncompressed along with
+// the nonce.
+func wrapKey(pub interface{}, dataKey []byte) ([]byte, error) {
+	switch pub := pub.(type) {
+	case *rsa.PublicKey:
+		return rsa.EncryptOAEP(sha1.New(), rand.Reader, pub, dataKey, nil)
+
+	case *ecdsa.PublicKey:
+		eph, err := ecdsa.GenerateKey(pub.Curve, rand.Reader)
+		if err != nil {
+			return nil, err
+		}
+		aead, err := newAEAD(sharedKey(pub.Curve, pub.X, pub.Y, eph.D.Bytes()))
+		if err != nil {
+			return nil, err
+		}
+		wrapped := elliptic.Marshal(pub.Curve, eph.X, eph.Y)
+		nonce := make([]byte, nonceSize)
+		if _, err := rand.Read(nonce); err != nil {
+			return nil, err
+		}
+		wrapped = append(wrapped, nonce...)
+		return aead.Seal(wrapped, nonce, dataKey, nil), nil
+
+	default:
+		return nil, fmt.Errorf("unsupported public key type %T", pub)
+	}
+}
+
+// unwrapKey decrypts a data key encrypted by wrapKey.
+func unwrapKey(priv interface{}, wrapped []byte) ([]byte, error) {
+	switch priv := priv.(type) {
+	case *rsa.PrivateKey:
+		return rsa.DecryptOAEP(sha1.New(), nil, priv, wrapped, nil)
+
+	case *ecdsa.PrivateKey:
+		pointSize := 1 + 2*((priv.Curve.Params().BitSize+7)/8)
+		if len(wrapped) < pointSize+nonceSize {
+			return nil, errors.New("encrypted data key is too short")
+		}
+		x, y := elliptic.Unmarshal(priv.Curve, wrapped[:pointSize])
+		if x == nil {
+			return nil, errors.New("invalid ephemeral public key")
+		}
+		aead, err := newAEAD(sharedKey(priv.Curve, x, y, priv.D.Bytes()))
+		if err != nil {
+			return nil, err
+		}
+		nonce := wrapped[pointSize : pointSize+nonceSize]
+		return aead.Open(nil, nonce, wrapped[pointSize+nonceSize:], nil)
+
+	default:
+		return nil, fmt.Errorf("unsupported private key type %T", priv)
+	}
+}
+
+// sharedKey returns the SHA-256 of the x coordinate of the
+// point (x, y) multiplied by the scalar k.
+func sharedKey(curve elliptic.Curve, x, y *big.Int, k []byte) []byte {
+	sx, _ := curve.ScalarMult(x, y, k)
+
+	shared := make([]byte, (curve.Params().BitSize+7)/8)
+	sx.FillBytes(shared)
+	sum := sha256.Sum256(shared)
+	return sum[:]
+}
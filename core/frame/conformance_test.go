@@ -0,0 +1,432 @@
+// Copyright 2018 Comcast Cable Communications Management, LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package frame_test
+
+import (
+	"bytes"
+	"compress/zlib"
+	"encoding/hex"
+	"encoding/json"
+	"flag"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/pepper-iot/pulsar-client-go/core/frame"
+	"github.com/pepper-iot/pulsar-client-go/core/msg"
+	"github.com/pepper-iot/pulsar-client-go/pkg/api"
+)
+
+var updateConformance = flag.Bool("update-conformance", false, "regenerate the generated frames of the conformance corpus")
+
+// conformanceDir holds the conformance corpus. Each <name>.frame file
+// holds a single frame, and <name>.json its expectation.
+const conformanceDir = "../../testdata/conformance"
+
+// conformanceExpect is what a frame of the conformance corpus is
+// expected to decode to.
+type conformanceExpect struct {
+	Type        string `json:"type"`
+	Compression string `json:"compression,omitempty"`
+
+	// Payloads of the messages, once decompressed and split into
+	// the messages of a batch. Unset for encrypted payloads.
+	Messages []string `json:"messages,omitempty"`
+	Keys     []string `json:"keys,omitempty"` // partition keys of the messages, if any
+
+	Chunk     *conformanceChunk `json:"chunk,omitempty"`
+	Encrypted bool              `json:"encrypted,omitempty"`
+}
+
+type conformanceChunk struct {
+	UUID      string `json:"uuid"`
+	ID        int32  `json:"id"`
+	Num       int32  `json:"num"`
+	TotalSize int32  `json:"total_size"`
+}
+
+// TestConformance_Captured decodes the frames captured from the Java
+// client, which may hold several frames each, and ensures they are
+// of the type in their name, and re-encode to the same bytes.
+func TestConformance_Captured(t *testing.T) {
+	inputs, err := filepath.Glob("../../testdata/frames/*.frame")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(inputs) == 0 {
+		t.Fatal("no captured frames")
+	}
+
+	for _, in := range inputs {
+		wire, err := ioutil.ReadFile(in)
+		if err != nil {
+			t.Fatal(err)
+		}
+		typ := strings.TrimSuffix(filepath.Base(in), ".frame")
+		typ = typ[strings.IndexByte(typ, '-')+1:]
+
+		for _, f := range decodeConformance(t, in, wire) {
+			if got := f.BaseCmd.GetType().String(); got != typ {
+				t.Errorf("%s: got frame of type %s; expected %s", in, got, typ)
+			}
+		}
+	}
+}
+
+// TestConformance_Corpus decodes the frames of the conformance corpus,
+// and checks them against their expectations.
+func TestConformance_Corpus(t *testing.T) {
+	if *updateConformance {
+		writeConformanceCorpus(t)
+	}
+
+	inputs, err := filepath.Glob(filepath.Join(conformanceDir, "*.frame"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(inputs) == 0 {
+		t.Fatal("empty conformance corpus")
+	}
+
+	for _, in := range inputs {
+		in := in
+		t.Run(filepath.Base(in), func(t *testing.T) {
+			wire, err := ioutil.ReadFile(in)
+			if err != nil {
+				t.Fatal(err)
+			}
+			b, err := ioutil.ReadFile(strings.TrimSuffix(in, ".frame") + ".json")
+			if err != nil {
+				t.Fatal(err)
+			}
+			var expect conformanceExpect
+			if err := json.Unmarshal(b, &expect); err != nil {
+				t.Fatal(err)
+			}
+
+			frames := decodeConformance(t, in, wire)
+			if len(frames) != 1 {
+				t.Fatalf("decoded %d frames; expected 1", len(frames))
+			}
+			checkConformance(t, frames[0], expect)
+		})
+	}
+}
+
+// decodeConformance decodes the frames of wire, ensuring that Frame.Decode and
+// Decoder agree, and that the frames re-encode to the same bytes.
+func decodeConformance(t *testing.T, name string, wire []byte) []frame.Frame {
+	var frames []frame.Frame
+	r := bytes.NewReader(wire)
+	d := frame.NewDecoder(nil)
+	for offset := 0; r.Len() > 0; offset = len(wire) - r.Len() {
+		var f frame.Frame
+		if err := f.Decode(r); err != nil {
+			t.Fatalf("%s: Decode() err = %v at offset %d", name, err, offset)
+		}
+		encoded := wire[offset : len(wire)-r.Len()]
+
+		var reused frame.Frame
+		if err := d.Decode(bytes.NewReader(encoded), &reused); err != nil {
+			t.Fatalf("%s: Decoder.Decode() err = %v at offset %d", name, err, offset)
+		}
+		if !reused.Equal(f) {
+			t.Fatalf("%s: Decoder decoded %+v; expected %+v", name, reused, f)
+		}
+
+		var out bytes.Buffer
+		if err := f.Encode(&out); err != nil {
+			t.Fatalf("%s: Encode() err = %v", name, err)
+		}
+		if !bytes.Equal(out.Bytes(), encoded) {
+			t.Fatalf("%s: re-encoded frame:\n%s\nexpected:\n%s", name, hex.Dump(out.Bytes()), hex.Dump(encoded))
+		}
+		frames = append(frames, f)
+	}
+	return frames
+}
+
+// checkConformance checks that f matches expect.
+func checkConformance(t *testing.T, f frame.Frame, expect conformanceExpect) {
+	if got := f.BaseCmd.GetType().String(); got != expect.Type {
+		t.Fatalf("got frame of type %s; expected %s", got, expect.Type)
+	}
+	if f.Metadata == nil {
+		if expect.Messages != nil || expect.Chunk != nil || expect.Encrypted {
+			t.Fatal("frame has no metadata; expected a message")
+		}
+		return
+	}
+	meta := f.Metadata
+
+	if got, expected := meta.GetCompression().String(), expect.Compression; expected != "" && got != expected {
+		t.Fatalf("compression = %s; expected %s", got, expected)
+	}
+
+	if expect.Chunk != nil {
+		got := conformanceChunk{
+			UUID:      meta.GetUuid(),
+			ID:        meta.GetChunkId(),
+			Num:       meta.GetNumChunksFromMsg(),
+			TotalSize: meta.GetTotalChunkMsgSize(),
+		}
+		if got != *expect.Chunk {
+			t.Fatalf("chunk = %+v; expected %+v", got, *expect.Chunk)
+		}
+	}
+
+	m := msg.Message{Meta: meta, Payload: f.Payload}
+	if got := m.IsEncrypted(); got != expect.Encrypted {
+		t.Fatalf("IsEncrypted() = %v; expected %v", got, expect.Encrypted)
+	}
+	if expect.Encrypted {
+		if _, err := msg.DecodeBatchMessage(&m); meta.NumMessagesInBatch != nil && err != msg.ErrEncrypted {
+			t.Fatalf("DecodeBatchMessage() err = %v; expected %v", err, msg.ErrEncrypted)
+		}
+		return
+	}
+
+	payload := f.Payload
+	switch meta.GetCompression() {
+	case api.CompressionType_NONE:
+	case api.CompressionType_ZLIB:
+		zr, err := zlib.NewReader(bytes.NewReader(payload))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if payload, err = ioutil.ReadAll(zr); err != nil {
+			t.Fatal(err)
+		}
+		if got, expected := len(payload), int(meta.GetUncompressedSize()); got != expected {
+			t.Fatalf("uncompressed %d bytes; expected uncompressed_size %d", got, expected)
+		}
+	default:
+		t.Fatalf("unsupported compression %s", meta.GetCompression())
+	}
+
+	var got, keys []string
+	if meta.NumMessagesInBatch == nil {
+		got = []string{string(payload)}
+		keys = []string{meta.GetPartitionKey()}
+	} else {
+		batch, err := msg.DecodeBatchPayload(payload, meta.GetNumMessagesInBatch())
+		if err != nil {
+			t.Fatalf("DecodeBatchPayload() err = %v", err)
+		}
+		for _, sm := range batch {
+			got = append(got, string(sm.SinglePayload))
+			keys = append(keys, sm.SingleMeta.GetPartitionKey())
+		}
+	}
+	if !equalStrings(got, expect.Messages) {
+		t.Fatalf("messages = %q; expected %q", got, expect.Messages)
+	}
+	if expect.Keys != nil && !equalStrings(keys, expect.Keys) {
+		t.Fatalf("keys = %q; expected %q", keys, expect.Keys)
+	}
+}
+
+func equalStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// conformanceFrame is a generated frame of the corpus.
+type conformanceFrame struct {
+	name   string
+	frame  frame.Frame
+	expect conformanceExpect
+}
+
+// generatedConformance returns the generated frames of the corpus. They
+// follow the layout of the frames of the Java client for the features
+// (batching, compression, chunking, encryption) that weren't captured yet.
+func generatedConformance(t *testing.T) []conformanceFrame {
+	send := func(seq, highest uint64, num int32) *api.BaseCommand {
+		cmd := &api.BaseCommand{
+			Type: api.BaseCommand_SEND.Enum(),
+			Send: &api.CommandSend{
+				ProducerId: proto.Uint64(0),
+				SequenceId: proto.Uint64(seq),
+			},
+		}
+		if num > 1 {
+			cmd.Send.NumMessages = proto.Int32(num)
+			cmd.Send.HighestSequenceId = proto.Uint64(highest)
+		}
+		return cmd
+	}
+	message := func(entry uint64) *api.BaseCommand {
+		return &api.BaseCommand{
+			Type: api.BaseCommand_MESSAGE.Enum(),
+			Message: &api.CommandMessage{
+				ConsumerId: proto.Uint64(0),
+				MessageId: &api.MessageIdData{
+					LedgerId: proto.Uint64(17),
+					EntryId:  proto.Uint64(entry),
+				},
+			},
+		}
+	}
+	metadata := func(seq uint64) *api.MessageMetadata {
+		return &api.MessageMetadata{
+			ProducerName: proto.String("standalone-0-7"),
+			SequenceId:   proto.Uint64(seq),
+			PublishTime:  proto.Uint64(1664582400000 + seq),
+		}
+	}
+
+	// a batch of 3 messages, the second of which has a key and a property
+	batch := func() []byte {
+		var b []byte
+		var err error
+		for i, payload := range []string{"batch-0", "batch-1", "batch-2"} {
+			single := &api.SingleMessageMetadata{SequenceId: proto.Uint64(uint64(10 + i))}
+			if i == 1 {
+				single.PartitionKey = proto.String("device-1")
+				single.Properties = []*api.KeyValue{{Key: proto.String("source"), Value: proto.String("java")}}
+			}
+			if b, err = msg.AppendBatchPayload(b, single, []byte(payload)); err != nil {
+				t.Fatal(err)
+			}
+		}
+		return b
+	}
+	zlibbed := func(b []byte) []byte {
+		var out bytes.Buffer
+		zw := zlib.NewWriter(&out)
+		if _, err := zw.Write(b); err != nil {
+			t.Fatal(err)
+		}
+		if err := zw.Close(); err != nil {
+			t.Fatal(err)
+		}
+		return out.Bytes()
+	}
+
+	batched := metadata(10)
+	batched.NumMessagesInBatch = proto.Int32(3)
+	batched.HighestSequenceId = proto.Uint64(12)
+
+	compressed := metadata(10)
+	compressed.NumMessagesInBatch = proto.Int32(3)
+	compressed.HighestSequenceId = proto.Uint64(12)
+	compressed.Compression = api.CompressionType_ZLIB.Enum()
+	compressed.UncompressedSize = proto.Uint32(uint32(len(batch())))
+
+	single := metadata(20)
+	single.PartitionKey = proto.String("device-2")
+	single.Compression = api.CompressionType_ZLIB.Enum()
+	single.UncompressedSize = proto.Uint32(uint32(len("single")))
+
+	// a 10 bytes message, split in chunks of 6 and 4 bytes
+	chunk := func(id int32) *api.MessageMetadata {
+		m := metadata(30)
+		m.Uuid = proto.String("standalone-0-7-30")
+		m.ChunkId = proto.Int32(id)
+		m.NumChunksFromMsg = proto.Int32(2)
+		m.TotalChunkMsgSize = proto.Int32(10)
+		return m
+	}
+
+	encrypted := metadata(40)
+	encrypted.EncryptionKeys = []*api.EncryptionKeys{{
+		Key:   proto.String("myapp.key"),
+		Value: bytes.Repeat([]byte{0xa5}, 256),
+	}}
+	encrypted.EncryptionParam = bytes.Repeat([]byte{0x3c}, 12)
+
+	batchMessages := []string{"batch-0", "batch-1", "batch-2"}
+	batchKeys := []string{"", "device-1", ""}
+
+	return []conformanceFrame{
+		{
+			name:   "send-batched",
+			frame:  frame.Frame{BaseCmd: send(10, 12, 3), Metadata: batched, Payload: batch()},
+			expect: conformanceExpect{Type: "SEND", Compression: "NONE", Messages: batchMessages, Keys: batchKeys},
+		},
+		{
+			name:   "send-batched-zlib",
+			frame:  frame.Frame{BaseCmd: send(10, 12, 3), Metadata: compressed, Payload: zlibbed(batch())},
+			expect: conformanceExpect{Type: "SEND", Compression: "ZLIB", Messages: batchMessages, Keys: batchKeys},
+		},
+		{
+			name:   "send-zlib",
+			frame:  frame.Frame{BaseCmd: send(20, 0, 1), Metadata: single, Payload: zlibbed([]byte("single"))},
+			expect: conformanceExpect{Type: "SEND", Compression: "ZLIB", Messages: []string{"single"}, Keys: []string{"device-2"}},
+		},
+		{
+			name:  "send-chunk-0",
+			frame: frame.Frame{BaseCmd: send(30, 0, 1), Metadata: chunk(0), Payload: []byte("chunke")},
+			expect: conformanceExpect{Type: "SEND", Messages: []string{"chunke"},
+				Chunk: &conformanceChunk{UUID: "standalone-0-7-30", ID: 0, Num: 2, TotalSize: 10}},
+		},
+		{
+			name:  "send-chunk-1",
+			frame: frame.Frame{BaseCmd: send(30, 0, 1), Metadata: chunk(1), Payload: []byte("d-10")},
+			expect: conformanceExpect{Type: "SEND", Messages: []string{"d-10"},
+				Chunk: &conformanceChunk{UUID: "standalone-0-7-30", ID: 1, Num: 2, TotalSize: 10}},
+		},
+		{
+			name:   "send-encrypted",
+			frame:  frame.Frame{BaseCmd: send(40, 0, 1), Metadata: encrypted, Payload: bytes.Repeat([]byte{0x5a}, 32)},
+			expect: conformanceExpect{Type: "SEND", Encrypted: true},
+		},
+		{
+			name:   "message-batched-zlib",
+			frame:  frame.Frame{BaseCmd: message(5), Metadata: compressed, Payload: zlibbed(batch())},
+			expect: conformanceExpect{Type: "MESSAGE", Compression: "ZLIB", Messages: batchMessages, Keys: batchKeys},
+		},
+		{
+			name:   "message-encrypted",
+			frame:  frame.Frame{BaseCmd: message(6), Metadata: encrypted, Payload: bytes.Repeat([]byte{0x5a}, 32)},
+			expect: conformanceExpect{Type: "MESSAGE", Encrypted: true},
+		},
+	}
+}
+
+// writeConformanceCorpus (re)writes the generated frames of the corpus
+// and their expectations.
+func writeConformanceCorpus(t *testing.T) {
+	if err := os.MkdirAll(conformanceDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	for _, c := range generatedConformance(t) {
+		var b bytes.Buffer
+		if err := c.frame.Encode(&b); err != nil {
+			t.Fatalf("%s: %v", c.name, err)
+		}
+		if err := ioutil.WriteFile(filepath.Join(conformanceDir, c.name+".frame"), b.Bytes(), 0644); err != nil {
+			t.Fatal(err)
+		}
+
+		expect, err := json.MarshalIndent(c.expect, "", "  ")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(filepath.Join(conformanceDir, c.name+".json"), append(expect, '\n'), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,182 @@
+// Copyright 2018 Comcast Cable Communications Management, LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package frame
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/pepper-iot/pulsar-client-go/pkg/api"
+)
+
+// errTruncatedFrame is returned by Decoder.Decode when a size
+// within a frame exceeds the frame itself.
+var errTruncatedFrame = errors.New("frame is truncated")
+
+// Decoder decodes frames like Frame.Decode, but reuses its buffers and
+// the BaseCommand and MessageMetadata structs from one frame to the
+// next, so that decoding a frame doesn't allocate beyond the fields
+// nested in the protobuf messages.
+//
+// The BaseCmd, Metadata, BrokerEntryMetadata and Payload of decoded
+// Frames alias the Decoder's buffers, and are only valid until the next
+// call to Decode. Callers that retain any of them must copy them, e.g.
+// with proto.Clone. A Decoder is not safe for concurrent use.
+type Decoder struct {
+	Checksum ChecksumPolicy // how checksums are verified
+
+	buf []byte
+	hdr [4]byte
+
+	cmd         api.BaseCommand
+	meta        api.MessageMetadata
+	brokerEntry api.BrokerEntryMetadata
+}
+
+// NewDecoder returns a Decoder reading frames into buf, e.g. a buffer
+// obtained from a pool. The buffer is grown when a frame doesn't fit.
+func NewDecoder(buf []byte) *Decoder {
+	return &Decoder{buf: buf[:0]}
+}
+
+// Decode reads a frame from r into f.
+func (d *Decoder) Decode(r io.Reader, f *Frame) error {
+	// Read totalSize
+	if _, err := io.ReadFull(r, d.hdr[:]); err != nil {
+		return err
+	}
+	totalSize := binary.BigEndian.Uint32(d.hdr[:])
+	if frameSize := int(totalSize) + 4; frameSize > MaxFrameSize {
+		return fmt.Errorf("frame size (%d) cannot be greater than max frame size (%d)", frameSize, MaxFrameSize)
+	}
+
+	// Read the rest of the frame at once
+	if cap(d.buf) < int(totalSize) {
+		d.buf = make([]byte, totalSize)
+	}
+	buf := d.buf[:totalSize]
+	if _, err := io.ReadFull(r, buf); err != nil {
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		return err
+	}
+
+	*f = Frame{
+		BaseCmd:             &d.cmd,
+		BrokerEntryMetadata: &d.brokerEntry,
+		Metadata:            &d.meta,
+	}
+	return unmarshalFrame(buf, f, d.Checksum)
+}
+
+// unmarshalFrame decodes the frame in buf, which follows its totalSize,
+// into f. The BaseCmd, BrokerEntryMetadata and Metadata of f are decoded
+// into if set, and allocated otherwise; those the frame doesn't contain
+// are set to nil. The Payload of f aliases buf. A *ChecksumError is
+// returned once f has been decoded, like Frame.Decode does.
+func unmarshalFrame(buf []byte, f *Frame, policy ChecksumPolicy) error {
+	brokerEntry, meta := f.BrokerEntryMetadata, f.Metadata
+	f.BrokerEntryMetadata, f.Metadata, f.Payload = nil, nil, nil
+
+	// Read cmdSize and the BaseCommand
+	cmdBuf, buf, err := sized(buf)
+	if err != nil {
+		return err
+	}
+	if f.BaseCmd == nil {
+		f.BaseCmd = NewBaseCommand()
+	}
+	if err = proto.Unmarshal(cmdBuf, f.BaseCmd); err != nil {
+		return err
+	}
+	// "simple" command
+	if len(buf) == 0 {
+		return nil
+	}
+	if len(buf) < 4 {
+		return errTruncatedFrame
+	}
+
+	// Optional broker entry metadata
+	if buf[0] == brokerEntryMagicNumber[0] && buf[1] == brokerEntryMagicNumber[1] {
+		var brokerEntryBuf []byte
+		if brokerEntryBuf, buf, err = sized(buf[2:]); err != nil {
+			return err
+		}
+		if brokerEntry == nil {
+			brokerEntry = new(api.BrokerEntryMetadata)
+		}
+		if err = proto.Unmarshal(brokerEntryBuf, brokerEntry); err != nil {
+			return err
+		}
+		f.BrokerEntryMetadata = brokerEntry
+		if len(buf) < 4 {
+			return errTruncatedFrame
+		}
+	}
+
+	// Optional checksum of everything that follows it, reported
+	// once the frame is decoded
+	var checksumErr error
+	if buf[0] == magicNumber[0] && buf[1] == magicNumber[1] {
+		if len(buf) < 6 {
+			return errTruncatedFrame
+		}
+		expected := binary.BigEndian.Uint32(buf[2:6])
+		buf = buf[6:]
+		if policy != ChecksumSkip {
+			if computed := crc32.Checksum(buf, crc32cTbl); computed != expected {
+				checksumErr = &ChecksumError{Computed: computed, Expected: expected}
+			}
+		}
+	}
+
+	// Read metadataSize and the metadata
+	metaBuf, buf, err := sized(buf)
+	if err != nil {
+		return err
+	}
+	if meta == nil {
+		meta = NewMessageMetadata()
+	}
+	if err = proto.Unmarshal(metaBuf, meta); err != nil {
+		return err
+	}
+	f.Metadata = meta
+
+	// Anything left in the frame is the payload
+	if len(buf) > 0 {
+		f.Payload = buf
+	}
+	return checksumErr
+}
+
+// sized splits b into the section prefixed by its
+// 4-byte size, and the bytes following it.
+func sized(b []byte) (section, rest []byte, err error) {
+	if len(b) < 4 {
+		return nil, nil, errTruncatedFrame
+	}
+	size := binary.BigEndian.Uint32(b)
+	b = b[4:]
+	if uint64(size) > uint64(len(b)) {
+		return nil, nil, errTruncatedFrame
+	}
+	return b[:size], b[size:], nil
+}
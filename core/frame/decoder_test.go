@@ -0,0 +1,161 @@
+// Copyright 2018 Comcast Cable Communications Management, LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package frame
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/pepper-iot/pulsar-client-go/pkg/api"
+)
+
+func TestDecoder_Captured(t *testing.T) {
+	// Decode every captured frame from a single stream with one
+	// Decoder, and ensure the result matches Frame.Decode.
+	inputs, err := filepath.Glob("../../testdata/frames/*.frame")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var wire []byte
+	for _, f := range inputs {
+		b, err := ioutil.ReadFile(f)
+		if err != nil {
+			t.Fatal(err)
+		}
+		wire = append(wire, b...)
+	}
+
+	// Some captures hold several frames
+	expected := bytes.NewReader(wire)
+	r := bytes.NewReader(wire)
+	d := NewDecoder(nil)
+	for i := 0; expected.Len() > 0; i++ {
+		var want Frame
+		if err := want.Decode(expected); err != nil {
+			t.Fatalf("frame %d: Frame.Decode() err = %v", i, err)
+		}
+		var got Frame
+		if err := d.Decode(r, &got); err != nil {
+			t.Fatalf("frame %d: Decoder.Decode() err = %v", i, err)
+		}
+		if !got.Equal(want) {
+			t.Fatalf("frame %d: got frame %+v; expected %+v", i, got, want)
+		}
+	}
+
+	var f Frame
+	if err := d.Decode(r, &f); err != io.EOF {
+		t.Fatalf("Decoder.Decode() err = %v; expected %v", err, io.EOF)
+	}
+}
+
+func TestDecoder_Reuse(t *testing.T) {
+	withPayload := benchFrame(16)
+	withPayload.BrokerEntryMetadata = &api.BrokerEntryMetadata{
+		Index: proto.Uint64(7),
+	}
+	simple := Frame{
+		BaseCmd: &api.BaseCommand{
+			Type: api.BaseCommand_PING.Enum(),
+			Ping: &api.CommandPing{},
+		},
+	}
+
+	var wire bytes.Buffer
+	for _, f := range []Frame{withPayload, simple, withPayload} {
+		if err := f.Encode(&wire); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	d := NewDecoder(make([]byte, 0, 8))
+	for i, expected := range []Frame{withPayload, simple, withPayload} {
+		var f Frame
+		if err := d.Decode(&wire, &f); err != nil {
+			t.Fatal(err)
+		}
+		// fields of the previous frame must not leak into this one
+		if !f.Equal(expected) {
+			t.Fatalf("frame %d: got %+v; expected %+v", i, f, expected)
+		}
+	}
+}
+
+func TestDecoder_UnexpectedEOF(t *testing.T) {
+	// truncated last byte
+	wire := `
+00000000  00 00 00 27 00 00 00 0d  08 09 4a 09 08 2a 12 05  |...'......J..*..|
+00000010  08 02 10 d2 02 00 00 00  0d 0a 02 67 6f 10 00 18  |...........go...|
+00000020  a8 f9 d2 bb 84 2c 68 69  3a 20                    |.....,hi: 0|
+`
+	var f Frame
+	err := NewDecoder(nil).Decode(bytes.NewReader(hexUndump(wire)), &f)
+	if err != io.ErrUnexpectedEOF {
+		t.Fatalf("Decoder.Decode() err = %v; expected %v", err, io.ErrUnexpectedEOF)
+	}
+}
+
+func TestDecoder_MaxSize(t *testing.T) {
+	// large command size value
+	wire := `
+00000000  FF FF FF FF 00 00 00 0d  08 09 4a 09 08 2a 12 05  |...'......J..*..|
+`
+	var f Frame
+	err := NewDecoder(nil).Decode(bytes.NewReader(hexUndump(wire)), &f)
+	if err == nil {
+		t.Fatalf("Decoder.Decode() err = %v; non-nil expected", err)
+	}
+	t.Logf("Decoder.Decode() = %v", err)
+}
+
+func TestDecoder_Truncated(t *testing.T) {
+	// command size exceeds the frame
+	wire := `
+00000000  00 00 00 08 00 00 00 0d  08 09 4a 09              |..........J.|
+`
+	var f Frame
+	err := NewDecoder(nil).Decode(bytes.NewReader(hexUndump(wire)), &f)
+	if err != errTruncatedFrame {
+		t.Fatalf("Decoder.Decode() err = %v; expected %v", err, errTruncatedFrame)
+	}
+}
+
+func BenchmarkDecoder(b *testing.B) {
+	for _, size := range []int{64, 1024, 64 * 1024} {
+		f := benchFrame(size)
+		var wire bytes.Buffer
+		if err := f.Encode(&wire); err != nil {
+			b.Fatal(err)
+		}
+		b.Run(fmt.Sprintf("%dB", size), func(b *testing.B) {
+			r := bytes.NewReader(wire.Bytes())
+			d := NewDecoder(nil)
+			var decoded Frame
+			b.SetBytes(int64(size))
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				r.Reset(wire.Bytes())
+				if err := d.Decode(r, &decoded); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
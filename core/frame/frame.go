@@ -14,6 +14,7 @@
 package frame
 
 import (
+	"bufio"
 	"bytes"
 	"encoding/binary"
 	"fmt"
@@ -35,6 +36,12 @@ const MaxFrameSize = 5 * 1024 * 1024 // 5mb
 // https://pulsar.incubator.apache.org/docs/latest/project/BinaryProtocol/#Payloadcommands-kbk8xf
 var magicNumber = [...]byte{0x0e, 0x01}
 
+// brokerEntryMagicNumber is a 2-byte byte array (0x0e02)
+// identifying an optional BrokerEntryMetadata section, which
+// brokers prepend to messages when exposingBrokerEntryMetadataToClientEnabled
+// is set. It isn't covered by the checksum.
+var brokerEntryMagicNumber = [...]byte{0x0e, 0x02}
+
 // Frame represents a pulsar message frame.
 // It can be used to encode and decode messages
 // to and from the Pulsar binary wire format.
@@ -67,6 +74,14 @@ var magicNumber = [...]byte{0x0e, 0x01}
 //	 |                 | 4 bytes are checksum | bytes              |                       |                             | or encrypted (see metadata) |
 //	 +-------------------------------------------------------------------------------------------------------------------------------------------------+
 //
+// Brokers may insert a broker entry metadata section between the "Simple"
+// fields and the magicNumber of MESSAGE frames:
+//
+//	 +---------------------------------------------------------------------------------------+
+//	 | brokerEntryMagicNumber (0x0e02) | brokerEntryMetadataSize | brokerEntryMetadata     |
+//	 |            2 bytes              |         4 bytes         | var length              |
+//	 +---------------------------------------------------------------------------------------+
+//
 type Frame struct {
 	// BaseCmd is a required field
 	BaseCmd *api.BaseCommand
@@ -77,6 +92,14 @@ type Frame struct {
 	// if there's only the BaseCmd.
 	Metadata *api.MessageMetadata
 	Payload  []byte
+
+	// BrokerEntryMetadata is optionally set
+	// by the broker on MESSAGE frames.
+	BrokerEntryMetadata *api.BrokerEntryMetadata
+
+	// PooledPayload is set if the Payload was taken from the payload
+	// pool by DecodePooled, and can be returned to it with Release.
+	PooledPayload bool
 }
 
 // Equal returns true if the other Frame is
@@ -90,12 +113,137 @@ func (f *Frame) Equal(other Frame) bool {
 		return false
 	}
 
+	if !proto.Equal(f.BrokerEntryMetadata, other.BrokerEntryMetadata) {
+		return false
+	}
+
 	return bytes.Equal(f.Payload, other.Payload)
 }
 
 // Decode the pulsar binary protocol from r into
 // the receiver frame. Returns any errors encountered.
+//
+// If r is a *bufio.Reader, frames that fit in its buffer are decoded
+// in place, without reading each of their sections separately.
 func (f *Frame) Decode(r io.Reader) error {
+	return f.DecodeWithPolicy(r, ChecksumVerify)
+}
+
+// DecodeWithPolicy is like Decode, but verifies the
+// checksum of the frame according to policy.
+func (f *Frame) DecodeWithPolicy(r io.Reader, policy ChecksumPolicy) error {
+	return f.decode(r, policy, false)
+}
+
+// DecodePooled is like DecodeWithPolicy, but takes the payload buffer
+// from the pool of NewPayload and sets PooledPayload. The payload can
+// be returned to the pool with Release or ReleasePayload once it is no
+// longer used.
+func (f *Frame) DecodePooled(r io.Reader, policy ChecksumPolicy) error {
+	return f.decode(r, policy, true)
+}
+
+// decode implements DecodeWithPolicy and DecodePooled.
+func (f *Frame) decode(r io.Reader, policy ChecksumPolicy, pooled bool) error {
+	if br, ok := r.(*bufio.Reader); ok {
+		if decoded, err := f.decodePeeked(br, policy, pooled); decoded {
+			return err
+		}
+	}
+
+	p, err := f.decodeStream(r, policy)
+	if err != nil {
+		return err
+	}
+
+	// Anything left in the frame is considered
+	// the payload and can be any sequence of bytes.
+	if n := p.Len(); n > 0 {
+		// guard against allocating large buffer
+		if n > MaxFrameSize {
+			return fmt.Errorf("frame payload size (%d) cannot be greater than max frame size (%d)", n, MaxFrameSize)
+		}
+		f.Payload, f.PooledPayload = newPayload(int(n), pooled), pooled
+		if _, err = io.ReadFull(p, f.Payload); err != nil {
+			return err
+		}
+	}
+
+	return p.Close()
+}
+
+// decodePeeked decodes a frame from the bytes buffered by r, and
+// returns false without consuming anything if the frame can't be
+// decoded that way. That's the case if it doesn't fit in the buffer,
+// or is invalid, so that decodeStream reads and reports it instead.
+// Errors reading from r are returned as is.
+func (f *Frame) decodePeeked(r *bufio.Reader, policy ChecksumPolicy, pooled bool) (bool, error) {
+	b, err := r.Peek(4)
+	if err != nil {
+		if err == io.EOF && len(b) > 0 {
+			err = io.ErrUnexpectedEOF
+		}
+		return true, err
+	}
+	frameSize := int(binary.BigEndian.Uint32(b)) + 4
+	if frameSize > MaxFrameSize {
+		return false, nil
+	}
+	if b, err = r.Peek(frameSize); err != nil {
+		if err == bufio.ErrBufferFull {
+			return false, nil
+		}
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		return true, err
+	}
+
+	var decoded Frame
+	if err = unmarshalFrame(b[4:], &decoded, policy); err != nil {
+		if _, ok := err.(*ChecksumError); !ok {
+			decoded.Release()
+			return false, nil
+		}
+	}
+	// the payload aliases the buffer of r
+	if decoded.Payload != nil {
+		payload := newPayload(len(decoded.Payload), pooled)
+		copy(payload, decoded.Payload)
+		decoded.Payload, decoded.PooledPayload = payload, pooled
+	}
+	*f = decoded
+	if _, discardErr := r.Discard(frameSize); discardErr != nil {
+		return true, discardErr
+	}
+	return true, err
+}
+
+// newPayload returns a payload buffer of length n,
+// taken from the pool if pooled is true.
+func newPayload(n int, pooled bool) []byte {
+	if pooled {
+		return NewPayload(n)
+	}
+	return make([]byte, n)
+}
+
+// DecodeStream decodes the pulsar binary protocol from r into the
+// receiver frame like Decode, except for the payload, which is left
+// in r and returned as a PayloadReader instead of being read into
+// Payload. This lets large payloads be streamed, e.g. to a file,
+// without holding them in memory.
+//
+// The PayloadReader must be read to the end or closed before the
+// next frame is read from r. Its checksum, if any, is verified once
+// the payload has been read.
+func (f *Frame) DecodeStream(r io.Reader) (*PayloadReader, error) {
+	return f.decodeStream(r, ChecksumVerify)
+}
+
+// decodeStream implements DecodeStream, verifying
+// the checksum of the frame according to policy.
+func (f *Frame) decodeStream(r io.Reader, policy ChecksumPolicy) (*PayloadReader, error) {
 	var err error
 
 	// reusable buffer for 4-byte uint32s
@@ -105,7 +253,7 @@ func (f *Frame) Decode(r io.Reader) error {
 	// totalSize: The size of the frame,
 	// counting everything that comes after it (in bytes)
 	if _, err = io.ReadFull(r, buf32); err != nil {
-		return err
+		return nil, err
 	}
 	totalSize := binary.BigEndian.Uint32(buf32)
 
@@ -114,7 +262,7 @@ func (f *Frame) Decode(r io.Reader) error {
 	frameSize := int(totalSize) + 4
 	// ensure reasonable frameSize
 	if frameSize > MaxFrameSize {
-		return fmt.Errorf("frame size (%d) cannot be greater than max frame size (%d)", frameSize, MaxFrameSize)
+		return nil, fmt.Errorf("frame size (%d) cannot be greater than max frame size (%d)", frameSize, MaxFrameSize)
 	}
 
 	// Wrap our reader so that we can only read
@@ -126,33 +274,35 @@ func (f *Frame) Decode(r io.Reader) error {
 
 	// Read cmdSize
 	if _, err = io.ReadFull(lr, buf32); err != nil {
-		return err
+		return nil, err
 	}
 	cmdSize := binary.BigEndian.Uint32(buf32)
 	// guard against allocating large buffer
 	if cmdSize > MaxFrameSize {
-		return fmt.Errorf("frame command size (%d) cannot b greater than max frame size (%d)", cmdSize, MaxFrameSize)
+		return nil, fmt.Errorf("frame command size (%d) cannot b greater than max frame size (%d)", cmdSize, MaxFrameSize)
 	}
 
 	// Read protobuf encoded BaseCommand
 	cmdBuf := make([]byte, cmdSize)
 	if _, err = io.ReadFull(lr, cmdBuf); err != nil {
-		return err
+		return nil, err
 	}
-	f.BaseCmd = new(api.BaseCommand)
+	f.BaseCmd = NewBaseCommand()
 	if err = proto.Unmarshal(cmdBuf, f.BaseCmd); err != nil {
-		return err
+		return nil, err
 	}
 
-	// There are 3 possibilities for the following fields:
+	// There are 4 possibilities for the following fields:
 	//  - EOF: If so, this is a "simple" command. No more parsing required.
+	//  - 2-byte broker entry magic number: Indicates the following 4 bytes
+	//    are the size of the broker entry metadata
 	//  - 2-byte magic number: Indicates the following 4 bytes are a checksum
 	//  - 4-byte metadata size
 
 	// The message may optionally stop here. If so,
 	// this is a "simple" command.
 	if lr.N <= 0 {
-		return nil
+		return &PayloadReader{lr: lr}, nil
 	}
 
 	// Optionally, the next 2 bytes may be the magicNumber. If
@@ -160,7 +310,38 @@ func (f *Frame) Decode(r io.Reader) error {
 	// If not, the following 2 bytes (plus the 2 bytes already read),
 	// are the metadataSize, which is why a 4 byte buffer is used.
 	if _, err = io.ReadFull(lr, buf32); err != nil {
-		return err
+		return nil, err
+	}
+
+	// Check for brokerEntryMagicNumber which
+	// indicates broker entry metadata
+	if brokerEntryMagicNumber[0] == buf32[0] && brokerEntryMagicNumber[1] == buf32[1] {
+		// We already read the 2-byte magic number and
+		// the initial 2 bytes of the size
+		sizeBuf := []byte{buf32[2], buf32[3], 0, 0}
+		if _, err = io.ReadFull(lr, sizeBuf[2:]); err != nil {
+			return nil, err
+		}
+		brokerEntrySize := binary.BigEndian.Uint32(sizeBuf)
+		// guard against allocating large buffer
+		if brokerEntrySize > MaxFrameSize {
+			return nil, fmt.Errorf("frame broker entry metadata size (%d) cannot be greater than max frame size (%d)", brokerEntrySize, MaxFrameSize)
+		}
+
+		brokerEntryBuf := make([]byte, brokerEntrySize)
+		if _, err = io.ReadFull(lr, brokerEntryBuf); err != nil {
+			return nil, err
+		}
+		f.BrokerEntryMetadata = new(api.BrokerEntryMetadata)
+		if err = proto.Unmarshal(brokerEntryBuf, f.BrokerEntryMetadata); err != nil {
+			return nil, err
+		}
+
+		// Fill buffer with what follows, which is what it would
+		// already contain if there were no broker entry metadata
+		if _, err = io.ReadFull(lr, buf32); err != nil {
+			return nil, err
+		}
 	}
 
 	// Check for magicNumber which indicates a checksum
@@ -176,17 +357,19 @@ func (f *Frame) Decode(r io.Reader) error {
 
 		// Read the remaining 2 bytes of the checksum
 		if _, err = io.ReadFull(lr, expectedChksum[2:]); err != nil {
-			return err
+			return nil, err
 		}
 
 		// Use a tee reader to compute the checksum
 		// of everything consumed after this point
-		lr.R = io.TeeReader(lr.R, &chksum)
+		if policy != ChecksumSkip {
+			lr.R = io.TeeReader(lr.R, &chksum)
+		}
 
 		// Fill buffer with metadata size, which is what it
 		// would already contain if there were no magic number / checksum
 		if _, err = io.ReadFull(lr, buf32); err != nil {
-			return err
+			return nil, err
 		}
 	}
 
@@ -194,46 +377,49 @@ func (f *Frame) Decode(r io.Reader) error {
 	metadataSize := binary.BigEndian.Uint32(buf32)
 	// guard against allocating large buffer
 	if metadataSize > MaxFrameSize {
-		return fmt.Errorf("frame metadata size (%d) cannot b greater than max frame size (%d)", metadataSize, MaxFrameSize)
+		return nil, fmt.Errorf("frame metadata size (%d) cannot b greater than max frame size (%d)", metadataSize, MaxFrameSize)
 	}
 
 	// Read protobuf encoded metadata
 	metaBuf := make([]byte, metadataSize)
 	if _, err = io.ReadFull(lr, metaBuf); err != nil {
-		return err
+		return nil, err
 	}
-	f.Metadata = new(api.MessageMetadata)
+	f.Metadata = NewMessageMetadata()
 	if err = proto.Unmarshal(metaBuf, f.Metadata); err != nil {
-		return err
-	}
-
-	// Anything left in the frame is considered
-	// the payload and can be any sequence of bytes.
-	if lr.N > 0 {
-		// guard against allocating large buffer
-		if lr.N > MaxFrameSize {
-			return fmt.Errorf("frame payload size (%d) cannot be greater than max frame size (%d)", lr.N, MaxFrameSize)
-		}
-		f.Payload = make([]byte, lr.N)
-		if _, err = io.ReadFull(lr, f.Payload); err != nil {
-			return err
-		}
+		return nil, err
 	}
 
-	if computed := chksum.compute(); !bytes.Equal(computed, expectedChksum) {
-		return fmt.Errorf("checksum mismatch: computed (0x%X) does not match given checksum (0x%X)", computed, expectedChksum)
+	p := &PayloadReader{lr: lr, expected: expectedChksum}
+	if policy != ChecksumSkip {
+		p.chksum = &chksum
 	}
-
-	return nil
+	return p, nil
 }
 
 // Encode writes the pulsar binary protocol encoded
 // frame into w.
 func (f *Frame) Encode(w io.Writer) error {
+	payload, err := f.EncodeHeader(w)
+	if err != nil || len(payload) == 0 {
+		return err
+	}
+
+	// write payload
+	_, err = w.Write(payload)
+	return err
+}
+
+// EncodeHeader writes the pulsar binary protocol encoded frame into
+// w like Encode, except for the payload. It returns the payload, which
+// the caller must write to w next, e.g. directly from f.Payload
+// rather than copying it into a buffer along with the header. The
+// returned payload is nil for "simple" commands.
+func (f *Frame) EncodeHeader(w io.Writer) (payload []byte, err error) {
 	// encode baseCommand
 	encodedBaseCmd, err := proto.Marshal(f.BaseCmd)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	cmdSize := uint32(len(encodedBaseCmd))
 
@@ -243,82 +429,104 @@ func (f *Frame) Encode(w io.Writer) error {
 	// no metadata nor payload
 	if f.Metadata != nil {
 		if encodedMetadata, err = proto.Marshal(f.Metadata); err != nil {
-			return err
+			return nil, err
 		}
 		metadataSize = uint32(len(encodedMetadata))
 	}
 
+	var encodedBrokerEntry []byte
+	if f.BrokerEntryMetadata != nil && metadataSize > 0 {
+		if encodedBrokerEntry, err = proto.Marshal(f.BrokerEntryMetadata); err != nil {
+			return nil, err
+		}
+	}
+
 	//
-	// | totalSize (4) | cmdSize (4) | cmd (...) | magic+checksum (6) | metadataSize (4) | metadata (...) | payload (...) |
+	// | totalSize (4) | cmdSize (4) | cmd (...) | [brokerEntryMagic+size (6) | brokerEntryMetadata (...)] | magic+checksum (6) | metadataSize (4) | metadata (...) | payload (...) |
 	//
 	totalSize := cmdSize + 4
 	if metadataSize > 0 {
 		totalSize += 6 + metadataSize + 4 + uint32(len(f.Payload))
 	}
+	if encodedBrokerEntry != nil {
+		totalSize += 6 + uint32(len(encodedBrokerEntry))
+	}
 
 	if frameSize := totalSize + 4; frameSize > MaxFrameSize {
-		return fmt.Errorf("encoded frame size (%d bytes) is larger than max allowed frame size (%d bytes)", frameSize, MaxFrameSize)
+		return nil, fmt.Errorf("encoded frame size (%d bytes) is larger than max allowed frame size (%d bytes)", frameSize, MaxFrameSize)
 	}
 
 	// write totalSize
 	if err = binary.Write(w, binary.BigEndian, totalSize); err != nil {
-		return err
+		return nil, err
 	}
 
 	// write cmdSize
 	if err = binary.Write(w, binary.BigEndian, cmdSize); err != nil {
-		return err
+		return nil, err
 	}
 
 	// write baseCommand
 	buf := bytes.NewReader(encodedBaseCmd)
 	if _, err = io.Copy(w, buf); err != nil {
-		return err
+		return nil, err
 	}
 
 	if metadataSize == 0 {
 		// this is a "simple" command
 		// (no metadata, payload)
-		return nil
+		return nil, nil
+	}
+
+	if encodedBrokerEntry != nil {
+		// write broker entry magic number, size and metadata
+		buf.Reset(brokerEntryMagicNumber[:])
+		if _, err = io.Copy(w, buf); err != nil {
+			return nil, err
+		}
+		if err = binary.Write(w, binary.BigEndian, uint32(len(encodedBrokerEntry))); err != nil {
+			return nil, err
+		}
+		buf.Reset(encodedBrokerEntry)
+		if _, err = io.Copy(w, buf); err != nil {
+			return nil, err
+		}
 	}
 
 	// write magic number to indicate that a checksum follows
 	buf.Reset(magicNumber[:])
 	if _, err = io.Copy(w, buf); err != nil {
-		return err
+		return nil, err
 	}
 
 	// build checksum
 	var chksum frameChecksum
 	if err = binary.Write(&chksum, binary.BigEndian, metadataSize); err != nil {
-		return err
+		return nil, err
 	}
 	if _, err = chksum.Write(encodedMetadata); err != nil {
-		return err
+		return nil, err
 	}
 	if _, err = chksum.Write(f.Payload); err != nil {
-		return err
+		return nil, err
 	}
 
 	// write checksum
 	buf.Reset(chksum.compute())
 	if _, err = io.Copy(w, buf); err != nil {
-		return err
+		return nil, err
 	}
 
 	// write metadataSize
 	if err = binary.Write(w, binary.BigEndian, metadataSize); err != nil {
-		return err
+		return nil, err
 	}
 
 	// write metadata
 	buf.Reset(encodedMetadata)
 	if _, err = io.Copy(w, buf); err != nil {
-		return err
+		return nil, err
 	}
 
-	// write payload
-	buf.Reset(f.Payload)
-	_, err = io.Copy(w, buf)
-	return err
+	return f.Payload, nil
 }
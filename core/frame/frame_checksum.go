@@ -14,34 +14,68 @@
 package frame
 
 import (
-	"hash"
+	"encoding/binary"
+	"fmt"
 	"hash/crc32"
 )
 
-// crc32cTbl holds the precomputed crc32 hash table
-// used by Pulsar (crc32c)
+// crc32cTbl holds the precomputed crc32 hash table used by Pulsar
+// (crc32c). hash/crc32 recognizes the Castagnoli table returned by
+// MakeTable, and computes it with the SSE4.2 or ARM64 CRC32
+// instructions when available.
 var crc32cTbl = crc32.MakeTable(crc32.Castagnoli)
 
 // frameChecksum handles computing the Frame checksum, both
 // when decoding and encoding. The empty value is valid and
 // represents no checksum. It is not thread-safe.
 type frameChecksum struct {
-	h hash.Hash32
+	sum     uint32
+	written bool
 }
 
-// Write updates the hash with given bytes.
+// Write updates the checksum with given bytes.
 func (f *frameChecksum) Write(p []byte) (int, error) {
-	if f.h == nil {
-		f.h = crc32.New(crc32cTbl)
-	}
-	return f.h.Write(p)
+	f.sum = crc32.Update(f.sum, crc32cTbl, p)
+	f.written = true
+	return len(p), nil
 }
 
 // compute returns the computed checksum. If nothing
 // was written to the checksum, nil is returned.
 func (f *frameChecksum) compute() []byte {
-	if f.h == nil {
+	if !f.written {
 		return nil
 	}
-	return f.h.Sum(nil)
+	b := make([]byte, 4)
+	binary.BigEndian.PutUint32(b, f.sum)
+	return b
+}
+
+// ChecksumPolicy determines how the checksums of decoded frames
+// are verified.
+type ChecksumPolicy int
+
+// Possible ChecksumPolicies.
+const (
+	// ChecksumVerify fails decoding with a *ChecksumError
+	// on mismatch. It is the default.
+	ChecksumVerify ChecksumPolicy = iota
+	// ChecksumSkip doesn't compute checksums, e.g. for trusted links.
+	ChecksumSkip
+	// ChecksumReport decodes like ChecksumVerify, but users of the
+	// decoded frames, like conn.Conn, drop frames with a mismatch
+	// instead of failing.
+	ChecksumReport
+)
+
+// ChecksumError is returned when decoding a frame whose checksum
+// doesn't match its contents. The frame has been decoded completely,
+// so the next frame can still be decoded from the same reader.
+type ChecksumError struct {
+	Computed uint32
+	Expected uint32
+}
+
+func (e *ChecksumError) Error() string {
+	return fmt.Sprintf("checksum mismatch: computed (0x%08X) does not match given checksum (0x%08X)", e.Computed, e.Expected)
 }
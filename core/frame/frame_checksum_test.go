@@ -15,6 +15,7 @@ package frame
 
 import (
 	"bytes"
+	"fmt"
 	"hash/crc32"
 	"testing"
 )
@@ -42,3 +43,18 @@ func TestFrameChecksum(t *testing.T) {
 		t.Logf("compute() = 0x%x", got)
 	}
 }
+
+func BenchmarkFrameChecksum(b *testing.B) {
+	for _, size := range []int{64, 1024, 64 * 1024} {
+		input := make([]byte, size)
+		b.Run(fmt.Sprintf("%dB", size), func(b *testing.B) {
+			b.SetBytes(int64(size))
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				var f frameChecksum
+				_, _ = f.Write(input)
+				_ = f.compute()
+			}
+		})
+	}
+}
@@ -0,0 +1,79 @@
+// Copyright 2018 Comcast Cable Communications Management, LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package frame
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"io/ioutil"
+)
+
+// PayloadReader reads the payload of a frame decoded by
+// Frame.DecodeStream, directly from the underlying reader.
+type PayloadReader struct {
+	lr       *io.LimitedReader
+	chksum   *frameChecksum // nil for "simple" commands, or if skipped
+	expected []byte
+
+	verified bool
+	err      error // sticky checksum error
+}
+
+// Len returns the number of payload bytes not read yet.
+func (p *PayloadReader) Len() int64 {
+	return p.lr.N
+}
+
+// Read reads up to len(b) bytes of the payload. Once the whole
+// payload has been read, it returns io.EOF, or an error if the
+// payload doesn't match the frame's checksum.
+func (p *PayloadReader) Read(b []byte) (int, error) {
+	if p.err != nil {
+		return 0, p.err
+	}
+	n, err := p.lr.Read(b)
+	if err == io.EOF {
+		if p.lr.N > 0 {
+			return n, io.ErrUnexpectedEOF
+		}
+		if p.err = p.verify(); p.err != nil {
+			return n, p.err
+		}
+	}
+	return n, err
+}
+
+// Close discards the rest of the payload, so that the next
+// frame can be decoded, and verifies the frame's checksum.
+func (p *PayloadReader) Close() error {
+	if _, err := io.Copy(ioutil.Discard, p); err != nil {
+		return err
+	}
+	return p.verify()
+}
+
+// verify compares the checksum of the payload to the
+// one in the frame. It must be called once the whole
+// payload has been read.
+func (p *PayloadReader) verify() error {
+	if p.chksum == nil || p.verified {
+		return p.err
+	}
+	p.verified = true
+	if computed := p.chksum.compute(); !bytes.Equal(computed, p.expected) {
+		return &ChecksumError{Computed: p.chksum.sum, Expected: binary.BigEndian.Uint32(p.expected)}
+	}
+	return nil
+}
@@ -0,0 +1,137 @@
+// Copyright 2018 Comcast Cable Communications Management, LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package frame
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/pepper-iot/pulsar-client-go/pkg/api"
+)
+
+func TestFrameDecodeStream(t *testing.T) {
+	f := benchFrame(64 * 1024)
+	for i := range f.Payload {
+		f.Payload[i] = byte(i)
+	}
+	ping := Frame{
+		BaseCmd: &api.BaseCommand{
+			Type: api.BaseCommand_PING.Enum(),
+			Ping: &api.CommandPing{},
+		},
+	}
+
+	var wire bytes.Buffer
+	for _, fr := range []Frame{f, ping, f} {
+		if err := fr.Encode(&wire); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// the payload is streamed in small reads
+	var decoded Frame
+	p, err := decoded.DecodeStream(&wire)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if decoded.Payload != nil {
+		t.Fatalf("got payload of %d bytes; expected none", len(decoded.Payload))
+	}
+	if got, expected := p.Len(), int64(len(f.Payload)); got != expected {
+		t.Fatalf("PayloadReader.Len() = %d; expected %d", got, expected)
+	}
+	payload, err := ioutil.ReadAll(iotest.OneByteReader(p))
+	if err != nil {
+		t.Fatal(err)
+	}
+	decoded.Payload = payload
+	if !decoded.Equal(f) {
+		t.Fatalf("got frame %+v; expected %+v", decoded, f)
+	}
+	if err = p.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	// "simple" commands have an empty payload
+	var simple Frame
+	if p, err = simple.DecodeStream(&wire); err != nil {
+		t.Fatal(err)
+	}
+	if p.Len() != 0 || !simple.Equal(ping) {
+		t.Fatalf("got frame %+v with %d payload bytes; expected %+v", simple, p.Len(), ping)
+	}
+	if err = p.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	// closing the PayloadReader skips the payload
+	if p, err = decoded.DecodeStream(&wire); err != nil {
+		t.Fatal(err)
+	}
+	if err = p.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if wire.Len() != 0 {
+		t.Fatalf("%d bytes left after closing the PayloadReader; expected 0", wire.Len())
+	}
+}
+
+func TestFrameDecodeStream_ChecksumMismatch(t *testing.T) {
+	f := benchFrame(16)
+	var wire bytes.Buffer
+	if err := f.Encode(&wire); err != nil {
+		t.Fatal(err)
+	}
+	b := wire.Bytes()
+	b[len(b)-1] ^= 0xff // corrupt the payload
+
+	var decoded Frame
+	p, err := decoded.DecodeStream(bytes.NewReader(b))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = ioutil.ReadAll(p); err == nil || !strings.Contains(err.Error(), "checksum mismatch") {
+		t.Fatalf("ReadAll(PayloadReader) err = %v; expected checksum mismatch", err)
+	}
+	if err = p.Close(); err == nil {
+		t.Fatalf("PayloadReader.Close() err = %v; expected checksum mismatch", err)
+	}
+
+	// Decode reports the mismatch as well
+	if err = decoded.Decode(bytes.NewReader(b)); err == nil || !strings.Contains(err.Error(), "checksum mismatch") {
+		t.Fatalf("Frame.Decode() err = %v; expected checksum mismatch", err)
+	}
+}
+
+func TestFrameDecodeStream_UnexpectedEOF(t *testing.T) {
+	f := benchFrame(16)
+	var wire bytes.Buffer
+	if err := f.Encode(&wire); err != nil {
+		t.Fatal(err)
+	}
+	b := wire.Bytes()[:wire.Len()-1]
+
+	var decoded Frame
+	p, err := decoded.DecodeStream(bytes.NewReader(b))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = io.Copy(ioutil.Discard, p); err != io.ErrUnexpectedEOF {
+		t.Fatalf("Copy(PayloadReader) err = %v; expected %v", err, io.ErrUnexpectedEOF)
+	}
+}
@@ -14,9 +14,12 @@
 package frame
 
 import (
+	"bufio"
 	"bytes"
 	"encoding/hex"
+	"fmt"
 	"io"
+	"math/rand"
 	"os"
 	"path/filepath"
 	"testing"
@@ -104,6 +107,63 @@ func TestFrameDecode_Payload(t *testing.T) {
 	}
 }
 
+func TestFrameDecode_Buffered(t *testing.T) {
+	// Frames decoded from a bufio.Reader, whether they fit in its
+	// buffer or not, must match those decoded from the stream.
+	r := rand.New(rand.NewSource(7))
+	frames := make([]Frame, 200)
+	var wire bytes.Buffer
+	for i := range frames {
+		frames[i] = randomFrame(r)
+		if i%10 == 0 && frames[i].Metadata != nil {
+			// larger than the buffer
+			frames[i].Payload = make([]byte, 4096)
+		}
+		if err := frames[i].Encode(&wire); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// a corrupt frame is reported, but decoded
+	last := benchFrame(16)
+	if err := last.Encode(&wire); err != nil {
+		t.Fatal(err)
+	}
+	wire.Bytes()[wire.Len()-1] ^= 0xff
+
+	br := bufio.NewReaderSize(bytes.NewReader(wire.Bytes()), 1024)
+	for i, expected := range frames {
+		var f Frame
+		if err := f.Decode(br); err != nil {
+			t.Fatalf("frame %d: Decode() err = %v", i, err)
+		}
+		if !f.Equal(expected) {
+			t.Fatalf("frame %d: got %+v; expected %+v", i, f, expected)
+		}
+	}
+
+	var f Frame
+	err := f.Decode(br)
+	if _, ok := err.(*ChecksumError); !ok {
+		t.Fatalf("Decode() err = %v; expected a *ChecksumError", err)
+	}
+	if got, expected := len(f.Payload), 16; got != expected {
+		t.Fatalf("got a payload of %d bytes; expected %d", got, expected)
+	}
+	if err := f.Decode(br); err != io.EOF {
+		t.Fatalf("Decode() err = %v; expected %v", err, io.EOF)
+	}
+
+	// truncated frames fail like they do unbuffered
+	var truncated bytes.Buffer
+	if err := last.Encode(&truncated); err != nil {
+		t.Fatal(err)
+	}
+	br.Reset(bytes.NewReader(truncated.Bytes()[:truncated.Len()-3]))
+	if err := f.Decode(br); err != io.ErrUnexpectedEOF {
+		t.Fatalf("Decode() err = %v; expected %v", err, io.ErrUnexpectedEOF)
+	}
+}
+
 func TestFrameDecode_UnexpectedEOF(t *testing.T) {
 	// truncated last byte
 	wire := `
@@ -206,10 +266,106 @@ func TestFrameEncode_Payload(t *testing.T) {
 	}
 }
 
+func TestFrame_BrokerEntryMetadata(t *testing.T) {
+	f := Frame{
+		BaseCmd: &api.BaseCommand{
+			Type: api.BaseCommand_MESSAGE.Enum(),
+			Message: &api.CommandMessage{
+				ConsumerId: proto.Uint64(42),
+				MessageId: &api.MessageIdData{
+					LedgerId: proto.Uint64(2),
+					EntryId:  proto.Uint64(338),
+				},
+			},
+		},
+		Metadata: &api.MessageMetadata{
+			ProducerName: proto.String("go"),
+			SequenceId:   proto.Uint64(0),
+			PublishTime:  proto.Uint64(1513027321000),
+		},
+		Payload: []byte("hi: 0"),
+		BrokerEntryMetadata: &api.BrokerEntryMetadata{
+			BrokerTimestamp: proto.Uint64(1513027321005),
+			Index:           proto.Uint64(7),
+		},
+	}
+
+	var b bytes.Buffer
+	if err := f.Encode(&b); err != nil {
+		t.Fatal(err)
+	}
+
+	// the section follows the BaseCommand
+	cmdSize := int(b.Bytes()[7])
+	if got, expected := b.Bytes()[8+cmdSize:10+cmdSize], brokerEntryMagicNumber[:]; !bytes.Equal(got, expected) {
+		t.Fatalf("got % x after the command; expected % x", got, expected)
+	}
+
+	var decoded Frame
+	if err := decoded.Decode(&b); err != nil {
+		t.Fatal(err)
+	}
+	if !decoded.Equal(f) {
+		t.Fatalf("got frame %+v; expected %+v", decoded, f)
+	}
+}
+
+func TestFrame_EncryptionMetadata(t *testing.T) {
+	// The encryption fields must survive encoding and decoding
+	// untouched, so that proxies can pass encrypted messages through.
+	f := benchFrame(32)
+	f.Metadata.EncryptionKeys = []*api.EncryptionKeys{
+		{
+			Key:   proto.String("consumer-a.pem"),
+			Value: []byte{0xde, 0xad, 0xbe, 0xef},
+			Metadata: []*api.KeyValue{
+				{Key: proto.String("version"), Value: proto.String("1")},
+			},
+		},
+		{
+			Key:   proto.String("consumer-b.pem"),
+			Value: []byte{0xca, 0xfe},
+		},
+	}
+	f.Metadata.EncryptionAlgo = proto.String("AES-GCM")
+	f.Metadata.EncryptionParam = []byte("0123456789ab")
+
+	var b bytes.Buffer
+	if err := f.Encode(&b); err != nil {
+		t.Fatal(err)
+	}
+	wire := b.Bytes()
+
+	var decoded Frame
+	if err := decoded.Decode(bytes.NewReader(wire)); err != nil {
+		t.Fatal(err)
+	}
+	if !decoded.Equal(f) {
+		t.Fatalf("Frame.Decode(): got frame %+v; expected %+v", decoded, f)
+	}
+
+	var reused Frame
+	if err := NewDecoder(nil).Decode(bytes.NewReader(wire), &reused); err != nil {
+		t.Fatal(err)
+	}
+	if !reused.Equal(f) {
+		t.Fatalf("Decoder.Decode(): got frame %+v; expected %+v", reused, f)
+	}
+
+	// re-encoding the decoded frame yields the same bytes
+	var out bytes.Buffer
+	if err := decoded.Encode(&out); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(out.Bytes(), wire) {
+		t.Fatalf("re-encoded frame:\n%s\nexpected:\n%s", hex.Dump(out.Bytes()), hex.Dump(wire))
+	}
+}
+
 func TestFrame_Captured(t *testing.T) {
 	// Read Pulsar frames captured off the wire. Ensure that
 	// they can be decoded and then re-encoded.
-	inputs, err := filepath.Glob("testdata/frames/*.frame")
+	inputs, err := filepath.Glob("../../testdata/frames/*.frame")
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -276,3 +432,78 @@ func TestFrameEncode_MaxFrameSize(t *testing.T) {
 	}
 	t.Logf("Frame.Encode() err = %v", err)
 }
+
+// benchFrame returns a MESSAGE frame with a payload of the given size.
+func benchFrame(size int) Frame {
+	return Frame{
+		BaseCmd: &api.BaseCommand{
+			Type: api.BaseCommand_MESSAGE.Enum(),
+			Message: &api.CommandMessage{
+				ConsumerId: proto.Uint64(42),
+				MessageId: &api.MessageIdData{
+					LedgerId: proto.Uint64(2),
+					EntryId:  proto.Uint64(338),
+				},
+			},
+		},
+		Metadata: &api.MessageMetadata{
+			ProducerName: proto.String("go"),
+			SequenceId:   proto.Uint64(0),
+			PublishTime:  proto.Uint64(1513027321000),
+		},
+		Payload: make([]byte, size),
+	}
+}
+
+func BenchmarkFrameEncode(b *testing.B) {
+	for _, size := range []int{64, 1024, 64 * 1024} {
+		f := benchFrame(size)
+		b.Run(fmt.Sprintf("%dB", size), func(b *testing.B) {
+			var out bytes.Buffer
+			b.SetBytes(int64(size))
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				out.Reset()
+				if err := f.Encode(&out); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkFrameDecode(b *testing.B) {
+	for _, size := range []int{64, 1024, 64 * 1024} {
+		f := benchFrame(size)
+		var wire bytes.Buffer
+		if err := f.Encode(&wire); err != nil {
+			b.Fatal(err)
+		}
+		b.Run(fmt.Sprintf("%dB", size), func(b *testing.B) {
+			r := bytes.NewReader(wire.Bytes())
+			b.SetBytes(int64(size))
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				r.Reset(wire.Bytes())
+				var decoded Frame
+				if err := decoded.Decode(r); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+		b.Run(fmt.Sprintf("%dB/buffered", size), func(b *testing.B) {
+			r := bytes.NewReader(wire.Bytes())
+			br := bufio.NewReaderSize(r, 128*1024)
+			b.SetBytes(int64(size))
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				r.Reset(wire.Bytes())
+				br.Reset(r)
+				var decoded Frame
+				if err := decoded.Decode(br); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
@@ -17,16 +17,33 @@ import (
 	"errors"
 	"fmt"
 	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/pepper-iot/pulsar-client-go/utils"
 )
 
+// dispatcherShards is the number of shards of the Dispatcher's
+// registrations. It must be a power of 2.
+const dispatcherShards = 16
+
 // NewFrameDispatcher returns an instantiated FrameDispatcher.
 func NewFrameDispatcher() *Dispatcher {
-	return &Dispatcher{
-		ProdSeqIDs: make(map[ProdSeqKey]AsyncResp),
-		ReqIDs:     make(map[uint64]AsyncResp),
+	return NewFrameDispatcherWithClock(utils.RealClock)
+}
+
+// NewFrameDispatcherWithClock returns an instantiated FrameDispatcher,
+// whose registrations expire once clock advanced by the time until
+// their deadlines.
+func NewFrameDispatcherWithClock(clock utils.Clock) *Dispatcher {
+	f := &Dispatcher{clock: clock}
+	for i := range f.reqIDs {
+		f.reqIDs[i].m = make(map[uint64]AsyncResp)
+	}
+	for i := range f.prodSeqIDs {
+		f.prodSeqIDs[i].m = make(map[ProdSeqKey]AsyncResp)
 	}
+	return f
 }
 
 // Dispatcher is Responsible for handling the request/Response
@@ -42,14 +59,118 @@ type Dispatcher struct {
 	Global   *AsyncResp
 
 	// All Responses that are correlated by their
-	// requestID
-	ReqIDMu sync.Mutex // protects following
-	ReqIDs  map[uint64]AsyncResp
+	// requestID, sharded by requestID
+	reqIDs [dispatcherShards]reqIDShard
 
-	// All Responses that are correlated by their
-	// (producerID, sequenceID) tuple
-	ProdSeqIDsMu sync.Mutex // protects following
-	ProdSeqIDs   map[ProdSeqKey]AsyncResp
+	// All Responses that are correlated by their (producerID,
+	// sequenceID) tuple, sharded by the tuple so that the
+	// receipts of concurrent producers don't contend
+	prodSeqIDs [dispatcherShards]prodSeqShard
+
+	clock utils.Clock // drives the deadlines of registrations
+
+	canceled   uint64 // accessed atomically
+	expired    uint64 // accessed atomically
+	duplicates uint64 // accessed atomically
+	unexpected uint64 // accessed atomically
+}
+
+// reqIDShard is a shard of the registrations correlated by requestID.
+type reqIDShard struct {
+	mu sync.Mutex // protects following
+	m  map[uint64]AsyncResp
+}
+
+// prodSeqShard is a shard of the registrations
+// correlated by (producerID, sequenceID).
+type prodSeqShard struct {
+	mu sync.Mutex // protects following
+	m  map[ProdSeqKey]AsyncResp
+}
+
+// reqIDShard returns the shard of requestID.
+func (f *Dispatcher) reqIDShard(requestID uint64) *reqIDShard {
+	return &f.reqIDs[requestID&(dispatcherShards-1)]
+}
+
+// prodSeqShard returns the shard of key. Sequence ids of a producer
+// are consecutive, so its outstanding sends spread over all shards.
+func (f *Dispatcher) prodSeqShard(key ProdSeqKey) *prodSeqShard {
+	return &f.prodSeqIDs[(key.ProducerID*31+key.SequenceID)&(dispatcherShards-1)]
+}
+
+// DispatcherStats are the metrics of a Dispatcher.
+type DispatcherStats struct {
+	PendingGlobal     int `json:"pending_global"`       // outstanding requests without id (0 or 1)
+	PendingReqIDs     int `json:"pending_req_ids"`      // outstanding requests correlated by requestID
+	PendingProdSeqIDs int `json:"pending_prod_seq_ids"` // outstanding sends correlated by (producerID, sequenceID)
+
+	Canceled   uint64 `json:"canceled"`   // requests canceled before their response arrived, typically timeouts
+	Expired    uint64 `json:"expired"`    // requests whose deadline passed before their response arrived
+	Duplicates uint64 `json:"duplicates"` // registrations rejected because the same id was outstanding
+	Unexpected uint64 `json:"unexpected"` // responses without an outstanding request, e.g. late or lost
+}
+
+// Stats returns the current metrics of the Dispatcher. A growing
+// number of pending requests hints at responses being lost, while
+// Unexpected responses usually arrive after their request timed out.
+func (f *Dispatcher) Stats() DispatcherStats {
+	var s DispatcherStats
+
+	f.GlobalMu.Lock()
+	if f.Global != nil {
+		s.PendingGlobal = 1
+	}
+	f.GlobalMu.Unlock()
+
+	for i := range f.reqIDs {
+		shard := &f.reqIDs[i]
+		shard.mu.Lock()
+		s.PendingReqIDs += len(shard.m)
+		shard.mu.Unlock()
+	}
+
+	for i := range f.prodSeqIDs {
+		shard := &f.prodSeqIDs[i]
+		shard.mu.Lock()
+		s.PendingProdSeqIDs += len(shard.m)
+		shard.mu.Unlock()
+	}
+
+	s.Canceled = atomic.LoadUint64(&f.canceled)
+	s.Expired = atomic.LoadUint64(&f.expired)
+	s.Duplicates = atomic.LoadUint64(&f.duplicates)
+	s.Unexpected = atomic.LoadUint64(&f.unexpected)
+
+	return s
+}
+
+// withoutDeadline adapts the results of the register functions
+// for registrations without deadline.
+func (f *Dispatcher) withoutDeadline(resp <-chan Frame, cancel func(*uint64), err error) (<-chan Frame, func(), error) {
+	if err != nil {
+		return nil, nil, err
+	}
+	return resp, func() { cancel(&f.canceled) }, nil
+}
+
+// withDeadline returns a function adapting the results of the register
+// functions, which cancels the registration once the deadline passes.
+// A zero deadline never passes.
+func (f *Dispatcher) withDeadline(deadline time.Time) func(<-chan Frame, func(*uint64), error) (<-chan Frame, func(), error) {
+	if deadline.IsZero() {
+		return f.withoutDeadline
+	}
+	return func(resp <-chan Frame, cancel func(*uint64), err error) (<-chan Frame, func(), error) {
+		if err != nil {
+			return nil, nil, err
+		}
+		t := f.clock.AfterFunc(time.Until(deadline), func() { cancel(&f.expired) })
+		return resp, func() {
+			t.Stop()
+			cancel(&f.canceled)
+		}, nil
+	}
 }
 
 // AsyncResp manages the state between a request
@@ -76,9 +197,25 @@ type ProdSeqKey struct {
 // is allowed at a time. Callers should always call cancel, specifically
 // when they're not interested in the Response.
 func (f *Dispatcher) RegisterGlobal() (Response <-chan Frame, cancel func(), err error) {
+	return f.withoutDeadline(f.registerGlobal())
+}
+
+// RegisterGlobalDeadline is like RegisterGlobal, but the global request is
+// canceled once the deadline passes without a Response, and counted
+// as expired. This ensures lost Responses don't leak registrations.
+// Callers must not rely on it to stop waiting for the Response, which
+// is never sent once the global request expired.
+// A zero deadline never passes, e.g. that of a context without one.
+func (f *Dispatcher) RegisterGlobalDeadline(deadline time.Time) (Response <-chan Frame, cancel func(), err error) {
+	return f.withDeadline(deadline)(f.registerGlobal())
+}
+
+// registerGlobal registers the global request. Its cancel function
+// increments counter if the global request is still outstanding.
+func (f *Dispatcher) registerGlobal() (Response <-chan Frame, cancel func(counter *uint64), err error) {
 	var mu sync.Mutex
 	done := make(chan struct{})
-	cancel = func() {
+	cancel = func(counter *uint64) {
 		mu.Lock()
 		defer mu.Unlock()
 		if done == nil {
@@ -86,7 +223,10 @@ func (f *Dispatcher) RegisterGlobal() (Response <-chan Frame, cancel func(), err
 		}
 
 		f.GlobalMu.Lock()
-		f.Global = nil
+		if f.Global != nil && f.Global.Done == (<-chan struct{})(done) {
+			atomic.AddUint64(counter, 1)
+			f.Global = nil
+		}
 		f.GlobalMu.Unlock()
 
 		close(done)
@@ -98,6 +238,7 @@ func (f *Dispatcher) RegisterGlobal() (Response <-chan Frame, cancel func(), err
 	f.GlobalMu.Lock()
 	if f.Global != nil {
 		f.GlobalMu.Unlock()
+		atomic.AddUint64(&f.duplicates, 1)
 		return nil, nil, errors.New("outstanding global request already in progress")
 	}
 	f.Global = &AsyncResp{
@@ -120,6 +261,7 @@ func (f *Dispatcher) NotifyGlobal(frame Frame) error {
 	f.GlobalMu.Unlock()
 
 	if a == nil {
+		atomic.AddUint64(&f.unexpected, 1)
 		return utils.NewUnexpectedErrMsg(frame.BaseCmd.GetType())
 	}
 
@@ -128,6 +270,7 @@ func (f *Dispatcher) NotifyGlobal(frame Frame) error {
 		// sent Response back to sender
 		return nil
 	case <-a.Done:
+		atomic.AddUint64(&f.unexpected, 1)
 		return utils.NewUnexpectedErrMsg(frame.BaseCmd.GetType())
 	}
 }
@@ -137,20 +280,40 @@ func (f *Dispatcher) NotifyGlobal(frame Frame) error {
 // specifically when they're not interested in the Response. It is an error
 // to have multiple outstanding requests with the same id tuple.
 func (f *Dispatcher) RegisterProdSeqIDs(producerID, sequenceID uint64) (Response <-chan Frame, cancel func(), err error) {
+	return f.withoutDeadline(f.registerProdSeqIDs(producerID, sequenceID))
+}
+
+// RegisterProdSeqIDsDeadline is like RegisterProdSeqIDs, but the send is
+// canceled once the deadline passes without a Response, and counted
+// as expired. This ensures lost Responses don't leak registrations.
+// Callers must not rely on it to stop waiting for the Response, which
+// is never sent once the send expired.
+// A zero deadline never passes, e.g. that of a context without one.
+func (f *Dispatcher) RegisterProdSeqIDsDeadline(producerID, sequenceID uint64, deadline time.Time) (Response <-chan Frame, cancel func(), err error) {
+	return f.withDeadline(deadline)(f.registerProdSeqIDs(producerID, sequenceID))
+}
+
+// registerProdSeqIDs registers the send. Its cancel function
+// increments counter if the send is still outstanding.
+func (f *Dispatcher) registerProdSeqIDs(producerID, sequenceID uint64) (Response <-chan Frame, cancel func(counter *uint64), err error) {
 	key := ProdSeqKey{producerID, sequenceID}
+	shard := f.prodSeqShard(key)
 
 	var mu sync.Mutex
 	done := make(chan struct{})
-	cancel = func() {
+	cancel = func(counter *uint64) {
 		mu.Lock()
 		defer mu.Unlock()
 		if done == nil {
 			return
 		}
 
-		f.ProdSeqIDsMu.Lock()
-		delete(f.ProdSeqIDs, key)
-		f.ProdSeqIDsMu.Unlock()
+		shard.mu.Lock()
+		if a, ok := shard.m[key]; ok && a.Done == (<-chan struct{})(done) {
+			atomic.AddUint64(counter, 1)
+			delete(shard.m, key)
+		}
+		shard.mu.Unlock()
 
 		close(done)
 		done = nil
@@ -158,16 +321,17 @@ func (f *Dispatcher) RegisterProdSeqIDs(producerID, sequenceID uint64) (Response
 
 	Resp := make(chan Frame)
 
-	f.ProdSeqIDsMu.Lock()
-	if _, ok := f.ProdSeqIDs[key]; ok {
-		f.ProdSeqIDsMu.Unlock()
+	shard.mu.Lock()
+	if _, ok := shard.m[key]; ok {
+		shard.mu.Unlock()
+		atomic.AddUint64(&f.duplicates, 1)
 		return nil, nil, fmt.Errorf("already exists an outstanding Response for producerID %d, sequenceID %d", producerID, sequenceID)
 	}
-	f.ProdSeqIDs[key] = AsyncResp{
+	shard.m[key] = AsyncResp{
 		Resp: Resp,
 		Done: done,
 	}
-	f.ProdSeqIDsMu.Unlock()
+	shard.mu.Unlock()
 
 	return Resp, cancel, nil
 }
@@ -176,16 +340,18 @@ func (f *Dispatcher) RegisterProdSeqIDs(producerID, sequenceID uint64) (Response
 // (producerID, sequenceID) id tuples to correlate them to their requests.
 func (f *Dispatcher) NotifyProdSeqIDs(producerID, sequenceID uint64, frame Frame) error {
 	key := ProdSeqKey{producerID, sequenceID}
+	shard := f.prodSeqShard(key)
 
-	f.ProdSeqIDsMu.Lock()
+	shard.mu.Lock()
 	// fetch Response channel from cubbyhole
-	a, ok := f.ProdSeqIDs[key]
+	a, ok := shard.m[key]
 	// ensure additional calls to notify with same key will
 	// fail with UnexpectedMsg (unless registerProdSeqIDs with same key is called)
-	delete(f.ProdSeqIDs, key)
-	f.ProdSeqIDsMu.Unlock()
+	delete(shard.m, key)
+	shard.mu.Unlock()
 
 	if !ok {
+		atomic.AddUint64(&f.unexpected, 1)
 		return utils.NewUnexpectedErrMsg(frame.BaseCmd.GetType(), producerID, sequenceID)
 	}
 
@@ -194,6 +360,7 @@ func (f *Dispatcher) NotifyProdSeqIDs(producerID, sequenceID uint64, frame Frame
 		// Response was correctly pushed into channel
 		return nil
 	case <-a.Done:
+		atomic.AddUint64(&f.unexpected, 1)
 		return utils.NewUnexpectedErrMsg(frame.BaseCmd.GetType(), producerID, sequenceID)
 	}
 }
@@ -203,18 +370,39 @@ func (f *Dispatcher) NotifyProdSeqIDs(producerID, sequenceID uint64, frame Frame
 // specifically when they're not interested in the Response. It is an error
 // to have multiple outstanding requests with the id.
 func (f *Dispatcher) RegisterReqID(requestID uint64) (Response <-chan Frame, cancel func(), err error) {
+	return f.withoutDeadline(f.registerReqID(requestID))
+}
+
+// RegisterReqIDDeadline is like RegisterReqID, but the request is
+// canceled once the deadline passes without a Response, and counted
+// as expired. This ensures lost Responses don't leak registrations.
+// Callers must not rely on it to stop waiting for the Response, which
+// is never sent once the request expired.
+// A zero deadline never passes, e.g. that of a context without one.
+func (f *Dispatcher) RegisterReqIDDeadline(requestID uint64, deadline time.Time) (Response <-chan Frame, cancel func(), err error) {
+	return f.withDeadline(deadline)(f.registerReqID(requestID))
+}
+
+// registerReqID registers the request. Its cancel function
+// increments counter if the request is still outstanding.
+func (f *Dispatcher) registerReqID(requestID uint64) (Response <-chan Frame, cancel func(counter *uint64), err error) {
+	shard := f.reqIDShard(requestID)
+
 	var mu sync.Mutex
 	done := make(chan struct{})
-	cancel = func() {
+	cancel = func(counter *uint64) {
 		mu.Lock()
 		defer mu.Unlock()
 		if done == nil {
 			return
 		}
 
-		f.ReqIDMu.Lock()
-		delete(f.ReqIDs, requestID)
-		f.ReqIDMu.Unlock()
+		shard.mu.Lock()
+		if a, ok := shard.m[requestID]; ok && a.Done == (<-chan struct{})(done) {
+			atomic.AddUint64(counter, 1)
+			delete(shard.m, requestID)
+		}
+		shard.mu.Unlock()
 
 		close(done)
 		done = nil
@@ -222,16 +410,17 @@ func (f *Dispatcher) RegisterReqID(requestID uint64) (Response <-chan Frame, can
 
 	Resp := make(chan Frame)
 
-	f.ReqIDMu.Lock()
-	if _, ok := f.ReqIDs[requestID]; ok {
-		f.ReqIDMu.Unlock()
+	shard.mu.Lock()
+	if _, ok := shard.m[requestID]; ok {
+		shard.mu.Unlock()
+		atomic.AddUint64(&f.duplicates, 1)
 		return nil, nil, fmt.Errorf("already exists an outstanding Response for requestID %d", requestID)
 	}
-	f.ReqIDs[requestID] = AsyncResp{
+	shard.m[requestID] = AsyncResp{
 		Resp: Resp,
 		Done: done,
 	}
-	f.ReqIDMu.Unlock()
+	shard.mu.Unlock()
 
 	return Resp, cancel, nil
 }
@@ -239,15 +428,18 @@ func (f *Dispatcher) RegisterReqID(requestID uint64) (Response <-chan Frame, can
 // NotifyReqID should be called with Response frames that have
 // a requestID to correlate them to their requests.
 func (f *Dispatcher) NotifyReqID(requestID uint64, frame Frame) error {
-	f.ReqIDMu.Lock()
+	shard := f.reqIDShard(requestID)
+
+	shard.mu.Lock()
 	// fetch Response channel from cubbyhole
-	a, ok := f.ReqIDs[requestID]
+	a, ok := shard.m[requestID]
 	// ensure additional calls to notifyReqID with same key will
 	// fail with UnexpectedMsg (unless addReqID with same key is called)
-	delete(f.ReqIDs, requestID)
-	f.ReqIDMu.Unlock()
+	delete(shard.m, requestID)
+	shard.mu.Unlock()
 
 	if !ok {
+		atomic.AddUint64(&f.unexpected, 1)
 		return utils.NewUnexpectedErrMsg(frame.BaseCmd.GetType(), requestID)
 	}
 
@@ -257,6 +449,7 @@ func (f *Dispatcher) NotifyReqID(requestID uint64, frame Frame) error {
 		// Response was correctly pushed into channel
 		return nil
 	case <-a.Done:
+		atomic.AddUint64(&f.unexpected, 1)
 		return utils.NewUnexpectedErrMsg(frame.BaseCmd.GetType(), requestID)
 	}
 }
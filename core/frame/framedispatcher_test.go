@@ -14,10 +14,13 @@
 package frame
 
 import (
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/pepper-iot/pulsar-client-go/pkg/api"
+	"github.com/pepper-iot/pulsar-client-go/utils"
 )
 
 type dispatcherTestCase struct {
@@ -242,3 +245,202 @@ func TestFrameDispatcher_Timeout(t *testing.T) {
 		})
 	}
 }
+
+func TestFrameDispatcher_Stats(t *testing.T) {
+	fd := NewFrameDispatcher()
+
+	f := Frame{
+		BaseCmd: &api.BaseCommand{
+			Type: api.BaseCommand_SUCCESS.Enum(),
+		},
+	}
+
+	_, cancelReq, err := fd.RegisterReqID(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err = fd.RegisterReqID(1); err == nil {
+		t.Fatal("RegisterReqID() err = nil; expected duplicate error")
+	}
+	_, cancelSend, err := fd.RegisterProdSeqIDs(1, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer cancelSend()
+	_, cancelGlobal, err := fd.RegisterGlobal()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer cancelGlobal()
+
+	expected := DispatcherStats{
+		PendingGlobal:     1,
+		PendingReqIDs:     1,
+		PendingProdSeqIDs: 1,
+		Duplicates:        1,
+	}
+	if got := fd.Stats(); got != expected {
+		t.Fatalf("Stats() = %+v; expected %+v", got, expected)
+	}
+
+	// the request times out, then its response arrives
+	cancelReq()
+	cancelReq()
+	if err = fd.NotifyReqID(1, f); err == nil {
+		t.Fatal("NotifyReqID() err = nil; expected unexpected error")
+	}
+
+	expected.PendingReqIDs = 0
+	expected.Canceled = 1
+	expected.Unexpected = 1
+	if got := fd.Stats(); got != expected {
+		t.Fatalf("Stats() = %+v; expected %+v", got, expected)
+	}
+}
+
+func TestFrameDispatcher_ConcurrentProducers(t *testing.T) {
+	fd := NewFrameDispatcher()
+
+	f := Frame{
+		BaseCmd: &api.BaseCommand{
+			Type: api.BaseCommand_SEND_RECEIPT.Enum(),
+		},
+	}
+
+	// many producers sending concurrently over one connection,
+	// with the receipts of all of them outstanding at once
+	const producers, sends = 8, 100
+	var wg sync.WaitGroup
+	for p := uint64(0); p < producers; p++ {
+		wg.Add(1)
+		go func(p uint64) {
+			defer wg.Done()
+			resps := make([]<-chan Frame, sends)
+			for s := range resps {
+				resp, cancel, err := fd.RegisterProdSeqIDs(p, uint64(s))
+				if err != nil {
+					t.Error(err)
+					return
+				}
+				defer cancel()
+				resps[s] = resp
+			}
+			for s := range resps {
+				go fd.NotifyProdSeqIDs(p, uint64(s), f)
+			}
+			for _, resp := range resps {
+				<-resp
+			}
+		}(p)
+	}
+	wg.Wait()
+
+	if got := fd.Stats(); got != (DispatcherStats{}) {
+		t.Fatalf("Stats() = %+v; expected zero", got)
+	}
+}
+
+func BenchmarkFrameDispatcher_ProdSeqIDs(b *testing.B) {
+	fd := NewFrameDispatcher()
+
+	f := Frame{
+		BaseCmd: &api.BaseCommand{
+			Type: api.BaseCommand_SEND_RECEIPT.Enum(),
+		},
+	}
+
+	var producerID uint64
+	b.ReportAllocs()
+	b.RunParallel(func(pb *testing.PB) {
+		p := atomic.AddUint64(&producerID, 1)
+		for s := uint64(0); pb.Next(); s++ {
+			resp, cancel, err := fd.RegisterProdSeqIDs(p, s)
+			if err != nil {
+				b.Fatal(err)
+			}
+			go fd.NotifyProdSeqIDs(p, s, f)
+			<-resp
+			cancel()
+		}
+	})
+}
+
+func TestFrameDispatcher_Deadline(t *testing.T) {
+	// deadlines far later than the test, which only
+	// pass when the manual clock is advanced
+	clock := utils.NewManualClock(time.Now())
+	fd := NewFrameDispatcherWithClock(clock)
+
+	f := Frame{
+		BaseCmd: &api.BaseCommand{
+			Type: api.BaseCommand_SUCCESS.Enum(),
+		},
+	}
+
+	deadline := time.Now().Add(time.Hour)
+	cases := map[string]dispatcherTestCase{
+		"global": {
+			register: func() (<-chan Frame, func(), error) { return fd.RegisterGlobalDeadline(deadline) },
+			notify:   fd.NotifyGlobal,
+		},
+		"prodSeqID": {
+			register: func() (<-chan Frame, func(), error) { return fd.RegisterProdSeqIDsDeadline(1, 2, deadline) },
+			notify:   func(f Frame) error { return fd.NotifyProdSeqIDs(1, 2, f) },
+		},
+		"reqID": {
+			register: func() (<-chan Frame, func(), error) { return fd.RegisterReqIDDeadline(42, deadline) },
+			notify:   func(f Frame) error { return fd.NotifyReqID(42, f) },
+		},
+	}
+
+	for name, dtc := range cases {
+		dtc := dtc
+		t.Run(name, func(t *testing.T) {
+			// the caller never cancels, e.g. because
+			// it waits for the Response without timeout
+			if _, _, err := dtc.register(); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+
+	// registrations are pending until the deadline
+	expected := DispatcherStats{
+		PendingGlobal:     1,
+		PendingReqIDs:     1,
+		PendingProdSeqIDs: 1,
+	}
+	if got := fd.Stats(); got != expected {
+		t.Fatalf("Stats() = %+v; expected %+v", got, expected)
+	}
+
+	clock.Advance(time.Hour)
+	expected = DispatcherStats{Expired: 3}
+	// registrations expire in their own goroutines
+	for start := time.Now(); fd.Stats() != expected && time.Since(start) < time.Second; {
+		time.Sleep(time.Millisecond)
+	}
+	if got := fd.Stats(); got != expected {
+		t.Fatalf("Stats() = %+v; expected %+v", got, expected)
+	}
+
+	// late Responses are unexpected, and the
+	// ids can be registered again
+	deadline = time.Now().Add(time.Hour)
+	for name, dtc := range cases {
+		if err := dtc.notify(f); err == nil {
+			t.Fatalf("%s: notify() err = nil; expected unexpected error", name)
+		}
+		if _, cancel, err := dtc.register(); err != nil {
+			t.Fatalf("%s: register() err = %v", name, err)
+		} else {
+			cancel()
+		}
+	}
+
+	// canceling before the deadline doesn't count as expired
+	expected = DispatcherStats{Expired: 3, Unexpected: 3, Canceled: 3}
+	if got := fd.Stats(); got != expected {
+		t.Fatalf("Stats() = %+v; expected %+v", got, expected)
+	}
+}
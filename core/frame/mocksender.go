@@ -14,6 +14,7 @@
 package frame
 
 import (
+	"context"
 	"sync"
 
 	"github.com/pepper-iot/pulsar-client-go/pkg/api"
@@ -32,6 +33,8 @@ type MockSender struct {
 	Mu      sync.Mutex // protects following
 	Frames  []Frame
 	Closedc chan struct{}
+
+	notify chan struct{} // closed once frames are sent, if set
 }
 
 func (m *MockSender) GetFrames() []Frame {
@@ -44,6 +47,40 @@ func (m *MockSender) GetFrames() []Frame {
 	return cp
 }
 
+// WaitFrames waits until at least n frames were sent, or ctx is
+// done, and returns the frames sent. It lets tests wait for the
+// goroutine under test to send a frame, instead of sleeping.
+func (m *MockSender) WaitFrames(ctx context.Context, n int) ([]Frame, error) {
+	for {
+		m.Mu.Lock()
+		if len(m.Frames) >= n {
+			cp := make([]Frame, len(m.Frames))
+			copy(cp, m.Frames)
+			m.Mu.Unlock()
+			return cp, nil
+		}
+		if m.notify == nil {
+			m.notify = make(chan struct{})
+		}
+		notify := m.notify
+		m.Mu.Unlock()
+
+		select {
+		case <-notify:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
+
+// sent wakes up WaitFrames callers. m.Mu must be held.
+func (m *MockSender) sent() {
+	if m.notify != nil {
+		close(m.notify)
+		m.notify = nil
+	}
+}
+
 func (m *MockSender) SendSimpleCmd(cmd api.BaseCommand) error {
 	m.Mu.Lock()
 	defer m.Mu.Unlock()
@@ -51,6 +88,7 @@ func (m *MockSender) SendSimpleCmd(cmd api.BaseCommand) error {
 	m.Frames = append(m.Frames, Frame{
 		BaseCmd: &cmd,
 	})
+	m.sent()
 
 	return nil
 }
@@ -64,6 +102,7 @@ func (m *MockSender) SendPayloadCmd(cmd api.BaseCommand, metadata api.MessageMet
 		Metadata: &metadata,
 		Payload:  payload,
 	})
+	m.sent()
 
 	return nil
 }
@@ -0,0 +1,122 @@
+// Copyright 2018 Comcast Cable Communications Management, LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package frame
+
+import (
+	"math/bits"
+	"sync"
+
+	"github.com/pepper-iot/pulsar-client-go/pkg/api"
+)
+
+// Frames are decoded into BaseCommands and MessageMetadatas taken from
+// pools. Releasing them once they're no longer used lets the next frames
+// reuse them instead of allocating, which reduces the garbage produced
+// by high rates of messages. Unreleased ones are garbage collected.
+var (
+	baseCmdPool = sync.Pool{
+		New: func() interface{} { return new(api.BaseCommand) },
+	}
+	metadataPool = sync.Pool{
+		New: func() interface{} { return new(api.MessageMetadata) },
+	}
+)
+
+// Payload buffers are pooled by capacity, in powers of two
+// from minPayloadCap up to the first one above MaxFrameSize.
+const (
+	minPayloadShift = 8 // 256 bytes
+	minPayloadCap   = 1 << minPayloadShift
+)
+
+var payloadPools [24 - minPayloadShift]sync.Pool // holds *[]byte
+
+// payloadClass returns the index of the pool of buffers
+// of capacity n, rounded up to the next power of two.
+func payloadClass(n int) int {
+	if n <= minPayloadCap {
+		return 0
+	}
+	return bits.Len(uint(n-1)) - minPayloadShift
+}
+
+// NewBaseCommand returns an empty BaseCommand, reusing
+// a released one if possible.
+func NewBaseCommand() *api.BaseCommand {
+	return baseCmdPool.Get().(*api.BaseCommand)
+}
+
+// ReleaseBaseCommand resets cmd and returns it to the pool, if it isn't
+// nil. The commands it contains, such as the CommandMessage of a MESSAGE,
+// aren't reset, and may still be used. cmd must not be used afterwards.
+func ReleaseBaseCommand(cmd *api.BaseCommand) {
+	if cmd == nil {
+		return
+	}
+	cmd.Reset()
+	baseCmdPool.Put(cmd)
+}
+
+// NewMessageMetadata returns an empty MessageMetadata,
+// reusing a released one if possible.
+func NewMessageMetadata() *api.MessageMetadata {
+	return metadataPool.Get().(*api.MessageMetadata)
+}
+
+// ReleaseMessageMetadata resets meta and returns it to the pool, if it
+// isn't nil. meta must not be used afterwards.
+func ReleaseMessageMetadata(meta *api.MessageMetadata) {
+	if meta == nil {
+		return
+	}
+	meta.Reset()
+	metadataPool.Put(meta)
+}
+
+// NewPayload returns a payload buffer of length n, reusing a released
+// one if possible. Buffers larger than MaxFrameSize aren't pooled.
+func NewPayload(n int) []byte {
+	c := payloadClass(n)
+	if c >= len(payloadPools) {
+		return make([]byte, n)
+	}
+	if b, ok := payloadPools[c].Get().(*[]byte); ok {
+		return (*b)[:n]
+	}
+	return make([]byte, n, minPayloadCap<<c)
+}
+
+// ReleasePayload returns b, which must have been returned by NewPayload,
+// to the pool. Other buffers are ignored. b must not be used afterwards.
+func ReleasePayload(b []byte) {
+	c := payloadClass(cap(b))
+	if c >= len(payloadPools) || cap(b) != minPayloadCap<<c {
+		return
+	}
+	b = b[:0]
+	payloadPools[c].Put(&b)
+}
+
+// Release returns the BaseCmd and Metadata of the frame to their pools,
+// as well as its Payload if PooledPayload is set, and resets the frame.
+// It must only be called once nothing uses them anymore, and never on
+// frames decoded by a Decoder, which reuses its own.
+func (f *Frame) Release() {
+	ReleaseBaseCommand(f.BaseCmd)
+	ReleaseMessageMetadata(f.Metadata)
+	if f.PooledPayload {
+		ReleasePayload(f.Payload)
+	}
+	*f = Frame{}
+}
@@ -0,0 +1,171 @@
+// Copyright 2018 Comcast Cable Communications Management, LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package frame
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/pepper-iot/pulsar-client-go/pkg/api"
+)
+
+func TestFrame_Release(t *testing.T) {
+	withMeta := benchFrame(16)
+	withMeta.Metadata.Properties = []*api.KeyValue{
+		{Key: proto.String("k"), Value: proto.String("v")},
+	}
+	simple := Frame{
+		BaseCmd: &api.BaseCommand{
+			Type: api.BaseCommand_PING.Enum(),
+			Ping: &api.CommandPing{},
+		},
+	}
+
+	var wire bytes.Buffer
+	for i := 0; i < 10; i++ {
+		for _, f := range []Frame{withMeta, simple} {
+			if err := f.Encode(&wire); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+
+	for i := 0; i < 10; i++ {
+		for _, expected := range []Frame{withMeta, simple} {
+			var f Frame
+			if err := f.Decode(&wire); err != nil {
+				t.Fatal(err)
+			}
+			// nothing of released frames leaks into this one
+			if !f.Equal(expected) {
+				t.Fatalf("got frame %+v; expected %+v", f, expected)
+			}
+			msg := f.BaseCmd.GetMessage()
+
+			f.Release()
+			if f.BaseCmd != nil || f.Metadata != nil || f.Payload != nil {
+				t.Fatalf("released frame isn't reset: %+v", f)
+			}
+			// nested commands aren't reset
+			if expected.BaseCmd.GetMessage() != nil && !proto.Equal(msg, expected.BaseCmd.GetMessage()) {
+				t.Fatalf("got command %v; expected %v", msg, expected.BaseCmd.GetMessage())
+			}
+		}
+	}
+
+	// releasing nothing is fine
+	var empty Frame
+	empty.Release()
+}
+
+func TestNewPayload(t *testing.T) {
+	for _, tc := range []struct {
+		n, cap int
+	}{
+		{0, minPayloadCap},
+		{1, minPayloadCap},
+		{minPayloadCap, minPayloadCap},
+		{minPayloadCap + 1, 2 * minPayloadCap},
+		{1000, 1024},
+		{MaxFrameSize, 8 * 1024 * 1024},
+		{16 * 1024 * 1024, 16 * 1024 * 1024}, // too large to be pooled
+	} {
+		b := NewPayload(tc.n)
+		if len(b) != tc.n || cap(b) != tc.cap {
+			t.Fatalf("NewPayload(%d) len, cap = %d, %d; expected %d, %d", tc.n, len(b), cap(b), tc.n, tc.cap)
+		}
+		ReleasePayload(b)
+	}
+
+	// buffers not returned by NewPayload are ignored
+	ReleasePayload(make([]byte, 3, 1000))
+	ReleasePayload(nil)
+	if b := NewPayload(900); cap(b) != 1024 {
+		t.Fatalf("NewPayload(900) cap = %d; expected 1024", cap(b))
+	}
+}
+
+func TestFrame_DecodePooled(t *testing.T) {
+	f := benchFrame(1000)
+	var wire bytes.Buffer
+	if err := f.Encode(&wire); err != nil {
+		t.Fatal(err)
+	}
+
+	// through a buffered reader, then directly
+	for _, r := range []io.Reader{bufio.NewReader(bytes.NewReader(wire.Bytes())), bytes.NewReader(wire.Bytes())} {
+		var decoded Frame
+		if err := decoded.DecodePooled(r, ChecksumVerify); err != nil {
+			t.Fatal(err)
+		}
+		if !decoded.Equal(f) {
+			t.Fatalf("got frame %+v; expected %+v", decoded, f)
+		}
+		if !decoded.PooledPayload || cap(decoded.Payload) != 1024 {
+			t.Fatalf("got PooledPayload, cap = %t, %d; expected true, 1024", decoded.PooledPayload, cap(decoded.Payload))
+		}
+		decoded.Release()
+		if decoded.PooledPayload {
+			t.Fatalf("released frame isn't reset: %+v", decoded)
+		}
+	}
+
+	var decoded Frame
+	if err := decoded.Decode(&wire); err != nil {
+		t.Fatal(err)
+	}
+	if decoded.PooledPayload {
+		t.Fatal("Decode() set PooledPayload")
+	}
+}
+
+func BenchmarkFrameDecode_Release(b *testing.B) {
+	for _, size := range []int{64, 1024} {
+		f := benchFrame(size)
+		var wire bytes.Buffer
+		if err := f.Encode(&wire); err != nil {
+			b.Fatal(err)
+		}
+		b.Run(fmt.Sprintf("%dB", size), func(b *testing.B) {
+			r := bytes.NewReader(wire.Bytes())
+			b.SetBytes(int64(size))
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				r.Reset(wire.Bytes())
+				var decoded Frame
+				if err := decoded.Decode(r); err != nil {
+					b.Fatal(err)
+				}
+				decoded.Release()
+			}
+		})
+		b.Run(fmt.Sprintf("%dB/pooled", size), func(b *testing.B) {
+			r := bytes.NewReader(wire.Bytes())
+			b.SetBytes(int64(size))
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				r.Reset(wire.Bytes())
+				var decoded Frame
+				if err := decoded.DecodePooled(r, ChecksumVerify); err != nil {
+					b.Fatal(err)
+				}
+				decoded.Release()
+			}
+		})
+	}
+}
@@ -0,0 +1,222 @@
+// Copyright 2018 Comcast Cable Communications Management, LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package manage
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/pepper-iot/pulsar-client-go/core/msg"
+	"github.com/pepper-iot/pulsar-client-go/core/sub"
+)
+
+// CheckpointStore persists the position of ManagedConsumers, ie the ID
+// of the latest message they acknowledged, so that a restarted
+// ManagedConsumer resumes right after it, independently of the
+// subscription's cursor on the broker. See ConsumerConfig.Checkpoints.
+// Implementations must be safe for concurrent use.
+type CheckpointStore interface {
+	// Load returns the checkpoint of the subscription to the topic.
+	// ok is false if there is none.
+	Load(ctx context.Context, topic, subscription string) (id msg.MessageID, ok bool, err error)
+	// Save replaces the checkpoint of the subscription to the topic.
+	Save(ctx context.Context, topic, subscription string, id msg.MessageID) error
+}
+
+// MemoryCheckpointStore is a CheckpointStore keeping checkpoints in
+// memory, eg for ManagedConsumers that are recreated within a process.
+// Its zero value is ready to use.
+type MemoryCheckpointStore struct {
+	checkpoints sync.Map // checkpointKey -> msg.MessageID
+}
+
+type checkpointKey struct {
+	topic        string
+	subscription string
+}
+
+// Load implements CheckpointStore.
+func (s *MemoryCheckpointStore) Load(ctx context.Context, topic, subscription string) (msg.MessageID, bool, error) {
+	id, ok := s.checkpoints.Load(checkpointKey{topic, subscription})
+	if !ok {
+		return msg.MessageID{}, false, nil
+	}
+	return id.(msg.MessageID), true, nil
+}
+
+// Save implements CheckpointStore.
+func (s *MemoryCheckpointStore) Save(ctx context.Context, topic, subscription string, id msg.MessageID) error {
+	s.checkpoints.Store(checkpointKey{topic, subscription}, id)
+	return nil
+}
+
+// FileCheckpointStore is a CheckpointStore keeping checkpoints in a
+// JSON file, which is replaced atomically on each Save. It is meant
+// for a single process.
+type FileCheckpointStore struct {
+	path string
+
+	mu sync.Mutex // serializes writes
+}
+
+// NewFileCheckpointStore returns a FileCheckpointStore using the file
+// at path, which is created by the first Save.
+func NewFileCheckpointStore(path string) *FileCheckpointStore {
+	return &FileCheckpointStore{path: path}
+}
+
+// Load implements CheckpointStore.
+func (s *FileCheckpointStore) Load(ctx context.Context, topic, subscription string) (msg.MessageID, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	checkpoints, err := s.read()
+	if err != nil {
+		return msg.MessageID{}, false, err
+	}
+	id, ok := checkpoints[fileCheckpointKey(topic, subscription)]
+	return id, ok, nil
+}
+
+// Save implements CheckpointStore.
+func (s *FileCheckpointStore) Save(ctx context.Context, topic, subscription string, id msg.MessageID) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	checkpoints, err := s.read()
+	if err != nil {
+		return err
+	}
+	checkpoints[fileCheckpointKey(topic, subscription)] = id
+	data, err := json.MarshalIndent(checkpoints, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	// write a temporary file, then rename it, so
+	// that a crash never leaves a partial file
+	tmp, err := os.CreateTemp(filepath.Dir(s.path), filepath.Base(s.path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err = tmp.Write(data); err == nil {
+		err = tmp.Sync()
+	}
+	if cerr := tmp.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), s.path)
+}
+
+// read returns the checkpoints of the file, which are
+// empty if it doesn't exist. s.mu must be held.
+func (s *FileCheckpointStore) read() (map[string]msg.MessageID, error) {
+	checkpoints := make(map[string]msg.MessageID)
+	data, err := os.ReadFile(s.path)
+	if os.IsNotExist(err) {
+		return checkpoints, nil
+	} else if err != nil {
+		return nil, err
+	}
+	if err = json.Unmarshal(data, &checkpoints); err != nil {
+		return nil, err
+	}
+	return checkpoints, nil
+}
+
+func fileCheckpointKey(topic, subscription string) string {
+	return topic + "/" + subscription
+}
+
+// restoreCheckpoint seeks the first Consumer of the ManagedConsumer to
+// the loaded checkpoint, if any. Brokers reset the cursor to the
+// checkpointed message itself, so messages up to it are then skipped.
+func (m *ManagedConsumer) restoreCheckpoint(ctx context.Context, c *sub.Consumer) error {
+	id, ok, err := m.cfg.Checkpoints.Load(ctx, m.cfg.Topic, m.cfg.Name)
+	if err != nil || !ok {
+		return err
+	}
+	if err = c.Seek(ctx, id.Data()); err != nil {
+		return err
+	}
+
+	m.cmu.Lock()
+	m.saved = &id
+	m.skipTo = &id
+	m.cmu.Unlock()
+	return nil
+}
+
+// skipped reports whether msg was checkpointed before the
+// ManagedConsumer started, in which case it is acknowledged
+// for the subscription's cursor to move past it, and isn't
+// delivered. Skipping stops at the first message after the
+// checkpoint.
+func (m *ManagedConsumer) skipped(c *sub.Consumer, msg msg.Message) bool {
+	m.cmu.Lock()
+	skip := m.skipTo != nil && !m.skipTo.Before(msg.ID())
+	if !skip {
+		m.skipTo = nil
+	}
+	m.cmu.Unlock()
+
+	if !skip {
+		return false
+	}
+	if err := c.Ack(msg); err != nil {
+		m.sendErr(err)
+	}
+	msg.ReleasePayload()
+	return true
+}
+
+// acknowledged records the ID of an acknowledged
+// message as the next checkpoint, if it is the latest.
+func (m *ManagedConsumer) acknowledged(id msg.MessageID) {
+	m.cmu.Lock()
+	if m.lastAcked == nil || m.lastAcked.Before(id) {
+		m.lastAcked = &id
+	}
+	m.cmu.Unlock()
+}
+
+// saveCheckpoint saves the ID of the latest acknowledged
+// message, unless it is already the saved checkpoint.
+func (m *ManagedConsumer) saveCheckpoint(ctx context.Context) {
+	m.cmu.Lock()
+	id := m.lastAcked
+	if id == nil || (m.saved != nil && !m.saved.Before(*id)) {
+		m.cmu.Unlock()
+		return
+	}
+	m.cmu.Unlock()
+
+	if err := m.cfg.Checkpoints.Save(ctx, m.cfg.Topic, m.cfg.Name, *id); err != nil {
+		m.sendErr(err)
+		return
+	}
+
+	m.cmu.Lock()
+	if m.saved == nil || m.saved.Before(*id) {
+		m.saved = id
+	}
+	m.cmu.Unlock()
+}
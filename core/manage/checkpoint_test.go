@@ -0,0 +1,164 @@
+// Copyright 2018 Comcast Cable Communications Management, LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package manage
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/pepper-iot/pulsar-client-go/core/frame"
+	"github.com/pepper-iot/pulsar-client-go/core/msg"
+	"github.com/pepper-iot/pulsar-client-go/core/srv"
+	"github.com/pepper-iot/pulsar-client-go/pkg/api"
+)
+
+func TestCheckpointStore(t *testing.T) {
+	stores := map[string]CheckpointStore{
+		"memory": &MemoryCheckpointStore{},
+		"file":   NewFileCheckpointStore(filepath.Join(t.TempDir(), "checkpoints.json")),
+	}
+	for name, s := range stores {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+
+			if id, ok, err := s.Load(ctx, "topic", "sub"); err != nil || ok {
+				t.Fatalf("Load() = %v, %v, %v; expected no checkpoint", id, ok, err)
+			}
+
+			a := msg.MessageID{LedgerID: 1, EntryID: 2, Partition: -1, BatchIndex: -1}
+			b := msg.MessageID{LedgerID: 3, EntryID: 4, Partition: 5, BatchIndex: 6}
+			if err := s.Save(ctx, "topic", "sub", a); err != nil {
+				t.Fatal(err)
+			}
+			if err := s.Save(ctx, "topic", "other", a); err != nil {
+				t.Fatal(err)
+			}
+			if err := s.Save(ctx, "topic", "sub", b); err != nil {
+				t.Fatal(err)
+			}
+
+			for sub, expected := range map[string]msg.MessageID{"sub": b, "other": a} {
+				if id, ok, err := s.Load(ctx, "topic", sub); err != nil || !ok || id != expected {
+					t.Fatalf("Load(%q) = %v, %v, %v; expected %v, true, nil", sub, id, ok, err, expected)
+				}
+			}
+		})
+	}
+}
+
+func TestManagedConsumer_Checkpoints(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	srv, err := srv.NewServer(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	store := &MemoryCheckpointStore{}
+	checkpoint := msg.MessageID{LedgerID: 1, EntryID: 1, Partition: -1, BatchIndex: -1}
+	if err = store.Save(ctx, "test-topic", "test", checkpoint); err != nil {
+		t.Fatal(err)
+	}
+
+	mc := NewManagedConsumer(ctx, NewClientPool(), ConsumerConfig{
+		ClientConfig: ClientConfig{
+			Addr: srv.Addr,
+		},
+		NewConsumerTimeout: time.Second,
+		Topic:              "test-topic",
+		Name:               "test",
+		SubMode:            SubscriptionModeExclusive,
+		Checkpoints:        store,
+		CheckpointInterval: time.Hour,
+	})
+
+	// next returns the next frame of the given type
+	next := func(typ api.BaseCommand_Type) frame.Frame {
+		t.Helper()
+		for {
+			select {
+			case f := <-srv.Received:
+				if f.BaseCmd.GetType() == typ {
+					return f
+				}
+			case <-ctx.Done():
+				t.Fatalf("timeout waiting for %s message", typ)
+			}
+		}
+	}
+
+	consumerID := next(api.BaseCommand_SUBSCRIBE).BaseCmd.GetSubscribe().GetConsumerId()
+	seek := next(api.BaseCommand_SEEK).BaseCmd.GetSeek()
+	if got := msg.NewMessageID(seek.GetMessageId()); got != checkpoint {
+		t.Fatalf("SEEK message ID = %v; expected %v", got, checkpoint)
+	}
+
+	received := make(chan msg.Message, 1)
+	go func() {
+		m, err := mc.Receive(ctx)
+		if err != nil {
+			t.Error(err)
+		}
+		received <- m
+	}()
+
+	// the broker resends the checkpointed message
+	for entry := uint64(1); entry <= 2; entry++ {
+		message := frame.Frame{
+			BaseCmd: &api.BaseCommand{
+				Type: api.BaseCommand_MESSAGE.Enum(),
+				Message: &api.CommandMessage{
+					ConsumerId: proto.Uint64(consumerID),
+					MessageId: &api.MessageIdData{
+						LedgerId: proto.Uint64(1),
+						EntryId:  proto.Uint64(entry),
+					},
+				},
+			},
+			Metadata: &api.MessageMetadata{
+				ProducerName: proto.String("something"),
+				SequenceId:   proto.Uint64(entry),
+				PublishTime:  proto.Uint64(12345),
+			},
+			Payload: []byte("hola mundo"),
+		}
+		if err = srv.Broadcast(message); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	ack := next(api.BaseCommand_ACK).BaseCmd.GetAck()
+	if got := ack.GetMessageId()[0].GetEntryId(); got != 1 {
+		t.Fatalf("skipped message ACK entry ID = %d; expected 1", got)
+	}
+	m := <-received
+	if got := m.ID().EntryID; got != 2 {
+		t.Fatalf("Receive() entry ID = %d; expected 2", got)
+	}
+	if err = mc.Ack(ctx, m); err != nil {
+		t.Fatal(err)
+	}
+
+	// the final checkpoint is saved when closing
+	if err = mc.Close(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if id, ok, err := store.Load(ctx, "test-topic", "test"); err != nil || !ok || id != m.ID() {
+		t.Fatalf("Load() = %v, %v, %v; expected %v, true, nil", id, ok, err, m.ID())
+	}
+}
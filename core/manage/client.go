@@ -15,12 +15,16 @@ package manage
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"sync"
+	"sync/atomic"
 
 	"github.com/pepper-iot/pulsar-client-go/core/conn"
 	"github.com/pepper-iot/pulsar-client-go/core/frame"
 	"github.com/pepper-iot/pulsar-client-go/core/msg"
 	"github.com/pepper-iot/pulsar-client-go/core/pub"
+	"github.com/pepper-iot/pulsar-client-go/core/schema"
 	"github.com/pepper-iot/pulsar-client-go/core/srv"
 	"github.com/pepper-iot/pulsar-client-go/core/sub"
 	"github.com/pepper-iot/pulsar-client-go/pkg/api"
@@ -35,22 +39,30 @@ func NewClient(cfg ClientConfig) (*Client, error) {
 	var err error
 
 	if cfg.TLSConfig != nil {
-		cnx, err = conn.NewTLSConn(cfg.ConnAddr(), cfg.TLSConfig, cfg.DialTimeout)
+		cnx, err = conn.NewTLSConn(cfg.ConnAddr(), cfg.tlsConfig(), cfg.DialTimeout)
 	} else {
 		cnx, err = conn.NewTCPConn(cfg.ConnAddr(), cfg.DialTimeout)
 	}
 	if err != nil {
 		return nil, err
 	}
+	if cfg.FrameTap != nil {
+		cnx.SetTap(cfg.FrameTap)
+	}
+	if len(cfg.InboundInterceptors) > 0 || len(cfg.OutboundInterceptors) > 0 {
+		cnx.SetInterceptors(cfg.InboundInterceptors, cfg.OutboundInterceptors)
+	}
 
 	reqID := msg.MonotonicID{0}
 
-	dispatcher := frame.NewFrameDispatcher()
+	dispatcher := frame.NewFrameDispatcherWithClock(cfg.clock())
 	subs := sub.NewSubscriptions()
 
 	c := &Client{
 		C:         cnx,
 		AsyncErrs: utils.AsyncErrors(cfg.Errs),
+		scope:     connScope(cfg),
+		listener:  cfg.ErrorListener,
 
 		Dispatcher:    dispatcher,
 		Subscriptions: subs,
@@ -63,6 +75,13 @@ func NewClient(cfg ClientConfig) (*Client, error) {
 		Pubsub:     sub.NewPubsub(cnx, dispatcher, subs, &reqID),
 	}
 
+	if cfg.ChecksumPolicy != frame.ChecksumVerify {
+		cnx.SetChecksumPolicy(cfg.ChecksumPolicy, c.handleCorruptFrame)
+	}
+	if cfg.PooledPayloads {
+		cnx.SetPooledPayloads(true)
+	}
+
 	handler := func(f frame.Frame) {
 		// All message types can be handled in
 		// parallel, since their ordering should not matter
@@ -76,16 +95,20 @@ func NewClient(cfg ClientConfig) (*Client, error) {
 	}
 
 	go func() {
+		// frames are handled on goroutines started from
+		// here, which are labeled as well
+		setLabels("role", "conn", "broker", cfg.Addr)
+
 		// If core.read() unblocks, it indicates that
 		// the connection has been closed and is no longer usable.
 		defer func() {
-			if err := c.Close(); err != nil {
-				c.AsyncErrs.Send(err)
+			if err := c.C.Close(); err != nil {
+				c.sendErr(err)
 			}
 		}()
 
 		if err := cnx.Read(handler); err != nil {
-			c.AsyncErrs.Send(err)
+			c.sendErr(err)
 		}
 	}()
 
@@ -105,6 +128,21 @@ type Client struct {
 	Pinger        *srv.Pinger
 	Discoverer    *srv.Discoverer
 	Pubsub        *sub.Pubsub
+
+	scope       ErrorScope    // of asynchronous errors
+	listener    ErrorListener // may be nil
+	droppedErrs uint64        // asynchronous errors dropped because AsyncErrs was full; accessed atomically
+
+	connected atomic.Value // *api.CommandConnected, once connected
+
+	rmu sync.RWMutex // protects following
+	raw map[api.BaseCommand_Type]RawHandler
+}
+
+// sendErr reports err to the async errors
+// channel and the ErrorListener.
+func (c *Client) sendErr(err error) {
+	reportErr(c.AsyncErrs, &c.droppedErrs, c.listener, err, c.scope)
 }
 
 // Closed returns a channel that unblocks when the client's connection
@@ -115,10 +153,92 @@ func (c *Client) Closed() <-chan struct{} {
 	return c.C.Closed()
 }
 
-// Close closes the connection. The channel returned from `Closed` will unblock.
-// The client should no longer be used after calling Close.
-func (c *Client) Close() error {
-	return c.C.Close()
+// Drain puts the client in lame-duck mode: consumers stop requesting
+// messages and producers reject new sends, while the receipts of
+// outstanding sends are waited for until ctx is done. Messages already
+// requested are still delivered. The client remains connected, and
+// Close should be called once draining is complete.
+func (c *Client) Drain(ctx context.Context) error {
+	producers, consumers := c.entities()
+
+	for _, cs := range consumers {
+		cs.StopFlow()
+	}
+	for _, p := range producers {
+		p.StopSends()
+	}
+
+	var errs []error
+	for _, p := range producers {
+		if err := p.Flush(ctx); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	if len(errs) > 0 {
+		return errs[0]
+	}
+	return nil
+}
+
+// Close gracefully shuts down the client. The client is first drained
+// (see Drain), then all producers and consumers are closed before
+// finally closing the connection. The channel returned from `Closed` will
+// unblock. The first error encountered is returned, but the connection is
+// always closed. The client should no longer be used after calling Close.
+func (c *Client) Close(ctx context.Context) error {
+	select {
+	case <-c.Closed():
+		// nothing can be flushed or closed
+		// over a closed connection
+		return nil
+	default:
+	}
+
+	var errs []error
+
+	if err := c.Drain(ctx); err != nil {
+		errs = append(errs, err)
+	}
+
+	producers, consumers := c.entities()
+	for _, p := range producers {
+		if err := p.Close(ctx); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	for _, cs := range consumers {
+		if err := cs.Close(ctx); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if err := c.C.Close(); err != nil {
+		errs = append(errs, err)
+	}
+
+	if len(errs) > 0 {
+		return errs[0]
+	}
+	return nil
+}
+
+// entities returns the client's current producers and consumers.
+func (c *Client) entities() ([]*pub.Producer, []*sub.Consumer) {
+	c.Subscriptions.Pmu.Lock()
+	producers := make([]*pub.Producer, 0, len(c.Subscriptions.Producers))
+	for _, p := range c.Subscriptions.Producers {
+		producers = append(producers, p)
+	}
+	c.Subscriptions.Pmu.Unlock()
+
+	c.Subscriptions.Cmu.RLock()
+	consumers := make([]*sub.Consumer, 0, len(c.Subscriptions.Consumers))
+	for _, cs := range c.Subscriptions.Consumers {
+		consumers = append(consumers, cs)
+	}
+	c.Subscriptions.Cmu.RUnlock()
+
+	return producers, consumers
 }
 
 // Connect sends a Connect message to the Pulsar server, then
@@ -132,7 +252,7 @@ func (c *Client) Close() error {
 // See "Connection establishment" for more info:
 // https://pulsar.incubator.apache.org/docs/latest/project/BinaryProtocol/#Connectionestablishment-6pslvw
 func (c *Client) Connect(ctx context.Context, proxyBrokerURL string) (*api.CommandConnected, error) {
-	return c.Connector.Connect(ctx, "", proxyBrokerURL)
+	return c.connect(ctx, "", proxyBrokerURL)
 }
 
 // ConnectTLS sends a Connect message to the Pulsar server, then
@@ -145,7 +265,25 @@ func (c *Client) Connect(ctx context.Context, proxyBrokerURL string) (*api.Comma
 // See "Connection establishment" for more info:
 // https://pulsar.incubator.apache.org/docs/latest/project/BinaryProtocol/#Connectionestablishment-6pslvw
 func (c *Client) ConnectTLS(ctx context.Context, proxyBrokerURL string) (*api.CommandConnected, error) {
-	return c.Connector.Connect(ctx, utils.AuthMethodTLS, proxyBrokerURL)
+	return c.connect(ctx, utils.AuthMethodTLS, proxyBrokerURL)
+}
+
+// connect sends the Connect message, and
+// remembers the server's CONNECTED response.
+func (c *Client) connect(ctx context.Context, authMethod, proxyBrokerURL string) (*api.CommandConnected, error) {
+	connected, err := c.Connector.Connect(ctx, authMethod, proxyBrokerURL)
+	if err != nil {
+		return nil, err
+	}
+	c.connected.Store(connected)
+	return connected, nil
+}
+
+// features returns the features supported by the server,
+// or nil until Connect has succeeded.
+func (c *Client) features() *api.FeatureFlags {
+	connected, _ := c.connected.Load().(*api.CommandConnected)
+	return connected.GetFeatureFlags()
 }
 
 // Ping sends a PING message to the Pulsar server, then
@@ -168,50 +306,134 @@ func (c *Client) LookupTopic(ctx context.Context, topic string, authoritative bo
 	return c.Discoverer.LookupTopic(ctx, topic, authoritative)
 }
 
+// GetSchema fetches the given version of the topic's schema, or the
+// latest one if version is nil. The version of the returned schema
+// is returned along with it.
+func (c *Client) GetSchema(ctx context.Context, topic string, version []byte) (schema.Info, []byte, error) {
+	resp, err := c.Discoverer.GetSchema(ctx, topic, version)
+	if err != nil {
+		return schema.Info{}, nil, err
+	}
+	if resp.ErrorCode != nil {
+		return schema.Info{}, nil, utils.NewServerError(resp.GetErrorCode(), resp.GetErrorMessage())
+	}
+
+	s := resp.GetSchema()
+	info := schema.Info{
+		Name:   s.GetName(),
+		Type:   s.GetType(),
+		Schema: s.GetSchemaData(),
+	}
+	if len(s.GetProperties()) > 0 {
+		info.Properties = make(map[string]string, len(s.GetProperties()))
+		for _, kv := range s.GetProperties() {
+			info.Properties[kv.GetKey()] = kv.GetValue()
+		}
+	}
+	return info, resp.GetSchemaVersion(), nil
+}
+
+// GetOrCreateSchema registers the schema with the broker as a version
+// of the topic's schema, unless it is already one, without creating a
+// producer, and returns its version. The broker fails if the schema
+// isn't compatible with the topic's.
+func (c *Client) GetOrCreateSchema(ctx context.Context, topic string, s schema.Schema) ([]byte, error) {
+	resp, err := c.Discoverer.GetOrCreateSchema(ctx, topic, s.Info().Proto())
+	if err != nil {
+		return nil, err
+	}
+	if resp.ErrorCode != nil {
+		return nil, utils.NewServerError(resp.GetErrorCode(), resp.GetErrorMessage())
+	}
+	return resp.GetSchemaVersion(), nil
+}
+
+// ErrTopicWatchersUnsupported is returned by WatchTopicList
+// if the broker doesn't support topic list watchers.
+var ErrTopicWatchersUnsupported = errors.New("broker doesn't support topic list watchers")
+
+// WatchTopicList watches the topics of the namespace matching the
+// pattern, for pattern subscriptions. Updates are sent to the updates
+// channel. Brokers before Pulsar 2.11 don't support it, in which case
+// ErrTopicWatchersUnsupported is returned.
+func (c *Client) WatchTopicList(ctx context.Context, namespace, pattern string, updates chan sub.TopicListUpdate) (*sub.TopicListWatcher, error) {
+	if !c.features().GetSupportsTopicWatchers() {
+		return nil, ErrTopicWatchersUnsupported
+	}
+	return c.Pubsub.WatchTopicList(ctx, namespace, pattern, updates)
+}
+
 // NewProducer creates a new producer capable of sending message to the
 // given topic.
 func (c *Client) NewProducer(ctx context.Context, topic, producerName string) (*pub.Producer, error) {
 	return c.Pubsub.Producer(ctx, topic, producerName)
 }
 
+// NewProducerWithSchema is like NewProducer, but registers the
+// producer's schema with the broker.
+func (c *Client) NewProducerWithSchema(ctx context.Context, topic, producerName string, s schema.Schema) (*pub.Producer, error) {
+	return c.Pubsub.ProducerWithSchema(ctx, topic, producerName, s.Info().Proto())
+}
+
 // NewSharedConsumer creates a new shared consumer capable of reading messages from the
 // given topic.
 // See "Subscription modes" for more information:
 // https://pulsar.incubator.apache.org/docs/latest/getting-started/ConceptsAndArchitecture/#Subscriptionmodes-jdrefl
-func (c *Client) NewSharedConsumer(ctx context.Context, topic, subscriptionName string, earliest bool, queue chan msg.Message) (*sub.Consumer, error) {
-	initialPosition := api.CommandSubscribe_Latest
-	if earliest {
-		initialPosition = api.CommandSubscribe_Earliest
-	}
-	return c.Pubsub.Subscribe(ctx, topic, subscriptionName, api.CommandSubscribe_Shared, initialPosition, queue)
+func (c *Client) NewSharedConsumer(ctx context.Context, topic, subscriptionName string, initialPosition sub.InitialPosition, queue chan msg.Message) (*sub.Consumer, error) {
+	return c.NewConsumerWithSchema(ctx, topic, subscriptionName, api.CommandSubscribe_Shared, initialPosition, queue, nil)
 }
 
 // NewExclusiveConsumer creates a new exclusive consumer capable of reading messages from the
 // given topic.
 // See "Subscription modes" for more information:
 // https://pulsar.incubator.apache.org/docs/latest/getting-started/ConceptsAndArchitecture/#Subscriptionmodes-jdrefl
-func (c *Client) NewExclusiveConsumer(ctx context.Context, topic, subscriptionName string, earliest bool, queue chan msg.Message) (*sub.Consumer, error) {
-	initialPosition := api.CommandSubscribe_Latest
-	if earliest {
-		initialPosition = api.CommandSubscribe_Earliest
-	}
-	return c.Pubsub.Subscribe(ctx, topic, subscriptionName, api.CommandSubscribe_Exclusive, initialPosition, queue)
+func (c *Client) NewExclusiveConsumer(ctx context.Context, topic, subscriptionName string, initialPosition sub.InitialPosition, queue chan msg.Message) (*sub.Consumer, error) {
+	return c.NewConsumerWithSchema(ctx, topic, subscriptionName, api.CommandSubscribe_Exclusive, initialPosition, queue, nil)
 }
 
 // NewFailoverConsumer creates a new failover consumer capable of reading messages from the
 // given topic.
 // See "Subscription modes" for more information:
 // https://pulsar.incubator.apache.org/docs/latest/getting-started/ConceptsAndArchitecture/#Subscriptionmodes-jdrefl
-func (c *Client) NewFailoverConsumer(ctx context.Context, topic, subscriptionName string, earliest bool, queue chan msg.Message) (*sub.Consumer, error) {
-	initialPosition := api.CommandSubscribe_Latest
-	if earliest {
-		initialPosition = api.CommandSubscribe_Earliest
+func (c *Client) NewFailoverConsumer(ctx context.Context, topic, subscriptionName string, initialPosition sub.InitialPosition, queue chan msg.Message) (*sub.Consumer, error) {
+	return c.NewConsumerWithSchema(ctx, topic, subscriptionName, api.CommandSubscribe_Failover, initialPosition, queue, nil)
+}
+
+// NewConsumerWithSchema creates a new consumer of the given subscription type,
+// registering the consumer's schema with the broker. The schema may be nil.
+func (c *Client) NewConsumerWithSchema(ctx context.Context, topic, subscriptionName string, subType api.CommandSubscribe_SubType, initialPosition sub.InitialPosition, queue chan msg.Message, s schema.Schema) (*sub.Consumer, error) {
+	var info *api.Schema
+	if s != nil {
+		info = s.Info().Proto()
 	}
-	return c.Pubsub.Subscribe(ctx, topic, subscriptionName, api.CommandSubscribe_Failover, initialPosition, queue)
+	return c.Pubsub.SubscribeWithSchema(ctx, topic, subscriptionName, subType, initialPosition, queue, info)
+}
+
+// handleCorruptFrame is called by the underlaying core with
+// received Frames whose checksum doesn't match, with the
+// frame.ChecksumReport policy. The frame is released once
+// handled, like those of handleFrame.
+func (c *Client) handleCorruptFrame(f frame.Frame, err error) {
+	defer f.Release()
+	if f.BaseCmd.GetType() == api.BaseCommand_MESSAGE {
+		err = c.Subscriptions.HandleCorruptMessage(f.BaseCmd.GetMessage().GetConsumerId(), f, err)
+	}
+	c.sendErr(err)
 }
 
 // handleFrame is called by the underlaying core with
 // all received Frames.
+//
+// Received frames are decoded into pooled BaseCommands and
+// MessageMetadatas (see frame.NewBaseCommand), which are owned as
+// follows. Responses are handed over to the goroutines waiting for
+// them, which then own them. The metadata and payload of a MESSAGE
+// are owned by the msg.Message it is queued as, which applications
+// release with msg.Message.Release, while its BaseCmd is released
+// once handled. Other frames are released once handled. Handlers
+// must therefore not retain the frames they're given, though they
+// may retain the commands these contain, e.g. the CommandMessage of
+// a MESSAGE, which aren't reset when released.
 func (c *Client) handleFrame(f frame.Frame) {
 	var err error
 
@@ -245,6 +467,18 @@ func (c *Client) handleFrame(f frame.Frame) {
 	case api.BaseCommand_PRODUCER_SUCCESS:
 		err = c.Dispatcher.NotifyReqID(f.BaseCmd.GetProducerSuccess().GetRequestId(), f)
 
+	case api.BaseCommand_GET_SCHEMA_RESPONSE:
+		err = c.Dispatcher.NotifyReqID(f.BaseCmd.GetGetSchemaResponse().GetRequestId(), f)
+
+	case api.BaseCommand_GET_OR_CREATE_SCHEMA_RESPONSE:
+		err = c.Dispatcher.NotifyReqID(f.BaseCmd.GetGetOrCreateSchemaResponse().GetRequestId(), f)
+
+	case api.BaseCommand_WATCH_TOPIC_LIST_SUCCESS:
+		err = c.Dispatcher.NotifyReqID(f.BaseCmd.GetWatchTopicListSuccess().GetRequestId(), f)
+
+	case api.BaseCommand_GET_LAST_MESSAGE_ID_RESPONSE:
+		err = c.Dispatcher.NotifyReqID(f.BaseCmd.GetGetLastMessageIdResponse().GetRequestId(), f)
+
 	// Solicited responses with a (producerID, sequenceID) tuple to correlate
 	// it to its request
 
@@ -259,33 +493,67 @@ func (c *Client) handleFrame(f frame.Frame) {
 	// Unsolicited responses that have a producer ID
 
 	case api.BaseCommand_CLOSE_PRODUCER:
+		defer f.Release()
 		err = c.Subscriptions.HandleCloseProducer(f.BaseCmd.GetCloseProducer().GetProducerId(), f)
 
 	// Unsolicited responses that have a consumer ID
 
 	case api.BaseCommand_CLOSE_CONSUMER:
+		defer f.Release()
 		err = c.Subscriptions.HandleCloseConsumer(f.BaseCmd.GetCloseConsumer().GetConsumerId(), f)
 
 	case api.BaseCommand_REACHED_END_OF_TOPIC:
+		defer f.Release()
 		err = c.Subscriptions.HandleReachedEndOfTopic(f.BaseCmd.GetReachedEndOfTopic().GetConsumerId(), f)
 
 	case api.BaseCommand_MESSAGE:
+		defer frame.ReleaseBaseCommand(f.BaseCmd)
 		err = c.Subscriptions.HandleMessage(f.BaseCmd.GetMessage().GetConsumerId(), f)
 
+	// Unsolicited responses that have a watcher ID
+
+	case api.BaseCommand_WATCH_TOPIC_UPDATE:
+		defer f.Release()
+		err = c.Subscriptions.HandleTopicListUpdate(f.BaseCmd.GetWatchTopicUpdate().GetWatcherId(), f)
+
 	// Unsolicited responses
 
 	case api.BaseCommand_PING:
+		defer f.Release()
 		err = c.Pinger.HandlePing(msgType, f.BaseCmd.GetPing())
 
 	// In the failover subscription mode,
 	// all consumers receive ACTIVE_CONSUMER_CHANGE when a new subscriber is created or a subscriber exits.
 	case api.BaseCommand_ACTIVE_CONSUMER_CHANGE:
+		defer f.Release()
 		err = c.Subscriptions.HandleActiveConsumerChange(f.BaseCmd.GetActiveConsumerChange().GetConsumerId(), f)
+
+	// The topic was migrated to another cluster. The producer or
+	// consumer is closed, and managed producers and consumers then
+	// reconnect by looking the topic up on the new cluster.
+	case api.BaseCommand_TOPIC_MIGRATED:
+		defer f.Release()
+		migrated := f.BaseCmd.GetTopicMigrated()
+		switch migrated.GetResourceType() {
+		case api.CommandTopicMigrated_Producer:
+			err = c.Subscriptions.HandleCloseProducer(migrated.GetResourceId(), f)
+		case api.CommandTopicMigrated_Consumer:
+			err = c.Subscriptions.HandleCloseConsumer(migrated.GetResourceId(), f)
+		}
+
 	default:
-		err = fmt.Errorf("unhandled message of type %q", f.BaseCmd.GetType())
+		if h := c.rawHandler(msgType); h != nil {
+			defer f.Release()
+			err = h(f)
+		} else if reqID, ok := requestID(f.BaseCmd); ok {
+			// response to a command sent with RequestRaw
+			err = c.Dispatcher.NotifyReqID(reqID, f)
+		} else {
+			err = fmt.Errorf("unhandled message of type %q", f.BaseCmd.GetType())
+		}
 	}
 
 	if err != nil {
-		c.AsyncErrs.Send(err)
+		c.sendErr(err)
 	}
 }
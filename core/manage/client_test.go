@@ -23,11 +23,235 @@ import (
 	"time"
 
 	"github.com/pepper-iot/pulsar-client-go/core/msg"
+	"github.com/pepper-iot/pulsar-client-go/core/pub"
+	"github.com/pepper-iot/pulsar-client-go/core/schema"
+	"github.com/pepper-iot/pulsar-client-go/core/srv"
 	"github.com/pepper-iot/pulsar-client-go/core/sub"
 	"github.com/pepper-iot/pulsar-client-go/pkg/api"
 	"github.com/pepper-iot/pulsar-client-go/utils"
 )
 
+// TestClient_Close creates a producer and a consumer, and asserts
+// that Close shuts them down before closing the connection.
+func TestClient_Close(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	srv, err := srv.NewServer(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c, err := NewClient(ClientConfig{
+		Addr: srv.Addr,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = c.Connect(ctx, ""); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = c.NewProducer(ctx, "test-topic", "test"); err != nil {
+		t.Fatal(err)
+	}
+	cs, err := c.NewSharedConsumer(ctx, "test-topic", "test", sub.Latest, make(chan msg.Message, 1))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectedFrames := []api.BaseCommand_Type{
+		api.BaseCommand_CONNECT,
+		api.BaseCommand_PRODUCER,
+		api.BaseCommand_SUBSCRIBE,
+	}
+	if err = srv.AssertReceived(ctx, expectedFrames...); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = c.Close(ctx); err != nil {
+		t.Fatalf("Close() err = %v; expected nil", err)
+	}
+
+	expectedFrames = []api.BaseCommand_Type{
+		api.BaseCommand_CLOSE_PRODUCER,
+		api.BaseCommand_CLOSE_CONSUMER,
+	}
+	if err = srv.AssertReceived(ctx, expectedFrames...); err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case <-c.Closed():
+	default:
+		t.Fatal("client is NOT closed; expected to be")
+	}
+
+	// no more permits are requested after Close
+	if err = cs.Flow(1); err != nil {
+		t.Fatalf("Flow() err = %v; expected nil", err)
+	}
+}
+
+func TestClient_Drain(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	srv, err := srv.NewServer(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c, err := NewClient(ClientConfig{
+		Addr: srv.Addr,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = c.Connect(ctx, ""); err != nil {
+		t.Fatal(err)
+	}
+	p, err := c.NewProducer(ctx, "test-topic", "test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	cs, err := c.NewSharedConsumer(ctx, "test-topic", "test", sub.Latest, make(chan msg.Message, 1))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectedFrames := []api.BaseCommand_Type{
+		api.BaseCommand_CONNECT,
+		api.BaseCommand_PRODUCER,
+		api.BaseCommand_SUBSCRIBE,
+	}
+	if err = srv.AssertReceived(ctx, expectedFrames...); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = c.Drain(ctx); err != nil {
+		t.Fatalf("Drain() err = %v; expected nil", err)
+	}
+
+	if _, err = p.Send(ctx, []byte("hi")); err != pub.ErrStoppedProducer {
+		t.Fatalf("Send() err = %v; expected %v", err, pub.ErrStoppedProducer)
+	}
+	// no more permits are requested after Drain
+	if err = cs.Flow(1); err != nil {
+		t.Fatalf("Flow() err = %v; expected nil", err)
+	}
+
+	select {
+	case f := <-srv.Received:
+		t.Fatalf("got unexpected frame of type %q", f.BaseCmd.GetType())
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	select {
+	case <-c.Closed():
+		t.Fatal("client is closed; expected NOT to be")
+	default:
+	}
+}
+
+func TestClient_GetSchema(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	srv, err := srv.NewServer(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv.SetSchema("test-topic", schema.NewJSON("{}").Info().Proto(), []byte{7})
+
+	c, err := NewClient(ClientConfig{
+		Addr: srv.Addr,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = c.Connect(ctx, ""); err != nil {
+		t.Fatal(err)
+	}
+
+	info, version, err := c.GetSchema(ctx, "test-topic", nil)
+	if err != nil {
+		t.Fatalf("GetSchema() err = %v; expected nil", err)
+	}
+	if got, expected := info.Type, api.Schema_Json; got != expected {
+		t.Fatalf("GetSchema() type = %v; expected %v", got, expected)
+	}
+	if got, expected := string(version), "\x07"; got != expected {
+		t.Fatalf("GetSchema() version = %q; expected %q", got, expected)
+	}
+
+	if _, _, err = c.GetSchema(ctx, "other-topic", nil); err == nil {
+		t.Fatal("GetSchema() err = nil; expected error for topic without schema")
+	}
+}
+
+func TestClient_GetOrCreateSchema(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	srv, err := srv.NewServer(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c, err := NewClient(ClientConfig{
+		Addr: srv.Addr,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = c.Connect(ctx, ""); err != nil {
+		t.Fatal(err)
+	}
+
+	s := schema.NewJSON(`{"type":"record","name":"Test","fields":[]}`)
+	version, err := c.GetOrCreateSchema(ctx, "test-topic", s)
+	if err != nil {
+		t.Fatalf("GetOrCreateSchema() err = %v; expected nil", err)
+	}
+	// registering it again returns the same version
+	if again, err := c.GetOrCreateSchema(ctx, "test-topic", s); err != nil || string(again) != string(version) {
+		t.Fatalf("GetOrCreateSchema() = %q, %v; expected %q, nil", again, err, version)
+	}
+
+	info, got, err := c.GetSchema(ctx, "test-topic", version)
+	if err != nil {
+		t.Fatalf("GetSchema() err = %v; expected nil", err)
+	}
+	if string(got) != string(version) || string(info.Schema) != string(s.Info().Schema) {
+		t.Fatalf("GetSchema() = %+v, %q; expected the registered schema, %q", info, got, version)
+	}
+}
+
+func TestClient_WatchTopicList_Unsupported(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	srv, err := srv.NewServer(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c, err := NewClient(ClientConfig{
+		Addr: srv.Addr,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = c.Connect(ctx, ""); err != nil {
+		t.Fatal(err)
+	}
+
+	// the mock server doesn't advertise topic watchers
+	if _, err = c.WatchTopicList(ctx, "public/default", ".*", nil); err != ErrTopicWatchersUnsupported {
+		t.Fatalf("WatchTopicList() err = %v; expected %v", err, ErrTopicWatchersUnsupported)
+	}
+}
+
 // TestClient_Int_PubSub creates a producer and multiple consumers.
 // Messages are created by the producer, and then it is asserted
 // that all the consumers receive those messages.
@@ -86,7 +310,7 @@ func TestClient_Int_PubSub(t *testing.T) {
 	subName := utils.RandString(16)
 	for i := range consumers {
 		name := fmt.Sprintf("%s-%d", subName, i)
-		consumers[i], err = c.NewExclusiveConsumer(ctx, topic, name, false, make(chan msg.Message, N))
+		consumers[i], err = c.NewExclusiveConsumer(ctx, topic, name, sub.Latest, make(chan msg.Message, N))
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -222,7 +446,7 @@ func TestClient_Int_ServerInitiatedTopicClose(t *testing.T) {
 	t.Log(topicResp.String())
 
 	subscriptionName := utils.RandString(32)
-	topicConsumer, err := c.NewExclusiveConsumer(ctx, topic, subscriptionName, false, make(chan msg.Message, 1))
+	topicConsumer, err := c.NewExclusiveConsumer(ctx, topic, subscriptionName, sub.Latest, make(chan msg.Message, 1))
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -351,7 +575,7 @@ func TestClient_Int_Unsubscribe(t *testing.T) {
 				}
 				t.Log(topicResp.String())
 
-				topicConsumer, err := c.NewExclusiveConsumer(ctx, topic, utils.RandString(32), false, make(chan msg.Message, 1))
+				topicConsumer, err := c.NewExclusiveConsumer(ctx, topic, utils.RandString(32), sub.Latest, make(chan msg.Message, 1))
 				if err != nil {
 					t.Fatal(err)
 				}
@@ -426,7 +650,7 @@ func TestClient_Int_RedeliverOverflow(t *testing.T) {
 	}
 
 	// create single consumer with buffer size 1
-	cs, err := c.NewSharedConsumer(ctx, topic, utils.RandString(16), make(chan msg.Message, 1))
+	cs, err := c.NewSharedConsumer(ctx, topic, utils.RandString(16), sub.Latest, make(chan msg.Message, 1))
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -562,7 +786,7 @@ func TestClient_Int_RedeliverAll(t *testing.T) {
 	}
 
 	// create single consumer with buffer size N
-	cs, err := c.NewExclusiveConsumer(ctx, topic, utils.RandString(16), false, make(chan msg.Message, N))
+	cs, err := c.NewExclusiveConsumer(ctx, topic, utils.RandString(16), sub.Latest, make(chan msg.Message, N))
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -0,0 +1,75 @@
+// Copyright 2018 Comcast Cable Communications Management, LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package manage
+
+import (
+	"strings"
+)
+
+// Credentials are the authentication method and data
+// sent by a connection in its CONNECT command.
+type Credentials struct {
+	AuthMethod string
+	AuthData   []byte
+}
+
+// CredentialsProvider selects the Credentials of the connections used
+// for the topics of a namespace, eg so that a multi-tenant gateway
+// publishes on behalf of each tenant with its own token. ok is false
+// to use those of the ClientConfig. It is called for each topic lookup,
+// so it should be fast, eg by caching the credentials.
+type CredentialsProvider interface {
+	Credentials(tenant, namespace string) (creds Credentials, ok bool)
+}
+
+// CredentialsProviderFunc adapts a function to a CredentialsProvider.
+type CredentialsProviderFunc func(tenant, namespace string) (Credentials, bool)
+
+// Credentials calls f(tenant, namespace).
+func (f CredentialsProviderFunc) Credentials(tenant, namespace string) (Credentials, bool) {
+	return f(tenant, namespace)
+}
+
+// withCredentials returns cfg with the Credentials selected for the
+// namespace of topic, if any.
+func (m *ClientPool) withCredentials(cfg ClientConfig, topic string) ClientConfig {
+	if m.credentials == nil {
+		return cfg
+	}
+	if creds, ok := m.credentials.Credentials(topicNamespace(topic)); ok {
+		cfg.AuthMethod = creds.AuthMethod
+		cfg.AuthData = creds.AuthData
+	}
+	return cfg
+}
+
+// topicNamespace returns the tenant and namespace of a topic name,
+// which may be short ("my-topic", in public/default), complete
+// ("persistent://tenant/namespace/my-topic"), or of the legacy
+// format including a cluster ("persistent://tenant/cluster/namespace/my-topic"),
+// whose namespace is then "cluster/namespace".
+func topicNamespace(topic string) (tenant, namespace string) {
+	if i := strings.Index(topic, "://"); i >= 0 {
+		topic = topic[i+len("://"):]
+	}
+	parts := strings.Split(topic, "/")
+	switch len(parts) {
+	case 1:
+		return "public", "default"
+	case 2, 3:
+		return parts[0], parts[1]
+	default:
+		return parts[0], parts[1] + "/" + parts[2]
+	}
+}
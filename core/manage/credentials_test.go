@@ -0,0 +1,101 @@
+// Copyright 2018 Comcast Cable Communications Management, LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package manage
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/pepper-iot/pulsar-client-go/core/srv"
+	"github.com/pepper-iot/pulsar-client-go/pkg/api"
+)
+
+func TestTopicNamespace(t *testing.T) {
+	tests := []struct {
+		topic     string
+		tenant    string
+		namespace string
+	}{
+		{"my-topic", "public", "default"},
+		{"persistent://tenant/ns/my-topic", "tenant", "ns"},
+		{"non-persistent://tenant/ns/my-topic", "tenant", "ns"},
+		{"tenant/ns/my-topic", "tenant", "ns"},
+		{"persistent://tenant/cluster/ns/my-topic", "tenant", "cluster/ns"},
+	}
+	for _, tt := range tests {
+		if tenant, namespace := topicNamespace(tt.topic); tenant != tt.tenant || namespace != tt.namespace {
+			t.Errorf("topicNamespace(%q) = (%q, %q); expected (%q, %q)", tt.topic, tenant, namespace, tt.tenant, tt.namespace)
+		}
+	}
+}
+
+func TestManagedClientPool_Credentials(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	srv, err := srv.NewServer(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cp := NewClientPoolWithConfig(ClientPoolConfig{
+		Credentials: CredentialsProviderFunc(func(tenant, namespace string) (Credentials, bool) {
+			if tenant == "other" {
+				return Credentials{}, false
+			}
+			return Credentials{AuthMethod: "token", AuthData: []byte(tenant + "/" + namespace)}, true
+		}),
+	})
+	cfg := ClientConfig{
+		Addr:       srv.Addr,
+		AuthMethod: "token",
+		AuthData:   []byte("default"),
+	}
+
+	clients := make(map[*ManagedClient]bool)
+	for _, topic := range []string{
+		"persistent://a/ns/topic",
+		"persistent://a/ns/other-topic",
+		"persistent://b/ns/topic",
+		"persistent://other/ns/topic",
+	} {
+		mc, err := cp.ForTopic(ctx, cfg, topic)
+		if err != nil {
+			t.Fatalf("ForTopic(%q) err = %v; expected nil", topic, err)
+		}
+		clients[mc] = true
+	}
+	if got, expected := len(clients), 3; got != expected {
+		t.Fatalf("got %d ManagedClients; expected %d", got, expected)
+	}
+
+	var authData []string
+	for len(authData) < 3 {
+		select {
+		case f := <-srv.Received:
+			if f.BaseCmd.GetType() == api.BaseCommand_CONNECT {
+				authData = append(authData, string(f.BaseCmd.GetConnect().GetAuthData()))
+			}
+		case <-ctx.Done():
+			t.Fatal("timeout waiting for CONNECT messages")
+		}
+	}
+	sort.Strings(authData)
+	if got, expected := authData, []string{"a/ns", "b/ns", "default"}; fmt.Sprint(got) != fmt.Sprint(expected) {
+		t.Fatalf("CONNECT auth data = %q; expected %q", got, expected)
+	}
+}
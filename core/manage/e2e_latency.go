@@ -0,0 +1,88 @@
+// Copyright 2018 Comcast Cable Communications Management, LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package manage
+
+import (
+	"sync"
+	"time"
+
+	"github.com/pepper-iot/pulsar-client-go/core/msg"
+	"github.com/pepper-iot/pulsar-client-go/utils"
+)
+
+// e2eLatency measures the latency from the publish time of messages,
+// set by their producer, to the local time they were received at.
+//
+// The clocks of the producers and of the consumer may be skewed. A
+// message can't be received before it was published, so when the
+// consumer's clock is behind, the smallest difference observed is
+// negative, and its opposite is a lower bound of the skew. Latencies
+// are corrected by this estimate. A consumer clock ahead of the
+// producers' can't be told apart from actual latency.
+type e2eLatency struct {
+	hist utils.Histogram
+
+	mu      sync.Mutex // protects following
+	minDiff time.Duration
+	seen    bool
+}
+
+// observe records the latency of m, if it has a publish time.
+func (l *e2eLatency) observe(m msg.Message) {
+	pt := m.Meta.GetPublishTime()
+	if pt == 0 || m.ReceivedAt.IsZero() {
+		return
+	}
+	diff := m.ReceivedAt.Sub(time.Unix(0, int64(pt)*int64(time.Millisecond)))
+
+	l.mu.Lock()
+	if !l.seen || diff < l.minDiff {
+		l.minDiff = diff
+		l.seen = true
+	}
+	l.mu.Unlock()
+
+	if d := diff + l.skew(); d > 0 {
+		l.hist.Observe(d)
+	} else {
+		l.hist.Observe(0)
+	}
+}
+
+// skew returns the estimate of how far the
+// consumer's clock is behind the producers'.
+func (l *e2eLatency) skew() time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.minDiff < 0 {
+		return -l.minDiff
+	}
+	return 0
+}
+
+// E2ELatency returns the distribution of the latency from publishing
+// messages to receiving them, corrected by the estimated clock skew
+// (see ClockSkew). Messages are measured when returned by Receive or
+// ReceiveAsync, and the latency covers the time they spent in the
+// broker and on the network, but not in the Consumer's queue.
+func (m *ManagedConsumer) E2ELatency() utils.HistogramSnapshot {
+	return m.e2e.hist.Snapshot()
+}
+
+// ClockSkew returns the estimate of how far the local clock is behind
+// the clocks of the producers of the topic. Zero means no skew was
+// detected.
+func (m *ManagedConsumer) ClockSkew() time.Duration {
+	return m.e2e.skew()
+}
@@ -0,0 +1,64 @@
+// Copyright 2018 Comcast Cable Communications Management, LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package manage
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/pepper-iot/pulsar-client-go/core/msg"
+	"github.com/pepper-iot/pulsar-client-go/pkg/api"
+)
+
+func TestE2ELatency(t *testing.T) {
+	now := time.Now().Truncate(time.Millisecond)
+	message := func(published, received time.Time) msg.Message {
+		return msg.Message{
+			Meta: &api.MessageMetadata{
+				PublishTime: proto.Uint64(uint64(published.UnixNano() / int64(time.Millisecond))),
+			},
+			ReceivedAt: received,
+		}
+	}
+
+	var l e2eLatency
+	l.observe(msg.Message{Meta: &api.MessageMetadata{}, ReceivedAt: now})
+	if got := l.hist.Snapshot().Count; got != 0 {
+		t.Fatalf("Count = %d after a message without publish time; expected 0", got)
+	}
+
+	l.observe(message(now, now.Add(20*time.Millisecond)))
+	if got, expected := l.skew(), time.Duration(0); got != expected {
+		t.Fatalf("skew() = %v; expected %v", got, expected)
+	}
+	if got, expected := l.hist.Snapshot().Sum, 20*time.Millisecond; got != expected {
+		t.Fatalf("Sum = %v; expected %v", got, expected)
+	}
+
+	// received before it was published: the local clock is behind
+	l.observe(message(now.Add(50*time.Millisecond), now.Add(40*time.Millisecond)))
+	if got, expected := l.skew(), 10*time.Millisecond; got != expected {
+		t.Fatalf("skew() = %v; expected %v", got, expected)
+	}
+
+	l.observe(message(now, now.Add(20*time.Millisecond)))
+	s := l.hist.Snapshot()
+	if got, expected := s.Count, uint64(3); got != expected {
+		t.Fatalf("Count = %d; expected %d", got, expected)
+	}
+	if got, expected := s.Sum, 20*time.Millisecond+30*time.Millisecond; got != expected {
+		t.Fatalf("Sum = %v; expected %v", got, expected)
+	}
+}
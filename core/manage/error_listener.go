@@ -0,0 +1,74 @@
+// Copyright 2018 Comcast Cable Communications Management, LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package manage
+
+import (
+	"sync/atomic"
+
+	"github.com/pepper-iot/pulsar-client-go/utils"
+)
+
+// ScopeEntity is the kind of entity an asynchronous error comes from.
+type ScopeEntity string
+
+// Possible ScopeEntities.
+const (
+	ScopeConnection ScopeEntity = "connection" // a Client or ManagedClient
+	ScopeProducer   ScopeEntity = "producer"   // a ManagedProducer
+	ScopeConsumer   ScopeEntity = "consumer"   // a ManagedConsumer
+	ScopeFailover   ScopeEntity = "failover"   // a Failover probe
+)
+
+// ErrorScope describes where an asynchronous error comes from.
+type ErrorScope struct {
+	Entity ScopeEntity
+	Labels map[string]string // e.g. topic, subscription, consumer_id and broker
+}
+
+// ErrorListener is notified of asynchronous errors. It is an
+// alternative to the Errs channels of the configs, which doesn't
+// require a goroutine to drain them. OnError is called from the
+// goroutine that encountered the error, so it must not block.
+type ErrorListener interface {
+	OnError(err error, scope ErrorScope)
+}
+
+// ErrorListenerFunc adapts a function to an ErrorListener.
+type ErrorListenerFunc func(err error, scope ErrorScope)
+
+// OnError calls f(err, scope).
+func (f ErrorListenerFunc) OnError(err error, scope ErrorScope) {
+	f(err, scope)
+}
+
+// connScope returns the ErrorScope of the connection of cfg.
+func connScope(cfg ClientConfig) ErrorScope {
+	return ErrorScope{
+		Entity: ScopeConnection,
+		Labels: map[string]string{"broker": brokerAddr(cfg)},
+	}
+}
+
+// reportErr sends err, labeled with the scope, to errs, and
+// notifies the listener. Both errs and the listener may be nil.
+// If errs is full, err is dropped from it and counted in dropped,
+// unless dropped is nil.
+func reportErr(errs utils.AsyncErrors, dropped *uint64, listener ErrorListener, err error, scope ErrorScope) {
+	if !errs.Send(&EntityError{Labels: scope.Labels, Err: err}) && errs != nil && dropped != nil {
+		atomic.AddUint64(dropped, 1)
+	}
+	if listener != nil {
+		listener.OnError(err, scope)
+	}
+}
@@ -0,0 +1,80 @@
+// Copyright 2018 Comcast Cable Communications Management, LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package manage
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestManagedProducer_ErrorListener(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	type report struct {
+		err   error
+		scope ErrorScope
+	}
+	reports := make(chan report, 16)
+	errs := make(chan error, 16)
+
+	// nothing listens on port 1,
+	// so every connection attempt fails
+	mp := NewManagedProducer(ctx, NewClientPool(), ProducerConfig{
+		ClientConfig: ClientConfig{
+			Addr: "pulsar://127.0.0.1:1",
+			Errs: errs,
+			ErrorListener: ErrorListenerFunc(func(err error, scope ErrorScope) {
+				select {
+				case reports <- report{err, scope}:
+				default:
+				}
+			}),
+		},
+		NewProducerTimeout:    time.Second,
+		InitialReconnectDelay: 10 * time.Millisecond,
+		Topic:                 "test-topic",
+	})
+	defer mp.Close(ctx)
+
+	var r report
+	for r.scope.Entity != ScopeProducer {
+		select {
+		case r = <-reports:
+		case <-ctx.Done():
+			t.Fatal("timeout waiting for a producer error")
+		}
+	}
+	if got, expected := r.scope.Labels["topic"], "test-topic"; got != expected {
+		t.Fatalf("scope.Labels[topic] = %q; expected %q", got, expected)
+	}
+
+	// the same errors are sent to Errs, labeled
+	for {
+		select {
+		case err := <-errs:
+			var entityErr *EntityError
+			if !errors.As(err, &entityErr) {
+				t.Fatalf("Errs got %T; expected *EntityError", err)
+			}
+			if entityErr.Labels["topic"] == "test-topic" {
+				return
+			}
+		case <-ctx.Done():
+			t.Fatal("timeout waiting for a producer error on Errs")
+		}
+	}
+}
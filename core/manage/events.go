@@ -0,0 +1,97 @@
+// Copyright 2018 Comcast Cable Communications Management, LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package manage
+
+import (
+	"sync"
+	"time"
+)
+
+// EventType is the type of a lifecycle Event.
+type EventType string
+
+// Possible EventTypes.
+const (
+	EventConnected    EventType = "connected"    // a connection, Producer or Consumer was established
+	EventDisconnected EventType = "disconnected" // a connection, Producer or Consumer was lost and is being recreated
+	EventError        EventType = "error"        // an attempt to establish a connection, Producer or Consumer failed
+	EventOverflow     EventType = "overflow"     // a Consumer dropped a message because its queue was full
+	EventClosed       EventType = "closed"       // a ManagedClient, ManagedProducer or ManagedConsumer was closed
+)
+
+// Event is a lifecycle event of a broker connection,
+// ManagedProducer or ManagedConsumer.
+type Event struct {
+	Time  time.Time `json:"time"`
+	Type  EventType `json:"type"`
+	Addr  string    `json:"addr,omitempty"`  // broker address, for connection events
+	Topic string    `json:"topic,omitempty"` // for producer and consumer events
+	Name  string    `json:"name,omitempty"`  // producer or subscription name
+	Err   string    `json:"error,omitempty"`
+}
+
+// eventLog is a bounded ring buffer of recent Events. A nil
+// *eventLog is valid and discards all events.
+type eventLog struct {
+	mu   sync.Mutex // protects following
+	buf  []Event
+	next int  // index of the next event to write
+	full bool // true once buf has wrapped around
+}
+
+func newEventLog(size int) *eventLog {
+	return &eventLog{buf: make([]Event, size)}
+}
+
+// add records e, overwriting the oldest event if the log is full.
+func (l *eventLog) add(e Event) {
+	if l == nil || len(l.buf) == 0 {
+		return
+	}
+	if e.Time.IsZero() {
+		e.Time = time.Now()
+	}
+
+	l.mu.Lock()
+	l.buf[l.next] = e
+	if l.next++; l.next == len(l.buf) {
+		l.next = 0
+		l.full = true
+	}
+	l.mu.Unlock()
+}
+
+// events returns the recorded events, oldest first.
+func (l *eventLog) events() []Event {
+	if l == nil {
+		return []Event{}
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if !l.full {
+		return append([]Event{}, l.buf[:l.next]...)
+	}
+	events := make([]Event, 0, len(l.buf))
+	events = append(events, l.buf[l.next:]...)
+	return append(events, l.buf[:l.next]...)
+}
+
+// Events returns the most recent lifecycle events of the pool's
+// connections, ManagedProducers and ManagedConsumers, oldest first.
+// At most EventLogSize events are kept, so that incidents can be
+// reconstructed without debug logging enabled.
+func (m *ClientPool) Events() []Event {
+	return m.events.events()
+}
@@ -0,0 +1,120 @@
+// Copyright 2018 Comcast Cable Communications Management, LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package manage
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/pepper-iot/pulsar-client-go/core/srv"
+)
+
+func TestEventLog(t *testing.T) {
+	var nilLog *eventLog
+	nilLog.add(Event{Type: EventConnected})
+	if got := nilLog.events(); len(got) != 0 {
+		t.Fatalf("events() = %v; expected none", got)
+	}
+
+	l := newEventLog(3)
+	topics := func() []string {
+		var topics []string
+		for _, e := range l.events() {
+			topics = append(topics, e.Topic)
+		}
+		return topics
+	}
+
+	l.add(Event{Topic: "a"})
+	l.add(Event{Topic: "b"})
+	if got, expected := topics(), []string{"a", "b"}; !reflect.DeepEqual(got, expected) {
+		t.Fatalf("events() = %v; expected %v", got, expected)
+	}
+
+	l.add(Event{Topic: "c"})
+	l.add(Event{Topic: "d"})
+	l.add(Event{Topic: "e"})
+	if got, expected := topics(), []string{"c", "d", "e"}; !reflect.DeepEqual(got, expected) {
+		t.Fatalf("events() = %v; expected %v", got, expected)
+	}
+
+	if l.events()[0].Time.IsZero() {
+		t.Fatal("events()[0].Time is zero; expected the time it was added")
+	}
+}
+
+func TestClientPool_Events(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	srv, err := srv.NewServer(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cp := NewClientPool()
+	mp := NewManagedProducer(ctx, cp, ProducerConfig{
+		ClientConfig: ClientConfig{
+			Addr:                  srv.Addr,
+			InitialReconnectDelay: 10 * time.Millisecond,
+		},
+		NewProducerTimeout:    time.Second,
+		InitialReconnectDelay: 10 * time.Millisecond,
+		Topic:                 "test-topic",
+		Name:                  "test",
+	})
+
+	// waitFor polls Events until the producer's events are expected.
+	// Errors are ignored, as reconnecting may take several attempts.
+	waitFor := func(expected ...EventType) {
+		var got []EventType
+		for ctx.Err() == nil {
+			got = got[:0]
+			for _, e := range cp.Events() {
+				if e.Topic == "test-topic" && e.Type != EventError {
+					got = append(got, e.Type)
+				}
+			}
+			if reflect.DeepEqual(got, expected) {
+				return
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+		t.Fatalf("producer events = %v; expected %v", got, expected)
+	}
+
+	waitFor(EventConnected)
+
+	if err = srv.CloseAll(); err != nil {
+		t.Fatal(err)
+	}
+	waitFor(EventConnected, EventDisconnected, EventConnected)
+
+	if err = mp.Close(ctx); err != nil {
+		t.Fatal(err)
+	}
+	waitFor(EventConnected, EventDisconnected, EventConnected, EventClosed)
+
+	var connected int
+	for _, e := range cp.Stats().Events {
+		if e.Addr != "" && e.Type == EventConnected {
+			connected++
+		}
+	}
+	if connected < 2 {
+		t.Fatalf("got %d connection events; expected at least 2", connected)
+	}
+}
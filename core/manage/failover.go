@@ -0,0 +1,240 @@
+// Copyright 2018 Comcast Cable Communications Management, LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package manage
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/pepper-iot/pulsar-client-go/utils"
+)
+
+// Cluster identifies one of the clusters of a Failover.
+type Cluster int
+
+// Clusters of a Failover.
+const (
+	ClusterPrimary Cluster = iota
+	ClusterBackup
+)
+
+func (c Cluster) String() string {
+	switch c {
+	case ClusterPrimary:
+		return "primary"
+	case ClusterBackup:
+		return "backup"
+	default:
+		return "unknown"
+	}
+}
+
+// FailoverConfig is used to configure a Failover.
+type FailoverConfig struct {
+	Primary ClientConfig // used while the primary cluster is healthy
+	Backup  ClientConfig // used while the primary cluster is unhealthy
+
+	ProbeInterval    time.Duration // how often to probe both clusters
+	ProbeTimeout     time.Duration // how long a single probe may take
+	FailureThreshold int           // consecutive failed probes before switching to the backup cluster
+	SuccessThreshold int           // consecutive successful probes before switching back to the primary cluster
+	Errs             chan<- error  // failed probes will be sent here. May be nil
+	ErrorListener    ErrorListener // notified of failed probes, in addition to Errs. May be nil
+	Clock            utils.Clock   // drives the probes. Defaults to utils.RealClock
+}
+
+// setDefaults returns a modified config with appropriate zero values set to defaults.
+func (c FailoverConfig) setDefaults() FailoverConfig {
+	if c.ProbeInterval <= 0 {
+		c.ProbeInterval = 30 * time.Second
+	}
+	if c.ProbeTimeout <= 0 {
+		c.ProbeTimeout = 5 * time.Second
+	}
+	if c.FailureThreshold <= 0 {
+		c.FailureThreshold = 3
+	}
+	if c.SuccessThreshold <= 0 {
+		c.SuccessThreshold = 3
+	}
+	if c.Clock == nil {
+		c.Clock = utils.RealClock
+	}
+	return c
+}
+
+// NewFailover returns a Failover that starts with the primary cluster
+// active, and probes both clusters in the background until ctx is done.
+func NewFailover(ctx context.Context, cp *ClientPool, cfg FailoverConfig) *Failover {
+	cfg = cfg.setDefaults()
+
+	f := Failover{
+		clientPool: cp,
+		cfg:        cfg,
+		asyncErrs:  utils.AsyncErrors(cfg.Errs),
+		active:     ClusterPrimary,
+		switchedc:  make(chan struct{}),
+	}
+
+	go f.probe(ctx)
+
+	return &f
+}
+
+// Failover switches ManagedProducers and ManagedConsumers between a
+// primary and a backup cluster. The primary cluster is probed with
+// PING requests; after FailureThreshold consecutive failures, and if the
+// backup cluster is healthy, all ManagedProducers and ManagedConsumers
+// configured with the Failover reconnect to the backup cluster. They
+// switch back after SuccessThreshold consecutive successful probes of
+// the primary cluster. SwitchTo can be used to switch manually.
+type Failover struct {
+	clientPool *ClientPool
+	cfg        FailoverConfig
+	asyncErrs  utils.AsyncErrors
+
+	mu        sync.RWMutex // protects following
+	active    Cluster
+	manual    bool          // if true, automatic switchover is disabled
+	switchedc chan struct{} // closed and replaced on every switch
+}
+
+// Active returns the currently active cluster.
+func (f *Failover) Active() Cluster {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	return f.active
+}
+
+// config returns the ClientConfig of the currently active cluster.
+func (f *Failover) config() ClientConfig {
+	if f.Active() == ClusterBackup {
+		return f.cfg.Backup
+	}
+	return f.cfg.Primary
+}
+
+// Switched returns a channel that unblocks the next time
+// the active cluster changes.
+func (f *Failover) Switched() <-chan struct{} {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	return f.switchedc
+}
+
+// SwitchTo makes the given cluster active, and disables automatic
+// switchover until Resume is called.
+func (f *Failover) SwitchTo(c Cluster) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.manual = true
+	f.switchLocked(c)
+}
+
+// Resume re-enables automatic switchover after a call to SwitchTo.
+func (f *Failover) Resume() {
+	f.mu.Lock()
+	f.manual = false
+	f.mu.Unlock()
+}
+
+// switchLocked makes c active. f.mu must be held.
+func (f *Failover) switchLocked(c Cluster) {
+	if f.active == c {
+		return
+	}
+	f.active = c
+	close(f.switchedc)
+	f.switchedc = make(chan struct{})
+}
+
+// autoSwitch makes c active, unless automatic
+// switchover is disabled.
+func (f *Failover) autoSwitch(c Cluster) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if !f.manual {
+		f.switchLocked(c)
+	}
+}
+
+// probe periodically checks the health of
+// both clusters until ctx is done.
+func (f *Failover) probe(ctx context.Context) {
+	setLabels("role", "failover", "primary", f.cfg.Primary.Addr, "backup", f.cfg.Backup.Addr)
+
+	var failures, successes int
+
+	ticker := f.cfg.Clock.NewTicker(f.cfg.ProbeInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C():
+		case <-ctx.Done():
+			return
+		}
+
+		if err := f.ping(ctx, f.cfg.Primary); err != nil {
+			f.sendErr(f.cfg.Primary, err)
+			failures++
+			successes = 0
+		} else {
+			successes++
+			failures = 0
+		}
+
+		switch f.Active() {
+		case ClusterPrimary:
+			if failures < f.cfg.FailureThreshold {
+				continue
+			}
+			// only switch if the backup is any better
+			if err := f.ping(ctx, f.cfg.Backup); err != nil {
+				f.sendErr(f.cfg.Backup, err)
+				continue
+			}
+			f.autoSwitch(ClusterBackup)
+
+		case ClusterBackup:
+			if successes >= f.cfg.SuccessThreshold {
+				f.autoSwitch(ClusterPrimary)
+			}
+		}
+	}
+}
+
+// sendErr reports a failed probe of the cluster with the given
+// configuration to the async errors channel and the ErrorListener.
+func (f *Failover) sendErr(cfg ClientConfig, err error) {
+	reportErr(f.asyncErrs, nil, f.cfg.ErrorListener, err, ErrorScope{
+		Entity: ScopeFailover,
+		Labels: map[string]string{"broker": brokerAddr(cfg)},
+	})
+}
+
+// ping sends a PING to the cluster with the given configuration.
+func (f *Failover) ping(ctx context.Context, cfg ClientConfig) error {
+	ctx, cancel := context.WithTimeout(ctx, f.cfg.ProbeTimeout)
+	defer cancel()
+
+	client, err := f.clientPool.Get(cfg).Get(ctx)
+	if err != nil {
+		return err
+	}
+	return client.Ping(ctx)
+}
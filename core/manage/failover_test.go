@@ -0,0 +1,197 @@
+// Copyright 2018 Comcast Cable Communications Management, LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package manage
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/pepper-iot/pulsar-client-go/core/frame"
+	"github.com/pepper-iot/pulsar-client-go/core/srv"
+	"github.com/pepper-iot/pulsar-client-go/pkg/api"
+)
+
+func TestFailover_Probe(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	primary, err := srv.NewServer(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	backup, err := srv.NewServer(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	f := NewFailover(ctx, NewClientPool(), FailoverConfig{
+		Primary:          ClientConfig{Addr: primary.Addr},
+		Backup:           ClientConfig{Addr: backup.Addr},
+		ProbeInterval:    20 * time.Millisecond,
+		ProbeTimeout:     100 * time.Millisecond,
+		FailureThreshold: 2,
+		SuccessThreshold: 2,
+	})
+	if got, expected := f.Active(), ClusterPrimary; got != expected {
+		t.Fatalf("Active() = %v; expected %v", got, expected)
+	}
+
+	// the primary cluster becomes unhealthy
+	switched := f.Switched()
+	primary.SetIgnorePings(true)
+	select {
+	case <-switched:
+	case <-ctx.Done():
+		t.Fatal("timeout waiting for switch to backup cluster")
+	}
+	if got, expected := f.Active(), ClusterBackup; got != expected {
+		t.Fatalf("Active() = %v; expected %v", got, expected)
+	}
+
+	// the primary cluster recovers
+	switched = f.Switched()
+	primary.SetIgnorePings(false)
+	select {
+	case <-switched:
+	case <-ctx.Done():
+		t.Fatal("timeout waiting for switch to primary cluster")
+	}
+	if got, expected := f.Active(), ClusterPrimary; got != expected {
+		t.Fatalf("Active() = %v; expected %v", got, expected)
+	}
+}
+
+func TestFailover_SwitchTo(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	primary, err := srv.NewServer(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	backup, err := srv.NewServer(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cp := NewClientPool()
+	f := NewFailover(ctx, cp, FailoverConfig{
+		Primary:       ClientConfig{Addr: primary.Addr},
+		Backup:        ClientConfig{Addr: backup.Addr},
+		ProbeInterval: time.Hour,
+	})
+	NewManagedProducer(ctx, cp, ProducerConfig{
+		NewProducerTimeout: time.Second,
+		Topic:              "test-topic",
+		Failover:           f,
+	})
+
+	expectedFrames := []api.BaseCommand_Type{
+		api.BaseCommand_CONNECT,
+		api.BaseCommand_LOOKUP,
+		api.BaseCommand_PRODUCER,
+	}
+	if err = primary.AssertReceived(ctx, expectedFrames...); err != nil {
+		t.Fatal(err)
+	}
+
+	f.SwitchTo(ClusterBackup)
+
+	if err = primary.AssertReceived(ctx, api.BaseCommand_CLOSE_PRODUCER); err != nil {
+		t.Fatal(err)
+	}
+	if err = backup.AssertReceived(ctx, expectedFrames...); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestFailover_TopicMigrated(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	primary, err := srv.NewServer(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	backup, err := srv.NewServer(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	migrated, err := srv.NewServer(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cp := NewClientPool()
+	f := NewFailover(ctx, cp, FailoverConfig{
+		Primary:       ClientConfig{Addr: primary.Addr},
+		Backup:        ClientConfig{Addr: backup.Addr},
+		ProbeInterval: time.Hour,
+	})
+	NewManagedProducer(ctx, cp, ProducerConfig{
+		NewProducerTimeout: time.Second,
+		Topic:              "test-topic",
+		Failover:           f,
+	})
+
+	if err = primary.AssertReceived(ctx, api.BaseCommand_CONNECT, api.BaseCommand_LOOKUP); err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case fr := <-primary.Received:
+		if got, expected := fr.BaseCmd.GetType(), api.BaseCommand_PRODUCER; got != expected {
+			t.Fatalf("got frame type %q; expected %q", got, expected)
+		}
+
+		topicMigrated := frame.Frame{
+			BaseCmd: &api.BaseCommand{
+				Type: api.BaseCommand_TOPIC_MIGRATED.Enum(),
+				TopicMigrated: &api.CommandTopicMigrated{
+					ResourceId:       fr.BaseCmd.GetProducer().ProducerId,
+					ResourceType:     api.CommandTopicMigrated_Producer.Enum(),
+					BrokerServiceUrl: proto.String(migrated.Addr),
+				},
+			},
+		}
+		if err = primary.Broadcast(topicMigrated); err != nil {
+			t.Fatal(err)
+		}
+
+	case <-ctx.Done():
+		t.Fatal(ctx.Err())
+	}
+
+	expectedFrames := []api.BaseCommand_Type{
+		api.BaseCommand_CONNECT,
+		api.BaseCommand_LOOKUP,
+		api.BaseCommand_PRODUCER,
+	}
+	if err = migrated.AssertReceived(ctx, expectedFrames...); err != nil {
+		t.Fatal(err)
+	}
+
+	// the migration only applies to the primary cluster,
+	// so the backup cluster is used as configured
+	f.SwitchTo(ClusterBackup)
+
+	if err = migrated.AssertReceived(ctx, api.BaseCommand_CLOSE_PRODUCER); err != nil {
+		t.Fatal(err)
+	}
+	if err = backup.AssertReceived(ctx, expectedFrames...); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,176 @@
+// Copyright 2018 Comcast Cable Communications Management, LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package manage
+
+import (
+	"context"
+	"errors"
+
+	"github.com/pepper-iot/pulsar-client-go/core/msg"
+)
+
+// WeightedConsumer pairs a ManagedConsumer with its share
+// of deliveries in a FairScheduler.
+type WeightedConsumer struct {
+	Consumer *ManagedConsumer
+	Weight   int // relative share of deliveries. Defaults to 1
+}
+
+// NewFairScheduler returns a FairScheduler for the given consumers.
+func NewFairScheduler(consumers ...WeightedConsumer) *FairScheduler {
+	s := FairScheduler{
+		consumers: make([]WeightedConsumer, len(consumers)),
+	}
+	for i, wc := range consumers {
+		if wc.Weight <= 0 {
+			wc.Weight = 1
+		}
+		s.consumers[i] = wc
+	}
+	return &s
+}
+
+// FairScheduler multiplexes several ManagedConsumers into a single
+// delivery channel. When more than one consumer has messages waiting,
+// deliveries are interleaved in proportion to the consumers' weights
+// (smooth weighted round-robin), so that a busy low-weight consumer
+// cannot starve a high-weight one.
+type FairScheduler struct {
+	consumers []WeightedConsumer
+}
+
+// Run blocks until the context is done. It receives from every consumer
+// using ReceiveAsync and sends the messages to out, ordered by weight.
+// Each consumer buffers up to its configured QueueSize messages while
+// waiting to be scheduled.
+func (s *FairScheduler) Run(ctx context.Context, out chan<- msg.Message) error {
+	if len(s.consumers) == 0 {
+		return errors.New("fair scheduler has no consumers")
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	// notify is signaled whenever a message becomes
+	// available on any of the inputs
+	notify := make(chan struct{}, 1)
+
+	inputs := make([]chan msg.Message, len(s.consumers))
+	weights := make([]int, len(s.consumers))
+	for i, wc := range s.consumers {
+		received := make(chan msg.Message, wc.Consumer.cfg.QueueSize)
+		inputs[i] = make(chan msg.Message, 1)
+		weights[i] = wc.Weight
+
+		go func(mc *ManagedConsumer) {
+			if err := mc.ReceiveAsync(ctx, received); err != nil && err != ctx.Err() {
+				mc.sendErr(err)
+			}
+		}(wc.Consumer)
+
+		go func(in chan<- msg.Message) {
+			for {
+				select {
+				case m := <-received:
+					select {
+					case in <- m:
+					case <-ctx.Done():
+						return
+					}
+					select {
+					case notify <- struct{}{}:
+					default:
+					}
+				case <-ctx.Done():
+					return
+				}
+			}
+		}(inputs[i])
+	}
+
+	sched := newWRR(weights)
+	heads := make([]*msg.Message, len(inputs))
+	ready := make([]bool, len(inputs))
+
+	for {
+		// pick up the next pending message
+		// from every input that has one
+		for i, in := range inputs {
+			if heads[i] != nil {
+				continue
+			}
+			select {
+			case m := <-in:
+				heads[i] = &m
+			default:
+			}
+			ready[i] = heads[i] != nil
+		}
+
+		next := sched.next(ready)
+		if next < 0 {
+			select {
+			case <-notify:
+				continue
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+
+		select {
+		case out <- *heads[next]:
+			heads[next] = nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// newWRR returns a smooth weighted round-robin selector
+// for the given weights.
+func newWRR(weights []int) *wrr {
+	return &wrr{
+		weights: weights,
+		current: make([]int, len(weights)),
+	}
+}
+
+// wrr implements smooth weighted round-robin selection,
+// as used by nginx. It is not thread-safe.
+type wrr struct {
+	weights []int
+	current []int
+}
+
+// next returns the index of the next ready entry to select,
+// or -1 if no entry is ready. Only ready entries take part in
+// the selection, so idle entries don't accumulate credit.
+func (w *wrr) next(ready []bool) int {
+	best := -1
+	total := 0
+	for i, ok := range ready {
+		if !ok {
+			continue
+		}
+		w.current[i] += w.weights[i]
+		total += w.weights[i]
+		if best < 0 || w.current[i] > w.current[best] {
+			best = i
+		}
+	}
+	if best >= 0 {
+		w.current[best] -= total
+	}
+	return best
+}
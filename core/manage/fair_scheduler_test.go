@@ -0,0 +1,67 @@
+// Copyright 2018 Comcast Cable Communications Management, LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package manage
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestWRR(t *testing.T) {
+	w := newWRR([]int{3, 1})
+	ready := []bool{true, true}
+
+	var got []int
+	for i := 0; i < 8; i++ {
+		got = append(got, w.next(ready))
+	}
+
+	counts := make([]int, 2)
+	for _, i := range got {
+		counts[i]++
+	}
+	if counts[0] != 6 || counts[1] != 2 {
+		t.Fatalf("wrr.next() selections = %v; expected 6 of 0 and 2 of 1", got)
+	}
+
+	// the low weight entry must be interleaved, not
+	// deferred until the end of the cycle
+	if got[3] != 1 && got[2] != 1 && got[1] != 1 {
+		t.Fatalf("wrr.next() selections = %v; expected entry 1 within first 4", got)
+	}
+}
+
+func TestWRR_NotReady(t *testing.T) {
+	w := newWRR([]int{1, 10})
+
+	if got := w.next([]bool{false, false}); got != -1 {
+		t.Fatalf("wrr.next() = %d; expected -1 when nothing is ready", got)
+	}
+
+	for i := 0; i < 5; i++ {
+		if got := w.next([]bool{true, false}); got != 0 {
+			t.Fatalf("wrr.next() = %d; expected only ready entry 0", got)
+		}
+	}
+}
+
+func TestFairScheduler_NoConsumers(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	if err := NewFairScheduler().Run(ctx, nil); err == nil {
+		t.Fatal("Run() err = nil; expected error with no consumers")
+	}
+}
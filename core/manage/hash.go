@@ -0,0 +1,100 @@
+// Copyright 2018 Comcast Cable Communications Management, LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package manage
+
+import (
+	"encoding/binary"
+	"math/bits"
+	"unicode/utf16"
+)
+
+// HashingScheme is the hash of partition keys with which MessageRouters
+// select their partition. Messages with the same key land on the same
+// partitions as those produced by the Java client (or any other) with
+// the same scheme.
+type HashingScheme int
+
+const (
+	// JavaStringHash is Java's String.hashCode, the
+	// default scheme of the Java client.
+	JavaStringHash HashingScheme = iota
+	// Murmur3_32Hash is the 32 bit Murmur3 hash
+	// of the UTF-8 encoded key.
+	Murmur3_32Hash
+)
+
+// Hash returns the hash of key, which is positive as an int32,
+// like that of the Java client.
+func (h HashingScheme) Hash(key string) uint32 {
+	switch h {
+	case Murmur3_32Hash:
+		return murmur3(key) & (1<<31 - 1)
+	default:
+		return javaStringHash(key) & (1<<31 - 1)
+	}
+}
+
+// javaStringHash is Java's String.hashCode, which
+// hashes the UTF-16 code units of the string.
+func javaStringHash(s string) uint32 {
+	var h uint32
+	for _, c := range utf16.Encode([]rune(s)) {
+		h = 31*h + uint32(c)
+	}
+	return h
+}
+
+// murmur3 is the x86 32 bit variant of MurmurHash3, with seed 0.
+func murmur3(s string) uint32 {
+	const (
+		c1 = 0xcc9e2d51
+		c2 = 0x1b873593
+	)
+	data := []byte(s)
+
+	var h uint32
+	for ; len(data) >= 4; data = data[4:] {
+		k := binary.LittleEndian.Uint32(data)
+		k *= c1
+		k = bits.RotateLeft32(k, 15)
+		k *= c2
+		h ^= k
+		h = bits.RotateLeft32(h, 13)
+		h = h*5 + 0xe6546b64
+	}
+
+	var k uint32
+	switch len(data) {
+	case 3:
+		k ^= uint32(data[2]) << 16
+		fallthrough
+	case 2:
+		k ^= uint32(data[1]) << 8
+		fallthrough
+	case 1:
+		k ^= uint32(data[0])
+		k *= c1
+		k = bits.RotateLeft32(k, 15)
+		k *= c2
+		h ^= k
+	}
+
+	h ^= uint32(len(s))
+	h ^= h >> 16
+	h *= 0x85ebca6b
+	h ^= h >> 13
+	h *= 0xc2b2ae35
+	h ^= h >> 16
+	return h
+}
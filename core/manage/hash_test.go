@@ -0,0 +1,42 @@
+// Copyright 2018 Comcast Cable Communications Management, LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package manage
+
+import (
+	"testing"
+)
+
+func TestHashingScheme(t *testing.T) {
+	tests := []struct {
+		scheme   HashingScheme
+		key      string
+		expected uint32
+	}{
+		{JavaStringHash, "", 0},
+		{JavaStringHash, "hello", 99162322},
+		{JavaStringHash, "😀", 1772899}, // hashed as a UTF-16 surrogate pair
+		// hashCode() is math.MinInt32, made positive
+		{JavaStringHash, "polygenelubricants", 0},
+
+		{Murmur3_32Hash, "", 0},
+		{Murmur3_32Hash, "hello", 0x248bfa47},
+		{Murmur3_32Hash, "hello world", 0x5e928f0f},
+		{Murmur3_32Hash, "The quick brown fox jumps over the lazy dog", 0x2e4ff723},
+	}
+	for _, test := range tests {
+		if got := test.scheme.Hash(test.key); got != test.expected {
+			t.Fatalf("%d.Hash(%q) = %#x; expected %#x", test.scheme, test.key, got, test.expected)
+		}
+	}
+}
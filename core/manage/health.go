@@ -0,0 +1,123 @@
+// Copyright 2018 Comcast Cable Communications Management, LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package manage
+
+import (
+	"context"
+	"errors"
+	"sort"
+)
+
+// ErrNoHealthyClient is returned by ClientPool.HealthCheck when
+// no broker connection answered the PING.
+var ErrNoHealthyClient = errors.New("no healthy broker connection")
+
+// errNotConnected is reported for ManagedClients
+// that are currently reconnecting.
+var errNotConnected = errors.New("not connected")
+
+// EntityHealth reports the state of a producer or consumer.
+type EntityHealth struct {
+	Topic    string
+	ID       uint64 // producer or consumer ID
+	Producer bool   // true for a producer, false for a consumer
+	Closed   bool   // true if closed, by either the client or the broker
+}
+
+// Health is the result of ClientPool.HealthCheck.
+type Health struct {
+	Brokers  map[string]error // broker address -> PING result, nil if healthy
+	Entities []EntityHealth   // sorted by topic
+}
+
+// HealthCheck verifies that the connection is alive with a PING
+// round-trip, and reports the state of the client's producers
+// and consumers.
+func (c *Client) HealthCheck(ctx context.Context) ([]EntityHealth, error) {
+	err := c.Ping(ctx)
+
+	producers, consumers := c.entities()
+	entities := make([]EntityHealth, 0, len(producers)+len(consumers))
+	for _, p := range producers {
+		var closed bool
+		select {
+		case <-p.Closed():
+			closed = true
+		default:
+		}
+		entities = append(entities, EntityHealth{
+			Topic:    p.Topic,
+			ID:       p.ProducerID,
+			Producer: true,
+			Closed:   closed,
+		})
+	}
+	for _, cs := range consumers {
+		var closed bool
+		select {
+		case <-cs.Closed():
+			closed = true
+		default:
+		}
+		entities = append(entities, EntityHealth{
+			Topic:  cs.Topic,
+			ID:     cs.ConsumerID,
+			Closed: closed,
+		})
+	}
+
+	return entities, err
+}
+
+// HealthCheck runs a health check on every pooled client. It returns
+// ErrNoHealthyClient unless at least one broker connection is alive,
+// which makes it suitable for readiness probes. Clients that are
+// reconnecting are reported without being waited for.
+func (m *ClientPool) HealthCheck(ctx context.Context) (Health, error) {
+	h := Health{
+		Brokers: make(map[string]error),
+	}
+
+	for i := range m.shards {
+		m.shards[i].pool.Range(func(k, v interface{}) bool {
+			addr := k.(clientPoolKey).logicalAddr
+
+			client := v.(*ManagedClient).current()
+			if client == nil {
+				if _, ok := h.Brokers[addr]; !ok {
+					h.Brokers[addr] = errNotConnected
+				}
+				return true
+			}
+
+			entities, err := client.HealthCheck(ctx)
+			if prev, ok := h.Brokers[addr]; !ok || prev != nil {
+				h.Brokers[addr] = err
+			}
+			h.Entities = append(h.Entities, entities...)
+			return true
+		})
+	}
+
+	sort.SliceStable(h.Entities, func(i, j int) bool {
+		return h.Entities[i].Topic < h.Entities[j].Topic
+	})
+
+	for _, err := range h.Brokers {
+		if err == nil {
+			return h, nil
+		}
+	}
+	return h, ErrNoHealthyClient
+}
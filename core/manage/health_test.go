@@ -0,0 +1,79 @@
+// Copyright 2018 Comcast Cable Communications Management, LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package manage
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pepper-iot/pulsar-client-go/core/srv"
+	"github.com/pepper-iot/pulsar-client-go/pkg/api"
+)
+
+func TestClientPool_HealthCheck(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	srv, err := srv.NewServer(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cp := NewClientPool()
+	if _, err = cp.HealthCheck(ctx); err != ErrNoHealthyClient {
+		t.Fatalf("HealthCheck() err = %v; expected %v", err, ErrNoHealthyClient)
+	}
+
+	NewManagedProducer(ctx, cp, ProducerConfig{
+		ClientConfig: ClientConfig{
+			Addr: srv.Addr,
+		},
+		NewProducerTimeout: time.Second,
+		Topic:              "test-topic",
+	})
+
+	expectedFrames := []api.BaseCommand_Type{
+		api.BaseCommand_CONNECT,
+		api.BaseCommand_LOOKUP,
+		api.BaseCommand_PRODUCER,
+	}
+	if err = srv.AssertReceived(ctx, expectedFrames...); err != nil {
+		t.Fatal(err)
+	}
+
+	h, err := cp.HealthCheck(ctx)
+	if err != nil {
+		t.Fatalf("HealthCheck() err = %v; expected nil", err)
+	}
+	addr := strings.TrimPrefix(srv.Addr, "pulsar://")
+	if err, ok := h.Brokers[addr]; !ok || err != nil {
+		t.Fatalf("HealthCheck() brokers = %v; expected %q to be healthy", h.Brokers, addr)
+	}
+	if got, expected := len(h.Entities), 1; got != expected {
+		t.Fatalf("HealthCheck() returned %d entities; expected %d", got, expected)
+	}
+	if e := h.Entities[0]; e.Topic != "test-topic" || !e.Producer || e.Closed {
+		t.Fatalf("HealthCheck() entity = %+v; expected open producer of %q", e, "test-topic")
+	}
+
+	// the broker stops answering
+	srv.SetIgnorePings(true)
+	pingCtx, pingCancel := context.WithTimeout(ctx, 100*time.Millisecond)
+	defer pingCancel()
+	if _, err = cp.HealthCheck(pingCtx); err != ErrNoHealthyClient {
+		t.Fatalf("HealthCheck() err = %v; expected %v", err, ErrNoHealthyClient)
+	}
+}
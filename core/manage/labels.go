@@ -0,0 +1,78 @@
+// Copyright 2018 Comcast Cable Communications Management, LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package manage
+
+import (
+	"context"
+	"runtime/pprof"
+	"sort"
+	"strings"
+
+	"github.com/pepper-iot/pulsar-client-go/pkg/log"
+)
+
+// EntityError is an error of a ManagedClient, ManagedProducer or
+// ManagedConsumer, as sent to ClientConfig.Errs. It is labeled with
+// the entity it comes from, e.g. topic, subscription, consumer_id
+// and broker.
+type EntityError struct {
+	Labels map[string]string
+	Err    error
+}
+
+func (e *EntityError) Error() string {
+	keys := make([]string, 0, len(e.Labels))
+	for k := range e.Labels {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var b strings.Builder
+	for _, k := range keys {
+		b.WriteString(k)
+		b.WriteByte('=')
+		b.WriteString(e.Labels[k])
+		b.WriteByte(' ')
+	}
+	b.WriteString(e.Err.Error())
+	return b.String()
+}
+
+// Unwrap returns the labeled error.
+func (e *EntityError) Unwrap() error {
+	return e.Err
+}
+
+// labeledLogger returns a Logger attaching labels to every message.
+func labeledLogger(labels map[string]string) *log.Logger {
+	fields := make(log.Fields, len(labels))
+	for k, v := range labels {
+		fields[k] = v
+	}
+	return log.Manage.With(fields)
+}
+
+// brokerAddr returns the address of the broker
+// of cfg, as used in labels, events and stats.
+func brokerAddr(cfg ClientConfig) string {
+	return strings.TrimPrefix(cfg.Addr, "pulsar://")
+}
+
+// setLabels sets the pprof labels of the calling goroutine from
+// key/value pairs, so that CPU and goroutine profiles can be
+// attributed to topics and brokers. Goroutines it starts
+// afterwards inherit the labels.
+func setLabels(args ...string) {
+	pprof.SetGoroutineLabels(pprof.WithLabels(context.Background(), pprof.Labels(args...)))
+}
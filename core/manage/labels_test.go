@@ -0,0 +1,126 @@
+// Copyright 2018 Comcast Cable Communications Management, LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package manage
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"reflect"
+	"runtime/pprof"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pepper-iot/pulsar-client-go/core/srv"
+)
+
+func TestManagedConsumer_Labels(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	srv, err := srv.NewServer(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cp := NewClientPool()
+	mc := NewManagedConsumer(ctx, cp, ConsumerConfig{
+		ClientConfig: ClientConfig{
+			Addr: srv.Addr,
+		},
+		NewConsumerTimeout: time.Second,
+		Topic:              "labeled-topic",
+		Name:               "labeled-sub",
+		SubMode:            SubscriptionModeShard,
+	})
+	defer mc.Close(ctx)
+
+	expected := []string{
+		`"role":"consumer"`,
+		`"subscription":"labeled-sub"`,
+		`"topic":"labeled-topic"`,
+		`"role":"conn"`,
+	}
+	for {
+		var b bytes.Buffer
+		if err = pprof.Lookup("goroutine").WriteTo(&b, 1); err != nil {
+			t.Fatal(err)
+		}
+		missing := ""
+		for _, label := range expected {
+			if !strings.Contains(b.String(), label) {
+				missing = label
+				break
+			}
+		}
+		if missing == "" {
+			return
+		}
+
+		select {
+		case <-time.After(10 * time.Millisecond):
+		case <-ctx.Done():
+			t.Fatalf("goroutine profile has no %s label:\n%s", missing, b.String())
+		}
+	}
+}
+
+func TestEntityError(t *testing.T) {
+	err := &EntityError{
+		Labels: map[string]string{"topic": "a-topic", "broker": "localhost:6650"},
+		Err:    context.DeadlineExceeded,
+	}
+	if got, expected := err.Error(), "broker=localhost:6650 topic=a-topic context deadline exceeded"; got != expected {
+		t.Fatalf("Error() = %q; expected %q", got, expected)
+	}
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatal("errors.Is(err, context.DeadlineExceeded) = false; expected true")
+	}
+}
+
+func TestManagedConsumer_labels(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	srv, err := srv.NewServer(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cp := NewClientPool()
+	mc := NewManagedConsumer(ctx, cp, ConsumerConfig{
+		ClientConfig: ClientConfig{
+			Addr: srv.Addr,
+		},
+		NewConsumerTimeout: time.Second,
+		Topic:              "labeled-topic",
+		Name:               "labeled-sub",
+		SubMode:            SubscriptionModeShard,
+	})
+	defer mc.Close(ctx)
+
+	if mc.ConsumerID(ctx); ctx.Err() != nil {
+		t.Fatal(ctx.Err())
+	}
+	expected := map[string]string{
+		"topic":        "labeled-topic",
+		"subscription": "labeled-sub",
+		"consumer_id":  "0",
+		"broker":       brokerAddr(ClientConfig{Addr: srv.Addr}),
+	}
+	if got := mc.labels(); !reflect.DeepEqual(got, expected) {
+		t.Fatalf("labels() = %v; expected %v", got, expected)
+	}
+}
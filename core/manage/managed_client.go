@@ -17,9 +17,12 @@ import (
 	"context"
 	"crypto/tls"
 	"errors"
+	"net"
 	"sync"
 	"time"
 
+	"github.com/pepper-iot/pulsar-client-go/core/conn"
+	"github.com/pepper-iot/pulsar-client-go/core/frame"
 	"github.com/pepper-iot/pulsar-client-go/utils"
 )
 
@@ -31,27 +34,89 @@ type ClientConfig struct {
 	TLSConfig   *tls.Config   // TLS configuration. May be nil, in which case TLS will not be used
 	Errs        chan<- error  // asynchronous errors will be sent here. May be nil
 
+	// SNIProxyAddr is the address of an SNI-routing proxy, eg Apache
+	// Traffic Server. If set, all connections are made to it, and the
+	// TLS ServerName is set to the host of the broker (or of the service
+	// URL, for lookups), so that the proxy routes them to it. It
+	// requires TLSConfig to be set.
+	SNIProxyAddr string
+
+	ErrorListener ErrorListener // notified of asynchronous errors, in addition to Errs. May be nil. Not part of the ClientPool key
+
 	PingFrequency         time.Duration // how often to PING server
 	PingTimeout           time.Duration // how long to wait for PONG response
 	ConnectTimeout        time.Duration // how long to wait for CONNECTED response
 	InitialReconnectDelay time.Duration // how long to initially wait to reconnect Client
 	MaxReconnectDelay     time.Duration // maximum time to wait to attempt to reconnect Client
+	CloseTimeout          time.Duration // how long to wait for a graceful Close when stopping
 
 	AuthMethod string
 	AuthData   []byte
+
+	FrameTap *conn.Tap // if set, all frames sent and received are written to it. Not part of the ClientPool key
+
+	InboundInterceptors  conn.Interceptors // frames received are passed through these. Not part of the ClientPool key
+	OutboundInterceptors conn.Interceptors // frames sent are passed through these. Not part of the ClientPool key
+
+	// ChecksumPolicy determines how the checksums of received frames
+	// are verified. With frame.ChecksumReport, messages with a mismatch
+	// are dropped, acknowledged with a validation error and reported
+	// as asynchronous errors, instead of closing the connection.
+	// Not part of the ClientPool key.
+	ChecksumPolicy frame.ChecksumPolicy
+
+	// PooledPayloads makes the payloads of received messages come from
+	// a pool of buffers. Applications return them with msg.Message.Release
+	// once done with a message, which saves allocating a buffer for each
+	// one. Messages that aren't released are garbage collected. Not part
+	// of the ClientPool key.
+	PooledPayloads bool
+
+	// Clock drives the reconnect delays and pings of the Client, and
+	// those of the producers and consumers configured with it. Defaults
+	// to utils.RealClock; tests may set a utils.ManualClock. Not part of
+	// the ClientPool key.
+	Clock utils.Clock
 }
 
 // ConnAddr returns the address that should be used
-// for the TCP connection. It defaults to phyAddr if set,
-// otherwise Addr. This is to support the proxying through
-// a broker, as determined during topic lookup.
+// for the TCP connection. It defaults to SNIProxyAddr or
+// phyAddr if set, otherwise Addr. This is to support the
+// proxying through a broker, as determined during topic lookup.
 func (c ClientConfig) ConnAddr() string {
+	if c.SNIProxyAddr != "" {
+		return c.SNIProxyAddr
+	}
 	if c.phyAddr != "" {
 		return c.phyAddr
 	}
 	return c.Addr
 }
 
+// tlsConfig returns the TLS configuration of connections. With an
+// SNI proxy, the ServerName is the host of Addr, which is otherwise
+// that of the address dialed.
+func (c ClientConfig) tlsConfig() *tls.Config {
+	if c.TLSConfig == nil || c.SNIProxyAddr == "" {
+		return c.TLSConfig
+	}
+	host := conn.HostPort(c.Addr)
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+	cfg := c.TLSConfig.Clone()
+	cfg.ServerName = host
+	return cfg
+}
+
+// clock returns the configured Clock, or utils.RealClock if it isn't set.
+func (c ClientConfig) clock() utils.Clock {
+	if c.Clock == nil {
+		return utils.RealClock
+	}
+	return c.Clock
+}
+
 // setDefaults returns a modified config with appropriate zero values set to defaults.
 func (c ClientConfig) SetDefaults() ClientConfig {
 	if c.DialTimeout <= 0 {
@@ -78,17 +143,28 @@ func (m ClientConfig) setDefaults() ClientConfig {
 	if m.MaxReconnectDelay <= 0 {
 		m.MaxReconnectDelay = 2 * time.Minute
 	}
+	if m.CloseTimeout <= 0 {
+		m.CloseTimeout = 5 * time.Second
+	}
 	return m
 }
 
 // NewManagedClient returns a ManagedClient for the given address. The
-// Client will be created and monitored in the background.
-func NewManagedClient(cfg ClientConfig) *ManagedClient {
+// Client will be created and monitored in the background until either
+// Stop is called or ctx is done.
+func NewManagedClient(ctx context.Context, cfg ClientConfig) *ManagedClient {
+	return newManagedClient(ctx, cfg, nil)
+}
+
+// newManagedClient returns a ManagedClient
+// recording its lifecycle events to events.
+func newManagedClient(ctx context.Context, cfg ClientConfig, events *eventLog) *ManagedClient {
 	cfg = cfg.setDefaults()
 
 	m := ManagedClient{
 		cfg:       cfg,
 		asyncErrs: utils.AsyncErrors(cfg.Errs),
+		events:    events,
 		donec:     make(chan struct{}),
 		waitc:     make(chan struct{}),
 	}
@@ -98,6 +174,14 @@ func NewManagedClient(cfg ClientConfig) *ManagedClient {
 	// is called
 	go m.manage()
 
+	go func() {
+		select {
+		case <-ctx.Done():
+			_ = m.Stop()
+		case <-m.donec:
+		}
+	}()
+
 	return &m
 }
 
@@ -106,7 +190,9 @@ func NewManagedClient(cfg ClientConfig) *ManagedClient {
 type ManagedClient struct {
 	cfg ClientConfig
 
-	asyncErrs utils.AsyncErrors
+	asyncErrs   utils.AsyncErrors
+	droppedErrs uint64 // asynchronous errors dropped because asyncErrs was full; accessed atomically
+	events      *eventLog
 
 	mu     sync.RWMutex // protects following
 	isDone bool
@@ -119,14 +205,14 @@ type ManagedClient struct {
 // it from re-connecting. The ManagedClient shouldn't be used
 // after calling Stop.
 func (m *ManagedClient) Stop() error {
-	m.mu.RLock()
+	m.mu.Lock()
 
 	if !m.isDone {
 		m.isDone = true
 		close(m.donec)
 	}
 
-	m.mu.RUnlock()
+	m.mu.Unlock()
 	return nil
 }
 
@@ -167,6 +253,14 @@ func (m *ManagedClient) Get(ctx context.Context) (*Client, error) {
 	}
 }
 
+// current returns the managed Client,
+// or nil if it is unavailable.
+func (m *ManagedClient) current() *Client {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.client
+}
+
 // set unblocks the "wait" channel (if not nil),
 // and sets the client under lock.
 func (m *ManagedClient) set(c *Client) {
@@ -221,7 +315,7 @@ func (m *ManagedClient) newClient(ctx context.Context) (*Client, error) {
 	}
 
 	if err != nil {
-		_ = client.Close()
+		_ = client.C.Close()
 		return nil, err
 	}
 
@@ -232,7 +326,10 @@ func (m *ManagedClient) newClient(ctx context.Context) (*Client, error) {
 // Nil will be returned if and only if Stop() was
 // called.
 func (m *ManagedClient) reconnect(initial bool) *Client {
-	retryDelay := m.cfg.InitialReconnectDelay
+	backoff := utils.Backoff{
+		Initial: m.cfg.InitialReconnectDelay,
+		Max:     m.cfg.MaxReconnectDelay,
+	}
 
 	for attempt := 1; ; attempt++ {
 		// Don't delay if this is the initial
@@ -244,36 +341,47 @@ func (m *ManagedClient) reconnect(initial bool) *Client {
 				return nil
 			default:
 			}
-		} else {
-			select {
-			case <-time.After(retryDelay):
-			case <-m.donec:
-				return nil
-			}
-		}
-
-		if retryDelay < m.cfg.MaxReconnectDelay {
-			// double retry delay until we reach the max
-			if retryDelay *= 2; retryDelay > m.cfg.MaxReconnectDelay {
-				retryDelay = m.cfg.MaxReconnectDelay
-			}
+		} else if !sleep(m.cfg.clock(), backoff.Next(), m.donec) {
+			return nil
 		}
 
 		ctx, cancel := context.WithTimeout(context.Background(), m.cfg.ConnectTimeout)
 		newClient, err := m.newClient(ctx)
 		cancel()
 		if err != nil {
-			m.asyncErrs.Send(err)
+			m.event(EventError, err)
+			m.sendErr(err)
 			continue
 		}
 
+		m.event(EventConnected, nil)
 		return newClient
 	}
 }
 
+// sendErr reports err to the async errors channel
+// and the ErrorListener, labeled with the broker.
+func (m *ManagedClient) sendErr(err error) {
+	reportErr(m.asyncErrs, &m.droppedErrs, m.cfg.ErrorListener, err, connScope(m.cfg))
+}
+
+// event records a lifecycle event of the connection.
+func (m *ManagedClient) event(typ EventType, err error) {
+	e := Event{
+		Type: typ,
+		Addr: brokerAddr(m.cfg),
+	}
+	if err != nil {
+		e.Err = err.Error()
+	}
+	m.events.add(e)
+}
+
 // managed monitors the Client for conditions that require it to
 // be re-created.
 func (m *ManagedClient) manage() {
+	setLabels("role", "client", "broker", m.cfg.Addr)
+
 	defer m.unset()
 
 	client := m.reconnect(true)
@@ -284,7 +392,7 @@ func (m *ManagedClient) manage() {
 	}
 	m.set(client)
 
-	pingTick := time.NewTicker(m.cfg.PingFrequency)
+	pingTick := m.cfg.clock().NewTicker(m.cfg.PingFrequency)
 	defer pingTick.Stop()
 
 	// Enter a loop to watch the client for any
@@ -294,9 +402,13 @@ func (m *ManagedClient) manage() {
 		// managed client was stopped.
 		// exit
 		case <-m.donec:
-			if err := client.Close(); err != nil {
-				m.asyncErrs.Send(err)
+			ctx, cancel := context.WithTimeout(context.Background(), m.cfg.CloseTimeout)
+			err := client.Close(ctx)
+			cancel()
+			if err != nil {
+				m.sendErr(err)
 			}
+			m.event(EventClosed, err)
 			return
 
 		// client was closed
@@ -305,7 +417,7 @@ func (m *ManagedClient) manage() {
 
 		// try to ping server
 		// if failure, reconnect
-		case <-pingTick.C:
+		case <-pingTick.C():
 			ctx, cancel := context.WithTimeout(context.Background(), m.cfg.PingTimeout)
 			err := client.Ping(ctx)
 			cancel()
@@ -313,10 +425,11 @@ func (m *ManagedClient) manage() {
 				// ping success, no reconnect
 				continue
 			}
-			m.asyncErrs.Send(err)
+			m.sendErr(err)
+			m.event(EventError, err)
 
-			if err = client.Close(); err != nil {
-				m.asyncErrs.Send(err)
+			if err = client.C.Close(); err != nil {
+				m.sendErr(err)
 			}
 
 		}
@@ -325,6 +438,7 @@ func (m *ManagedClient) manage() {
 		// be re-created
 
 		m.unset()
+		m.event(EventDisconnected, nil)
 		if client = m.reconnect(false); client == nil {
 			// client == nil only if the ManagedClient
 			// was stopped
@@ -333,3 +447,17 @@ func (m *ManagedClient) manage() {
 		m.set(client)
 	}
 }
+
+// sleep waits for d on clock, and reports
+// whether it did before done was closed.
+func sleep(clock utils.Clock, d time.Duration, done <-chan struct{}) bool {
+	timer := clock.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C():
+		return true
+	case <-done:
+		return false
+	}
+}
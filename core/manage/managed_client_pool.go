@@ -16,25 +16,95 @@ package manage
 import (
 	"context"
 	"fmt"
-	"strings"
+	"hash/fnv"
 	"sync"
 	"time"
 
 	"github.com/pepper-iot/pulsar-client-go/pkg/api"
+	"github.com/pepper-iot/pulsar-client-go/utils"
 )
 
-// NewClientPool initializes a ClientPool.
+// ClientPoolConfig is used to configure a ClientPool.
+type ClientPoolConfig struct {
+	MaxConcurrentLookups int // maximum number of topic lookups in progress at once. Defaults to 64
+	EventLogSize         int // number of recent lifecycle events kept for Events and Stats. Defaults to 256
+
+	// MemoryLimit, if positive, is the maximum number of bytes of the
+	// payloads of the pending sends of the pool's ManagedProducers and
+	// of the messages buffered by its ManagedConsumers. Sends wait for
+	// memory, or fail if their ProducerConfig.MemoryLimitFailFast is
+	// set, and consumers stop requesting messages, while it's exceeded.
+	MemoryLimit int64
+
+	// Credentials, if set, selects the credentials of the connections
+	// used for each topic by namespace, overriding the AuthMethod and
+	// AuthData of the ClientConfig. Connections with different
+	// credentials are never shared.
+	Credentials CredentialsProvider
+}
+
+// setDefaults returns a modified config with appropriate zero values set to defaults.
+func (c ClientPoolConfig) setDefaults() ClientPoolConfig {
+	if c.MaxConcurrentLookups <= 0 {
+		c.MaxConcurrentLookups = 64
+	}
+	if c.EventLogSize <= 0 {
+		c.EventLogSize = 256
+	}
+	return c
+}
+
+// NewClientPool initializes a ClientPool with the default configuration.
 func NewClientPool() *ClientPool {
+	return NewClientPoolWithConfig(ClientPoolConfig{})
+}
+
+// NewClientPoolWithConfig initializes a ClientPool.
+func NewClientPoolWithConfig(cfg ClientPoolConfig) *ClientPool {
+	cfg = cfg.setDefaults()
 	return &ClientPool{
-		pool: make(map[clientPoolKey]*ManagedClient),
+		lookups:     make(chan struct{}, cfg.MaxConcurrentLookups),
+		events:      newEventLog(cfg.EventLogSize),
+		credentials: cfg.Credentials,
+		memory:      utils.NewMemoryLimitController(cfg.MemoryLimit),
 	}
 }
 
+// Memory returns the MemoryLimitController of the pool's MemoryLimit,
+// eg to monitor its usage, or nil if there is no limit.
+func (m *ClientPool) Memory() *utils.MemoryLimitController {
+	return m.memory
+}
+
+// clientPoolShards is the number of shards in a ClientPool.
+const clientPoolShards = 32
+
 // ClientPool provides a thread-safe store for ManagedClients,
-// based on their address.
+// based on their address. It is sharded by broker address, and
+// looking up an existing ManagedClient doesn't take any lock.
 type ClientPool struct {
-	mu   sync.RWMutex                     // protects following
-	pool map[clientPoolKey]*ManagedClient // key -> managedClient
+	shards   [clientPoolShards]clientPoolShard
+	lookups  chan struct{} // semaphore bounding concurrent topic lookups
+	registry registry      // live ManagedProducers and ManagedConsumers
+	schemas  schemaCache   // schemas fetched from the broker
+	events   *eventLog     // recent lifecycle events
+
+	credentials CredentialsProvider          // may be nil
+	memory      *utils.MemoryLimitController // nil without a MemoryLimit
+}
+
+// clientPoolShard holds the ManagedClients
+// whose address hashes to the shard.
+type clientPoolShard struct {
+	mu   sync.Mutex // serializes creation of ManagedClients
+	pool sync.Map   // clientPoolKey -> *ManagedClient
+}
+
+// shard returns the shard for the given key.
+func (m *ClientPool) shard(key clientPoolKey) *clientPoolShard {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(key.logicalAddr))
+	return &m.shards[h.Sum32()%clientPoolShards]
 }
 
 // clientPoolKey defines the unique attributes of a client
@@ -43,6 +113,9 @@ type clientPoolKey struct {
 	phyAddr     string
 	dialTimeout time.Duration
 	tls         bool
+	sniProxy    string
+	authMethod  string
+	authData    string
 
 	pingFrequency         time.Duration
 	pingTimeout           time.Duration
@@ -56,9 +129,13 @@ type clientPoolKey struct {
 // a new one is created and cached, then returned.
 func (m *ClientPool) Get(cfg ClientConfig) *ManagedClient {
 	key := clientPoolKey{
-		logicalAddr:           strings.TrimPrefix(cfg.Addr, "pulsar://"),
+		logicalAddr:           brokerAddr(cfg),
+		phyAddr:               cfg.phyAddr,
 		dialTimeout:           cfg.DialTimeout,
 		tls:                   cfg.TLSConfig != nil,
+		sniProxy:              cfg.SNIProxyAddr,
+		authMethod:            cfg.AuthMethod,
+		authData:              string(cfg.AuthData),
 		pingFrequency:         cfg.PingFrequency,
 		pingTimeout:           cfg.PingTimeout,
 		connectTimeout:        cfg.ConnectTimeout,
@@ -66,33 +143,30 @@ func (m *ClientPool) Get(cfg ClientConfig) *ManagedClient {
 		maxReconnectDelay:     cfg.MaxReconnectDelay,
 	}
 
-	m.mu.RLock()
-	mc, ok := m.pool[key]
-	m.mu.RUnlock()
-
-	if ok {
-		return mc
+	shard := m.shard(key)
+	if mc, ok := shard.pool.Load(key); ok {
+		return mc.(*ManagedClient)
 	}
 
-	m.mu.Lock()
-	defer m.mu.Unlock()
+	shard.mu.Lock()
+	defer shard.mu.Unlock()
 
 	// Double-check locking
-	if mc, ok = m.pool[key]; ok {
-		return mc
+	if mc, ok := shard.pool.Load(key); ok {
+		return mc.(*ManagedClient)
 	}
 
-	mc = NewManagedClient(cfg)
-	m.pool[key] = mc
+	mc := newManagedClient(context.Background(), cfg, m.events)
+	shard.pool.Store(key, mc)
 
 	go func() {
 		// Remove the ManagedClient from the
 		// pool if/when it is stopped.
 		<-mc.Done()
 
-		m.mu.Lock()
-		delete(m.pool, key)
-		m.mu.Unlock()
+		shard.mu.Lock()
+		shard.pool.Delete(key)
+		shard.mu.Unlock()
 	}()
 
 	return mc
@@ -104,10 +178,23 @@ func (m *ClientPool) Get(cfg ClientConfig) *ManagedClient {
 const maxTopicLookupRedirects = 8
 
 // ForTopic performs topic lookup for the given topic and returns
-// the ManagedClient for the discovered topic information.
+// the ManagedClient for the discovered topic information. At most
+// MaxConcurrentLookups lookups are performed at once; others wait
+// for their turn, or until ctx is done. If the ClientPool has a
+// CredentialsProvider, the credentials it selects for the namespace
+// of the topic are used, including for the lookup itself.
 // https://pulsar.incubator.apache.org/docs/latest/project/BinaryProtocol/#Topiclookup-6g0lo
 // incubator-pulsar/pulsar-client/src/main/java/org/apache/pulsar/client/impl/BinaryProtoLookupService.java
 func (m *ClientPool) ForTopic(ctx context.Context, cfg ClientConfig, topic string) (*ManagedClient, error) {
+	select {
+	case m.lookups <- struct{}{}:
+		defer func() { <-m.lookups }()
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+
+	cfg = m.withCredentials(cfg, topic)
+
 	// For initial lookup request, authoritative should == false
 	var authoritative bool
 	serviceAddr := cfg.Addr
@@ -133,8 +220,7 @@ func (m *ClientPool) ForTopic(ctx context.Context, cfg ClientConfig, topic strin
 		authoritative = lookupResp.GetAuthoritative()
 
 		if lookupType == api.CommandLookupTopicResponse_Failed {
-			lookupErr := lookupResp.GetError()
-			return nil, fmt.Errorf("(%s) %s", lookupErr.String(), lookupResp.GetMessage())
+			return nil, utils.NewServerError(lookupResp.GetError(), lookupResp.GetMessage())
 		}
 
 		// Update configured address with address
@@ -155,7 +241,8 @@ func (m *ClientPool) ForTopic(ctx context.Context, cfg ClientConfig, topic strin
 			// TCP connection. The lookup response address must then
 			// be provided in the Connect command. But the broker service
 			// address should be used by the pool as part of the lookup key.
-			if lookupResp.GetProxyThroughServiceUrl() {
+			// An SNI proxy routes to the broker by itself.
+			if lookupResp.GetProxyThroughServiceUrl() && cfg.SNIProxyAddr == "" {
 				cfg.phyAddr = serviceAddr
 			}
 			return m.Get(cfg), nil
@@ -165,8 +252,9 @@ func (m *ClientPool) ForTopic(ctx context.Context, cfg ClientConfig, topic strin
 	return nil, fmt.Errorf("max topic lookup redirects (%d) for topic %q", maxTopicLookupRedirects, topic)
 }
 
+// Partitions performs a PARTITIONED_METADATA request for the given topic.
 func (m *ClientPool) Partitions(ctx context.Context, cfg ClientConfig, topic string) (*api.CommandPartitionedTopicMetadataResponse, error) {
-	mClient := m.Get(cfg)
+	mClient := m.Get(m.withCredentials(cfg, topic))
 	client, err := mClient.Get(ctx)
 	if err != nil {
 		return nil, err
@@ -15,6 +15,7 @@ package manage
 
 import (
 	"context"
+	"sync"
 	"testing"
 	"time"
 
@@ -57,6 +58,58 @@ func TestManagedClientPool(t *testing.T) {
 	}
 }
 
+func TestManagedClientPool_Concurrent(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	srvs := make([]*srv.Server, 3)
+	for i := range srvs {
+		s, err := srv.NewServer(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		srvs[i] = s
+	}
+
+	// concurrent calls to Get for the same address
+	// must all return the same ManagedClient
+	mcp := NewClientPool()
+	results := make([][]*ManagedClient, len(srvs))
+	var wg sync.WaitGroup
+	for i, s := range srvs {
+		results[i] = make([]*ManagedClient, 10)
+		for j := range results[i] {
+			wg.Add(1)
+			go func(i, j int, addr string) {
+				defer wg.Done()
+				results[i][j] = mcp.Get(ClientConfig{
+					Addr: addr,
+				})
+			}(i, j, s.Addr)
+		}
+	}
+	wg.Wait()
+
+	for i := range results {
+		for _, mc := range results[i] {
+			if mc != results[i][0] {
+				t.Fatalf("Get() for %q returned different ManagedClients", srvs[i].Addr)
+			}
+		}
+		for k := range results[:i] {
+			if results[i][0] == results[k][0] {
+				t.Fatalf("Get() for %q and %q returned the same ManagedClient", srvs[i].Addr, srvs[k].Addr)
+			}
+		}
+	}
+
+	for _, s := range srvs {
+		if err := s.AssertReceived(ctx, api.BaseCommand_CONNECT); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
 func TestManagedClientPool_Stop(t *testing.T) {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
@@ -125,6 +178,50 @@ func TestManagedClientPool_ForTopic(t *testing.T) {
 	}
 }
 
+func TestManagedClientPool_ForTopic_MaxConcurrentLookups(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	stuckSrv, err := srv.NewServer(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// lookups through this server never complete
+	stuckSrv.SetIgnoreConnects(true)
+
+	otherSrv, err := srv.NewServer(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cp := NewClientPoolWithConfig(ClientPoolConfig{
+		MaxConcurrentLookups: 1,
+	})
+	go func() {
+		_, _ = cp.ForTopic(ctx, ClientConfig{
+			Addr: stuckSrv.Addr,
+		}, "test")
+	}()
+	if err = stuckSrv.AssertReceived(ctx, api.BaseCommand_CONNECT); err != nil {
+		t.Fatal(err)
+	}
+
+	// the second lookup must wait for the first one
+	waitCtx, waitCancel := context.WithTimeout(ctx, 100*time.Millisecond)
+	defer waitCancel()
+	if _, err = cp.ForTopic(waitCtx, ClientConfig{
+		Addr: otherSrv.Addr,
+	}, "test"); err != context.DeadlineExceeded {
+		t.Fatalf("ForTopic() err = %v; expected %v", err, context.DeadlineExceeded)
+	}
+
+	select {
+	case f := <-otherSrv.Received:
+		t.Fatalf("got unexpected frame of type %q", f.BaseCmd.GetType())
+	default:
+	}
+}
+
 func TestManagedClientPool_ForTopic_Failed(t *testing.T) {
 	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
 	defer cancel()
@@ -15,11 +15,18 @@ package manage
 
 import (
 	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"math/big"
 	"testing"
 	"time"
 
 	"github.com/pepper-iot/pulsar-client-go/core/srv"
 	"github.com/pepper-iot/pulsar-client-go/pkg/api"
+	"github.com/pepper-iot/pulsar-client-go/utils"
 )
 
 func TestManagedClient(t *testing.T) {
@@ -31,7 +38,7 @@ func TestManagedClient(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	mc := NewManagedClient(ClientConfig{
+	mc := NewManagedClient(ctx, ClientConfig{
 		Addr: srv.Addr,
 	})
 	defer mc.Stop()
@@ -64,7 +71,7 @@ func TestManagedClient_SrvClosed(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	mc := NewManagedClient(ClientConfig{
+	mc := NewManagedClient(ctx, ClientConfig{
 		Addr: srv.Addr,
 	})
 	defer mc.Stop()
@@ -108,7 +115,7 @@ func TestManagedClient_PingFailure(t *testing.T) {
 	srv.SetIgnorePings(true)
 
 	// Set client to ping every 1/2 second
-	mc := NewManagedClient(ClientConfig{
+	mc := NewManagedClient(ctx, ClientConfig{
 		Addr:          srv.Addr,
 		PingFrequency: 500 * time.Millisecond,
 	})
@@ -128,6 +135,51 @@ func TestManagedClient_PingFailure(t *testing.T) {
 	}
 }
 
+func TestManagedClient_Clock(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	srv, err := srv.NewServer(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Delays far longer than the test, which only
+	// pass when the manual clock is advanced
+	clock := utils.NewManualClock(time.Now())
+	mc := NewManagedClient(ctx, ClientConfig{
+		Addr:                  srv.Addr,
+		PingFrequency:         time.Hour,
+		InitialReconnectDelay: time.Hour,
+		Clock:                 clock,
+	})
+	defer mc.Stop()
+
+	if err = srv.AssertReceived(ctx, api.BaseCommand_CONNECT); err != nil {
+		t.Fatal(err)
+	}
+
+	// wait for the ping ticker
+	clock.BlockUntil(1)
+	clock.Advance(time.Hour)
+	if err = srv.AssertReceived(ctx, api.BaseCommand_PING); err != nil {
+		t.Fatal(err)
+	}
+
+	// wait for the reconnect delay, in addition to the ping ticker
+	if err = srv.CloseAll(); err != nil {
+		t.Fatal(err)
+	}
+	clock.BlockUntil(2)
+	clock.Advance(time.Hour)
+	if err = srv.AssertReceived(ctx, api.BaseCommand_CONNECT); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := mc.Get(ctx); err != nil {
+		t.Fatalf("Get() err = %v; expected nil", err)
+	}
+}
+
 func TestManagedClient_ConnectFailure(t *testing.T) {
 	timeout := 5 * time.Second
 	ctx, cancel := context.WithTimeout(context.Background(), timeout)
@@ -142,7 +194,7 @@ func TestManagedClient_ConnectFailure(t *testing.T) {
 	// then later enable them
 	srv.SetIgnoreConnects(true)
 
-	mc := NewManagedClient(ClientConfig{
+	mc := NewManagedClient(ctx, ClientConfig{
 		Addr:           srv.Addr,
 		ConnectTimeout: time.Second, // shorten connect timeout since no CONNECT response is expected
 	})
@@ -180,7 +232,7 @@ func TestManagedClient_Stop(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	mc := NewManagedClient(ClientConfig{
+	mc := NewManagedClient(ctx, ClientConfig{
 		Addr: srv.Addr,
 	})
 	defer mc.Stop()
@@ -229,3 +281,90 @@ func TestManagedClient_Stop(t *testing.T) {
 	}
 	t.Logf("Get() err (expected) = %v", err)
 }
+
+func TestManagedClient_ContextDone(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	srv, err := srv.NewServer(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	mcCtx, mcCancel := context.WithCancel(ctx)
+	mc := NewManagedClient(mcCtx, ClientConfig{
+		Addr: srv.Addr,
+	})
+
+	c, err := mc.Get(ctx)
+	if err != nil {
+		t.Fatalf("Get() err = %v; nil expected", err)
+	}
+
+	mcCancel()
+
+	select {
+	case <-mc.Done():
+	case <-time.After(time.Second):
+		t.Fatal("ManagedClient is NOT done; expected to be")
+	}
+
+	select {
+	case <-c.Closed():
+	case <-time.After(time.Second):
+		t.Fatal("client is NOT closed; expected to be")
+	}
+}
+
+func TestNewClient_SNIProxyAddr(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, &template, &template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the proxy records the SNI of connections
+	serverNames := make(chan string, 1)
+	l, err := tls.Listen("tcp", "127.0.0.1:0", &tls.Config{
+		Certificates: []tls.Certificate{{Certificate: [][]byte{der}, PrivateKey: key}},
+		GetConfigForClient: func(hello *tls.ClientHelloInfo) (*tls.Config, error) {
+			serverNames <- hello.ServerName
+			return nil, nil
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+	go func() {
+		c, err := l.Accept()
+		if err != nil {
+			return
+		}
+		defer c.Close()
+		_ = c.(*tls.Conn).Handshake()
+	}()
+
+	c, err := NewClient(ClientConfig{
+		Addr:         "pulsar+ssl://broker-1.example.com:6651",
+		SNIProxyAddr: l.Addr().String(),
+		TLSConfig:    &tls.Config{InsecureSkipVerify: true},
+		DialTimeout:  time.Second,
+	})
+	if err != nil {
+		t.Fatalf("NewClient() err = %v; expected nil", err)
+	}
+	defer c.C.Close()
+
+	if got, expected := <-serverNames, "broker-1.example.com"; got != expected {
+		t.Fatalf("TLS ServerName = %q; expected %q", got, expected)
+	}
+}
@@ -16,11 +16,16 @@ package manage
 import (
 	"context"
 	"errors"
+	"math"
+	"strconv"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/pepper-iot/pulsar-client-go/core/msg"
+	"github.com/pepper-iot/pulsar-client-go/core/schema"
 	"github.com/pepper-iot/pulsar-client-go/core/sub"
+	"github.com/pepper-iot/pulsar-client-go/pkg/api"
 	"github.com/pepper-iot/pulsar-client-go/pkg/log"
 	"github.com/pepper-iot/pulsar-client-go/utils"
 )
@@ -53,6 +58,10 @@ const (
 // ErrorInvalidSubMode When SubscriptionMode is not one of SubscriptionModeExclusive, SubscriptionModeShard, SubscriptionModeFailover
 var ErrorInvalidSubMode = errors.New("invalid subscription mode")
 
+// ErrManagedConsumerClosed is returned when using a ManagedConsumer
+// that has been closed, or whose parent context is done.
+var ErrManagedConsumerClosed = errors.New("managed consumer is closed")
+
 // ConsumerConfig is used to configure a ManagedConsumer.
 type ConsumerConfig struct {
 	ClientConfig
@@ -60,12 +69,53 @@ type ConsumerConfig struct {
 	Topic     string
 	Name      string           // subscription name
 	SubMode   SubscriptionMode // SubscriptionMode
-	Earliest  bool             // if true, subscription cursor set to beginning
 	QueueSize int              // number of messages to buffer before dropping messages
 
+	// InitialPosition is where a new subscription starts. Defaults to
+	// sub.Latest. A MessageID or timestamp position only applies to the
+	// first Consumer: reconnected Consumers resume from the cursor.
+	InitialPosition sub.InitialPosition
+
+	// Flow control watermarks used by ReceiveAsync. More messages are requested
+	// once the number of messages buffered or already requested drops to the low
+	// watermark, bringing it back up to the high watermark. If the byte-based
+	// watermarks are set, they further limit the count-based ones, converted
+	// using the average size of the payloads received so far.
+	FlowHighWater      int // defaults to QueueSize/2
+	FlowLowWater       int // defaults to FlowHighWater/2
+	FlowHighWaterBytes int // optional
+	FlowLowWaterBytes  int // defaults to FlowHighWaterBytes/2
+
+	// Maximum rates at which messages are dispatched to Receive and
+	// ReceiveAsync, enforced by pacing the flow permits granted to the
+	// broker, eg so that a backlog catch-up doesn't overwhelm downstream
+	// systems. Up to one second worth of messages may be dispatched in a
+	// burst. As the payload sizes aren't known before the messages are
+	// received, the byte rate is enforced on average: permits are held
+	// back until the bytes received in excess are paid off.
+	MaxMessagesPerSecond float64 // optional
+	MaxBytesPerSecond    int     // optional
+
 	NewConsumerTimeout    time.Duration // maximum duration to create Consumer, including topic lookup
 	InitialReconnectDelay time.Duration // how long to initially wait to reconnect Producer
 	MaxReconnectDelay     time.Duration // maximum time to wait to attempt to reconnect Producer
+
+	InitialRedeliveryDelay time.Duration // how long Process initially waits to redeliver a failed message. Defaults to 100ms
+	MaxRedeliveryDelay     time.Duration // maximum time Process waits to redeliver a failed message. Defaults to 1m
+
+	Failover *Failover     // if set, the active cluster's ClientConfig is used instead of ClientConfig
+	Schema   schema.Schema // if set, registered with the broker when subscribing
+
+	// If Checkpoints is set, the ID of the latest message acknowledged
+	// is saved to it every CheckpointInterval, and when the
+	// ManagedConsumer is closed. The first Consumer then seeks to the
+	// loaded checkpoint, and messages up to it are acknowledged without
+	// being delivered, so that a restarted ManagedConsumer resumes where
+	// the previous one left off. Checkpoints assume messages are
+	// processed in order, so they are meant for exclusive and failover
+	// subscriptions.
+	Checkpoints        CheckpointStore
+	CheckpointInterval time.Duration // defaults to 10s
 }
 
 // SetDefaults returns a modified config with appropriate zero values set to defaults.
@@ -79,26 +129,53 @@ func (m ConsumerConfig) SetDefaults() ConsumerConfig {
 	if m.MaxReconnectDelay <= 0 {
 		m.MaxReconnectDelay = 5 * time.Minute
 	}
+	if m.InitialRedeliveryDelay <= 0 {
+		m.InitialRedeliveryDelay = 100 * time.Millisecond
+	}
+	if m.MaxRedeliveryDelay <= 0 {
+		m.MaxRedeliveryDelay = time.Minute
+	}
+	if m.CheckpointInterval <= 0 {
+		m.CheckpointInterval = 10 * time.Second
+	}
 	// unbuffered queue not allowed
 	if m.QueueSize <= 0 {
 		m.QueueSize = 128
 	}
+	if m.FlowHighWater <= 0 || m.FlowHighWater > m.QueueSize {
+		m.FlowHighWater = m.QueueSize / 2
+		if m.FlowHighWater == 0 {
+			m.FlowHighWater = 1
+		}
+	}
+	if m.FlowLowWater <= 0 || m.FlowLowWater >= m.FlowHighWater {
+		m.FlowLowWater = m.FlowHighWater / 2
+	}
+	if m.FlowHighWaterBytes > 0 && (m.FlowLowWaterBytes <= 0 || m.FlowLowWaterBytes >= m.FlowHighWaterBytes) {
+		m.FlowLowWaterBytes = m.FlowHighWaterBytes / 2
+	}
 
 	return m
 }
 
 // NewManagedConsumer returns an initialized ManagedConsumer. It will create and recreate
 // a Consumer for the given discovery address and topic on a background goroutine.
-func NewManagedConsumer(cp *ClientPool, cfg ConsumerConfig) *ManagedConsumer {
+// When ctx is done, the background goroutine stops and the Consumer is closed,
+// whether or not it was ever successfully created.
+func NewManagedConsumer(ctx context.Context, cp *ClientPool, cfg ConsumerConfig) *ManagedConsumer {
 	cfg = cfg.SetDefaults()
 
+	ctx, cancel := context.WithCancel(ctx)
+
 	m := ManagedConsumer{
-		clientPool:     cp,
-		cfg:            cfg,
-		asyncErrs:      utils.AsyncErrors(cfg.Errs),
-		queue:          make(chan msg.Message, cfg.QueueSize),
-		waitc:          make(chan struct{}),
-		stopManageChan: make(chan struct{}),
+		clientPool: cp,
+		cfg:        cfg,
+		asyncErrs:  utils.AsyncErrors(cfg.Errs),
+		waitc:      make(chan struct{}),
+		ctx:        ctx,
+		cancel:     cancel,
+		donec:      make(chan struct{}),
+		pace:       newPacer(cfg.clock(), cfg.MaxMessagesPerSecond, cfg.MaxBytesPerSecond),
 	}
 
 	go m.manage()
@@ -112,12 +189,61 @@ type ManagedConsumer struct {
 	cfg        ConsumerConfig
 	asyncErrs  utils.AsyncErrors
 
-	queue chan msg.Message
+	droppedErrs uint64 // asynchronous errors dropped because asyncErrs was full; accessed atomically
+
+	mu       sync.RWMutex  // protects following
+	consumer *sub.Consumer // either consumer is nil and wait isn't or vice versa
+	waitc    chan struct{} // if consumer is nil, this will unblock when it's been re-set
+
+	ctx      context.Context    // lifecycle of the manage goroutine
+	cancel   context.CancelFunc // stops the manage goroutine
+	donec    chan struct{}      // closed when the manage goroutine has returned
+	closeErr error              // result of closing the Consumer, valid once donec is closed
+
+	hmu            sync.Mutex // protects following
+	reconnectHooks []func(*sub.Consumer)
+
+	migration migration // cluster the topic was migrated to, if any
+
+	reconnects int32        // number of times the Consumer was lost; accessed atomically
+	broker     atomic.Value // address of the broker of the latest Consumer, for labels
+	subscribed bool         // whether a Consumer was created; only used by the manage goroutine
+	pace       *pacer       // nil if the dispatch rate isn't limited
+
+	ackLatency utils.Histogram // time from receiving messages to acknowledging them
+	received   rateCounter     // messages returned by Receive or ReceiveAsync
+	e2e        e2eLatency      // latency from publishing messages to receiving them
+	acked      uint64          // acknowledged messages; accessed atomically
 
-	mu             sync.RWMutex  // protects following
-	consumer       *sub.Consumer // either consumer is nil and wait isn't or vice versa
-	waitc          chan struct{} // if consumer is nil, this will unblock when it's been re-set
-	stopManageChan chan struct{}
+	overflowed uint64 // messages overflowed by previous Consumers; accessed atomically
+	dropped    uint64 // stale messages dropped; accessed atomically
+
+	cmu       sync.Mutex     // protects following
+	lastAcked *msg.MessageID // latest message acknowledged, to be checkpointed
+	saved     *msg.MessageID // latest checkpoint saved or loaded
+	skipTo    *msg.MessageID // messages up to the loaded checkpoint are skipped
+}
+
+// OnReconnect registers fn to be called each time the underlying
+// Consumer is re-established. fn receives the new Consumer, which
+// carries the new ConsumerID. Hooks are called in registration order
+// from the manage goroutine once the new Consumer is available, so they
+// may use the ManagedConsumer but should not block for long.
+func (m *ManagedConsumer) OnReconnect(fn func(*sub.Consumer)) {
+	m.hmu.Lock()
+	m.reconnectHooks = append(m.reconnectHooks, fn)
+	m.hmu.Unlock()
+}
+
+// runReconnectHooks calls all hooks registered with OnReconnect.
+func (m *ManagedConsumer) runReconnectHooks(c *sub.Consumer) {
+	m.hmu.Lock()
+	hooks := m.reconnectHooks
+	m.hmu.Unlock()
+
+	for _, fn := range hooks {
+		fn(c)
+	}
 }
 
 // Unactive returns consumer's Unactive
@@ -147,13 +273,15 @@ func (m *ManagedConsumer) ConsumerID(ctx context.Context) uint64 {
 				// Re-enter read-lock to obtain it.
 				continue
 			case <-ctx.Done():
-				log.Warnf("get ConsumerID timeout faild(retry time:%d), topic:%s\n", i, m.cfg.Topic)
+				m.logger().Warnf("getting ConsumerID timed out after %d retries", i)
+				return 0
+			case <-m.ctx.Done():
 				return 0
 			}
 		}
 		return consumer.ConsumerID
 	}
-	log.Warnf("get ConsumerID faild(retry time:%d), topic:%s\n", retry, m.cfg.Topic)
+	m.logger().Warnf("getting ConsumerID failed after %d retries", retry)
 	return 0
 }
 
@@ -173,17 +301,202 @@ func (m *ManagedConsumer) Ack(ctx context.Context, msg msg.Message) error {
 				continue
 			case <-ctx.Done():
 				return ctx.Err()
+			case <-m.ctx.Done():
+				return ErrManagedConsumerClosed
 			}
 		}
 
-		return consumer.Ack(msg)
+		if err := consumer.Ack(msg); err != nil {
+			return err
+		}
+		atomic.AddUint64(&m.acked, 1)
+		if m.cfg.Checkpoints != nil {
+			m.acknowledged(msg.ID())
+		}
+		if !msg.ReceivedAt.IsZero() {
+			m.ackLatency.Observe(time.Since(msg.ReceivedAt))
+		}
+		return nil
 	}
 }
 
+// Nack acquires a consumer and asks the broker to redeliver the given
+// message. For subscriptions other than shared ones, _all_ unacknowledged
+// messages are redelivered.
+func (m *ManagedConsumer) Nack(ctx context.Context, msg msg.Message) error {
+	for {
+		m.mu.RLock()
+		consumer := m.consumer
+		wait := m.waitc
+		m.mu.RUnlock()
+
+		if consumer == nil {
+			select {
+			case <-wait:
+				// a new consumer was established.
+				// Re-enter read-lock to obtain it.
+				continue
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-m.ctx.Done():
+				return ErrManagedConsumerClosed
+			}
+		}
+		return consumer.Nack(msg)
+	}
+}
+
+// Process receives messages and passes them to handle one at a time,
+// until ctx is done or the ManagedConsumer is closed, and returns why it
+// stopped. Messages are acknowledged once handle returns nil. When it
+// returns an error, the message is negatively acknowledged so that the
+// broker redelivers it, after a delay doubling with each consecutive
+// failure from InitialRedeliveryDelay up to MaxRedeliveryDelay. Failing
+// to acknowledge a message is reported as an asynchronous error: the
+// message is then redelivered, so handle must be idempotent.
+func (m *ManagedConsumer) Process(ctx context.Context, handle func(msg.Message) error) error {
+	return m.process(ctx, handle, nil)
+}
+
+// ProcessBacklog is like Process, but returns nil once the last message
+// of the topic as of when it is called has been handled successfully,
+// or right away if the subscription has no backlog. It lets batch jobs
+// process the current backlog, then exit. It is meant for exclusive and
+// failover subscriptions: on shared ones, the last message may be
+// dispatched to another consumer.
+func (m *ManagedConsumer) ProcessBacklog(ctx context.Context, handle func(msg.Message) error) error {
+	resp, err := m.GetLastMessageID(ctx)
+	if err != nil {
+		return err
+	}
+	last := msg.NewMessageID(resp.GetLastMessageId())
+	if last.EntryID == math.MaxUint64 {
+		// the topic is empty
+		return nil
+	}
+	if resp.ConsumerMarkDeletePosition != nil && reached(msg.NewMessageID(resp.ConsumerMarkDeletePosition), last) {
+		return nil
+	}
+
+	return m.process(ctx, handle, func(handled msg.Message) bool {
+		return reached(handled.ID(), last)
+	})
+}
+
+// reached returns true if the entry of id is the entry of
+// last or after it. Batch indexes are ignored, since batches
+// are received as single messages, and so are partitions,
+// which the broker may or may not set.
+func reached(id, last msg.MessageID) bool {
+	id.Partition, id.BatchIndex = last.Partition, last.BatchIndex
+	return !id.Before(last)
+}
+
+// process implements Process. It returns nil once done returns true for
+// a message handled successfully, if done isn't nil.
+func (m *ManagedConsumer) process(ctx context.Context, handle func(msg.Message) error, done func(msg.Message) bool) error {
+	backoff := utils.Backoff{
+		Initial: m.cfg.InitialRedeliveryDelay,
+		Max:     m.cfg.MaxRedeliveryDelay,
+	}
+
+	for {
+		msg, err := m.Receive(ctx)
+		if err != nil {
+			return err
+		}
+
+		handleErr := handle(msg)
+		if handleErr != nil {
+			if !sleep(m.cfg.clock(), backoff.Next(), ctx.Done()) {
+				return ctx.Err()
+			}
+			err = m.Nack(ctx, msg)
+		} else {
+			backoff.Reset()
+			err = m.Ack(ctx, msg)
+		}
+		switch {
+		case err == nil:
+		case ctx.Err() != nil:
+			return ctx.Err()
+		case errors.Is(err, ErrManagedConsumerClosed):
+			return err
+		default:
+			m.sendErr(err)
+		}
+
+		if handleErr == nil && done != nil && done(msg) {
+			return nil
+		}
+	}
+}
+
+// GetLastMessageID acquires a consumer and asks the broker for the ID of
+// the last message of the topic, and the mark-delete position of the
+// subscription, which older brokers don't send. The last message ID of
+// an empty topic has ledger and entry IDs of -1, i.e. math.MaxUint64.
+func (m *ManagedConsumer) GetLastMessageID(ctx context.Context) (*api.CommandGetLastMessageIdResponse, error) {
+	for {
+		m.mu.RLock()
+		consumer := m.consumer
+		wait := m.waitc
+		m.mu.RUnlock()
+
+		if consumer == nil {
+			select {
+			case <-wait:
+				// a new consumer was established.
+				// Re-enter read-lock to obtain it.
+				continue
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			case <-m.ctx.Done():
+				return nil, ErrManagedConsumerClosed
+			}
+		}
+		return consumer.GetLastMessageID(ctx)
+	}
+}
+
+// AckLatency returns the distribution of the time between receiving
+// messages from the broker and acknowledging them with Ack, i.e. the
+// time they spent queued and being processed by the application.
+func (m *ManagedConsumer) AckLatency() utils.HistogramSnapshot {
+	return m.ackLatency.Snapshot()
+}
+
+// Decode decodes the payload of msg into v, using the version of
+// the topic's schema msg was produced with. Versions are fetched
+// from the broker the first time they are seen, then cached by the
+// ClientPool. Messages without a schema version are decoded with
+// the configured Schema.
+func (m *ManagedConsumer) Decode(ctx context.Context, msg msg.Message, v interface{}) error {
+	s, err := m.schemaFor(ctx, msg.SchemaVersion())
+	if err != nil {
+		return err
+	}
+	return s.Decode(msg.Payload, v)
+}
+
+// schemaFor returns the Schema for the given version of the topic's schema.
+func (m *ManagedConsumer) schemaFor(ctx context.Context, version []byte) (schema.Schema, error) {
+	if len(version) == 0 {
+		if m.cfg.Schema == nil {
+			return nil, ErrNoSchema
+		}
+		return m.cfg.Schema, nil
+	}
+	return m.clientPool.Schema(ctx, m.clientConfig(), m.cfg.Topic, version)
+}
+
 // Receive returns a single Message, if available.
 // A reasonable context should be provided that will be used
 // to wait for an incoming message if none are available.
 func (m *ManagedConsumer) Receive(ctx context.Context) (msg.Message, error) {
+	retry := retryTimer{clock: m.cfg.clock()}
+	defer retry.stop()
+
 	for {
 		m.mu.RLock()
 		consumer := m.consumer
@@ -198,21 +511,48 @@ func (m *ManagedConsumer) Receive(ctx context.Context) (msg.Message, error) {
 				continue
 			case <-ctx.Done():
 				return msg.Message{}, ctx.Err()
+			case <-m.ctx.Done():
+				return msg.Message{}, ErrManagedConsumerClosed
 			}
 		}
 
 		// TODO: determine when, if ever, to call
 		// consumer.RedeliverOverflow
 
-		if err := consumer.Flow(1); err != nil {
+		// only request a message if none is
+		// already buffered or requested
+		delay, err := m.flowUpTo(consumer, 0, 1)
+		if err != nil {
 			return msg.Message{}, err
 		}
+		retry.reset(delay)
 
 		select {
-		case msg := <-m.queue:
+		case msg := <-consumer.Queue:
+			consumer.Dequeued(msg)
+			if isStale(consumer) {
+				// the message belongs to a previous consumer,
+				// and will be redelivered to the new one
+				atomic.AddUint64(&m.dropped, 1)
+				msg.ReleasePayload()
+				continue
+			}
+			if m.skipped(consumer, msg) {
+				continue
+			}
+			m.delivered(msg)
 			return msg, nil
 
+		case <-retry.C():
+			// the pacer has a permit
+			continue
+
+		case <-consumer.DropSignal:
+			// a dropped message used the permit
+			continue
+
 		case <-consumer.OverflowSignal:
+			m.event(EventOverflow, nil)
 			return msg.Message{}, errors.New("consumer overflow")
 
 		case <-ctx.Done():
@@ -223,10 +563,22 @@ func (m *ManagedConsumer) Receive(ctx context.Context) (msg.Message, error) {
 
 		case <-consumer.ConnClosed():
 			return msg.Message{}, errors.New("consumer connection closed")
+
+		case <-m.ctx.Done():
+			return msg.Message{}, ErrManagedConsumerClosed
 		}
 	}
 }
 
+// delivered records a message returned to the application.
+func (m *ManagedConsumer) delivered(msg msg.Message) {
+	m.received.inc()
+	m.e2e.observe(msg)
+	if m.pace != nil {
+		m.pace.charge(len(msg.Payload))
+	}
+}
+
 func (m *ManagedConsumer) Consumer(ctx context.Context) *sub.Consumer {
 	// gain lock on consumer
 	m.mu.RLock()
@@ -237,28 +589,19 @@ func (m *ManagedConsumer) Consumer(ctx context.Context) *sub.Consumer {
 
 // ReceiveAsync blocks until the context is done. It continuously reads messages from the
 // consumer and Sends them to the provided channel. It manages flow control internally based
-// on the queue size.
+// on the configured flow watermarks (see ConsumerConfig).
 func (m *ManagedConsumer) ReceiveAsync(ctx context.Context, msgs chan<- msg.Message) error {
-	// Send flow request after 1/2 of the queue
-	// has been consumed
-	highwater := uint32(cap(m.queue)) / 2
+	// average payload size, used for
+	// the byte-based watermarks
+	var avgSize float64
 
-	drain := func() {
-		for {
-			select {
-			case msg := <-m.queue:
-				msgs <- msg
-			default:
-				return
-			}
-		}
-	}
+	// fires when permits held back
+	// by the pacer may be granted
+	retry := retryTimer{clock: m.cfg.clock()}
+	defer retry.stop()
 
 CONSUMER:
 	for {
-		// ensure that the message queue is empty
-		drain()
-
 		// gain lock on consumer
 		m.mu.RLock()
 		consumer := m.consumer
@@ -273,58 +616,193 @@ CONSUMER:
 				continue
 			case <-ctx.Done():
 				return ctx.Err()
+			case <-m.ctx.Done():
+				return ErrManagedConsumerClosed
 			}
 		}
 
 		// TODO: determine when, if ever, to call
 		// consumer.RedeliverOverflow
 
-		// request half the buffer's capacity
-		if err := consumer.Flow(highwater); err != nil {
-			m.asyncErrs.Send(err)
+		// request up to the high watermark, minus
+		// whatever is still buffered or requested
+		lowwater, highwater := m.watermarks(avgSize)
+		delay, err := m.flowUpTo(consumer, highwater-1, highwater)
+		if err != nil {
+			m.sendErr(err)
 			continue CONSUMER
 		}
-
-		var receivedSinceFlow uint32
+		retry.reset(delay)
 
 		for {
 			select {
-			case msg := <-m.queue:
+			case msg := <-consumer.Queue:
+				consumer.Dequeued(msg)
+				if isStale(consumer) {
+					// the message belongs to a previous consumer,
+					// and will be redelivered to the new one
+					atomic.AddUint64(&m.dropped, 1)
+					msg.ReleasePayload()
+					continue CONSUMER
+				}
+				if m.skipped(consumer, msg) {
+					if err := m.reflow(consumer, lowwater, highwater, &retry); err != nil {
+						m.sendErr(err)
+						continue CONSUMER
+					}
+					continue
+				}
+				m.delivered(msg)
+
 				if len(msgs) == cap(msgs) {
-					log.Debugf("msg queue blocking,topic:%s\n", msg.Topic)
+					m.logger().Debugf("msg queue blocking")
 					msgs <- msg
-					log.Debugf("msg queue un-blocking ,topic:%s\n", msg.Topic)
+					m.logger().Debugf("msg queue unblocked")
 				} else {
 					msgs <- msg
 				}
 
-				if receivedSinceFlow++; receivedSinceFlow >= highwater {
-					if err := consumer.Flow(receivedSinceFlow); err != nil {
-						m.asyncErrs.Send(err)
-						continue CONSUMER
+				if m.cfg.FlowHighWaterBytes > 0 {
+					// exponential moving average
+					if size := float64(len(msg.Payload)); avgSize == 0 {
+						avgSize = size
+					} else {
+						avgSize += (size - avgSize) / 16
 					}
-					receivedSinceFlow = 0
+					lowwater, highwater = m.watermarks(avgSize)
+				}
+
+				if err := m.reflow(consumer, lowwater, highwater, &retry); err != nil {
+					m.sendErr(err)
+					continue CONSUMER
 				}
 				continue
 
+			case <-retry.C():
+				if err := m.reflow(consumer, lowwater, highwater, &retry); err != nil {
+					m.sendErr(err)
+					continue CONSUMER
+				}
+
+			case <-consumer.DropSignal:
+				// dropped messages used permits
+				if err := m.reflow(consumer, lowwater, highwater, &retry); err != nil {
+					m.sendErr(err)
+					continue CONSUMER
+				}
+
 			case <-ctx.Done():
 				return ctx.Err()
 
 			case <-consumer.OverflowSignal:
-				receivedSinceFlow++
+				m.event(EventOverflow, nil)
+				// the dropped message used a permit
+				if err := m.reflow(consumer, lowwater, highwater, &retry); err != nil {
+					m.sendErr(err)
+					continue CONSUMER
+				}
 
 			case <-consumer.Closed():
-				m.asyncErrs.Send(errors.New("consumer closed"))
+				m.sendErr(errors.New("consumer closed"))
 				continue CONSUMER
 
 			case <-consumer.ConnClosed():
-				m.asyncErrs.Send(errors.New("consumer connection closed"))
+				m.sendErr(errors.New("consumer connection closed"))
 				continue CONSUMER
+
+			case <-m.ctx.Done():
+				return ErrManagedConsumerClosed
 			}
 		}
 	}
 }
 
+// isStale returns true if the Consumer, or its connection, has been
+// closed. Any messages still buffered by a stale Consumer must not be
+// delivered: acks for them would be sent on behalf of a consumer that
+// no longer exists, and the broker redelivers all unacknowledged
+// messages to the Consumer that replaces it.
+func isStale(c *sub.Consumer) bool {
+	select {
+	case <-c.Closed():
+		return true
+	case <-c.ConnClosed():
+		return true
+	default:
+		return false
+	}
+}
+
+// watermarks returns the low and high flow control watermarks, in
+// number of messages, given the average payload size received so far.
+func (m *ManagedConsumer) watermarks(avgSize float64) (lowwater, highwater uint32) {
+	low, high := m.cfg.FlowLowWater, m.cfg.FlowHighWater
+
+	if m.cfg.FlowHighWaterBytes > 0 && avgSize >= 1 {
+		if n := int(float64(m.cfg.FlowHighWaterBytes) / avgSize); n < high {
+			high = n
+		}
+		if n := int(float64(m.cfg.FlowLowWaterBytes) / avgSize); n < low {
+			low = n
+		}
+	}
+
+	if high < 1 {
+		high = 1
+	}
+	if low >= high {
+		low = high - 1
+	}
+	return uint32(low), uint32(high)
+}
+
+// memoryRetryDelay is how often a ManagedConsumer checks whether
+// it may request messages again, while the ClientPool's MemoryLimit
+// is exceeded.
+const memoryRetryDelay = 100 * time.Millisecond
+
+// flowUpTo requests more permits from the broker once the number of
+// messages that are either buffered or already requested has dropped
+// to lowwater or below, bringing it back up to highwater. Permits are
+// tracked by the Consumer, so a new Consumer (after a reconnect)
+// starts with none. If the pacer holds permits back, wait is how
+// long until flowUpTo should be called again, as when the
+// ClientPool's MemoryLimit is exceeded.
+func (m *ManagedConsumer) flowUpTo(c *sub.Consumer, lowwater, highwater uint32) (wait time.Duration, err error) {
+	permits := c.Permits()
+	if permits < 0 {
+		// the broker pushed more messages than
+		// were requested, eg redeliveries
+		permits = 0
+	}
+	inflight := permits + int64(len(c.Queue))
+	if inflight > int64(lowwater) || inflight >= int64(highwater) {
+		return 0, nil
+	}
+	if m.clientPool.memory.Exceeded() {
+		// buffered messages are using up the
+		// memory limit, so none is requested
+		return memoryRetryDelay, nil
+	}
+	n := uint32(int64(highwater) - inflight)
+	if m.pace != nil {
+		if n, wait = m.pace.take(n); n == 0 {
+			return wait, nil
+		}
+	}
+	return wait, c.Flow(n)
+}
+
+// reflow calls flowUpTo, and resets retry if the pacer held
+// permits back. A pending retry is kept if no permit was needed.
+func (m *ManagedConsumer) reflow(c *sub.Consumer, lowwater, highwater uint32, retry *retryTimer) error {
+	wait, err := m.flowUpTo(c, lowwater, highwater)
+	if wait > 0 {
+		retry.reset(wait)
+	}
+	return err
+}
+
 // set unblocks the "wait" channel (if not nil),
 // and sets the consumer under lock.
 func (m *ManagedConsumer) set(c *sub.Consumer) {
@@ -356,9 +834,34 @@ func (m *ManagedConsumer) unset() {
 	m.mu.Unlock()
 }
 
-// newConsumer attempts to create a Consumer.
+// clientConfig returns the ClientConfig used to create Consumers.
+func (m *ManagedConsumer) clientConfig() ClientConfig {
+	return m.migration.apply(m.activeConfig())
+}
+
+// activeConfig returns the ClientConfig of the active cluster,
+// regardless of any migration of the topic.
+func (m *ManagedConsumer) activeConfig() ClientConfig {
+	if m.cfg.Failover != nil {
+		return m.cfg.Failover.config()
+	}
+	return m.cfg.ClientConfig
+}
+
+// switched returns a channel that unblocks when the Consumer must
+// be recreated on another cluster. It never unblocks without Failover.
+func (m *ManagedConsumer) switched() <-chan struct{} {
+	if m.cfg.Failover != nil {
+		return m.cfg.Failover.Switched()
+	}
+	return nil
+}
+
+// newConsumer attempts to create a Consumer. Every Consumer
+// gets its own message queue, so that messages buffered by a
+// previous Consumer are never delivered as if they were its own.
 func (m *ManagedConsumer) newConsumer(ctx context.Context) (*sub.Consumer, error) {
-	mc, err := m.clientPool.ForTopic(ctx, m.cfg.ClientConfig, m.cfg.Topic)
+	mc, err := m.clientPool.ForTopic(ctx, m.clientConfig(), m.cfg.Topic)
 	if err != nil {
 		return nil, err
 	}
@@ -367,90 +870,292 @@ func (m *ManagedConsumer) newConsumer(ctx context.Context) (*sub.Consumer, error
 	if err != nil {
 		return nil, err
 	}
+	m.broker.Store(brokerAddr(mc.cfg))
+
+	queue := make(chan msg.Message, m.cfg.QueueSize)
 
 	// Create the topic consumer. A non-blank consumer name is required.
+	var subType api.CommandSubscribe_SubType
 	switch m.cfg.SubMode {
 	case SubscriptionModeExclusive:
-		return client.NewExclusiveConsumer(ctx, m.cfg.Topic, m.cfg.Name, m.cfg.Earliest, m.queue)
+		subType = api.CommandSubscribe_Exclusive
 	case SubscriptionModeFailover:
-		return client.NewFailoverConsumer(ctx, m.cfg.Topic, m.cfg.Name, m.cfg.Earliest, m.queue)
+		subType = api.CommandSubscribe_Failover
 	case SubscriptionModeShard:
-		return client.NewSharedConsumer(ctx, m.cfg.Topic, m.cfg.Name, m.cfg.Earliest, m.queue)
+		subType = api.CommandSubscribe_Shared
 	default:
 		return nil, ErrorInvalidSubMode
 	}
+	initialPosition := m.cfg.InitialPosition
+	if m.subscribed && initialPosition != sub.Earliest {
+		initialPosition = sub.Latest
+	}
+	c, err := client.NewConsumerWithSchema(ctx, m.cfg.Topic, m.cfg.Name, subType, initialPosition, queue, m.cfg.Schema)
+	if err != nil {
+		return nil, err
+	}
+	if !m.subscribed && m.cfg.Checkpoints != nil {
+		if err = m.restoreCheckpoint(ctx, c); err != nil {
+			_ = c.Close(ctx)
+			return nil, err
+		}
+	}
+	c.SetMemoryLimit(m.clientPool.memory)
+	m.subscribed = true
+	return c, nil
 }
 
 // reconnect blocks while a new Consumer is created.
+// Nil will be returned if and only if the ManagedConsumer's
+// context is done.
 func (m *ManagedConsumer) reconnect(initial bool) *sub.Consumer {
-	retryDelay := m.cfg.InitialReconnectDelay
+	backoff := utils.Backoff{
+		Initial: m.cfg.InitialReconnectDelay,
+		Max:     m.cfg.MaxReconnectDelay,
+	}
 	reconnectFlag := initial
 
 	for attempt := 1; ; attempt++ {
 		if initial {
 			initial = false
-		} else {
-			<-time.After(retryDelay)
-			if retryDelay < m.cfg.MaxReconnectDelay {
-				// double retry delay until we reach the max
-				if retryDelay *= 2; retryDelay > m.cfg.MaxReconnectDelay {
-					retryDelay = m.cfg.MaxReconnectDelay
-				}
+			select {
+			case <-m.ctx.Done():
+				return nil
+			default:
 			}
+		} else if !sleep(m.cfg.clock(), backoff.Next(), m.ctx.Done()) {
+			return nil
 		}
 
-		ctx, cancel := context.WithTimeout(context.Background(), m.cfg.NewConsumerTimeout)
+		ctx, cancel := context.WithTimeout(m.ctx, m.cfg.NewConsumerTimeout)
 		if !reconnectFlag {
-			log.Debugf("reconnecting consumer topic:%v\n", m.cfg.Topic)
+			m.logger().Debugf("reconnecting consumer")
 		}
 		newConsumer, err := m.newConsumer(ctx)
 		cancel()
 		if err != nil {
-			m.asyncErrs.Send(err)
+			m.event(EventError, err)
+			m.sendErr(err)
 			continue
 		}
+		m.event(EventConnected, nil)
 		if !reconnectFlag {
-			log.Debugf("reconnect consumer sucess, topic:%v\n", m.cfg.Topic)
+			m.logger().Debugf("reconnected consumer")
 		}
 
 		return newConsumer
 	}
 }
 
+// labels returns the labels of the ManagedConsumer's
+// log messages and errors.
+func (m *ManagedConsumer) labels() map[string]string {
+	labels := map[string]string{
+		"topic":        m.cfg.Topic,
+		"subscription": m.cfg.Name,
+	}
+	m.mu.RLock()
+	if m.consumer != nil {
+		labels["consumer_id"] = strconv.FormatUint(m.consumer.ConsumerID, 10)
+	}
+	m.mu.RUnlock()
+	if broker, _ := m.broker.Load().(string); broker != "" {
+		labels["broker"] = broker
+	}
+	return labels
+}
+
+// logger returns a Logger labeling messages
+// with the ManagedConsumer's labels.
+func (m *ManagedConsumer) logger() *log.Logger {
+	return labeledLogger(m.labels())
+}
+
+// sendErr reports err to the async errors channel and the
+// ErrorListener, labeled with the ManagedConsumer's labels.
+func (m *ManagedConsumer) sendErr(err error) {
+	reportErr(m.asyncErrs, &m.droppedErrs, m.cfg.ErrorListener, err, ErrorScope{
+		Entity: ScopeConsumer,
+		Labels: m.labels(),
+	})
+}
+
+// event records a lifecycle event of the ManagedConsumer.
+func (m *ManagedConsumer) event(typ EventType, err error) {
+	e := Event{
+		Type:  typ,
+		Topic: m.cfg.Topic,
+		Name:  m.cfg.Name,
+	}
+	if err != nil {
+		e.Err = err.Error()
+	}
+	m.clientPool.events.add(e)
+}
+
 // manage Monitors the Consumer for conditions
 // that require it to be recreated.
 func (m *ManagedConsumer) manage() {
+	setLabels("role", "consumer", "topic", m.cfg.Topic, "subscription", m.cfg.Name)
+
+	m.clientPool.registry.add(m)
+	defer m.clientPool.registry.remove(m)
+	defer close(m.donec)
 	defer m.unset()
+	if m.cfg.Checkpoints != nil {
+		// the final checkpoint
+		defer func() {
+			ctx, cancel := context.WithTimeout(context.Background(), m.cfg.CheckpointInterval)
+			m.saveCheckpoint(ctx)
+			cancel()
+		}()
+	}
 
+	switched := m.switched()
 	consumer := m.reconnect(true)
+	if consumer == nil {
+		// consumer == nil only if the
+		// context is done
+		return
+	}
 	m.set(consumer)
 
+	var checkpoint <-chan time.Time
+	if m.cfg.Checkpoints != nil {
+		ticker := m.cfg.clock().NewTicker(m.cfg.CheckpointInterval)
+		defer ticker.Stop()
+		checkpoint = ticker.C()
+	}
+
 	for {
 		select {
+		case <-checkpoint:
+			ctx, cancel := context.WithTimeout(m.ctx, m.cfg.CheckpointInterval)
+			m.saveCheckpoint(ctx)
+			cancel()
+			continue
+
 		case <-consumer.ReachedEndOfTopic():
-			// TODO: What to do here? For now, reconnect
-			// reconnect
+			// TODO: What to do here? For now, reconnect.
+			// The consumer is still open, so close it first
+			// for its buffered messages to be considered stale.
+			m.closeConsumer(consumer)
+
+		case <-switched:
+			// the active cluster changed
+			m.closeConsumer(consumer)
 
 		case <-consumer.Closed():
-			// reconnect
+			// reconnect, to the cluster the
+			// topic was migrated to, if any
+			if url, tlsURL, ok := consumer.MigratedTo(); ok {
+				m.migration.set(m.activeConfig(), url, tlsURL)
+			}
 
 		case <-consumer.ConnClosed():
 			// reconnect
 
-		case <-m.stopManageChan:
+		case <-m.ctx.Done():
+			m.unset()
+			ctx, cancel := context.WithTimeout(context.Background(), m.cfg.NewConsumerTimeout)
+			m.closeErr = consumer.Close(ctx)
+			cancel()
+			m.drain(consumer)
+			m.event(EventClosed, m.closeErr)
 			return
 		}
 
 		m.unset()
+		m.event(EventDisconnected, nil)
+		m.drain(consumer)
+		atomic.AddInt32(&m.reconnects, 1)
+		atomic.AddUint64(&m.overflowed, consumer.Overflowed())
+		switched = m.switched()
 		oldConsumer := consumer
-		consumer = m.reconnect(false)
+		if consumer = m.reconnect(false); consumer == nil {
+			// consumer == nil only if the
+			// context is done
+			return
+		}
+		// overflowed messages of the old consumer are not carried
+		// over: the broker redelivers them to the new consumer
 		consumer.OverflowSignal = oldConsumer.OverflowSignal
-
-		oldConsumer.Omu.Lock()
-		consumer.Overflow = oldConsumer.Overflow
-		oldConsumer.Omu.Unlock()
 		m.set(consumer)
+		m.runReconnectHooks(consumer)
+	}
+}
+
+// drain drops the messages still buffered by a Consumer that was
+// replaced or closed, releasing their memory, if the ClientPool has a
+// MemoryLimit. They are never delivered, and are redelivered by the
+// broker, but would otherwise use up the limit.
+func (m *ManagedConsumer) drain(c *sub.Consumer) {
+	if m.clientPool.memory == nil {
+		return
+	}
+	for {
+		select {
+		case msg := <-c.Queue:
+			c.Dequeued(msg)
+			atomic.AddUint64(&m.dropped, 1)
+			msg.ReleasePayload()
+		default:
+			return
+		}
+	}
+}
+
+// closeConsumer closes a Consumer that is about to be replaced.
+func (m *ManagedConsumer) closeConsumer(consumer *sub.Consumer) {
+	ctx, cancel := context.WithTimeout(m.ctx, m.cfg.NewConsumerTimeout)
+	defer cancel()
+	if err := consumer.Close(ctx); err != nil {
+		m.sendErr(err)
+	}
+}
+
+// inspect implements inspector.
+func (m *ManagedConsumer) inspect() EntityInfo {
+	m.mu.RLock()
+	consumer := m.consumer
+	m.mu.RUnlock()
+
+	info := EntityInfo{
+		Topic:      m.cfg.Topic,
+		Name:       m.cfg.Name,
+		Reconnects: int(atomic.LoadInt32(&m.reconnects)),
+		Overflowed: m.Overflowed(),
+		Dropped:    m.Dropped(),
+	}
+	switch {
+	case consumer != nil:
+		info.State = EntityConnected
+	case info.Reconnects == 0:
+		info.State = EntityConnecting
+	default:
+		info.State = EntityReconnecting
+	}
+	return info
+}
+
+// Overflowed returns the number of messages dropped because the
+// queue was full. They are redelivered by RedeliverOverflow, or
+// by the broker after reconnecting.
+func (m *ManagedConsumer) Overflowed() uint64 {
+	n := atomic.LoadUint64(&m.overflowed)
+	m.mu.RLock()
+	if m.consumer != nil {
+		n += m.consumer.Overflowed()
 	}
+	m.mu.RUnlock()
+	return n
+}
+
+// Dropped returns the number of messages buffered by a Consumer
+// that were dropped because the Consumer was replaced before they
+// were received. The broker redelivers them to the new Consumer.
+func (m *ManagedConsumer) Dropped() uint64 {
+	return atomic.LoadUint64(&m.dropped)
 }
 
 // RedeliverUnacknowledged sends of REDELIVER_UNACKNOWLEDGED_MESSAGES request
@@ -470,6 +1175,8 @@ func (m *ManagedConsumer) RedeliverUnacknowledged(ctx context.Context) error {
 				continue
 			case <-ctx.Done():
 				return ctx.Err()
+			case <-m.ctx.Done():
+				return ErrManagedConsumerClosed
 			}
 		}
 		return consumer.RedeliverUnacknowledged(ctx)
@@ -496,6 +1203,8 @@ func (m *ManagedConsumer) RedeliverOverflow(ctx context.Context) (int, error) {
 				continue
 			case <-ctx.Done():
 				return -1, ctx.Err()
+			case <-m.ctx.Done():
+				return -1, ErrManagedConsumerClosed
 			}
 		}
 		return consumer.RedeliverOverflow(ctx)
@@ -518,6 +1227,8 @@ func (m *ManagedConsumer) Unsubscribe(ctx context.Context) error {
 				continue
 			case <-ctx.Done():
 				return ctx.Err()
+			case <-m.ctx.Done():
+				return ErrManagedConsumerClosed
 			}
 		}
 		return consumer.Unsubscribe(ctx)
@@ -530,26 +1241,24 @@ func (m *ManagedConsumer) Monitor() func() {
 	return m.mu.Unlock
 }
 
-// Close consumer
-func (m *ManagedConsumer) Close(ctx context.Context) error {
-	for {
-		m.mu.RLock()
-		consumer := m.consumer
-		wait := m.waitc
-		m.mu.RUnlock()
+// Done returns a channel that unblocks when the ManagedConsumer
+// has stopped managing its Consumer, either because Close was
+// called or because its parent context is done.
+func (m *ManagedConsumer) Done() <-chan struct{} {
+	return m.donec
+}
 
-		if consumer == nil {
-			select {
-			case <-wait:
-				// a new consumer was established.
-				// Re-enter read-lock to obtain it.
-				continue
-			case <-ctx.Done():
-				return ctx.Err()
-			}
-		}
-		// stop manage()
-		close(m.stopManageChan)
-		return m.consumer.Close(ctx)
+// Close stops the ManagedConsumer and closes its Consumer, if one
+// was established. It waits for the Consumer to close or for ctx to
+// be done. The ManagedConsumer shouldn't be used after calling Close.
+func (m *ManagedConsumer) Close(ctx context.Context) error {
+	// stop manage()
+	m.cancel()
+
+	select {
+	case <-m.donec:
+		return m.closeErr
+	case <-ctx.Done():
+		return ctx.Err()
 	}
 }
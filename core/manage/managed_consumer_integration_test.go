@@ -52,7 +52,7 @@ func TestManagedConsumer_Int_ReceiveAsync(t *testing.T) {
 		QueueSize:    128,
 		SubMode:      SubscriptionModeFailover,
 	}
-	mc := NewManagedConsumer(cp, consumerCfg)
+	mc := NewManagedConsumer(ctx, cp, consumerCfg)
 	go func() {
 		errs <- mc.ReceiveAsync(ctx, messages)
 	}()
@@ -62,7 +62,7 @@ func TestManagedConsumer_Int_ReceiveAsync(t *testing.T) {
 		Name:         utils.RandString(8),
 		Topic:        topic,
 	}
-	mp := NewManagedProducer(cp, producerCfg)
+	mp := NewManagedProducer(ctx, cp, producerCfg)
 
 	expected := make([]string, 2048)
 	for i := range expected {
@@ -156,7 +156,7 @@ func TestManagedConsumer_Int_ReceiveAsync_Multiple(t *testing.T) {
 			Topic:        topic,
 			QueueSize:    128,
 		}
-		consumers[i] = NewManagedConsumer(cp, consumerCfg)
+		consumers[i] = NewManagedConsumer(ctx, cp, consumerCfg)
 		go func(mc *ManagedConsumer) {
 			errs <- mc.ReceiveAsync(ctx, messages)
 		}(consumers[i])
@@ -167,7 +167,7 @@ func TestManagedConsumer_Int_ReceiveAsync_Multiple(t *testing.T) {
 		Name:         utils.RandString(8),
 		Topic:        topic,
 	}
-	mp := NewManagedProducer(cp, producerCfg)
+	mp := NewManagedProducer(ctx, cp, producerCfg)
 
 	expected := make([]string, 2048)
 	for i := range expected {
@@ -15,6 +15,7 @@ package manage
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"testing"
 	"time"
@@ -22,8 +23,11 @@ import (
 	"github.com/golang/protobuf/proto"
 	"github.com/pepper-iot/pulsar-client-go/core/frame"
 	"github.com/pepper-iot/pulsar-client-go/core/msg"
+	"github.com/pepper-iot/pulsar-client-go/core/schema"
 	"github.com/pepper-iot/pulsar-client-go/core/srv"
+	"github.com/pepper-iot/pulsar-client-go/core/sub"
 	"github.com/pepper-iot/pulsar-client-go/pkg/api"
+	"github.com/pepper-iot/pulsar-client-go/utils"
 )
 
 func TestManagedConsumer(t *testing.T) {
@@ -36,7 +40,7 @@ func TestManagedConsumer(t *testing.T) {
 	}
 
 	cp := NewClientPool()
-	mc := NewManagedConsumer(cp, ConsumerConfig{
+	mc := NewManagedConsumer(ctx, cp, ConsumerConfig{
 		ClientConfig: ClientConfig{
 			Addr: srv.Addr,
 		},
@@ -100,6 +104,168 @@ func TestManagedConsumer(t *testing.T) {
 		t.Fatalf("Receive() message payload = %q; expected %q\n%#v", got, expected, msg)
 	}
 	t.Logf("Receive() message payload = %q", msg.Payload)
+
+	if err = mc.Ack(ctx, msg); err != nil {
+		t.Fatalf("Ack() err = %v; nil expected", err)
+	}
+	if got, expected := mc.AckLatency().Count, uint64(1); got != expected {
+		t.Fatalf("AckLatency().Count = %d; expected %d", got, expected)
+	}
+}
+
+func TestManagedConsumer_Receive_Buffered(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	srv, err := srv.NewServer(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cp := NewClientPool()
+	mc := NewManagedConsumer(ctx, cp, ConsumerConfig{
+		ClientConfig: ClientConfig{
+			Addr: srv.Addr,
+		},
+		NewConsumerTimeout: time.Second,
+		Topic:              "test-topic",
+		Name:               "test",
+		SubMode:            SubscriptionModeShard,
+	})
+
+	expectedFrames := []api.BaseCommand_Type{
+		api.BaseCommand_CONNECT,
+		api.BaseCommand_LOOKUP,
+	}
+	if err = srv.AssertReceived(ctx, expectedFrames...); err != nil {
+		t.Fatal(err)
+	}
+
+	var consumerID uint64
+	select {
+	case f := <-srv.Received:
+		if got, expected := f.BaseCmd.GetType(), api.BaseCommand_SUBSCRIBE; got != expected {
+			t.Fatalf("got frame type %q; expected %q", got, expected)
+		}
+		consumerID = f.BaseCmd.GetSubscribe().GetConsumerId()
+
+	case <-time.After(time.Second):
+		t.Fatal("timeout waiting for SUBSCRIBE message")
+	}
+
+	// The first Receive requests a single message. The
+	// server pushes two, so the second Receive must be served
+	// from the buffer without requesting another.
+	received := make(chan error, 1)
+	go func() {
+		_, err := mc.Receive(ctx)
+		received <- err
+	}()
+
+	if err = srv.AssertReceived(ctx, api.BaseCommand_FLOW); err != nil {
+		t.Fatal(err)
+	}
+
+	for i := 0; i < 2; i++ {
+		message := frame.Frame{
+			BaseCmd: &api.BaseCommand{
+				Type: api.BaseCommand_MESSAGE.Enum(),
+				Message: &api.CommandMessage{
+					ConsumerId: proto.Uint64(consumerID),
+					MessageId: &api.MessageIdData{
+						EntryId:  proto.Uint64(uint64(i)),
+						LedgerId: proto.Uint64(1),
+					},
+				},
+			},
+			Metadata: &api.MessageMetadata{
+				ProducerName: proto.String("something"),
+				SequenceId:   proto.Uint64(uint64(i)),
+				PublishTime:  proto.Uint64(12345),
+			},
+			Payload: []byte("hola mundo"),
+		}
+		if err = srv.Broadcast(message); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err = <-received; err != nil {
+		t.Fatalf("Receive() err = %v; nil expected", err)
+	}
+	// wait for the second message to be buffered
+	time.Sleep(100 * time.Millisecond)
+	if _, err = mc.Receive(ctx); err != nil {
+		t.Fatalf("Receive() err = %v; nil expected", err)
+	}
+
+	select {
+	case f := <-srv.Received:
+		t.Fatalf("got unexpected frame of type %q", f.BaseCmd.GetType())
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
+func TestManagedConsumer_MaxMessagesPerSecond(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	srv, err := srv.NewServer(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	clock := utils.NewManualClock(time.Now())
+	mc := NewManagedConsumer(ctx, NewClientPool(), ConsumerConfig{
+		ClientConfig: ClientConfig{
+			Addr:          srv.Addr,
+			PingFrequency: time.Hour,
+			Clock:         clock,
+		},
+		NewConsumerTimeout:   time.Second,
+		Topic:                "test-topic",
+		Name:                 "test",
+		SubMode:              SubscriptionModeShard,
+		MaxMessagesPerSecond: 2,
+	})
+
+	expectedFrames := []api.BaseCommand_Type{
+		api.BaseCommand_CONNECT,
+		api.BaseCommand_LOOKUP,
+		api.BaseCommand_SUBSCRIBE,
+	}
+	if err = srv.AssertReceived(ctx, expectedFrames...); err != nil {
+		t.Fatal(err)
+	}
+
+	go func() {
+		_ = mc.ReceiveAsync(ctx, make(chan msg.Message, 8))
+	}()
+
+	flow := func(expected uint32) {
+		t.Helper()
+		select {
+		case f := <-srv.Received:
+			if got := f.BaseCmd.GetType(); got != api.BaseCommand_FLOW {
+				t.Fatalf("got frame type %q; expected %q", got, api.BaseCommand_FLOW)
+			}
+			if got := f.BaseCmd.GetFlow().GetMessagePermits(); got != expected {
+				t.Fatalf("FLOW permits = %d; expected %d", got, expected)
+			}
+		case <-ctx.Done():
+			t.Fatal("timeout waiting for FLOW message")
+		}
+	}
+
+	// one second worth of permits, then
+	// one more each half second
+	flow(2)
+	for i := 0; i < 2; i++ {
+		// wait for the retry timer, in addition to the ping ticker
+		clock.BlockUntil(2)
+		clock.Advance(500 * time.Millisecond)
+		flow(1)
+	}
 }
 
 func TestManagedConsumer_ReceiveAsync(t *testing.T) {
@@ -114,7 +280,7 @@ func TestManagedConsumer_ReceiveAsync(t *testing.T) {
 	queueSize := 4
 
 	cp := NewClientPool()
-	mc := NewManagedConsumer(cp, ConsumerConfig{
+	mc := NewManagedConsumer(ctx, cp, ConsumerConfig{
 		ClientConfig: ClientConfig{
 			Addr: srv.Addr,
 		},
@@ -238,7 +404,7 @@ func TestManagedConsumer_SrvClosed(t *testing.T) {
 	}
 
 	cp := NewClientPool()
-	NewManagedConsumer(cp, ConsumerConfig{
+	NewManagedConsumer(ctx, cp, ConsumerConfig{
 		ClientConfig: ClientConfig{
 			Addr: srv.Addr,
 		},
@@ -263,6 +429,74 @@ func TestManagedConsumer_SrvClosed(t *testing.T) {
 	}
 }
 
+func TestManagedConsumer_TopicMigrated(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	migratedSrv, err := srv.NewServer(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv, err := srv.NewServer(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cp := NewClientPool()
+	NewManagedConsumer(ctx, cp, ConsumerConfig{
+		ClientConfig: ClientConfig{
+			Addr: srv.Addr,
+		},
+		NewConsumerTimeout: time.Second,
+		Topic:              "test-topic",
+		Name:               "test",
+		SubMode:            SubscriptionModeShard,
+	})
+
+	expectedFrames := []api.BaseCommand_Type{
+		api.BaseCommand_CONNECT,
+		api.BaseCommand_LOOKUP,
+	}
+	if err = srv.AssertReceived(ctx, expectedFrames...); err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case f := <-srv.Received:
+		if got, expected := f.BaseCmd.GetType(), api.BaseCommand_SUBSCRIBE; got != expected {
+			t.Fatalf("got frame type %q; expected %q", got, expected)
+		}
+
+		migrated := frame.Frame{
+			BaseCmd: &api.BaseCommand{
+				Type: api.BaseCommand_TOPIC_MIGRATED.Enum(),
+				TopicMigrated: &api.CommandTopicMigrated{
+					ResourceId:       f.BaseCmd.GetSubscribe().ConsumerId,
+					ResourceType:     api.CommandTopicMigrated_Consumer.Enum(),
+					BrokerServiceUrl: proto.String(migratedSrv.Addr),
+				},
+			},
+		}
+		if err = srv.Broadcast(migrated); err != nil {
+			t.Fatal(err)
+		}
+
+	case <-ctx.Done():
+		t.Fatal(ctx.Err())
+	}
+
+	// the consumer is recreated through a lookup
+	// on the cluster the topic was migrated to
+	expectedFrames = []api.BaseCommand_Type{
+		api.BaseCommand_CONNECT,
+		api.BaseCommand_LOOKUP,
+		api.BaseCommand_SUBSCRIBE,
+	}
+	if err = migratedSrv.AssertReceived(ctx, expectedFrames...); err != nil {
+		t.Fatal(err)
+	}
+}
+
 func TestManagedConsumer_ConsumerClosed(t *testing.T) {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
@@ -273,7 +507,7 @@ func TestManagedConsumer_ConsumerClosed(t *testing.T) {
 	}
 
 	cp := NewClientPool()
-	NewManagedConsumer(cp, ConsumerConfig{
+	NewManagedConsumer(ctx, cp, ConsumerConfig{
 		ClientConfig: ClientConfig{
 			Addr: srv.Addr,
 		},
@@ -330,3 +564,881 @@ func TestManagedConsumer_ConsumerClosed(t *testing.T) {
 		}
 	}
 }
+
+func TestManagedConsumer_Process(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	srv, err := srv.NewServer(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	mc := NewManagedConsumer(ctx, NewClientPool(), ConsumerConfig{
+		ClientConfig: ClientConfig{
+			Addr: srv.Addr,
+		},
+		NewConsumerTimeout:     time.Second,
+		InitialRedeliveryDelay: time.Millisecond,
+		Topic:                  "test-topic",
+		Name:                   "test",
+		SubMode:                SubscriptionModeShard,
+	})
+
+	// next returns the next frame received of the given type
+	next := func(typ api.BaseCommand_Type) frame.Frame {
+		for {
+			select {
+			case f := <-srv.Received:
+				if f.BaseCmd.GetType() == typ {
+					return f
+				}
+			case <-ctx.Done():
+				t.Fatalf("timeout waiting for %s", typ)
+			}
+		}
+	}
+	consumerID := next(api.BaseCommand_SUBSCRIBE).BaseCmd.GetSubscribe().GetConsumerId()
+
+	handled := make(chan string, 2)
+	fail := errors.New("failed")
+	processCtx, stop := context.WithCancel(ctx)
+	processed := make(chan error, 1)
+	go func() {
+		processed <- mc.Process(processCtx, func(m msg.Message) error {
+			handled <- string(m.Payload)
+			if len(handled) == 1 {
+				return fail
+			}
+			return nil
+		})
+	}()
+
+	message := frame.Frame{
+		BaseCmd: &api.BaseCommand{
+			Type: api.BaseCommand_MESSAGE.Enum(),
+			Message: &api.CommandMessage{
+				ConsumerId: proto.Uint64(consumerID),
+				MessageId: &api.MessageIdData{
+					EntryId:  proto.Uint64(1),
+					LedgerId: proto.Uint64(1),
+				},
+			},
+		},
+		Metadata: &api.MessageMetadata{
+			ProducerName: proto.String("something"),
+			SequenceId:   proto.Uint64(42),
+			PublishTime:  proto.Uint64(12345),
+		},
+		Payload: []byte("hola mundo"),
+	}
+
+	// the failed message is negatively acknowledged,
+	// and acknowledged once redelivered and handled
+	if err = srv.Broadcast(message); err != nil {
+		t.Fatal(err)
+	}
+	redeliver := next(api.BaseCommand_REDELIVER_UNACKNOWLEDGED_MESSAGES).BaseCmd.GetRedeliverUnacknowledgedMessages()
+	if got, expected := redeliver.GetMessageIds(), message.BaseCmd.Message.MessageId; len(got) != 1 || !proto.Equal(got[0], expected) {
+		t.Fatalf("redelivered message IDs = %v; expected [%v]", got, expected)
+	}
+	if err = srv.Broadcast(message); err != nil {
+		t.Fatal(err)
+	}
+	ack := next(api.BaseCommand_ACK).BaseCmd.GetAck()
+	if got, expected := ack.GetMessageId(), message.BaseCmd.Message.MessageId; len(got) != 1 || !proto.Equal(got[0], expected) {
+		t.Fatalf("acknowledged message IDs = %v; expected [%v]", got, expected)
+	}
+	if got := len(handled); got != 2 {
+		t.Fatalf("handled %d messages; expected 2", got)
+	}
+
+	stop()
+	if err := <-processed; err != context.Canceled {
+		t.Fatalf("Process() err = %v; expected %v", err, context.Canceled)
+	}
+}
+
+func TestManagedConsumer_ProcessBacklog(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	srv, err := srv.NewServer(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	mc := NewManagedConsumer(ctx, NewClientPool(), ConsumerConfig{
+		ClientConfig: ClientConfig{
+			Addr: srv.Addr,
+		},
+		NewConsumerTimeout: time.Second,
+		Topic:              "test-topic",
+		Name:               "test",
+		SubMode:            SubscriptionModeExclusive,
+	})
+
+	// the topic is empty
+	if err := mc.ProcessBacklog(ctx, func(m msg.Message) error {
+		t.Fatalf("handled message %v of empty topic", m.ID())
+		return nil
+	}); err != nil {
+		t.Fatalf("ProcessBacklog() err = %v; expected nil", err)
+	}
+
+	var consumerID uint64
+	for subscribed := false; !subscribed; {
+		select {
+		case f := <-srv.Received:
+			if f.BaseCmd.GetType() == api.BaseCommand_SUBSCRIBE {
+				consumerID = f.BaseCmd.GetSubscribe().GetConsumerId()
+				subscribed = true
+			}
+		case <-ctx.Done():
+			t.Fatal("timeout waiting for SUBSCRIBE message")
+		}
+	}
+
+	srv.SetLastMessageID(&api.MessageIdData{
+		LedgerId:  proto.Uint64(1),
+		EntryId:   proto.Uint64(2),
+		Partition: proto.Int32(0),
+	})
+	var handled []uint64
+	processed := make(chan error, 1)
+	go func() {
+		processed <- mc.ProcessBacklog(ctx, func(m msg.Message) error {
+			handled = append(handled, m.ID().EntryID)
+			return nil
+		})
+	}()
+
+	for entry := uint64(0); entry < 4; entry++ {
+		message := frame.Frame{
+			BaseCmd: &api.BaseCommand{
+				Type: api.BaseCommand_MESSAGE.Enum(),
+				Message: &api.CommandMessage{
+					ConsumerId: proto.Uint64(consumerID),
+					MessageId: &api.MessageIdData{
+						LedgerId: proto.Uint64(1),
+						EntryId:  proto.Uint64(entry),
+					},
+				},
+			},
+			Metadata: &api.MessageMetadata{
+				ProducerName: proto.String("something"),
+				SequenceId:   proto.Uint64(entry),
+				PublishTime:  proto.Uint64(12345),
+			},
+			Payload: []byte("hola mundo"),
+		}
+		if err = srv.Broadcast(message); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	select {
+	case err := <-processed:
+		if err != nil {
+			t.Fatalf("ProcessBacklog() err = %v; expected nil", err)
+		}
+	case <-ctx.Done():
+		t.Fatal("ProcessBacklog() didn't return at the last message")
+	}
+	if got, expected := fmt.Sprint(handled), "[0 1 2]"; got != expected {
+		t.Fatalf("handled entries %s; expected %s", got, expected)
+	}
+}
+
+func TestManagedConsumer_InitialPosition(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	srv, err := srv.NewServer(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	start := msg.MessageID{LedgerID: 12, EntryID: 34, Partition: -1, BatchIndex: -1}
+	NewManagedConsumer(ctx, NewClientPool(), ConsumerConfig{
+		ClientConfig: ClientConfig{
+			Addr: srv.Addr,
+		},
+
+		NewConsumerTimeout:    time.Second,
+		InitialReconnectDelay: time.Millisecond,
+		Topic:                 "test-topic",
+		Name:                  "test",
+		SubMode:               SubscriptionModeExclusive,
+		InitialPosition:       sub.AtMessageID(start),
+	})
+
+	if err := srv.AssertReceived(ctx, api.BaseCommand_CONNECT); err != nil {
+		t.Fatal(err)
+	}
+
+	// the first SUBSCRIBE starts at the configured message,
+	// and the one after reconnecting resumes from the cursor
+	for i, expected := range []*api.MessageIdData{start.Data(), nil} {
+		if err := srv.AssertReceived(ctx, api.BaseCommand_LOOKUP); err != nil {
+			t.Fatal(err)
+		}
+
+		var subscribe *api.CommandSubscribe
+		select {
+		case f := <-srv.Received:
+			if got, expected := f.BaseCmd.GetType(), api.BaseCommand_SUBSCRIBE; got != expected {
+				t.Fatalf("got frame type %q; expected %q", got, expected)
+			}
+			subscribe = f.BaseCmd.GetSubscribe()
+
+		case <-time.After(time.Second):
+			t.Fatal("timeout waiting for SUBSCRIBE message")
+		}
+		if got := subscribe.GetStartMessageId(); !proto.Equal(got, expected) {
+			t.Fatalf("SUBSCRIBE %d start message ID = %v; expected %v", i, got, expected)
+		}
+		if got, expected := subscribe.GetInitialPosition(), api.CommandSubscribe_Latest; got != expected {
+			t.Fatalf("SUBSCRIBE %d initial position = %v; expected %v", i, got, expected)
+		}
+
+		closeConsumer := frame.Frame{
+			BaseCmd: &api.BaseCommand{
+				Type: api.BaseCommand_CLOSE_CONSUMER.Enum(),
+				CloseConsumer: &api.CommandCloseConsumer{
+					ConsumerId: proto.Uint64(subscribe.GetConsumerId()),
+					RequestId:  proto.Uint64(42),
+				},
+			},
+		}
+		if err := srv.Broadcast(closeConsumer); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestManagedConsumer_Receive_Reconnect(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	srv, err := srv.NewServer(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cp := NewClientPool()
+	mc := NewManagedConsumer(ctx, cp, ConsumerConfig{
+		ClientConfig: ClientConfig{
+			Addr: srv.Addr,
+		},
+		NewConsumerTimeout: time.Second,
+		Topic:              "test-topic",
+		Name:               "test",
+		SubMode:            SubscriptionModeShard,
+	})
+
+	subscribed := func() uint64 {
+		if err := srv.AssertReceived(ctx, api.BaseCommand_LOOKUP); err != nil {
+			t.Fatal(err)
+		}
+		select {
+		case f := <-srv.Received:
+			if got, expected := f.BaseCmd.GetType(), api.BaseCommand_SUBSCRIBE; got != expected {
+				t.Fatalf("got frame type %q; expected %q", got, expected)
+			}
+			return f.BaseCmd.GetSubscribe().GetConsumerId()
+		case <-time.After(time.Second):
+			t.Fatal("timeout waiting for SUBSCRIBE message")
+		}
+		return 0
+	}
+	send := func(consumerID, entryID uint64) {
+		message := frame.Frame{
+			BaseCmd: &api.BaseCommand{
+				Type: api.BaseCommand_MESSAGE.Enum(),
+				Message: &api.CommandMessage{
+					ConsumerId: proto.Uint64(consumerID),
+					MessageId: &api.MessageIdData{
+						EntryId:  proto.Uint64(entryID),
+						LedgerId: proto.Uint64(1),
+					},
+				},
+			},
+			Metadata: &api.MessageMetadata{
+				ProducerName: proto.String("something"),
+				SequenceId:   proto.Uint64(entryID),
+				PublishTime:  proto.Uint64(12345),
+			},
+			Payload: []byte("hola mundo"),
+		}
+		if err := srv.Broadcast(message); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err = srv.AssertReceived(ctx, api.BaseCommand_CONNECT); err != nil {
+		t.Fatal(err)
+	}
+	first := subscribed()
+
+	// Receive one message, leaving a second
+	// one buffered by the first consumer.
+	received := make(chan error, 1)
+	go func() {
+		_, err := mc.Receive(ctx)
+		received <- err
+	}()
+	if err = srv.AssertReceived(ctx, api.BaseCommand_FLOW); err != nil {
+		t.Fatal(err)
+	}
+	send(first, 0)
+	send(first, 1)
+	if err = <-received; err != nil {
+		t.Fatalf("Receive() err = %v; nil expected", err)
+	}
+	time.Sleep(100 * time.Millisecond)
+
+	// Close the first consumer. The buffered message
+	// must not be delivered once the consumer is replaced.
+	closeConsumer := frame.Frame{
+		BaseCmd: &api.BaseCommand{
+			Type: api.BaseCommand_CLOSE_CONSUMER.Enum(),
+			CloseConsumer: &api.CommandCloseConsumer{
+				ConsumerId: proto.Uint64(first),
+				RequestId:  proto.Uint64(42),
+			},
+		},
+	}
+	if err = srv.Broadcast(closeConsumer); err != nil {
+		t.Fatal(err)
+	}
+	second := subscribed()
+
+	// wait for the second consumer to be in place
+	for mc.ConsumerID(ctx) != second {
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	// the broker redelivers the unacknowledged
+	// message to the second consumer
+	got := make(chan msg.Message, 1)
+	go func() {
+		m, err := mc.Receive(ctx)
+		if err != nil {
+			t.Errorf("Receive() err = %v; nil expected", err)
+			return
+		}
+		got <- m
+	}()
+	if err = srv.AssertReceived(ctx, api.BaseCommand_FLOW); err != nil {
+		t.Fatal(err)
+	}
+	send(second, 1)
+
+	select {
+	case m := <-got:
+		if m.ConsumerID != second {
+			t.Fatalf("Receive() message consumer ID = %d; expected %d", m.ConsumerID, second)
+		}
+	case <-ctx.Done():
+		t.Fatal(ctx.Err())
+	}
+}
+
+func TestManagedConsumer_OnReconnect(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	srv, err := srv.NewServer(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cp := NewClientPool()
+	mc := NewManagedConsumer(ctx, cp, ConsumerConfig{
+		ClientConfig: ClientConfig{
+			Addr: srv.Addr,
+		},
+		NewConsumerTimeout: time.Second,
+		Topic:              "test-topic",
+		Name:               "test",
+		SubMode:            SubscriptionModeShard,
+	})
+
+	reconnected := make(chan uint64, 1)
+	mc.OnReconnect(func(c *sub.Consumer) {
+		reconnected <- c.ConsumerID
+	})
+
+	expectedFrames := []api.BaseCommand_Type{
+		api.BaseCommand_CONNECT,
+		api.BaseCommand_LOOKUP,
+		api.BaseCommand_SUBSCRIBE,
+	}
+	if err = srv.AssertReceived(ctx, expectedFrames...); err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case id := <-reconnected:
+		t.Fatalf("OnReconnect hook called for initial consumer (id = %d)", id)
+	default:
+	}
+
+	if err = srv.CloseAll(); err != nil {
+		t.Fatal(err)
+	}
+	if err = srv.AssertReceived(ctx, expectedFrames...); err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case id := <-reconnected:
+		if got := mc.ConsumerID(ctx); got != id {
+			t.Fatalf("OnReconnect hook got consumer id %d; expected %d", id, got)
+		}
+	case <-ctx.Done():
+		t.Fatal("timeout waiting for OnReconnect hook")
+	}
+}
+
+func TestManagedConsumer_CloseNeverConnected(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	srv, err := srv.NewServer(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the consumer will never be able to connect
+	srv.SetIgnoreConnects(true)
+
+	mcCtx, mcCancel := context.WithCancel(ctx)
+	cp := NewClientPool()
+	mc := NewManagedConsumer(mcCtx, cp, ConsumerConfig{
+		ClientConfig: ClientConfig{
+			Addr: srv.Addr,
+		},
+		NewConsumerTimeout: time.Second,
+		Topic:              "test-topic",
+		Name:               "test",
+		SubMode:            SubscriptionModeShard,
+	})
+
+	if err = srv.AssertReceived(ctx, api.BaseCommand_CONNECT); err != nil {
+		t.Fatal(err)
+	}
+
+	mcCancel()
+
+	select {
+	case <-mc.Done():
+	case <-time.After(time.Second):
+		t.Fatal("ManagedConsumer is NOT done; expected to be")
+	}
+
+	if _, err = mc.Receive(ctx); err != ErrManagedConsumerClosed {
+		t.Fatalf("Receive() err = %v; expected %v", err, ErrManagedConsumerClosed)
+	}
+	if err = mc.Close(ctx); err != nil {
+		t.Fatalf("Close() err = %v; expected nil", err)
+	}
+}
+
+func TestManagedConsumer_Close(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	srv, err := srv.NewServer(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cp := NewClientPool()
+	mc := NewManagedConsumer(ctx, cp, ConsumerConfig{
+		ClientConfig: ClientConfig{
+			Addr: srv.Addr,
+		},
+		NewConsumerTimeout: time.Second,
+		Topic:              "test-topic",
+		Name:               "test",
+		SubMode:            SubscriptionModeShard,
+	})
+
+	expectedFrames := []api.BaseCommand_Type{
+		api.BaseCommand_CONNECT,
+		api.BaseCommand_LOOKUP,
+		api.BaseCommand_SUBSCRIBE,
+	}
+	if err = srv.AssertReceived(ctx, expectedFrames...); err != nil {
+		t.Fatal(err)
+	}
+	// wait for the consumer to be established
+	mc.ConsumerID(ctx)
+
+	if err = mc.Close(ctx); err != nil {
+		t.Fatalf("Close() err = %v; expected nil", err)
+	}
+
+	if err = srv.AssertReceived(ctx, api.BaseCommand_CLOSE_CONSUMER); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestConsumerConfig_SetDefaults_Watermarks(t *testing.T) {
+	cfg := ConsumerConfig{QueueSize: 10}.SetDefaults()
+	if cfg.FlowHighWater != 5 || cfg.FlowLowWater != 2 {
+		t.Fatalf("watermarks = (%d, %d); expected (2, 5)", cfg.FlowLowWater, cfg.FlowHighWater)
+	}
+
+	// high watermark can't exceed the queue size
+	cfg = ConsumerConfig{QueueSize: 10, FlowHighWater: 20, FlowLowWater: 8}.SetDefaults()
+	if cfg.FlowHighWater != 5 || cfg.FlowLowWater != 2 {
+		t.Fatalf("watermarks = (%d, %d); expected (2, 5)", cfg.FlowLowWater, cfg.FlowHighWater)
+	}
+
+	cfg = ConsumerConfig{QueueSize: 10, FlowHighWater: 8, FlowLowWater: 3, FlowHighWaterBytes: 1000}.SetDefaults()
+	if cfg.FlowHighWater != 8 || cfg.FlowLowWater != 3 || cfg.FlowLowWaterBytes != 500 {
+		t.Fatalf("watermarks = (%d, %d, %d bytes); expected (3, 8, 500 bytes)", cfg.FlowLowWater, cfg.FlowHighWater, cfg.FlowLowWaterBytes)
+	}
+}
+
+func TestManagedConsumer_watermarks(t *testing.T) {
+	m := ManagedConsumer{
+		cfg: ConsumerConfig{
+			QueueSize:          100,
+			FlowHighWater:      40,
+			FlowLowWater:       20,
+			FlowHighWaterBytes: 1000,
+			FlowLowWaterBytes:  500,
+		}.SetDefaults(),
+	}
+
+	tests := []struct {
+		avgSize   float64
+		low, high uint32
+	}{
+		{avgSize: 0, low: 20, high: 40},   // nothing received yet
+		{avgSize: 10, low: 20, high: 40},  // bytes allow more than count
+		{avgSize: 100, low: 5, high: 10},  // bytes are the limit
+		{avgSize: 400, low: 1, high: 2},   // low rounds down
+		{avgSize: 10000, low: 0, high: 1}, // always request at least one
+	}
+	for _, tt := range tests {
+		low, high := m.watermarks(tt.avgSize)
+		if low != tt.low || high != tt.high {
+			t.Errorf("watermarks(%v) = (%d, %d); expected (%d, %d)", tt.avgSize, low, high, tt.low, tt.high)
+		}
+	}
+}
+
+func TestManagedConsumer_Decode(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	srv, err := srv.NewServer(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv.SetSchema("test-topic", schema.NewJSON(`{"type":"record","name":"old"}`).Info().Proto(), []byte{1})
+
+	cp := NewClientPool()
+	mc := NewManagedConsumer(ctx, cp, ConsumerConfig{
+		ClientConfig: ClientConfig{
+			Addr: srv.Addr,
+		},
+		NewConsumerTimeout: time.Second,
+		Topic:              "test-topic",
+		Name:               "test",
+		SubMode:            SubscriptionModeShard,
+	})
+
+	type reading struct {
+		Value float64 `json:"value"`
+	}
+
+	m := msg.Message{
+		Meta: &api.MessageMetadata{
+			SchemaVersion: []byte{1},
+		},
+		Payload: []byte(`{"value":1.5}`),
+	}
+	for i := 0; i < 2; i++ {
+		var r reading
+		if err = mc.Decode(ctx, m, &r); err != nil {
+			t.Fatalf("Decode() err = %v; expected nil", err)
+		}
+		if got, expected := r.Value, 1.5; got != expected {
+			t.Fatalf("Decode() value = %v; expected %v", got, expected)
+		}
+	}
+
+	var r reading
+	m.Meta.SchemaVersion = []byte{2}
+	if err = mc.Decode(ctx, m, &r); err == nil {
+		t.Fatal("Decode() err = nil; expected error for unknown schema version")
+	}
+
+	m.Meta.SchemaVersion = nil
+	if err = mc.Decode(ctx, m, &r); err != ErrNoSchema {
+		t.Fatalf("Decode() err = %v; expected %v", err, ErrNoSchema)
+	}
+}
+
+func TestManagedConsumer_MemoryLimit(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	srv, err := srv.NewServer(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cp := NewClientPoolWithConfig(ClientPoolConfig{MemoryLimit: 10})
+	// eg the payloads of pending sends
+	cp.Memory().ForceReserve(10)
+
+	mc := NewManagedConsumer(ctx, cp, ConsumerConfig{
+		ClientConfig: ClientConfig{
+			Addr: srv.Addr,
+		},
+		NewConsumerTimeout: time.Second,
+		Topic:              "test-topic",
+		Name:               "test",
+		SubMode:            SubscriptionModeShard,
+	})
+
+	if err = srv.AssertReceived(ctx, api.BaseCommand_CONNECT, api.BaseCommand_LOOKUP); err != nil {
+		t.Fatal(err)
+	}
+	var consumerID uint64
+	select {
+	case f := <-srv.Received:
+		if got, expected := f.BaseCmd.GetType(), api.BaseCommand_SUBSCRIBE; got != expected {
+			t.Fatalf("got frame type %q; expected %q", got, expected)
+		}
+		consumerID = f.BaseCmd.GetSubscribe().GetConsumerId()
+	case <-ctx.Done():
+		t.Fatal("timeout waiting for SUBSCRIBE message")
+	}
+
+	received := make(chan error, 1)
+	go func() {
+		_, err := mc.Receive(ctx)
+		received <- err
+	}()
+
+	// no message is requested while the limit is exceeded
+	select {
+	case f := <-srv.Received:
+		t.Fatalf("got frame of type %q over the memory limit; expected none", f.BaseCmd.GetType())
+	case <-time.After(200 * time.Millisecond):
+	}
+	cp.Memory().Release(10)
+	if err = srv.AssertReceived(ctx, api.BaseCommand_FLOW); err != nil {
+		t.Fatal(err)
+	}
+
+	for i := 0; i < 2; i++ {
+		message := frame.Frame{
+			BaseCmd: &api.BaseCommand{
+				Type: api.BaseCommand_MESSAGE.Enum(),
+				Message: &api.CommandMessage{
+					ConsumerId: proto.Uint64(consumerID),
+					MessageId: &api.MessageIdData{
+						EntryId:  proto.Uint64(uint64(i)),
+						LedgerId: proto.Uint64(1),
+					},
+				},
+			},
+			Metadata: &api.MessageMetadata{
+				ProducerName: proto.String("something"),
+				SequenceId:   proto.Uint64(uint64(i)),
+				PublishTime:  proto.Uint64(12345),
+			},
+			Payload: []byte("hola mundo"),
+		}
+		if err = srv.Broadcast(message); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err = <-received; err != nil {
+		t.Fatalf("Receive() err = %v; nil expected", err)
+	}
+
+	// the buffered message counts against the limit
+	// until it is taken from the queue
+	time.Sleep(100 * time.Millisecond)
+	if got, expected := cp.Memory().Used(), int64(10); got != expected {
+		t.Fatalf("Used() = %d with a buffered message; expected %d", got, expected)
+	}
+	if _, err = mc.Receive(ctx); err != nil {
+		t.Fatalf("Receive() err = %v; nil expected", err)
+	}
+	if got := cp.Memory().Used(); got != 0 {
+		t.Fatalf("Used() = %d once received; expected 0", got)
+	}
+}
+
+// testReceiveAfterDrop checks that Receive replaces the permits used
+// by the messages that push makes the Consumer drop, and then returns
+// the next message, pushed with the metadata next.
+func testReceiveAfterDrop(t *testing.T, next *api.MessageMetadata, push func(s *srv.Server, c *sub.Consumer, consumerID uint64) error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	s, err := srv.NewServer(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	mc := NewManagedConsumer(ctx, NewClientPool(), ConsumerConfig{
+		ClientConfig: ClientConfig{
+			Addr: s.Addr,
+		},
+		NewConsumerTimeout: time.Second,
+		Topic:              "test-topic",
+		Name:               "test",
+		SubMode:            SubscriptionModeShard,
+	})
+	consumerID := receiveFrames(ctx, t, s, api.BaseCommand_SUBSCRIBE, 1)[0].BaseCmd.GetSubscribe().GetConsumerId()
+
+	type result struct {
+		msg msg.Message
+		err error
+	}
+	received := make(chan result, 1)
+	go func() {
+		m, err := mc.Receive(ctx)
+		received <- result{m, err}
+	}()
+
+	receiveFrames(ctx, t, s, api.BaseCommand_FLOW, 1)
+	if err = push(s, mc.Consumer(ctx), consumerID); err != nil {
+		t.Fatal(err)
+	}
+	// the dropped messages' permits are replaced
+	receiveFrames(ctx, t, s, api.BaseCommand_FLOW, 1)
+
+	next.ProducerName = proto.String("something")
+	next.SequenceId = proto.Uint64(2)
+	next.PublishTime = proto.Uint64(12345)
+
+	err = s.Broadcast(frame.Frame{
+		BaseCmd: &api.BaseCommand{
+			Type: api.BaseCommand_MESSAGE.Enum(),
+			Message: &api.CommandMessage{
+				ConsumerId: proto.Uint64(consumerID),
+				MessageId: &api.MessageIdData{
+					LedgerId: proto.Uint64(1),
+					EntryId:  proto.Uint64(2),
+				},
+			},
+		},
+		Metadata: next,
+		Payload:  []byte("hola mundo"),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r := <-received
+	if r.err != nil {
+		t.Fatalf("Receive() err = %v; nil expected", r.err)
+	}
+	if got, expected := string(r.msg.Payload), "hola mundo"; got != expected {
+		t.Fatalf("Receive() message payload = %q; expected %q", got, expected)
+	}
+}
+
+func TestManagedConsumer_Receive_Marker(t *testing.T) {
+	testReceiveAfterDrop(t, new(api.MessageMetadata), func(s *srv.Server, c *sub.Consumer, consumerID uint64) error {
+		return s.Broadcast(frame.Frame{
+			BaseCmd: &api.BaseCommand{
+				Type: api.BaseCommand_MESSAGE.Enum(),
+				Message: &api.CommandMessage{
+					ConsumerId: proto.Uint64(consumerID),
+					MessageId: &api.MessageIdData{
+						LedgerId: proto.Uint64(1),
+						EntryId:  proto.Uint64(1),
+					},
+				},
+			},
+			Metadata: &api.MessageMetadata{
+				ProducerName: proto.String("something"),
+				SequenceId:   proto.Uint64(1),
+				PublishTime:  proto.Uint64(12345),
+				MarkerType:   proto.Int32(int32(msg.MarkerReplicatedSubscriptionSnapshot)),
+			},
+		})
+	})
+}
+
+func TestManagedConsumer_Receive_AbortedTxn(t *testing.T) {
+	committed := msg.TxnID{MostBits: 1, LeastBits: 1}
+	aborted := msg.TxnID{MostBits: 1, LeastBits: 2}
+	txnMetadata := func(marker msg.MarkerType, txnID msg.TxnID, seq uint64) *api.MessageMetadata {
+		meta := &api.MessageMetadata{
+			ProducerName:   proto.String("something"),
+			SequenceId:     proto.Uint64(seq),
+			PublishTime:    proto.Uint64(12345),
+			TxnidLeastBits: proto.Uint64(txnID.LeastBits),
+			TxnidMostBits:  proto.Uint64(txnID.MostBits),
+		}
+		if marker != msg.MarkerNone {
+			meta.MarkerType = proto.Int32(int32(marker))
+		}
+		return meta
+	}
+
+	next := txnMetadata(msg.MarkerNone, committed, 0)
+	testReceiveAfterDrop(t, next, func(s *srv.Server, c *sub.Consumer, consumerID uint64) error {
+		// the abort marker, then a message of the aborted transaction
+		for i, meta := range []*api.MessageMetadata{
+			txnMetadata(msg.MarkerTxnAbort, aborted, 0),
+			txnMetadata(msg.MarkerNone, aborted, 1),
+		} {
+			err := s.Broadcast(frame.Frame{
+				BaseCmd: &api.BaseCommand{
+					Type: api.BaseCommand_MESSAGE.Enum(),
+					Message: &api.CommandMessage{
+						ConsumerId: proto.Uint64(consumerID),
+						MessageId: &api.MessageIdData{
+							LedgerId: proto.Uint64(1),
+							EntryId:  proto.Uint64(uint64(i)),
+						},
+					},
+				},
+				Metadata: meta,
+				Payload:  []byte("aborted"),
+			})
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func TestManagedConsumer_Receive_Corrupt(t *testing.T) {
+	testReceiveAfterDrop(t, new(api.MessageMetadata), func(s *srv.Server, c *sub.Consumer, consumerID uint64) error {
+		// as done by the Client for frames whose checksum doesn't match
+		err := c.HandleCorruptMessage(frame.Frame{
+			BaseCmd: &api.BaseCommand{
+				Type: api.BaseCommand_MESSAGE.Enum(),
+				Message: &api.CommandMessage{
+					ConsumerId: proto.Uint64(consumerID),
+					MessageId: &api.MessageIdData{
+						LedgerId: proto.Uint64(1),
+						EntryId:  proto.Uint64(1),
+					},
+				},
+			},
+			Metadata: new(api.MessageMetadata),
+		}, &frame.ChecksumError{Computed: 1, Expected: 2})
+		if err == nil {
+			return errors.New("HandleCorruptMessage() err = nil; expected the dropped message")
+		}
+		return nil
+	})
+}
@@ -15,14 +15,40 @@ package manage
 
 import (
 	"context"
+	"errors"
+	"strconv"
 	"sync"
+	"sync/atomic"
 	"time"
 
+	"github.com/pepper-iot/pulsar-client-go/core/encryption"
 	"github.com/pepper-iot/pulsar-client-go/core/pub"
+	"github.com/pepper-iot/pulsar-client-go/core/schema"
 	"github.com/pepper-iot/pulsar-client-go/pkg/api"
+	"github.com/pepper-iot/pulsar-client-go/pkg/log"
 	"github.com/pepper-iot/pulsar-client-go/utils"
 )
 
+// ErrManagedProducerClosed is returned when using a ManagedProducer
+// that has been closed, or whose parent context is done.
+var ErrManagedProducerClosed = errors.New("managed producer is closed")
+
+// ErrPendingQueueFull is returned by ManagedProducer.Send when the
+// Producer is unavailable and the pending-send queue is full.
+var ErrPendingQueueFull = errors.New("managed producer pending-send queue is full")
+
+// ErrNoSchema is returned by ManagedProducer.SendValue and
+// ManagedConsumer.Decode when no Schema is configured.
+var ErrNoSchema = errors.New("no schema configured")
+
+// ErrMemoryLimitExceeded is returned by ManagedProducer.Send when the
+// ClientPool's MemoryLimit is exceeded, if MemoryLimitFailFast is set.
+var ErrMemoryLimitExceeded = errors.New("client memory limit exceeded")
+
+// ErrPendingTimeout is returned by ManagedProducer.Send when a queued
+// send waited longer than MaxPendingWait for the Producer.
+var ErrPendingTimeout = errors.New("timed out waiting for producer")
+
 // ProducerConfig is used to configure a ManagedProducer.
 type ProducerConfig struct {
 	ClientConfig
@@ -33,6 +59,26 @@ type ProducerConfig struct {
 	NewProducerTimeout    time.Duration // maximum duration to create Producer, including topic lookup
 	InitialReconnectDelay time.Duration // how long to initially wait to reconnect Producer
 	MaxReconnectDelay     time.Duration // maximum time to wait to attempt to reconnect Producer
+
+	PendingQueueSize    int              // maximum number of sends queued while the Producer is unavailable. Defaults to 1000
+	MaxPendingWait      time.Duration    // maximum time a queued send waits for the Producer. Zero waits until the send's context is done
+	MemoryLimitFailFast bool             // if true, sends fail with ErrMemoryLimitExceeded instead of waiting while the ClientPool's MemoryLimit is exceeded
+	StatsInterval       time.Duration    // if positive, a summary of the sends is logged at this interval
+	SendRetry           *SendRetryPolicy // if set, sends failing with a temporary ServerError are retried
+
+	Failover *Failover     // if set, the active cluster's ClientConfig is used instead of ClientConfig
+	Schema   schema.Schema // if set, registered with the broker and used by SendValue
+
+	TraceHook    pub.TraceHook             // if set, added to every Producer
+	TraceHooks   []pub.TraceHook           // added to every Producer, in order, after TraceHook
+	Interceptors []pub.ProducerInterceptor // added to every Producer, in order, after the trace hooks
+	Batching     *pub.BatchConfig          // if set, concurrent sends are batched. Its Clock defaults to that of the ClientConfig
+	Encryptor    *encryption.Encryptor     // if set, payloads are encrypted for the consumers holding its keys
+	RateLimiter  *pub.RateLimiter          // if set, limits the rate of sends. May be shared by ManagedProducers to limit their combined rate
+
+	Router                   MessageRouter // selects the partitions of the messages of PartitionedProducers. Defaults to a RoundRobinRouter
+	Hashing                  HashingScheme // hash of partition keys of the default Router. Defaults to JavaStringHash
+	PartitionsUpdateInterval time.Duration // if positive, PartitionedProducers look up the number of partitions at this interval, to send to new ones
 }
 
 // setDefaults returns a modified config with appropriate zero values set to defaults.
@@ -46,23 +92,45 @@ func (m ProducerConfig) setDefaults() ProducerConfig {
 	if m.MaxReconnectDelay <= 0 {
 		m.MaxReconnectDelay = 5 * time.Minute
 	}
+	if m.PendingQueueSize <= 0 {
+		m.PendingQueueSize = 1000
+	}
+	if m.Router == nil {
+		m.Router = &RoundRobinRouter{Hashing: m.Hashing}
+	}
+	if m.SendRetry != nil {
+		retry := m.SendRetry.setDefaults()
+		m.SendRetry = &retry
+	}
 
 	return m
 }
 
 // NewManagedProducer returns an initialized ManagedProducer. It will create and re-create
 // a Producer for the given discovery address and topic on a background goroutine.
-func NewManagedProducer(cp *ClientPool, cfg ProducerConfig) *ManagedProducer {
+// When ctx is done, the background goroutine stops and the Producer is closed,
+// whether or not it was ever successfully created.
+func NewManagedProducer(ctx context.Context, cp *ClientPool, cfg ProducerConfig) *ManagedProducer {
 	cfg = cfg.setDefaults()
 
+	ctx, cancel := context.WithCancel(ctx)
+
 	m := ManagedProducer{
 		ClientPool: cp,
 		Cfg:        cfg,
 		AsyncErrs:  utils.AsyncErrors(cfg.Errs),
 		Waitc:      make(chan struct{}),
+		ctx:        ctx,
+		cancel:     cancel,
+		donec:      make(chan struct{}),
+		pending:    make(chan *pendingSend, cfg.PendingQueueSize),
 	}
 
 	go m.manage()
+	go m.sendPending()
+	if cfg.StatsInterval > 0 {
+		go m.logStats()
+	}
 
 	return &m
 }
@@ -73,14 +141,277 @@ type ManagedProducer struct {
 	Cfg        ProducerConfig
 	AsyncErrs  utils.AsyncErrors
 
+	droppedErrs uint64 // asynchronous errors dropped because AsyncErrs was full; accessed atomically
+
 	Mu       sync.RWMutex  // protects following
 	Producer *pub.Producer // either producer is nil and wait isn't or vice versa
 	Waitc    chan struct{} // if producer is nil, this will unblock when it's been re-set
+
+	ctx      context.Context    // lifecycle of the manage goroutine
+	cancel   context.CancelFunc // stops the manage goroutine
+	donec    chan struct{}      // closed when the manage goroutine has returned
+	closeErr error              // result of closing the Producer, valid once donec is closed
+
+	hmu            sync.Mutex // protects following
+	reconnectHooks []func(*pub.Producer)
+
+	migration migration // cluster the topic was migrated to, if any
+
+	pending chan *pendingSend // sends waiting for a Producer, in order
+	queued  int32             // number of sends queued or being retried; accessed atomically
+	qmu     sync.Mutex        // serializes queuing sends with the final draining of pending
+
+	smu     sync.Mutex    // protects following
+	sending int           // number of sends in progress, including queued ones whose caller gave up
+	idle    chan struct{} // if non-nil, closed once sending drops to zero
+
+	reconnects int32        // number of times the Producer was lost; accessed atomically
+	broker     atomic.Value // address of the broker of the latest Producer, for labels
+
+	sendLatency utils.Histogram // round-trips of successful sends
+	sent        rateCounter     // successful sends
+	sentBytes   uint64          // payload bytes of successful sends; accessed atomically
+	sendErrors  uint64          // failed sends; accessed atomically
+}
+
+// pendingSend is a send queued while the Producer was unavailable.
+type pendingSend struct {
+	ctx      context.Context
+	msg      pub.Message
+	deadline <-chan time.Time // fires after MaxPendingWait, if set
+	result   chan sendResult  // buffered, receives exactly one result
+}
+
+type sendResult struct {
+	receipt *api.CommandSendReceipt
+	err     error
+}
+
+// OnReconnect registers fn to be called each time the underlying
+// Producer is re-established. fn receives the new Producer, which
+// carries the new ProducerID and ProducerName. Hooks are called in
+// registration order from the manage goroutine once the new Producer
+// is available, so they may use the ManagedProducer but should not
+// block for long.
+func (m *ManagedProducer) OnReconnect(fn func(*pub.Producer)) {
+	m.hmu.Lock()
+	m.reconnectHooks = append(m.reconnectHooks, fn)
+	m.hmu.Unlock()
 }
 
-// Send attempts to use the Producer's Send method if available. If not available,
-// an error is returned.
+// runReconnectHooks calls all hooks registered with OnReconnect.
+func (m *ManagedProducer) runReconnectHooks(p *pub.Producer) {
+	m.hmu.Lock()
+	hooks := m.reconnectHooks
+	m.hmu.Unlock()
+
+	for _, fn := range hooks {
+		fn(p)
+	}
+}
+
+// Send sends the payload using the current Producer. If the Producer
+// is unavailable, for example while it is reconnecting, the send is
+// queued and retried in order once a new Producer is established.
+// At most PendingQueueSize sends are queued; beyond that
+// ErrPendingQueueFull is returned immediately. A queued send
+// fails with ErrPendingTimeout after waiting MaxPendingWait. The
+// payload counts against the ClientPool's MemoryLimit, if any, until
+// the send completes.
+//
+// A send that fails because its Producer was closed before the receipt
+// arrived is retried as well, so the message may be persisted twice.
 func (m *ManagedProducer) Send(ctx context.Context, payload []byte) (*api.CommandSendReceipt, error) {
+	return m.SendMessage(ctx, pub.Message{Payload: payload})
+}
+
+// SendMessage is like Send, but sends a message with the metadata
+// set on it, such as its partition key or properties.
+func (m *ManagedProducer) SendMessage(ctx context.Context, message pub.Message) (*api.CommandSendReceipt, error) {
+	select {
+	case <-m.ctx.Done():
+		return nil, ErrManagedProducerClosed
+	default:
+	}
+	m.addSending(1)
+	defer m.addSending(-1)
+
+	// the payload counts against the memory
+	// limit until the send completes
+	size := int64(len(message.Payload))
+	memory := m.ClientPool.memory
+	if m.Cfg.MemoryLimitFailFast {
+		if !memory.TryReserve(size) {
+			return nil, ErrMemoryLimitExceeded
+		}
+	} else if err := memory.Reserve(ctx, size); err != nil {
+		return nil, err
+	}
+	defer memory.Release(size)
+
+	m.Mu.RLock()
+	producer := m.Producer
+	m.Mu.RUnlock()
+
+	// only bypass the queue if it's empty,
+	// so that sends are kept in order
+	if producer != nil && atomic.LoadInt32(&m.queued) == 0 {
+		receipt, err := m.send(ctx, producer, message)
+		if err == nil || !isRetriable(ctx, producer) {
+			return receipt, err
+		}
+	}
+
+	return m.enqueue(ctx, message)
+}
+
+// SendValue encodes v using the configured Schema, then sends it.
+func (m *ManagedProducer) SendValue(ctx context.Context, v interface{}) (*api.CommandSendReceipt, error) {
+	if m.Cfg.Schema == nil {
+		return nil, ErrNoSchema
+	}
+	payload, err := m.Cfg.Schema.Encode(v)
+	if err != nil {
+		return nil, err
+	}
+	return m.Send(ctx, payload)
+}
+
+// send sends message with the Producer, retrying it according to
+// the SendRetry policy, and records the round-trip if successful.
+func (m *ManagedProducer) send(ctx context.Context, producer *pub.Producer, message pub.Message) (*api.CommandSendReceipt, error) {
+	retrier := newSendRetrier(m.Cfg.SendRetry, message)
+	for {
+		start := time.Now()
+		receipt, err := producer.SendMessage(ctx, message)
+		if err == nil {
+			m.sendLatency.Observe(time.Since(start))
+			m.sent.inc()
+			atomic.AddUint64(&m.sentBytes, uint64(len(message.Payload)))
+			return receipt, nil
+		}
+		atomic.AddUint64(&m.sendErrors, 1)
+
+		delay, ok := retrier.next(err)
+		if !ok || !sleep(m.Cfg.clock(), delay, ctx.Done()) {
+			return receipt, err
+		}
+	}
+}
+
+// SendLatency returns the distribution of the round-trips of successful
+// sends, from the SEND command to its receipt. Time spent waiting in the
+// pending queue isn't included.
+func (m *ManagedProducer) SendLatency() utils.HistogramSnapshot {
+	return m.sendLatency.Snapshot()
+}
+
+// enqueue adds a send to the pending queue
+// and waits for its result.
+func (m *ManagedProducer) enqueue(ctx context.Context, message pub.Message) (*api.CommandSendReceipt, error) {
+	req := pendingSend{
+		ctx:    ctx,
+		msg:    message,
+		result: make(chan sendResult, 1),
+	}
+	if m.Cfg.MaxPendingWait > 0 {
+		timer := m.Cfg.clock().NewTimer(m.Cfg.MaxPendingWait)
+		defer timer.Stop()
+		req.deadline = timer.C()
+	}
+
+	if err := m.push(&req); err != nil {
+		return nil, err
+	}
+
+	select {
+	case r := <-req.result:
+		return r.receipt, r.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case <-m.ctx.Done():
+		// sendPending fails it, unless it completed meanwhile
+		select {
+		case r := <-req.result:
+			return r.receipt, r.err
+		default:
+			return nil, ErrManagedProducerClosed
+		}
+	}
+}
+
+// push adds req to the pending queue, unless the ManagedProducer is
+// closed, in which case sendPending may no longer read the queue.
+func (m *ManagedProducer) push(req *pendingSend) error {
+	m.qmu.Lock()
+	defer m.qmu.Unlock()
+
+	if m.ctx.Err() != nil {
+		return ErrManagedProducerClosed
+	}
+
+	atomic.AddInt32(&m.queued, 1)
+	select {
+	case m.pending <- req:
+		// counted until sendPending is done
+		// with it, even if the caller gives up
+		m.addSending(1)
+		return nil
+	default:
+		atomic.AddInt32(&m.queued, -1)
+		return ErrPendingQueueFull
+	}
+}
+
+// sendPending sends queued messages one at a time, in the order
+// they were queued, until the ManagedProducer is closed. Pending
+// sends are failed with ErrManagedProducerClosed once it is.
+func (m *ManagedProducer) sendPending() {
+	setLabels("role", "producer", "topic", m.Cfg.Topic)
+
+	for {
+		select {
+		case req := <-m.pending:
+			receipt, err := m.retrySend(req)
+			req.result <- sendResult{receipt: receipt, err: err}
+			atomic.AddInt32(&m.queued, -1)
+			m.addSending(-1)
+
+		case <-m.ctx.Done():
+			// once push is done, no sends are
+			// queued anymore, so all get drained
+			m.qmu.Lock()
+			defer m.qmu.Unlock()
+			for {
+				select {
+				case req := <-m.pending:
+					req.result <- sendResult{err: ErrManagedProducerClosed}
+					atomic.AddInt32(&m.queued, -1)
+					m.addSending(-1)
+				default:
+					return
+				}
+			}
+		}
+	}
+}
+
+// addSending adjusts the number of sends in progress, waking
+// up any callers of Flush once there are none left.
+func (m *ManagedProducer) addSending(delta int) {
+	m.smu.Lock()
+	m.sending += delta
+	if m.sending == 0 && m.idle != nil {
+		close(m.idle)
+		m.idle = nil
+	}
+	m.smu.Unlock()
+}
+
+// retrySend waits for a Producer and sends the queued message,
+// retrying with the next Producer if the current one is closed
+// before the send completes.
+func (m *ManagedProducer) retrySend(req *pendingSend) (*api.CommandSendReceipt, error) {
 	for {
 		m.Mu.RLock()
 		producer := m.Producer
@@ -88,7 +419,14 @@ func (m *ManagedProducer) Send(ctx context.Context, payload []byte) (*api.Comman
 		m.Mu.RUnlock()
 
 		if producer != nil {
-			return producer.Send(ctx, payload)
+			receipt, err := m.send(req.ctx, producer, req.msg)
+			if err == nil || !isRetriable(req.ctx, producer) {
+				return receipt, err
+			}
+			// don't wait for manage() to notice that the
+			// Producer is gone before waiting for a new one
+			m.unsetIf(producer)
+			continue
 		}
 
 		select {
@@ -96,12 +434,33 @@ func (m *ManagedProducer) Send(ctx context.Context, payload []byte) (*api.Comman
 			// a new producer was established.
 			// Re-enter read-lock to obtain it.
 			continue
-		case <-ctx.Done():
-			return nil, ctx.Err()
+		case <-req.deadline:
+			return nil, ErrPendingTimeout
+		case <-req.ctx.Done():
+			return nil, req.ctx.Err()
+		case <-m.ctx.Done():
+			return nil, ErrManagedProducerClosed
 		}
 	}
 }
 
+// isRetriable returns true if a failed send should be retried
+// with a new Producer: the send's context isn't done, and its
+// Producer or the Producer's connection was closed.
+func isRetriable(ctx context.Context, p *pub.Producer) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+	select {
+	case <-p.Closed():
+		return true
+	case <-p.ConnClosed():
+		return true
+	default:
+		return false
+	}
+}
+
 // Set unblocks the "wait" channel (if not nil),
 // and sets the producer under lock.
 func (m *ManagedProducer) Set(p *pub.Producer) {
@@ -133,9 +492,23 @@ func (m *ManagedProducer) Unset() {
 	m.Mu.Unlock()
 }
 
+// unsetIf unsets the producer if it is still p.
+func (m *ManagedProducer) unsetIf(p *pub.Producer) {
+	m.Mu.Lock()
+
+	if m.Producer == p {
+		if m.Waitc == nil {
+			m.Waitc = make(chan struct{})
+		}
+		m.Producer = nil
+	}
+
+	m.Mu.Unlock()
+}
+
 // NewProducer attempts to create a Producer.
 func (m *ManagedProducer) NewProducer(ctx context.Context) (*pub.Producer, error) {
-	mc, err := m.ClientPool.ForTopic(ctx, m.Cfg.ClientConfig, m.Cfg.Topic)
+	mc, err := m.ClientPool.ForTopic(ctx, m.clientConfig(), m.Cfg.Topic)
 	if err != nil {
 		return nil, err
 	}
@@ -144,69 +517,296 @@ func (m *ManagedProducer) NewProducer(ctx context.Context) (*pub.Producer, error
 	if err != nil {
 		return nil, err
 	}
+	m.broker.Store(brokerAddr(mc.cfg))
 
 	// Create the topic producer. A blank producer name will
 	// cause Pulsar to generate a unique name.
-	return client.NewProducer(ctx, m.Cfg.Topic, m.Cfg.Name)
+	var p *pub.Producer
+	if m.Cfg.Schema != nil {
+		p, err = client.NewProducerWithSchema(ctx, m.Cfg.Topic, m.Cfg.Name, m.Cfg.Schema)
+	} else {
+		p, err = client.NewProducer(ctx, m.Cfg.Topic, m.Cfg.Name)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if m.Cfg.TraceHook != nil {
+		p.AddTraceHook(m.Cfg.TraceHook)
+	}
+	for _, th := range m.Cfg.TraceHooks {
+		p.AddTraceHook(th)
+	}
+	for _, i := range m.Cfg.Interceptors {
+		p.AddInterceptor(i)
+	}
+	if m.Cfg.Batching != nil {
+		batching := *m.Cfg.Batching
+		if batching.Clock == nil {
+			batching.Clock = m.clientConfig().clock()
+		}
+		p.EnableBatching(batching)
+	}
+	if m.Cfg.Encryptor != nil {
+		p.EnableEncryption(m.Cfg.Encryptor)
+	}
+	if m.Cfg.RateLimiter != nil {
+		p.SetRateLimiter(m.Cfg.RateLimiter)
+	}
+	return p, nil
+}
+
+// clientConfig returns the ClientConfig used to create Producers.
+func (m *ManagedProducer) clientConfig() ClientConfig {
+	return m.migration.apply(m.activeConfig())
+}
+
+// activeConfig returns the ClientConfig of the active cluster,
+// regardless of any migration of the topic.
+func (m *ManagedProducer) activeConfig() ClientConfig {
+	if m.Cfg.Failover != nil {
+		return m.Cfg.Failover.config()
+	}
+	return m.Cfg.ClientConfig
+}
+
+// switched returns a channel that unblocks when the Producer must
+// be recreated on another cluster. It never unblocks without Failover.
+func (m *ManagedProducer) switched() <-chan struct{} {
+	if m.Cfg.Failover != nil {
+		return m.Cfg.Failover.Switched()
+	}
+	return nil
 }
 
 // Reconnect blocks while a new Producer is created.
+// Nil will be returned if and only if the ManagedProducer's
+// context is done.
 func (m *ManagedProducer) Reconnect(initial bool) *pub.Producer {
-	retryDelay := m.Cfg.InitialReconnectDelay
+	backoff := utils.Backoff{
+		Initial: m.Cfg.InitialReconnectDelay,
+		Max:     m.Cfg.MaxReconnectDelay,
+	}
 
 	for attempt := 1; ; attempt++ {
 		if initial {
 			initial = false
-		} else {
-			<-time.After(retryDelay)
-			if retryDelay < m.Cfg.MaxReconnectDelay {
-				// double retry delay until we reach the max
-				if retryDelay *= 2; retryDelay > m.Cfg.MaxReconnectDelay {
-					retryDelay = m.Cfg.MaxReconnectDelay
-				}
+			select {
+			case <-m.ctx.Done():
+				return nil
+			default:
 			}
+		} else if !sleep(m.Cfg.clock(), backoff.Next(), m.ctx.Done()) {
+			return nil
 		}
 
-		ctx, cancel := context.WithTimeout(context.Background(), m.Cfg.NewProducerTimeout)
+		ctx, cancel := context.WithTimeout(m.ctx, m.Cfg.NewProducerTimeout)
 		newProducer, err := m.NewProducer(ctx)
 		cancel()
 		if err != nil {
-			m.AsyncErrs.Send(err)
+			m.event(EventError, err)
+			m.sendErr(err)
 			continue
 		}
+		m.event(EventConnected, nil)
 
 		return newProducer
 	}
 }
 
+// labels returns the labels of the ManagedProducer's errors.
+func (m *ManagedProducer) labels() map[string]string {
+	labels := map[string]string{
+		"topic": m.Cfg.Topic,
+	}
+	m.Mu.RLock()
+	if m.Producer != nil {
+		labels["producer_id"] = strconv.FormatUint(m.Producer.ProducerID, 10)
+		labels["producer_name"] = m.Producer.ProducerName
+	}
+	m.Mu.RUnlock()
+	if broker, _ := m.broker.Load().(string); broker != "" {
+		labels["broker"] = broker
+	}
+	return labels
+}
+
+// logger returns a Logger labeling messages
+// with the ManagedProducer's labels.
+func (m *ManagedProducer) logger() *log.Logger {
+	return labeledLogger(m.labels())
+}
+
+// sendErr reports err to the async errors channel and the
+// ErrorListener, labeled with the ManagedProducer's labels.
+func (m *ManagedProducer) sendErr(err error) {
+	reportErr(m.AsyncErrs, &m.droppedErrs, m.Cfg.ErrorListener, err, ErrorScope{
+		Entity: ScopeProducer,
+		Labels: m.labels(),
+	})
+}
+
+// event records a lifecycle event of the ManagedProducer.
+func (m *ManagedProducer) event(typ EventType, err error) {
+	e := Event{
+		Type:  typ,
+		Topic: m.Cfg.Topic,
+		Name:  m.Cfg.Name,
+	}
+	if err != nil {
+		e.Err = err.Error()
+	}
+	m.ClientPool.events.add(e)
+}
+
 // managed Monitors the Producer for conditions
 // that require it to be recreated.
 func (m *ManagedProducer) manage() {
+	setLabels("role", "producer", "topic", m.Cfg.Topic)
+
+	m.ClientPool.registry.add(m)
+	defer m.ClientPool.registry.remove(m)
+	defer close(m.donec)
 	defer m.Unset()
 
+	switched := m.switched()
 	producer := m.Reconnect(true)
+	if producer == nil {
+		// producer == nil only if the
+		// context is done
+		return
+	}
 	m.Set(producer)
 
 	for {
 		select {
 		case <-producer.Closed():
+			if url, tlsURL, ok := producer.MigratedTo(); ok {
+				m.migration.set(m.activeConfig(), url, tlsURL)
+			}
 		case <-producer.ConnClosed():
+		case <-switched:
+			// the active cluster changed
+			m.Unset()
+			ctx, cancel := context.WithTimeout(m.ctx, m.Cfg.NewProducerTimeout)
+			if err := producer.Close(ctx); err != nil {
+				m.sendErr(err)
+			}
+			cancel()
+		case <-m.ctx.Done():
+			m.Unset()
+			ctx, cancel := context.WithTimeout(context.Background(), m.Cfg.NewProducerTimeout)
+			m.closeErr = producer.Close(ctx)
+			cancel()
+			m.event(EventClosed, m.closeErr)
+			return
 		}
 
 		m.Unset()
-		producer = m.Reconnect(false)
+		m.event(EventDisconnected, nil)
+		atomic.AddInt32(&m.reconnects, 1)
+		switched = m.switched()
+		if producer = m.Reconnect(false); producer == nil {
+			// producer == nil only if the
+			// context is done
+			return
+		}
 		m.Set(producer)
+		m.runReconnectHooks(producer)
 	}
 }
 
+// inspect implements inspector.
+func (m *ManagedProducer) inspect() EntityInfo {
+	m.Mu.RLock()
+	producer := m.Producer
+	m.Mu.RUnlock()
+
+	info := EntityInfo{
+		Topic:      m.Cfg.Topic,
+		Name:       m.Cfg.Name,
+		Producer:   true,
+		Reconnects: int(atomic.LoadInt32(&m.reconnects)),
+	}
+	switch {
+	case producer != nil:
+		info.State = EntityConnected
+		info.Name = producer.ProducerName
+	case info.Reconnects == 0:
+		info.State = EntityConnecting
+	default:
+		info.State = EntityReconnecting
+	}
+	return info
+}
+
 // Monitor a scoped deferrable lock
 func (m *ManagedProducer) Monitor() func() {
 	m.Mu.Lock()
 	return m.Mu.Unlock
 }
 
-// Close producer
+// Done returns a channel that unblocks when the ManagedProducer
+// has stopped managing its Producer, either because Close was
+// called or because its parent context is done.
+func (m *ManagedProducer) Done() <-chan struct{} {
+	return m.donec
+}
+
+// Flush waits until the sends in progress when it is called, and
+// those started meanwhile, have completed, including queued sends
+// and those being retried, or until ctx is done. Any batch of the
+// Producer is then sent and waited for as well.
+func (m *ManagedProducer) Flush(ctx context.Context) error {
+	m.smu.Lock()
+	if m.sending > 0 {
+		if m.idle == nil {
+			m.idle = make(chan struct{})
+		}
+		idle := m.idle
+		m.smu.Unlock()
+
+		select {
+		case <-idle:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	} else {
+		m.smu.Unlock()
+	}
+
+	m.Mu.RLock()
+	producer := m.Producer
+	m.Mu.RUnlock()
+	if producer == nil {
+		return nil
+	}
+	return producer.Flush(ctx)
+}
+
+// CloseGracefully is like Close, but first waits for the sends in
+// progress to complete, like Flush, instead of failing them with
+// ErrManagedProducerClosed. Sends should no longer be started. If
+// ctx is done before, the remaining sends are abandoned and the
+// ManagedProducer is closed regardless.
+func (m *ManagedProducer) CloseGracefully(ctx context.Context) error {
+	if err := m.Flush(ctx); err != nil {
+		m.cancel()
+		return err
+	}
+	return m.Close(ctx)
+}
+
+// Close stops the ManagedProducer and closes its Producer, if one
+// was established. It waits for the Producer to close or for ctx to
+// be done. The ManagedProducer shouldn't be used after calling Close.
 func (m *ManagedProducer) Close(ctx context.Context) error {
-	defer m.Monitor()()
-	return m.Producer.Close(ctx)
+	// stop manage()
+	m.cancel()
+
+	select {
+	case <-m.donec:
+		return m.closeErr
+	case <-ctx.Done():
+		return ctx.Err()
+	}
 }
@@ -16,11 +16,14 @@ package manage
 import (
 	"bytes"
 	"context"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/golang/protobuf/proto"
 	"github.com/pepper-iot/pulsar-client-go/core/frame"
+	"github.com/pepper-iot/pulsar-client-go/core/pub"
+	"github.com/pepper-iot/pulsar-client-go/core/schema"
 	"github.com/pepper-iot/pulsar-client-go/core/srv"
 	"github.com/pepper-iot/pulsar-client-go/pkg/api"
 )
@@ -35,7 +38,7 @@ func TestManagedProducer(t *testing.T) {
 	}
 
 	cp := NewClientPool()
-	mp := NewManagedProducer(cp, ProducerConfig{
+	mp := NewManagedProducer(ctx, cp, ProducerConfig{
 		ClientConfig: ClientConfig{
 			Addr: srv.Addr,
 		},
@@ -91,7 +94,7 @@ func TestManagedProducer_Redirect(t *testing.T) {
 	primarySrv.SetTopicLookupResp(topic, topicSrv.Addr, api.CommandLookupTopicResponse_Connect, false)
 
 	cp := NewClientPool()
-	mp := NewManagedProducer(cp, ProducerConfig{
+	mp := NewManagedProducer(ctx, cp, ProducerConfig{
 		ClientConfig: ClientConfig{
 			Addr: primarySrv.Addr,
 		},
@@ -147,7 +150,7 @@ func TestManagedProducer_SrvClosed(t *testing.T) {
 	}
 
 	cp := NewClientPool()
-	mp := NewManagedProducer(cp, ProducerConfig{
+	mp := NewManagedProducer(ctx, cp, ProducerConfig{
 		ClientConfig: ClientConfig{
 			Addr: srv.Addr,
 		},
@@ -198,7 +201,7 @@ func TestManagedProducer_ProducerClosed(t *testing.T) {
 	}
 
 	cp := NewClientPool()
-	mp := NewManagedProducer(cp, ProducerConfig{
+	mp := NewManagedProducer(ctx, cp, ProducerConfig{
 		ClientConfig: ClientConfig{
 			Addr: srv.Addr,
 		},
@@ -274,3 +277,492 @@ func TestManagedProducer_ProducerClosed(t *testing.T) {
 		t.Fatal("timeout waiting for message")
 	}
 }
+
+func TestManagedProducer_OnReconnect(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	srv, err := srv.NewServer(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cp := NewClientPool()
+	mp := NewManagedProducer(ctx, cp, ProducerConfig{
+		ClientConfig: ClientConfig{
+			Addr: srv.Addr,
+		},
+		NewProducerTimeout: time.Second,
+		Topic:              "test-topic",
+	})
+
+	reconnected := make(chan *pub.Producer, 1)
+	mp.OnReconnect(func(p *pub.Producer) {
+		reconnected <- p
+	})
+
+	expectedFrames := []api.BaseCommand_Type{
+		api.BaseCommand_CONNECT,
+		api.BaseCommand_LOOKUP,
+		api.BaseCommand_PRODUCER,
+	}
+	if err = srv.AssertReceived(ctx, expectedFrames...); err != nil {
+		t.Fatal(err)
+	}
+	if err = srv.CloseAll(); err != nil {
+		t.Fatal(err)
+	}
+	if err = srv.AssertReceived(ctx, expectedFrames...); err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case p := <-reconnected:
+		if got, expected := p.ProducerName, "test"; got != expected {
+			t.Fatalf("OnReconnect hook got producer name %q; expected %q", got, expected)
+		}
+	case <-ctx.Done():
+		t.Fatal("timeout waiting for OnReconnect hook")
+	}
+}
+
+func TestManagedProducer_CloseNeverConnected(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	srv, err := srv.NewServer(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the producer will never be able to connect
+	srv.SetIgnoreConnects(true)
+
+	cp := NewClientPool()
+	mp := NewManagedProducer(ctx, cp, ProducerConfig{
+		ClientConfig: ClientConfig{
+			Addr: srv.Addr,
+		},
+		NewProducerTimeout: time.Second,
+		Topic:              "test-topic",
+	})
+
+	if err = srv.AssertReceived(ctx, api.BaseCommand_CONNECT); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = mp.Close(ctx); err != nil {
+		t.Fatalf("Close() err = %v; expected nil", err)
+	}
+
+	if _, err = mp.Send(ctx, []byte("hi")); err != ErrManagedProducerClosed {
+		t.Fatalf("Send() err = %v; expected %v", err, ErrManagedProducerClosed)
+	}
+}
+
+func TestManagedProducer_PendingSends(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	srv, err := srv.NewServer(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the producer can't connect until
+	// all sends have been queued
+	srv.SetIgnoreConnects(true)
+
+	cp := NewClientPool()
+	mp := NewManagedProducer(ctx, cp, ProducerConfig{
+		ClientConfig: ClientConfig{
+			Addr: srv.Addr,
+		},
+		NewProducerTimeout:    200 * time.Millisecond,
+		InitialReconnectDelay: 10 * time.Millisecond,
+		MaxReconnectDelay:     50 * time.Millisecond,
+		Topic:                 "test-topic",
+	})
+
+	payloads := []string{"one", "two", "three"}
+	errs := make(chan error, len(payloads))
+	for i, payload := range payloads {
+		go func(payload string) {
+			_, err := mp.Send(ctx, []byte(payload))
+			errs <- err
+		}(payload)
+
+		// wait for the send to be queued
+		for atomic.LoadInt32(&mp.queued) != int32(i+1) {
+			time.Sleep(time.Millisecond)
+		}
+	}
+
+	srv.SetIgnoreConnects(false)
+
+	for i := range payloads {
+		if err = <-errs; err != nil {
+			t.Fatalf("Send() %d err = %v; expected nil", i, err)
+		}
+	}
+
+	var got []string
+	for len(got) < len(payloads) {
+		select {
+		case f := <-srv.Received:
+			if f.BaseCmd.GetType() == api.BaseCommand_SEND {
+				got = append(got, string(f.Payload))
+			}
+		case <-ctx.Done():
+			t.Fatal("timeout waiting for SEND messages")
+		}
+	}
+	for i := range payloads {
+		if got[i] != payloads[i] {
+			t.Fatalf("got payloads %q; expected %q", got, payloads)
+		}
+	}
+}
+
+func TestManagedProducer_CloseWhileQueuing(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	srv, err := srv.NewServer(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the producer will never be able to connect
+	srv.SetIgnoreConnects(true)
+
+	cp := NewClientPool()
+	mp := NewManagedProducer(ctx, cp, ProducerConfig{
+		ClientConfig: ClientConfig{
+			Addr: srv.Addr,
+		},
+		NewProducerTimeout: time.Second,
+		Topic:              "test-topic",
+	})
+
+	// sends without a deadline are queued while the ManagedProducer
+	// is closed, and must all fail instead of waiting forever
+	const n = 50
+	errs := make(chan error, n)
+	for i := 0; i < n; i++ {
+		go func() {
+			_, err := mp.Send(context.Background(), []byte("hi"))
+			errs <- err
+		}()
+	}
+	if err = mp.Close(ctx); err != nil {
+		t.Fatalf("Close() err = %v; expected nil", err)
+	}
+	for i := 0; i < n; i++ {
+		select {
+		case err = <-errs:
+			if err != ErrManagedProducerClosed {
+				t.Fatalf("Send() err = %v; expected %v", err, ErrManagedProducerClosed)
+			}
+		case <-ctx.Done():
+			t.Fatalf("%d sends never returned", n-i)
+		}
+	}
+
+	// Allow the pending queue to be drained
+	time.Sleep(100 * time.Millisecond)
+
+	// queued once the pending queue isn't read anymore,
+	// as if SendMessage was preempted before enqueue
+	go func() {
+		_, err := mp.enqueue(context.Background(), pub.Message{Payload: []byte("hi")})
+		errs <- err
+	}()
+	select {
+	case err = <-errs:
+		if err != ErrManagedProducerClosed {
+			t.Fatalf("enqueue() err = %v; expected %v", err, ErrManagedProducerClosed)
+		}
+	case <-ctx.Done():
+		t.Fatal("enqueue() never returned")
+	}
+
+	if err = mp.Flush(ctx); err != nil {
+		t.Fatalf("Flush() err = %v; expected nil", err)
+	}
+	if got := atomic.LoadInt32(&mp.queued); got != 0 {
+		t.Fatalf("queued = %d; expected 0", got)
+	}
+}
+
+func TestManagedProducer_PendingLimits(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	srv, err := srv.NewServer(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the producer will never be able to connect
+	srv.SetIgnoreConnects(true)
+
+	cp := NewClientPool()
+	mp := NewManagedProducer(ctx, cp, ProducerConfig{
+		ClientConfig: ClientConfig{
+			Addr: srv.Addr,
+		},
+		NewProducerTimeout: time.Second,
+		Topic:              "test-topic",
+		PendingQueueSize:   1,
+		MaxPendingWait:     200 * time.Millisecond,
+	})
+
+	// the first send is picked up from the queue and waits for the
+	// producer, the second one fills the queue, the third is rejected
+	errs := make(chan error, 2)
+	for i := 0; i < 2; i++ {
+		go func() {
+			_, err := mp.Send(ctx, []byte("hi"))
+			errs <- err
+		}()
+		for atomic.LoadInt32(&mp.queued) != int32(i+1) {
+			time.Sleep(time.Millisecond)
+		}
+	}
+	for len(mp.pending) != 1 {
+		time.Sleep(time.Millisecond)
+	}
+
+	if _, err = mp.Send(ctx, []byte("hi")); err != ErrPendingQueueFull {
+		t.Fatalf("Send() err = %v; expected %v", err, ErrPendingQueueFull)
+	}
+
+	for i := 0; i < 2; i++ {
+		if err = <-errs; err != ErrPendingTimeout {
+			t.Fatalf("Send() err = %v; expected %v", err, ErrPendingTimeout)
+		}
+	}
+}
+
+func TestManagedProducer_Schema(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	srv, err := srv.NewServer(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cp := NewClientPool()
+	mp := NewManagedProducer(ctx, cp, ProducerConfig{
+		ClientConfig: ClientConfig{
+			Addr: srv.Addr,
+		},
+		NewProducerTimeout: time.Second,
+		Topic:              "test-topic",
+		Schema:             schema.NewJSON(`{"type":"record","name":"Test","fields":[]}`),
+	})
+
+	if err = srv.AssertReceived(ctx, api.BaseCommand_CONNECT, api.BaseCommand_LOOKUP); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case f := <-srv.Received:
+		if got, expected := f.BaseCmd.GetType(), api.BaseCommand_PRODUCER; got != expected {
+			t.Fatalf("got frame type %q; expected %q", got, expected)
+		}
+		if got, expected := f.BaseCmd.GetProducer().GetSchema().GetType(), api.Schema_Json; got != expected {
+			t.Fatalf("got schema type %v; expected %v", got, expected)
+		}
+	case <-ctx.Done():
+		t.Fatal("timeout waiting for PRODUCER message")
+	}
+
+	if _, err = mp.SendValue(ctx, map[string]int{"a": 1}); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case f := <-srv.Received:
+		if got, expected := string(f.Payload), `{"a":1}`; got != expected {
+			t.Fatalf("got payload %q; expected %q", got, expected)
+		}
+		// the version registered by the producer
+		if got, expected := f.Metadata.GetSchemaVersion(), []byte{0, 0, 0, 0, 0, 0, 0, 0}; !bytes.Equal(got, expected) {
+			t.Fatalf("got schema version %v; expected %v", got, expected)
+		}
+	case <-ctx.Done():
+		t.Fatal("timeout waiting for SEND message")
+	}
+	if got, expected := mp.SendLatency().Count, uint64(1); got != expected {
+		t.Fatalf("SendLatency().Count = %d; expected %d", got, expected)
+	}
+}
+
+func TestManagedProducer_TopicMigrated(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	migratedSrv, err := srv.NewServer(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv, err := srv.NewServer(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cp := NewClientPool()
+	NewManagedProducer(ctx, cp, ProducerConfig{
+		ClientConfig: ClientConfig{
+			Addr: srv.Addr,
+		},
+		NewProducerTimeout: time.Second,
+		Topic:              "test-topic",
+	})
+
+	expectedFrames := []api.BaseCommand_Type{
+		api.BaseCommand_CONNECT,
+		api.BaseCommand_LOOKUP,
+	}
+	if err = srv.AssertReceived(ctx, expectedFrames...); err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case f := <-srv.Received:
+		if got, expected := f.BaseCmd.GetType(), api.BaseCommand_PRODUCER; got != expected {
+			t.Fatalf("got frame type %q; expected %q", got, expected)
+		}
+
+		migrated := frame.Frame{
+			BaseCmd: &api.BaseCommand{
+				Type: api.BaseCommand_TOPIC_MIGRATED.Enum(),
+				TopicMigrated: &api.CommandTopicMigrated{
+					ResourceId:       f.BaseCmd.GetProducer().ProducerId,
+					ResourceType:     api.CommandTopicMigrated_Producer.Enum(),
+					BrokerServiceUrl: proto.String(migratedSrv.Addr),
+				},
+			},
+		}
+		if err = srv.Broadcast(migrated); err != nil {
+			t.Fatal(err)
+		}
+
+	case <-ctx.Done():
+		t.Fatal(ctx.Err())
+	}
+
+	// the producer is recreated through a lookup
+	// on the cluster the topic was migrated to
+	expectedFrames = []api.BaseCommand_Type{
+		api.BaseCommand_CONNECT,
+		api.BaseCommand_LOOKUP,
+		api.BaseCommand_PRODUCER,
+	}
+	if err = migratedSrv.AssertReceived(ctx, expectedFrames...); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestManagedProducer_MemoryLimit(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	srv, err := srv.NewServer(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cp := NewClientPoolWithConfig(ClientPoolConfig{MemoryLimit: 10})
+	cfg := ProducerConfig{
+		ClientConfig: ClientConfig{
+			Addr: srv.Addr,
+		},
+		NewProducerTimeout: time.Second,
+		Topic:              "test-topic",
+	}
+	mp := NewManagedProducer(ctx, cp, cfg)
+	cfg.Name = "fail-fast"
+	cfg.MemoryLimitFailFast = true
+	failFast := NewManagedProducer(ctx, cp, cfg)
+
+	if _, err = mp.Send(ctx, []byte("hola")); err != nil {
+		t.Fatal(err)
+	}
+	if got := cp.Memory().Used(); got != 0 {
+		t.Fatalf("Used() = %d after the send; expected 0", got)
+	}
+
+	// eg messages buffered by consumers
+	cp.Memory().ForceReserve(10)
+	if _, err = failFast.Send(ctx, []byte("hola")); err != ErrMemoryLimitExceeded {
+		t.Fatalf("Send() err = %v; expected %v", err, ErrMemoryLimitExceeded)
+	}
+
+	sent := make(chan error, 1)
+	go func() {
+		_, err := mp.Send(ctx, []byte("hola"))
+		sent <- err
+	}()
+	select {
+	case err := <-sent:
+		t.Fatalf("Send() = %v over the memory limit; expected it to wait", err)
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	cp.Memory().Release(10)
+	if err = <-sent; err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestManagedProducer_CloseGracefully(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	srv, err := srv.NewServer(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	retrying := make(chan struct{}, 1)
+	mp := NewManagedProducer(ctx, NewClientPool(), ProducerConfig{
+		ClientConfig:       ClientConfig{Addr: srv.Addr},
+		NewProducerTimeout: time.Second,
+		Topic:              "test-topic",
+		SendRetry: &SendRetryPolicy{
+			InitialDelay: 100 * time.Millisecond,
+			OnRetry: func(err error, retry int, delay time.Duration) {
+				retrying <- struct{}{}
+			},
+		},
+	})
+	if _, err = mp.Send(ctx, []byte("hola")); err != nil {
+		t.Fatal(err)
+	}
+
+	// the send is in progress while closing
+	srv.SetSendErrors(api.ServerError_ServiceNotReady)
+	sent := make(chan error, 1)
+	go func() {
+		_, err := mp.Send(ctx, []byte("hola"))
+		sent <- err
+	}()
+	<-retrying
+
+	// not yet completed
+	timeoutCtx, timeoutCancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer timeoutCancel()
+	if err = mp.Flush(timeoutCtx); err != context.DeadlineExceeded {
+		t.Fatalf("Flush() err = %v; expected %v", err, context.DeadlineExceeded)
+	}
+
+	if err = mp.CloseGracefully(ctx); err != nil {
+		t.Fatalf("CloseGracefully() err = %v; expected nil", err)
+	}
+	if err = <-sent; err != nil {
+		t.Fatalf("Send() err = %v; expected nil", err)
+	}
+	select {
+	case <-mp.Done():
+	default:
+		t.Fatal("Done() not closed after CloseGracefully()")
+	}
+}
@@ -0,0 +1,59 @@
+// Copyright 2018 Comcast Cable Communications Management, LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package manage
+
+import (
+	"sync"
+)
+
+// migration holds the service URLs of the cluster a topic was migrated
+// to, as reported by the broker with TOPIC_MIGRATED. They then replace
+// the configured address of the cluster it was migrated from, so that
+// producers and consumers are recreated by looking the topic up on the
+// new cluster. Other clusters, such as the backup cluster of a
+// Failover, are still used as configured. Its zero value holds no
+// migration.
+type migration struct {
+	mu     sync.RWMutex // protects following
+	from   string       // configured address of the cluster the topic was migrated from
+	url    string
+	tlsURL string
+}
+
+// set records a migration, from the cluster configured with
+// from, to the cluster of the service URLs.
+func (m *migration) set(from ClientConfig, url, tlsURL string) {
+	m.mu.Lock()
+	m.from, m.url, m.tlsURL = from.Addr, url, tlsURL
+	m.mu.Unlock()
+}
+
+// apply returns cfg, with the service URL of the cluster the topic
+// was migrated to as Addr, if it was migrated from cfg's cluster.
+func (m *migration) apply(cfg ClientConfig) ClientConfig {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if cfg.Addr != m.from {
+		return cfg
+	}
+	url := m.url
+	if cfg.TLSConfig != nil {
+		url = m.tlsURL
+	}
+	if url != "" {
+		cfg.Addr = url
+	}
+	return cfg
+}
@@ -0,0 +1,156 @@
+// Copyright 2018 Comcast Cable Communications Management, LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package manage
+
+import (
+	"math"
+	"sync"
+	"time"
+
+	"github.com/pepper-iot/pulsar-client-go/utils"
+)
+
+// pacer is a token bucket limiting the rate of the flow permits
+// granted to the broker, in messages and payload bytes per second.
+// Both buckets hold up to one second worth of tokens. Message tokens
+// are taken when permits are granted, while byte tokens are charged
+// once messages are delivered, since their size isn't known before:
+// no permit is granted while the byte bucket is in debt.
+// It is safe for concurrent use.
+type pacer struct {
+	clock    utils.Clock
+	msgRate  float64 // messages per second, 0 for no limit
+	byteRate float64 // bytes per second, 0 for no limit
+
+	mu    sync.Mutex // protects following
+	last  time.Time  // time of the latest refill
+	msgs  float64    // available message tokens
+	bytes float64    // available byte tokens, negative when in debt
+}
+
+// newPacer returns a pacer with full buckets,
+// or nil if neither rate is limited.
+func newPacer(clock utils.Clock, msgRate float64, byteRate int) *pacer {
+	if msgRate <= 0 && byteRate <= 0 {
+		return nil
+	}
+	p := pacer{
+		clock:    clock,
+		msgRate:  math.Max(msgRate, 0),
+		byteRate: math.Max(float64(byteRate), 0),
+		last:     clock.Now(),
+	}
+	p.msgs = p.msgBurst()
+	p.bytes = p.byteRate
+	return &p
+}
+
+// msgBurst is the capacity of the message bucket, which
+// must hold at least one token for any permit to be granted.
+func (p *pacer) msgBurst() float64 {
+	return math.Max(p.msgRate, 1)
+}
+
+// refill adds the tokens accumulated since the latest refill.
+// p.mu must be held.
+func (p *pacer) refill() {
+	now := p.clock.Now()
+	elapsed := now.Sub(p.last).Seconds()
+	if elapsed <= 0 {
+		return
+	}
+	p.last = now
+	if p.msgRate > 0 {
+		p.msgs = math.Min(p.msgs+elapsed*p.msgRate, p.msgBurst())
+	}
+	if p.byteRate > 0 {
+		p.bytes = math.Min(p.bytes+elapsed*p.byteRate, p.byteRate)
+	}
+}
+
+// take returns how many of n permits may be granted now. If fewer
+// than n may, wait is how long until more permits are available.
+func (p *pacer) take(n uint32) (granted uint32, wait time.Duration) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.refill()
+
+	if p.byteRate > 0 && p.bytes < 0 {
+		return 0, seconds(-p.bytes / p.byteRate)
+	}
+	if p.msgRate <= 0 {
+		return n, 0
+	}
+
+	granted = n
+	if avail := uint32(p.msgs); avail < granted {
+		granted = avail
+	}
+	p.msgs -= float64(granted)
+	if granted < n {
+		wait = seconds((1 - p.msgs) / p.msgRate)
+	}
+	return granted, wait
+}
+
+// charge takes the byte tokens of a delivered message.
+func (p *pacer) charge(size int) {
+	if p.byteRate <= 0 {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.refill()
+	p.bytes -= float64(size)
+}
+
+// seconds converts s seconds to a Duration,
+// rounded up so that waiting for it is enough.
+func seconds(s float64) time.Duration {
+	return time.Duration(math.Ceil(s * float64(time.Second)))
+}
+
+// retryTimer fires when permits held back by a
+// pacer may be granted. Its zero value is stopped.
+type retryTimer struct {
+	clock utils.Clock
+	timer utils.Timer
+}
+
+// reset stops the timer, then restarts it to fire after wait,
+// unless wait is zero.
+func (r *retryTimer) reset(wait time.Duration) {
+	r.stop()
+	if wait > 0 {
+		r.timer = r.clock.NewTimer(wait)
+	}
+}
+
+// C returns the channel of the timer, or nil, which
+// blocks forever when selected, if it is stopped.
+func (r *retryTimer) C() <-chan time.Time {
+	if r.timer == nil {
+		return nil
+	}
+	return r.timer.C()
+}
+
+func (r *retryTimer) stop() {
+	if r.timer != nil {
+		r.timer.Stop()
+		r.timer = nil
+	}
+}
@@ -0,0 +1,69 @@
+// Copyright 2018 Comcast Cable Communications Management, LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package manage
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pepper-iot/pulsar-client-go/utils"
+)
+
+func TestPacer(t *testing.T) {
+	if p := newPacer(utils.RealClock, 0, 0); p != nil {
+		t.Fatalf("newPacer(0, 0) = %v; expected nil", p)
+	}
+
+	clock := utils.NewManualClock(time.Now())
+	p := newPacer(clock, 10, 0)
+
+	take := func(n, expected uint32, expectedWait time.Duration) {
+		t.Helper()
+		if got, wait := p.take(n); got != expected || wait != expectedWait {
+			t.Fatalf("take(%d) = (%d, %v); expected (%d, %v)", n, got, wait, expected, expectedWait)
+		}
+	}
+
+	// one second worth of burst
+	take(4, 4, 0)
+	take(64, 6, 100*time.Millisecond)
+	take(1, 0, 100*time.Millisecond)
+
+	clock.Advance(250 * time.Millisecond)
+	take(64, 2, 50*time.Millisecond)
+
+	// the bucket doesn't fill beyond the burst
+	clock.Advance(time.Hour)
+	take(64, 10, 100*time.Millisecond)
+}
+
+func TestPacer_bytes(t *testing.T) {
+	clock := utils.NewManualClock(time.Now())
+	p := newPacer(clock, 0, 1000)
+
+	if got, wait := p.take(64); got != 64 || wait != 0 {
+		t.Fatalf("take(64) = (%d, %v); expected (64, 0)", got, wait)
+	}
+
+	// no permit while in debt
+	p.charge(1500)
+	if got, wait := p.take(64); got != 0 || wait != 500*time.Millisecond {
+		t.Fatalf("take(64) = (%d, %v); expected (0, 500ms)", got, wait)
+	}
+
+	clock.Advance(500 * time.Millisecond)
+	if got, wait := p.take(64); got != 64 || wait != 0 {
+		t.Fatalf("take(64) = (%d, %v); expected (64, 0)", got, wait)
+	}
+}
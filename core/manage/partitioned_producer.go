@@ -0,0 +1,233 @@
+// Copyright 2018 Comcast Cable Communications Management, LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package manage
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/pepper-iot/pulsar-client-go/core/pub"
+	"github.com/pepper-iot/pulsar-client-go/pkg/api"
+	"github.com/pepper-iot/pulsar-client-go/utils"
+)
+
+// PartitionTopic returns the name of partition i of a partitioned topic.
+func PartitionTopic(topic string, i int) string {
+	return fmt.Sprintf("%s-partition-%d", topic, i)
+}
+
+// NewPartitionedProducer looks up the number of partitions of
+// cfg.Topic, waiting at most cfg.NewProducerTimeout, then returns a
+// PartitionedProducer with a ManagedProducer for each partition. If
+// cfg.PartitionsUpdateInterval is positive, the number of partitions is
+// then looked up again at that interval, and ManagedProducers are added
+// for new partitions, to which messages are then routed as well.
+func NewPartitionedProducer(ctx context.Context, cp *ClientPool, cfg ProducerConfig) (*PartitionedProducer, error) {
+	cfg = cfg.setDefaults()
+
+	ctx, cancel := context.WithCancel(ctx)
+	p := PartitionedProducer{
+		Cfg:        cfg,
+		clientPool: cp,
+		ctx:        ctx,
+		cancel:     cancel,
+		donec:      make(chan struct{}),
+	}
+	n, err := p.partitions()
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+
+	// topics that aren't partitioned have 0 partitions,
+	// and are handled as a single one
+	p.partitioned = n > 0
+	if !p.partitioned {
+		p.Producers = []*ManagedProducer{NewManagedProducer(ctx, cp, cfg)}
+	} else {
+		p.grow(n)
+	}
+
+	go func() {
+		if p.partitioned && cfg.PartitionsUpdateInterval > 0 {
+			p.updatePartitions()
+		}
+		// no Producers are added anymore
+		for _, mp := range p.producers() {
+			<-mp.Done()
+		}
+		close(p.donec)
+	}()
+
+	return &p, nil
+}
+
+// PartitionedProducer sends messages to the partitions of a partitioned
+// topic, with a ManagedProducer for each. The partition of each message
+// is chosen by the Router of its ProducerConfig.
+type PartitionedProducer struct {
+	Cfg ProducerConfig
+
+	Mu        sync.RWMutex       // protects following
+	Producers []*ManagedProducer // by partition index
+
+	clientPool  *ClientPool
+	partitioned bool               // false if the topic isn't partitioned, and has a single Producer
+	ctx         context.Context    // lifecycle of the Producers and partitions updates
+	cancel      context.CancelFunc // stops partitions updates
+	donec       chan struct{}      // closed once all Producers are done
+}
+
+// partitions looks up the number of partitions of the topic,
+// which is 0 if it isn't partitioned.
+func (p *PartitionedProducer) partitions() (int, error) {
+	clientCfg := p.Cfg.ClientConfig
+	if p.Cfg.Failover != nil {
+		clientCfg = p.Cfg.Failover.config()
+	}
+	ctx, cancel := context.WithTimeout(p.ctx, p.Cfg.NewProducerTimeout)
+	defer cancel()
+	resp, err := p.clientPool.Partitions(ctx, clientCfg, p.Cfg.Topic)
+	if err != nil {
+		return 0, err
+	}
+	if resp.GetResponse() == api.CommandPartitionedTopicMetadataResponse_Failed {
+		return 0, utils.NewServerError(resp.GetError(), resp.GetMessage())
+	}
+	return int(resp.GetPartitions()), nil
+}
+
+// grow adds ManagedProducers for the partitions up to n, if
+// there are fewer. Partitions are never removed from a topic.
+func (p *PartitionedProducer) grow(n int) {
+	p.Mu.Lock()
+	defer p.Mu.Unlock()
+
+	for i := len(p.Producers); i < n; i++ {
+		partitionCfg := p.Cfg
+		partitionCfg.Topic = PartitionTopic(p.Cfg.Topic, i)
+		p.Producers = append(p.Producers, NewManagedProducer(p.ctx, p.clientPool, partitionCfg))
+	}
+}
+
+// updatePartitions looks up the number of partitions every
+// PartitionsUpdateInterval, and adds ManagedProducers for new
+// ones, until the PartitionedProducer is closed.
+func (p *PartitionedProducer) updatePartitions() {
+	ticker := p.Cfg.clock().NewTicker(p.Cfg.PartitionsUpdateInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C():
+			n, err := p.partitions()
+			if err != nil {
+				// retried at the next interval
+				labeledLogger(map[string]string{"topic": p.Cfg.Topic}).Infof("partitions update failed: %v", err)
+				continue
+			}
+			p.grow(n)
+
+		case <-p.ctx.Done():
+			return
+		}
+	}
+}
+
+// producers returns the ManagedProducers, by partition index.
+func (p *PartitionedProducer) producers() []*ManagedProducer {
+	p.Mu.RLock()
+	defer p.Mu.RUnlock()
+	return p.Producers
+}
+
+// Send sends the payload to the next partition.
+// See ManagedProducer.Send.
+func (p *PartitionedProducer) Send(ctx context.Context, payload []byte) (*api.CommandSendReceipt, error) {
+	return p.SendMessage(ctx, pub.Message{Payload: payload})
+}
+
+// SendMessage is like Send, but sends a message with the metadata
+// set on it, such as its partition key or properties, to the
+// partition chosen by the configured Router. The partition is set in
+// the MessageId of the receipt, since brokers don't set it.
+func (p *PartitionedProducer) SendMessage(ctx context.Context, message pub.Message) (*api.CommandSendReceipt, error) {
+	producers := p.producers()
+	n := len(producers)
+	i := 0
+	if n > 1 {
+		i = p.Cfg.Router.ChoosePartition(&message, n)
+	}
+	if i < 0 || i >= n {
+		return nil, fmt.Errorf("router chose partition %d of topic %q with %d partitions", i, p.Cfg.Topic, n)
+	}
+	receipt, err := producers[i].SendMessage(ctx, message)
+	if p.partitioned && receipt.GetMessageId() != nil {
+		receipt.MessageId.Partition = proto.Int32(int32(i))
+	}
+	return receipt, err
+}
+
+// SendValue encodes v using the configured Schema, then sends it.
+func (p *PartitionedProducer) SendValue(ctx context.Context, v interface{}) (*api.CommandSendReceipt, error) {
+	if p.Cfg.Schema == nil {
+		return nil, ErrNoSchema
+	}
+	payload, err := p.Cfg.Schema.Encode(v)
+	if err != nil {
+		return nil, err
+	}
+	return p.Send(ctx, payload)
+}
+
+// SendLatency returns the distribution of the round-trips of successful
+// sends, of all partitions. See ManagedProducer.SendLatency.
+func (p *PartitionedProducer) SendLatency() utils.HistogramSnapshot {
+	var s utils.HistogramSnapshot
+	for _, mp := range p.producers() {
+		s = s.Merge(mp.SendLatency())
+	}
+	return s
+}
+
+// Done returns a channel that unblocks once the
+// ManagedProducers of all partitions are done.
+func (p *PartitionedProducer) Done() <-chan struct{} {
+	return p.donec
+}
+
+// Close closes the ManagedProducers of all partitions, and
+// returns the first error. See ManagedProducer.Close.
+func (p *PartitionedProducer) Close(ctx context.Context) error {
+	// stop adding Producers
+	p.cancel()
+
+	producers := p.producers()
+	errs := make(chan error, len(producers))
+	for _, mp := range producers {
+		go func(mp *ManagedProducer) {
+			errs <- mp.Close(ctx)
+		}(mp)
+	}
+
+	var err error
+	for range producers {
+		if perr := <-errs; err == nil {
+			err = perr
+		}
+	}
+	return err
+}
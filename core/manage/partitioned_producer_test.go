@@ -0,0 +1,249 @@
+// Copyright 2018 Comcast Cable Communications Management, LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package manage
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pepper-iot/pulsar-client-go/core/frame"
+	"github.com/pepper-iot/pulsar-client-go/core/pub"
+	"github.com/pepper-iot/pulsar-client-go/core/srv"
+	"github.com/pepper-iot/pulsar-client-go/pkg/api"
+)
+
+// receiveFrames returns the next n frames of the given
+// type received by the server, skipping other frames.
+func receiveFrames(ctx context.Context, t *testing.T, srv *srv.Server, typ api.BaseCommand_Type, n int) []frame.Frame {
+	t.Helper()
+	var frames []frame.Frame
+	for len(frames) < n {
+		select {
+		case f := <-srv.Received:
+			if f.BaseCmd.GetType() == typ {
+				frames = append(frames, f)
+			}
+		case <-ctx.Done():
+			t.Fatalf("timeout waiting for %d %v frames, got %d", n, typ, len(frames))
+		}
+	}
+	return frames
+}
+
+func TestPartitionedProducer(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	srv, err := srv.NewServer(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv.SetPartitions("test-topic", 3)
+
+	cp := NewClientPool()
+	pp, err := NewPartitionedProducer(ctx, cp, ProducerConfig{
+		ClientConfig: ClientConfig{
+			Addr: srv.Addr,
+		},
+		NewProducerTimeout: time.Second,
+		Topic:              "test-topic",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, expected := len(pp.Producers), 3; got != expected {
+		t.Fatalf("got %d producers; expected %d", got, expected)
+	}
+
+	topics := make(map[uint64]string) // producer id -> topic
+	for _, f := range receiveFrames(ctx, t, srv, api.BaseCommand_PRODUCER, 3) {
+		topics[f.BaseCmd.GetProducer().GetProducerId()] = f.BaseCmd.GetProducer().GetTopic()
+	}
+	sentTo := func() string {
+		f := receiveFrames(ctx, t, srv, api.BaseCommand_SEND, 1)[0]
+		return topics[f.BaseCmd.GetSend().GetProducerId()]
+	}
+
+	// messages without a key are sent round-robin
+	seen := make(map[string]bool)
+	for i := 0; i < 3; i++ {
+		if _, err := pp.Send(ctx, []byte("hola mundo")); err != nil {
+			t.Fatal(err)
+		}
+		seen[sentTo()] = true
+	}
+	for i := 0; i < 3; i++ {
+		if topic := PartitionTopic("test-topic", i); !seen[topic] {
+			t.Fatalf("sent to %v; expected %q too", seen, topic)
+		}
+	}
+
+	// and those with a key to the partition of their key,
+	// as selected by the Java client
+	for i := 0; i < 2; i++ {
+		receipt, err := pp.SendMessage(ctx, pub.Message{Payload: []byte("hola mundo"), Key: "hello"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		// "hello".hashCode() == 99162322
+		if got, expected := sentTo(), PartitionTopic("test-topic", 99162322%3); got != expected {
+			t.Fatalf("sent key to %q; expected %q", got, expected)
+		}
+		// receipts identify the partition
+		if got, expected := pub.MessageID(receipt).Partition, int32(99162322%3); got != expected {
+			t.Fatalf("receipt partition = %d; expected %d", got, expected)
+		}
+	}
+
+	if got, expected := pp.SendLatency().Count, uint64(5); got != expected {
+		t.Fatalf("SendLatency().Count = %d; expected %d", got, expected)
+	}
+
+	if err := pp.Close(ctx); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case <-pp.Done():
+	case <-ctx.Done():
+		t.Fatal("timeout waiting for Done")
+	}
+}
+
+func TestPartitionedProducer_notPartitioned(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	srv, err := srv.NewServer(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cp := NewClientPool()
+	pp, err := NewPartitionedProducer(ctx, cp, ProducerConfig{
+		ClientConfig: ClientConfig{
+			Addr: srv.Addr,
+		},
+		NewProducerTimeout: time.Second,
+		Topic:              "test-topic",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	f := receiveFrames(ctx, t, srv, api.BaseCommand_PRODUCER, 1)[0]
+	if got, expected := f.BaseCmd.GetProducer().GetTopic(), "test-topic"; got != expected {
+		t.Fatalf("got producer topic %q; expected %q", got, expected)
+	}
+	receipt, err := pp.SendMessage(ctx, pub.Message{Payload: []byte("hola mundo"), Key: "hello"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, expected := pub.MessageID(receipt).Partition, int32(-1); got != expected {
+		t.Fatalf("receipt partition = %d; expected %d", got, expected)
+	}
+}
+
+func TestPartitionedProducer_Router(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	srv, err := srv.NewServer(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv.SetPartitions("test-topic", 3)
+
+	cp := NewClientPool()
+	pp, err := NewPartitionedProducer(ctx, cp, ProducerConfig{
+		ClientConfig: ClientConfig{
+			Addr: srv.Addr,
+		},
+		NewProducerTimeout: time.Second,
+		Topic:              "test-topic",
+		Router: MessageRouterFunc(func(msg *pub.Message, numPartitions int) int {
+			if msg.Key == "invalid" {
+				return numPartitions
+			}
+			return numPartitions - 1
+		}),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	topics := make(map[uint64]string) // producer id -> topic
+	for _, f := range receiveFrames(ctx, t, srv, api.BaseCommand_PRODUCER, 3) {
+		topics[f.BaseCmd.GetProducer().GetProducerId()] = f.BaseCmd.GetProducer().GetTopic()
+	}
+
+	if _, err := pp.SendMessage(ctx, pub.Message{Payload: []byte("hola mundo"), Key: "invalid"}); err == nil {
+		t.Fatal("SendMessage() to an invalid partition err = nil; expected an error")
+	}
+	if _, err := pp.Send(ctx, []byte("hola mundo")); err != nil {
+		t.Fatal(err)
+	}
+	f := receiveFrames(ctx, t, srv, api.BaseCommand_SEND, 1)[0]
+	if got, expected := topics[f.BaseCmd.GetSend().GetProducerId()], PartitionTopic("test-topic", 2); got != expected {
+		t.Fatalf("sent to %q; expected %q", got, expected)
+	}
+}
+
+func TestPartitionedProducer_PartitionsUpdate(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	srv, err := srv.NewServer(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv.SetPartitions("test-topic", 2)
+
+	pp, err := NewPartitionedProducer(ctx, NewClientPool(), ProducerConfig{
+		ClientConfig: ClientConfig{
+			Addr: srv.Addr,
+		},
+		NewProducerTimeout:       time.Second,
+		Topic:                    "test-topic",
+		PartitionsUpdateInterval: 10 * time.Millisecond,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer pp.Close(ctx)
+	receiveFrames(ctx, t, srv, api.BaseCommand_PRODUCER, 2)
+
+	// the topic grows
+	srv.SetPartitions("test-topic", 3)
+	f := receiveFrames(ctx, t, srv, api.BaseCommand_PRODUCER, 1)[0]
+	if got, expected := f.BaseCmd.GetProducer().GetTopic(), PartitionTopic("test-topic", 2); got != expected {
+		t.Fatalf("created producer on %q; expected %q", got, expected)
+	}
+
+	// and messages are sent round-robin to the new partition too
+	for i := 0; i < 3; i++ {
+		if _, err = pp.Send(ctx, []byte("hola mundo")); err != nil {
+			t.Fatal(err)
+		}
+	}
+	pp.Mu.RLock()
+	producers := pp.Producers
+	pp.Mu.RUnlock()
+	if got, expected := len(producers), 3; got != expected {
+		t.Fatalf("got %d producers; expected %d", got, expected)
+	}
+	if got, expected := producers[2].SendLatency().Count, uint64(1); got != expected {
+		t.Fatalf("sent %d messages to the new partition; expected %d", got, expected)
+	}
+}
@@ -0,0 +1,133 @@
+// Copyright 2018 Comcast Cable Communications Management, LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package manage
+
+import (
+	"context"
+	"errors"
+	"reflect"
+
+	"github.com/pepper-iot/pulsar-client-go/core/frame"
+	"github.com/pepper-iot/pulsar-client-go/pkg/api"
+	"github.com/pepper-iot/pulsar-client-go/utils"
+)
+
+// The methods in this file are an escape hatch to the connection of
+// the Client, e.g. to experiment with commands the Client doesn't
+// support yet. Frames sent this way bypass the state the Client keeps
+// about its producers and consumers.
+
+// ErrNoCommand is returned by SendRaw if the frame has no BaseCmd.
+var ErrNoCommand = errors.New("frame has no command")
+
+// RawHandler handles received frames of a command type the Client
+// doesn't handle itself. The frame is released once it returns, so
+// it must not be retained, though the commands it contains may be.
+type RawHandler func(f frame.Frame) error
+
+// HandleRaw sets the handler of received frames of the command type,
+// or removes it if h is nil. Handlers are only called for types the
+// Client doesn't handle itself, and may be called concurrently. Errors
+// they return are reported like other asynchronous errors.
+func (c *Client) HandleRaw(t api.BaseCommand_Type, h RawHandler) {
+	c.rmu.Lock()
+	defer c.rmu.Unlock()
+	if h == nil {
+		delete(c.raw, t)
+		return
+	}
+	if c.raw == nil {
+		c.raw = make(map[api.BaseCommand_Type]RawHandler)
+	}
+	c.raw[t] = h
+}
+
+// rawHandler returns the handler of the command type, if any.
+func (c *Client) rawHandler(t api.BaseCommand_Type) RawHandler {
+	c.rmu.RLock()
+	defer c.rmu.RUnlock()
+	return c.raw[t]
+}
+
+// SendRaw sends the frame as is. It's a "payload" frame if
+// its Metadata is set, and a "simple" frame otherwise.
+func (c *Client) SendRaw(f frame.Frame) error {
+	if f.BaseCmd == nil {
+		return ErrNoCommand
+	}
+	if f.Metadata == nil {
+		return c.C.SendSimpleCmd(*f.BaseCmd)
+	}
+	return c.C.SendPayloadCmd(*f.BaseCmd, *f.Metadata, f.Payload)
+}
+
+// NewRequestID returns a request ID for a command sent with
+// RequestRaw, which doesn't collide with those of the Client.
+func (c *Client) NewRequestID() uint64 {
+	return *c.Pubsub.ReqID.Next()
+}
+
+// RequestRaw sends cmd, whose request ID must be reqID, and waits for
+// the response with the same request ID, or the context to be done.
+// Responses of any command type with a request_id field are routed to
+// it, unless the Client handles that type itself. An ERROR response is
+// returned as a *utils.ServerError.
+func (c *Client) RequestRaw(ctx context.Context, cmd api.BaseCommand, reqID uint64) (frame.Frame, error) {
+	deadline, _ := ctx.Deadline()
+	resp, cancel, err := c.Dispatcher.RegisterReqIDDeadline(reqID, deadline)
+	if err != nil {
+		return frame.Frame{}, err
+	}
+	defer cancel()
+
+	if err := c.C.SendSimpleCmd(cmd); err != nil {
+		return frame.Frame{}, err
+	}
+
+	select {
+	case <-ctx.Done():
+		return frame.Frame{}, ctx.Err()
+
+	case f := <-resp:
+		if f.BaseCmd.GetType() == api.BaseCommand_ERROR {
+			errMsg := f.BaseCmd.GetError()
+			return f, utils.NewServerError(errMsg.GetError(), errMsg.GetMessage())
+		}
+		return f, nil
+	}
+}
+
+// requestID returns the request ID of the command set in cmd,
+// and false if it doesn't have one.
+func requestID(cmd *api.BaseCommand) (uint64, bool) {
+	type requestIDer interface {
+		GetRequestId() uint64
+	}
+
+	v := reflect.ValueOf(cmd).Elem()
+	for i := 0; i < v.NumField(); i++ {
+		field := v.Field(i)
+		if field.Kind() != reflect.Ptr || field.IsNil() || field.Elem().Kind() != reflect.Struct {
+			continue
+		}
+		r, ok := field.Interface().(requestIDer)
+		if !ok {
+			continue
+		}
+		if id := field.Elem().FieldByName("RequestId"); id.IsValid() && !id.IsNil() {
+			return r.GetRequestId(), true
+		}
+	}
+	return 0, false
+}
@@ -0,0 +1,138 @@
+// Copyright 2018 Comcast Cable Communications Management, LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package manage
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/pepper-iot/pulsar-client-go/core/frame"
+	"github.com/pepper-iot/pulsar-client-go/core/srv"
+	"github.com/pepper-iot/pulsar-client-go/pkg/api"
+	"github.com/pepper-iot/pulsar-client-go/utils"
+)
+
+func TestClient_RequestRaw(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	srv, err := srv.NewServer(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c, err := NewClient(ClientConfig{
+		Addr: srv.Addr,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = c.Connect(ctx, ""); err != nil {
+		t.Fatal(err)
+	}
+
+	reqID := c.NewRequestID()
+	cmd := api.BaseCommand{
+		Type: api.BaseCommand_LOOKUP.Enum(),
+		LookupTopic: &api.CommandLookupTopic{
+			Topic:     proto.String("persistent://public/default/raw"),
+			RequestId: proto.Uint64(reqID),
+		},
+	}
+	f, err := c.RequestRaw(ctx, cmd, reqID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, expected := f.BaseCmd.GetType(), api.BaseCommand_LOOKUP_RESPONSE; got != expected {
+		t.Fatalf("got response of type %v; expected %v", got, expected)
+	}
+	if got, expected := f.BaseCmd.GetLookupTopicResponse().GetRequestId(), reqID; got != expected {
+		t.Fatalf("got response to request %d; expected %d", got, expected)
+	}
+
+	if err := c.SendRaw(frame.Frame{}); err != ErrNoCommand {
+		t.Fatalf("SendRaw() err = %v; expected %v", err, ErrNoCommand)
+	}
+}
+
+func TestClient_handleFrame_Raw(t *testing.T) {
+	errs := make(chan error, 1)
+	c := &Client{
+		Dispatcher: frame.NewFrameDispatcher(),
+		AsyncErrs:  utils.AsyncErrors(errs),
+	}
+
+	// responses the Client doesn't handle are routed
+	// by their request ID
+	resp, cancel, err := c.Dispatcher.RegisterReqID(7)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer cancel()
+	lastID := frame.Frame{
+		BaseCmd: &api.BaseCommand{
+			Type: api.BaseCommand_GET_LAST_MESSAGE_ID_RESPONSE.Enum(),
+			GetLastMessageIdResponse: &api.CommandGetLastMessageIdResponse{
+				LastMessageId: &api.MessageIdData{
+					LedgerId: proto.Uint64(1),
+					EntryId:  proto.Uint64(2),
+				},
+				RequestId: proto.Uint64(7),
+			},
+		},
+	}
+	go c.handleFrame(lastID)
+	select {
+	case f := <-resp:
+		if !f.Equal(lastID) {
+			t.Fatalf("got frame %+v; expected %+v", f, lastID)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("timeout waiting for response")
+	}
+
+	// other command types go to their handler
+	challenge := frame.Frame{
+		BaseCmd: &api.BaseCommand{
+			Type:          api.BaseCommand_AUTH_CHALLENGE.Enum(),
+			AuthChallenge: &api.CommandAuthChallenge{},
+		},
+	}
+	handled := make(chan frame.Frame, 1)
+	c.HandleRaw(api.BaseCommand_AUTH_CHALLENGE, func(f frame.Frame) error {
+		handled <- f
+		return nil
+	})
+	c.handleFrame(challenge)
+	select {
+	case f := <-handled:
+		if !f.Equal(challenge) {
+			t.Fatalf("got frame %+v; expected %+v", f, challenge)
+		}
+	default:
+		t.Fatal("handler wasn't called")
+	}
+
+	// and are unhandled without one
+	c.HandleRaw(api.BaseCommand_AUTH_CHALLENGE, nil)
+	c.handleFrame(challenge)
+	select {
+	case err := <-errs:
+		t.Logf("unhandled frame: %v", err)
+	default:
+		t.Fatal("expected an error for an unhandled frame")
+	}
+}
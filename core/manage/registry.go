@@ -0,0 +1,92 @@
+// Copyright 2018 Comcast Cable Communications Management, LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package manage
+
+import (
+	"sort"
+	"sync"
+)
+
+// EntityState is the state of a ManagedProducer or ManagedConsumer.
+type EntityState string
+
+// Possible EntityStates.
+const (
+	EntityConnecting   EntityState = "connecting"   // not connected yet
+	EntityConnected    EntityState = "connected"    // the Producer or Consumer is available
+	EntityReconnecting EntityState = "reconnecting" // the Producer or Consumer is being recreated
+)
+
+// EntityInfo describes a live ManagedProducer or ManagedConsumer.
+type EntityInfo struct {
+	Topic      string
+	Name       string // producer name or subscription name
+	Producer   bool   // true for a ManagedProducer, false for a ManagedConsumer
+	State      EntityState
+	Reconnects int // number of times the Producer or Consumer was recreated
+
+	// ManagedConsumers only
+	Overflowed uint64 // messages dropped because the queue was full
+	Dropped    uint64 // buffered messages dropped because the Consumer was replaced
+}
+
+// inspector is implemented by ManagedProducer and ManagedConsumer.
+type inspector interface {
+	inspect() EntityInfo
+}
+
+// registry holds the live managed entities of a ClientPool.
+type registry struct {
+	mu       sync.Mutex
+	entities map[inspector]struct{}
+}
+
+func (r *registry) add(e inspector) {
+	r.mu.Lock()
+	if r.entities == nil {
+		r.entities = make(map[inspector]struct{})
+	}
+	r.entities[e] = struct{}{}
+	r.mu.Unlock()
+}
+
+func (r *registry) remove(e inspector) {
+	r.mu.Lock()
+	delete(r.entities, e)
+	r.mu.Unlock()
+}
+
+// Inspect returns information about every live ManagedProducer and
+// ManagedConsumer created with the pool, sorted by topic. It is meant
+// for debugging endpoints and admin dashboards.
+func (m *ClientPool) Inspect() []EntityInfo {
+	m.registry.mu.Lock()
+	entities := make([]inspector, 0, len(m.registry.entities))
+	for e := range m.registry.entities {
+		entities = append(entities, e)
+	}
+	m.registry.mu.Unlock()
+
+	infos := make([]EntityInfo, len(entities))
+	for i, e := range entities {
+		infos[i] = e.inspect()
+	}
+	sort.Slice(infos, func(i, j int) bool {
+		if infos[i].Topic != infos[j].Topic {
+			return infos[i].Topic < infos[j].Topic
+		}
+		return infos[i].Name < infos[j].Name
+	})
+	return infos
+}
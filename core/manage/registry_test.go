@@ -0,0 +1,85 @@
+// Copyright 2018 Comcast Cable Communications Management, LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package manage
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/pepper-iot/pulsar-client-go/core/srv"
+)
+
+func TestClientPool_Inspect(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	srv, err := srv.NewServer(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cp := NewClientPool()
+	clientCfg := ClientConfig{
+		Addr:                  srv.Addr,
+		InitialReconnectDelay: 10 * time.Millisecond,
+	}
+	mp := NewManagedProducer(ctx, cp, ProducerConfig{
+		ClientConfig:          clientCfg,
+		NewProducerTimeout:    time.Second,
+		InitialReconnectDelay: 10 * time.Millisecond,
+		Topic:                 "a-topic",
+	})
+	NewManagedConsumer(ctx, cp, ConsumerConfig{
+		ClientConfig:          clientCfg,
+		NewConsumerTimeout:    time.Second,
+		InitialReconnectDelay: 10 * time.Millisecond,
+		Topic:                 "b-topic",
+		Name:                  "test",
+		SubMode:               SubscriptionModeShard,
+	})
+
+	// waitFor polls Inspect until it returns expected
+	waitFor := func(expected []EntityInfo) {
+		var got []EntityInfo
+		for ctx.Err() == nil {
+			if got = cp.Inspect(); reflect.DeepEqual(got, expected) {
+				return
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+		t.Fatalf("Inspect() = %+v; expected %+v", got, expected)
+	}
+
+	waitFor([]EntityInfo{
+		{Topic: "a-topic", Name: "test", Producer: true, State: EntityConnected},
+		{Topic: "b-topic", Name: "test", State: EntityConnected},
+	})
+
+	if err = srv.CloseAll(); err != nil {
+		t.Fatal(err)
+	}
+	waitFor([]EntityInfo{
+		{Topic: "a-topic", Name: "test", Producer: true, State: EntityConnected, Reconnects: 1},
+		{Topic: "b-topic", Name: "test", State: EntityConnected, Reconnects: 1},
+	})
+
+	if err = mp.Close(ctx); err != nil {
+		t.Fatal(err)
+	}
+	waitFor([]EntityInfo{
+		{Topic: "b-topic", Name: "test", State: EntityConnected, Reconnects: 1},
+	})
+}
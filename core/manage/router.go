@@ -0,0 +1,81 @@
+// Copyright 2018 Comcast Cable Communications Management, LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package manage
+
+import (
+	"sync/atomic"
+
+	"github.com/pepper-iot/pulsar-client-go/core/pub"
+)
+
+// MessageRouter selects the partition each message of a
+// PartitionedProducer is sent to, eg to keep messages on partitions
+// close to their consumers. It must be safe for concurrent use.
+type MessageRouter interface {
+	// ChoosePartition returns the index of the partition to send
+	// msg to, from 0 to numPartitions-1.
+	ChoosePartition(msg *pub.Message, numPartitions int) int
+}
+
+// MessageRouterFunc adapts a function to a MessageRouter.
+type MessageRouterFunc func(msg *pub.Message, numPartitions int) int
+
+// ChoosePartition calls f(msg, numPartitions).
+func (f MessageRouterFunc) ChoosePartition(msg *pub.Message, numPartitions int) int {
+	return f(msg, numPartitions)
+}
+
+// RoundRobinRouter is the default MessageRouter. It sends messages
+// with a partition key to the partition of their key, so that they
+// stay in order, and distributes the others round-robin. Its zero
+// value is ready to use.
+type RoundRobinRouter struct {
+	Hashing HashingScheme // hash of the partition keys
+
+	next uint32 // accessed atomically
+}
+
+// ChoosePartition implements MessageRouter.
+func (r *RoundRobinRouter) ChoosePartition(msg *pub.Message, numPartitions int) int {
+	if i, ok := keyPartition(r.Hashing, msg, numPartitions); ok {
+		return i
+	}
+	return int((atomic.AddUint32(&r.next, 1) - 1) % uint32(numPartitions))
+}
+
+// SinglePartitionRouter is a MessageRouter sending messages with a
+// partition key to the partition of their key, and all the others to
+// the same partition, eg to keep them in order.
+type SinglePartitionRouter struct {
+	Partition int           // partition of the messages without a key, modulo the number of partitions
+	Hashing   HashingScheme // hash of the partition keys
+}
+
+// ChoosePartition implements MessageRouter.
+func (r SinglePartitionRouter) ChoosePartition(msg *pub.Message, numPartitions int) int {
+	if i, ok := keyPartition(r.Hashing, msg, numPartitions); ok {
+		return i
+	}
+	return r.Partition % numPartitions
+}
+
+// keyPartition returns the partition of the partition key of msg,
+// hashed with h, and false if it doesn't have one.
+func keyPartition(h HashingScheme, msg *pub.Message, numPartitions int) (int, bool) {
+	key := msg.PartitionKey()
+	if key == "" {
+		return 0, false
+	}
+	return int(h.Hash(key) % uint32(numPartitions)), true
+}
@@ -0,0 +1,62 @@
+// Copyright 2018 Comcast Cable Communications Management, LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package manage
+
+import (
+	"testing"
+
+	"github.com/pepper-iot/pulsar-client-go/core/pub"
+)
+
+func TestRoundRobinRouter(t *testing.T) {
+	var r RoundRobinRouter
+	for i := 0; i < 6; i++ {
+		if got, expected := r.ChoosePartition(&pub.Message{}, 3), i%3; got != expected {
+			t.Fatalf("ChoosePartition() #%d = %d; expected %d", i, got, expected)
+		}
+	}
+
+	// "hello".hashCode() == 99162322
+	for i := 0; i < 3; i++ {
+		if got, expected := r.ChoosePartition(&pub.Message{Key: "hello"}, 3), 99162322%3; got != expected {
+			t.Fatalf("ChoosePartition() of key = %d; expected %d", got, expected)
+		}
+	}
+}
+
+func TestSinglePartitionRouter(t *testing.T) {
+	r := SinglePartitionRouter{Partition: 3}
+	for i := 0; i < 3; i++ {
+		if got, expected := r.ChoosePartition(&pub.Message{}, 3), 0; got != expected {
+			t.Fatalf("ChoosePartition() = %d; expected %d", got, expected)
+		}
+	}
+	if got, expected := r.ChoosePartition(&pub.Message{Key: "hello"}, 3), 99162322%3; got != expected {
+		t.Fatalf("ChoosePartition() of key = %d; expected %d", got, expected)
+	}
+}
+
+func TestRoundRobinRouter_Hashing(t *testing.T) {
+	r := RoundRobinRouter{Hashing: Murmur3_32Hash}
+	// murmur3("hello") == 0x248bfa47
+	if got, expected := r.ChoosePartition(&pub.Message{Key: "hello"}, 7), 0x248bfa47%7; got != expected {
+		t.Fatalf("ChoosePartition() of key = %d; expected %d", got, expected)
+	}
+
+	// the default Router of a ProducerConfig uses its Hashing
+	cfg := ProducerConfig{Hashing: Murmur3_32Hash}.setDefaults()
+	if got, expected := cfg.Router.ChoosePartition(&pub.Message{Key: "hello"}, 7), 0x248bfa47%7; got != expected {
+		t.Fatalf("default Router ChoosePartition() of key = %d; expected %d", got, expected)
+	}
+}
@@ -0,0 +1,66 @@
+// Copyright 2018 Comcast Cable Communications Management, LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package manage
+
+import (
+	"context"
+	"sync"
+
+	"github.com/pepper-iot/pulsar-client-go/core/schema"
+)
+
+// schemaKey identifies a version of a topic's schema.
+type schemaKey struct {
+	topic   string
+	version string
+}
+
+// schemaCache holds the schemas fetched from the broker. Schema
+// versions are immutable, so entries never need to be invalidated.
+type schemaCache struct {
+	entries sync.Map // schemaKey -> schema.Schema
+}
+
+// Schema returns the Schema for the given version of the topic's schema.
+// It is fetched from the broker the first time it is requested, then
+// cached for the lifetime of the ClientPool, so that ManagedConsumers
+// don't fetch it again when they are restarted.
+func (m *ClientPool) Schema(ctx context.Context, cfg ClientConfig, topic string, version []byte) (schema.Schema, error) {
+	key := schemaKey{topic: topic, version: string(version)}
+	if s, ok := m.schemas.entries.Load(key); ok {
+		return s.(schema.Schema), nil
+	}
+
+	mc, err := m.ForTopic(ctx, cfg, topic)
+	if err != nil {
+		return nil, err
+	}
+	client, err := mc.Get(ctx)
+	if err != nil {
+		return nil, err
+	}
+	info, _, err := client.GetSchema(ctx, topic, version)
+	if err != nil {
+		return nil, err
+	}
+	s, err := schema.FromInfo(info)
+	if err != nil {
+		return nil, err
+	}
+
+	// concurrent fetches of the same version
+	// yield equivalent schemas; keep the first
+	actual, _ := m.schemas.entries.LoadOrStore(key, s)
+	return actual.(schema.Schema), nil
+}
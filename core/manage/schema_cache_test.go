@@ -0,0 +1,76 @@
+// Copyright 2018 Comcast Cable Communications Management, LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package manage
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pepper-iot/pulsar-client-go/core/schema"
+	"github.com/pepper-iot/pulsar-client-go/core/srv"
+	"github.com/pepper-iot/pulsar-client-go/pkg/api"
+)
+
+func TestClientPool_Schema(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	srv, err := srv.NewServer(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv.SetSchema("test-topic", schema.NewJSON("{}").Info().Proto(), []byte{1})
+
+	cp := NewClientPool()
+	cfg := ClientConfig{
+		Addr: srv.Addr,
+	}
+
+	s, err := cp.Schema(ctx, cfg, "test-topic", []byte{1})
+	if err != nil {
+		t.Fatalf("Schema() err = %v; expected nil", err)
+	}
+	if got, expected := s.Info().Type, api.Schema_Json; got != expected {
+		t.Fatalf("Schema() type = %v; expected %v", got, expected)
+	}
+
+	// drain the frames of the first fetch
+	expectedFrames := []api.BaseCommand_Type{
+		api.BaseCommand_CONNECT,
+		api.BaseCommand_LOOKUP,
+		api.BaseCommand_GET_SCHEMA,
+	}
+	if err = srv.AssertReceived(ctx, expectedFrames...); err != nil {
+		t.Fatal(err)
+	}
+
+	cached, err := cp.Schema(ctx, cfg, "test-topic", []byte{1})
+	if err != nil {
+		t.Fatalf("Schema() err = %v; expected nil", err)
+	}
+	if cached != s {
+		t.Fatal("Schema() returned a different Schema; expected the cached one")
+	}
+
+	select {
+	case f := <-srv.Received:
+		t.Fatalf("got %q frame; expected cached Schema to be used", f.BaseCmd.GetType())
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	if _, err = cp.Schema(ctx, cfg, "test-topic", []byte{2}); err == nil {
+		t.Fatal("Schema() err = nil; expected error for unknown schema version")
+	}
+}
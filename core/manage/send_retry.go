@@ -0,0 +1,93 @@
+// Copyright 2018 Comcast Cable Communications Management, LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package manage
+
+import (
+	"errors"
+	"time"
+
+	"github.com/pepper-iot/pulsar-client-go/core/pub"
+	"github.com/pepper-iot/pulsar-client-go/utils"
+)
+
+// SendRetryPolicy configures how a ManagedProducer retries the sends
+// failing with a temporary ServerError, such as ServiceNotReady or
+// TooManyRequests: see utils.ServerError.Temporary. Other errors, eg
+// TopicTerminated or a schema mismatch, are fatal and returned right
+// away, as are those of sends whose context is done.
+type SendRetryPolicy struct {
+	MaxRetries   int           // maximum number of retries of a send. Defaults to 5
+	InitialDelay time.Duration // delay before the first retry, doubling after each. Defaults to 100ms
+	MaxDelay     time.Duration // maximum delay between retries. Defaults to 10s
+
+	// OnRetry, if set, is called before each retry with the error of
+	// the failed attempt, the number of the retry, from 1, and the delay
+	// before it, eg to record metrics. It must not block.
+	OnRetry func(err error, retry int, delay time.Duration)
+}
+
+// setDefaults returns a modified policy with appropriate zero values set to defaults.
+func (r SendRetryPolicy) setDefaults() SendRetryPolicy {
+	if r.MaxRetries <= 0 {
+		r.MaxRetries = 5
+	}
+	if r.InitialDelay <= 0 {
+		r.InitialDelay = 100 * time.Millisecond
+	}
+	if r.MaxDelay <= 0 {
+		r.MaxDelay = 10 * time.Second
+	}
+	return r
+}
+
+// IsRetriableSendError reports whether a send failing with err may
+// succeed if retried with the same Producer, ie whether err is a
+// temporary ServerError.
+func IsRetriableSendError(err error) bool {
+	return utils.IsTemporary(err)
+}
+
+// sendRetrier retries the attempts of a single send.
+type sendRetrier struct {
+	policy  *SendRetryPolicy // nil if sends aren't retried
+	backoff utils.Backoff
+	retries int
+}
+
+func newSendRetrier(policy *SendRetryPolicy, message pub.Message) sendRetrier {
+	if policy == nil || message.SequenceID != nil {
+		// retrying a message with its own sequence id
+		// would fail, since the id is already used
+		return sendRetrier{}
+	}
+	return sendRetrier{
+		policy:  policy,
+		backoff: utils.Backoff{Initial: policy.InitialDelay, Max: policy.MaxDelay},
+	}
+}
+
+// next returns the delay before retrying an attempt that failed with
+// err, and false if it mustn't be retried.
+func (r *sendRetrier) next(err error) (time.Duration, bool) {
+	if r.policy == nil || r.retries >= r.policy.MaxRetries || !IsRetriableSendError(err) ||
+		errors.Is(err, ErrManagedProducerClosed) {
+		return 0, false
+	}
+	r.retries++
+	delay := r.backoff.Next()
+	if r.policy.OnRetry != nil {
+		r.policy.OnRetry(err, r.retries, delay)
+	}
+	return delay, true
+}
@@ -0,0 +1,92 @@
+// Copyright 2018 Comcast Cable Communications Management, LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package manage
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/pepper-iot/pulsar-client-go/core/srv"
+	"github.com/pepper-iot/pulsar-client-go/pkg/api"
+	"github.com/pepper-iot/pulsar-client-go/utils"
+)
+
+func TestManagedProducer_SendRetry(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	srv, err := srv.NewServer(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var mu sync.Mutex
+	var retries []int
+	mp := NewManagedProducer(ctx, NewClientPool(), ProducerConfig{
+		ClientConfig:       ClientConfig{Addr: srv.Addr},
+		NewProducerTimeout: time.Second,
+		Topic:              "test-topic",
+		SendRetry: &SendRetryPolicy{
+			MaxRetries:   2,
+			InitialDelay: time.Millisecond,
+			OnRetry: func(err error, retry int, delay time.Duration) {
+				if !IsRetriableSendError(err) {
+					t.Errorf("OnRetry(%v) called; expected a retriable error", err)
+				}
+				mu.Lock()
+				retries = append(retries, retry)
+				mu.Unlock()
+			},
+		},
+	})
+	retried := func() []int {
+		mu.Lock()
+		defer mu.Unlock()
+		r := retries
+		retries = nil
+		return r
+	}
+
+	// temporary errors are retried
+	srv.SetSendErrors(api.ServerError_ServiceNotReady, api.ServerError_TooManyRequests)
+	if _, err = mp.Send(ctx, []byte("hola")); err != nil {
+		t.Fatalf("Send() err = %v; expected nil", err)
+	}
+	if got, expected := retried(), []int{1, 2}; len(got) != len(expected) || got[0] != expected[0] || got[1] != expected[1] {
+		t.Fatalf("retries = %v; expected %v", got, expected)
+	}
+
+	// up to MaxRetries
+	srv.SetSendErrors(api.ServerError_ServiceNotReady, api.ServerError_ServiceNotReady, api.ServerError_ServiceNotReady)
+	_, err = mp.Send(ctx, []byte("hola"))
+	var serverErr *utils.ServerError
+	if !errors.As(err, &serverErr) || serverErr.Code != api.ServerError_ServiceNotReady {
+		t.Fatalf("Send() err = %v; expected ServiceNotReady", err)
+	}
+	if got, expected := len(retried()), 2; got != expected {
+		t.Fatalf("len(retries) = %d; expected %d", got, expected)
+	}
+
+	// other errors are fatal
+	srv.SetSendErrors(api.ServerError_TopicTerminatedError)
+	if _, err = mp.Send(ctx, []byte("hola")); !errors.As(err, &serverErr) || serverErr.Code != api.ServerError_TopicTerminatedError {
+		t.Fatalf("Send() err = %v; expected TopicTerminatedError", err)
+	}
+	if got := retried(); len(got) != 0 {
+		t.Fatalf("retries = %v; expected none", got)
+	}
+}
@@ -0,0 +1,289 @@
+// Copyright 2018 Comcast Cable Communications Management, LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package manage
+
+import (
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/pepper-iot/pulsar-client-go/core/frame"
+	"github.com/pepper-iot/pulsar-client-go/utils"
+)
+
+// Stats is a point-in-time snapshot of a ClientPool. It is
+// JSON-serializable, e.g. for exposing on a debug HTTP endpoint.
+type Stats struct {
+	Time        time.Time       `json:"time"`
+	Connections []ConnStats     `json:"connections"` // sorted by address
+	Producers   []ProducerStats `json:"producers"`   // sorted by topic
+	Consumers   []ConsumerStats `json:"consumers"`   // sorted by topic
+	Events      []Event         `json:"events"`      // recent lifecycle events, oldest first
+}
+
+// ConnStats describes a broker connection.
+type ConnStats struct {
+	Addr       string                `json:"addr"`
+	Connected  bool                  `json:"connected"` // false while reconnecting
+	Producers  int                   `json:"producers"`
+	Consumers  int                   `json:"consumers"`
+	Dispatcher frame.DispatcherStats `json:"dispatcher"`
+
+	DroppedErrors uint64 `json:"dropped_errors"` // asynchronous errors dropped because Errs was full
+}
+
+// ProducerStats describes a ManagedProducer.
+type ProducerStats struct {
+	Topic       string                  `json:"topic"`
+	Name        string                  `json:"name"`
+	State       EntityState             `json:"state"`
+	Reconnects  int                     `json:"reconnects"`
+	Sent        uint64                  `json:"sent"`       // successful sends
+	SentBytes   uint64                  `json:"sent_bytes"` // payload bytes of successful sends
+	SendErrors  uint64                  `json:"send_errors"`
+	SendRate    float64                 `json:"send_rate"` // sends per second since the previous snapshot
+	Pending     int                     `json:"pending"`   // sends queued while the Producer is unavailable
+	InFlight    int                     `json:"in_flight"` // sends awaiting their receipt
+	SendLatency utils.HistogramSnapshot `json:"send_latency"`
+	SendP50     time.Duration           `json:"send_p50"` // median of SendLatency
+	SendP99     time.Duration           `json:"send_p99"` // 99th percentile of SendLatency
+
+	DroppedErrors uint64 `json:"dropped_errors"` // asynchronous errors dropped because Errs was full
+}
+
+// ConsumerStats describes a ManagedConsumer.
+type ConsumerStats struct {
+	Topic       string                  `json:"topic"`
+	Name        string                  `json:"name"`
+	State       EntityState             `json:"state"`
+	Reconnects  int                     `json:"reconnects"`
+	Received    uint64                  `json:"received"` // messages returned by Receive or ReceiveAsync
+	Acked       uint64                  `json:"acked"`
+	ReceiveRate float64                 `json:"receive_rate"` // messages per second since the previous snapshot
+	QueueDepth  int                     `json:"queue_depth"`  // messages buffered by the Consumer
+	Permits     int64                   `json:"permits"`      // flow permits not used yet
+	Overflowed  uint64                  `json:"overflowed"`
+	Dropped     uint64                  `json:"dropped"`
+	AckLatency  utils.HistogramSnapshot `json:"ack_latency"`
+	E2ELatency  utils.HistogramSnapshot `json:"e2e_latency"` // from publishing messages to receiving them
+	ClockSkew   time.Duration           `json:"clock_skew"`  // estimate of how far the local clock is behind the producers'
+
+	DroppedErrors uint64 `json:"dropped_errors"` // asynchronous errors dropped because Errs was full
+}
+
+// rateCounter is a counter whose rate is measured
+// between consecutive snapshots.
+type rateCounter struct {
+	n uint64 // accessed atomically
+
+	mu    sync.Mutex // protects following
+	prevN uint64
+	prevT time.Time
+}
+
+func (r *rateCounter) inc() {
+	atomic.AddUint64(&r.n, 1)
+}
+
+func (r *rateCounter) load() uint64 {
+	return atomic.LoadUint64(&r.n)
+}
+
+// snapshot returns the counter and its per-second rate since the
+// previous snapshot. The rate of the first snapshot is zero.
+func (r *rateCounter) snapshot(now time.Time) (uint64, float64) {
+	n := r.load()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var rate float64
+	if d := now.Sub(r.prevT); !r.prevT.IsZero() && d > 0 {
+		rate = float64(n-r.prevN) / d.Seconds()
+	}
+	r.prevN, r.prevT = n, now
+	return n, rate
+}
+
+// Stats returns a snapshot of the client's connection.
+func (c *Client) Stats() ConnStats {
+	producers, consumers := c.entities()
+	return ConnStats{
+		Connected:  true,
+		Producers:  len(producers),
+		Consumers:  len(consumers),
+		Dispatcher: c.Dispatcher.Stats(),
+
+		DroppedErrors: atomic.LoadUint64(&c.droppedErrs),
+	}
+}
+
+// stats returns a snapshot of the ManagedProducer.
+func (m *ManagedProducer) stats(now time.Time) ProducerStats {
+	info := m.inspect()
+	sent, rate := m.sent.snapshot(now)
+	latency := m.SendLatency()
+	return ProducerStats{
+		Topic:       info.Topic,
+		Name:        info.Name,
+		State:       info.State,
+		Reconnects:  info.Reconnects,
+		Sent:        sent,
+		SentBytes:   atomic.LoadUint64(&m.sentBytes),
+		SendErrors:  atomic.LoadUint64(&m.sendErrors),
+		SendRate:    rate,
+		Pending:     int(atomic.LoadInt32(&m.queued)),
+		InFlight:    m.inFlight(),
+		SendLatency: latency,
+		SendP50:     latency.Quantile(0.5),
+		SendP99:     latency.Quantile(0.99),
+
+		DroppedErrors: atomic.LoadUint64(&m.droppedErrs),
+	}
+}
+
+// Stats returns a snapshot of the ManagedProducer. The send rate is
+// measured since its previous snapshot, by Stats or ClientPool.Stats.
+func (m *ManagedProducer) Stats() ProducerStats {
+	return m.stats(time.Now())
+}
+
+// inFlight returns the number of sends of the
+// current Producer awaiting their receipt.
+func (m *ManagedProducer) inFlight() int {
+	m.Mu.RLock()
+	defer m.Mu.RUnlock()
+	if m.Producer == nil {
+		return 0
+	}
+	return m.Producer.Pending()
+}
+
+// logStats logs a summary of the sends of the ManagedProducer every
+// StatsInterval, like the Java client does every statsIntervalSeconds,
+// until it is closed. Counts and rates are those of the interval.
+func (m *ManagedProducer) logStats() {
+	ticker := m.Cfg.clock().NewTicker(m.Cfg.StatsInterval)
+	defer ticker.Stop()
+
+	prevSent := m.sent.load()
+	prevBytes := atomic.LoadUint64(&m.sentBytes)
+	prevErrors := atomic.LoadUint64(&m.sendErrors)
+	for {
+		select {
+		case <-ticker.C():
+		case <-m.ctx.Done():
+			return
+		}
+
+		sent := m.sent.load()
+		bytes := atomic.LoadUint64(&m.sentBytes)
+		failed := atomic.LoadUint64(&m.sendErrors)
+		latency := m.SendLatency()
+		secs := m.Cfg.StatsInterval.Seconds()
+		m.logger().Infof("sent %d msgs (%.1f msg/s, %.1f KB/s), %d errors, %d pending, %d in flight, send latency p50 %v p99 %v",
+			sent-prevSent, float64(sent-prevSent)/secs, float64(bytes-prevBytes)/1024/secs, failed-prevErrors,
+			atomic.LoadInt32(&m.queued), m.inFlight(), latency.Quantile(0.5), latency.Quantile(0.99))
+		prevSent, prevBytes, prevErrors = sent, bytes, failed
+	}
+}
+
+// stats returns a snapshot of the ManagedConsumer.
+func (m *ManagedConsumer) stats(now time.Time) ConsumerStats {
+	info := m.inspect()
+	received, rate := m.received.snapshot(now)
+	s := ConsumerStats{
+		Topic:       info.Topic,
+		Name:        info.Name,
+		State:       info.State,
+		Reconnects:  info.Reconnects,
+		Received:    received,
+		Acked:       atomic.LoadUint64(&m.acked),
+		ReceiveRate: rate,
+		Overflowed:  info.Overflowed,
+		Dropped:     info.Dropped,
+		AckLatency:  m.AckLatency(),
+		E2ELatency:  m.E2ELatency(),
+		ClockSkew:   m.ClockSkew(),
+
+		DroppedErrors: atomic.LoadUint64(&m.droppedErrs),
+	}
+	m.mu.RLock()
+	if m.consumer != nil {
+		s.QueueDepth = len(m.consumer.Queue)
+		s.Permits = m.consumer.Permits()
+	}
+	m.mu.RUnlock()
+	return s
+}
+
+// Stats returns a snapshot of every broker connection, ManagedProducer
+// and ManagedConsumer of the pool. Rates are measured since the
+// previous call to Stats.
+func (m *ClientPool) Stats() Stats {
+	s := Stats{
+		Time:        time.Now(),
+		Connections: []ConnStats{},
+		Producers:   []ProducerStats{},
+		Consumers:   []ConsumerStats{},
+		Events:      m.Events(),
+	}
+
+	for i := range m.shards {
+		m.shards[i].pool.Range(func(k, v interface{}) bool {
+			var cs ConnStats
+			mc := v.(*ManagedClient)
+			if client := mc.current(); client != nil {
+				cs = client.Stats()
+			}
+			cs.Addr = k.(clientPoolKey).logicalAddr
+			cs.DroppedErrors += atomic.LoadUint64(&mc.droppedErrs)
+			s.Connections = append(s.Connections, cs)
+			return true
+		})
+	}
+	sort.SliceStable(s.Connections, func(i, j int) bool {
+		return s.Connections[i].Addr < s.Connections[j].Addr
+	})
+
+	m.registry.mu.Lock()
+	entities := make([]inspector, 0, len(m.registry.entities))
+	for e := range m.registry.entities {
+		entities = append(entities, e)
+	}
+	m.registry.mu.Unlock()
+
+	for _, e := range entities {
+		switch e := e.(type) {
+		case *ManagedProducer:
+			s.Producers = append(s.Producers, e.stats(s.Time))
+		case *ManagedConsumer:
+			s.Consumers = append(s.Consumers, e.stats(s.Time))
+		}
+	}
+	sort.Slice(s.Producers, func(i, j int) bool {
+		if s.Producers[i].Topic != s.Producers[j].Topic {
+			return s.Producers[i].Topic < s.Producers[j].Topic
+		}
+		return s.Producers[i].Name < s.Producers[j].Name
+	})
+	sort.Slice(s.Consumers, func(i, j int) bool {
+		if s.Consumers[i].Topic != s.Consumers[j].Topic {
+			return s.Consumers[i].Topic < s.Consumers[j].Topic
+		}
+		return s.Consumers[i].Name < s.Consumers[j].Name
+	})
+
+	return s
+}
@@ -0,0 +1,171 @@
+// Copyright 2018 Comcast Cable Communications Management, LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package manage
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pepper-iot/pulsar-client-go/core/srv"
+	"github.com/pepper-iot/pulsar-client-go/utils"
+)
+
+func TestClientPool_Stats(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	srv, err := srv.NewServer(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cp := NewClientPool()
+	clientCfg := ClientConfig{
+		Addr: srv.Addr,
+	}
+	mp := NewManagedProducer(ctx, cp, ProducerConfig{
+		ClientConfig:       clientCfg,
+		NewProducerTimeout: time.Second,
+		Topic:              "a-topic",
+	})
+	NewManagedConsumer(ctx, cp, ConsumerConfig{
+		ClientConfig:       clientCfg,
+		NewConsumerTimeout: time.Second,
+		Topic:              "b-topic",
+		Name:               "test",
+		SubMode:            SubscriptionModeShard,
+	})
+
+	for i := 0; i < 2; i++ {
+		if _, err = mp.Send(ctx, []byte("hola")); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var s Stats
+	for ctx.Err() == nil {
+		s = cp.Stats()
+		if len(s.Consumers) == 1 && s.Consumers[0].State == EntityConnected {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	if got, expected := len(s.Connections), 1; got != expected {
+		t.Fatalf("len(Connections) = %d; expected %d", got, expected)
+	}
+	conn := s.Connections[0]
+	if !strings.HasSuffix(srv.Addr, conn.Addr) || !conn.Connected || conn.Producers != 1 || conn.Consumers != 1 {
+		t.Fatalf("Connections[0] = %+v; expected a connection to %s with 1 producer and 1 consumer", conn, srv.Addr)
+	}
+
+	if got, expected := len(s.Producers), 1; got != expected {
+		t.Fatalf("len(Producers) = %d; expected %d", got, expected)
+	}
+	p := s.Producers[0]
+	if p.Topic != "a-topic" || p.State != EntityConnected {
+		t.Fatalf("Producers[0] = %+v; expected a connected producer on a-topic", p)
+	}
+	if p.Sent != 2 || p.SentBytes != 8 || p.SendErrors != 0 || p.SendLatency.Count != 2 {
+		t.Fatalf("Producers[0] = %+v; expected 2 sends of 8 bytes", p)
+	}
+	if p.SendRate != 0 {
+		t.Fatalf("Producers[0].SendRate = %v; expected 0 for the first snapshot", p.SendRate)
+	}
+
+	if got, expected := s.Consumers[0].Topic, "b-topic"; got != expected {
+		t.Fatalf("Consumers[0].Topic = %q; expected %q", got, expected)
+	}
+
+	if _, err = mp.Send(ctx, []byte("hola")); err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(10 * time.Millisecond)
+	if rate := cp.Stats().Producers[0].SendRate; rate <= 0 {
+		t.Fatalf("Producers[0].SendRate = %v; expected > 0", rate)
+	}
+
+	if _, err = json.Marshal(s); err != nil {
+		t.Fatalf("json.Marshal() err = %v; nil expected", err)
+	}
+}
+
+func TestClientPool_Stats_DroppedErrors(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	// nobody reads the unbuffered channel, and nothing
+	// listens on port 1, so every error is dropped
+	cp := NewClientPool()
+	mp := NewManagedProducer(ctx, cp, ProducerConfig{
+		ClientConfig: ClientConfig{
+			Addr: "pulsar://127.0.0.1:1",
+			Errs: make(chan error),
+		},
+		NewProducerTimeout:    time.Second,
+		InitialReconnectDelay: 10 * time.Millisecond,
+		Topic:                 "test-topic",
+	})
+	defer mp.Close(ctx)
+
+	for ctx.Err() == nil {
+		s := cp.Stats()
+		if len(s.Producers) == 1 && s.Producers[0].DroppedErrors > 0 &&
+			len(s.Connections) == 1 && s.Connections[0].DroppedErrors > 0 {
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatalf("Stats() = %+v; expected dropped errors", cp.Stats())
+}
+
+func TestManagedProducer_Stats(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	srv, err := srv.NewServer(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	clock := utils.NewManualClock(time.Now())
+	mp := NewManagedProducer(ctx, NewClientPool(), ProducerConfig{
+		ClientConfig: ClientConfig{
+			Addr:  srv.Addr,
+			Clock: clock,
+		},
+		NewProducerTimeout: time.Second,
+		Topic:              "a-topic",
+		StatsInterval:      time.Minute,
+	})
+
+	for i := 0; i < 2; i++ {
+		if _, err = mp.Send(ctx, []byte("hola")); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// logs the stats of the interval
+	clock.Advance(time.Minute)
+
+	s := mp.Stats()
+	if s.Sent != 2 || s.SentBytes != 8 || s.InFlight != 0 || s.Pending != 0 {
+		t.Fatalf("Stats() = %+v; expected 2 sends of 8 bytes, and none in flight", s)
+	}
+	if s.SendP50 <= 0 || s.SendP50 > s.SendP99 {
+		t.Fatalf("Stats() send latency p50 = %v, p99 = %v; expected 0 < p50 <= p99", s.SendP50, s.SendP99)
+	}
+}
@@ -0,0 +1,66 @@
+// Copyright 2018 Comcast Cable Communications Management, LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package msg
+
+import (
+	"encoding/binary"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/pepper-iot/pulsar-client-go/pkg/api"
+)
+
+// AppendBatchPayload appends one element of a batch payload to dst,
+// and returns the extended buffer. An element is made up of:
+//
+//	[SINGLE_META_SIZE] [SINGLE_META] [PAYLOAD]
+//
+// The payload size of meta is set to the length of payload, on a copy
+// if it differs, so meta may be nil or shared between calls.
+func AppendBatchPayload(dst []byte, meta *api.SingleMessageMetadata, payload []byte) ([]byte, error) {
+	if meta == nil {
+		meta = new(api.SingleMessageMetadata)
+	}
+	if meta.PayloadSize == nil || meta.GetPayloadSize() != int32(len(payload)) {
+		meta = proto.Clone(meta).(*api.SingleMessageMetadata)
+		meta.PayloadSize = proto.Int32(int32(len(payload)))
+	}
+
+	metaBuf, err := proto.Marshal(meta)
+	if err != nil {
+		return nil, err
+	}
+
+	var size [4]byte
+	binary.BigEndian.PutUint32(size[:], uint32(len(metaBuf)))
+	dst = append(dst, size[:]...)
+	dst = append(dst, metaBuf...)
+	return append(dst, payload...), nil
+}
+
+// EncodeBatchPayload encodes the elements of a batch into the payload
+// of a message, which is the inverse of DecodeBatchPayload. The
+// SingleMetaSize of each element is ignored. The metadata of the
+// message must set num_messages_in_batch to len(list).
+func EncodeBatchPayload(list []*SingleMessage) ([]byte, error) {
+	var (
+		bp  []byte
+		err error
+	)
+	for _, m := range list {
+		if bp, err = AppendBatchPayload(bp, m.SingleMeta, m.SinglePayload); err != nil {
+			return nil, err
+		}
+	}
+	return bp, nil
+}
@@ -0,0 +1,96 @@
+// Copyright 2018 Comcast Cable Communications Management, LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package msg
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/pepper-iot/pulsar-client-go/pkg/api"
+)
+
+func TestAppendBatchPayload(t *testing.T) {
+	// same bytes as TestDecodeBatchPayload
+	expected := []byte{0, 0, 0, 2, 24, 12, 104, 101, 108, 108, 111, 45, 112, 117, 108, 115, 97, 114}
+
+	got, err := AppendBatchPayload(nil, nil, []byte("hello-pulsar"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, expected) {
+		t.Fatalf("AppendBatchPayload() = % x; expected % x", got, expected)
+	}
+
+	// shared metadata isn't modified
+	meta := &api.SingleMessageMetadata{PayloadSize: proto.Int32(1)}
+	if _, err := AppendBatchPayload(nil, meta, []byte("hello")); err != nil {
+		t.Fatal(err)
+	}
+	if got := meta.GetPayloadSize(); got != 1 {
+		t.Fatalf("payload size = %d; expected 1", got)
+	}
+}
+
+func TestEncodeBatchPayload(t *testing.T) {
+	list := []*SingleMessage{
+		{
+			SingleMeta: &api.SingleMessageMetadata{
+				PartitionKey: proto.String("a"),
+				Properties: []*api.KeyValue{
+					{Key: proto.String("k"), Value: proto.String("v")},
+				},
+			},
+			SinglePayload: []byte("first"),
+		},
+		{
+			SingleMeta:    &api.SingleMessageMetadata{SequenceId: proto.Uint64(1)},
+			SinglePayload: []byte{},
+		},
+		{
+			SinglePayload: []byte("third"),
+		},
+	}
+
+	bp, err := EncodeBatchPayload(list)
+	if err != nil {
+		t.Fatal(err)
+	}
+	decoded, err := DecodeBatchPayload(bp, int32(len(list)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(decoded) != len(list) {
+		t.Fatalf("decoded %d messages; expected %d", len(decoded), len(list))
+	}
+	for i, m := range decoded {
+		if !bytes.Equal(m.SinglePayload, list[i].SinglePayload) {
+			t.Fatalf("message %d: payload = %q; expected %q", i, m.SinglePayload, list[i].SinglePayload)
+		}
+		expected := &api.SingleMessageMetadata{PayloadSize: proto.Int32(int32(len(list[i].SinglePayload)))}
+		if list[i].SingleMeta != nil {
+			expected = proto.Clone(list[i].SingleMeta).(*api.SingleMessageMetadata)
+			expected.PayloadSize = proto.Int32(int32(len(list[i].SinglePayload)))
+		}
+		if !proto.Equal(m.SingleMeta, expected) {
+			t.Fatalf("message %d: metadata = %v; expected %v", i, m.SingleMeta, expected)
+		}
+	}
+
+	// one message too many
+	if _, err := DecodeBatchPayload(bp, int32(len(list)+1)); err != io.EOF {
+		t.Fatalf("DecodeBatchPayload() err = %v; expected %v", err, io.EOF)
+	}
+}
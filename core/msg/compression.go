@@ -0,0 +1,41 @@
+// Copyright 2018 Comcast Cable Communications Management, LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package msg
+
+import (
+	"github.com/pepper-iot/pulsar-client-go/core/compression"
+	"github.com/pepper-iot/pulsar-client-go/pkg/api"
+)
+
+// Decompress replaces the payload of the message with its decompressed
+// form, using the compression.Codec registered for its compression
+// type, and clears the compression type and uncompressed size of its
+// metadata. Payloads are compressed before being encrypted, so those
+// of encrypted messages are left as is.
+func (m *Message) Decompress() error {
+	t := m.Meta.GetCompression()
+	if t == api.CompressionType_NONE || m.IsEncrypted() {
+		return nil
+	}
+
+	payload, err := compression.Decompress(t, m.Payload, int(m.Meta.GetUncompressedSize()))
+	if err != nil {
+		return err
+	}
+	m.ReleasePayload()
+	m.Payload = payload
+	m.Meta.Compression = nil
+	m.Meta.UncompressedSize = nil
+	return nil
+}
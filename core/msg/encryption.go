@@ -0,0 +1,88 @@
+// Copyright 2018 Comcast Cable Communications Management, LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package msg
+
+import (
+	"errors"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/pepper-iot/pulsar-client-go/core/encryption"
+	"github.com/pepper-iot/pulsar-client-go/pkg/api"
+)
+
+// ErrEncrypted is returned when decoding the payload of an
+// encrypted message, which must be decrypted first.
+var ErrEncrypted = errors.New("message payload is encrypted")
+
+// Encryption describes how the payload of a message is encrypted.
+// The payload is encrypted with a symmetric data key, which is
+// itself encrypted with the public key of each consumer that may
+// decrypt it.
+type Encryption struct {
+	Keys  []*api.EncryptionKeys // data key, encrypted with each named public key
+	Algo  string                // algorithm of the data key, if not the default
+	Param []byte                // parameter of the algorithm, e.g. the IV of AES-GCM
+}
+
+// IsEncrypted returns true if the payload of the message is encrypted.
+func (m *Message) IsEncrypted() bool {
+	return len(m.Meta.GetEncryptionKeys()) > 0
+}
+
+// Encryption returns how the payload of the message is encrypted. The
+// second return value is false if it isn't.
+func (m *Message) Encryption() (Encryption, bool) {
+	if !m.IsEncrypted() {
+		return Encryption{}, false
+	}
+	return Encryption{
+		Keys:  m.Meta.GetEncryptionKeys(),
+		Algo:  m.Meta.GetEncryptionAlgo(),
+		Param: m.Meta.GetEncryptionParam(),
+	}, true
+}
+
+// Encryption sets how the payload of the message is encrypted.
+// The payload passed to the Producer must be encrypted already.
+func (b *MetadataBuilder) Encryption(e Encryption) *MetadataBuilder {
+	b.meta.EncryptionKeys = e.Keys
+	b.meta.EncryptionAlgo = nil
+	if e.Algo != "" {
+		b.meta.EncryptionAlgo = proto.String(e.Algo)
+	}
+	b.meta.EncryptionParam = e.Param
+	return b
+}
+
+// Decrypt replaces the payload of an encrypted message with its
+// decrypted form, using the private keys read with reader, and clears
+// the encryption fields of its metadata. Payloads that aren't
+// encrypted are left as is. Once decrypted, a payload may still need
+// to be decompressed.
+func (m *Message) Decrypt(reader encryption.CryptoKeyReader) error {
+	if !m.IsEncrypted() {
+		return nil
+	}
+
+	payload, err := encryption.Decrypt(reader, m.Meta, m.Payload)
+	if err != nil {
+		return err
+	}
+	m.ReleasePayload()
+	m.Payload = payload
+	m.Meta.EncryptionKeys = nil
+	m.Meta.EncryptionAlgo = nil
+	m.Meta.EncryptionParam = nil
+	return nil
+}
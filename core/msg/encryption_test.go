@@ -0,0 +1,62 @@
+// Copyright 2018 Comcast Cable Communications Management, LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package msg
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/pepper-iot/pulsar-client-go/pkg/api"
+)
+
+func TestMessage_Encryption(t *testing.T) {
+	e := Encryption{
+		Keys: []*api.EncryptionKeys{
+			{Key: proto.String("consumer.pem"), Value: []byte{1, 2, 3}},
+		},
+		Algo:  "AES-GCM",
+		Param: []byte("iv"),
+	}
+	meta := NewMetadata().Encryption(e).Build()
+	if got, expected := meta.GetEncryptionAlgo(), e.Algo; got != expected {
+		t.Fatalf("encryption algo = %q; expected %q", got, expected)
+	}
+
+	m := Message{Meta: meta}
+	if !m.IsEncrypted() {
+		t.Fatal("IsEncrypted() = false; expected true")
+	}
+	got, ok := m.Encryption()
+	if !ok {
+		t.Fatal("Encryption() ok = false; expected true")
+	}
+	if !reflect.DeepEqual(got, e) {
+		t.Fatalf("Encryption() = %+v; expected %+v", got, e)
+	}
+
+	// batches can't be decoded until they're decrypted
+	meta.NumMessagesInBatch = proto.Int32(2)
+	if _, err := DecodeBatchMessage(&m); err != ErrEncrypted {
+		t.Fatalf("DecodeBatchMessage() err = %v; expected %v", err, ErrEncrypted)
+	}
+
+	plain := Message{Meta: NewMetadata().Build()}
+	if plain.IsEncrypted() {
+		t.Fatal("IsEncrypted() = true; expected false")
+	}
+	if _, ok := plain.Encryption(); ok {
+		t.Fatal("Encryption() ok = true; expected false")
+	}
+}
@@ -15,11 +15,14 @@ package msg
 
 import (
 	"bytes"
+	"encoding/base64"
 	"encoding/binary"
 	"errors"
 	"io"
+	"time"
 
 	"github.com/golang/protobuf/proto"
+	"github.com/pepper-iot/pulsar-client-go/core/frame"
 	"github.com/pepper-iot/pulsar-client-go/pkg/api"
 )
 
@@ -31,6 +34,104 @@ type Message struct {
 	Msg     *api.CommandMessage
 	Meta    *api.MessageMetadata
 	Payload []byte
+
+	ReceivedAt time.Time // local time the message was received at
+
+	// BrokerEntry is set if the broker exposes broker
+	// entry metadata to clients, and nil otherwise.
+	BrokerEntry *api.BrokerEntryMetadata
+
+	// PooledPayload is set if Payload comes from the frame
+	// payload pool, to which Release returns it.
+	PooledPayload bool
+}
+
+// BrokerTimestamp returns the time the broker stored the message at,
+// or the zero Time if the broker doesn't expose broker entry metadata.
+func (m *Message) BrokerTimestamp() time.Time {
+	ts := m.BrokerEntry.GetBrokerTimestamp()
+	if ts == 0 {
+		return time.Time{}
+	}
+	return time.Unix(0, int64(ts)*int64(time.Millisecond))
+}
+
+// EventTime returns the application-defined time of the message,
+// or the zero Time if the producer didn't set one.
+func (m *Message) EventTime() time.Time {
+	ts := m.Meta.GetEventTime()
+	if ts == 0 {
+		return time.Time{}
+	}
+	return time.Unix(0, int64(ts)*int64(time.Millisecond))
+}
+
+// Index returns the index of the message in the topic partition, and
+// false if the broker doesn't expose broker entry metadata or indexes.
+func (m *Message) Index() (uint64, bool) {
+	if m.BrokerEntry == nil || m.BrokerEntry.Index == nil {
+		return 0, false
+	}
+	return m.BrokerEntry.GetIndex(), true
+}
+
+// SchemaVersion returns the version of the topic's schema the
+// message was produced with, or nil if the producer didn't set one.
+func (m *Message) SchemaVersion() []byte {
+	return m.Meta.GetSchemaVersion()
+}
+
+// Key returns the partition key of the message, which is base64
+// encoded if it is a binary key, or "" if the producer didn't set one.
+func (m *Message) Key() string {
+	return m.Meta.GetPartitionKey()
+}
+
+// KeyBytes returns the partition key of the message, decoded if it is
+// a binary key. A binary key that isn't valid base64 is returned as is.
+func (m *Message) KeyBytes() []byte {
+	key := m.Meta.GetPartitionKey()
+	if m.Meta.GetPartitionKeyB64Encoded() {
+		if b, err := base64.StdEncoding.DecodeString(key); err == nil {
+			return b
+		}
+	}
+	return []byte(key)
+}
+
+// Properties returns the application-defined properties
+// of the message, or nil if it has none.
+func (m *Message) Properties() map[string]string {
+	kvs := m.Meta.GetProperties()
+	if len(kvs) == 0 {
+		return nil
+	}
+	props := make(map[string]string, len(kvs))
+	for _, kv := range kvs {
+		props[kv.GetKey()] = kv.GetValue()
+	}
+	return props
+}
+
+// Release returns the metadata of the message to the pool frames are
+// decoded with, so that the next messages reuse it, as well as its
+// payload if PooledPayload is set, and resets the message. It must only
+// be called once nothing uses the message, its metadata or its payload
+// anymore.
+func (m *Message) Release() {
+	frame.ReleaseMessageMetadata(m.Meta)
+	m.ReleasePayload()
+	*m = Message{}
+}
+
+// ReleasePayload returns the payload of the message to the frame
+// payload pool if PooledPayload is set, and clears it. It must only be
+// called once nothing uses the payload anymore.
+func (m *Message) ReleasePayload() {
+	if m.PooledPayload {
+		frame.ReleasePayload(m.Payload)
+	}
+	m.Payload, m.PooledPayload = nil, false
 }
 
 // Equal returns true if the provided other Message
@@ -49,8 +150,12 @@ type SingleMessage struct {
 	SinglePayload  []byte
 }
 
-// DecodeBatchMessage decode message if num_messages_in_batch exist and bigger than 0
+// DecodeBatchMessage decode message if num_messages_in_batch exist and bigger than 0.
+// The payload of an encrypted message can't be decoded until it's decrypted.
 func DecodeBatchMessage(msg *Message) ([]*SingleMessage, error) {
+	if msg.IsEncrypted() {
+		return nil, ErrEncrypted
+	}
 	num := msg.Meta.GetNumMessagesInBatch()
 	if num == 0 || msg.Meta.NumMessagesInBatch == nil {
 		return nil, errors.New("num_message_in_batch is nil or 0")
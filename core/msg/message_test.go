@@ -1,7 +1,13 @@
 package msg
 
 import (
+	"reflect"
 	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/pepper-iot/pulsar-client-go/core/frame"
+	"github.com/pepper-iot/pulsar-client-go/pkg/api"
 )
 
 func TestDecodeBatchPayload(t *testing.T) {
@@ -19,3 +25,98 @@ func TestDecodeBatchPayload(t *testing.T) {
 		t.Errorf("want %v, but get %v", get, want)
 	}
 }
+
+func TestMessage_BrokerEntry(t *testing.T) {
+	var m Message
+	if got := m.BrokerTimestamp(); !got.IsZero() {
+		t.Fatalf("BrokerTimestamp() = %v; expected zero", got)
+	}
+	if _, ok := m.Index(); ok {
+		t.Fatal("Index() ok = true; expected false")
+	}
+
+	m.BrokerEntry = &api.BrokerEntryMetadata{
+		BrokerTimestamp: proto.Uint64(1513027321005),
+		Index:           proto.Uint64(0),
+	}
+	if got, expected := m.BrokerTimestamp(), time.Unix(1513027321, 5*int64(time.Millisecond)); !got.Equal(expected) {
+		t.Fatalf("BrokerTimestamp() = %v; expected %v", got, expected)
+	}
+	if index, ok := m.Index(); !ok || index != 0 {
+		t.Fatalf("Index() = %d, %t; expected 0, true", index, ok)
+	}
+}
+
+func TestMessage_EventTime(t *testing.T) {
+	var m Message
+	if got := m.EventTime(); !got.IsZero() {
+		t.Fatalf("EventTime() = %v; expected zero", got)
+	}
+
+	expected := time.Unix(1513027321, 5*int64(time.Millisecond))
+	m.Meta = NewMetadata().EventTime(expected).Build()
+	if got := m.EventTime(); !got.Equal(expected) {
+		t.Fatalf("EventTime() = %v; expected %v", got, expected)
+	}
+}
+
+func TestMessage_Key(t *testing.T) {
+	var m Message
+	if got := m.Key(); got != "" {
+		t.Fatalf("Key() = %q; expected none", got)
+	}
+
+	m.Meta = NewMetadata().Key("device-42").Build()
+	if got, expected := m.Key(), "device-42"; got != expected {
+		t.Fatalf("Key() = %q; expected %q", got, expected)
+	}
+	if got, expected := string(m.KeyBytes()), "device-42"; got != expected {
+		t.Fatalf("KeyBytes() = %q; expected %q", got, expected)
+	}
+
+	m.Meta = NewMetadata().KeyBytes([]byte{0, 0xff}).Build()
+	if got, expected := m.Key(), "AP8="; got != expected {
+		t.Fatalf("Key() = %q; expected %q", got, expected)
+	}
+	if got, expected := string(m.KeyBytes()), "\x00\xff"; got != expected {
+		t.Fatalf("KeyBytes() = %q; expected %q", got, expected)
+	}
+}
+
+func TestMessage_Properties(t *testing.T) {
+	var m Message
+	if got := m.Properties(); got != nil {
+		t.Fatalf("Properties() = %v; expected nil", got)
+	}
+
+	m.Meta = NewMetadata().Property("a", "1").Property("b", "2").Build()
+	if got, expected := m.Properties(), map[string]string{"a": "1", "b": "2"}; !reflect.DeepEqual(got, expected) {
+		t.Fatalf("Properties() = %v; expected %v", got, expected)
+	}
+}
+
+func TestMessage_Release(t *testing.T) {
+	m := Message{
+		Msg:     &api.CommandMessage{ConsumerId: proto.Uint64(1)},
+		Meta:    &api.MessageMetadata{ProducerName: proto.String("go")},
+		Payload: []byte("hi"),
+	}
+	meta := m.Meta
+
+	m.Release()
+	if m.Meta != nil || m.Msg != nil || m.Payload != nil {
+		t.Fatalf("released message isn't reset: %+v", m)
+	}
+	if meta.ProducerName != nil {
+		t.Fatalf("released metadata isn't reset: %v", meta)
+	}
+
+	// releasing nothing is fine
+	m.Release()
+
+	m = Message{Payload: frame.NewPayload(2), PooledPayload: true}
+	m.Release()
+	if m.Payload != nil || m.PooledPayload {
+		t.Fatalf("released message isn't reset: %+v", m)
+	}
+}
@@ -0,0 +1,185 @@
+// Copyright 2018 Comcast Cable Communications Management, LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package msg
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/pepper-iot/pulsar-client-go/pkg/api"
+)
+
+// MessageID is the position of a message in a topic. It can be stored,
+// e.g. as a checkpoint, and turned back into a position to seek to.
+type MessageID struct {
+	LedgerID   uint64
+	EntryID    uint64
+	Partition  int32 // -1 if the topic isn't partitioned
+	BatchIndex int32 // -1 if the message isn't part of a batch
+}
+
+// NewMessageID returns the MessageID of the given message ID data.
+func NewMessageID(id *api.MessageIdData) MessageID {
+	return MessageID{
+		LedgerID:   id.GetLedgerId(),
+		EntryID:    id.GetEntryId(),
+		Partition:  id.GetPartition(),
+		BatchIndex: id.GetBatchIndex(),
+	}
+}
+
+// ID returns the MessageID of the message.
+func (m *Message) ID() MessageID {
+	return NewMessageID(m.Msg.GetMessageId())
+}
+
+// Compare returns -1 if id is before other, 1 if it is after other and
+// 0 if they're equal. IDs are ordered by ledger, entry, partition, then
+// batch index, like in the Java client. A message that isn't part of a
+// batch is before the messages of a batch in the same entry.
+//
+// Only the IDs of the same topic partition give the order in which
+// the messages were stored.
+func (id MessageID) Compare(other MessageID) int {
+	switch {
+	case id.LedgerID != other.LedgerID:
+		return compare(id.LedgerID < other.LedgerID)
+	case id.EntryID != other.EntryID:
+		return compare(id.EntryID < other.EntryID)
+	case id.Partition != other.Partition:
+		return compare(id.Partition < other.Partition)
+	case id.BatchIndex != other.BatchIndex:
+		return compare(id.BatchIndex < other.BatchIndex)
+	}
+	return 0
+}
+
+// compare returns -1 if less is true, and 1 otherwise.
+func compare(less bool) int {
+	if less {
+		return -1
+	}
+	return 1
+}
+
+// Before returns true if id is before other.
+func (id MessageID) Before(other MessageID) bool {
+	return id.Compare(other) < 0
+}
+
+// Equals returns true if id and other identify the same message.
+func (id MessageID) Equals(other MessageID) bool {
+	return id == other
+}
+
+// Data returns the message ID data of id, e.g. to seek to it.
+func (id MessageID) Data() *api.MessageIdData {
+	d := &api.MessageIdData{
+		LedgerId: proto.Uint64(id.LedgerID),
+		EntryId:  proto.Uint64(id.EntryID),
+	}
+	if id.Partition >= 0 {
+		d.Partition = proto.Int32(id.Partition)
+	}
+	if id.BatchIndex >= 0 {
+		d.BatchIndex = proto.Int32(id.BatchIndex)
+	}
+	return d
+}
+
+// String formats id as ledger:entry:partition, followed by :batchIndex
+// if the message is part of a batch, like the Java client does.
+func (id MessageID) String() string {
+	s := fmt.Sprintf("%d:%d:%d", id.LedgerID, id.EntryID, id.Partition)
+	if id.BatchIndex >= 0 {
+		s += ":" + strconv.FormatInt(int64(id.BatchIndex), 10)
+	}
+	return s
+}
+
+// ParseMessageID parses a message ID formatted by MessageID.String.
+// The partition and batch index are optional, and default to -1.
+func ParseMessageID(s string) (MessageID, error) {
+	parts := strings.Split(s, ":")
+	if len(parts) < 2 || len(parts) > 4 {
+		return MessageID{}, fmt.Errorf("invalid message ID %q: expected ledger:entry[:partition[:batchIndex]]", s)
+	}
+
+	id := MessageID{Partition: -1, BatchIndex: -1}
+	var err error
+	if id.LedgerID, err = strconv.ParseUint(parts[0], 10, 64); err != nil {
+		return MessageID{}, fmt.Errorf("invalid message ID %q: %v", s, err)
+	}
+	if id.EntryID, err = strconv.ParseUint(parts[1], 10, 64); err != nil {
+		return MessageID{}, fmt.Errorf("invalid message ID %q: %v", s, err)
+	}
+	for i, field := range []*int32{&id.Partition, &id.BatchIndex}[:len(parts)-2] {
+		n, err := strconv.ParseInt(parts[i+2], 10, 32)
+		if err != nil || n < -1 {
+			return MessageID{}, fmt.Errorf("invalid message ID %q: invalid field %q", s, parts[i+2])
+		}
+		*field = int32(n)
+	}
+	return id, nil
+}
+
+// Bytes serializes id as a MessageIdData protobuf, the format
+// of the Java client's MessageId.toByteArray.
+func (id MessageID) Bytes() []byte {
+	b, err := proto.Marshal(id.Data())
+	if err != nil {
+		// only possible with missing required fields, which Data always sets
+		panic(err)
+	}
+	return b
+}
+
+// MessageIDFromBytes parses a message ID serialized by MessageID.Bytes.
+func MessageIDFromBytes(b []byte) (MessageID, error) {
+	d := new(api.MessageIdData)
+	if err := proto.Unmarshal(b, d); err != nil {
+		return MessageID{}, fmt.Errorf("invalid message ID: %v", err)
+	}
+	return NewMessageID(d), nil
+}
+
+// Serialize serializes id, eg to persist a publish position. It is the
+// same as Bytes.
+func (id MessageID) Serialize() []byte {
+	return id.Bytes()
+}
+
+// DeserializeMessageID parses a message ID serialized by
+// MessageID.Serialize.
+func DeserializeMessageID(b []byte) (MessageID, error) {
+	return MessageIDFromBytes(b)
+}
+
+// MarshalText implements encoding.TextMarshaler, so that
+// MessageIDs can be stored as JSON strings.
+func (id MessageID) MarshalText() ([]byte, error) {
+	return []byte(id.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (id *MessageID) UnmarshalText(b []byte) error {
+	parsed, err := ParseMessageID(string(b))
+	if err != nil {
+		return err
+	}
+	*id = parsed
+	return nil
+}
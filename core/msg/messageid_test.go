@@ -0,0 +1,169 @@
+// Copyright 2018 Comcast Cable Communications Management, LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package msg
+
+import (
+	"encoding/json"
+	"sort"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/pepper-iot/pulsar-client-go/pkg/api"
+)
+
+func TestParseMessageID(t *testing.T) {
+	for s, expected := range map[string]MessageID{
+		"12:34":          {LedgerID: 12, EntryID: 34, Partition: -1, BatchIndex: -1},
+		"12:34:-1":       {LedgerID: 12, EntryID: 34, Partition: -1, BatchIndex: -1},
+		"12:34:5":        {LedgerID: 12, EntryID: 34, Partition: 5, BatchIndex: -1},
+		"12:34:-1:7":     {LedgerID: 12, EntryID: 34, Partition: -1, BatchIndex: 7},
+		"12:34:5:7":      {LedgerID: 12, EntryID: 34, Partition: 5, BatchIndex: 7},
+		"0:0:0:0":        {},
+		"1:2:2147483647": {LedgerID: 1, EntryID: 2, Partition: 2147483647, BatchIndex: -1},
+	} {
+		got, err := ParseMessageID(s)
+		if err != nil {
+			t.Fatalf("ParseMessageID(%q) err = %v; expected nil", s, err)
+		}
+		if got != expected {
+			t.Fatalf("ParseMessageID(%q) = %+v; expected %+v", s, got, expected)
+		}
+		again, err := ParseMessageID(got.String())
+		if err != nil || again != got {
+			t.Fatalf("ParseMessageID(%q) = %+v, %v; expected %+v", got.String(), again, err, got)
+		}
+	}
+
+	for _, s := range []string{"", "12", "12:34:5:7:9", "a:34", "12:b", "12:34:c", "12:34:-2", "12:34:5:2147483648", "-1:34"} {
+		if _, err := ParseMessageID(s); err == nil {
+			t.Fatalf("ParseMessageID(%q) err = nil; expected an error", s)
+		}
+	}
+}
+
+func TestMessageIDBytes(t *testing.T) {
+	for _, id := range []MessageID{
+		{LedgerID: 12, EntryID: 34, Partition: -1, BatchIndex: -1},
+		{LedgerID: 12, EntryID: 34, Partition: 5, BatchIndex: 7},
+	} {
+		got, err := MessageIDFromBytes(id.Bytes())
+		if err != nil {
+			t.Fatalf("MessageIDFromBytes() err = %v; expected nil", err)
+		}
+		if got != id {
+			t.Fatalf("MessageIDFromBytes() = %+v; expected %+v", got, id)
+		}
+		if got, err = DeserializeMessageID(id.Serialize()); err != nil || got != id {
+			t.Fatalf("DeserializeMessageID() = %+v, %v; expected %+v", got, err, id)
+		}
+	}
+
+	// as serialized by other clients
+	b, err := proto.Marshal(&api.MessageIdData{
+		LedgerId:   proto.Uint64(12),
+		EntryId:    proto.Uint64(34),
+		Partition:  proto.Int32(5),
+		BatchIndex: proto.Int32(7),
+		BatchSize:  proto.Int32(10),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := MessageIDFromBytes(b)
+	if err != nil {
+		t.Fatalf("MessageIDFromBytes() err = %v; expected nil", err)
+	}
+	if expected := (MessageID{LedgerID: 12, EntryID: 34, Partition: 5, BatchIndex: 7}); got != expected {
+		t.Fatalf("MessageIDFromBytes() = %+v; expected %+v", got, expected)
+	}
+
+	if _, err := MessageIDFromBytes([]byte{0xff}); err == nil {
+		t.Fatal("MessageIDFromBytes() err = nil; expected an error")
+	}
+}
+
+func TestMessageIDJSON(t *testing.T) {
+	type checkpoint struct {
+		ID MessageID `json:"id"`
+	}
+	in := checkpoint{ID: MessageID{LedgerID: 12, EntryID: 34, Partition: 5, BatchIndex: -1}}
+	b, err := json.Marshal(in)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, expected := string(b), `{"id":"12:34:5"}`; got != expected {
+		t.Fatalf("json.Marshal() = %s; expected %s", got, expected)
+	}
+	var out checkpoint
+	if err := json.Unmarshal(b, &out); err != nil {
+		t.Fatalf("json.Unmarshal() err = %v; expected nil", err)
+	}
+	if out != in {
+		t.Fatalf("json.Unmarshal() = %+v; expected %+v", out, in)
+	}
+}
+
+func TestMessage_ID(t *testing.T) {
+	m := Message{Msg: &api.CommandMessage{MessageId: &api.MessageIdData{
+		LedgerId: proto.Uint64(12),
+		EntryId:  proto.Uint64(34),
+	}}}
+	if got, expected := m.ID(), (MessageID{LedgerID: 12, EntryID: 34, Partition: -1, BatchIndex: -1}); got != expected {
+		t.Fatalf("ID() = %+v; expected %+v", got, expected)
+	}
+	if !proto.Equal(m.ID().Data(), m.Msg.GetMessageId()) {
+		t.Fatalf("Data() = %v; expected %v", m.ID().Data(), m.Msg.GetMessageId())
+	}
+}
+
+func TestMessageIDCompare(t *testing.T) {
+	// in order
+	ids := []MessageID{
+		{LedgerID: 1, EntryID: 9, Partition: 3, BatchIndex: 9},
+		{LedgerID: 2, EntryID: 0, Partition: -1, BatchIndex: -1},
+		{LedgerID: 2, EntryID: 1, Partition: -1, BatchIndex: -1},
+		{LedgerID: 2, EntryID: 1, Partition: 0, BatchIndex: -1},
+		{LedgerID: 2, EntryID: 1, Partition: 0, BatchIndex: 0},
+		{LedgerID: 2, EntryID: 1, Partition: 0, BatchIndex: 1},
+		{LedgerID: 2, EntryID: 2, Partition: 0, BatchIndex: -1},
+	}
+	for i, a := range ids {
+		for j, b := range ids {
+			expected := 0
+			switch {
+			case i < j:
+				expected = -1
+			case i > j:
+				expected = 1
+			}
+			if got := a.Compare(b); got != expected {
+				t.Fatalf("%v.Compare(%v) = %d; expected %d", a, b, got, expected)
+			}
+			if got := a.Before(b); got != (i < j) {
+				t.Fatalf("%v.Before(%v) = %t; expected %t", a, b, got, i < j)
+			}
+			if got := a.Equals(b); got != (i == j) {
+				t.Fatalf("%v.Equals(%v) = %t; expected %t", a, b, got, i == j)
+			}
+		}
+	}
+
+	shuffled := []MessageID{ids[4], ids[0], ids[6], ids[2], ids[5], ids[1], ids[3]}
+	sort.Slice(shuffled, func(i, j int) bool { return shuffled[i].Before(shuffled[j]) })
+	for i := range ids {
+		if !shuffled[i].Equals(ids[i]) {
+			t.Fatalf("sorted[%d] = %v; expected %v", i, shuffled[i], ids[i])
+		}
+	}
+}
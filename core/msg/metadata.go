@@ -0,0 +1,124 @@
+// Copyright 2018 Comcast Cable Communications Management, LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package msg
+
+import (
+	"encoding/base64"
+	"sort"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/pepper-iot/pulsar-client-go/pkg/api"
+)
+
+// MetadataBuilder builds the MessageMetadata of a message to send,
+// e.g. with pub.Producer.SendWithMetadata:
+//
+//	meta := msg.NewMetadata().
+//		Key("device-42").
+//		Property("source", "gateway").
+//		EventTime(t).
+//		Build()
+//
+// The fields managed by the Producer, like the sequence id,
+// producer name and publish time, are set when sending.
+type MetadataBuilder struct {
+	meta api.MessageMetadata
+}
+
+// NewMetadata returns an empty MetadataBuilder.
+func NewMetadata() *MetadataBuilder {
+	return &MetadataBuilder{}
+}
+
+// Key sets the partition key of the message, which
+// determines its partition and Key_Shared consumer.
+func (b *MetadataBuilder) Key(key string) *MetadataBuilder {
+	b.meta.PartitionKey = proto.String(key)
+	b.meta.PartitionKeyB64Encoded = nil
+	return b
+}
+
+// KeyBytes sets a binary partition key, which is base64 encoded
+// since partition keys are strings in the protocol.
+func (b *MetadataBuilder) KeyBytes(key []byte) *MetadataBuilder {
+	b.meta.PartitionKey = proto.String(base64.StdEncoding.EncodeToString(key))
+	b.meta.PartitionKeyB64Encoded = proto.Bool(true)
+	return b
+}
+
+// OrderingKey sets the key used to order messages for Key_Shared
+// consumers, in place of the partition key.
+func (b *MetadataBuilder) OrderingKey(key []byte) *MetadataBuilder {
+	b.meta.OrderingKey = key
+	return b
+}
+
+// Property adds a property to the message,
+// replacing any property with the same key.
+func (b *MetadataBuilder) Property(key, value string) *MetadataBuilder {
+	for _, kv := range b.meta.Properties {
+		if kv.GetKey() == key {
+			kv.Value = proto.String(value)
+			return b
+		}
+	}
+	b.meta.Properties = append(b.meta.Properties, &api.KeyValue{
+		Key:   proto.String(key),
+		Value: proto.String(value),
+	})
+	return b
+}
+
+// Properties adds the properties to the message, in key order
+// so that the encoded metadata doesn't depend on map iteration.
+func (b *MetadataBuilder) Properties(props map[string]string) *MetadataBuilder {
+	keys := make([]string, 0, len(props))
+	for k := range props {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		b.Property(k, props[k])
+	}
+	return b
+}
+
+// EventTime sets the application-defined time of the
+// message, with millisecond precision.
+func (b *MetadataBuilder) EventTime(t time.Time) *MetadataBuilder {
+	b.meta.EventTime = proto.Uint64(uint64(t.UnixNano() / int64(time.Millisecond)))
+	return b
+}
+
+// DeliverAt sets the time the message is delivered at, with
+// millisecond precision. Only Shared and Key_Shared subscriptions
+// delay messages: others receive them right away.
+func (b *MetadataBuilder) DeliverAt(t time.Time) *MetadataBuilder {
+	b.meta.DeliverAtTime = proto.Int64(t.UnixNano() / int64(time.Millisecond))
+	return b
+}
+
+// SchemaVersion sets the version of the topic's
+// schema the payload is encoded with.
+func (b *MetadataBuilder) SchemaVersion(version []byte) *MetadataBuilder {
+	b.meta.SchemaVersion = version
+	return b
+}
+
+// Build returns the MessageMetadata. The builder
+// may be reused to build further metadata.
+func (b *MetadataBuilder) Build() *api.MessageMetadata {
+	return proto.Clone(&b.meta).(*api.MessageMetadata)
+}
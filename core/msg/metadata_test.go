@@ -0,0 +1,57 @@
+// Copyright 2018 Comcast Cable Communications Management, LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package msg
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/pepper-iot/pulsar-client-go/pkg/api"
+)
+
+func TestMetadataBuilder(t *testing.T) {
+	eventTime := time.Date(2018, 1, 2, 3, 4, 5, 6e6, time.UTC)
+
+	b := NewMetadata().
+		Key("device-42").
+		OrderingKey([]byte("order")).
+		Properties(map[string]string{"b": "2", "a": "1"}).
+		Property("b", "3").
+		EventTime(eventTime).
+		DeliverAt(eventTime.Add(time.Minute)).
+		SchemaVersion([]byte{0, 1})
+	got := b.Build()
+
+	expected := &api.MessageMetadata{
+		PartitionKey: proto.String("device-42"),
+		OrderingKey:  []byte("order"),
+		Properties: []*api.KeyValue{
+			{Key: proto.String("a"), Value: proto.String("1")},
+			{Key: proto.String("b"), Value: proto.String("3")},
+		},
+		EventTime:     proto.Uint64(uint64(eventTime.UnixNano() / int64(time.Millisecond))),
+		DeliverAtTime: proto.Int64(eventTime.Add(time.Minute).UnixNano() / int64(time.Millisecond)),
+		SchemaVersion: []byte{0, 1},
+	}
+	if !proto.Equal(got, expected) {
+		t.Fatalf("Build() = %v; expected %v", got, expected)
+	}
+
+	// built metadata doesn't change with the builder
+	b.Key("other")
+	if got.GetPartitionKey() != "device-42" {
+		t.Fatalf("built partition key changed to %q", got.GetPartitionKey())
+	}
+}
@@ -25,3 +25,25 @@ func (r *MonotonicID) Next() *uint64 {
 	nid := atomic.AddUint64(&r.ID, 1) - 1
 	return &nid
 }
+
+// NextN reserves n consecutive IDs, e.g. the sequence IDs of
+// the messages of a batch, and returns the first one.
+func (r *MonotonicID) NextN(n uint64) *uint64 {
+	nid := atomic.AddUint64(&r.ID, n) - n
+	return &nid
+}
+
+// Reserve reserves id, and all the IDs below it, so that Next
+// returns the ID after it. It returns false, and reserves
+// nothing, if id was already reserved.
+func (r *MonotonicID) Reserve(id uint64) bool {
+	for {
+		next := atomic.LoadUint64(&r.ID)
+		if id < next {
+			return false
+		}
+		if atomic.CompareAndSwapUint64(&r.ID, next, id+1) {
+			return true
+		}
+	}
+}
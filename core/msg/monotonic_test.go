@@ -76,3 +76,33 @@ func TestMonotonicIDs_Parallel(t *testing.T) {
 		}
 	}
 }
+
+func TestMonotonicIDs_NextN(t *testing.T) {
+	rid := MonotonicID{42}
+
+	if got, expected := *rid.NextN(10), uint64(42); got != expected {
+		t.Fatalf("NextN(10) = %d; expected %d", got, expected)
+	}
+	if got, expected := *rid.Next(), uint64(52); got != expected {
+		t.Fatalf("Next() = %d; expected %d", got, expected)
+	}
+}
+
+func TestMonotonicIDs_Reserve(t *testing.T) {
+	rid := MonotonicID{42}
+
+	if !rid.Reserve(50) {
+		t.Fatal("Reserve(50) = false; expected true")
+	}
+	if got, expected := *rid.Next(), uint64(51); got != expected {
+		t.Fatalf("Next() = %d; expected %d", got, expected)
+	}
+	for _, id := range []uint64{50, 51} {
+		if rid.Reserve(id) {
+			t.Fatalf("Reserve(%d) = true; expected false", id)
+		}
+	}
+	if !rid.Reserve(52) {
+		t.Fatal("Reserve(52) = false; expected true")
+	}
+}
@@ -0,0 +1,66 @@
+// Copyright 2018 Comcast Cable Communications Management, LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package msg
+
+// MarkerType is the type of a marker message. Markers are control
+// records written to topics by the broker, not by producers, e.g. for
+// transactions and replicated subscriptions. Consumers acknowledge
+// them without delivering them to the application.
+type MarkerType int32
+
+// Marker types, as defined in PulsarMarkers.proto.
+const (
+	MarkerNone                                  MarkerType = 0
+	MarkerReplicatedSubscriptionSnapshotRequest MarkerType = 10
+	MarkerReplicatedSubscriptionSnapshotResp    MarkerType = 11
+	MarkerReplicatedSubscriptionSnapshot        MarkerType = 12
+	MarkerReplicatedSubscriptionUpdate          MarkerType = 13
+	MarkerTxnCommitting                         MarkerType = 20
+	MarkerTxnCommit                             MarkerType = 21
+	MarkerTxnAbort                              MarkerType = 22
+)
+
+// IsTxn returns true for transaction markers.
+func (t MarkerType) IsTxn() bool {
+	return t >= MarkerTxnCommitting && t <= MarkerTxnAbort
+}
+
+// IsReplication returns true for replicated subscription markers.
+func (t MarkerType) IsReplication() bool {
+	return t >= MarkerReplicatedSubscriptionSnapshotRequest && t <= MarkerReplicatedSubscriptionUpdate
+}
+
+// TxnID identifies a transaction.
+type TxnID struct {
+	MostBits  uint64
+	LeastBits uint64
+}
+
+// MarkerType returns the marker type of the message,
+// or MarkerNone if it isn't a marker.
+func (m *Message) MarkerType() MarkerType {
+	return MarkerType(m.Meta.GetMarkerType())
+}
+
+// TxnID returns the transaction the message was produced in. The
+// second return value is false if it wasn't produced in a transaction.
+func (m *Message) TxnID() (TxnID, bool) {
+	if m.Meta == nil || m.Meta.TxnidMostBits == nil {
+		return TxnID{}, false
+	}
+	return TxnID{
+		MostBits:  m.Meta.GetTxnidMostBits(),
+		LeastBits: m.Meta.GetTxnidLeastBits(),
+	}, true
+}
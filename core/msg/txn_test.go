@@ -0,0 +1,79 @@
+// Copyright 2018 Comcast Cable Communications Management, LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package msg
+
+import (
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/pepper-iot/pulsar-client-go/pkg/api"
+)
+
+func TestMessage_MarkerType_TxnID(t *testing.T) {
+	b, err := proto.Marshal(&api.MessageMetadata{
+		ProducerName:   proto.String("p"),
+		SequenceId:     proto.Uint64(1),
+		PublishTime:    proto.Uint64(1),
+		MarkerType:     proto.Int32(int32(MarkerTxnAbort)),
+		TxnidLeastBits: proto.Uint64(7),
+		TxnidMostBits:  proto.Uint64(3),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	meta := new(api.MessageMetadata)
+	if err = proto.Unmarshal(b, meta); err != nil {
+		t.Fatal(err)
+	}
+
+	m := Message{Meta: meta}
+	if got, expected := m.MarkerType(), MarkerTxnAbort; got != expected {
+		t.Fatalf("MarkerType() = %v; expected %v", got, expected)
+	}
+	txnID, ok := m.TxnID()
+	if !ok {
+		t.Fatal("TxnID() ok = false; expected true")
+	}
+	if got, expected := txnID, (TxnID{MostBits: 3, LeastBits: 7}); got != expected {
+		t.Fatalf("TxnID() = %+v; expected %+v", got, expected)
+	}
+
+	plain := Message{Meta: &api.MessageMetadata{}}
+	if got := plain.MarkerType(); got != MarkerNone {
+		t.Fatalf("MarkerType() = %v; expected %v", got, MarkerNone)
+	}
+	if _, ok := plain.TxnID(); ok {
+		t.Fatal("TxnID() ok = true; expected false")
+	}
+}
+
+func TestMarkerType_Kind(t *testing.T) {
+	for _, tc := range []struct {
+		marker           MarkerType
+		txn, replication bool
+	}{
+		{MarkerNone, false, false},
+		{MarkerReplicatedSubscriptionSnapshotRequest, false, true},
+		{MarkerReplicatedSubscriptionUpdate, false, true},
+		{MarkerTxnCommitting, true, false},
+		{MarkerTxnAbort, true, false},
+	} {
+		if got := tc.marker.IsTxn(); got != tc.txn {
+			t.Errorf("MarkerType(%d).IsTxn() = %v; expected %v", tc.marker, got, tc.txn)
+		}
+		if got := tc.marker.IsReplication(); got != tc.replication {
+			t.Errorf("MarkerType(%d).IsReplication() = %v; expected %v", tc.marker, got, tc.replication)
+		}
+	}
+}
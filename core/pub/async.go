@@ -0,0 +1,116 @@
+// Copyright 2018 Comcast Cable Communications Management, LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pub
+
+import (
+	"context"
+
+	"github.com/pepper-iot/pulsar-client-go/pkg/api"
+)
+
+// MaxAsyncSends is the number of sends of SendAsync that may await
+// their SendReceipt at once. SendAsync blocks while there are more.
+const MaxAsyncSends = 4096
+
+// asyncSend is a send of SendAsync awaiting its SendReceipt.
+type asyncSend struct {
+	ctx      context.Context
+	wait     func(context.Context) (*api.CommandSendReceipt, error)
+	callback func(*api.CommandSendReceipt, error)
+}
+
+// SendAsync sends a message without waiting for its SendReceipt:
+// callback is called with the SendReceipt or error once it arrives,
+// or once ctx is done. Callbacks are called in the order of the sends,
+// from a single goroutine of the Producer, so they must not block.
+// Unlike with Send, many messages may be awaiting their SendReceipt
+// without a goroutine each. SendAsync only blocks while MaxAsyncSends
+// sends are awaiting theirs, or until ctx is done.
+func (p *Producer) SendAsync(ctx context.Context, payload []byte, callback func(*api.CommandSendReceipt, error)) {
+	p.SendWithMetadataAsync(ctx, nil, payload, callback)
+}
+
+// SendWithMetadataAsync is like SendAsync, but sends the message with
+// the given metadata, like SendWithMetadata.
+func (p *Producer) SendWithMetadataAsync(ctx context.Context, meta *api.MessageMetadata, payload []byte, callback func(*api.CommandSendReceipt, error)) {
+	p.amu.Lock()
+	if p.asyncSends == nil {
+		p.asyncSlots = make(chan struct{}, MaxAsyncSends)
+		p.asyncSends = make(chan *asyncSend, MaxAsyncSends)
+		go p.trackAsync()
+	}
+	slots := p.asyncSlots
+	p.amu.Unlock()
+
+	select {
+	case slots <- struct{}{}:
+	case <-ctx.Done():
+		callback(nil, ctx.Err())
+		return
+	}
+
+	// sends are written and queued in the same
+	// order, so that their receipts come in order
+	p.amu.Lock()
+	var err error
+	if p.asyncStopped {
+		err = ErrClosedProducer
+	} else {
+		var wait func(context.Context) (*api.CommandSendReceipt, error)
+		if wait, err = p.enqueue(ctx, meta, payload); err == nil {
+			// never blocks: the send has a slot
+			p.asyncSends <- &asyncSend{ctx: ctx, wait: wait, callback: callback}
+		}
+	}
+	p.amu.Unlock()
+
+	if err != nil {
+		<-slots
+		callback(nil, err)
+	}
+}
+
+// trackAsync waits for the SendReceipts of the sends of SendAsync, in
+// order, until the Producer or its connection is closed.
+func (p *Producer) trackAsync() {
+	for {
+		select {
+		case s := <-p.asyncSends:
+			p.completeAsync(s)
+			continue
+		case <-p.Closed():
+		case <-p.ConnClosed():
+		}
+
+		// fail the sends still queued
+		p.amu.Lock()
+		p.asyncStopped = true
+		p.amu.Unlock()
+		for {
+			select {
+			case s := <-p.asyncSends:
+				p.completeAsync(s)
+			default:
+				return
+			}
+		}
+	}
+}
+
+// completeAsync waits for the SendReceipt of s, then calls its callback.
+func (p *Producer) completeAsync(s *asyncSend) {
+	receipt, err := s.wait(s.ctx)
+	<-p.asyncSlots
+	s.callback(receipt, err)
+}
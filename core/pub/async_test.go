@@ -0,0 +1,116 @@
+// Copyright 2018 Comcast Cable Communications Management, LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pub
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/pepper-iot/pulsar-client-go/core/frame"
+	"github.com/pepper-iot/pulsar-client-go/core/msg"
+	"github.com/pepper-iot/pulsar-client-go/pkg/api"
+)
+
+func TestProducer_SendAsync(t *testing.T) {
+	var ms frame.MockSender
+	prodID := uint64(123)
+	reqID := msg.MonotonicID{ID: 43}
+	dispatcher := frame.NewFrameDispatcher()
+
+	p := NewProducer(&ms, dispatcher, &reqID, prodID)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	type result struct {
+		receipt *api.CommandSendReceipt
+		err     error
+	}
+	const n = 100
+	results := make(chan result, n)
+	for i := 0; i < n; i++ {
+		p.SendAsync(ctx, []byte("hola mundo"), func(receipt *api.CommandSendReceipt, err error) {
+			results <- result{receipt, err}
+		})
+	}
+
+	// the sends don't wait for their receipts
+	frames, err := ms.WaitFrames(ctx, n)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, f := range frames {
+		if got, expected := f.BaseCmd.GetSend().GetSequenceId(), uint64(i); got != expected {
+			t.Fatalf("send %d sequence id %d; expected %d", i, got, expected)
+		}
+	}
+
+	for i := uint64(0); i < n; i++ {
+		f := frame.Frame{
+			BaseCmd: &api.BaseCommand{
+				Type: api.BaseCommand_SEND_RECEIPT.Enum(),
+				SendReceipt: &api.CommandSendReceipt{
+					ProducerId: proto.Uint64(prodID),
+					SequenceId: proto.Uint64(i),
+				},
+			},
+		}
+		if err := dispatcher.NotifyProdSeqIDs(prodID, i, f); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// callbacks are called in order
+	for i := uint64(0); i < n; i++ {
+		r := <-results
+		if r.err != nil {
+			t.Fatalf("callback %d err = %v; expected nil", i, r.err)
+		}
+		if got := r.receipt.GetSequenceId(); got != i {
+			t.Fatalf("callback %d receipt sequence id %d; expected %d", i, got, i)
+		}
+	}
+}
+
+func TestProducer_SendAsync_Closed(t *testing.T) {
+	var ms frame.MockSender
+	prodID := uint64(123)
+	reqID := msg.MonotonicID{ID: 43}
+	dispatcher := frame.NewFrameDispatcher()
+
+	p := NewProducer(&ms, dispatcher, &reqID, prodID)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	errs := make(chan error, 2)
+	callback := func(receipt *api.CommandSendReceipt, err error) {
+		errs <- err
+	}
+
+	p.SendAsync(ctx, []byte("hola mundo"), callback)
+	if err := p.HandleCloseProducer(frame.Frame{}); err != nil {
+		t.Fatal(err)
+	}
+	if err := <-errs; err != ErrClosedProducer {
+		t.Fatalf("callback err = %v; expected %v", err, ErrClosedProducer)
+	}
+
+	p.SendAsync(ctx, []byte("hola mundo"), callback)
+	if err := <-errs; err != ErrClosedProducer {
+		t.Fatalf("callback err = %v; expected %v", err, ErrClosedProducer)
+	}
+}
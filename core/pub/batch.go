@@ -0,0 +1,300 @@
+// Copyright 2018 Comcast Cable Communications Management, LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pub
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/pepper-iot/pulsar-client-go/core/msg"
+	"github.com/pepper-iot/pulsar-client-go/pkg/api"
+	"github.com/pepper-iot/pulsar-client-go/utils"
+)
+
+// BatchConfig configures the batching of a Producer's sends.
+// A batch is sent once any of the limits is reached.
+type BatchConfig struct {
+	MaxMessages int           // maximum number of messages in a batch. Defaults to 1000
+	MaxBytes    int           // maximum size of the payloads of a batch. Defaults to 128KB
+	MaxLinger   time.Duration // maximum time a message waits for its batch to be sent. Defaults to 10ms
+	Clock       utils.Clock   // drives MaxLinger. Defaults to utils.RealClock
+}
+
+// setDefaults returns a modified config with appropriate zero values set to defaults.
+func (c BatchConfig) setDefaults() BatchConfig {
+	if c.MaxMessages <= 0 {
+		c.MaxMessages = 1000
+	}
+	if c.MaxBytes <= 0 {
+		c.MaxBytes = 128 * 1024
+	}
+	if c.MaxLinger <= 0 {
+		c.MaxLinger = 10 * time.Millisecond
+	}
+	if c.Clock == nil {
+		c.Clock = utils.RealClock
+	}
+	return c
+}
+
+// EnableBatching makes Send and SendWithMetadata pack the messages
+// sent concurrently into batches, which are sent as a single SEND
+// command with SendBatchPayload. Each call still blocks until the
+// SendReceipt of its batch, which it returns. Messages whose metadata
+// has fields that only apply to a whole batch, such as a schema
+// version or a delivery time, are sent on their own. It must be called
+// once, before the Producer is used.
+func (p *Producer) EnableBatching(cfg BatchConfig) {
+	p.batcher = &batcher{
+		p:   p,
+		cfg: cfg.setDefaults(),
+	}
+}
+
+// Receipt is the result of sending one of the messages of SendBatch.
+type Receipt struct {
+	ID         msg.MessageID // BatchIndex is the index of the message in the batch, or -1 if it was sent on its own
+	SequenceID uint64
+}
+
+// ErrUnbatchable is returned by SendBatch when a message has fields
+// that only apply to a whole batch, such as a sequence id or a
+// delivery time.
+var ErrUnbatchable = errors.New("message can't be sent in a batch")
+
+// SendBatch sends messages as a single batch, in one SEND command, and
+// waits for its SendReceipt, which it maps back to a Receipt for each
+// message, in order. It is meant for callers already aggregating
+// messages, and doesn't depend on EnableBatching, whose current batch
+// is sent first. A single message is sent on its own.
+func (p *Producer) SendBatch(ctx context.Context, msgs []Message) ([]Receipt, error) {
+	if len(msgs) == 0 {
+		return nil, errors.New("no messages to send")
+	}
+
+	singles := make([]*msg.SingleMessage, len(msgs))
+	size := 0
+	for i := range msgs {
+		m := &msgs[i]
+		single, ok := singleMetadata(m.buildMetadata())
+		if !ok || m.SequenceID != nil {
+			return nil, fmt.Errorf("%w: message %d", ErrUnbatchable, i)
+		}
+		singles[i] = &msg.SingleMessage{SingleMeta: single, SinglePayload: m.Payload}
+		size += len(m.Payload)
+	}
+	if err := p.limit(ctx, len(msgs), size); err != nil {
+		return nil, err
+	}
+
+	var intercepted []*api.MessageMetadata
+	if len(p.interceptors) > 0 {
+		// like with EnableBatching, the interceptors see the metadata
+		// of each message, whose fields that apply to it are kept
+		intercepted = make([]*api.MessageMetadata, len(singles))
+		for i, single := range singles {
+			intercepted[i] = metadata(single.SingleMeta)
+			single.SinglePayload = p.interceptors.BeforeSend(ctx, intercepted[i], single.SinglePayload)
+			single.SingleMeta, _ = singleMetadata(intercepted[i])
+		}
+	}
+	acknowledge := func(receipt *api.CommandSendReceipt, err error) {
+		for _, meta := range intercepted {
+			p.interceptors.OnSendAcknowledgement(ctx, meta, receipt, err)
+		}
+	}
+
+	// the current batch was sent first, so
+	// it has the sequence ids below
+	if p.batcher != nil {
+		p.batcher.flush()
+	}
+	first := *p.SeqID.NextN(uint64(len(singles)))
+	wait, err := p.writeBatch(ctx, singles, first)
+	if err != nil {
+		acknowledge(nil, err)
+		return nil, err
+	}
+	receipt, err := wait(ctx)
+	acknowledge(receipt, err)
+	if err != nil {
+		return nil, err
+	}
+
+	id := msg.NewMessageID(receipt.GetMessageId())
+	receipts := make([]Receipt, len(singles))
+	for i := range receipts {
+		if len(singles) > 1 {
+			id.BatchIndex = int32(i)
+		}
+		receipts[i] = Receipt{ID: id, SequenceID: first + uint64(i)}
+	}
+	return receipts, nil
+}
+
+// batcher packs messages into batches.
+type batcher struct {
+	p   *Producer
+	cfg BatchConfig
+
+	mu  sync.Mutex // protects following, and orders the batches sent
+	cur *batch     // batch being filled, if any
+}
+
+// batch is a batch of messages, and the result of sending it.
+type batch struct {
+	msgs  []*msg.SingleMessage
+	size  int         // total size of the payloads
+	timer utils.Timer // sends the batch after MaxLinger
+
+	done    chan struct{} // closed once receipt and err are set
+	receipt *api.CommandSendReceipt
+	err     error
+}
+
+// add adds a message to the current batch, and returns it.
+func (b *batcher) add(single *api.SingleMessageMetadata, payload []byte) *batch {
+	b.mu.Lock()
+	if b.cur != nil && b.cur.size+len(payload) > b.cfg.MaxBytes {
+		b.flushLocked()
+	}
+	if b.cur == nil {
+		cur := &batch{done: make(chan struct{})}
+		cur.timer = b.cfg.Clock.AfterFunc(b.cfg.MaxLinger, func() {
+			b.mu.Lock()
+			if b.cur == cur {
+				b.flushLocked()
+			}
+			b.mu.Unlock()
+		})
+		b.cur = cur
+	}
+	cur := b.cur
+	cur.msgs = append(cur.msgs, &msg.SingleMessage{SingleMeta: single, SinglePayload: payload})
+	cur.size += len(payload)
+	if len(cur.msgs) >= b.cfg.MaxMessages || cur.size >= b.cfg.MaxBytes {
+		b.flushLocked()
+	}
+	b.mu.Unlock()
+	return cur
+}
+
+// flush sends the current batch, if any.
+func (b *batcher) flush() {
+	b.mu.Lock()
+	if b.cur != nil {
+		b.flushLocked()
+	}
+	b.mu.Unlock()
+}
+
+// flushLocked sends the current batch, which must not be nil, and
+// waits for its SendReceipt on a new goroutine. b.mu must be held.
+func (b *batcher) flushLocked() {
+	cur := b.cur
+	b.cur = nil
+	cur.timer.Stop()
+
+	wait, err := b.write(cur)
+	if err != nil {
+		cur.finish(nil, err)
+		return
+	}
+	go func() {
+		// the batch is waited for until the
+		// Producer or its connection is closed
+		cur.finish(wait(context.Background()))
+	}()
+}
+
+// write sends a batch.
+func (b *batcher) write(cur *batch) (func(context.Context) (*api.CommandSendReceipt, error), error) {
+	first := *b.p.SeqID.NextN(uint64(len(cur.msgs)))
+	return b.p.writeBatch(context.Background(), cur.msgs, first)
+}
+
+// writeBatch sends messages in a single payload, like write. Each
+// message is given its own sequence id, starting from first. A single
+// message is sent on its own.
+func (p *Producer) writeBatch(ctx context.Context, msgs []*msg.SingleMessage, first uint64) (func(context.Context) (*api.CommandSendReceipt, error), error) {
+	if len(msgs) == 1 {
+		// no need for a batch
+		m := msgs[0]
+		return p.write(ctx, metadata(m.SingleMeta), m.SinglePayload, first, 1, false)
+	}
+
+	for i, m := range msgs {
+		m.SingleMeta.SequenceId = proto.Uint64(first + uint64(i))
+	}
+	payload, err := msg.EncodeBatchPayload(msgs)
+	if err != nil {
+		return nil, err
+	}
+	return p.write(ctx, new(api.MessageMetadata), payload, first, len(msgs), false)
+}
+
+// wait waits for the batch to be sent and its SendReceipt.
+func (c *batch) wait(ctx context.Context) (*api.CommandSendReceipt, error) {
+	select {
+	case <-c.done:
+		return c.receipt, c.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+func (c *batch) finish(receipt *api.CommandSendReceipt, err error) {
+	c.receipt, c.err = receipt, err
+	close(c.done)
+}
+
+// singleMetadata returns the metadata of a message within a batch,
+// and reports whether meta only sets fields that may differ between
+// the messages of a batch, besides those set by the Producer.
+func singleMetadata(meta *api.MessageMetadata) (*api.SingleMessageMetadata, bool) {
+	single := new(api.SingleMessageMetadata)
+	if meta == nil {
+		return single, true
+	}
+
+	rest := proto.Clone(meta).(*api.MessageMetadata)
+	single.Properties, rest.Properties = rest.Properties, nil
+	single.PartitionKey, rest.PartitionKey = rest.PartitionKey, nil
+	single.PartitionKeyB64Encoded, rest.PartitionKeyB64Encoded = rest.PartitionKeyB64Encoded, nil
+	single.EventTime, rest.EventTime = rest.EventTime, nil
+	single.OrderingKey, rest.OrderingKey = rest.OrderingKey, nil
+	single.NullValue, rest.NullValue = rest.NullValue, nil
+	single.NullPartitionKey, rest.NullPartitionKey = rest.NullPartitionKey, nil
+	rest.SequenceId, rest.ProducerName, rest.PublishTime = nil, nil, nil
+
+	return single, proto.Equal(rest, new(api.MessageMetadata))
+}
+
+// metadata returns the metadata of a message sent on its own,
+// given its metadata within a batch.
+func metadata(single *api.SingleMessageMetadata) *api.MessageMetadata {
+	return &api.MessageMetadata{
+		Properties:             single.Properties,
+		PartitionKey:           single.PartitionKey,
+		PartitionKeyB64Encoded: single.PartitionKeyB64Encoded,
+		EventTime:              single.EventTime,
+		OrderingKey:            single.OrderingKey,
+		NullValue:              single.NullValue,
+		NullPartitionKey:       single.NullPartitionKey,
+	}
+}
@@ -0,0 +1,297 @@
+// Copyright 2018 Comcast Cable Communications Management, LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pub
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/pepper-iot/pulsar-client-go/core/frame"
+	"github.com/pepper-iot/pulsar-client-go/core/msg"
+	"github.com/pepper-iot/pulsar-client-go/pkg/api"
+	"github.com/pepper-iot/pulsar-client-go/utils"
+)
+
+func TestProducer_EnableBatching(t *testing.T) {
+	var ms frame.MockSender
+	prodID := uint64(123)
+	reqID := msg.MonotonicID{ID: 43}
+	dispatcher := frame.NewFrameDispatcher()
+
+	p := NewProducer(&ms, dispatcher, &reqID, prodID)
+	p.EnableBatching(BatchConfig{
+		MaxMessages: 3,
+		MaxBytes:    1024,
+		MaxLinger:   time.Hour,
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	type response struct {
+		receipt *api.CommandSendReceipt
+		err     error
+	}
+	resps := make(chan response, 3)
+	for _, key := range []string{"a", "b", "c"} {
+		go func(key string) {
+			var r response
+			meta := msg.NewMetadata().Key(key).Property("k", key).Build()
+			r.receipt, r.err = p.SendWithMetadata(ctx, meta, []byte("payload "+key))
+			resps <- r
+		}(key)
+	}
+
+	// the third message fills the batch
+	frames, err := ms.WaitFrames(ctx, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sent := frames[0]
+	if got, expected := sent.BaseCmd.GetSend().GetNumMessages(), int32(3); got != expected {
+		t.Fatalf("sent %d messages; expected %d", got, expected)
+	}
+	if got, expected := sent.Metadata.GetNumMessagesInBatch(), int32(3); got != expected {
+		t.Fatalf("sent metadata with %d messages in batch; expected %d", got, expected)
+	}
+	if sent.Metadata.PartitionKey != nil {
+		t.Fatalf("sent batch partition key %q; expected none", sent.Metadata.GetPartitionKey())
+	}
+
+	singles, err := msg.DecodeBatchPayload(sent.Payload, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var keys []string
+	for i, single := range singles {
+		key := single.SingleMeta.GetPartitionKey()
+		keys = append(keys, key)
+		if got, expected := string(single.SinglePayload), "payload "+key; got != expected {
+			t.Fatalf("message %d payload %q; expected %q", i, got, expected)
+		}
+		if props := single.SingleMeta.GetProperties(); len(props) != 1 || props[0].GetValue() != key {
+			t.Fatalf("message %d properties %v; expected k=%s", i, props, key)
+		}
+		if got, expected := single.SingleMeta.GetSequenceId(), uint64(i); got != expected {
+			t.Fatalf("message %d sequence id %d; expected %d", i, got, expected)
+		}
+	}
+	sort.Strings(keys)
+	if got, expected := keys, []string{"a", "b", "c"}; len(got) != 3 || got[0] != expected[0] || got[1] != expected[1] || got[2] != expected[2] {
+		t.Fatalf("batched partition keys %v; expected %v", got, expected)
+	}
+
+	expected := api.CommandSendReceipt{
+		ProducerId:        proto.Uint64(prodID),
+		SequenceId:        proto.Uint64(0),
+		HighestSequenceId: proto.Uint64(2),
+	}
+	f := frame.Frame{
+		BaseCmd: &api.BaseCommand{
+			Type:        api.BaseCommand_SEND_RECEIPT.Enum(),
+			SendReceipt: &expected,
+		},
+	}
+	if err := dispatcher.NotifyProdSeqIDs(prodID, 0, f); err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 3; i++ {
+		if r := <-resps; r.err != nil || !proto.Equal(r.receipt, &expected) {
+			t.Fatalf("SendWithMetadata() = %v, %v; expected %v, nil", r.receipt, r.err, &expected)
+		}
+	}
+}
+
+func TestProducer_EnableBatching_Flush(t *testing.T) {
+	var ms frame.MockSender
+	prodID := uint64(123)
+	reqID := msg.MonotonicID{ID: 43}
+	dispatcher := frame.NewFrameDispatcher()
+
+	// MaxLinger only passes when the manual clock is advanced
+	clock := utils.NewManualClock(time.Now())
+	p := NewProducer(&ms, dispatcher, &reqID, prodID)
+	p.EnableBatching(BatchConfig{
+		MaxMessages: 100,
+		MaxBytes:    10,
+		MaxLinger:   time.Hour,
+		Clock:       clock,
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	send := func(meta *api.MessageMetadata, payload string) {
+		go func() { _, _ = p.SendWithMetadata(ctx, meta, []byte(payload)) }()
+	}
+	expectSent := func(n int, numMessages int32) frame.Frame {
+		t.Helper()
+		frames, err := ms.WaitFrames(ctx, n)
+		if err != nil {
+			t.Fatal(err)
+		}
+		sent := frames[n-1]
+		if got := sent.BaseCmd.GetSend().GetNumMessages(); got != numMessages {
+			t.Fatalf("frame %d has %d messages; expected %d", n, got, numMessages)
+		}
+		return sent
+	}
+	// waits for the current batch to have n messages
+	expectBatched := func(n int) {
+		t.Helper()
+		for {
+			p.batcher.mu.Lock()
+			batched := 0
+			if p.batcher.cur != nil {
+				batched = len(p.batcher.cur.msgs)
+			}
+			p.batcher.mu.Unlock()
+			if batched == n {
+				return
+			}
+
+			select {
+			case <-ctx.Done():
+				t.Fatalf("batch has %d messages; expected %d", batched, n)
+			case <-time.After(time.Millisecond):
+			}
+		}
+	}
+
+	// a batch that would exceed MaxBytes is sent first
+	send(nil, "123456")
+	expectBatched(1)
+	send(nil, "123456")
+	sent := expectSent(1, 1)
+	if sent.Metadata.NumMessagesInBatch != nil {
+		t.Fatalf("a single message was sent as a batch of %d", sent.Metadata.GetNumMessagesInBatch())
+	}
+
+	// the second batch is sent after MaxLinger
+	expectBatched(1)
+	clock.Advance(time.Hour - time.Millisecond)
+	expectBatched(1)
+	clock.Advance(time.Millisecond)
+	expectSent(2, 1)
+
+	// messages with batch-wide metadata are sent on their own
+	send(&api.MessageMetadata{DeliverAtTime: proto.Int64(1)}, "1")
+	if got := expectSent(3, 1).Metadata.GetDeliverAtTime(); got != 1 {
+		t.Fatalf("sent deliver at time %d; expected 1", got)
+	}
+
+	// Flush sends the current batch right away
+	send(nil, "1")
+	send(nil, "2")
+	expectBatched(2)
+	flushCtx, flushCancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer flushCancel()
+	if err := p.Flush(flushCtx); err != context.DeadlineExceeded {
+		t.Fatalf("Flush() err = %v; expected %v without receipts", err, context.DeadlineExceeded)
+	}
+	expectSent(4, 2)
+}
+
+func TestProducer_SendBatch(t *testing.T) {
+	var ms frame.MockSender
+	prodID := uint64(123)
+	reqID := msg.MonotonicID{ID: 43}
+	dispatcher := frame.NewFrameDispatcher()
+
+	p := NewProducer(&ms, dispatcher, &reqID, prodID)
+	p.SeqID.ID = 10
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if _, err := p.SendBatch(ctx, nil); err == nil {
+		t.Fatal("SendBatch() without messages err = nil; expected an error")
+	}
+	delayed := []Message{{Payload: []byte("a")}, {Payload: []byte("b"), DeliverAfter: time.Minute}}
+	if _, err := p.SendBatch(ctx, delayed); !errors.Is(err, ErrUnbatchable) {
+		t.Fatalf("SendBatch() err = %v; expected %v", err, ErrUnbatchable)
+	}
+
+	type response struct {
+		receipts []Receipt
+		err      error
+	}
+	resps := make(chan response, 1)
+	go func() {
+		var r response
+		r.receipts, r.err = p.SendBatch(ctx, []Message{
+			{Payload: []byte("payload a"), Key: "a"},
+			{Payload: []byte("payload b"), Key: "b"},
+			{Payload: []byte("payload c"), Key: "c"},
+		})
+		resps <- r
+	}()
+
+	frames, err := ms.WaitFrames(ctx, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sent := frames[0]
+	if got, expected := sent.Metadata.GetNumMessagesInBatch(), int32(3); got != expected {
+		t.Fatalf("sent metadata with %d messages in batch; expected %d", got, expected)
+	}
+	singles, err := msg.DecodeBatchPayload(sent.Payload, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, key := range []string{"a", "b", "c"} {
+		if got := singles[i].SingleMeta.GetPartitionKey(); got != key {
+			t.Fatalf("sent message %d with key %q; expected %q", i, got, key)
+		}
+	}
+
+	f := frame.Frame{
+		BaseCmd: &api.BaseCommand{
+			Type: api.BaseCommand_SEND_RECEIPT.Enum(),
+			SendReceipt: &api.CommandSendReceipt{
+				ProducerId:        proto.Uint64(prodID),
+				SequenceId:        proto.Uint64(10),
+				HighestSequenceId: proto.Uint64(12),
+				MessageId: &api.MessageIdData{
+					LedgerId: proto.Uint64(5),
+					EntryId:  proto.Uint64(7),
+				},
+			},
+		},
+	}
+	if err := dispatcher.NotifyProdSeqIDs(prodID, 10, f); err != nil {
+		t.Fatal(err)
+	}
+
+	r := <-resps
+	if r.err != nil {
+		t.Fatal(r.err)
+	}
+	if got, expected := len(r.receipts), 3; got != expected {
+		t.Fatalf("got %d receipts; expected %d", got, expected)
+	}
+	for i, receipt := range r.receipts {
+		expected := Receipt{
+			ID:         msg.MessageID{LedgerID: 5, EntryID: 7, Partition: -1, BatchIndex: int32(i)},
+			SequenceID: 10 + uint64(i),
+		}
+		if receipt != expected {
+			t.Fatalf("receipt %d = %+v; expected %+v", i, receipt, expected)
+		}
+	}
+}
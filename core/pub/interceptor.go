@@ -0,0 +1,99 @@
+// Copyright 2018 Comcast Cable Communications Management, LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pub
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/pepper-iot/pulsar-client-go/pkg/api"
+)
+
+// ProducerInterceptor intercepts the messages sent by a Producer, eg
+// to record metrics, audit or transform them. Interceptors are called
+// for each message, including those sent within a batch.
+type ProducerInterceptor interface {
+	// BeforeSend is called before a message is sent, with its metadata,
+	// which it may modify, and its payload. It returns the payload to
+	// send, which may be replaced.
+	BeforeSend(ctx context.Context, meta *api.MessageMetadata, payload []byte) []byte
+	// OnSendAcknowledgement is called once the SendReceipt of a message
+	// passed to BeforeSend, or the error sending it, is received.
+	OnSendAcknowledgement(ctx context.Context, meta *api.MessageMetadata, receipt *api.CommandSendReceipt, err error)
+}
+
+// ProducerInterceptors chains interceptors into one, which calls
+// them in order, passing each the payload returned by the previous.
+type ProducerInterceptors []ProducerInterceptor
+
+// BeforeSend implements ProducerInterceptor.
+func (c ProducerInterceptors) BeforeSend(ctx context.Context, meta *api.MessageMetadata, payload []byte) []byte {
+	for _, i := range c {
+		payload = i.BeforeSend(ctx, meta, payload)
+	}
+	return payload
+}
+
+// OnSendAcknowledgement implements ProducerInterceptor.
+func (c ProducerInterceptors) OnSendAcknowledgement(ctx context.Context, meta *api.MessageMetadata, receipt *api.CommandSendReceipt, err error) {
+	for _, i := range c {
+		i.OnSendAcknowledgement(ctx, meta, receipt, err)
+	}
+}
+
+// AddInterceptor adds an interceptor, which is called after those
+// already added. It must be called before the Producer is used.
+func (p *Producer) AddInterceptor(i ProducerInterceptor) {
+	p.interceptors = append(p.interceptors, i)
+}
+
+// intercepted returns wait, calling the interceptors with the
+// SendReceipt or error it returns for the message of meta.
+func (p *Producer) intercepted(meta *api.MessageMetadata, wait func(context.Context) (*api.CommandSendReceipt, error)) func(context.Context) (*api.CommandSendReceipt, error) {
+	if len(p.interceptors) == 0 {
+		return wait
+	}
+	return func(ctx context.Context) (*api.CommandSendReceipt, error) {
+		receipt, err := wait(ctx)
+		p.interceptors.OnSendAcknowledgement(ctx, meta, receipt, err)
+		return receipt, err
+	}
+}
+
+// traceInterceptor is the ProducerInterceptor of a TraceHook.
+type traceInterceptor struct {
+	TraceHook
+
+	started sync.Map // *api.MessageMetadata -> time.Time of BeforeSend, until acknowledged
+}
+
+func (t *traceInterceptor) BeforeSend(ctx context.Context, meta *api.MessageMetadata, payload []byte) []byte {
+	t.OnSend(ctx, meta, payload)
+	t.started.Store(meta, time.Now())
+	return payload
+}
+
+func (t *traceInterceptor) OnSendAcknowledgement(ctx context.Context, meta *api.MessageMetadata, receipt *api.CommandSendReceipt, err error) {
+	start, ok := t.started.LoadAndDelete(meta)
+	if err != nil {
+		t.OnSendError(ctx, err)
+		return
+	}
+	var latency time.Duration
+	if ok {
+		latency = time.Since(start.(time.Time))
+	}
+	t.OnSendReceipt(ctx, receipt, latency)
+}
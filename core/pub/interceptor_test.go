@@ -0,0 +1,267 @@
+// Copyright 2018 Comcast Cable Communications Management, LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pub
+
+import (
+	"bytes"
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/pepper-iot/pulsar-client-go/core/frame"
+	"github.com/pepper-iot/pulsar-client-go/core/msg"
+	"github.com/pepper-iot/pulsar-client-go/pkg/api"
+)
+
+// recordingInterceptor sets a property on the messages it
+// intercepts, appends its name to their payload and records
+// the acknowledgements.
+type recordingInterceptor struct {
+	name string
+
+	mu   sync.Mutex
+	acks []string // name of the interceptor and key of the message
+}
+
+func (r *recordingInterceptor) BeforeSend(ctx context.Context, meta *api.MessageMetadata, payload []byte) []byte {
+	meta.Properties = append(meta.Properties, &api.KeyValue{
+		Key:   proto.String(r.name),
+		Value: proto.String("seen"),
+	})
+	return append(append([]byte{}, payload...), " "+r.name...)
+}
+
+func (r *recordingInterceptor) OnSendAcknowledgement(ctx context.Context, meta *api.MessageMetadata, receipt *api.CommandSendReceipt, err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if err != nil {
+		r.acks = append(r.acks, r.name+" "+err.Error())
+		return
+	}
+	r.acks = append(r.acks, r.name+" "+meta.GetPartitionKey())
+}
+
+func (r *recordingInterceptor) recorded() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]string(nil), r.acks...)
+}
+
+func TestProducer_AddInterceptor(t *testing.T) {
+	var ms frame.MockSender
+	prodID := uint64(123)
+	reqID := msg.MonotonicID{ID: 43}
+	dispatcher := frame.NewFrameDispatcher()
+
+	p := NewProducer(&ms, dispatcher, &reqID, prodID)
+	first := &recordingInterceptor{name: "first"}
+	second := &recordingInterceptor{name: "second"}
+	p.AddInterceptor(first)
+	p.AddInterceptor(second)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	errs := make(chan error, 1)
+	go func() {
+		_, err := p.SendMessage(ctx, Message{Payload: []byte("hola"), Key: "key"})
+		errs <- err
+	}()
+
+	frames, err := ms.WaitFrames(ctx, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sent := frames[0]
+	if got, expected := sent.Payload, []byte("hola first second"); !bytes.Equal(got, expected) {
+		t.Fatalf("sent payload %q; expected %q", got, expected)
+	}
+	if got, expected := len(sent.Metadata.GetProperties()), 2; got != expected {
+		t.Fatalf("sent %d properties; expected %d", got, expected)
+	}
+	if got := first.recorded(); len(got) != 0 {
+		t.Fatalf("acknowledged %q before the receipt; expected none", got)
+	}
+
+	f := frame.Frame{
+		BaseCmd: &api.BaseCommand{
+			Type: api.BaseCommand_SEND_RECEIPT.Enum(),
+			SendReceipt: &api.CommandSendReceipt{
+				ProducerId: proto.Uint64(prodID),
+				SequenceId: proto.Uint64(0),
+			},
+		},
+	}
+	if err := dispatcher.NotifyProdSeqIDs(prodID, 0, f); err != nil {
+		t.Fatal(err)
+	}
+	if err := <-errs; err != nil {
+		t.Fatal(err)
+	}
+	for _, i := range []*recordingInterceptor{first, second} {
+		if got, expected := i.recorded(), []string{i.name + " key"}; len(got) != 1 || got[0] != expected[0] {
+			t.Fatalf("%s acknowledged %q; expected %q", i.name, got, expected)
+		}
+	}
+}
+
+func TestProducer_AddInterceptor_batching(t *testing.T) {
+	var ms frame.MockSender
+	prodID := uint64(123)
+	reqID := msg.MonotonicID{ID: 43}
+	dispatcher := frame.NewFrameDispatcher()
+
+	p := NewProducer(&ms, dispatcher, &reqID, prodID)
+	p.EnableBatching(BatchConfig{
+		MaxMessages: 2,
+		MaxLinger:   time.Hour,
+	})
+	interceptor := &recordingInterceptor{name: "interceptor"}
+	p.AddInterceptor(interceptor)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	errs := make(chan error, 2)
+	for _, key := range []string{"a", "b"} {
+		go func(key string) {
+			_, err := p.SendMessage(ctx, Message{Payload: []byte(key), Key: key})
+			errs <- err
+		}(key)
+	}
+
+	frames, err := ms.WaitFrames(ctx, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	singles, err := msg.DecodeBatchPayload(frames[0].Payload, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, single := range singles {
+		key := single.SingleMeta.GetPartitionKey()
+		if got, expected := string(single.SinglePayload), key+" interceptor"; got != expected {
+			t.Fatalf("sent payload %q; expected %q", got, expected)
+		}
+		if got := single.SingleMeta.GetProperties(); len(got) != 1 || got[0].GetKey() != "interceptor" {
+			t.Fatalf("sent properties %v; expected interceptor=seen", got)
+		}
+	}
+
+	// failed sends are acknowledged with their error
+	if err := p.HandleCloseProducer(frame.Frame{}); err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 2; i++ {
+		if err := <-errs; err != ErrClosedProducer {
+			t.Fatalf("SendMessage() err = %v; expected %v", err, ErrClosedProducer)
+		}
+	}
+	expected := "interceptor " + ErrClosedProducer.Error()
+	if got := interceptor.recorded(); len(got) != 2 || got[0] != expected || got[1] != expected {
+		t.Fatalf("acknowledged %q; expected %q twice", got, expected)
+	}
+}
+
+// recordingTraceHook records the outcomes of the sends it traces.
+type recordingTraceHook struct {
+	mu       sync.Mutex
+	sent     int
+	receipts []time.Duration // latencies
+	errs     []error
+}
+
+func (r *recordingTraceHook) OnSend(ctx context.Context, meta *api.MessageMetadata, payload []byte) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.sent++
+}
+
+func (r *recordingTraceHook) OnSendReceipt(ctx context.Context, receipt *api.CommandSendReceipt, latency time.Duration) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.receipts = append(r.receipts, latency)
+}
+
+func (r *recordingTraceHook) OnSendError(ctx context.Context, err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.errs = append(r.errs, err)
+}
+
+func TestProducer_AddTraceHook(t *testing.T) {
+	var ms frame.MockSender
+	prodID := uint64(123)
+	reqID := msg.MonotonicID{ID: 43}
+	dispatcher := frame.NewFrameDispatcher()
+
+	p := NewProducer(&ms, dispatcher, &reqID, prodID)
+	hooks := []*recordingTraceHook{{}, {}}
+	for _, h := range hooks {
+		p.AddTraceHook(h)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	// the first send is acknowledged, the second fails
+	responses := []*api.BaseCommand{
+		{
+			Type: api.BaseCommand_SEND_RECEIPT.Enum(),
+			SendReceipt: &api.CommandSendReceipt{
+				ProducerId: proto.Uint64(prodID),
+				SequenceId: proto.Uint64(0),
+			},
+		},
+		{
+			Type: api.BaseCommand_SEND_ERROR.Enum(),
+			SendError: &api.CommandSendError{
+				ProducerId: proto.Uint64(prodID),
+				SequenceId: proto.Uint64(1),
+				Error:      api.ServerError_PersistenceError.Enum(),
+				Message:    proto.String("bookies unavailable"),
+			},
+		},
+	}
+	for i, resp := range responses {
+		errs := make(chan error, 1)
+		go func() {
+			_, err := p.Send(ctx, []byte("hola"))
+			errs <- err
+		}()
+		if _, err := ms.WaitFrames(ctx, i+1); err != nil {
+			t.Fatal(err)
+		}
+		time.Sleep(10 * time.Millisecond)
+		if err := dispatcher.NotifyProdSeqIDs(prodID, uint64(i), frame.Frame{BaseCmd: resp}); err != nil {
+			t.Fatal(err)
+		}
+		if err := <-errs; (err != nil) != (i == 1) {
+			t.Fatalf("Send() err = %v for response %d", err, i)
+		}
+	}
+
+	for i, h := range hooks {
+		h.mu.Lock()
+		if h.sent != 2 || len(h.receipts) != 1 || len(h.errs) != 1 {
+			t.Fatalf("hook %d traced %d sends, %d receipts and %d errors; expected 2, 1 and 1", i, h.sent, len(h.receipts), len(h.errs))
+		}
+		if got, expected := h.receipts[0], 10*time.Millisecond; got < expected {
+			t.Fatalf("hook %d latency = %v; expected at least %v", i, got, expected)
+		}
+		h.mu.Unlock()
+	}
+}
@@ -0,0 +1,136 @@
+// Copyright 2018 Comcast Cable Communications Management, LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pub
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"time"
+
+	"github.com/pepper-iot/pulsar-client-go/core/msg"
+	"github.com/pepper-iot/pulsar-client-go/pkg/api"
+)
+
+// Message is a message to send with SendMessage.
+type Message struct {
+	Payload []byte
+
+	// Key is the partition key of the message. It determines the
+	// partition of the message, its consumer on Key_Shared
+	// subscriptions, and which messages topic compaction keeps.
+	Key string
+	// KeyBytes is a binary partition key, which is sent base64
+	// encoded. It is used in place of Key if set.
+	KeyBytes []byte
+
+	// Properties are application-defined headers of the message.
+	Properties map[string]string
+
+	// EventTime is the application-defined time of the message, eg
+	// when the event it describes occurred, with millisecond precision.
+	// It isn't sent if zero.
+	EventTime time.Time
+
+	// DeliverAt delays the delivery of the message until the given
+	// time, eg to schedule a retry, and DeliverAfter until the given
+	// duration after it is sent, if DeliverAt is zero. Only Shared and
+	// Key_Shared subscriptions delay messages: others receive them
+	// right away. Delayed messages are never batched.
+	DeliverAt    time.Time
+	DeliverAfter time.Duration
+
+	// SequenceID is the sequence id of the message, eg derived from a
+	// database offset, in place of the one the Producer assigns. With
+	// deduplication enabled, the broker then persists each sequence id
+	// once per producer name: see IsDuplicate. Sequence ids must
+	// increase, and are best not mixed with assigned ones. Messages
+	// with a sequence id are never batched.
+	SequenceID *uint64
+}
+
+// ErrStaleSequenceID is returned when sending a message
+// whose SequenceID isn't above that of the previous message.
+var ErrStaleSequenceID = errors.New("sequence id isn't above that of the previous message")
+
+// SendMessage sends a message and waits for a SendReceipt.
+func (p *Producer) SendMessage(ctx context.Context, m Message) (*api.CommandSendReceipt, error) {
+	if m.SequenceID == nil {
+		return p.SendWithMetadata(ctx, m.buildMetadata(), m.Payload)
+	}
+
+	if err := p.limit(ctx, 1, len(m.Payload)); err != nil {
+		return nil, err
+	}
+	// the current batch was sent first, so
+	// it has the sequence ids below
+	if p.batcher != nil {
+		p.batcher.flush()
+	}
+	if !p.SeqID.Reserve(*m.SequenceID) {
+		return nil, ErrStaleSequenceID
+	}
+	wait, err := p.write(ctx, m.buildMetadata(), m.Payload, *m.SequenceID, 1, true)
+	if err != nil {
+		return nil, err
+	}
+	return wait(ctx)
+}
+
+// IsDuplicate reports whether a SendReceipt is that of a message the
+// broker didn't persist, since deduplication is enabled and it already
+// persisted a message with the same sequence id, or a higher one, from
+// a producer with the same name.
+func IsDuplicate(receipt *api.CommandSendReceipt) bool {
+	id := receipt.GetMessageId()
+	// the broker sends -1:-1 as the id of duplicates
+	return id != nil && int64(id.GetLedgerId()) == -1 && int64(id.GetEntryId()) == -1
+}
+
+// MessageID returns the ID the broker assigned to the message of a
+// SendReceipt, eg to persist its publish position. The ID of a batch
+// is that of its entry, without a batch index: see SendBatch for the
+// IDs of its messages. Brokers don't set the partition, which
+// PartitionedProducers do.
+func MessageID(receipt *api.CommandSendReceipt) msg.MessageID {
+	return msg.NewMessageID(receipt.GetMessageId())
+}
+
+// PartitionKey returns the partition key of the message as sent,
+// ie KeyBytes base64 encoded if set, and Key otherwise.
+func (m *Message) PartitionKey() string {
+	if m.KeyBytes != nil {
+		return base64.StdEncoding.EncodeToString(m.KeyBytes)
+	}
+	return m.Key
+}
+
+// buildMetadata returns the metadata of the message.
+func (m *Message) buildMetadata() *api.MessageMetadata {
+	b := msg.NewMetadata()
+	if m.KeyBytes != nil {
+		b.KeyBytes(m.KeyBytes)
+	} else if m.Key != "" {
+		b.Key(m.Key)
+	}
+	if !m.EventTime.IsZero() {
+		b.EventTime(m.EventTime)
+	}
+	if !m.DeliverAt.IsZero() {
+		b.DeliverAt(m.DeliverAt)
+	} else if m.DeliverAfter > 0 {
+		b.DeliverAt(time.Now().Add(m.DeliverAfter))
+	}
+	return b.Properties(m.Properties).Build()
+}
@@ -18,9 +18,11 @@ import (
 	"errors"
 	"fmt"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/golang/protobuf/proto"
+	"github.com/pepper-iot/pulsar-client-go/core/encryption"
 	"github.com/pepper-iot/pulsar-client-go/core/frame"
 	"github.com/pepper-iot/pulsar-client-go/core/msg"
 	"github.com/pepper-iot/pulsar-client-go/pkg/api"
@@ -31,6 +33,10 @@ import (
 // from a closed Producer.
 var ErrClosedProducer = errors.New("producer is closed")
 
+// ErrStoppedProducer is returned when attempting to send
+// from a Producer after StopSends was called.
+var ErrStoppedProducer = errors.New("producer is not accepting sends")
+
 // NewProducer returns a ready-to-use producer. A producer
 // sends messages (type MESSAGE) to Pulsar.
 func NewProducer(s frame.CmdSender, dispatcher *frame.Dispatcher, reqID *msg.MonotonicID, producerID uint64) *Producer {
@@ -49,8 +55,10 @@ func NewProducer(s frame.CmdSender, dispatcher *frame.Dispatcher, reqID *msg.Mon
 type Producer struct {
 	S frame.CmdSender
 
-	ProducerID   uint64
-	ProducerName string
+	Topic         string
+	ProducerID    uint64
+	ProducerName  string
+	SchemaVersion []byte // version of the topic's schema registered by the producer, if any, set on the messages it sends
 
 	ReqID *msg.MonotonicID
 	SeqID *msg.MonotonicID
@@ -60,21 +68,138 @@ type Producer struct {
 	Mu       sync.RWMutex // protects following
 	IsClosed bool
 	Closedc  chan struct{}
+	migrated *api.CommandTopicMigrated // set if the Producer was closed because its topic was migrated
+
+	pmu     sync.Mutex    // protects following
+	pending int           // number of sends awaiting a response
+	idle    chan struct{} // if non-nil, closed when pending drops to 0
+
+	sendsStopped uint32 // atomically set to 1 by StopSends
 
-	traceHook TraceHook
+	interceptors ProducerInterceptors
+	batcher      *batcher              // nil unless batching is enabled
+	encryptor    *encryption.Encryptor // nil unless encryption is enabled
+	limiter      *RateLimiter          // nil unless sends are rate limited
+
+	amu          sync.Mutex      // protects following, and orders the sends of SendAsync
+	asyncSlots   chan struct{}   // semaphore bounding the sends of SendAsync
+	asyncSends   chan *asyncSend // sends of SendAsync awaiting their SendReceipt, in order
+	asyncStopped bool            // set once no SendReceipt is awaited anymore
 }
 
+// TraceHook traces the messages sent by a Producer, eg to propagate
+// the trace context of sends and close their spans.
 type TraceHook interface {
+	// OnSend is called before a message is sent, with its metadata,
+	// which it may modify, eg to inject the trace context of ctx.
 	OnSend(ctx context.Context, msg *api.MessageMetadata, payload []byte)
+	// OnSendReceipt is called once the SendReceipt of a message passed
+	// to OnSend is received, with the time elapsed since OnSend.
+	OnSendReceipt(ctx context.Context, receipt *api.CommandSendReceipt, latency time.Duration)
+	// OnSendError is called instead of OnSendReceipt if sending the
+	// message failed.
+	OnSendError(ctx context.Context, err error)
 }
 
-// 只在初始化Producer的时候执行一次AddTraceHook
+// AddTraceHook adds a TraceHook, as a ProducerInterceptor. Several
+// hooks may be added, which are called in order. It must be called
+// before the Producer is used.
 func (p *Producer) AddTraceHook(th TraceHook) {
-	p.traceHook = th
+	p.AddInterceptor(&traceInterceptor{TraceHook: th})
+}
+
+// EnableEncryption makes the Producer encrypt the payloads it sends,
+// after they are intercepted and batched, so that brokers can't read
+// them. It must be called once, before the Producer is used.
+func (p *Producer) EnableEncryption(e *encryption.Encryptor) {
+	p.encryptor = e
 }
 
 // Send sends a message and waits for a SendReceipt.
 func (p *Producer) Send(ctx context.Context, payload []byte) (*api.CommandSendReceipt, error) {
+	return p.SendWithMetadata(ctx, nil, payload)
+}
+
+// SendWithMetadata is like Send, but sends the message with the given
+// metadata, e.g. built with msg.NewMetadata. The sequence id, producer
+// name and publish time are set by the Producer, and the metadata
+// isn't modified. meta may be nil.
+func (p *Producer) SendWithMetadata(ctx context.Context, meta *api.MessageMetadata, payload []byte) (*api.CommandSendReceipt, error) {
+	wait, err := p.enqueue(ctx, meta, payload)
+	if err != nil {
+		return nil, err
+	}
+	return wait(ctx)
+}
+
+// enqueue sends a message, or adds it to the current batch, and
+// returns a function waiting for its SendReceipt, which must be
+// called unless enqueue fails.
+func (p *Producer) enqueue(ctx context.Context, meta *api.MessageMetadata, payload []byte) (func(context.Context) (*api.CommandSendReceipt, error), error) {
+	if err := p.limit(ctx, 1, len(payload)); err != nil {
+		return nil, err
+	}
+
+	if p.batcher != nil {
+		if single, ok := singleMetadata(meta); ok {
+			if len(p.interceptors) > 0 {
+				// the interceptors see the metadata of the message,
+				// whose fields that apply to it are then set on single
+				intercepted := metadata(single)
+				payload = p.interceptors.BeforeSend(ctx, intercepted, payload)
+				single, _ = singleMetadata(intercepted)
+				return p.intercepted(intercepted, p.batcher.add(single, payload).wait), nil
+			}
+			return p.batcher.add(single, payload).wait, nil
+		}
+	}
+
+	var metadata api.MessageMetadata
+	if meta != nil {
+		proto.Merge(&metadata, meta)
+	}
+	sequenceID := p.SeqID.Next()
+	return p.write(ctx, &metadata, payload, *sequenceID, 1, true)
+}
+
+// SendBatchPayload is like SendWithMetadata, but sends a batch of
+// numMessages messages, whose payload is encoded with
+// msg.EncodeBatchPayload. The batch is given numMessages consecutive
+// sequence ids: the lowest is its sequence_id and the highest its
+// highest_sequence_id, so that brokers deduplicating messages handle
+// it as a whole.
+func (p *Producer) SendBatchPayload(ctx context.Context, meta *api.MessageMetadata, payload []byte, numMessages int) (*api.CommandSendReceipt, error) {
+	if numMessages < 1 {
+		return nil, fmt.Errorf("invalid number of messages in batch: %d", numMessages)
+	}
+	return p.send(ctx, meta, payload, numMessages)
+}
+
+// send sends numMessages messages in a single payload,
+// and waits for a SendReceipt.
+func (p *Producer) send(ctx context.Context, meta *api.MessageMetadata, payload []byte, numMessages int) (*api.CommandSendReceipt, error) {
+	if err := p.limit(ctx, numMessages, len(payload)); err != nil {
+		return nil, err
+	}
+
+	var metadata api.MessageMetadata
+	if meta != nil {
+		proto.Merge(&metadata, meta)
+	}
+	sequenceID := p.SeqID.NextN(uint64(numMessages))
+	wait, err := p.write(ctx, &metadata, payload, *sequenceID, numMessages, true)
+	if err != nil {
+		return nil, err
+	}
+	return wait(ctx)
+}
+
+// write sends numMessages messages in a single payload with the given
+// metadata, which it completes, and the sequence ids reserved from
+// sequenceID. It returns a function waiting for the SendReceipt, which
+// must be called unless write fails. The interceptors are called if
+// intercept is true.
+func (p *Producer) write(ctx context.Context, metadata *api.MessageMetadata, payload []byte, sequenceID uint64, numMessages int, intercept bool) (func(context.Context) (*api.CommandSendReceipt, error), error) {
 	p.Mu.RLock()
 	if p.IsClosed {
 		p.Mu.RUnlock()
@@ -82,61 +207,160 @@ func (p *Producer) Send(ctx context.Context, payload []byte) (*api.CommandSendRe
 	}
 	p.Mu.RUnlock()
 
-	sequenceID := p.SeqID.Next()
+	if atomic.LoadUint32(&p.sendsStopped) == 1 {
+		return nil, ErrStoppedProducer
+	}
+
+	p.addPending(1)
+
+	highestSequenceID := sequenceID + uint64(numMessages) - 1
 
 	cmd := api.BaseCommand{
 		Type: api.BaseCommand_SEND.Enum(),
 		Send: &api.CommandSend{
 			ProducerId:  proto.Uint64(p.ProducerID),
-			SequenceId:  sequenceID,
-			NumMessages: proto.Int32(1),
+			SequenceId:  proto.Uint64(sequenceID),
+			NumMessages: proto.Int32(int32(numMessages)),
 		},
 	}
-	metadata := api.MessageMetadata{
-		SequenceId:   sequenceID,
-		ProducerName: proto.String(p.ProducerName),
-		PublishTime:  proto.Uint64(uint64(time.Now().Unix()) * 1000),
-		Compression:  api.CompressionType_NONE.Enum(),
+	metadata.SequenceId = proto.Uint64(sequenceID)
+	if numMessages > 1 {
+		cmd.Send.HighestSequenceId = proto.Uint64(highestSequenceID)
+		metadata.HighestSequenceId = proto.Uint64(highestSequenceID)
+		metadata.NumMessagesInBatch = proto.Int32(int32(numMessages))
+	}
+	metadata.ProducerName = proto.String(p.ProducerName)
+	if metadata.SchemaVersion == nil {
+		metadata.SchemaVersion = p.SchemaVersion
 	}
+	metadata.PublishTime = proto.Uint64(uint64(time.Now().Unix()) * 1000)
+	metadata.Compression = api.CompressionType_NONE.Enum()
 
-	resp, cancel, err := p.Dispatcher.RegisterProdSeqIDs(p.ProducerID, *sequenceID)
+	deadline, _ := ctx.Deadline()
+	resp, cancel, err := p.Dispatcher.RegisterProdSeqIDsDeadline(p.ProducerID, sequenceID, deadline)
 	if err != nil {
+		p.addPending(-1)
 		return nil, err
 	}
-	defer cancel()
 
-	// trace
-	if p.traceHook != nil {
-		p.traceHook.OnSend(ctx, &metadata, payload)
+	intercept = intercept && len(p.interceptors) > 0
+	if intercept {
+		payload = p.interceptors.BeforeSend(ctx, metadata, payload)
+	}
+	if p.encryptor != nil {
+		payload, err = p.encryptor.Encrypt(metadata, payload)
+	}
+	if err == nil {
+		err = p.S.SendPayloadCmd(cmd, *metadata, payload)
 	}
-	if err := p.S.SendPayloadCmd(cmd, metadata, payload); err != nil {
+	if err != nil {
+		cancel()
+		p.addPending(-1)
+		if intercept {
+			p.interceptors.OnSendAcknowledgement(ctx, metadata, nil, err)
+		}
 		return nil, err
 	}
 
-	// wait for timeout, closed producer, or response/error
-	select {
-	case <-ctx.Done():
-		return nil, ctx.Err()
+	wait := func(ctx context.Context) (*api.CommandSendReceipt, error) {
+		defer p.addPending(-1)
+		defer cancel()
+
+		// wait for timeout, closed producer, or response/error
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+
+		case <-p.Closed():
+			return nil, ErrClosedProducer
+
+		case <-p.ConnClosed():
+			return nil, ErrClosedProducer
+
+		case f := <-resp:
+			// the response is owned once received, and
+			// only the commands it contains are returned
+			defer frame.ReleaseBaseCommand(f.BaseCmd)
+
+			msgType := f.BaseCmd.GetType()
+			// Possible responses types are:
+			//  - SendReceipt
+			//  - SendError
+			switch msgType {
+			case api.BaseCommand_SEND_RECEIPT:
+				// receipts are matched by their lowest sequence id,
+				// and older brokers don't send the highest one
+				receipt := f.BaseCmd.GetSendReceipt()
+				if receipt.HighestSequenceId != nil && receipt.GetHighestSequenceId() != highestSequenceID {
+					return nil, utils.NewUnexpectedErrMsg(msgType, p.ProducerID, sequenceID, receipt.GetHighestSequenceId())
+				}
+				return receipt, nil
+
+			case api.BaseCommand_SEND_ERROR:
+				errMsg := f.BaseCmd.GetSendError()
+				return nil, utils.NewServerError(errMsg.GetError(), errMsg.GetMessage())
+
+			default:
+				return nil, utils.NewUnexpectedErrMsg(msgType, p.ProducerID, sequenceID)
+			}
+		}
+	}
+	if intercept {
+		return p.intercepted(metadata, wait), nil
+	}
+	return wait, nil
+}
 
-	case <-p.Closed():
-		return nil, ErrClosedProducer
+// addPending adjusts the number of outstanding sends, waking
+// up any callers of Flush once there are none left.
+func (p *Producer) addPending(delta int) {
+	p.pmu.Lock()
+	p.pending += delta
+	if p.pending == 0 && p.idle != nil {
+		close(p.idle)
+		p.idle = nil
+	}
+	p.pmu.Unlock()
+}
 
-	case f := <-resp:
-		msgType := f.BaseCmd.GetType()
-		// Possible responses types are:
-		//  - SendReceipt
-		//  - SendError
-		switch msgType {
-		case api.BaseCommand_SEND_RECEIPT:
-			return f.BaseCmd.GetSendReceipt(), nil
-
-		case api.BaseCommand_SEND_ERROR:
-			errMsg := f.BaseCmd.GetSendError()
-			return nil, fmt.Errorf("%s: %s", errMsg.GetError().String(), errMsg.GetMessage())
-
-		default:
-			return nil, utils.NewUnexpectedErrMsg(msgType, p.ProducerID, *sequenceID)
-		}
+// Pending returns the number of sends awaiting their SendReceipt.
+func (p *Producer) Pending() int {
+	p.pmu.Lock()
+	defer p.pmu.Unlock()
+	return p.pending
+}
+
+// StopSends makes any further call to Send fail with ErrStoppedProducer.
+// Sends already in progress are unaffected, and can be waited for
+// using Flush. It is used when draining.
+func (p *Producer) StopSends() {
+	atomic.StoreUint32(&p.sendsStopped, 1)
+}
+
+// Flush blocks until all sends in progress have received their
+// SendReceipt (or SendError), or until the context is done. With
+// batching, the current batch is sent right away.
+func (p *Producer) Flush(ctx context.Context) error {
+	if p.batcher != nil {
+		p.batcher.flush()
+	}
+
+	p.pmu.Lock()
+	if p.pending == 0 {
+		p.pmu.Unlock()
+		return nil
+	}
+	if p.idle == nil {
+		p.idle = make(chan struct{})
+	}
+	idle := p.idle
+	p.pmu.Unlock()
+
+	select {
+	case <-idle:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
 	}
 }
 
@@ -154,11 +378,28 @@ func (p *Producer) ConnClosed() <-chan struct{} {
 	return p.S.Closed()
 }
 
+// CloseGracefully is like Close, but first waits for the outstanding
+// sends, including those of the current batch, to be acknowledged,
+// like Flush, instead of abandoning them. If ctx is done before, the
+// Producer isn't closed, and ctx's error is returned.
+func (p *Producer) CloseGracefully(ctx context.Context) error {
+	if err := p.Flush(ctx); err != nil {
+		return err
+	}
+	return p.Close(ctx)
+}
+
 // Close closes the producer. When receiving a CloseProducer command,
 // the broker will stop accepting any more messages for the producer,
 // wait until all pending messages are persisted and then reply Success to the client.
 // https://pulsar.incubator.apache.org/docs/latest/project/BinaryProtocol/#command-closeproducer
 func (p *Producer) Close(ctx context.Context) error {
+	if p.batcher != nil {
+		// send the current batch
+		// before the producer closes
+		p.batcher.flush()
+	}
+
 	p.Mu.Lock()
 	defer p.Mu.Unlock()
 
@@ -176,7 +417,8 @@ func (p *Producer) Close(ctx context.Context) error {
 		},
 	}
 
-	resp, cancel, err := p.Dispatcher.RegisterReqID(*requestID)
+	deadline, _ := ctx.Deadline()
+	resp, cancel, err := p.Dispatcher.RegisterReqIDDeadline(*requestID, deadline)
 	if err != nil {
 		return err
 	}
@@ -215,7 +457,20 @@ func (p *Producer) HandleCloseProducer(f frame.Frame) error {
 	}
 
 	p.IsClosed = true
+	p.migrated = f.BaseCmd.GetTopicMigrated()
 	close(p.Closedc)
 
 	return nil
 }
+
+// MigratedTo returns the service URLs of the cluster the topic was
+// migrated to, if the Producer was closed by a TOPIC_MIGRATED command,
+// passed to HandleCloseProducer.
+func (p *Producer) MigratedTo() (url, tlsURL string, ok bool) {
+	p.Mu.RLock()
+	defer p.Mu.RUnlock()
+	if p.migrated == nil {
+		return "", "", false
+	}
+	return p.migrated.GetBrokerServiceUrl(), p.migrated.GetBrokerServiceUrlTls(), true
+}
@@ -15,10 +15,18 @@ package pub
 
 import (
 	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"math"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/golang/protobuf/proto"
+	"github.com/pepper-iot/pulsar-client-go/core/encryption"
 	"github.com/pepper-iot/pulsar-client-go/core/frame"
 	"github.com/pepper-iot/pulsar-client-go/core/msg"
 	"github.com/pepper-iot/pulsar-client-go/pkg/api"
@@ -49,8 +57,10 @@ func TestProducer_Send_Success(t *testing.T) {
 		resp <- r
 	}()
 
-	// Allow goroutine time to complete
-	time.Sleep(100 * time.Millisecond)
+	// Wait for the goroutine to send the command
+	if _, err := ms.WaitFrames(ctx, 1); err != nil {
+		t.Fatal(err)
+	}
 
 	expected := api.CommandSendReceipt{
 		ProducerId: proto.Uint64(prodID),
@@ -80,6 +90,318 @@ func TestProducer_Send_Success(t *testing.T) {
 	}
 }
 
+func TestProducer_SendWithMetadata(t *testing.T) {
+	var ms frame.MockSender
+	id := uint64(43)
+	prodID := uint64(123)
+	reqID := msg.MonotonicID{ID: id}
+	dispatcher := frame.NewFrameDispatcher()
+
+	p := NewProducer(&ms, dispatcher, &reqID, prodID)
+	p.ProducerName = "producer"
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	meta := msg.NewMetadata().Key("key").Property("a", "b").Build()
+	meta.SequenceId = proto.Uint64(99) // overridden by the Producer
+	if _, err := p.SendWithMetadata(ctx, meta, []byte("hola mundo")); err != context.DeadlineExceeded {
+		t.Fatalf("SendWithMetadata() err = %v; expected %v", err, context.DeadlineExceeded)
+	}
+
+	frames := ms.GetFrames()
+	if got, expected := len(frames), 1; got != expected {
+		t.Fatalf("got %d frame; expected %d", got, expected)
+	}
+	sent := frames[0].Metadata
+	if got, expected := sent.GetPartitionKey(), "key"; got != expected {
+		t.Fatalf("sent partition key %q; expected %q", got, expected)
+	}
+	if got := sent.GetProperties(); len(got) != 1 || got[0].GetKey() != "a" || got[0].GetValue() != "b" {
+		t.Fatalf("sent properties %v; expected a=b", got)
+	}
+	if got, expected := sent.GetSequenceId(), uint64(0); got != expected {
+		t.Fatalf("sent sequence id %d; expected %d", got, expected)
+	}
+	if got, expected := sent.GetProducerName(), "producer"; got != expected {
+		t.Fatalf("sent producer name %q; expected %q", got, expected)
+	}
+
+	// the given metadata isn't modified
+	if got, expected := meta.GetSequenceId(), uint64(99); got != expected {
+		t.Fatalf("metadata sequence id = %d; expected %d", got, expected)
+	}
+}
+
+func TestProducer_SendMessage(t *testing.T) {
+	var ms frame.MockSender
+	prodID := uint64(123)
+	reqID := msg.MonotonicID{ID: 43}
+	dispatcher := frame.NewFrameDispatcher()
+
+	p := NewProducer(&ms, dispatcher, &reqID, prodID)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	messages := []Message{
+		{Payload: []byte("hola mundo"), Key: "key"},
+		{Payload: []byte("hola mundo"), Key: "key", KeyBytes: []byte{0, 0xff}},
+		{Payload: []byte("hola mundo"), Properties: map[string]string{"b": "2", "a": "1"}},
+		{Payload: []byte("hola mundo"), EventTime: time.Unix(1513027321, 5*int64(time.Millisecond))},
+		{Payload: []byte("hola mundo"), DeliverAt: time.Unix(1513027321, 5*int64(time.Millisecond)), DeliverAfter: time.Hour},
+	}
+	for _, m := range messages {
+		if _, err := p.SendMessage(ctx, m); err != context.DeadlineExceeded {
+			t.Fatalf("SendMessage() err = %v; expected %v", err, context.DeadlineExceeded)
+		}
+	}
+
+	frames := ms.GetFrames()
+	if got, expected := len(frames), len(messages); got != expected {
+		t.Fatalf("got %d frames; expected %d", got, expected)
+	}
+	expected := []struct {
+		key       string
+		b64       bool
+		props     string
+		eventTime uint64
+		deliverAt int64
+	}{
+		{"key", false, "", 0, 0},
+		{"AP8=", true, "", 0, 0},
+		{"", false, "a=1,b=2", 0, 0},
+		{"", false, "", 1513027321005, 0},
+		{"", false, "", 0, 1513027321005},
+	}
+	for i, e := range expected {
+		sent := frames[i].Metadata
+		if got := sent.GetPartitionKey(); got != e.key {
+			t.Fatalf("message %d sent partition key %q; expected %q", i, got, e.key)
+		}
+		if got := sent.GetPartitionKeyB64Encoded(); got != e.b64 {
+			t.Fatalf("message %d sent partition_key_b64_encoded %t; expected %t", i, got, e.b64)
+		}
+		var props []string
+		for _, kv := range sent.GetProperties() {
+			props = append(props, kv.GetKey()+"="+kv.GetValue())
+		}
+		if got := strings.Join(props, ","); got != e.props {
+			t.Fatalf("message %d sent properties %q; expected %q", i, got, e.props)
+		}
+		if got := sent.GetEventTime(); got != e.eventTime {
+			t.Fatalf("message %d sent event time %d; expected %d", i, got, e.eventTime)
+		}
+		if got := sent.GetDeliverAtTime(); got != e.deliverAt {
+			t.Fatalf("message %d sent deliver at time %d; expected %d", i, got, e.deliverAt)
+		}
+		if got := string(frames[i].Payload); got != "hola mundo" {
+			t.Fatalf("message %d sent payload %q; expected %q", i, got, "hola mundo")
+		}
+	}
+}
+
+func TestProducer_SendMessage_DeliverAfter(t *testing.T) {
+	var ms frame.MockSender
+	prodID := uint64(123)
+	reqID := msg.MonotonicID{ID: 43}
+	dispatcher := frame.NewFrameDispatcher()
+
+	p := NewProducer(&ms, dispatcher, &reqID, prodID)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	before := time.Now().Add(time.Minute)
+	if _, err := p.SendMessage(ctx, Message{Payload: []byte("hola mundo"), DeliverAfter: time.Minute}); err != context.DeadlineExceeded {
+		t.Fatalf("SendMessage() err = %v; expected %v", err, context.DeadlineExceeded)
+	}
+	after := time.Now().Add(time.Minute)
+
+	frames := ms.GetFrames()
+	if got, expected := len(frames), 1; got != expected {
+		t.Fatalf("got %d frames; expected %d", got, expected)
+	}
+	deliverAt := time.Unix(0, frames[0].Metadata.GetDeliverAtTime()*int64(time.Millisecond))
+	if deliverAt.Before(before.Truncate(time.Millisecond)) || deliverAt.After(after) {
+		t.Fatalf("sent deliver at time %v; expected between %v and %v", deliverAt, before, after)
+	}
+}
+
+func TestProducer_SendMessage_SequenceID(t *testing.T) {
+	var ms frame.MockSender
+	prodID := uint64(123)
+	reqID := msg.MonotonicID{ID: 43}
+	dispatcher := frame.NewFrameDispatcher()
+
+	p := NewProducer(&ms, dispatcher, &reqID, prodID)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	type response struct {
+		receipt *api.CommandSendReceipt
+		err     error
+	}
+	send := func(m Message) chan response {
+		resp := make(chan response, 1)
+		go func() {
+			var r response
+			r.receipt, r.err = p.SendMessage(ctx, m)
+			resp <- r
+		}()
+		return resp
+	}
+	receipt := func(seqID uint64, id *api.MessageIdData) {
+		f := frame.Frame{
+			BaseCmd: &api.BaseCommand{
+				Type: api.BaseCommand_SEND_RECEIPT.Enum(),
+				SendReceipt: &api.CommandSendReceipt{
+					ProducerId: proto.Uint64(prodID),
+					SequenceId: proto.Uint64(seqID),
+					MessageId:  id,
+				},
+			},
+		}
+		if err := dispatcher.NotifyProdSeqIDs(prodID, seqID, f); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	resp := send(Message{Payload: []byte("hola mundo"), SequenceID: proto.Uint64(10)})
+	frames, err := ms.WaitFrames(ctx, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, expected := frames[0].BaseCmd.GetSend().GetSequenceId(), uint64(10); got != expected {
+		t.Fatalf("sent sequence id %d; expected %d", got, expected)
+	}
+	receipt(10, &api.MessageIdData{LedgerId: proto.Uint64(1), EntryId: proto.Uint64(2)})
+	r := <-resp
+	if r.err != nil || IsDuplicate(r.receipt) {
+		t.Fatalf("SendMessage() = %v, %v; expected a receipt that isn't a duplicate", r.receipt, r.err)
+	}
+	if got, expected := MessageID(r.receipt), (msg.MessageID{LedgerID: 1, EntryID: 2, Partition: -1, BatchIndex: -1}); got != expected {
+		t.Fatalf("MessageID() = %+v; expected %+v", got, expected)
+	}
+
+	// sequence ids must increase
+	if _, err := p.SendMessage(ctx, Message{Payload: []byte("hola mundo"), SequenceID: proto.Uint64(10)}); err != ErrStaleSequenceID {
+		t.Fatalf("SendMessage() err = %v; expected %v", err, ErrStaleSequenceID)
+	}
+
+	// and are continued by assigned ones
+	resp = send(Message{Payload: []byte("hola mundo")})
+	if frames, err = ms.WaitFrames(ctx, 2); err != nil {
+		t.Fatal(err)
+	}
+	if got, expected := frames[1].BaseCmd.GetSend().GetSequenceId(), uint64(11); got != expected {
+		t.Fatalf("sent sequence id %d; expected %d", got, expected)
+	}
+
+	// brokers deduplicating messages send -1:-1 as the id of duplicates
+	receipt(11, &api.MessageIdData{LedgerId: proto.Uint64(math.MaxUint64), EntryId: proto.Uint64(math.MaxUint64)})
+	if r := <-resp; r.err != nil || !IsDuplicate(r.receipt) {
+		t.Fatalf("SendMessage() = %v, %v; expected a duplicate receipt", r.receipt, r.err)
+	}
+}
+
+func TestProducer_SendBatchPayload(t *testing.T) {
+	var ms frame.MockSender
+	prodID := uint64(123)
+	reqID := msg.MonotonicID{ID: 43}
+	dispatcher := frame.NewFrameDispatcher()
+
+	p := NewProducer(&ms, dispatcher, &reqID, prodID)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if _, err := p.SendBatchPayload(ctx, nil, nil, 0); err == nil {
+		t.Fatal("SendBatchPayload() of 0 messages err = nil; expected an error")
+	}
+
+	type response struct {
+		receipt *api.CommandSendReceipt
+		err     error
+	}
+	var sends int
+	sendBatch := func(lowest, highest uint64, receiptHighest *uint64) response {
+		resp := make(chan response, 1)
+		go func() {
+			var r response
+			r.receipt, r.err = p.SendBatchPayload(ctx, nil, []byte("batch"), int(highest-lowest+1))
+			resp <- r
+		}()
+
+		// wait for the batch to be sent
+		sends++
+		frames, err := ms.WaitFrames(ctx, sends)
+		if err != nil {
+			t.Fatal(err)
+		}
+		sent := frames[sends-1]
+
+		send := sent.BaseCmd.GetSend()
+		if got, expected := send.GetHighestSequenceId(), highest; got != expected {
+			t.Fatalf("sent highest sequence id %d; expected %d", got, expected)
+		}
+		if got, expected := send.GetNumMessages(), int32(highest-lowest+1); got != expected {
+			t.Fatalf("sent %d messages; expected %d", got, expected)
+		}
+		if got, expected := sent.Metadata.GetSequenceId(), lowest; got != expected {
+			t.Fatalf("sent metadata sequence id %d; expected %d", got, expected)
+		}
+		if got, expected := sent.Metadata.GetHighestSequenceId(), highest; got != expected {
+			t.Fatalf("sent metadata highest sequence id %d; expected %d", got, expected)
+		}
+		if got, expected := sent.Metadata.GetNumMessagesInBatch(), int32(highest-lowest+1); got != expected {
+			t.Fatalf("sent metadata with %d messages in batch; expected %d", got, expected)
+		}
+
+		f := frame.Frame{
+			BaseCmd: &api.BaseCommand{
+				Type: api.BaseCommand_SEND_RECEIPT.Enum(),
+				SendReceipt: &api.CommandSendReceipt{
+					ProducerId:        proto.Uint64(prodID),
+					SequenceId:        proto.Uint64(lowest),
+					HighestSequenceId: receiptHighest,
+				},
+			},
+		}
+		if err := dispatcher.NotifyProdSeqIDs(prodID, lowest, f); err != nil {
+			t.Fatal(err)
+		}
+		return <-resp
+	}
+
+	if r := sendBatch(0, 2, proto.Uint64(2)); r.err != nil {
+		t.Fatalf("SendBatchPayload() err = %v", r.err)
+	}
+	// brokers that don't send the highest sequence id
+	if r := sendBatch(3, 4, nil); r.err != nil {
+		t.Fatalf("SendBatchPayload() err = %v", r.err)
+	}
+	// a receipt for another range
+	if r := sendBatch(5, 9, proto.Uint64(7)); r.err == nil {
+		t.Fatal("SendBatchPayload() err = nil; expected an error for a mismatched receipt")
+	}
+
+	// single messages follow the batches
+	go func() { _, _ = p.Send(ctx, []byte("single")) }()
+	frames, err := ms.WaitFrames(ctx, 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	send := frames[3].BaseCmd.GetSend()
+	if got, expected := send.GetSequenceId(), uint64(10); got != expected {
+		t.Fatalf("sent sequence id %d; expected %d", got, expected)
+	}
+	if send.HighestSequenceId != nil {
+		t.Fatalf("sent highest sequence id %d for a single message", send.GetHighestSequenceId())
+	}
+}
+
 func TestProducer_Send_Error(t *testing.T) {
 	var ms frame.MockSender
 	id := uint64(43)
@@ -105,8 +427,10 @@ func TestProducer_Send_Error(t *testing.T) {
 		resp <- r
 	}()
 
-	// Allow goroutine time to complete
-	time.Sleep(100 * time.Millisecond)
+	// Wait for the goroutine to send the command
+	if _, err := ms.WaitFrames(ctx, 1); err != nil {
+		t.Fatal(err)
+	}
 
 	f := frame.Frame{
 		BaseCmd: &api.BaseCommand{
@@ -149,8 +473,10 @@ func TestProducer_Close_Success(t *testing.T) {
 
 	go func() { resp <- p.Close(ctx) }()
 
-	// Allow goroutine time to complete
-	time.Sleep(100 * time.Millisecond)
+	// Wait for the goroutine to send the command
+	if _, err := ms.WaitFrames(ctx, 1); err != nil {
+		t.Fatal(err)
+	}
 
 	select {
 	case <-p.Closed():
@@ -225,3 +551,129 @@ func TestProducer_handleCloseProducer(t *testing.T) {
 		t.Fatalf("Closed() blocked; expected to be unblocked after handleCloseProducer()")
 	}
 }
+
+func TestProducer_Flush(t *testing.T) {
+	var ms frame.MockSender
+	id := uint64(43)
+	prodID := uint64(123)
+	reqID := msg.MonotonicID{ID: id}
+	dispatcher := frame.NewFrameDispatcher()
+
+	p := NewProducer(&ms, dispatcher, &reqID, prodID)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// nothing pending
+	if err := p.Flush(ctx); err != nil {
+		t.Fatalf("Flush() err = %v; nil expected", err)
+	}
+
+	sent := make(chan error, 1)
+	go func() {
+		_, err := p.Send(ctx, []byte("hola mundo"))
+		sent <- err
+	}()
+
+	// Wait for the goroutine to send the command
+	if _, err := ms.WaitFrames(ctx, 1); err != nil {
+		t.Fatal(err)
+	}
+
+	flushCtx, flushCancel := context.WithTimeout(ctx, 100*time.Millisecond)
+	defer flushCancel()
+	if err := p.Flush(flushCtx); err == nil {
+		t.Fatal("Flush() err = nil; expected timeout while send is pending")
+	}
+
+	flushed := make(chan error, 1)
+	go func() {
+		flushed <- p.Flush(ctx)
+	}()
+
+	f := frame.Frame{
+		BaseCmd: &api.BaseCommand{
+			Type: api.BaseCommand_SEND_RECEIPT.Enum(),
+			SendReceipt: &api.CommandSendReceipt{
+				ProducerId: proto.Uint64(prodID),
+				SequenceId: proto.Uint64(0),
+			},
+		},
+	}
+	if err := dispatcher.NotifyProdSeqIDs(prodID, 0, f); err != nil {
+		t.Fatalf("HandleProdSeqIDs() err = %v; nil expected", err)
+	}
+
+	if err := <-sent; err != nil {
+		t.Fatalf("Send() err = %v; nil expected", err)
+	}
+	if err := <-flushed; err != nil {
+		t.Fatalf("Flush() err = %v; nil expected", err)
+	}
+}
+
+// ecKeyReader is an encryption.CryptoKeyReader of a single ECDSA key pair.
+type ecKeyReader struct {
+	key *ecdsa.PrivateKey
+}
+
+func (r ecKeyReader) PublicKey(name string, metadata map[string]string) (encryption.KeyInfo, error) {
+	der, err := x509.MarshalPKIXPublicKey(&r.key.PublicKey)
+	return encryption.KeyInfo{Key: pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})}, err
+}
+
+func (r ecKeyReader) PrivateKey(name string, metadata map[string]string) (encryption.KeyInfo, error) {
+	der, err := x509.MarshalECPrivateKey(r.key)
+	return encryption.KeyInfo{Key: pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: der})}, err
+}
+
+func TestProducer_EnableEncryption(t *testing.T) {
+	var ms frame.MockSender
+	prodID := uint64(123)
+	reqID := msg.MonotonicID{ID: 43}
+	dispatcher := frame.NewFrameDispatcher()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	e, err := encryption.NewEncryptor(ecKeyReader{key}, "app")
+	if err != nil {
+		t.Fatal(err)
+	}
+	p := NewProducer(&ms, dispatcher, &reqID, prodID)
+	p.EnableEncryption(e)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	payload := []byte("hola mundo")
+	if _, err := p.SendMessage(ctx, Message{Payload: payload, Key: "key"}); err != context.DeadlineExceeded {
+		t.Fatalf("SendMessage() err = %v; expected %v", err, context.DeadlineExceeded)
+	}
+
+	frames := ms.GetFrames()
+	if got, expected := len(frames), 1; got != expected {
+		t.Fatalf("got %d frames; expected %d", got, expected)
+	}
+	sent := msg.Message{Meta: frames[0].Metadata, Payload: frames[0].Payload}
+	if !sent.IsEncrypted() {
+		t.Fatal("sent message isn't encrypted")
+	}
+	if strings.Contains(string(sent.Payload), string(payload)) {
+		t.Fatalf("sent payload %q; expected it to be encrypted", sent.Payload)
+	}
+	if got, expected := sent.Key(), "key"; got != expected {
+		t.Fatalf("sent key %q; expected %q", got, expected)
+	}
+
+	if err := sent.Decrypt(ecKeyReader{key}); err != nil {
+		t.Fatal(err)
+	}
+	if got, expected := string(sent.Payload), string(payload); got != expected {
+		t.Fatalf("decrypted payload %q; expected %q", got, expected)
+	}
+	if sent.IsEncrypted() {
+		t.Fatal("decrypted message is still encrypted")
+	}
+}
@@ -0,0 +1,172 @@
+// Copyright 2018 Comcast Cable Communications Management, LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pub
+
+import (
+	"context"
+	"errors"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/pepper-iot/pulsar-client-go/utils"
+)
+
+// ErrRateLimited is returned when sending from a Producer
+// over its rate limit, if the limit fails fast.
+var ErrRateLimited = errors.New("producer rate limit exceeded")
+
+// RateLimitConfig configures a RateLimiter.
+type RateLimitConfig struct {
+	MessagesPerSecond float64     // maximum rate of messages, 0 for no limit
+	BytesPerSecond    int         // maximum rate of payload bytes, 0 for no limit
+	FailFast          bool        // if true, sends over the limit fail with ErrRateLimited instead of waiting
+	Clock             utils.Clock // Defaults to utils.RealClock
+}
+
+// RateLimiter is a token bucket limiting the rate of the sends of
+// Producers, in messages and payload bytes per second, eg so that a
+// backfill doesn't overwhelm consumers or exceed the broker's quotas.
+// Both buckets hold up to one second worth of tokens. A send may
+// proceed once a message token is available and the byte bucket isn't
+// in debt, then takes the tokens of all its messages and bytes, so
+// that payloads larger than the byte rate still get through.
+// It is safe for concurrent use, and may be shared by Producers to
+// limit their combined rate.
+type RateLimiter struct {
+	cfg RateLimitConfig
+
+	mu    sync.Mutex // protects following
+	last  time.Time  // time of the latest refill
+	msgs  float64    // available message tokens, negative when in debt
+	bytes float64    // available byte tokens, negative when in debt
+}
+
+// NewRateLimiter returns a RateLimiter with full buckets.
+func NewRateLimiter(cfg RateLimitConfig) *RateLimiter {
+	if cfg.Clock == nil {
+		cfg.Clock = utils.RealClock
+	}
+	cfg.MessagesPerSecond = math.Max(cfg.MessagesPerSecond, 0)
+	if cfg.BytesPerSecond < 0 {
+		cfg.BytesPerSecond = 0
+	}
+	l := RateLimiter{
+		cfg:   cfg,
+		last:  cfg.Clock.Now(),
+		bytes: float64(cfg.BytesPerSecond),
+	}
+	l.msgs = l.msgBurst()
+	return &l
+}
+
+// SetRateLimiter makes the Producer wait for l before each send,
+// or fail with ErrRateLimited if it fails fast. It must be called
+// once, before the Producer is used.
+func (p *Producer) SetRateLimiter(l *RateLimiter) {
+	p.limiter = l
+}
+
+// limit waits for the rate limiter to allow sending
+// numMessages messages of size bytes, if there is one.
+func (p *Producer) limit(ctx context.Context, numMessages, size int) error {
+	if p.limiter == nil {
+		return nil
+	}
+	return p.limiter.Wait(ctx, numMessages, size)
+}
+
+// Wait takes the tokens of numMessages messages of size bytes,
+// waiting until they may be sent. It returns ErrRateLimited instead
+// of waiting if the limiter fails fast, and ctx.Err() if ctx is done
+// first.
+func (l *RateLimiter) Wait(ctx context.Context, numMessages, size int) error {
+	for {
+		wait := l.take(numMessages, size)
+		if wait == 0 {
+			return nil
+		}
+		if l.cfg.FailFast {
+			return ErrRateLimited
+		}
+
+		t := l.cfg.Clock.NewTimer(wait)
+		select {
+		case <-t.C():
+		case <-ctx.Done():
+			t.Stop()
+			return ctx.Err()
+		}
+	}
+}
+
+// take takes the tokens of numMessages messages of size bytes and
+// returns 0 if they may be sent now, and how long until they may
+// otherwise, without taking them.
+func (l *RateLimiter) take(numMessages, size int) time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.refill()
+
+	var wait time.Duration
+	if l.cfg.MessagesPerSecond > 0 && l.msgs < 1 {
+		wait = seconds((1 - l.msgs) / l.cfg.MessagesPerSecond)
+	}
+	if rate := float64(l.cfg.BytesPerSecond); rate > 0 && l.bytes < 0 {
+		if w := seconds(-l.bytes / rate); w > wait {
+			wait = w
+		}
+	}
+	if wait > 0 {
+		return wait
+	}
+
+	if l.cfg.MessagesPerSecond > 0 {
+		l.msgs -= float64(numMessages)
+	}
+	if l.cfg.BytesPerSecond > 0 {
+		l.bytes -= float64(size)
+	}
+	return 0
+}
+
+// msgBurst is the capacity of the message bucket, which
+// must hold at least one token for any message to be sent.
+func (l *RateLimiter) msgBurst() float64 {
+	return math.Max(l.cfg.MessagesPerSecond, 1)
+}
+
+// refill adds the tokens accumulated since the latest refill.
+// l.mu must be held.
+func (l *RateLimiter) refill() {
+	now := l.cfg.Clock.Now()
+	elapsed := now.Sub(l.last).Seconds()
+	if elapsed <= 0 {
+		return
+	}
+	l.last = now
+	if l.cfg.MessagesPerSecond > 0 {
+		l.msgs = math.Min(l.msgs+elapsed*l.cfg.MessagesPerSecond, l.msgBurst())
+	}
+	if rate := float64(l.cfg.BytesPerSecond); rate > 0 {
+		l.bytes = math.Min(l.bytes+elapsed*rate, rate)
+	}
+}
+
+// seconds converts s seconds to a Duration,
+// rounded up so that waiting for it is enough.
+func seconds(s float64) time.Duration {
+	return time.Duration(math.Ceil(s * float64(time.Second)))
+}
@@ -0,0 +1,128 @@
+// Copyright 2018 Comcast Cable Communications Management, LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pub
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pepper-iot/pulsar-client-go/core/frame"
+	"github.com/pepper-iot/pulsar-client-go/core/msg"
+	"github.com/pepper-iot/pulsar-client-go/utils"
+)
+
+func TestRateLimiter_take(t *testing.T) {
+	clock := utils.NewManualClock(time.Now())
+	l := NewRateLimiter(RateLimitConfig{MessagesPerSecond: 10, Clock: clock})
+
+	take := func(n int, expected time.Duration) {
+		t.Helper()
+		if got := l.take(n, 0); got != expected {
+			t.Fatalf("take(%d) = %v; expected %v", n, got, expected)
+		}
+	}
+
+	// one second worth of burst
+	take(4, 0)
+	take(6, 0)
+	take(1, 100*time.Millisecond)
+
+	// batches may take more tokens than available
+	clock.Advance(150 * time.Millisecond)
+	take(3, 0)
+	take(1, 250*time.Millisecond)
+
+	// the bucket doesn't fill beyond the burst
+	clock.Advance(time.Hour)
+	take(10, 0)
+	take(1, 100*time.Millisecond)
+}
+
+func TestRateLimiter_bytes(t *testing.T) {
+	clock := utils.NewManualClock(time.Now())
+	l := NewRateLimiter(RateLimitConfig{BytesPerSecond: 1000, Clock: clock})
+
+	// payloads larger than the rate get through, then wait for the debt
+	if got := l.take(1, 1500); got != 0 {
+		t.Fatalf("take(1, 1500) = %v; expected 0", got)
+	}
+	if got, expected := l.take(1, 1), 500*time.Millisecond; got != expected {
+		t.Fatalf("take(1, 1) = %v; expected %v", got, expected)
+	}
+	clock.Advance(500 * time.Millisecond)
+	if got := l.take(1, 1); got != 0 {
+		t.Fatalf("take(1, 1) = %v; expected 0", got)
+	}
+}
+
+func TestRateLimiter_Wait(t *testing.T) {
+	clock := utils.NewManualClock(time.Now())
+	l := NewRateLimiter(RateLimitConfig{MessagesPerSecond: 1, Clock: clock})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := l.Wait(ctx, 1, 0); err != nil {
+		t.Fatal(err)
+	}
+
+	errs := make(chan error, 1)
+	go func() {
+		errs <- l.Wait(ctx, 1, 0)
+	}()
+	select {
+	case err := <-errs:
+		t.Fatalf("Wait() = %v before the bucket refilled; expected it to block", err)
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	clock.Advance(time.Second)
+	if err := <-errs; err != nil {
+		t.Fatalf("Wait() = %v; expected nil", err)
+	}
+
+	// waits are cut short by the context
+	short, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+	defer cancel()
+	if err := l.Wait(short, 1, 0); err != context.DeadlineExceeded {
+		t.Fatalf("Wait() = %v; expected %v", err, context.DeadlineExceeded)
+	}
+}
+
+func TestProducer_SetRateLimiter(t *testing.T) {
+	var ms frame.MockSender
+	prodID := uint64(123)
+	reqID := msg.MonotonicID{ID: 43}
+	dispatcher := frame.NewFrameDispatcher()
+
+	p := NewProducer(&ms, dispatcher, &reqID, prodID)
+	clock := utils.NewManualClock(time.Now())
+	p.SetRateLimiter(NewRateLimiter(RateLimitConfig{MessagesPerSecond: 2, FailFast: true, Clock: clock}))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	for i := 0; i < 2; i++ {
+		if _, err := p.Send(ctx, []byte("hola mundo")); err != context.DeadlineExceeded {
+			t.Fatalf("Send() err = %v; expected %v", err, context.DeadlineExceeded)
+		}
+	}
+	if _, err := p.Send(ctx, []byte("hola mundo")); err != ErrRateLimited {
+		t.Fatalf("Send() err = %v; expected %v", err, ErrRateLimited)
+	}
+	if got, expected := len(ms.GetFrames()), 2; got != expected {
+		t.Fatalf("got %d frames; expected %d", got, expected)
+	}
+}
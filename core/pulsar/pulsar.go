@@ -0,0 +1,99 @@
+// Copyright 2018 Comcast Cable Communications Management, LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package pulsar defines the interfaces implemented by the managed types
+// of the manage package. Applications can depend on these interfaces
+// instead of the concrete types, and replace them with mocks (e.g.
+// generated by gomock or written with testify) in their tests.
+package pulsar
+
+import (
+	"context"
+
+	"github.com/pepper-iot/pulsar-client-go/core/manage"
+	"github.com/pepper-iot/pulsar-client-go/core/msg"
+	"github.com/pepper-iot/pulsar-client-go/core/pub"
+	"github.com/pepper-iot/pulsar-client-go/pkg/api"
+	"github.com/pepper-iot/pulsar-client-go/utils"
+)
+
+// Producer is implemented by *manage.ManagedProducer
+// and *manage.PartitionedProducer.
+type Producer interface {
+	// Send sends payload, waiting for the Producer to be
+	// available if it is reconnecting.
+	Send(ctx context.Context, payload []byte) (*api.CommandSendReceipt, error)
+	// SendMessage is like Send, but sends a message with the metadata
+	// set on it, such as its partition key or properties.
+	SendMessage(ctx context.Context, message pub.Message) (*api.CommandSendReceipt, error)
+	// SendValue encodes v using the configured Schema, then sends it.
+	SendValue(ctx context.Context, v interface{}) (*api.CommandSendReceipt, error)
+	// SendLatency returns the distribution of the round-trips of sends.
+	SendLatency() utils.HistogramSnapshot
+	// Done is closed once the producer is closed.
+	Done() <-chan struct{}
+	// Close closes the producer.
+	Close(ctx context.Context) error
+}
+
+// Consumer is implemented by *manage.ManagedConsumer.
+type Consumer interface {
+	// Receive returns a single message, waiting
+	// for one if none are available.
+	Receive(ctx context.Context) (msg.Message, error)
+	// ReceiveAsync sends received messages to msgs until ctx is done.
+	ReceiveAsync(ctx context.Context, msgs chan<- msg.Message) error
+	// Ack acknowledges a message.
+	Ack(ctx context.Context, msg msg.Message) error
+	// Nack asks the broker to redeliver a message.
+	Nack(ctx context.Context, msg msg.Message) error
+	// Process passes received messages to handle, acknowledging
+	// them on success and redelivering them on failure.
+	Process(ctx context.Context, handle func(msg.Message) error) error
+	// ProcessBacklog is like Process, but returns once the last
+	// message of the topic at the time of the call is handled.
+	ProcessBacklog(ctx context.Context, handle func(msg.Message) error) error
+	// Decode decodes the payload of a message into v using the
+	// configured Schema.
+	Decode(ctx context.Context, msg msg.Message, v interface{}) error
+	// RedeliverUnacknowledged asks the broker to redeliver
+	// all unacknowledged messages.
+	RedeliverUnacknowledged(ctx context.Context) error
+	// RedeliverOverflow asks the broker to redeliver the messages
+	// dropped because the queue was full, and returns their number.
+	RedeliverOverflow(ctx context.Context) (int, error)
+	// Unsubscribe removes the subscription.
+	Unsubscribe(ctx context.Context) error
+	// Done is closed once the consumer is closed.
+	Done() <-chan struct{}
+	// Close closes the consumer.
+	Close(ctx context.Context) error
+}
+
+// Client is implemented by *manage.ManagedClient.
+type Client interface {
+	// Get returns the client, waiting while
+	// it is temporarily unavailable.
+	Get(ctx context.Context) (*manage.Client, error)
+	// Done is closed once the client is stopped.
+	Done() <-chan struct{}
+	// Stop closes the client.
+	Stop() error
+}
+
+var (
+	_ Producer = (*manage.ManagedProducer)(nil)
+	_ Producer = (*manage.PartitionedProducer)(nil)
+	_ Consumer = (*manage.ManagedConsumer)(nil)
+	_ Client   = (*manage.ManagedClient)(nil)
+)
@@ -0,0 +1,70 @@
+// Copyright 2018 Comcast Cable Communications Management, LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pulsar
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pepper-iot/pulsar-client-go/core/manage"
+	"github.com/pepper-iot/pulsar-client-go/core/srv"
+	"github.com/pepper-iot/pulsar-client-go/pkg/api"
+)
+
+func TestManagedTypes(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	srv, err := srv.NewServer(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg := manage.ClientConfig{Addr: srv.Addr}
+
+	var c Client = manage.NewManagedClient(ctx, cfg)
+	if _, err := c.Get(ctx); err != nil {
+		t.Fatalf("Get() err = %v; expected nil", err)
+	}
+	if err := c.Stop(); err != nil {
+		t.Fatalf("Stop() err = %v; expected nil", err)
+	}
+	select {
+	case <-c.Done():
+	case <-ctx.Done():
+		t.Fatal("Done() blocked after Stop()")
+	}
+	if err = srv.AssertReceived(ctx, api.BaseCommand_CONNECT); err != nil {
+		t.Fatal(err)
+	}
+
+	var p Producer = manage.NewManagedProducer(ctx, manage.NewClientPool(), manage.ProducerConfig{
+		ClientConfig: cfg,
+		Topic:        "test-topic",
+	})
+	if _, err := p.Send(ctx, []byte("hi")); err != nil {
+		t.Fatalf("Send() err = %v; expected nil", err)
+	}
+	if err := p.Close(ctx); err != nil {
+		t.Fatalf("Close() err = %v; expected nil", err)
+	}
+	select {
+	case <-p.Done():
+	case <-ctx.Done():
+		t.Fatal("Done() blocked after Close()")
+	}
+	if got := p.SendLatency().Count; got != 1 {
+		t.Fatalf("SendLatency().Count = %d; expected 1", got)
+	}
+}
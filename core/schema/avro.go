@@ -0,0 +1,54 @@
+// Copyright 2018 Comcast Cable Communications Management, LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schema
+
+import (
+	"github.com/pepper-iot/pulsar-client-go/pkg/api"
+)
+
+// Codec encodes values into payloads and decodes them back,
+// eg using an Avro library.
+type Codec interface {
+	Encode(v interface{}) ([]byte, error)
+	Decode(data []byte, v interface{}) error
+}
+
+// NewAvro returns a Schema registering the Avro record schema
+// definition with the broker, e.g.
+//
+//	{"type":"record","name":"Reading","fields":[{"name":"value","type":"double"}]}
+//
+// This package doesn't implement the Avro binary encoding, so values
+// are encoded and decoded by codec, which must follow definition.
+func NewAvro(definition string, codec Codec) *Avro {
+	return &Avro{
+		Codec: codec,
+		info: Info{
+			Type:   api.Schema_Avro,
+			Schema: []byte(definition),
+		},
+	}
+}
+
+// Avro is a Schema for Avro payloads.
+type Avro struct {
+	Codec
+
+	info Info
+}
+
+// Info implements Schema.
+func (s *Avro) Info() Info {
+	return s.info
+}
@@ -0,0 +1,54 @@
+// Copyright 2018 Comcast Cable Communications Management, LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schema
+
+import (
+	"encoding/json"
+
+	"github.com/pepper-iot/pulsar-client-go/pkg/api"
+)
+
+// NewJSON returns a Schema encoding values with encoding/json.
+// As with other Pulsar clients, the definition registered with the
+// broker is an Avro record schema describing the JSON documents, e.g.
+//
+//	{"type":"record","name":"Reading","fields":[{"name":"value","type":"double"}]}
+func NewJSON(definition string) *JSON {
+	return &JSON{
+		info: Info{
+			Type:   api.Schema_Json,
+			Schema: []byte(definition),
+		},
+	}
+}
+
+// JSON is a Schema for JSON payloads.
+type JSON struct {
+	info Info
+}
+
+// Encode implements Schema.
+func (s *JSON) Encode(v interface{}) ([]byte, error) {
+	return json.Marshal(v)
+}
+
+// Decode implements Schema.
+func (s *JSON) Decode(data []byte, v interface{}) error {
+	return json.Unmarshal(data, v)
+}
+
+// Info implements Schema.
+func (s *JSON) Info() Info {
+	return s.info
+}
@@ -0,0 +1,90 @@
+// Copyright 2018 Comcast Cable Communications Management, LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schema
+
+import (
+	"fmt"
+
+	"github.com/pepper-iot/pulsar-client-go/pkg/api"
+)
+
+// NewString returns a Schema for UTF-8 string payloads.
+func NewString() *String {
+	return &String{info: Info{Type: api.Schema_String}}
+}
+
+// String is a Schema for string payloads.
+type String struct {
+	info Info
+}
+
+// Encode implements Schema. v must be a string.
+func (s *String) Encode(v interface{}) ([]byte, error) {
+	str, ok := v.(string)
+	if !ok {
+		return nil, fmt.Errorf("string schema can't encode %T", v)
+	}
+	return []byte(str), nil
+}
+
+// Decode implements Schema. v must be a *string.
+func (s *String) Decode(data []byte, v interface{}) error {
+	str, ok := v.(*string)
+	if !ok {
+		return fmt.Errorf("string schema can't decode into %T", v)
+	}
+	*str = string(data)
+	return nil
+}
+
+// Info implements Schema.
+func (s *String) Info() Info {
+	return s.info
+}
+
+// NewBytes returns a Schema for raw payloads, ie the
+// schema of topics without one.
+func NewBytes() *Bytes {
+	return &Bytes{info: Info{Type: api.Schema_None}}
+}
+
+// Bytes is a Schema for raw payloads.
+type Bytes struct {
+	info Info
+}
+
+// Encode implements Schema. v must be a []byte.
+func (s *Bytes) Encode(v interface{}) ([]byte, error) {
+	b, ok := v.([]byte)
+	if !ok {
+		return nil, fmt.Errorf("bytes schema can't encode %T", v)
+	}
+	return b, nil
+}
+
+// Decode implements Schema. v must be a *[]byte, which is
+// set to data, without copying it.
+func (s *Bytes) Decode(data []byte, v interface{}) error {
+	b, ok := v.(*[]byte)
+	if !ok {
+		return fmt.Errorf("bytes schema can't decode into %T", v)
+	}
+	*b = data
+	return nil
+}
+
+// Info implements Schema.
+func (s *Bytes) Info() Info {
+	return s.info
+}
@@ -0,0 +1,368 @@
+// Copyright 2018 Comcast Cable Communications Management, LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schema
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"strings"
+
+	"github.com/golang/protobuf/descriptor"
+	"github.com/golang/protobuf/proto"
+	pb "github.com/golang/protobuf/protoc-gen-go/descriptor"
+	"github.com/pepper-iot/pulsar-client-go/pkg/api"
+)
+
+// NewProtobuf returns a PROTOBUF Schema for messages of the same type as
+// msg. As with the Java client, the definition registered with the broker
+// is an Avro record schema generated from the message descriptor.
+func NewProtobuf(msg descriptor.Message) (*Protobuf, error) {
+	files, err := loadFiles(msg)
+	if err != nil {
+		return nil, err
+	}
+	fd, md := descriptor.ForMessage(msg)
+
+	g := avroGen{
+		files:   files,
+		defined: make(map[string]bool),
+	}
+	def, err := json.Marshal(g.record(fd.GetPackage(), md))
+	if err != nil {
+		return nil, err
+	}
+
+	return &Protobuf{
+		info: Info{
+			Type:   api.Schema_Protobuf,
+			Schema: def,
+		},
+	}, nil
+}
+
+// NewProtobufNative returns a PROTOBUF_NATIVE Schema for messages of the
+// same type as msg. The definition registered with the broker holds the
+// descriptors of msg's file and all of its dependencies.
+func NewProtobufNative(msg descriptor.Message) (*Protobuf, error) {
+	files, err := loadFiles(msg)
+	if err != nil {
+		return nil, err
+	}
+	fd, md := descriptor.ForMessage(msg)
+
+	set, err := proto.Marshal(&pb.FileDescriptorSet{File: files})
+	if err != nil {
+		return nil, err
+	}
+
+	rootName := md.GetName()
+	if pkg := fd.GetPackage(); pkg != "" {
+		rootName = pkg + "." + rootName
+	}
+
+	// encoding/json encodes []byte as base64
+	def, err := json.Marshal(struct {
+		FileDescriptorSet      []byte `json:"fileDescriptorSet"`
+		RootMessageTypeName    string `json:"rootMessageTypeName"`
+		RootFileDescriptorName string `json:"rootFileDescriptorName"`
+	}{
+		FileDescriptorSet:      set,
+		RootMessageTypeName:    rootName,
+		RootFileDescriptorName: fd.GetName(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &Protobuf{
+		info: Info{
+			Type:   api.Schema_ProtobufNative,
+			Schema: def,
+		},
+	}, nil
+}
+
+// Protobuf is a Schema for protobuf payloads.
+type Protobuf struct {
+	info Info
+}
+
+// Encode implements Schema. v must be a proto.Message.
+func (s *Protobuf) Encode(v interface{}) ([]byte, error) {
+	msg, ok := v.(proto.Message)
+	if !ok {
+		return nil, fmt.Errorf("protobuf schema can't encode %T", v)
+	}
+	return proto.Marshal(msg)
+}
+
+// Decode implements Schema. v must be a proto.Message.
+func (s *Protobuf) Decode(data []byte, v interface{}) error {
+	msg, ok := v.(proto.Message)
+	if !ok {
+		return fmt.Errorf("protobuf schema can't decode into %T", v)
+	}
+	return proto.Unmarshal(data, msg)
+}
+
+// Info implements Schema.
+func (s *Protobuf) Info() Info {
+	return s.info
+}
+
+// loadFiles returns the descriptor of the file defining msg, preceded
+// by the descriptors of all of its transitive dependencies.
+func loadFiles(msg descriptor.Message) ([]*pb.FileDescriptorProto, error) {
+	var files []*pb.FileDescriptorProto
+	seen := make(map[string]bool)
+
+	var load func(fd *pb.FileDescriptorProto) error
+	load = func(fd *pb.FileDescriptorProto) error {
+		seen[fd.GetName()] = true
+		for _, dep := range fd.GetDependency() {
+			if seen[dep] {
+				continue
+			}
+			gz := proto.FileDescriptor(dep)
+			if gz == nil {
+				return fmt.Errorf("descriptor of %q isn't registered", dep)
+			}
+			depFd, err := extractFile(gz)
+			if err != nil {
+				return err
+			}
+			if err := load(depFd); err != nil {
+				return err
+			}
+		}
+		files = append(files, fd)
+		return nil
+	}
+
+	fd, _ := descriptor.ForMessage(msg)
+	if err := load(fd); err != nil {
+		return nil, err
+	}
+	return files, nil
+}
+
+// extractFile extracts a FileDescriptorProto from a gzip'd buffer,
+// as registered by generated code.
+func extractFile(gz []byte) (*pb.FileDescriptorProto, error) {
+	r, err := gzip.NewReader(bytes.NewReader(gz))
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+
+	b, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	fd := new(pb.FileDescriptorProto)
+	if err := proto.Unmarshal(b, fd); err != nil {
+		return nil, err
+	}
+	return fd, nil
+}
+
+// avroGen generates Avro schemas from protobuf descriptors,
+// following the mapping of Avro's ProtobufData.
+type avroGen struct {
+	files   []*pb.FileDescriptorProto
+	defined map[string]bool // full names of the Avro types already defined
+}
+
+// record returns the Avro record for the message md
+// of the given package.
+func (g *avroGen) record(namespace string, md *pb.DescriptorProto) interface{} {
+	fullName := md.GetName()
+	if namespace != "" {
+		fullName = namespace + "." + fullName
+	}
+	if g.defined[fullName] {
+		return fullName
+	}
+	g.defined[fullName] = true
+
+	fields := make([]map[string]interface{}, 0, len(md.GetField()))
+	for _, f := range md.GetField() {
+		field := map[string]interface{}{
+			"name": f.GetName(),
+			"type": g.fieldType(f),
+		}
+		if f.GetType() == pb.FieldDescriptorProto_TYPE_MESSAGE &&
+			f.GetLabel() != pb.FieldDescriptorProto_LABEL_REPEATED {
+			field["default"] = nil
+		}
+		fields = append(fields, field)
+	}
+
+	return map[string]interface{}{
+		"type":      "record",
+		"name":      md.GetName(),
+		"namespace": namespace,
+		"fields":    fields,
+	}
+}
+
+// fieldType returns the Avro type of the field f.
+func (g *avroGen) fieldType(f *pb.FieldDescriptorProto) interface{} {
+	var t interface{}
+
+	switch f.GetType() {
+	case pb.FieldDescriptorProto_TYPE_DOUBLE:
+		t = "double"
+	case pb.FieldDescriptorProto_TYPE_FLOAT:
+		t = "float"
+	case pb.FieldDescriptorProto_TYPE_INT64, pb.FieldDescriptorProto_TYPE_UINT64,
+		pb.FieldDescriptorProto_TYPE_SINT64, pb.FieldDescriptorProto_TYPE_FIXED64,
+		pb.FieldDescriptorProto_TYPE_SFIXED64:
+		t = "long"
+	case pb.FieldDescriptorProto_TYPE_INT32, pb.FieldDescriptorProto_TYPE_UINT32,
+		pb.FieldDescriptorProto_TYPE_SINT32, pb.FieldDescriptorProto_TYPE_FIXED32,
+		pb.FieldDescriptorProto_TYPE_SFIXED32:
+		t = "int"
+	case pb.FieldDescriptorProto_TYPE_BOOL:
+		t = "boolean"
+	case pb.FieldDescriptorProto_TYPE_STRING:
+		t = "string"
+	case pb.FieldDescriptorProto_TYPE_BYTES:
+		t = "bytes"
+	case pb.FieldDescriptorProto_TYPE_ENUM:
+		t = g.enum(f.GetTypeName())
+	case pb.FieldDescriptorProto_TYPE_MESSAGE:
+		t = g.message(f.GetTypeName())
+		if f.GetLabel() != pb.FieldDescriptorProto_LABEL_REPEATED {
+			// messages are optional
+			t = []interface{}{"null", t}
+		}
+	default:
+		// groups are not supported by Avro
+		t = "bytes"
+	}
+
+	if f.GetLabel() == pb.FieldDescriptorProto_LABEL_REPEATED {
+		t = map[string]interface{}{
+			"type":  "array",
+			"items": t,
+		}
+	}
+	return t
+}
+
+// message returns the Avro record for the
+// fully-qualified protobuf message name.
+func (g *avroGen) message(typeName string) interface{} {
+	namespace, md := g.findMessage(typeName)
+	if md == nil {
+		return "bytes"
+	}
+	return g.record(namespace, md)
+}
+
+// enum returns the Avro enum for the
+// fully-qualified protobuf enum name.
+func (g *avroGen) enum(typeName string) interface{} {
+	fullName := strings.TrimPrefix(typeName, ".")
+	if g.defined[fullName] {
+		return fullName
+	}
+
+	namespace, ed := g.findEnum(typeName)
+	if ed == nil {
+		return "string"
+	}
+	g.defined[fullName] = true
+
+	symbols := make([]string, len(ed.GetValue()))
+	for i, v := range ed.GetValue() {
+		symbols[i] = v.GetName()
+	}
+	return map[string]interface{}{
+		"type":      "enum",
+		"name":      ed.GetName(),
+		"namespace": namespace,
+		"symbols":   symbols,
+	}
+}
+
+// findMessage returns the descriptor of the fully-qualified message
+// name (e.g. ".pkg.Outer.Inner"), and the namespace it belongs to
+// (e.g. "pkg.Outer").
+func (g *avroGen) findMessage(typeName string) (string, *pb.DescriptorProto) {
+	for _, fd := range g.files {
+		prefix := "." + fd.GetPackage()
+		if fd.GetPackage() == "" {
+			prefix = ""
+		}
+		for _, md := range fd.GetMessageType() {
+			if ns, found := findNested(prefix, md, typeName); found != nil {
+				return ns, found
+			}
+		}
+	}
+	return "", nil
+}
+
+func findNested(prefix string, md *pb.DescriptorProto, typeName string) (string, *pb.DescriptorProto) {
+	name := prefix + "." + md.GetName()
+	if name == typeName {
+		return strings.TrimPrefix(prefix, "."), md
+	}
+	if !strings.HasPrefix(typeName, name+".") {
+		return "", nil
+	}
+	for _, nested := range md.GetNestedType() {
+		if ns, found := findNested(name, nested, typeName); found != nil {
+			return ns, found
+		}
+	}
+	return "", nil
+}
+
+// findEnum returns the descriptor of the fully-qualified enum
+// name, and the namespace it belongs to.
+func (g *avroGen) findEnum(typeName string) (string, *pb.EnumDescriptorProto) {
+	i := strings.LastIndex(typeName, ".")
+	parent, name := typeName[:i], typeName[i+1:]
+
+	for _, fd := range g.files {
+		if "."+fd.GetPackage() == parent || (fd.GetPackage() == "" && parent == "") {
+			for _, ed := range fd.GetEnumType() {
+				if ed.GetName() == name {
+					return fd.GetPackage(), ed
+				}
+			}
+		}
+	}
+
+	ns, md := g.findMessage(parent)
+	if md == nil {
+		return "", nil
+	}
+	for _, ed := range md.GetEnumType() {
+		if ed.GetName() == name {
+			if ns != "" {
+				return ns + "." + md.GetName(), ed
+			}
+			return md.GetName(), ed
+		}
+	}
+	return "", nil
+}
@@ -0,0 +1,125 @@
+// Copyright 2018 Comcast Cable Communications Management, LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schema
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	pb "github.com/golang/protobuf/protoc-gen-go/descriptor"
+	"github.com/pepper-iot/pulsar-client-go/pkg/api"
+)
+
+func TestNewProtobuf(t *testing.T) {
+	s, err := NewProtobuf(&api.CommandSubscribe{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, expected := s.Info().Type, api.Schema_Protobuf; got != expected {
+		t.Fatalf("Info() type = %v; expected %v", got, expected)
+	}
+
+	var def struct {
+		Type      string `json:"type"`
+		Name      string `json:"name"`
+		Namespace string `json:"namespace"`
+		Fields    []struct {
+			Name string          `json:"name"`
+			Type json.RawMessage `json:"type"`
+		} `json:"fields"`
+	}
+	if err = json.Unmarshal(s.Info().Schema, &def); err != nil {
+		t.Fatalf("definition is not valid JSON: %v", err)
+	}
+	if def.Type != "record" || def.Name != "CommandSubscribe" || def.Namespace != "pulsar.proto" {
+		t.Fatalf("got record %s %s.%s; expected record pulsar.proto.CommandSubscribe", def.Type, def.Namespace, def.Name)
+	}
+
+	types := make(map[string]string)
+	for _, f := range def.Fields {
+		types[f.Name] = string(f.Type)
+	}
+	if got, expected := types["consumer_id"], `"long"`; got != expected {
+		t.Fatalf("consumer_id type = %s; expected %s", got, expected)
+	}
+	if got := types["subType"]; !strings.Contains(got, `"symbols":["Exclusive","Shared","Failover","Key_Shared"]`) {
+		t.Fatalf("subType type = %s; expected enum of subscription types", got)
+	}
+	// KeyValue is defined once, then referenced by name
+	if got := types["schema"]; !strings.Contains(got, `"items":"pulsar.proto.KeyValue"`) {
+		t.Fatalf("schema type = %s; expected reference to pulsar.proto.KeyValue", got)
+	}
+}
+
+func TestNewProtobufNative(t *testing.T) {
+	s, err := NewProtobufNative(&api.CommandSubscribe{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, expected := s.Info().Type, api.Schema_ProtobufNative; got != expected {
+		t.Fatalf("Info() type = %v; expected %v", got, expected)
+	}
+
+	var def struct {
+		FileDescriptorSet      []byte `json:"fileDescriptorSet"`
+		RootMessageTypeName    string `json:"rootMessageTypeName"`
+		RootFileDescriptorName string `json:"rootFileDescriptorName"`
+	}
+	if err = json.Unmarshal(s.Info().Schema, &def); err != nil {
+		t.Fatalf("definition is not valid JSON: %v", err)
+	}
+	if got, expected := def.RootMessageTypeName, "pulsar.proto.CommandSubscribe"; got != expected {
+		t.Fatalf("rootMessageTypeName = %q; expected %q", got, expected)
+	}
+	if got, expected := def.RootFileDescriptorName, "PulsarApi.proto"; got != expected {
+		t.Fatalf("rootFileDescriptorName = %q; expected %q", got, expected)
+	}
+
+	var set pb.FileDescriptorSet
+	if err = proto.Unmarshal(def.FileDescriptorSet, &set); err != nil {
+		t.Fatal(err)
+	}
+	if got, expected := len(set.GetFile()), 1; got != expected {
+		t.Fatalf("got %d file descriptors; expected %d", got, expected)
+	}
+}
+
+func TestProtobuf_EncodeDecode(t *testing.T) {
+	s, err := NewProtobuf(&api.MessageIdData{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := s.Encode(&api.MessageIdData{
+		LedgerId: proto.Uint64(1),
+		EntryId:  proto.Uint64(2),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var id api.MessageIdData
+	if err = s.Decode(data, &id); err != nil {
+		t.Fatal(err)
+	}
+	if id.GetLedgerId() != 1 || id.GetEntryId() != 2 {
+		t.Fatalf("Decode() = %v; expected ledger 1, entry 2", &id)
+	}
+
+	if _, err = s.Encode("not a message"); err == nil {
+		t.Fatal("Encode() err = nil; expected error for non-proto value")
+	}
+}
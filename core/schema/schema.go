@@ -0,0 +1,89 @@
+// Copyright 2018 Comcast Cable Communications Management, LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package schema describes the format of message payloads to the
+// broker, and encodes and decodes payloads accordingly.
+package schema
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/pepper-iot/pulsar-client-go/pkg/api"
+)
+
+// Schema encodes values into message payloads and decodes them back.
+// Its Info is registered with the broker when creating producers and
+// consumers, so that schema-enforced topics accept them.
+type Schema interface {
+	Encode(v interface{}) ([]byte, error)
+	Decode(data []byte, v interface{}) error
+	Info() Info
+}
+
+// ErrUnsupportedType is returned by FromInfo for schema
+// types this package can't decode.
+var ErrUnsupportedType = errors.New("unsupported schema type")
+
+// FromInfo returns a Schema for the given Info, typically one
+// fetched from the broker to decode messages produced with a
+// previous version of a topic's schema. Avro schemas are
+// unsupported, since they need a Codec.
+func FromInfo(info Info) (Schema, error) {
+	switch info.Type {
+	case api.Schema_None:
+		return &Bytes{info: info}, nil
+	case api.Schema_String:
+		return &String{info: info}, nil
+	case api.Schema_Json:
+		return &JSON{info: info}, nil
+	case api.Schema_Protobuf, api.Schema_ProtobufNative:
+		return &Protobuf{info: info}, nil
+	default:
+		return nil, fmt.Errorf("%w: %v", ErrUnsupportedType, info.Type)
+	}
+}
+
+// Info describes a schema to the broker.
+type Info struct {
+	Name       string
+	Type       api.Schema_Type
+	Schema     []byte // schema definition, its format depends on Type
+	Properties map[string]string
+}
+
+// Proto returns the Info as sent in PRODUCER and SUBSCRIBE commands.
+func (i Info) Proto() *api.Schema {
+	keys := make([]string, 0, len(i.Properties))
+	for k := range i.Properties {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	properties := make([]*api.KeyValue, len(keys))
+	for j, k := range keys {
+		properties[j] = &api.KeyValue{
+			Key:   proto.String(k),
+			Value: proto.String(i.Properties[k]),
+		}
+	}
+
+	return &api.Schema{
+		Name:       proto.String(i.Name),
+		SchemaData: i.Schema,
+		Type:       i.Type.Enum(),
+		Properties: properties,
+	}
+}
@@ -0,0 +1,133 @@
+// Copyright 2018 Comcast Cable Communications Management, LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schema
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/pepper-iot/pulsar-client-go/pkg/api"
+)
+
+func TestInfo_Proto(t *testing.T) {
+	info := Info{
+		Name:   "test",
+		Type:   api.Schema_Json,
+		Schema: []byte("{}"),
+		Properties: map[string]string{
+			"b": "2",
+			"a": "1",
+		},
+	}
+
+	s := info.Proto()
+	if got, expected := s.GetType(), api.Schema_Json; got != expected {
+		t.Fatalf("Proto() type = %v; expected %v", got, expected)
+	}
+	if got, expected := string(s.GetSchemaData()), "{}"; got != expected {
+		t.Fatalf("Proto() schema data = %q; expected %q", got, expected)
+	}
+	// properties are sorted by key
+	var keys string
+	for _, kv := range s.GetProperties() {
+		keys += kv.GetKey() + "=" + kv.GetValue() + " "
+	}
+	if expected := "a=1 b=2 "; keys != expected {
+		t.Fatalf("Proto() properties = %q; expected %q", keys, expected)
+	}
+}
+
+func TestJSON(t *testing.T) {
+	type reading struct {
+		Value float64 `json:"value"`
+	}
+
+	s := NewJSON(`{"type":"record","name":"Reading","fields":[{"name":"value","type":"double"}]}`)
+	if got, expected := s.Info().Type, api.Schema_Json; got != expected {
+		t.Fatalf("Info() type = %v; expected %v", got, expected)
+	}
+
+	data, err := s.Encode(reading{Value: 1.5})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, expected := string(data), `{"value":1.5}`; got != expected {
+		t.Fatalf("Encode() = %q; expected %q", got, expected)
+	}
+
+	var r reading
+	if err = s.Decode(data, &r); err != nil {
+		t.Fatal(err)
+	}
+	if got, expected := r.Value, 1.5; got != expected {
+		t.Fatalf("Decode() value = %v; expected %v", got, expected)
+	}
+}
+
+func TestFromInfo(t *testing.T) {
+	for _, typ := range []api.Schema_Type{api.Schema_None, api.Schema_String, api.Schema_Json, api.Schema_Protobuf, api.Schema_ProtobufNative} {
+		s, err := FromInfo(Info{Type: typ})
+		if err != nil {
+			t.Fatalf("FromInfo(%v) err = %v; expected nil", typ, err)
+		}
+		if got := s.Info().Type; got != typ {
+			t.Fatalf("FromInfo(%v).Info().Type = %v", typ, got)
+		}
+	}
+
+	if _, err := FromInfo(Info{Type: api.Schema_Avro}); !errors.Is(err, ErrUnsupportedType) {
+		t.Fatalf("FromInfo(Avro) err = %v; expected %v", err, ErrUnsupportedType)
+	}
+}
+
+func TestString(t *testing.T) {
+	s := NewString()
+	data, err := s.Encode("hola")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var str string
+	if err = s.Decode(data, &str); err != nil {
+		t.Fatal(err)
+	}
+	if got, expected := str, "hola"; got != expected {
+		t.Fatalf("Decode() = %q; expected %q", got, expected)
+	}
+	if _, err = s.Encode(1); err == nil {
+		t.Fatal("Encode(1) err = nil; expected an error")
+	}
+}
+
+// jsonCodec stands for an Avro codec.
+type jsonCodec struct {
+	JSON
+}
+
+func TestAvro(t *testing.T) {
+	definition := `{"type":"record","name":"Test","fields":[]}`
+	s := NewAvro(definition, &jsonCodec{})
+	if got, expected := s.Info().Type, api.Schema_Avro; got != expected {
+		t.Fatalf("Info() type = %v; expected %v", got, expected)
+	}
+	if got := string(s.Info().Schema); got != definition {
+		t.Fatalf("Info() schema = %q; expected %q", got, definition)
+	}
+	data, err := s.Encode(map[string]int{"a": 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, expected := string(data), `{"a":1}`; got != expected {
+		t.Fatalf("Encode() = %q; expected %q", got, expected)
+	}
+}
@@ -55,7 +55,8 @@ func (d *Discoverer) PartitionedMetadata(ctx context.Context, topic string) (*ap
 		},
 	}
 
-	resp, cancel, err := d.Dispatcher.RegisterReqID(*requestID)
+	deadline, _ := ctx.Deadline()
+	resp, cancel, err := d.Dispatcher.RegisterReqIDDeadline(*requestID, deadline)
 	if err != nil {
 		return nil, err
 	}
@@ -93,7 +94,8 @@ func (d *Discoverer) LookupTopic(ctx context.Context, topic string, authoritativ
 		},
 	}
 
-	resp, cancel, err := d.Dispatcher.RegisterReqID(*requestID)
+	deadline, _ := ctx.Deadline()
+	resp, cancel, err := d.Dispatcher.RegisterReqIDDeadline(*requestID, deadline)
 	if err != nil {
 		return nil, err
 	}
@@ -113,3 +115,76 @@ func (d *Discoverer) LookupTopic(ctx context.Context, topic string, authoritativ
 		return f.BaseCmd.GetLookupTopicResponse(), nil
 	}
 }
+
+// GetSchema performs a GET_SCHEMA request for the given topic. If version
+// is nil, the latest version of the topic's schema is requested.
+func (d *Discoverer) GetSchema(ctx context.Context, topic string, version []byte) (*api.CommandGetSchemaResponse, error) {
+	requestID := d.ReqID.Next()
+
+	cmd := api.BaseCommand{
+		Type: api.BaseCommand_GET_SCHEMA.Enum(),
+		GetSchema: &api.CommandGetSchema{
+			RequestId:     requestID,
+			Topic:         proto.String(topic),
+			SchemaVersion: version,
+		},
+	}
+
+	deadline, _ := ctx.Deadline()
+	resp, cancel, err := d.Dispatcher.RegisterReqIDDeadline(*requestID, deadline)
+	if err != nil {
+		return nil, err
+	}
+	defer cancel()
+
+	if err := d.S.SendSimpleCmd(cmd); err != nil {
+		return nil, err
+	}
+
+	// wait for response or timeout
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+
+	case f := <-resp:
+		return f.BaseCmd.GetGetSchemaResponse(), nil
+	}
+}
+
+// GetOrCreateSchema performs a GET_OR_CREATE_SCHEMA request, which
+// registers schema as a version of the topic's schema, unless it is
+// already one. The response holds the version of schema.
+func (d *Discoverer) GetOrCreateSchema(ctx context.Context, topic string, schema *api.Schema) (*api.CommandGetOrCreateSchemaResponse, error) {
+	requestID := d.ReqID.Next()
+
+	cmd := api.BaseCommand{
+		Type: api.BaseCommand_GET_OR_CREATE_SCHEMA.Enum(),
+		GetOrCreateSchema: &api.CommandGetOrCreateSchema{
+			RequestId: requestID,
+			Topic:     proto.String(topic),
+			Schema:    schema,
+		},
+	}
+
+	deadline, _ := ctx.Deadline()
+	resp, cancel, err := d.Dispatcher.RegisterReqIDDeadline(*requestID, deadline)
+	if err != nil {
+		return nil, err
+	}
+	defer cancel()
+
+	if err := d.S.SendSimpleCmd(cmd); err != nil {
+		return nil, err
+	}
+
+	// wait for response or timeout
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+
+	case f := <-resp:
+		return f.BaseCmd.GetGetOrCreateSchemaResponse(), nil
+	}
+}
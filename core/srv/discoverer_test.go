@@ -182,3 +182,115 @@ func TestDiscoverer_LookupTopic_BadRequestID(t *testing.T) {
 	}
 	t.Logf("discoverer.lookupTopic() err = %v", r.err)
 }
+
+func TestDiscoverer_GetSchema(t *testing.T) {
+	var ms frame.MockSender
+	id := uint64(43)
+	reqID := msg.MonotonicID{ID: id}
+
+	dispatcher := frame.NewFrameDispatcher()
+	d := NewDiscoverer(&ms, dispatcher, &reqID)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	type response struct {
+		success *api.CommandGetSchemaResponse
+		err     error
+	}
+	resp := make(chan response, 1)
+
+	go func() {
+		var r response
+		r.success, r.err = d.GetSchema(ctx, "test", []byte{1})
+		resp <- r
+	}()
+
+	// Allow goroutine time to complete
+	time.Sleep(100 * time.Millisecond)
+
+	expected := api.CommandGetSchemaResponse{
+		RequestId:     proto.Uint64(id),
+		SchemaVersion: []byte{1},
+	}
+	f := frame.Frame{
+		BaseCmd: &api.BaseCommand{
+			Type:              api.BaseCommand_GET_SCHEMA_RESPONSE.Enum(),
+			GetSchemaResponse: &expected,
+		},
+	}
+	if err := dispatcher.NotifyReqID(id, f); err != nil {
+		t.Fatalf("HandleReqID() err = %v; nil expected", err)
+	}
+
+	r := <-resp
+	if r.err != nil {
+		t.Fatalf("discoverer.GetSchema() err = %v; nil expected", r.err)
+	}
+
+	if !proto.Equal(r.success, &expected) {
+		t.Fatalf("discoverer.GetSchema() response = %v; expected %v", r.success, expected)
+	}
+
+	if got, expected := ms.GetFrames()[0].BaseCmd.GetGetSchema().GetSchemaVersion(), []byte{1}; string(got) != string(expected) {
+		t.Fatalf("GET_SCHEMA schema version = %v; expected %v", got, expected)
+	}
+}
+
+func TestDiscoverer_GetOrCreateSchema(t *testing.T) {
+	var ms frame.MockSender
+	id := uint64(43)
+	reqID := msg.MonotonicID{ID: id}
+
+	dispatcher := frame.NewFrameDispatcher()
+	d := NewDiscoverer(&ms, dispatcher, &reqID)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	type response struct {
+		success *api.CommandGetOrCreateSchemaResponse
+		err     error
+	}
+	resp := make(chan response, 1)
+
+	schema := &api.Schema{
+		Name:       proto.String("test"),
+		Type:       api.Schema_String.Enum(),
+		SchemaData: []byte{},
+	}
+	go func() {
+		var r response
+		r.success, r.err = d.GetOrCreateSchema(ctx, "test", schema)
+		resp <- r
+	}()
+
+	// Allow goroutine time to complete
+	time.Sleep(100 * time.Millisecond)
+
+	if got := ms.GetFrames()[0].BaseCmd.GetGetOrCreateSchema(); got.GetTopic() != "test" || !proto.Equal(got.GetSchema(), schema) {
+		t.Fatalf("GET_OR_CREATE_SCHEMA = %v; expected the schema of topic test", got)
+	}
+
+	expected := api.CommandGetOrCreateSchemaResponse{
+		RequestId:     proto.Uint64(id),
+		SchemaVersion: []byte{1},
+	}
+	f := frame.Frame{
+		BaseCmd: &api.BaseCommand{
+			Type:                      api.BaseCommand_GET_OR_CREATE_SCHEMA_RESPONSE.Enum(),
+			GetOrCreateSchemaResponse: &expected,
+		},
+	}
+	if err := dispatcher.NotifyReqID(id, f); err != nil {
+		t.Fatalf("HandleReqID() err = %v; nil expected", err)
+	}
+
+	r := <-resp
+	if r.err != nil {
+		t.Fatalf("discoverer.GetOrCreateSchema() err = %v; nil expected", r.err)
+	}
+	if !proto.Equal(r.success, &expected) {
+		t.Fatalf("discoverer.GetOrCreateSchema() response = %v; expected %v", r.success, expected)
+	}
+}
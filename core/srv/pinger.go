@@ -44,7 +44,8 @@ type Pinger struct {
 // waits for either a PONG response or the context to
 // timeout.
 func (p *Pinger) Ping(ctx context.Context) error {
-	resp, cancel, err := p.Dispatcher.RegisterGlobal()
+	deadline, _ := ctx.Deadline()
+	resp, cancel, err := p.Dispatcher.RegisterGlobalDeadline(deadline)
 	if err != nil {
 		return err
 	}
@@ -16,11 +16,14 @@ package srv
 import (
 	"bytes"
 	"context"
+	"encoding/binary"
 	"fmt"
 	"io"
+	"math"
 	"net"
 	"os"
 	"sync"
+	"sync/atomic"
 
 	"github.com/golang/protobuf/proto"
 	"github.com/pepper-iot/pulsar-client-go/core/frame"
@@ -52,6 +55,8 @@ func NewServer(ctx context.Context) (*Server, error) {
 		Addr:             fmt.Sprintf("pulsar://%s", l.Addr().String()),
 		Received:         received,
 		topicLookupResps: make(map[string]topicLookupResp),
+		schemas:          make(map[string][]topicSchema),
+		partitions:       make(map[string]uint32),
 		conns:            make(map[string]net.Conn),
 	}
 
@@ -72,15 +77,20 @@ func NewServer(ctx context.Context) (*Server, error) {
 
 			// close all connections when
 			// context is canceled
+			closed := make(chan struct{})
 			go func() {
-				<-ctx.Done()
-				_ = c.Close()
+				select {
+				case <-ctx.Done():
+					_ = c.Close()
+				case <-closed:
+				}
 			}()
 
 			// handle individual connection
 			go func(c net.Conn, remoteAddr string) {
 				defer func() {
 					// cleanup connection
+					close(closed)
 					_ = c.Close()
 					srv.mu.Lock()
 					delete(srv.conns, remoteAddr)
@@ -121,6 +131,20 @@ type Server struct {
 	trmu             sync.Mutex
 	topicLookupResps map[string]topicLookupResp // map of topic -> topicLookupResp
 
+	smu     sync.Mutex
+	schemas map[string][]topicSchema // map of topic -> versions of its schema, latest last
+
+	lmu           sync.Mutex
+	lastMessageID *api.MessageIdData // returned for GET_LAST_MESSAGE_ID requests
+
+	pmu        sync.Mutex
+	partitions map[string]uint32 // map of topic -> number of partitions
+
+	entries uint64 // number of SEND commands received, the entry id of the next receipt; accessed atomically
+
+	semu       sync.Mutex        // protects following
+	sendErrors []api.ServerError // errors returned for the next SEND commands, in order
+
 	imu            sync.Mutex // protects following
 	ignoreConnects bool
 	ignorePings    bool
@@ -130,6 +154,11 @@ type Server struct {
 	conns      map[string]net.Conn
 }
 
+type topicSchema struct {
+	schema  *api.Schema
+	version []byte
+}
+
 type topicLookupResp struct {
 	respType               api.CommandLookupTopicResponse_LookupType
 	proxyThroughServiceURL bool
@@ -184,6 +213,69 @@ func (m *Server) SetTopicLookupResp(topic, serviceURL string, respType api.Comma
 	m.trmu.Unlock()
 }
 
+// SetSchema adds a version of the schema of the given topic. GET_SCHEMA
+// requests are answered with the requested version, or the latest one
+// if none is requested. Unknown topics and versions get a TopicNotFound
+// error.
+func (m *Server) SetSchema(topic string, schema *api.Schema, version []byte) {
+	m.smu.Lock()
+	m.schemas[topic] = append(m.schemas[topic], topicSchema{
+		schema:  schema,
+		version: version,
+	})
+	m.smu.Unlock()
+}
+
+// registerSchema returns the version of the topic's schema of producers
+// and GET_OR_CREATE_SCHEMA requests, which is added as the latest
+// version, numbered like brokers do, unless it is already one of its
+// versions.
+func (m *Server) registerSchema(topic string, schema *api.Schema) []byte {
+	m.smu.Lock()
+	defer m.smu.Unlock()
+
+	versions := m.schemas[topic]
+	for _, v := range versions {
+		if proto.Equal(v.schema, schema) {
+			return v.version
+		}
+	}
+	version := make([]byte, 8)
+	binary.BigEndian.PutUint64(version, uint64(len(versions)))
+	m.schemas[topic] = append(versions, topicSchema{
+		schema:  schema,
+		version: version,
+	})
+	return version
+}
+
+// SetLastMessageID sets the message ID returned for
+// GET_LAST_MESSAGE_ID requests, of any consumer. If not
+// set, that of an empty topic is returned.
+func (m *Server) SetLastMessageID(id *api.MessageIdData) {
+	m.lmu.Lock()
+	m.lastMessageID = id
+	m.lmu.Unlock()
+}
+
+// SetPartitions sets the number of partitions returned for
+// PARTITIONED_METADATA requests for the topic. Topics are
+// not partitioned unless set.
+func (m *Server) SetPartitions(topic string, n uint32) {
+	m.pmu.Lock()
+	m.partitions[topic] = n
+	m.pmu.Unlock()
+}
+
+// SetSendErrors makes the server answer the next SEND commands
+// with SEND_ERRORs of the given codes, in order, instead of
+// SEND_RECEIPTs.
+func (m *Server) SetSendErrors(codes ...api.ServerError) {
+	m.semu.Lock()
+	m.sendErrors = append(m.sendErrors, codes...)
+	m.semu.Unlock()
+}
+
 // TotalNumConns returns the total number of connections
 // (active or inactive) received by the Server.
 func (m *Server) TotalNumConns() int {
@@ -305,12 +397,18 @@ func (m *Server) handleFrame(f frame.Frame, remoteAddr string) *frame.Frame {
 
 	// allow Producers to be created
 	case api.BaseCommand_PRODUCER:
+		req := f.BaseCmd.GetProducer()
+		var version []byte
+		if req.Schema != nil {
+			version = m.registerSchema(req.GetTopic(), req.Schema)
+		}
 		return &frame.Frame{
 			BaseCmd: &api.BaseCommand{
 				Type: api.BaseCommand_PRODUCER_SUCCESS.Enum(),
 				ProducerSuccess: &api.CommandProducerSuccess{
-					RequestId:    f.BaseCmd.GetProducer().RequestId,
-					ProducerName: proto.String("test"),
+					RequestId:     req.RequestId,
+					ProducerName:  proto.String("test"),
+					SchemaVersion: version,
 				},
 			},
 		}
@@ -326,13 +424,155 @@ func (m *Server) handleFrame(f frame.Frame, remoteAddr string) *frame.Frame {
 			},
 		}
 
+	// allow Producers and Consumers to be closed
+	case api.BaseCommand_CLOSE_PRODUCER:
+		return &frame.Frame{
+			BaseCmd: &api.BaseCommand{
+				Type: api.BaseCommand_SUCCESS.Enum(),
+				Success: &api.CommandSuccess{
+					RequestId: f.BaseCmd.GetCloseProducer().RequestId,
+				},
+			},
+		}
+
+	case api.BaseCommand_CLOSE_CONSUMER:
+		return &frame.Frame{
+			BaseCmd: &api.BaseCommand{
+				Type: api.BaseCommand_SUCCESS.Enum(),
+				Success: &api.CommandSuccess{
+					RequestId: f.BaseCmd.GetCloseConsumer().RequestId,
+				},
+			},
+		}
+
+	// allow Consumers to seek; unlike brokers,
+	// the server doesn't then close them
+	case api.BaseCommand_SEEK:
+		return &frame.Frame{
+			BaseCmd: &api.BaseCommand{
+				Type: api.BaseCommand_SUCCESS.Enum(),
+				Success: &api.CommandSuccess{
+					RequestId: f.BaseCmd.GetSeek().RequestId,
+				},
+			},
+		}
+
 	case api.BaseCommand_SEND:
+		m.semu.Lock()
+		var code *api.ServerError
+		if len(m.sendErrors) > 0 {
+			code = m.sendErrors[0].Enum()
+			m.sendErrors = m.sendErrors[1:]
+		}
+		m.semu.Unlock()
+		if code != nil {
+			return &frame.Frame{
+				BaseCmd: &api.BaseCommand{
+					Type: api.BaseCommand_SEND_ERROR.Enum(),
+					SendError: &api.CommandSendError{
+						ProducerId: f.BaseCmd.GetSend().ProducerId,
+						SequenceId: f.BaseCmd.GetSend().SequenceId,
+						Error:      code,
+						Message:    proto.String(code.String()),
+					},
+				},
+			}
+		}
+
 		return &frame.Frame{
 			BaseCmd: &api.BaseCommand{
 				Type: api.BaseCommand_SEND_RECEIPT.Enum(),
 				SendReceipt: &api.CommandSendReceipt{
-					ProducerId: f.BaseCmd.GetSend().ProducerId,
-					SequenceId: f.BaseCmd.GetSend().SequenceId,
+					ProducerId:        f.BaseCmd.GetSend().ProducerId,
+					SequenceId:        f.BaseCmd.GetSend().SequenceId,
+					HighestSequenceId: f.BaseCmd.GetSend().HighestSequenceId,
+					MessageId: &api.MessageIdData{
+						LedgerId: proto.Uint64(1),
+						EntryId:  proto.Uint64(atomic.AddUint64(&m.entries, 1) - 1),
+					},
+				},
+			},
+		}
+
+	case api.BaseCommand_GET_OR_CREATE_SCHEMA:
+		req := f.BaseCmd.GetGetOrCreateSchema()
+		return &frame.Frame{
+			BaseCmd: &api.BaseCommand{
+				Type: api.BaseCommand_GET_OR_CREATE_SCHEMA_RESPONSE.Enum(),
+				GetOrCreateSchemaResponse: &api.CommandGetOrCreateSchemaResponse{
+					RequestId:     req.RequestId,
+					SchemaVersion: m.registerSchema(req.GetTopic(), req.GetSchema()),
+				},
+			},
+		}
+
+	case api.BaseCommand_GET_SCHEMA:
+		req := f.BaseCmd.GetGetSchema()
+		resp := api.CommandGetSchemaResponse{
+			RequestId: req.RequestId,
+		}
+
+		var ts topicSchema
+		var ok bool
+		m.smu.Lock()
+		for _, v := range m.schemas[req.GetTopic()] {
+			// without a requested version, the latest one wins
+			if req.SchemaVersion == nil || bytes.Equal(v.version, req.SchemaVersion) {
+				ts, ok = v, true
+			}
+		}
+		m.smu.Unlock()
+
+		if ok {
+			resp.Schema = ts.schema
+			resp.SchemaVersion = ts.version
+		} else {
+			resp.ErrorCode = api.ServerError_TopicNotFound.Enum()
+			resp.ErrorMessage = proto.String("schema not found")
+		}
+
+		return &frame.Frame{
+			BaseCmd: &api.BaseCommand{
+				Type:              api.BaseCommand_GET_SCHEMA_RESPONSE.Enum(),
+				GetSchemaResponse: &resp,
+			},
+		}
+
+	case api.BaseCommand_PARTITIONED_METADATA:
+		req := f.BaseCmd.GetPartitionMetadata()
+		m.pmu.Lock()
+		n := m.partitions[req.GetTopic()]
+		m.pmu.Unlock()
+
+		return &frame.Frame{
+			BaseCmd: &api.BaseCommand{
+				Type: api.BaseCommand_PARTITIONED_METADATA_RESPONSE.Enum(),
+				PartitionMetadataResponse: &api.CommandPartitionedTopicMetadataResponse{
+					RequestId:  req.RequestId,
+					Partitions: proto.Uint32(n),
+					Response:   api.CommandPartitionedTopicMetadataResponse_Success.Enum(),
+				},
+			},
+		}
+
+	case api.BaseCommand_GET_LAST_MESSAGE_ID:
+		m.lmu.Lock()
+		id := m.lastMessageID
+		m.lmu.Unlock()
+		if id == nil {
+			// -1:-1, as brokers send for empty topics
+			id = &api.MessageIdData{
+				LedgerId: proto.Uint64(math.MaxUint64),
+				EntryId:  proto.Uint64(math.MaxUint64),
+			}
+		}
+
+		return &frame.Frame{
+			BaseCmd: &api.BaseCommand{
+				Type: api.BaseCommand_GET_LAST_MESSAGE_ID_RESPONSE.Enum(),
+				GetLastMessageIdResponse: &api.CommandGetLastMessageIdResponse{
+					LastMessageId: id,
+					RequestId:     f.BaseCmd.GetGetLastMessageId().RequestId,
 				},
 			},
 		}
@@ -0,0 +1,275 @@
+// Copyright 2018 Comcast Cable Communications Management, LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package stream adapts topics to io.Writer and io.Reader, for log
+// shipping and file transfers.
+//
+// A Writer splits the bytes written to it into chunks, one per message.
+// Each chunk starts with a header holding its sequence number, so that
+// a Reader can drop the chunks redelivered after a reconnect and put
+// back in order the ones delivered out of order. Closing the Writer
+// sends an empty final chunk, after which the Reader returns io.EOF.
+//
+// A topic should carry a single stream at a time, read by a single
+// Reader on an exclusive or failover subscription.
+package stream
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/pepper-iot/pulsar-client-go/core/pulsar"
+)
+
+// DefaultChunkSize is the chunk size of a Writer if none is set.
+const DefaultChunkSize = 64 * 1024
+
+// DefaultMaxEarly is the maximum number of chunks a Reader
+// holds while waiting for a missing one, if none is set.
+const DefaultMaxEarly = 64
+
+const (
+	headerSize = 9 // flags, then big-endian sequence number
+
+	flagFinal byte = 1 << 0 // last chunk of the stream
+)
+
+// ErrClosed is returned when writing to a closed Writer.
+var ErrClosed = errors.New("stream writer is closed")
+
+// ErrCorruptChunk is returned by Reader.Read when
+// a message doesn't start with a chunk header.
+var ErrCorruptChunk = errors.New("message is not a stream chunk")
+
+// ErrMissingChunk is returned by Reader.Read when more than MaxEarly
+// chunks arrived after a chunk that never did.
+var ErrMissingChunk = errors.New("stream chunk is missing")
+
+// Writer is an io.WriteCloser sending the bytes written to it to a topic.
+// It isn't safe for concurrent use.
+type Writer struct {
+	ctx context.Context
+	p   pulsar.Producer
+
+	chunkSize int
+	buf       []byte // header of the next chunk, then its data
+	seq       uint64 // sequence number of the next chunk
+	err       error  // sticky error of the first failed send
+	closed    bool
+}
+
+// NewWriter returns a Writer sending chunks of up to chunkSize bytes with p.
+// A chunkSize of zero or less selects DefaultChunkSize. Sends are bound to
+// ctx. Closing the Writer doesn't close p.
+func NewWriter(ctx context.Context, p pulsar.Producer, chunkSize int) *Writer {
+	if chunkSize <= 0 {
+		chunkSize = DefaultChunkSize
+	}
+	return &Writer{
+		ctx:       ctx,
+		p:         p,
+		chunkSize: chunkSize,
+		buf:       make([]byte, headerSize, headerSize+chunkSize),
+	}
+}
+
+// Write implements io.Writer. Full chunks are sent before Write returns,
+// the remainder is sent by the next Write, Flush or Close.
+func (w *Writer) Write(b []byte) (int, error) {
+	if w.closed {
+		return 0, ErrClosed
+	}
+	if w.err != nil {
+		return 0, w.err
+	}
+
+	var n int
+	for len(b) > 0 {
+		m := copy(w.buf[len(w.buf):cap(w.buf)], b)
+		w.buf = w.buf[:len(w.buf)+m]
+		b = b[m:]
+		n += m
+
+		if len(w.buf) == cap(w.buf) {
+			if err := w.send(0); err != nil {
+				return n, err
+			}
+		}
+	}
+	return n, nil
+}
+
+// Flush sends the buffered bytes, if any, as a chunk.
+func (w *Writer) Flush() error {
+	if w.closed {
+		return ErrClosed
+	}
+	if w.err != nil {
+		return w.err
+	}
+	if len(w.buf) == headerSize {
+		return nil
+	}
+	return w.send(0)
+}
+
+// Close flushes the buffered bytes, then sends the final chunk.
+func (w *Writer) Close() error {
+	if w.closed {
+		return ErrClosed
+	}
+	if w.err != nil {
+		w.closed = true
+		return w.err
+	}
+	err := w.send(flagFinal)
+	w.closed = true
+	return err
+}
+
+// send sends the buffered bytes as a chunk with the given flags.
+func (w *Writer) send(flags byte) error {
+	w.buf[0] = flags
+	binary.BigEndian.PutUint64(w.buf[1:headerSize], w.seq)
+
+	if _, err := w.p.Send(w.ctx, w.buf); err != nil {
+		w.err = fmt.Errorf("stream: sending chunk %d: %w", w.seq, err)
+		return w.err
+	}
+	w.seq++
+	// the producer may still hold the sent chunk
+	w.buf = make([]byte, headerSize, headerSize+w.chunkSize)
+	return nil
+}
+
+// ReaderConfig is used to configure a Reader.
+type ReaderConfig struct {
+	// MaxEarly is the maximum number of chunks held while waiting
+	// for a missing one. Defaults to DefaultMaxEarly.
+	MaxEarly int
+}
+
+// Reader is an io.Reader returning, in order, the bytes
+// written by a Writer to a topic. It isn't safe for concurrent use.
+type Reader struct {
+	ctx context.Context
+	c   pulsar.Consumer
+	cfg ReaderConfig
+
+	next  uint64           // sequence number of the next chunk
+	early map[uint64]chunk // chunks received before the next one, by sequence number
+	data  []byte           // unread bytes of the current chunk
+	final bool             // whether the current chunk is the final one
+	err   error            // sticky error
+}
+
+// chunk is a decoded stream chunk.
+type chunk struct {
+	seq   uint64
+	final bool
+	data  []byte
+}
+
+// NewReader returns a Reader receiving chunks with c. Receives and
+// acknowledgements are bound to ctx. Chunks are acknowledged once
+// received, before their bytes are returned by Read.
+func NewReader(ctx context.Context, c pulsar.Consumer, cfg ReaderConfig) *Reader {
+	if cfg.MaxEarly <= 0 {
+		cfg.MaxEarly = DefaultMaxEarly
+	}
+	return &Reader{
+		ctx:   ctx,
+		c:     c,
+		cfg:   cfg,
+		early: make(map[uint64]chunk),
+	}
+}
+
+// Read implements io.Reader. It returns io.EOF once
+// the final chunk sent by Writer.Close is read.
+func (r *Reader) Read(b []byte) (int, error) {
+	for len(r.data) == 0 {
+		if r.err != nil {
+			return 0, r.err
+		}
+		if r.final {
+			r.err = io.EOF
+			return 0, r.err
+		}
+		c, err := r.nextChunk()
+		if err != nil {
+			r.err = err
+			return 0, err
+		}
+		r.next++
+		r.data, r.final = c.data, c.final
+	}
+
+	n := copy(b, r.data)
+	r.data = r.data[n:]
+	return n, nil
+}
+
+// nextChunk returns the chunk with sequence number r.next, receiving
+// chunks until it arrives.
+func (r *Reader) nextChunk() (chunk, error) {
+	if c, ok := r.early[r.next]; ok {
+		delete(r.early, r.next)
+		return c, nil
+	}
+
+	for {
+		m, err := r.c.Receive(r.ctx)
+		if err != nil {
+			return chunk{}, err
+		}
+		if err := r.c.Ack(r.ctx, m); err != nil {
+			return chunk{}, err
+		}
+
+		c, err := decodeChunk(m.Payload)
+		if err != nil {
+			return chunk{}, err
+		}
+		switch {
+		case c.seq == r.next:
+			return c, nil
+		case c.seq < r.next:
+			// redelivered
+			continue
+		}
+		if _, ok := r.early[c.seq]; ok {
+			continue
+		}
+		if len(r.early) >= r.cfg.MaxEarly {
+			return chunk{}, fmt.Errorf("%w: waiting for chunk %d", ErrMissingChunk, r.next)
+		}
+		r.early[c.seq] = c
+	}
+}
+
+// decodeChunk decodes the header of a chunk. The returned
+// chunk's data aliases payload.
+func decodeChunk(payload []byte) (chunk, error) {
+	if len(payload) < headerSize || payload[0]&^flagFinal != 0 {
+		return chunk{}, ErrCorruptChunk
+	}
+	return chunk{
+		seq:   binary.BigEndian.Uint64(payload[1:headerSize]),
+		final: payload[0]&flagFinal != 0,
+		data:  payload[headerSize:],
+	}, nil
+}
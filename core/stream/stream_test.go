@@ -0,0 +1,165 @@
+// Copyright 2018 Comcast Cable Communications Management, LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stream
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/pepper-iot/pulsar-client-go/core/msg"
+	"github.com/pepper-iot/pulsar-client-go/core/pulsar"
+	"github.com/pepper-iot/pulsar-client-go/pkg/api"
+)
+
+// topic is an in-memory topic, used as both
+// a pulsar.Producer and a pulsar.Consumer.
+type topic struct {
+	pulsar.Producer
+	pulsar.Consumer
+
+	msgs  [][]byte
+	acked int
+	err   error // returned by Send if set
+}
+
+func (t *topic) Send(ctx context.Context, payload []byte) (*api.CommandSendReceipt, error) {
+	if t.err != nil {
+		return nil, t.err
+	}
+	t.msgs = append(t.msgs, payload)
+	return new(api.CommandSendReceipt), nil
+}
+
+func (t *topic) Receive(ctx context.Context) (msg.Message, error) {
+	if len(t.msgs) == 0 {
+		return msg.Message{}, context.DeadlineExceeded
+	}
+	m := msg.Message{Payload: t.msgs[0]}
+	t.msgs = t.msgs[1:]
+	return m, nil
+}
+
+func (t *topic) Ack(ctx context.Context, m msg.Message) error {
+	t.acked++
+	return nil
+}
+
+func (t *topic) Done() <-chan struct{}           { return nil }
+func (t *topic) Close(ctx context.Context) error { return nil }
+
+func TestRoundTrip(t *testing.T) {
+	data := bytes.Repeat([]byte("0123456789"), 100)
+	for _, chunkSize := range []int{1, 7, 100, 1000, 5000} {
+		tp := new(topic)
+		w := NewWriter(context.Background(), tp, chunkSize)
+		// write in uneven pieces
+		for b := data; len(b) > 0; {
+			n := 13
+			if n > len(b) {
+				n = len(b)
+			}
+			if _, err := w.Write(b[:n]); err != nil {
+				t.Fatalf("chunk size %d: Write() err = %v; expected nil", chunkSize, err)
+			}
+			b = b[n:]
+		}
+		if err := w.Close(); err != nil {
+			t.Fatalf("chunk size %d: Close() err = %v; expected nil", chunkSize, err)
+		}
+		for _, m := range tp.msgs {
+			if len(m) > headerSize+chunkSize {
+				t.Fatalf("chunk size %d: sent %d bytes", chunkSize, len(m)-headerSize)
+			}
+		}
+		sent := len(tp.msgs)
+
+		got, err := io.ReadAll(NewReader(context.Background(), tp, ReaderConfig{}))
+		if err != nil {
+			t.Fatalf("chunk size %d: ReadAll() err = %v; expected nil", chunkSize, err)
+		}
+		if !bytes.Equal(got, data) {
+			t.Fatalf("chunk size %d: read %q; expected %q", chunkSize, got, data)
+		}
+		if tp.acked != sent {
+			t.Fatalf("chunk size %d: acked %d chunks; expected %d", chunkSize, tp.acked, sent)
+		}
+	}
+}
+
+func TestReaderReorders(t *testing.T) {
+	tp := new(topic)
+	w := NewWriter(context.Background(), tp, 1)
+	if _, err := w.Write([]byte("abcd")); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	// a, c, b, a (redelivered), d, final
+	m := tp.msgs
+	tp.msgs = [][]byte{m[0], m[2], m[1], m[0], m[3], m[4]}
+
+	got, err := io.ReadAll(NewReader(context.Background(), tp, ReaderConfig{}))
+	if err != nil {
+		t.Fatalf("ReadAll() err = %v; expected nil", err)
+	}
+	if string(got) != "abcd" {
+		t.Fatalf("read %q; expected %q", got, "abcd")
+	}
+}
+
+func TestReaderMissingChunk(t *testing.T) {
+	tp := new(topic)
+	w := NewWriter(context.Background(), tp, 1)
+	if _, err := w.Write([]byte("abcd")); err != nil {
+		t.Fatal(err)
+	}
+	tp.msgs = tp.msgs[1:]
+
+	_, err := io.ReadAll(NewReader(context.Background(), tp, ReaderConfig{MaxEarly: 2}))
+	if !errors.Is(err, ErrMissingChunk) {
+		t.Fatalf("ReadAll() err = %v; expected %v", err, ErrMissingChunk)
+	}
+}
+
+func TestReaderCorruptChunk(t *testing.T) {
+	tp := &topic{msgs: [][]byte{[]byte("hi")}}
+
+	_, err := io.ReadAll(NewReader(context.Background(), tp, ReaderConfig{}))
+	if err != ErrCorruptChunk {
+		t.Fatalf("ReadAll() err = %v; expected %v", err, ErrCorruptChunk)
+	}
+}
+
+func TestWriterErrors(t *testing.T) {
+	sendErr := errors.New("send failed")
+	tp := &topic{err: sendErr}
+	w := NewWriter(context.Background(), tp, 2)
+
+	if n, err := w.Write([]byte("abc")); n != 2 || !errors.Is(err, sendErr) {
+		t.Fatalf("Write() = %d, %v; expected 2, %v", n, err, sendErr)
+	}
+	if _, err := w.Write([]byte("d")); !errors.Is(err, sendErr) {
+		t.Fatalf("Write() after failed send err = %v; expected %v", err, sendErr)
+	}
+	if err := w.Close(); !errors.Is(err, sendErr) {
+		t.Fatalf("Close() err = %v; expected %v", err, sendErr)
+	}
+	if _, err := w.Write([]byte("e")); err != ErrClosed {
+		t.Fatalf("Write() after Close() err = %v; expected %v", err, ErrClosed)
+	}
+}
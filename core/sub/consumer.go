@@ -17,11 +17,14 @@ import (
 	"context"
 	"fmt"
 	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/golang/protobuf/proto"
 	"github.com/pepper-iot/pulsar-client-go/core/frame"
 	"github.com/pepper-iot/pulsar-client-go/core/msg"
 	"github.com/pepper-iot/pulsar-client-go/pkg/api"
+	"github.com/pepper-iot/pulsar-client-go/utils"
 )
 
 // maxRedeliverUnacknowledged is the maxiMum number of
@@ -29,6 +32,10 @@ import (
 // message.
 const maxRedeliverUnacknowledged = 1000
 
+// maxAbortedTxns is the maximum number of aborted
+// transactions remembered by a consumer.
+const maxAbortedTxns = 1000
+
 // newConsumer returns a ready-to-use consumer.
 // A consumer is used to attach to a subscription and
 // consumes messages from it. The provided channel is sent
@@ -44,6 +51,7 @@ func newConsumer(s frame.CmdSender, dispatcher *frame.Dispatcher, topic string,
 		Closedc:        make(chan struct{}),
 		EndOfTopicc:    make(chan struct{}),
 		OverflowSignal: make(chan struct{}),
+		DropSignal:     make(chan struct{}, 1),
 	}
 }
 
@@ -63,13 +71,29 @@ type Consumer struct {
 	Overflow       []*api.MessageIdData // IDs of messages that were dropped because of full buffer
 	OverflowSignal chan struct{}
 
+	// DropSignal receives a value, without blocking, once messages
+	// were dropped without being queued, eg markers, after their
+	// permits were used, so that those can be replaced.
+	DropSignal chan struct{}
+
 	Mu           sync.Mutex // protects following
 	IsClosed     bool
 	Closedc      chan struct{}
+	migrated     *api.CommandTopicMigrated // set if the Consumer was closed because its topic was migrated
 	IsEndOfTopic bool
 	EndOfTopicc  chan struct{}
 
 	Unactive bool // Unactive will change when you receive a msg of ActiveConsumerChange
+
+	tmu          sync.Mutex             // protects following
+	aborted      map[msg.TxnID]struct{} // transactions whose abort marker was received
+	abortedOrder []msg.TxnID            // aborted transactions, oldest first
+
+	flowStopped uint32 // atomically set to 1 by StopFlow
+	permits     int64  // atomically updated number of permits not yet used by a message
+	overflowed  uint64 // atomically updated number of messages dropped because Queue was full
+
+	memory atomic.Value // *utils.MemoryLimitController of the messages in Queue, if set
 }
 
 // Messages returns a read-only channel of messages
@@ -94,14 +118,33 @@ func (c *Consumer) Ack(msg msg.Message) error {
 	return c.S.SendSimpleCmd(cmd)
 }
 
+// Nack asks the broker to redeliver the given message. Only shared
+// subscriptions redeliver individual messages: for the other subscription
+// types, _all_ unacknowledged messages are redelivered.
+func (c *Consumer) Nack(msg msg.Message) error {
+	cmd := api.BaseCommand{
+		Type: api.BaseCommand_REDELIVER_UNACKNOWLEDGED_MESSAGES.Enum(),
+		RedeliverUnacknowledgedMessages: &api.CommandRedeliverUnacknowledgedMessages{
+			ConsumerId: proto.Uint64(c.ConsumerID),
+			MessageIds: []*api.MessageIdData{msg.Msg.GetMessageId()},
+		},
+	}
+
+	return c.S.SendSimpleCmd(cmd)
+}
+
 // Flow command gives additional permits to send messages to the consumer.
 // A typical consumer implementation will use a queue to accuMulate these messages
 // before the application is ready to consume them. After the consumer is ready,
 // the client needs to give permission to the broker to push messages.
+// Once StopFlow has been called, Flow is a no-op.
 func (c *Consumer) Flow(permits uint32) error {
 	if permits <= 0 {
 		return fmt.Errorf("invalid number of permits requested: %d", permits)
 	}
+	if atomic.LoadUint32(&c.flowStopped) == 1 {
+		return nil
+	}
 
 	cmd := api.BaseCommand{
 		Type: api.BaseCommand_FLOW.Enum(),
@@ -111,7 +154,74 @@ func (c *Consumer) Flow(permits uint32) error {
 		},
 	}
 
-	return c.S.SendSimpleCmd(cmd)
+	if err := c.S.SendSimpleCmd(cmd); err != nil {
+		return err
+	}
+	atomic.AddInt64(&c.permits, int64(permits))
+
+	return nil
+}
+
+// Permits returns the number of permits granted with Flow that
+// haven't yet been used by a message pushed from the broker. A
+// newly created consumer has no permits.
+func (c *Consumer) Permits() int64 {
+	return atomic.LoadInt64(&c.permits)
+}
+
+// usePermits accounts for the permits used by a received message.
+// Each message in a batch uses one permit.
+func (c *Consumer) usePermits(f frame.Frame) {
+	n := int64(f.Metadata.GetNumMessagesInBatch())
+	if n < 1 {
+		n = 1
+	}
+	atomic.AddInt64(&c.permits, -n)
+}
+
+// dropped accounts for the permits used by a received message that
+// was dropped without being queued, then signals DropSignal.
+func (c *Consumer) dropped(f frame.Frame) {
+	c.usePermits(f)
+	select {
+	case c.DropSignal <- struct{}{}:
+	default:
+		// already signaled
+	}
+}
+
+// SetMemoryLimit makes the Consumer reserve the payload size of each
+// message it queues with mc, even if it exceeds the limit, since the
+// message was already received. Messages taken from the Queue must
+// then be passed to Dequeued.
+func (c *Consumer) SetMemoryLimit(mc *utils.MemoryLimitController) {
+	c.memory.Store(mc)
+}
+
+// Dequeued releases the memory reserved for a message taken from
+// the Queue, if the Consumer has a memory limit.
+func (c *Consumer) Dequeued(m msg.Message) {
+	c.memoryLimit().Release(int64(len(m.Payload)))
+}
+
+// memoryLimit returns the MemoryLimitController set with
+// SetMemoryLimit, or nil, which has no limit, if none is.
+func (c *Consumer) memoryLimit() *utils.MemoryLimitController {
+	mc, _ := c.memory.Load().(*utils.MemoryLimitController)
+	return mc
+}
+
+// Overflowed returns the number of messages dropped
+// because the consumer's Queue was full.
+func (c *Consumer) Overflowed() uint64 {
+	return atomic.LoadUint64(&c.overflowed)
+}
+
+// StopFlow prevents any further permits from being sent to the broker,
+// so that no more messages are pushed to the consumer beyond those
+// already requested. It is used when shutting down.
+func (c *Consumer) StopFlow() {
+	atomic.StoreUint32(&c.flowStopped, 1)
 }
 
 // Closed returns a channel that will block _unless_ the
@@ -147,7 +257,8 @@ func (c *Consumer) Close(ctx context.Context) error {
 		},
 	}
 
-	resp, cancel, err := c.Dispatcher.RegisterReqID(*requestID)
+	deadline, _ := ctx.Deadline()
+	resp, cancel, err := c.Dispatcher.RegisterReqIDDeadline(*requestID, deadline)
 	if err != nil {
 		return err
 	}
@@ -181,7 +292,8 @@ func (c *Consumer) Unsubscribe(ctx context.Context) error {
 		},
 	}
 
-	resp, cancel, err := c.Dispatcher.RegisterReqID(*requestID)
+	deadline, _ := ctx.Deadline()
+	resp, cancel, err := c.Dispatcher.RegisterReqIDDeadline(*requestID, deadline)
 	if err != nil {
 		return err
 	}
@@ -201,6 +313,99 @@ func (c *Consumer) Unsubscribe(ctx context.Context) error {
 	}
 }
 
+// Seek resets the subscription's cursor to the given message ID.
+// The broker disconnects the subscription's consumers after a successful
+// seek, so the Consumer has to be recreated to receive messages from the
+// new position.
+func (c *Consumer) Seek(ctx context.Context, id *api.MessageIdData) error {
+	return c.seek(ctx, &api.CommandSeek{MessageId: id})
+}
+
+// SeekTime resets the subscription's cursor to the first message
+// published at or after t. See Seek.
+func (c *Consumer) SeekTime(ctx context.Context, t time.Time) error {
+	ms := uint64(t.UnixNano() / int64(time.Millisecond))
+	return c.seek(ctx, &api.CommandSeek{MessagePublishTime: proto.Uint64(ms)})
+}
+
+func (c *Consumer) seek(ctx context.Context, seek *api.CommandSeek) error {
+	requestID := c.ReqID.Next()
+	seek.RequestId = requestID
+	seek.ConsumerId = proto.Uint64(c.ConsumerID)
+
+	cmd := api.BaseCommand{
+		Type: api.BaseCommand_SEEK.Enum(),
+		Seek: seek,
+	}
+
+	deadline, _ := ctx.Deadline()
+	resp, cancel, err := c.Dispatcher.RegisterReqIDDeadline(*requestID, deadline)
+	if err != nil {
+		return err
+	}
+	defer cancel()
+
+	if err := c.S.SendSimpleCmd(cmd); err != nil {
+		return err
+	}
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+
+	case f := <-resp:
+		if f.BaseCmd.GetType() == api.BaseCommand_ERROR {
+			errMsg := f.BaseCmd.GetError()
+			return utils.NewServerError(errMsg.GetError(), errMsg.GetMessage())
+		}
+		// Response type is SUCCESS
+		return nil
+	}
+}
+
+// GetLastMessageID asks the broker for the ID of the last message
+// of the topic, and the mark-delete position of the subscription.
+func (c *Consumer) GetLastMessageID(ctx context.Context) (*api.CommandGetLastMessageIdResponse, error) {
+	requestID := c.ReqID.Next()
+
+	cmd := api.BaseCommand{
+		Type: api.BaseCommand_GET_LAST_MESSAGE_ID.Enum(),
+		GetLastMessageId: &api.CommandGetLastMessageId{
+			ConsumerId: proto.Uint64(c.ConsumerID),
+			RequestId:  requestID,
+		},
+	}
+
+	deadline, _ := ctx.Deadline()
+	resp, cancel, err := c.Dispatcher.RegisterReqIDDeadline(*requestID, deadline)
+	if err != nil {
+		return nil, err
+	}
+	defer cancel()
+
+	if err := c.S.SendSimpleCmd(cmd); err != nil {
+		return nil, err
+	}
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+
+	case f := <-resp:
+		switch msgType := f.BaseCmd.GetType(); msgType {
+		case api.BaseCommand_GET_LAST_MESSAGE_ID_RESPONSE:
+			return f.BaseCmd.GetGetLastMessageIdResponse(), nil
+
+		case api.BaseCommand_ERROR:
+			errMsg := f.BaseCmd.GetError()
+			return nil, utils.NewServerError(errMsg.GetError(), errMsg.GetMessage())
+
+		default:
+			return nil, utils.NewUnexpectedErrMsg(msgType, *requestID)
+		}
+	}
+}
+
 // HandleCloseConsumer should be called when a CLOSE_CONSUMER message is received
 // associated with this consumer.
 func (c *Consumer) HandleCloseConsumer(f frame.Frame) error {
@@ -212,11 +417,24 @@ func (c *Consumer) HandleCloseConsumer(f frame.Frame) error {
 	}
 
 	c.IsClosed = true
+	c.migrated = f.BaseCmd.GetTopicMigrated()
 	close(c.Closedc)
 
 	return nil
 }
 
+// MigratedTo returns the service URLs of the cluster the topic was
+// migrated to, if the Consumer was closed by a TOPIC_MIGRATED command,
+// passed to HandleCloseConsumer.
+func (c *Consumer) MigratedTo() (url, tlsURL string, ok bool) {
+	c.Mu.Lock()
+	defer c.Mu.Unlock()
+	if c.migrated == nil {
+		return "", "", false
+	}
+	return c.migrated.GetBrokerServiceUrl(), c.migrated.GetBrokerServiceUrlTls(), true
+}
+
 // ReachedEndOfTopic unblocks whenever the topic has been "terminated" and
 // all the messages on the subscription were acknowledged.
 func (c *Consumer) ReachedEndOfTopic() <-chan struct{} {
@@ -304,7 +522,8 @@ func (c *Consumer) RedeliverOverflow(ctx context.Context) (int, error) {
 }
 
 // HandleMessage should be called for all MESSAGE messages received for
-// this consumer.
+// this consumer. Compressed payloads are decompressed before the
+// messages are queued.
 func (c *Consumer) HandleMessage(f frame.Frame) error {
 	m := msg.Message{
 		Topic:      c.Topic,
@@ -312,13 +531,57 @@ func (c *Consumer) HandleMessage(f frame.Frame) error {
 		Msg:        f.BaseCmd.GetMessage(),
 		Meta:       f.Metadata,
 		Payload:    f.Payload,
+		ReceivedAt: time.Now(),
+
+		PooledPayload: f.PooledPayload,
+
+		BrokerEntry: f.BrokerEntryMetadata,
+	}
+
+	// the permits are accounted for only after the message has been
+	// queued, so that Permits() + len(Queue) never under-counts. Those
+	// of messages dropped otherwise than by overflowing are signaled.
+	drop := true
+	defer func() {
+		if drop {
+			c.dropped(f)
+		} else {
+			c.usePermits(f)
+		}
+	}()
+
+	// Marker messages are control records written by the broker, and
+	// messages of aborted transactions must never be seen. Neither is
+	// delivered, and both are acknowledged so they aren't redelivered.
+	if c.skipTxn(m) || m.MarkerType() != msg.MarkerNone {
+		defer m.ReleasePayload()
+		return c.Ack(m)
+	}
+
+	// a payload that can't be decompressed will never be, so it is
+	// dropped and acknowledged with a validation error
+	if err := m.Decompress(); err != nil {
+		m.ReleasePayload()
+		return c.reject(f, api.CommandAck_DecompressionError, "undecompressable", err)
 	}
 
+	// reserved before the message is queued, so
+	// that it's released after being reserved
+	memory := c.memoryLimit()
+	memory.ForceReserve(int64(len(m.Payload)))
+
 	select {
 	case c.Queue <- m:
+		drop = false
 		return nil
 
 	default:
+		// signaled with OverflowSignal instead
+		drop = false
+		memory.Release(int64(len(m.Payload)))
+		atomic.AddUint64(&c.overflowed, 1)
+		m.ReleasePayload()
+
 		// Add messageId to Overflow buffer, avoiding duplicates.
 		newMid := f.BaseCmd.GetMessage().GetMessageId()
 
@@ -339,3 +602,72 @@ func (c *Consumer) HandleMessage(f frame.Frame) error {
 		return fmt.Errorf("consumer message queue on topic %q is full (capacity = %d)", c.Topic, cap(c.Queue))
 	}
 }
+
+// HandleCorruptMessage should be called for MESSAGE messages received
+// for this consumer whose checksum doesn't match, in place of
+// HandleMessage. The message is dropped and acknowledged with a
+// validation error, so that the broker doesn't redeliver it. The
+// returned error describes the dropped message.
+func (c *Consumer) HandleCorruptMessage(f frame.Frame, err error) error {
+	defer c.dropped(f)
+	return c.reject(f, api.CommandAck_ChecksumMismatch, "corrupt", err)
+}
+
+// reject acknowledges the message of f with a validation error, so that
+// the broker doesn't redeliver it, and returns an error describing the
+// dropped message as what, eg "corrupt".
+func (c *Consumer) reject(f frame.Frame, validationErr api.CommandAck_ValidationError, what string, err error) error {
+	mid := f.BaseCmd.GetMessage().GetMessageId()
+	cmd := api.BaseCommand{
+		Type: api.BaseCommand_ACK.Enum(),
+		Ack: &api.CommandAck{
+			ConsumerId:      proto.Uint64(c.ConsumerID),
+			MessageId:       []*api.MessageIdData{mid},
+			AckType:         api.CommandAck_Individual.Enum(),
+			ValidationError: validationErr.Enum(),
+		},
+	}
+	if ackErr := c.S.SendSimpleCmd(cmd); ackErr != nil {
+		return ackErr
+	}
+
+	return fmt.Errorf("dropped %s message %d:%d on topic %q: %w", what, mid.GetLedgerId(), mid.GetEntryId(), c.Topic, err)
+}
+
+// skipTxn returns true if m must not be delivered to the application
+// under read-committed isolation: transaction markers are control
+// records, and messages of aborted transactions must never be seen.
+// The broker only dispatches messages of resolved transactions and
+// normally filters both out; this guards against those that get
+// through, e.g. redeliveries of messages of a since aborted transaction.
+func (c *Consumer) skipTxn(m msg.Message) bool {
+	marker := m.MarkerType()
+	txnID, inTxn := m.TxnID()
+
+	if !marker.IsTxn() && !inTxn {
+		return false
+	}
+
+	c.tmu.Lock()
+	defer c.tmu.Unlock()
+
+	if marker == msg.MarkerTxnAbort && inTxn {
+		if _, ok := c.aborted[txnID]; !ok {
+			if c.aborted == nil {
+				c.aborted = make(map[msg.TxnID]struct{})
+			}
+			if len(c.abortedOrder) >= maxAbortedTxns {
+				delete(c.aborted, c.abortedOrder[0])
+				c.abortedOrder = c.abortedOrder[1:]
+			}
+			c.aborted[txnID] = struct{}{}
+			c.abortedOrder = append(c.abortedOrder, txnID)
+		}
+	}
+	if marker.IsTxn() {
+		return true
+	}
+
+	_, aborted := c.aborted[txnID]
+	return aborted
+}
@@ -16,11 +16,13 @@ package sub
 import (
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"testing"
 	"time"
 
 	"github.com/golang/protobuf/proto"
+	"github.com/pepper-iot/pulsar-client-go/core/compression"
 	"github.com/pepper-iot/pulsar-client-go/core/frame"
 	"github.com/pepper-iot/pulsar-client-go/core/msg"
 	"github.com/pepper-iot/pulsar-client-go/pkg/api"
@@ -44,6 +46,98 @@ func TestConsumer_Flow(t *testing.T) {
 	}
 }
 
+func TestConsumer_Nack(t *testing.T) {
+	var ms frame.MockSender
+	reqID := msg.MonotonicID{ID: 43}
+	consID := uint64(123)
+	c := newConsumer(&ms, frame.NewFrameDispatcher(), "test", &reqID, consID, make(chan msg.Message, 1))
+
+	mid := &api.MessageIdData{LedgerId: proto.Uint64(1), EntryId: proto.Uint64(2)}
+	if err := c.Nack(msg.Message{Msg: &api.CommandMessage{MessageId: mid}}); err != nil {
+		t.Fatalf("Nack() err = %v; nil expected", err)
+	}
+
+	sentFrames := ms.GetFrames()
+	if got, expected := len(sentFrames), 1; got != expected {
+		t.Fatalf("%d Frames were sent; expected %d", got, expected)
+	}
+	redeliver := sentFrames[0].BaseCmd.GetRedeliverUnacknowledgedMessages()
+	if got, expected := redeliver.GetConsumerId(), consID; got != expected {
+		t.Fatalf("REDELIVER_UNACKNOWLEDGED_MESSAGES consumer ID = %d; expected %d", got, expected)
+	}
+	if got := redeliver.GetMessageIds(); len(got) != 1 || !proto.Equal(got[0], mid) {
+		t.Fatalf("REDELIVER_UNACKNOWLEDGED_MESSAGES message IDs = %v; expected [%v]", got, mid)
+	}
+}
+
+func TestConsumer_Permits(t *testing.T) {
+	var ms frame.MockSender
+	id := uint64(43)
+	consID := uint64(123)
+	reqID := msg.MonotonicID{ID: id}
+	dispatcher := frame.NewFrameDispatcher()
+
+	c := newConsumer(&ms, dispatcher, "test", &reqID, consID, make(chan msg.Message, 4))
+
+	if got := c.Permits(); got != 0 {
+		t.Fatalf("Permits() = %d; expected 0 for new consumer", got)
+	}
+
+	if err := c.Flow(3); err != nil {
+		t.Fatalf("Flow() err = %v; nil expected", err)
+	}
+	if got := c.Permits(); got != 3 {
+		t.Fatalf("Permits() = %d; expected 3", got)
+	}
+
+	f := frame.Frame{
+		BaseCmd: &api.BaseCommand{
+			Type: api.BaseCommand_MESSAGE.Enum(),
+			Message: &api.CommandMessage{
+				ConsumerId: proto.Uint64(consID),
+			},
+		},
+		Metadata: &api.MessageMetadata{
+			ProducerName: proto.String("hi"),
+			SequenceId:   proto.Uint64(9933),
+		},
+	}
+	if err := c.HandleMessage(f); err != nil {
+		t.Fatalf("HandleMessage() err = %v; nil expected", err)
+	}
+	if got := c.Permits(); got != 2 {
+		t.Fatalf("Permits() = %d; expected 2 after one message", got)
+	}
+
+	// a batch uses one permit per message
+	f.Metadata.NumMessagesInBatch = proto.Int32(2)
+	if err := c.HandleMessage(f); err != nil {
+		t.Fatalf("HandleMessage() err = %v; nil expected", err)
+	}
+	if got := c.Permits(); got != 0 {
+		t.Fatalf("Permits() = %d; expected 0 after batch of 2", got)
+	}
+}
+
+func TestConsumer_StopFlow(t *testing.T) {
+	var ms frame.MockSender
+	id := uint64(43)
+	consID := uint64(123)
+	reqID := msg.MonotonicID{ID: id}
+	dispatcher := frame.NewFrameDispatcher()
+
+	c := newConsumer(&ms, dispatcher, "test", &reqID, consID, make(chan msg.Message, 1))
+	c.StopFlow()
+
+	if err := c.Flow(123); err != nil {
+		t.Fatalf("Flow() err = %v; nil expected", err)
+	}
+
+	if got, expected := len(ms.Frames), 0; got != expected {
+		t.Fatalf("got %d frame; expected %d after StopFlow", got, expected)
+	}
+}
+
 func TestConsumer_Close_Success(t *testing.T) {
 	var ms frame.MockSender
 	id := uint64(43)
@@ -60,8 +154,10 @@ func TestConsumer_Close_Success(t *testing.T) {
 
 	go func() { resp <- c.Close(ctx) }()
 
-	// Allow goroutine time to complete
-	time.Sleep(100 * time.Millisecond)
+	// Wait for the goroutine to send the command
+	if _, err := ms.WaitFrames(ctx, 1); err != nil {
+		t.Fatal(err)
+	}
 
 	select {
 	case <-c.Closed():
@@ -100,6 +196,131 @@ func TestConsumer_Close_Success(t *testing.T) {
 	}
 }
 
+func TestConsumer_Seek(t *testing.T) {
+	var ms frame.MockSender
+	id := uint64(43)
+	consID := uint64(123)
+	reqID := msg.MonotonicID{ID: id}
+	dispatcher := frame.NewFrameDispatcher()
+
+	c := newConsumer(&ms, dispatcher, "test", &reqID, consID, make(chan msg.Message, 1))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	msgID := &api.MessageIdData{LedgerId: proto.Uint64(7), EntryId: proto.Uint64(9)}
+	resp := make(chan error, 1)
+	go func() { resp <- c.Seek(ctx, msgID) }()
+
+	// Wait for the goroutine to send the command
+	if _, err := ms.WaitFrames(ctx, 1); err != nil {
+		t.Fatal(err)
+	}
+
+	if got, expected := len(ms.Frames), 1; got != expected {
+		t.Fatalf("got %d frame; expected %d", got, expected)
+	}
+	seek := ms.Frames[0].BaseCmd.GetSeek()
+	if seek.GetConsumerId() != consID || seek.GetRequestId() != id {
+		t.Fatalf("SEEK = %v; expected consumer ID %d and request ID %d", seek, consID, id)
+	}
+	if !proto.Equal(seek.GetMessageId(), msgID) {
+		t.Fatalf("SEEK message ID = %v; expected %v", seek.GetMessageId(), msgID)
+	}
+
+	f := frame.Frame{
+		BaseCmd: &api.BaseCommand{
+			Type:    api.BaseCommand_SUCCESS.Enum(),
+			Success: &api.CommandSuccess{RequestId: proto.Uint64(id)},
+		},
+	}
+	if err := dispatcher.NotifyReqID(id, f); err != nil {
+		t.Fatal(err)
+	}
+	if err := <-resp; err != nil {
+		t.Fatalf("Seek() err = %v; nil expected", err)
+	}
+
+	// A publish time seek which the broker rejects
+	go func() { resp <- c.SeekTime(ctx, time.Unix(1, 0)) }()
+	if _, err := ms.WaitFrames(ctx, 2); err != nil {
+		t.Fatal(err)
+	}
+
+	if got, expected := len(ms.Frames), 2; got != expected {
+		t.Fatalf("got %d frames; expected %d", got, expected)
+	}
+	seek = ms.Frames[1].BaseCmd.GetSeek()
+	if got, expected := seek.GetMessagePublishTime(), uint64(1000); got != expected {
+		t.Fatalf("SEEK publish time = %d; expected %d", got, expected)
+	}
+
+	f = frame.Frame{
+		BaseCmd: &api.BaseCommand{
+			Type: api.BaseCommand_ERROR.Enum(),
+			Error: &api.CommandError{
+				RequestId: proto.Uint64(id + 1),
+				Error:     api.ServerError_UnknownError.Enum(),
+				Message:   proto.String("seek failed"),
+			},
+		},
+	}
+	if err := dispatcher.NotifyReqID(id+1, f); err != nil {
+		t.Fatal(err)
+	}
+	if err := <-resp; err == nil {
+		t.Fatal("SeekTime() err = nil; expected error")
+	}
+}
+
+func TestConsumer_GetLastMessageID(t *testing.T) {
+	var ms frame.MockSender
+	id := uint64(43)
+	consID := uint64(123)
+	reqID := msg.MonotonicID{ID: id}
+	dispatcher := frame.NewFrameDispatcher()
+
+	c := newConsumer(&ms, dispatcher, "test", &reqID, consID, make(chan msg.Message, 1))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	type result struct {
+		resp *api.CommandGetLastMessageIdResponse
+		err  error
+	}
+	resp := make(chan result, 1)
+	go func() {
+		r, err := c.GetLastMessageID(ctx)
+		resp <- result{r, err}
+	}()
+
+	if _, err := ms.WaitFrames(ctx, 1); err != nil {
+		t.Fatal(err)
+	}
+	req := ms.Frames[0].BaseCmd.GetGetLastMessageId()
+	if req.GetConsumerId() != consID || req.GetRequestId() != id {
+		t.Fatalf("GET_LAST_MESSAGE_ID = %v; expected consumer ID %d and request ID %d", req, consID, id)
+	}
+
+	expected := &api.CommandGetLastMessageIdResponse{
+		LastMessageId: &api.MessageIdData{LedgerId: proto.Uint64(7), EntryId: proto.Uint64(9)},
+		RequestId:     proto.Uint64(id),
+	}
+	f := frame.Frame{
+		BaseCmd: &api.BaseCommand{
+			Type:                     api.BaseCommand_GET_LAST_MESSAGE_ID_RESPONSE.Enum(),
+			GetLastMessageIdResponse: expected,
+		},
+	}
+	if err := dispatcher.NotifyReqID(id, f); err != nil {
+		t.Fatal(err)
+	}
+	if r := <-resp; r.err != nil || !proto.Equal(r.resp, expected) {
+		t.Fatalf("GetLastMessageID() = %v, %v; expected %v, nil", r.resp, r.err, expected)
+	}
+}
+
 func TestConsumer_handleMessage(t *testing.T) {
 	var ms frame.MockSender
 	id := uint64(43)
@@ -149,6 +370,127 @@ func TestConsumer_handleMessage(t *testing.T) {
 	t.Logf("got msg.Message:\n%+v", got)
 }
 
+func TestConsumer_handleCorruptMessage(t *testing.T) {
+	var ms frame.MockSender
+	id := uint64(43)
+	consID := uint64(123)
+	reqID := msg.MonotonicID{ID: id}
+	dispatcher := frame.NewFrameDispatcher()
+
+	c := newConsumer(&ms, dispatcher, "test", &reqID, consID, make(chan msg.Message, 1))
+
+	f := frame.Frame{
+		BaseCmd: &api.BaseCommand{
+			Type: api.BaseCommand_MESSAGE.Enum(),
+			Message: &api.CommandMessage{
+				ConsumerId: proto.Uint64(consID),
+				MessageId: &api.MessageIdData{
+					LedgerId: proto.Uint64(1),
+					EntryId:  proto.Uint64(2),
+				},
+			},
+		},
+		Metadata: &api.MessageMetadata{
+			ProducerName: proto.String("hi"),
+			SequenceId:   proto.Uint64(9933),
+		},
+		Payload: []byte("hola mundo"),
+	}
+
+	mismatch := &frame.ChecksumError{Computed: 1, Expected: 2}
+	err := c.HandleCorruptMessage(f, mismatch)
+	var checksumErr *frame.ChecksumError
+	if !errors.As(err, &checksumErr) {
+		t.Fatalf("HandleCorruptMessage() err = %v; expected a ChecksumError", err)
+	}
+
+	// the message is dropped
+	select {
+	case m := <-c.Messages():
+		t.Fatalf("got message %+v; expected none", m)
+	default:
+	}
+
+	// and acknowledged with a validation error
+	if got, expected := len(ms.Frames), 1; got != expected {
+		t.Fatalf("got %d frames; expected %d", got, expected)
+	}
+	ack := ms.Frames[0].BaseCmd.GetAck()
+	if got, expected := ack.GetValidationError(), api.CommandAck_ChecksumMismatch; got != expected {
+		t.Fatalf("ack validation error = %v; expected %v", got, expected)
+	}
+	if !proto.Equal(ack.GetMessageId()[0], f.BaseCmd.GetMessage().GetMessageId()) {
+		t.Fatalf("acked %v; expected %v", ack.GetMessageId(), f.BaseCmd.GetMessage().GetMessageId())
+	}
+}
+
+func TestConsumer_handleMessage_compressed(t *testing.T) {
+	var ms frame.MockSender
+	id := uint64(43)
+	consID := uint64(123)
+	reqID := msg.MonotonicID{ID: id}
+	dispatcher := frame.NewFrameDispatcher()
+
+	c := newConsumer(&ms, dispatcher, "test", &reqID, consID, make(chan msg.Message, 1))
+
+	payload := []byte("hola mundo, hola mundo")
+	compressed, err := compression.Snappy{}.Compress(payload)
+	if err != nil {
+		t.Fatal(err)
+	}
+	message := func(compressed []byte) frame.Frame {
+		return frame.Frame{
+			BaseCmd: &api.BaseCommand{
+				Type: api.BaseCommand_MESSAGE.Enum(),
+				Message: &api.CommandMessage{
+					ConsumerId: proto.Uint64(consID),
+					MessageId: &api.MessageIdData{
+						LedgerId: proto.Uint64(1),
+						EntryId:  proto.Uint64(2),
+					},
+				},
+			},
+			Metadata: &api.MessageMetadata{
+				ProducerName:     proto.String("hi"),
+				SequenceId:       proto.Uint64(9933),
+				Compression:      api.CompressionType_SNAPPY.Enum(),
+				UncompressedSize: proto.Uint32(uint32(len(payload))),
+			},
+			Payload: compressed,
+		}
+	}
+
+	if err := c.HandleMessage(message(compressed)); err != nil {
+		t.Fatal(err)
+	}
+	got := <-c.Messages()
+	if !bytes.Equal(got.Payload, payload) {
+		t.Fatalf("got payload:\n%q\nexpected:\n%q", got.Payload, payload)
+	}
+	if got, expected := got.Meta.GetCompression(), api.CompressionType_NONE; got != expected {
+		t.Fatalf("got compression %v; expected %v", got, expected)
+	}
+
+	// a payload that can't be decompressed is dropped,
+	// and acknowledged with a validation error
+	err = c.HandleMessage(message(compressed[:len(compressed)-1]))
+	if !errors.Is(err, compression.ErrCorrupt) {
+		t.Fatalf("HandleMessage() err = %v; expected %v", err, compression.ErrCorrupt)
+	}
+	select {
+	case m := <-c.Messages():
+		t.Fatalf("got message %+v; expected none", m)
+	default:
+	}
+	if got, expected := len(ms.Frames), 1; got != expected {
+		t.Fatalf("got %d frames; expected %d", got, expected)
+	}
+	ack := ms.Frames[0].BaseCmd.GetAck()
+	if got, expected := ack.GetValidationError(), api.CommandAck_DecompressionError; got != expected {
+		t.Fatalf("ack validation error = %v; expected %v", got, expected)
+	}
+}
+
 func TestConsumer_handleMessage_fullQueue(t *testing.T) {
 	var ms frame.MockSender
 	id := uint64(43)
@@ -159,10 +501,10 @@ func TestConsumer_handleMessage_fullQueue(t *testing.T) {
 	queueSize := 3
 	c := newConsumer(&ms, dispatcher, "test", &reqID, consID, make(chan msg.Message, queueSize))
 
-	var receivedSinceFlow int
+	overflows := make(chan struct{}, queueSize+1)
 	go func() {
 		for range c.OverflowSignal {
-			receivedSinceFlow++
+			overflows <- struct{}{}
 		}
 	}()
 
@@ -201,9 +543,14 @@ func TestConsumer_handleMessage_fullQueue(t *testing.T) {
 	if got, expected := len(c.Overflow), 1; got != expected {
 		t.Fatalf("len(consumer overflow buffer) = %d; expected %d", got, expected)
 	}
+	if got, expected := c.Overflowed(), uint64(1); got != expected {
+		t.Fatalf("Overflowed() = %d; expected %d", got, expected)
+	}
 
-	if got, expected := receivedSinceFlow, 1; got != expected {
-		t.Fatalf("receivedSinceFlow = %d; expected %d", got, expected)
+	select {
+	case <-overflows:
+	case <-time.After(time.Second):
+		t.Fatal("overflow wasn't signaled")
 	}
 
 	for i := 0; i < queueSize; i++ {
@@ -383,3 +730,88 @@ func TestConsumer_RedeliverOverflow(t *testing.T) {
 		}
 	}
 }
+
+// txnMetadata returns MessageMetadata carrying
+// the given marker type and transaction.
+func txnMetadata(marker msg.MarkerType, txnID msg.TxnID) *api.MessageMetadata {
+	meta := &api.MessageMetadata{
+		ProducerName:   proto.String("hi"),
+		TxnidLeastBits: proto.Uint64(txnID.LeastBits),
+		TxnidMostBits:  proto.Uint64(txnID.MostBits),
+	}
+	if marker != msg.MarkerNone {
+		meta.MarkerType = proto.Int32(int32(marker))
+	}
+	return meta
+}
+
+func TestConsumer_handleMessage_txn(t *testing.T) {
+	var ms frame.MockSender
+	reqID := msg.MonotonicID{ID: 43}
+	consID := uint64(123)
+	dispatcher := frame.NewFrameDispatcher()
+
+	c := newConsumer(&ms, dispatcher, "test", &reqID, consID, make(chan msg.Message, 10))
+
+	committed := msg.TxnID{MostBits: 1, LeastBits: 1}
+	aborted := msg.TxnID{MostBits: 1, LeastBits: 2}
+
+	frames := []struct {
+		meta    *api.MessageMetadata
+		payload string
+	}{
+		{txnMetadata(msg.MarkerNone, committed), "committed"},
+		{txnMetadata(msg.MarkerTxnCommit, committed), "commit marker"},
+		{txnMetadata(msg.MarkerTxnAbort, aborted), "abort marker"},
+		{txnMetadata(msg.MarkerNone, aborted), "aborted"},
+		{&api.MessageMetadata{ProducerName: proto.String("hi")}, "plain"},
+		{&api.MessageMetadata{
+			ProducerName: proto.String("hi"),
+			MarkerType:   proto.Int32(int32(msg.MarkerReplicatedSubscriptionSnapshot)),
+		}, "replication marker"},
+	}
+	for i, fr := range frames {
+		f := frame.Frame{
+			BaseCmd: &api.BaseCommand{
+				Type: api.BaseCommand_MESSAGE.Enum(),
+				Message: &api.CommandMessage{
+					ConsumerId: proto.Uint64(consID),
+					MessageId: &api.MessageIdData{
+						LedgerId: proto.Uint64(1),
+						EntryId:  proto.Uint64(uint64(i)),
+					},
+				},
+			},
+			Metadata: fr.meta,
+			Payload:  []byte(fr.payload),
+		}
+		if err := c.HandleMessage(f); err != nil {
+			t.Fatalf("HandleMessage(%q) err = %v; expected nil", fr.payload, err)
+		}
+	}
+
+	var got []string
+	for len(c.Queue) > 0 {
+		m := <-c.Queue
+		got = append(got, string(m.Payload))
+	}
+	if expected := []string{"committed", "plain"}; fmt.Sprint(got) != fmt.Sprint(expected) {
+		t.Fatalf("delivered messages = %q; expected %q", got, expected)
+	}
+
+	// skipped messages still use their permits
+	if got, expected := c.Permits(), int64(-len(frames)); got != expected {
+		t.Fatalf("Permits() = %d; expected %d", got, expected)
+	}
+
+	// and are acknowledged
+	var acked []uint64
+	for _, f := range ms.GetFrames() {
+		for _, mid := range f.BaseCmd.GetAck().GetMessageId() {
+			acked = append(acked, mid.GetEntryId())
+		}
+	}
+	if expected := []uint64{1, 2, 3, 5}; fmt.Sprint(acked) != fmt.Sprint(expected) {
+		t.Fatalf("acked entries %v; expected %v", acked, expected)
+	}
+}
@@ -0,0 +1,79 @@
+// Copyright 2018 Comcast Cable Communications Management, LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sub
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/pepper-iot/pulsar-client-go/core/msg"
+	"github.com/pepper-iot/pulsar-client-go/pkg/api"
+)
+
+// InitialPosition is the position the cursor of a new subscription
+// starts at. The zero value is Latest. Subscribing to an existing
+// subscription with Latest or Earliest leaves its cursor as is.
+type InitialPosition struct {
+	earliest bool
+	id       *msg.MessageID
+	ts       time.Time
+}
+
+var (
+	// Latest starts after the last message of the topic.
+	Latest = InitialPosition{}
+	// Earliest starts at the first message of the topic.
+	Earliest = InitialPosition{earliest: true}
+)
+
+// AtMessageID starts at the message with the given ID.
+func AtMessageID(id msg.MessageID) InitialPosition {
+	return InitialPosition{id: &id}
+}
+
+// AtTimestamp starts at the first message published at or after t,
+// rounded down to the second. The broker resets the cursor of existing
+// subscriptions too.
+func AtTimestamp(t time.Time) InitialPosition {
+	return InitialPosition{ts: t}
+}
+
+// String implements fmt.Stringer.
+func (p InitialPosition) String() string {
+	switch {
+	case p.earliest:
+		return "earliest"
+	case p.id != nil:
+		return "message " + p.id.String()
+	case !p.ts.IsZero():
+		return fmt.Sprintf("timestamp %s", p.ts.Format(time.RFC3339))
+	}
+	return "latest"
+}
+
+// apply sets the fields of cmd selecting p, as of now.
+func (p InitialPosition) apply(cmd *api.CommandSubscribe, now time.Time) {
+	cmd.InitialPosition = api.CommandSubscribe_Latest.Enum()
+	switch {
+	case p.earliest:
+		cmd.InitialPosition = api.CommandSubscribe_Earliest.Enum()
+	case p.id != nil:
+		cmd.StartMessageId = p.id.Data()
+	case !p.ts.IsZero():
+		if d := now.Sub(p.ts); d > 0 {
+			cmd.StartMessageRollbackDurationSec = proto.Uint64(uint64((d + time.Second - 1) / time.Second))
+		}
+	}
+}
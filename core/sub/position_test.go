@@ -0,0 +1,83 @@
+// Copyright 2018 Comcast Cable Communications Management, LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sub
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/pepper-iot/pulsar-client-go/core/msg"
+	"github.com/pepper-iot/pulsar-client-go/pkg/api"
+)
+
+func TestInitialPosition_apply(t *testing.T) {
+	now := time.Date(2018, 7, 1, 12, 0, 0, 0, time.UTC)
+	id := msg.MessageID{LedgerID: 12, EntryID: 34, Partition: 1, BatchIndex: -1}
+
+	for name, tc := range map[string]struct {
+		p        InitialPosition
+		expected api.CommandSubscribe
+	}{
+		"zero": {
+			p:        InitialPosition{},
+			expected: api.CommandSubscribe{InitialPosition: api.CommandSubscribe_Latest.Enum()},
+		},
+		"latest": {
+			p:        Latest,
+			expected: api.CommandSubscribe{InitialPosition: api.CommandSubscribe_Latest.Enum()},
+		},
+		"earliest": {
+			p:        Earliest,
+			expected: api.CommandSubscribe{InitialPosition: api.CommandSubscribe_Earliest.Enum()},
+		},
+		"message ID": {
+			p: AtMessageID(id),
+			expected: api.CommandSubscribe{
+				InitialPosition: api.CommandSubscribe_Latest.Enum(),
+				StartMessageId:  id.Data(),
+			},
+		},
+		"timestamp": {
+			p: AtTimestamp(now.Add(-90*time.Second - time.Millisecond)),
+			expected: api.CommandSubscribe{
+				InitialPosition:                 api.CommandSubscribe_Latest.Enum(),
+				StartMessageRollbackDurationSec: proto.Uint64(91),
+			},
+		},
+		"future timestamp": {
+			p:        AtTimestamp(now.Add(time.Minute)),
+			expected: api.CommandSubscribe{InitialPosition: api.CommandSubscribe_Latest.Enum()},
+		},
+	} {
+		var got api.CommandSubscribe
+		tc.p.apply(&got, now)
+		if !proto.Equal(&got, &tc.expected) {
+			t.Fatalf("%s: apply() = %v; expected %v", name, &got, &tc.expected)
+		}
+	}
+}
+
+func TestInitialPosition_String(t *testing.T) {
+	for p, expected := range map[InitialPosition]string{
+		Latest:   "latest",
+		Earliest: "earliest",
+		AtMessageID(msg.MessageID{LedgerID: 12, EntryID: 34, Partition: -1, BatchIndex: -1}): "message 12:34:-1",
+		AtTimestamp(time.Date(2018, 7, 1, 12, 0, 0, 0, time.UTC)):                            "timestamp 2018-07-01T12:00:00Z",
+	} {
+		if got := p.String(); got != expected {
+			t.Fatalf("String() = %q; expected %q", got, expected)
+		}
+	}
+}
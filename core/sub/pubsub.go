@@ -15,7 +15,7 @@ package sub
 
 import (
 	"context"
-	"fmt"
+	"time"
 
 	"github.com/golang/protobuf/proto"
 	"github.com/pepper-iot/pulsar-client-go/core/frame"
@@ -32,6 +32,7 @@ func NewPubsub(s frame.CmdSender, dispatcher *frame.Dispatcher, subscriptions *S
 		ReqID:         reqID,
 		ProducerID:    &msg.MonotonicID{ID: 0},
 		ConsumerID:    &msg.MonotonicID{ID: 0},
+		WatcherID:     &msg.MonotonicID{ID: 0},
 		Dispatcher:    dispatcher,
 		Subscriptions: subscriptions,
 	}
@@ -43,6 +44,7 @@ type Pubsub struct {
 	ReqID      *msg.MonotonicID
 	ProducerID *msg.MonotonicID
 	ConsumerID *msg.MonotonicID
+	WatcherID  *msg.MonotonicID
 
 	Dispatcher    *frame.Dispatcher // handles request response state
 	Subscriptions *Subscriptions
@@ -51,23 +53,32 @@ type Pubsub struct {
 // Subscribe subscribes to the given topic. The queueSize determines the buffer
 // size of the Consumer.Messages() channel.
 func (t *Pubsub) Subscribe(ctx context.Context, topic, sub string, subType api.CommandSubscribe_SubType,
-	initialPosition api.CommandSubscribe_InitialPosition, queue chan msg.Message) (*Consumer, error) {
+	initialPosition InitialPosition, queue chan msg.Message) (*Consumer, error) {
+	return t.SubscribeWithSchema(ctx, topic, sub, subType, initialPosition, queue, nil)
+}
+
+// SubscribeWithSchema is like Subscribe, but also sends the consumer's
+// schema to the broker. The schema may be nil.
+func (t *Pubsub) SubscribeWithSchema(ctx context.Context, topic, sub string, subType api.CommandSubscribe_SubType,
+	initialPosition InitialPosition, queue chan msg.Message, schema *api.Schema) (*Consumer, error) {
 	requestID := t.ReqID.Next()
 	consumerID := t.ConsumerID.Next()
 
 	cmd := api.BaseCommand{
 		Type: api.BaseCommand_SUBSCRIBE.Enum(),
 		Subscribe: &api.CommandSubscribe{
-			SubType:         subType.Enum(),
-			Topic:           proto.String(topic),
-			Subscription:    proto.String(sub),
-			RequestId:       requestID,
-			ConsumerId:      consumerID,
-			InitialPosition: initialPosition.Enum(),
+			SubType:      subType.Enum(),
+			Topic:        proto.String(topic),
+			Subscription: proto.String(sub),
+			RequestId:    requestID,
+			ConsumerId:   consumerID,
+			Schema:       schema,
 		},
 	}
+	initialPosition.apply(cmd.Subscribe, time.Now())
 
-	resp, cancel, errs := t.Dispatcher.RegisterReqID(*requestID)
+	deadline, _ := ctx.Deadline()
+	resp, cancel, errs := t.Dispatcher.RegisterReqIDDeadline(*requestID, deadline)
 	if errs != nil {
 		return nil, errs
 	}
@@ -105,7 +116,7 @@ func (t *Pubsub) Subscribe(ctx context.Context, topic, sub string, subType api.C
 			t.Subscriptions.DelConsumer(c)
 
 			errMsg := f.BaseCmd.GetError()
-			return nil, fmt.Errorf("%s: %s", errMsg.GetError().String(), errMsg.GetMessage())
+			return nil, utils.NewServerError(errMsg.GetError(), errMsg.GetMessage())
 
 		default:
 			t.Subscriptions.DelConsumer(c)
@@ -115,8 +126,83 @@ func (t *Pubsub) Subscribe(ctx context.Context, topic, sub string, subType api.C
 	}
 }
 
+// WatchTopicList watches the topics of the namespace whose names match
+// the pattern, a regular expression in Java syntax matched against the
+// full topic names. The returned watcher holds the topics matching
+// initially, and its updates are sent to the updates channel.
+//
+// https://pulsar.apache.org/docs/next/developing-binary-protocol/#topic-list-watcher
+func (t *Pubsub) WatchTopicList(ctx context.Context, namespace, pattern string, updates chan TopicListUpdate) (*TopicListWatcher, error) {
+	requestID := t.ReqID.Next()
+	watcherID := t.WatcherID.Next()
+
+	cmd := api.BaseCommand{
+		Type: api.BaseCommand_WATCH_TOPIC_LIST.Enum(),
+		WatchTopicList: &api.CommandWatchTopicList{
+			RequestId:     requestID,
+			WatcherId:     watcherID,
+			Namespace:     proto.String(namespace),
+			TopicsPattern: proto.String(pattern),
+		},
+	}
+
+	deadline, _ := ctx.Deadline()
+	resp, cancel, err := t.Dispatcher.RegisterReqIDDeadline(*requestID, deadline)
+	if err != nil {
+		return nil, err
+	}
+	defer cancel()
+
+	w := newTopicListWatcher(t.S, t.Dispatcher, t.ReqID, *watcherID, namespace, pattern, updates)
+	// like consumers, the watcher is added before sending the
+	// command, so that no update following the response is missed
+	t.Subscriptions.AddWatcher(w)
+
+	if err := t.S.SendSimpleCmd(cmd); err != nil {
+		t.Subscriptions.DelWatcher(w)
+		return nil, err
+	}
+
+	// wait for a response or timeout
+
+	select {
+	case <-ctx.Done():
+		t.Subscriptions.DelWatcher(w)
+		return nil, ctx.Err()
+
+	case f := <-resp:
+		msgType := f.BaseCmd.GetType()
+		// Possible responses types are:
+		//  - WatchTopicListSuccess
+		//  - Error
+		switch msgType {
+		case api.BaseCommand_WATCH_TOPIC_LIST_SUCCESS:
+			w.setTopics(f.BaseCmd.GetWatchTopicListSuccess())
+			return w, nil
+
+		case api.BaseCommand_ERROR:
+			t.Subscriptions.DelWatcher(w)
+
+			errMsg := f.BaseCmd.GetError()
+			return nil, utils.NewServerError(errMsg.GetError(), errMsg.GetMessage())
+
+		default:
+			t.Subscriptions.DelWatcher(w)
+
+			return nil, utils.NewUnexpectedErrMsg(msgType, *requestID)
+		}
+	}
+}
+
 // Producer creates a new producer for the given topic and producerName.
 func (t *Pubsub) Producer(ctx context.Context, topic, producerName string) (*pub.Producer, error) {
+	return t.ProducerWithSchema(ctx, topic, producerName, nil)
+}
+
+// ProducerWithSchema is like Producer, but also sends the producer's
+// schema to the broker. The schema may be nil. The version of the
+// schema returned by the broker is set on the messages sent.
+func (t *Pubsub) ProducerWithSchema(ctx context.Context, topic, producerName string, schema *api.Schema) (*pub.Producer, error) {
 	requestID := t.ReqID.Next()
 	producerID := t.ProducerID.Next()
 
@@ -126,19 +212,22 @@ func (t *Pubsub) Producer(ctx context.Context, topic, producerName string) (*pub
 			RequestId:  requestID,
 			ProducerId: producerID,
 			Topic:      proto.String(topic),
+			Schema:     schema,
 		},
 	}
 	if producerName != "" {
 		cmd.Producer.ProducerName = proto.String(producerName)
 	}
 
-	resp, cancel, err := t.Dispatcher.RegisterReqID(*requestID)
+	deadline, _ := ctx.Deadline()
+	resp, cancel, err := t.Dispatcher.RegisterReqIDDeadline(*requestID, deadline)
 	if err != nil {
 		return nil, err
 	}
 	defer cancel()
 
 	p := pub.NewProducer(t.S, t.Dispatcher, t.ReqID, *producerID)
+	p.Topic = topic
 	// the new producer needs to be added to subscriptions before sending
 	// the create command to avoid potential race conditions
 	t.Subscriptions.AddProducer(p)
@@ -165,13 +254,20 @@ func (t *Pubsub) Producer(ctx context.Context, topic, producerName string) (*pub
 			success := f.BaseCmd.GetProducerSuccess()
 			// TODO: is this a race?
 			p.ProducerName = success.GetProducerName()
+			p.SchemaVersion = success.GetSchemaVersion()
+			// with deduplication enabled, the broker returns the
+			// highest sequence id it persisted for the producer name,
+			// from which the sequence ids continue. Otherwise it is -1.
+			if last := success.GetLastSequenceId(); last >= 0 {
+				p.SeqID.ID = uint64(last) + 1
+			}
 			return p, nil
 
 		case api.BaseCommand_ERROR:
 			t.Subscriptions.DelProducer(p)
 
 			errMsg := f.BaseCmd.GetError()
-			return nil, fmt.Errorf("%s: %s", errMsg.GetError().String(), errMsg.GetMessage())
+			return nil, utils.NewServerError(errMsg.GetError(), errMsg.GetMessage())
 
 		default:
 			t.Subscriptions.DelProducer(p)
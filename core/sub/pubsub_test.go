@@ -15,6 +15,7 @@ package sub
 
 import (
 	"context"
+	"errors"
 	"testing"
 	"time"
 
@@ -23,6 +24,7 @@ import (
 	"github.com/pepper-iot/pulsar-client-go/core/msg"
 	"github.com/pepper-iot/pulsar-client-go/core/pub"
 	"github.com/pepper-iot/pulsar-client-go/pkg/api"
+	"github.com/pepper-iot/pulsar-client-go/utils"
 )
 
 func TestPubsub_Subscribe_Success(t *testing.T) {
@@ -50,12 +52,14 @@ func TestPubsub_Subscribe_Success(t *testing.T) {
 	go func() {
 		var r response
 		r.c, r.err = tp.Subscribe(ctx, "test-topic", "test-subscription", api.CommandSubscribe_Exclusive,
-			api.CommandSubscribe_Latest, make(chan msg.Message, 1))
+			Latest, make(chan msg.Message, 1))
 		resp <- r
 	}()
 
-	// Allow goroutine time to complete
-	time.Sleep(100 * time.Millisecond)
+	// Wait for the goroutine to send the command
+	if _, err := ms.WaitFrames(ctx, 1); err != nil {
+		t.Fatal(err)
+	}
 
 	// send success response
 	success := api.CommandSuccess{
@@ -109,16 +113,19 @@ func TestPubsub_Subscribe_Error(t *testing.T) {
 	go func() {
 		var r response
 		r.c, r.err = tp.Subscribe(ctx, "test-topic", "test-subscription", api.CommandSubscribe_Exclusive,
-			api.CommandSubscribe_Latest, make(chan msg.Message, 1))
+			Latest, make(chan msg.Message, 1))
 		resp <- r
 	}()
 
-	// Allow goroutine time to complete
-	time.Sleep(100 * time.Millisecond)
+	// Wait for the goroutine to send the command
+	if _, err := ms.WaitFrames(ctx, 1); err != nil {
+		t.Fatal(err)
+	}
 
 	// send error response
 	cmdErr := api.CommandError{
 		RequestId: proto.Uint64(id),
+		Error:     api.ServerError_ConsumerBusy.Enum(),
 		Message:   proto.String("oh noo"),
 	}
 	f := frame.Frame{
@@ -136,6 +143,9 @@ func TestPubsub_Subscribe_Error(t *testing.T) {
 		t.Fatalf("subscribe() err = %v; expected non-nil", r.err)
 	}
 	t.Logf("subscribe() err = %v", r.err)
+	if !errors.Is(r.err, utils.ErrConsumerBusy) {
+		t.Fatalf("subscribe() err = %v; expected %v", r.err, utils.ErrConsumerBusy)
+	}
 
 	if got, expected := len(subs.Consumers), 0; got != expected {
 		t.Fatalf("subscriptions.consumers has %d elements; expected %d", got, expected)
@@ -170,8 +180,10 @@ func TestPubsub_Producer_Success(t *testing.T) {
 		resp <- r
 	}()
 
-	// Allow goroutine time to complete
-	time.Sleep(100 * time.Millisecond)
+	// Wait for the goroutine to send the command
+	if _, err := ms.WaitFrames(ctx, 1); err != nil {
+		t.Fatal(err)
+	}
 
 	// send success response
 	prodName := "returned producer name"
@@ -210,6 +222,63 @@ func TestPubsub_Producer_Success(t *testing.T) {
 	}
 }
 
+func TestPubsub_Producer_LastSequenceID(t *testing.T) {
+	for _, c := range []struct {
+		last     *int64
+		expected uint64
+	}{
+		{nil, 0},
+		{proto.Int64(-1), 0},
+		{proto.Int64(41), 42},
+	} {
+		var ms frame.MockSender
+		id := uint64(42)
+		reqID := &msg.MonotonicID{ID: id}
+		dispatcher := frame.NewFrameDispatcher()
+
+		tp := NewPubsub(&ms, dispatcher, NewSubscriptions(), reqID)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		type response struct {
+			p   *pub.Producer
+			err error
+		}
+		resp := make(chan response, 1)
+		go func() {
+			var r response
+			r.p, r.err = tp.Producer(ctx, "test-topic", "test-name")
+			resp <- r
+		}()
+
+		if _, err := ms.WaitFrames(ctx, 1); err != nil {
+			t.Fatal(err)
+		}
+		f := frame.Frame{
+			BaseCmd: &api.BaseCommand{
+				Type: api.BaseCommand_PRODUCER_SUCCESS.Enum(),
+				ProducerSuccess: &api.CommandProducerSuccess{
+					RequestId:      proto.Uint64(id),
+					ProducerName:   proto.String("test-name"),
+					LastSequenceId: c.last,
+				},
+			},
+		}
+		if err := dispatcher.NotifyReqID(id, f); err != nil {
+			t.Fatal(err)
+		}
+
+		r := <-resp
+		if r.err != nil {
+			t.Fatal(r.err)
+		}
+		if got := *r.p.SeqID.Next(); got != c.expected {
+			t.Fatalf("last sequence id %v: next sequence id = %d; expected %d", c.last, got, c.expected)
+		}
+	}
+}
+
 func TestPubsub_Producer_Error(t *testing.T) {
 	var ms frame.MockSender
 	id := uint64(42)
@@ -238,8 +307,10 @@ func TestPubsub_Producer_Error(t *testing.T) {
 		resp <- r
 	}()
 
-	// Allow goroutine time to complete
-	time.Sleep(100 * time.Millisecond)
+	// Wait for the goroutine to send the command
+	if _, err := ms.WaitFrames(ctx, 1); err != nil {
+		t.Fatal(err)
+	}
 
 	// send error response
 	cmdErr := api.CommandError{
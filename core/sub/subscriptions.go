@@ -26,6 +26,7 @@ func NewSubscriptions() *Subscriptions {
 	return &Subscriptions{
 		Consumers: make(map[uint64]*Consumer),
 		Producers: make(map[uint64]*pub.Producer),
+		Watchers:  make(map[uint64]*TopicListWatcher),
 	}
 }
 
@@ -37,6 +38,9 @@ type Subscriptions struct {
 
 	Pmu       sync.Mutex // protects following
 	Producers map[uint64]*pub.Producer
+
+	Wmu      sync.Mutex // protects following
+	Watchers map[uint64]*TopicListWatcher
 }
 
 func (s *Subscriptions) AddConsumer(c *Consumer) {
@@ -89,6 +93,18 @@ func (s *Subscriptions) HandleMessage(consumerID uint64, f frame.Frame) error {
 	return c.HandleMessage(f)
 }
 
+func (s *Subscriptions) HandleCorruptMessage(consumerID uint64, f frame.Frame, err error) error {
+	s.Cmu.RLock()
+	c, ok := s.Consumers[consumerID]
+	s.Cmu.RUnlock()
+
+	if !ok {
+		return utils.NewUnexpectedErrMsg(f.BaseCmd.GetType(), consumerID)
+	}
+
+	return c.HandleCorruptMessage(f, err)
+}
+
 func (s *Subscriptions) AddProducer(p *pub.Producer) {
 	s.Pmu.Lock()
 	s.Producers[p.ProducerID] = p
@@ -130,3 +146,27 @@ func (s *Subscriptions) HandleActiveConsumerChange(consumerID uint64, f frame.Fr
 
 	return nil
 }
+
+func (s *Subscriptions) AddWatcher(w *TopicListWatcher) {
+	s.Wmu.Lock()
+	s.Watchers[w.WatcherID] = w
+	s.Wmu.Unlock()
+}
+
+func (s *Subscriptions) DelWatcher(w *TopicListWatcher) {
+	s.Wmu.Lock()
+	delete(s.Watchers, w.WatcherID)
+	s.Wmu.Unlock()
+}
+
+func (s *Subscriptions) HandleTopicListUpdate(watcherID uint64, f frame.Frame) error {
+	s.Wmu.Lock()
+	w, ok := s.Watchers[watcherID]
+	s.Wmu.Unlock()
+
+	if !ok {
+		return utils.NewUnexpectedErrMsg(f.BaseCmd.GetType(), watcherID)
+	}
+
+	return w.HandleUpdate(f)
+}
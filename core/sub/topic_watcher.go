@@ -0,0 +1,189 @@
+// Copyright 2018 Comcast Cable Communications Management, LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sub
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"sync/atomic"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/pepper-iot/pulsar-client-go/core/frame"
+	"github.com/pepper-iot/pulsar-client-go/core/msg"
+	"github.com/pepper-iot/pulsar-client-go/pkg/api"
+)
+
+// TopicListUpdate is a change of the topics matched by a TopicListWatcher.
+type TopicListUpdate struct {
+	NewTopics     []string
+	DeletedTopics []string
+	TopicsHash    string // hash of the topics after the update, computed by the broker
+}
+
+// newTopicListWatcher returns a ready-to-use TopicListWatcher.
+func newTopicListWatcher(s frame.CmdSender, dispatcher *frame.Dispatcher, reqID *msg.MonotonicID, watcherID uint64, namespace, pattern string, updates chan TopicListUpdate) *TopicListWatcher {
+	return &TopicListWatcher{
+		S:          s,
+		ReqID:      reqID,
+		Dispatcher: dispatcher,
+		WatcherID:  watcherID,
+		Namespace:  namespace,
+		Pattern:    pattern,
+		Updates:    updates,
+		topics:     make(map[string]struct{}),
+		Closedc:    make(chan struct{}),
+	}
+}
+
+// TopicListWatcher is notified by the broker (Pulsar 2.11+) of the
+// topics of a namespace matching a pattern being created or deleted,
+// which lets regex subscriptions discover topics without polling.
+type TopicListWatcher struct {
+	S frame.CmdSender
+
+	WatcherID uint64
+	Namespace string
+	Pattern   string
+
+	ReqID      *msg.MonotonicID
+	Dispatcher *frame.Dispatcher // handles request/response state
+
+	Updates chan TopicListUpdate
+
+	tmu    sync.Mutex // protects following
+	topics map[string]struct{}
+	hash   string
+
+	Mu       sync.Mutex // protects following
+	IsClosed bool
+	Closedc  chan struct{}
+
+	overflowed uint64 // atomically updated number of updates dropped because Updates was full
+}
+
+// Topics returns the topics currently matched, sorted. It reflects
+// all updates, including those dropped because Updates was full.
+func (w *TopicListWatcher) Topics() []string {
+	w.tmu.Lock()
+	defer w.tmu.Unlock()
+
+	topics := make([]string, 0, len(w.topics))
+	for t := range w.topics {
+		topics = append(topics, t)
+	}
+	sort.Strings(topics)
+	return topics
+}
+
+// TopicsHash returns the broker-computed hash of the topics currently
+// matched. It can be compared to the hash of a topic list obtained
+// otherwise to detect missed updates.
+func (w *TopicListWatcher) TopicsHash() string {
+	w.tmu.Lock()
+	defer w.tmu.Unlock()
+	return w.hash
+}
+
+// Overflowed returns the number of updates dropped
+// because the watcher's Updates channel was full.
+func (w *TopicListWatcher) Overflowed() uint64 {
+	return atomic.LoadUint64(&w.overflowed)
+}
+
+// Closed returns a channel that unblocks when the watcher is closed.
+func (w *TopicListWatcher) Closed() <-chan struct{} {
+	return w.Closedc
+}
+
+// Close stops watching the topic list.
+func (w *TopicListWatcher) Close(ctx context.Context) error {
+	w.Mu.Lock()
+	defer w.Mu.Unlock()
+
+	if w.IsClosed {
+		return nil
+	}
+
+	requestID := w.ReqID.Next()
+
+	cmd := api.BaseCommand{
+		Type: api.BaseCommand_WATCH_TOPIC_LIST_CLOSE.Enum(),
+		WatchTopicListClose: &api.CommandWatchTopicListClose{
+			RequestId: requestID,
+			WatcherId: proto.Uint64(w.WatcherID),
+		},
+	}
+
+	deadline, _ := ctx.Deadline()
+	resp, cancel, err := w.Dispatcher.RegisterReqIDDeadline(*requestID, deadline)
+	if err != nil {
+		return err
+	}
+	defer cancel()
+
+	if err := w.S.SendSimpleCmd(cmd); err != nil {
+		return err
+	}
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+
+	case <-resp:
+		w.IsClosed = true
+		close(w.Closedc)
+
+		return nil
+	}
+}
+
+// setTopics sets the initial topics from the WATCH_TOPIC_LIST_SUCCESS response.
+func (w *TopicListWatcher) setTopics(success *api.CommandWatchTopicListSuccess) {
+	w.tmu.Lock()
+	defer w.tmu.Unlock()
+
+	for _, t := range success.GetTopic() {
+		w.topics[t] = struct{}{}
+	}
+	w.hash = success.GetTopicsHash()
+}
+
+// HandleUpdate should be called for all WATCH_TOPIC_UPDATE
+// messages received for this watcher.
+func (w *TopicListWatcher) HandleUpdate(f frame.Frame) error {
+	update := f.BaseCmd.GetWatchTopicUpdate()
+
+	w.tmu.Lock()
+	for _, t := range update.GetNewTopics() {
+		w.topics[t] = struct{}{}
+	}
+	for _, t := range update.GetDeletedTopics() {
+		delete(w.topics, t)
+	}
+	w.hash = update.GetTopicsHash()
+	w.tmu.Unlock()
+
+	select {
+	case w.Updates <- TopicListUpdate{
+		NewTopics:     update.GetNewTopics(),
+		DeletedTopics: update.GetDeletedTopics(),
+		TopicsHash:    update.GetTopicsHash(),
+	}:
+	default:
+		atomic.AddUint64(&w.overflowed, 1)
+	}
+
+	return nil
+}
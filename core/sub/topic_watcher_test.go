@@ -0,0 +1,139 @@
+// Copyright 2018 Comcast Cable Communications Management, LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sub
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/pepper-iot/pulsar-client-go/core/frame"
+	"github.com/pepper-iot/pulsar-client-go/core/msg"
+	"github.com/pepper-iot/pulsar-client-go/pkg/api"
+)
+
+func TestPubsub_WatchTopicList(t *testing.T) {
+	var ms frame.MockSender
+	id := uint64(42)
+	reqID := &msg.MonotonicID{ID: id}
+	dispatcher := frame.NewFrameDispatcher()
+	subs := NewSubscriptions()
+
+	tp := NewPubsub(&ms, dispatcher, subs, reqID)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	type response struct {
+		w   *TopicListWatcher
+		err error
+	}
+	resp := make(chan response, 1)
+	updates := make(chan TopicListUpdate, 1)
+
+	go func() {
+		var r response
+		r.w, r.err = tp.WatchTopicList(ctx, "public/default", "persistent://public/default/sensor-.*", updates)
+		resp <- r
+	}()
+
+	// Wait for the goroutine to send the command
+	if _, err := ms.WaitFrames(ctx, 1); err != nil {
+		t.Fatal(err)
+	}
+
+	sent := ms.GetFrames()
+	if len(sent) != 1 || sent[0].BaseCmd.GetType() != api.BaseCommand_WATCH_TOPIC_LIST {
+		t.Fatalf("sent frames %v; expected a WATCH_TOPIC_LIST", sent)
+	}
+	watcherID := sent[0].BaseCmd.GetWatchTopicList().GetWatcherId()
+
+	f := frame.Frame{
+		BaseCmd: &api.BaseCommand{
+			Type: api.BaseCommand_WATCH_TOPIC_LIST_SUCCESS.Enum(),
+			WatchTopicListSuccess: &api.CommandWatchTopicListSuccess{
+				RequestId:  proto.Uint64(id),
+				WatcherId:  proto.Uint64(watcherID),
+				Topic:      []string{"persistent://public/default/sensor-b", "persistent://public/default/sensor-a"},
+				TopicsHash: proto.String("hash1"),
+			},
+		},
+	}
+	if err := dispatcher.NotifyReqID(id, f); err != nil {
+		t.Fatalf("dispatcher.NotifyReqID() err = %v; nil expected", err)
+	}
+
+	r := <-resp
+	if r.err != nil {
+		t.Fatalf("WatchTopicList() err = %v; expected nil", r.err)
+	}
+	w := r.w
+	expected := []string{"persistent://public/default/sensor-a", "persistent://public/default/sensor-b"}
+	if got := w.Topics(); !reflect.DeepEqual(got, expected) {
+		t.Fatalf("Topics() = %v; expected %v", got, expected)
+	}
+
+	// the broker pushes an update
+	update := frame.Frame{
+		BaseCmd: &api.BaseCommand{
+			Type: api.BaseCommand_WATCH_TOPIC_UPDATE.Enum(),
+			WatchTopicUpdate: &api.CommandWatchTopicUpdate{
+				WatcherId:     proto.Uint64(watcherID),
+				NewTopics:     []string{"persistent://public/default/sensor-c"},
+				DeletedTopics: []string{"persistent://public/default/sensor-a"},
+				TopicsHash:    proto.String("hash2"),
+			},
+		},
+	}
+	if err := subs.HandleTopicListUpdate(watcherID, update); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case u := <-updates:
+		if !reflect.DeepEqual(u.NewTopics, update.BaseCmd.WatchTopicUpdate.NewTopics) ||
+			!reflect.DeepEqual(u.DeletedTopics, update.BaseCmd.WatchTopicUpdate.DeletedTopics) {
+			t.Fatalf("got update %+v; expected %+v", u, update.BaseCmd.WatchTopicUpdate)
+		}
+	default:
+		t.Fatal("no update received")
+	}
+	expected = []string{"persistent://public/default/sensor-b", "persistent://public/default/sensor-c"}
+	if got := w.Topics(); !reflect.DeepEqual(got, expected) {
+		t.Fatalf("Topics() = %v; expected %v", got, expected)
+	}
+	if got := w.TopicsHash(); got != "hash2" {
+		t.Fatalf("TopicsHash() = %q; expected %q", got, "hash2")
+	}
+
+	// updates that don't fit in the channel are still applied
+	update.BaseCmd.WatchTopicUpdate.NewTopics = []string{"persistent://public/default/sensor-d"}
+	update.BaseCmd.WatchTopicUpdate.DeletedTopics = nil
+	for i := 0; i < 2; i++ {
+		if err := subs.HandleTopicListUpdate(watcherID, update); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if got := w.Overflowed(); got != 1 {
+		t.Fatalf("Overflowed() = %d; expected 1", got)
+	}
+	if got := w.Topics(); len(got) != 3 {
+		t.Fatalf("Topics() = %v; expected 3 topics", got)
+	}
+
+	// unknown watchers are unexpected
+	if err := subs.HandleTopicListUpdate(watcherID+1, update); err == nil {
+		t.Fatal("HandleTopicListUpdate() err = nil for unknown watcher; expected error")
+	}
+}
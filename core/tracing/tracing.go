@@ -0,0 +1,223 @@
+// Copyright 2018 Comcast Cable Communications Management, LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package tracing propagates OpenTelemetry trace context through
+// message properties, using the W3C Trace Context format
+// ("traceparent" and "tracestate" properties) by default, and
+// creates spans for sending, receiving and acknowledging messages.
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/pepper-iot/pulsar-client-go/core/manage"
+	"github.com/pepper-iot/pulsar-client-go/core/msg"
+	"github.com/pepper-iot/pulsar-client-go/core/pub"
+	"github.com/pepper-iot/pulsar-client-go/pkg/api"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// instrumentationName identifies the spans created by this package.
+const instrumentationName = "github.com/pepper-iot/pulsar-client-go/core/tracing"
+
+// Config is used to configure a Tracer.
+type Config struct {
+	TracerProvider trace.TracerProvider          // defaults to the global TracerProvider
+	Propagator     propagation.TextMapPropagator // defaults to W3C Trace Context
+}
+
+// setDefaults returns a modified config with appropriate zero values set to defaults.
+func (c Config) setDefaults() Config {
+	if c.TracerProvider == nil {
+		c.TracerProvider = otel.GetTracerProvider()
+	}
+	if c.Propagator == nil {
+		c.Propagator = propagation.TraceContext{}
+	}
+	return c
+}
+
+// NewTracer returns an initialized Tracer.
+func NewTracer(cfg Config) *Tracer {
+	cfg = cfg.setDefaults()
+	return &Tracer{
+		tracer:     cfg.TracerProvider.Tracer(instrumentationName),
+		propagator: cfg.Propagator,
+	}
+}
+
+// Tracer traces messages across producers and consumers. It is a
+// pub.TraceHook, which injects the trace context of the context
+// passed to Send into the properties of the sent message, and should
+// be set as the TraceHook of ManagedProducers. Receive extracts it
+// on the consuming side.
+type Tracer struct {
+	tracer     trace.Tracer
+	propagator propagation.TextMapPropagator
+}
+
+var _ pub.TraceHook = (*Tracer)(nil)
+
+// OnSend implements pub.TraceHook.
+func (t *Tracer) OnSend(ctx context.Context, meta *api.MessageMetadata, payload []byte) {
+	t.propagator.Inject(ctx, &Carrier{Meta: meta})
+}
+
+// OnSendReceipt implements pub.TraceHook. It adds an event with the
+// message's ID and round-trip to the span of ctx, if any, eg that of
+// Send.
+func (t *Tracer) OnSendReceipt(ctx context.Context, receipt *api.CommandSendReceipt, latency time.Duration) {
+	trace.SpanFromContext(ctx).AddEvent("message acknowledged", trace.WithAttributes(
+		attribute.String("messaging.message_id", messageID(receipt.GetMessageId())),
+		attribute.Int64("messaging.pulsar.send_latency_ms", latency.Milliseconds()),
+	))
+}
+
+// OnSendError implements pub.TraceHook. It records err
+// on the span of ctx, if any.
+func (t *Tracer) OnSendError(ctx context.Context, err error) {
+	trace.SpanFromContext(ctx).RecordError(err)
+}
+
+// Send sends payload with the ManagedProducer within a producer span.
+// The ManagedProducer must be configured with t as TraceHook for the
+// span to be propagated to consumers.
+func (t *Tracer) Send(ctx context.Context, mp *manage.ManagedProducer, payload []byte) (*api.CommandSendReceipt, error) {
+	ctx, span := t.tracer.Start(ctx, "send "+mp.Cfg.Topic,
+		trace.WithSpanKind(trace.SpanKindProducer),
+		trace.WithAttributes(
+			attribute.String("messaging.system", "pulsar"),
+			attribute.String("messaging.destination", mp.Cfg.Topic),
+			attribute.Int("messaging.message_payload_size_bytes", len(payload)),
+		),
+	)
+	defer span.End()
+
+	receipt, err := mp.Send(ctx, payload)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+	span.SetAttributes(attribute.String("messaging.message_id", messageID(receipt.GetMessageId())))
+	return receipt, nil
+}
+
+// Receive receives a message with the ManagedConsumer within a
+// consumer span, whose parent is the trace context carried by the
+// message, if any. The returned context carries the span, so that
+// processing the message can be traced as part of the same trace.
+func (t *Tracer) Receive(ctx context.Context, mc *manage.ManagedConsumer) (context.Context, msg.Message, error) {
+	m, err := mc.Receive(ctx)
+	if err != nil {
+		return ctx, m, err
+	}
+
+	ctx, span := t.tracer.Start(t.Extract(ctx, m), "receive "+m.Topic,
+		trace.WithSpanKind(trace.SpanKindConsumer),
+		trace.WithAttributes(
+			attribute.String("messaging.system", "pulsar"),
+			attribute.String("messaging.destination", m.Topic),
+			attribute.String("messaging.operation", "receive"),
+			attribute.String("messaging.message_id", messageID(m.Msg.GetMessageId())),
+			attribute.Int("messaging.message_payload_size_bytes", len(m.Payload)),
+		),
+	)
+	span.End()
+
+	return ctx, m, nil
+}
+
+// Ack acknowledges the message with the ManagedConsumer within a span.
+func (t *Tracer) Ack(ctx context.Context, mc *manage.ManagedConsumer, m msg.Message) error {
+	_, span := t.tracer.Start(ctx, "ack "+m.Topic,
+		trace.WithSpanKind(trace.SpanKindConsumer),
+		trace.WithAttributes(
+			attribute.String("messaging.system", "pulsar"),
+			attribute.String("messaging.destination", m.Topic),
+			attribute.String("messaging.message_id", messageID(m.Msg.GetMessageId())),
+		),
+	)
+	defer span.End()
+
+	if err := mc.Ack(ctx, m); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return err
+	}
+	return nil
+}
+
+// Extract returns a copy of ctx carrying the trace context
+// found in the properties of m, e.g. for messages received
+// with ManagedConsumer.ReceiveAsync.
+func (t *Tracer) Extract(ctx context.Context, m msg.Message) context.Context {
+	if m.Meta == nil {
+		return ctx
+	}
+	return t.propagator.Extract(ctx, &Carrier{Meta: m.Meta})
+}
+
+// Carrier is a propagation.TextMapCarrier
+// over the properties of a message.
+type Carrier struct {
+	Meta *api.MessageMetadata
+}
+
+var _ propagation.TextMapCarrier = (*Carrier)(nil)
+
+// Get implements propagation.TextMapCarrier.
+func (c *Carrier) Get(key string) string {
+	for _, kv := range c.Meta.GetProperties() {
+		if kv.GetKey() == key {
+			return kv.GetValue()
+		}
+	}
+	return ""
+}
+
+// Set implements propagation.TextMapCarrier. An existing
+// property with the same key is overwritten.
+func (c *Carrier) Set(key, value string) {
+	for _, kv := range c.Meta.Properties {
+		if kv.GetKey() == key {
+			kv.Value = proto.String(value)
+			return
+		}
+	}
+	c.Meta.Properties = append(c.Meta.Properties, &api.KeyValue{
+		Key:   proto.String(key),
+		Value: proto.String(value),
+	})
+}
+
+// Keys implements propagation.TextMapCarrier.
+func (c *Carrier) Keys() []string {
+	keys := make([]string, len(c.Meta.GetProperties()))
+	for i, kv := range c.Meta.GetProperties() {
+		keys[i] = kv.GetKey()
+	}
+	return keys
+}
+
+// messageID formats a message ID as ledgerId:entryId:partition.
+func messageID(id *api.MessageIdData) string {
+	return fmt.Sprintf("%d:%d:%d", id.GetLedgerId(), id.GetEntryId(), id.GetPartition())
+}
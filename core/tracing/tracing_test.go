@@ -0,0 +1,146 @@
+// Copyright 2018 Comcast Cable Communications Management, LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tracing
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/pepper-iot/pulsar-client-go/core/frame"
+	"github.com/pepper-iot/pulsar-client-go/core/manage"
+	"github.com/pepper-iot/pulsar-client-go/core/srv"
+	"github.com/pepper-iot/pulsar-client-go/pkg/api"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestCarrier(t *testing.T) {
+	c := Carrier{Meta: new(api.MessageMetadata)}
+	c.Set("traceparent", "a")
+	c.Set("tracestate", "b")
+	c.Set("traceparent", "c")
+
+	if got, expected := c.Get("traceparent"), "c"; got != expected {
+		t.Fatalf("Get() = %q; expected %q", got, expected)
+	}
+	if got := c.Get("missing"); got != "" {
+		t.Fatalf("Get() = %q; expected empty", got)
+	}
+	if got, expected := len(c.Keys()), 2; got != expected {
+		t.Fatalf("len(Keys()) = %d; expected %d", got, expected)
+	}
+}
+
+// nextFrame returns the next frame of the given type received by s.
+func nextFrame(ctx context.Context, t *testing.T, s *srv.Server, typ api.BaseCommand_Type) frame.Frame {
+	for {
+		select {
+		case f := <-s.Received:
+			if f.BaseCmd.GetType() == typ {
+				return f
+			}
+		case <-ctx.Done():
+			t.Fatalf("timeout waiting for %q frame", typ)
+		}
+	}
+}
+
+func TestTracer(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	s, err := srv.NewServer(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	recorder := tracetest.NewSpanRecorder()
+	tracer := NewTracer(Config{
+		TracerProvider: sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)),
+	})
+
+	cp := manage.NewClientPool()
+	mp := manage.NewManagedProducer(ctx, cp, manage.ProducerConfig{
+		ClientConfig: manage.ClientConfig{
+			Addr: s.Addr,
+		},
+		NewProducerTimeout: time.Second,
+		Topic:              "test-topic",
+		TraceHook:          tracer,
+	})
+	if _, err = tracer.Send(ctx, mp, []byte("hola mundo")); err != nil {
+		t.Fatalf("Send() err = %v; expected nil", err)
+	}
+	sent := nextFrame(ctx, t, s, api.BaseCommand_SEND)
+	if (&Carrier{Meta: sent.Metadata}).Get("traceparent") == "" {
+		t.Fatalf("sent properties = %v; expected traceparent", sent.Metadata.GetProperties())
+	}
+
+	mc := manage.NewManagedConsumer(ctx, cp, manage.ConsumerConfig{
+		ClientConfig: manage.ClientConfig{
+			Addr: s.Addr,
+		},
+		NewConsumerTimeout: time.Second,
+		Topic:              "test-topic",
+		Name:               "test",
+		SubMode:            manage.SubscriptionModeShard,
+	})
+	sub := nextFrame(ctx, t, s, api.BaseCommand_SUBSCRIBE)
+
+	err = s.Broadcast(frame.Frame{
+		BaseCmd: &api.BaseCommand{
+			Type: api.BaseCommand_MESSAGE.Enum(),
+			Message: &api.CommandMessage{
+				ConsumerId: sub.BaseCmd.GetSubscribe().ConsumerId,
+				MessageId: &api.MessageIdData{
+					LedgerId: proto.Uint64(1),
+					EntryId:  proto.Uint64(1),
+				},
+			},
+		},
+		Metadata: sent.Metadata,
+		Payload:  sent.Payload,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	msgCtx, m, err := tracer.Receive(ctx, mc)
+	if err != nil {
+		t.Fatalf("Receive() err = %v; expected nil", err)
+	}
+	if err = tracer.Ack(msgCtx, mc, m); err != nil {
+		t.Fatalf("Ack() err = %v; expected nil", err)
+	}
+
+	spans := recorder.Ended()
+	if got, expected := len(spans), 3; got != expected {
+		t.Fatalf("got %d spans; expected %d", got, expected)
+	}
+	send, receive, ack := spans[0], spans[1], spans[2]
+	if got, expected := receive.Parent().SpanID(), send.SpanContext().SpanID(); got != expected {
+		t.Fatalf("receive span parent = %v; expected send span %v", got, expected)
+	}
+	if got, expected := ack.Parent().SpanID(), receive.SpanContext().SpanID(); got != expected {
+		t.Fatalf("ack span parent = %v; expected receive span %v", got, expected)
+	}
+	if got, expected := ack.SpanContext().TraceID(), send.SpanContext().TraceID(); got != expected {
+		t.Fatalf("ack trace = %v; expected %v", got, expected)
+	}
+	if events := send.Events(); len(events) != 1 || events[0].Name != "message acknowledged" {
+		t.Fatalf("send span events = %v; expected the acknowledgement", events)
+	}
+}
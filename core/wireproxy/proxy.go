@@ -0,0 +1,106 @@
+// Copyright 2018 Comcast Cable Communications Management, LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package wireproxy
+
+import (
+	"io"
+	"net"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// Proxy forwards the frames of the connections it accepts to a broker,
+// and the broker's back, recording them. Frames are forwarded as read
+// off the wire, whether or not they can be decoded.
+type Proxy struct {
+	BrokerAddr string    // e.g. "pulsar://localhost:6650"
+	Recorder   *Recorder // if set, frames are recorded to it
+
+	// OnRecord, if set, is called with each forwarded frame,
+	// e.g. to log it. It's called concurrently.
+	OnRecord func(rec Record)
+
+	// OnError, if set, is called with the errors
+	// closing proxied connections.
+	OnError func(connID uint32, err error)
+
+	connID uint32 // of the last accepted connection
+}
+
+// Serve accepts connections from l and proxies them, until
+// accepting fails, e.g. because l was closed.
+func (p *Proxy) Serve(l net.Listener) error {
+	for {
+		c, err := l.Accept()
+		if err != nil {
+			return err
+		}
+		go p.proxy(atomic.AddUint32(&p.connID, 1), c)
+	}
+}
+
+// proxy forwards frames between client and a new
+// connection to the broker, until either closes.
+func (p *Proxy) proxy(connID uint32, client net.Conn) {
+	defer client.Close()
+
+	broker, err := net.Dial("tcp", strings.TrimPrefix(p.BrokerAddr, "pulsar://"))
+	if err != nil {
+		p.onError(connID, err)
+		return
+	}
+	defer broker.Close()
+
+	errs := make(chan error, 2)
+	go func() { errs <- p.forward(connID, FromClient, client, broker) }()
+	go func() { errs <- p.forward(connID, FromBroker, broker, client) }()
+
+	// closing both connections ends the other direction
+	err = <-errs
+	_ = client.Close()
+	_ = broker.Close()
+	<-errs
+	if err != io.EOF {
+		p.onError(connID, err)
+	}
+}
+
+// forward records and forwards frames from src to dst.
+func (p *Proxy) forward(connID uint32, dir Direction, src io.Reader, dst io.Writer) error {
+	for {
+		raw, err := readRawFrame(src)
+		if err != nil {
+			return err
+		}
+		rec := Record{ConnID: connID, Dir: dir, Time: time.Now(), Raw: raw}
+		if p.Recorder != nil {
+			if err := p.Recorder.Record(rec); err != nil {
+				return err
+			}
+		}
+		if p.OnRecord != nil {
+			p.OnRecord(rec)
+		}
+		if _, err := dst.Write(raw); err != nil {
+			return err
+		}
+	}
+}
+
+func (p *Proxy) onError(connID uint32, err error) {
+	if p.OnError != nil {
+		p.OnError(connID, err)
+	}
+}
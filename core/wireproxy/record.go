@@ -0,0 +1,170 @@
+// Copyright 2018 Comcast Cable Communications Management, LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package wireproxy records the frames exchanged by Pulsar clients and
+// brokers through a proxy, and replays the broker's side of recordings
+// to clients, to reproduce issues at the wire level deterministically.
+package wireproxy
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/pepper-iot/pulsar-client-go/core/frame"
+)
+
+// Direction is the direction of a recorded frame.
+type Direction uint8
+
+// Possible Directions.
+const (
+	FromClient Direction = iota + 1
+	FromBroker
+)
+
+func (d Direction) String() string {
+	switch d {
+	case FromClient:
+		return ">>>"
+	case FromBroker:
+		return "<<<"
+	default:
+		return fmt.Sprintf("Direction(%d)", d)
+	}
+}
+
+// Recordings start with magic, followed by records made up of:
+//
+//	[CONN_ID] [DIRECTION] [TIME] [FRAME]
+//
+// CONN_ID is a uint32 identifying the proxied connection, DIRECTION
+// a byte, and TIME the int64 Unix time in nanoseconds the frame was
+// received at, all big-endian. FRAME is the frame as read off the
+// wire, starting with its totalSize.
+var magic = []byte("PULSARWIRE1\n")
+
+// ErrNotRecording is returned by NewReader if the
+// input doesn't start like a recording does.
+var ErrNotRecording = errors.New("not a wire recording")
+
+// Record is a recorded frame.
+type Record struct {
+	ConnID uint32
+	Dir    Direction
+	Time   time.Time
+	Raw    []byte // as read off the wire
+}
+
+// Frame decodes the frame of the record.
+func (r *Record) Frame() (frame.Frame, error) {
+	var f frame.Frame
+	err := f.Decode(bytes.NewReader(r.Raw))
+	return f, err
+}
+
+// Recorder writes records to a recording. It is safe for concurrent use.
+type Recorder struct {
+	mu      sync.Mutex // protects following
+	w       io.Writer
+	started bool
+}
+
+// NewRecorder returns a Recorder writing to w.
+func NewRecorder(w io.Writer) *Recorder {
+	return &Recorder{w: w}
+}
+
+// Record writes the record.
+func (r *Recorder) Record(rec Record) error {
+	var hdr [13]byte
+	binary.BigEndian.PutUint32(hdr[:4], rec.ConnID)
+	hdr[4] = byte(rec.Dir)
+	binary.BigEndian.PutUint64(hdr[5:], uint64(rec.Time.UnixNano()))
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if !r.started {
+		if _, err := r.w.Write(magic); err != nil {
+			return err
+		}
+		r.started = true
+	}
+	if _, err := r.w.Write(hdr[:]); err != nil {
+		return err
+	}
+	_, err := r.w.Write(rec.Raw)
+	return err
+}
+
+// Reader reads the records of a recording.
+type Reader struct {
+	r io.Reader
+}
+
+// NewReader returns a Reader reading the recording from r.
+func NewReader(r io.Reader) (*Reader, error) {
+	b := make([]byte, len(magic))
+	if _, err := io.ReadFull(r, b); err != nil || !bytes.Equal(b, magic) {
+		return nil, ErrNotRecording
+	}
+	return &Reader{r: r}, nil
+}
+
+// Next returns the next record, or io.EOF
+// once the whole recording has been read.
+func (r *Reader) Next() (Record, error) {
+	var hdr [13]byte
+	if _, err := io.ReadFull(r.r, hdr[:]); err != nil {
+		return Record{}, err
+	}
+	raw, err := readRawFrame(r.r)
+	if err == io.EOF {
+		err = io.ErrUnexpectedEOF
+	}
+	if err != nil {
+		return Record{}, err
+	}
+	return Record{
+		ConnID: binary.BigEndian.Uint32(hdr[:4]),
+		Dir:    Direction(hdr[4]),
+		Time:   time.Unix(0, int64(binary.BigEndian.Uint64(hdr[5:]))),
+		Raw:    raw,
+	}, nil
+}
+
+// readRawFrame reads a frame without decoding it,
+// and returns it starting with its totalSize.
+func readRawFrame(r io.Reader) ([]byte, error) {
+	var size [4]byte
+	if _, err := io.ReadFull(r, size[:]); err != nil {
+		return nil, err
+	}
+	totalSize := binary.BigEndian.Uint32(size[:])
+	if frameSize := int(totalSize) + 4; frameSize > frame.MaxFrameSize {
+		return nil, fmt.Errorf("frame size (%d) cannot be greater than max frame size (%d)", frameSize, frame.MaxFrameSize)
+	}
+	raw := make([]byte, 4+totalSize)
+	copy(raw, size[:])
+	if _, err := io.ReadFull(r, raw[4:]); err != nil {
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		return nil, err
+	}
+	return raw, nil
+}
@@ -0,0 +1,141 @@
+// Copyright 2018 Comcast Cable Communications Management, LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package wireproxy
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net"
+	"sync"
+
+	"github.com/pepper-iot/pulsar-client-go/core/frame"
+	"github.com/pepper-iot/pulsar-client-go/pkg/api"
+)
+
+// DivergedError is returned when a client whose connection is
+// replayed sends a frame of another type than the recorded one.
+type DivergedError struct {
+	ConnID   uint32
+	Index    int // of the frame in the recorded connection
+	Expected api.BaseCommand_Type
+	Got      api.BaseCommand_Type
+}
+
+func (e *DivergedError) Error() string {
+	return fmt.Sprintf("connection %d diverged from the recording at frame %d: got %v; expected %v", e.ConnID, e.Index, e.Got, e.Expected)
+}
+
+// Replayer plays the broker's side of recorded connections back to
+// clients: the n-th connection it accepts replays the n-th recorded
+// one. Frames are replayed in the recorded order, regardless of the
+// recorded times: the frames the broker sent after receiving a frame
+// are sent once the client has sent the corresponding frame.
+type Replayer struct {
+	// OnError, if set, is called with the errors
+	// ending replayed connections, if any.
+	OnError func(connID uint32, err error)
+
+	mu    sync.Mutex // protects following
+	conns [][]Record // in the order of their first record
+	next  int        // index of the next connection to replay
+}
+
+// NewReplayer returns a Replayer of the recording read from r.
+func NewReplayer(r io.Reader) (*Replayer, error) {
+	rr, err := NewReader(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var rp Replayer
+	index := make(map[uint32]int)
+	for {
+		rec, err := rr.Next()
+		if err == io.EOF {
+			return &rp, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		i, ok := index[rec.ConnID]
+		if !ok {
+			i = len(rp.conns)
+			index[rec.ConnID] = i
+			rp.conns = append(rp.conns, nil)
+		}
+		rp.conns[i] = append(rp.conns[i], rec)
+	}
+}
+
+// Serve accepts connections from l and replays the recorded ones to
+// them, until accepting fails, e.g. because l was closed. Connections
+// beyond the recorded ones are closed right away.
+func (rp *Replayer) Serve(l net.Listener) error {
+	for {
+		c, err := l.Accept()
+		if err != nil {
+			return err
+		}
+
+		rp.mu.Lock()
+		var recs []Record
+		if rp.next < len(rp.conns) {
+			recs = rp.conns[rp.next]
+			rp.next++
+		}
+		rp.mu.Unlock()
+
+		go func() {
+			defer c.Close()
+			if err := replay(c, recs); err != nil && rp.OnError != nil {
+				rp.OnError(recs[0].ConnID, err)
+			}
+		}()
+	}
+}
+
+// replay plays the broker's side of the records back to c. It
+// returns once all records have been replayed, or on error.
+func replay(c io.ReadWriter, recs []Record) error {
+	for i, rec := range recs {
+		switch rec.Dir {
+		case FromBroker:
+			if _, err := c.Write(rec.Raw); err != nil {
+				return err
+			}
+
+		case FromClient:
+			raw, err := readRawFrame(c)
+			if err != nil {
+				return err
+			}
+			expected, got := commandType(rec.Raw), commandType(raw)
+			if got != expected {
+				return &DivergedError{ConnID: rec.ConnID, Index: i, Expected: expected, Got: got}
+			}
+		}
+	}
+	return nil
+}
+
+// commandType returns the type of the command of
+// the raw frame, or 0 if it can't be decoded.
+func commandType(raw []byte) api.BaseCommand_Type {
+	var f frame.Frame
+	if err := f.Decode(bytes.NewReader(raw)); err != nil {
+		return 0
+	}
+	return f.BaseCmd.GetType()
+}
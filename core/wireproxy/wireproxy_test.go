@@ -0,0 +1,224 @@
+// Copyright 2018 Comcast Cable Communications Management, LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package wireproxy
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"io/ioutil"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/pepper-iot/pulsar-client-go/core/frame"
+	"github.com/pepper-iot/pulsar-client-go/core/manage"
+	"github.com/pepper-iot/pulsar-client-go/core/srv"
+	"github.com/pepper-iot/pulsar-client-go/pkg/api"
+)
+
+func TestRecorder(t *testing.T) {
+	f := frame.Frame{
+		BaseCmd: &api.BaseCommand{
+			Type: api.BaseCommand_PING.Enum(),
+			Ping: &api.CommandPing{},
+		},
+	}
+	var raw bytes.Buffer
+	if err := f.Encode(&raw); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []Record{
+		{ConnID: 1, Dir: FromClient, Time: time.Unix(1, 2), Raw: raw.Bytes()},
+		{ConnID: 2, Dir: FromBroker, Time: time.Unix(3, 4), Raw: raw.Bytes()},
+	}
+	var b bytes.Buffer
+	r := NewRecorder(&b)
+	for _, rec := range expected {
+		if err := r.Record(rec); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	rr, err := NewReader(&b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, want := range expected {
+		got, err := rr.Next()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got.ConnID != want.ConnID || got.Dir != want.Dir || !got.Time.Equal(want.Time) || !bytes.Equal(got.Raw, want.Raw) {
+			t.Fatalf("record %d = %+v; expected %+v", i, got, want)
+		}
+		decoded, err := got.Frame()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !decoded.Equal(f) {
+			t.Fatalf("record %d frame = %+v; expected %+v", i, decoded, f)
+		}
+	}
+	if _, err := rr.Next(); err != io.EOF {
+		t.Fatalf("Next() err = %v; expected %v", err, io.EOF)
+	}
+
+	if _, err := NewReader(bytes.NewReader([]byte("not a recording"))); err != ErrNotRecording {
+		t.Fatalf("NewReader() err = %v; expected %v", err, ErrNotRecording)
+	}
+}
+
+// connectAndPing connects a Client to addr, and pings through it.
+func connectAndPing(ctx context.Context, addr string) error {
+	c, err := manage.NewClient(manage.ClientConfig{Addr: addr})
+	if err != nil {
+		return err
+	}
+	defer c.Close(ctx)
+	if _, err := c.Connect(ctx, ""); err != nil {
+		return err
+	}
+	return c.Ping(ctx)
+}
+
+func listen(t *testing.T) net.Listener {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return l
+}
+
+func TestProxy_RecordReplay(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	broker, err := srv.NewServer(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// record through the proxy
+	var (
+		mu        sync.Mutex
+		recording bytes.Buffer
+		types     []api.BaseCommand_Type
+	)
+	p := Proxy{
+		BrokerAddr: broker.Addr,
+		Recorder:   NewRecorder(&recording),
+		OnRecord: func(rec Record) {
+			f, err := rec.Frame()
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			mu.Lock()
+			types = append(types, f.BaseCmd.GetType())
+			mu.Unlock()
+		},
+	}
+	l := listen(t)
+	defer l.Close()
+	go func() { _ = p.Serve(l) }()
+
+	if err := connectAndPing(ctx, l.Addr().String()); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []api.BaseCommand_Type{
+		api.BaseCommand_CONNECT,
+		api.BaseCommand_CONNECTED,
+		api.BaseCommand_PING,
+		api.BaseCommand_PONG,
+	}
+	// the client may have closed before the last frame was recorded
+	for {
+		mu.Lock()
+		n := len(types)
+		mu.Unlock()
+		if n >= len(expected) {
+			break
+		}
+		select {
+		case <-ctx.Done():
+			t.Fatalf("recorded %d frames; expected %d", n, len(expected))
+		case <-time.After(time.Millisecond):
+		}
+	}
+	mu.Lock()
+	got := append([]api.BaseCommand_Type(nil), types...)
+	recorded := append([]byte(nil), recording.Bytes()...)
+	mu.Unlock()
+	for i := range expected {
+		if got[i] != expected[i] {
+			t.Fatalf("recorded %v; expected %v", got, expected)
+		}
+	}
+
+	// replay without the broker
+	rp, err := NewReplayer(bytes.NewReader(recorded))
+	if err != nil {
+		t.Fatal(err)
+	}
+	replayErrs := make(chan error, 1)
+	rp.OnError = func(connID uint32, err error) { replayErrs <- err }
+	rl := listen(t)
+	defer rl.Close()
+	go func() { _ = rp.Serve(rl) }()
+
+	if err := connectAndPing(ctx, rl.Addr().String()); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case err := <-replayErrs:
+		t.Fatalf("replay err = %v", err)
+	default:
+	}
+}
+
+func TestReplay_Diverged(t *testing.T) {
+	encode := func(typ api.BaseCommand_Type) []byte {
+		f := frame.Frame{BaseCmd: &api.BaseCommand{Type: typ.Enum()}}
+		if typ == api.BaseCommand_PING {
+			f.BaseCmd.Ping = &api.CommandPing{}
+		} else {
+			f.BaseCmd.Connect = &api.CommandConnect{ClientVersion: proto.String("go"), ProtocolVersion: proto.Int32(1)}
+		}
+		var b bytes.Buffer
+		if err := f.Encode(&b); err != nil {
+			t.Fatal(err)
+		}
+		return b.Bytes()
+	}
+
+	recs := []Record{{ConnID: 3, Dir: FromClient, Raw: encode(api.BaseCommand_CONNECT)}}
+	c := struct {
+		io.Reader
+		io.Writer
+	}{bytes.NewReader(encode(api.BaseCommand_PING)), ioutil.Discard}
+
+	err := replay(c, recs)
+	diverged, ok := err.(*DivergedError)
+	if !ok {
+		t.Fatalf("replay() err = %v; expected a *DivergedError", err)
+	}
+	if diverged.Expected != api.BaseCommand_CONNECT || diverged.Got != api.BaseCommand_PING {
+		t.Fatalf("replay() err = %v", err)
+	}
+}
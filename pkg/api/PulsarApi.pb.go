@@ -3,9 +3,11 @@
 
 package api
 
-import proto "github.com/golang/protobuf/proto"
-import fmt "fmt"
-import math "math"
+import (
+	fmt "fmt"
+	proto "github.com/golang/protobuf/proto"
+	math "math"
+)
 
 // Reference imports to suppress errors if they are not otherwise used.
 var _ = proto.Marshal
@@ -16,15 +18,16 @@ var _ = math.Inf
 // is compatible with the proto package it is being compiled against.
 // A compilation error at this line likely means your copy of the
 // proto package needs to be updated.
-const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
 
 type CompressionType int32
 
 const (
-	CompressionType_NONE CompressionType = 0
-	CompressionType_LZ4  CompressionType = 1
-	CompressionType_ZLIB CompressionType = 2
-	CompressionType_ZSTD CompressionType = 3
+	CompressionType_NONE   CompressionType = 0
+	CompressionType_LZ4    CompressionType = 1
+	CompressionType_ZLIB   CompressionType = 2
+	CompressionType_ZSTD   CompressionType = 3
+	CompressionType_SNAPPY CompressionType = 4
 )
 
 var CompressionType_name = map[int32]string{
@@ -32,12 +35,15 @@ var CompressionType_name = map[int32]string{
 	1: "LZ4",
 	2: "ZLIB",
 	3: "ZSTD",
+	4: "SNAPPY",
 }
+
 var CompressionType_value = map[string]int32{
-	"NONE": 0,
-	"LZ4":  1,
-	"ZLIB": 2,
-	"ZSTD": 3,
+	"NONE":   0,
+	"LZ4":    1,
+	"ZLIB":   2,
+	"ZSTD":   3,
+	"SNAPPY": 4,
 }
 
 func (x CompressionType) Enum() *CompressionType {
@@ -45,9 +51,11 @@ func (x CompressionType) Enum() *CompressionType {
 	*p = x
 	return p
 }
+
 func (x CompressionType) String() string {
 	return proto.EnumName(CompressionType_name, int32(x))
 }
+
 func (x *CompressionType) UnmarshalJSON(data []byte) error {
 	value, err := proto.UnmarshalJSONEnum(CompressionType_value, data, "CompressionType")
 	if err != nil {
@@ -56,8 +64,55 @@ func (x *CompressionType) UnmarshalJSON(data []byte) error {
 	*x = CompressionType(value)
 	return nil
 }
+
 func (CompressionType) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_PulsarApi_8cdc98e965f026ee, []int{0}
+	return fileDescriptor_39529ba7ad9caeb8, []int{0}
+}
+
+type ProducerAccessMode int32
+
+const (
+	ProducerAccessMode_Shared               ProducerAccessMode = 0
+	ProducerAccessMode_Exclusive            ProducerAccessMode = 1
+	ProducerAccessMode_WaitForExclusive     ProducerAccessMode = 2
+	ProducerAccessMode_ExclusiveWithFencing ProducerAccessMode = 3
+)
+
+var ProducerAccessMode_name = map[int32]string{
+	0: "Shared",
+	1: "Exclusive",
+	2: "WaitForExclusive",
+	3: "ExclusiveWithFencing",
+}
+
+var ProducerAccessMode_value = map[string]int32{
+	"Shared":               0,
+	"Exclusive":            1,
+	"WaitForExclusive":     2,
+	"ExclusiveWithFencing": 3,
+}
+
+func (x ProducerAccessMode) Enum() *ProducerAccessMode {
+	p := new(ProducerAccessMode)
+	*p = x
+	return p
+}
+
+func (x ProducerAccessMode) String() string {
+	return proto.EnumName(ProducerAccessMode_name, int32(x))
+}
+
+func (x *ProducerAccessMode) UnmarshalJSON(data []byte) error {
+	value, err := proto.UnmarshalJSONEnum(ProducerAccessMode_value, data, "ProducerAccessMode")
+	if err != nil {
+		return err
+	}
+	*x = ProducerAccessMode(value)
+	return nil
+}
+
+func (ProducerAccessMode) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_39529ba7ad9caeb8, []int{1}
 }
 
 type ServerError int32
@@ -83,6 +138,13 @@ const (
 	ServerError_ProducerBusy                          ServerError = 16
 	ServerError_InvalidTopicName                      ServerError = 17
 	ServerError_IncompatibleSchema                    ServerError = 18
+	ServerError_ConsumerAssignError                   ServerError = 19
+	ServerError_TransactionCoordinatorNotFound        ServerError = 20
+	ServerError_InvalidTxnStatus                      ServerError = 21
+	ServerError_NotAllowedError                       ServerError = 22
+	ServerError_TransactionConflict                   ServerError = 23
+	ServerError_TransactionNotFound                   ServerError = 24
+	ServerError_ProducerFenced                        ServerError = 25
 )
 
 var ServerError_name = map[int32]string{
@@ -105,7 +167,15 @@ var ServerError_name = map[int32]string{
 	16: "ProducerBusy",
 	17: "InvalidTopicName",
 	18: "IncompatibleSchema",
+	19: "ConsumerAssignError",
+	20: "TransactionCoordinatorNotFound",
+	21: "InvalidTxnStatus",
+	22: "NotAllowedError",
+	23: "TransactionConflict",
+	24: "TransactionNotFound",
+	25: "ProducerFenced",
 }
+
 var ServerError_value = map[string]int32{
 	"UnknownError":                          0,
 	"MetadataError":                         1,
@@ -126,6 +196,13 @@ var ServerError_value = map[string]int32{
 	"ProducerBusy":                          16,
 	"InvalidTopicName":                      17,
 	"IncompatibleSchema":                    18,
+	"ConsumerAssignError":                   19,
+	"TransactionCoordinatorNotFound":        20,
+	"InvalidTxnStatus":                      21,
+	"NotAllowedError":                       22,
+	"TransactionConflict":                   23,
+	"TransactionNotFound":                   24,
+	"ProducerFenced":                        25,
 }
 
 func (x ServerError) Enum() *ServerError {
@@ -133,9 +210,11 @@ func (x ServerError) Enum() *ServerError {
 	*p = x
 	return p
 }
+
 func (x ServerError) String() string {
 	return proto.EnumName(ServerError_name, int32(x))
 }
+
 func (x *ServerError) UnmarshalJSON(data []byte) error {
 	value, err := proto.UnmarshalJSONEnum(ServerError_value, data, "ServerError")
 	if err != nil {
@@ -144,8 +223,9 @@ func (x *ServerError) UnmarshalJSON(data []byte) error {
 	*x = ServerError(value)
 	return nil
 }
+
 func (ServerError) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_PulsarApi_8cdc98e965f026ee, []int{1}
+	return fileDescriptor_39529ba7ad9caeb8, []int{2}
 }
 
 type AuthMethod int32
@@ -161,6 +241,7 @@ var AuthMethod_name = map[int32]string{
 	1: "AuthMethodYcaV1",
 	2: "AuthMethodAthens",
 }
+
 var AuthMethod_value = map[string]int32{
 	"AuthMethodNone":   0,
 	"AuthMethodYcaV1":  1,
@@ -172,9 +253,11 @@ func (x AuthMethod) Enum() *AuthMethod {
 	*p = x
 	return p
 }
+
 func (x AuthMethod) String() string {
 	return proto.EnumName(AuthMethod_name, int32(x))
 }
+
 func (x *AuthMethod) UnmarshalJSON(data []byte) error {
 	value, err := proto.UnmarshalJSONEnum(AuthMethod_value, data, "AuthMethod")
 	if err != nil {
@@ -183,8 +266,9 @@ func (x *AuthMethod) UnmarshalJSON(data []byte) error {
 	*x = AuthMethod(value)
 	return nil
 }
+
 func (AuthMethod) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_PulsarApi_8cdc98e965f026ee, []int{2}
+	return fileDescriptor_39529ba7ad9caeb8, []int{3}
 }
 
 // Each protocol version identify new features that are
@@ -208,6 +292,14 @@ const (
 	// Added CommandActiveConsumerChange
 	// Added CommandGetTopicsOfNamespace
 	ProtocolVersion_v13 ProtocolVersion = 13
+	ProtocolVersion_v14 ProtocolVersion = 14
+	// Added Key_Shared subscription
+	ProtocolVersion_v15 ProtocolVersion = 15
+	ProtocolVersion_v16 ProtocolVersion = 16
+	ProtocolVersion_v17 ProtocolVersion = 17
+	ProtocolVersion_v18 ProtocolVersion = 18
+	ProtocolVersion_v19 ProtocolVersion = 19
+	ProtocolVersion_v20 ProtocolVersion = 20
 )
 
 var ProtocolVersion_name = map[int32]string{
@@ -225,7 +317,15 @@ var ProtocolVersion_name = map[int32]string{
 	11: "v11",
 	12: "v12",
 	13: "v13",
+	14: "v14",
+	15: "v15",
+	16: "v16",
+	17: "v17",
+	18: "v18",
+	19: "v19",
+	20: "v20",
 }
+
 var ProtocolVersion_value = map[string]int32{
 	"v0":  0,
 	"v1":  1,
@@ -241,6 +341,13 @@ var ProtocolVersion_value = map[string]int32{
 	"v11": 11,
 	"v12": 12,
 	"v13": 13,
+	"v14": 14,
+	"v15": 15,
+	"v16": 16,
+	"v17": 17,
+	"v18": 18,
+	"v19": 19,
+	"v20": 20,
 }
 
 func (x ProtocolVersion) Enum() *ProtocolVersion {
@@ -248,9 +355,11 @@ func (x ProtocolVersion) Enum() *ProtocolVersion {
 	*p = x
 	return p
 }
+
 func (x ProtocolVersion) String() string {
 	return proto.EnumName(ProtocolVersion_name, int32(x))
 }
+
 func (x *ProtocolVersion) UnmarshalJSON(data []byte) error {
 	value, err := proto.UnmarshalJSONEnum(ProtocolVersion_value, data, "ProtocolVersion")
 	if err != nil {
@@ -259,33 +368,163 @@ func (x *ProtocolVersion) UnmarshalJSON(data []byte) error {
 	*x = ProtocolVersion(value)
 	return nil
 }
+
 func (ProtocolVersion) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_PulsarApi_8cdc98e965f026ee, []int{3}
+	return fileDescriptor_39529ba7ad9caeb8, []int{4}
+}
+
+type KeySharedMode int32
+
+const (
+	KeySharedMode_AUTO_SPLIT KeySharedMode = 0
+	KeySharedMode_STICKY     KeySharedMode = 1
+)
+
+var KeySharedMode_name = map[int32]string{
+	0: "AUTO_SPLIT",
+	1: "STICKY",
+}
+
+var KeySharedMode_value = map[string]int32{
+	"AUTO_SPLIT": 0,
+	"STICKY":     1,
+}
+
+func (x KeySharedMode) Enum() *KeySharedMode {
+	p := new(KeySharedMode)
+	*p = x
+	return p
+}
+
+func (x KeySharedMode) String() string {
+	return proto.EnumName(KeySharedMode_name, int32(x))
+}
+
+func (x *KeySharedMode) UnmarshalJSON(data []byte) error {
+	value, err := proto.UnmarshalJSONEnum(KeySharedMode_value, data, "KeySharedMode")
+	if err != nil {
+		return err
+	}
+	*x = KeySharedMode(value)
+	return nil
+}
+
+func (KeySharedMode) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_39529ba7ad9caeb8, []int{5}
+}
+
+type TxnAction int32
+
+const (
+	TxnAction_COMMIT TxnAction = 0
+	TxnAction_ABORT  TxnAction = 1
+)
+
+var TxnAction_name = map[int32]string{
+	0: "COMMIT",
+	1: "ABORT",
+}
+
+var TxnAction_value = map[string]int32{
+	"COMMIT": 0,
+	"ABORT":  1,
+}
+
+func (x TxnAction) Enum() *TxnAction {
+	p := new(TxnAction)
+	*p = x
+	return p
+}
+
+func (x TxnAction) String() string {
+	return proto.EnumName(TxnAction_name, int32(x))
+}
+
+func (x *TxnAction) UnmarshalJSON(data []byte) error {
+	value, err := proto.UnmarshalJSONEnum(TxnAction_value, data, "TxnAction")
+	if err != nil {
+		return err
+	}
+	*x = TxnAction(value)
+	return nil
+}
+
+func (TxnAction) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_39529ba7ad9caeb8, []int{6}
 }
 
 type Schema_Type int32
 
 const (
-	Schema_None     Schema_Type = 0
-	Schema_String   Schema_Type = 1
-	Schema_Json     Schema_Type = 2
-	Schema_Protobuf Schema_Type = 3
-	Schema_Avro     Schema_Type = 4
+	Schema_None           Schema_Type = 0
+	Schema_String         Schema_Type = 1
+	Schema_Json           Schema_Type = 2
+	Schema_Protobuf       Schema_Type = 3
+	Schema_Avro           Schema_Type = 4
+	Schema_Bool           Schema_Type = 5
+	Schema_Int8           Schema_Type = 6
+	Schema_Int16          Schema_Type = 7
+	Schema_Int32          Schema_Type = 8
+	Schema_Int64          Schema_Type = 9
+	Schema_Float          Schema_Type = 10
+	Schema_Double         Schema_Type = 11
+	Schema_Date           Schema_Type = 12
+	Schema_Time           Schema_Type = 13
+	Schema_Timestamp      Schema_Type = 14
+	Schema_KeyValue       Schema_Type = 15
+	Schema_Instant        Schema_Type = 16
+	Schema_LocalDate      Schema_Type = 17
+	Schema_LocalTime      Schema_Type = 18
+	Schema_LocalDateTime  Schema_Type = 19
+	Schema_ProtobufNative Schema_Type = 20
 )
 
 var Schema_Type_name = map[int32]string{
-	0: "None",
-	1: "String",
-	2: "Json",
-	3: "Protobuf",
-	4: "Avro",
+	0:  "None",
+	1:  "String",
+	2:  "Json",
+	3:  "Protobuf",
+	4:  "Avro",
+	5:  "Bool",
+	6:  "Int8",
+	7:  "Int16",
+	8:  "Int32",
+	9:  "Int64",
+	10: "Float",
+	11: "Double",
+	12: "Date",
+	13: "Time",
+	14: "Timestamp",
+	15: "KeyValue",
+	16: "Instant",
+	17: "LocalDate",
+	18: "LocalTime",
+	19: "LocalDateTime",
+	20: "ProtobufNative",
 }
+
 var Schema_Type_value = map[string]int32{
-	"None":     0,
-	"String":   1,
-	"Json":     2,
-	"Protobuf": 3,
-	"Avro":     4,
+	"None":           0,
+	"String":         1,
+	"Json":           2,
+	"Protobuf":       3,
+	"Avro":           4,
+	"Bool":           5,
+	"Int8":           6,
+	"Int16":          7,
+	"Int32":          8,
+	"Int64":          9,
+	"Float":          10,
+	"Double":         11,
+	"Date":           12,
+	"Time":           13,
+	"Timestamp":      14,
+	"KeyValue":       15,
+	"Instant":        16,
+	"LocalDate":      17,
+	"LocalTime":      18,
+	"LocalDateTime":  19,
+	"ProtobufNative": 20,
 }
 
 func (x Schema_Type) Enum() *Schema_Type {
@@ -293,9 +532,11 @@ func (x Schema_Type) Enum() *Schema_Type {
 	*p = x
 	return p
 }
+
 func (x Schema_Type) String() string {
 	return proto.EnumName(Schema_Type_name, int32(x))
 }
+
 func (x *Schema_Type) UnmarshalJSON(data []byte) error {
 	value, err := proto.UnmarshalJSONEnum(Schema_Type_value, data, "Schema_Type")
 	if err != nil {
@@ -304,27 +545,32 @@ func (x *Schema_Type) UnmarshalJSON(data []byte) error {
 	*x = Schema_Type(value)
 	return nil
 }
+
 func (Schema_Type) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_PulsarApi_8cdc98e965f026ee, []int{0, 0}
+	return fileDescriptor_39529ba7ad9caeb8, []int{0, 0}
 }
 
 type CommandSubscribe_SubType int32
 
 const (
-	CommandSubscribe_Exclusive CommandSubscribe_SubType = 0
-	CommandSubscribe_Shared    CommandSubscribe_SubType = 1
-	CommandSubscribe_Failover  CommandSubscribe_SubType = 2
+	CommandSubscribe_Exclusive  CommandSubscribe_SubType = 0
+	CommandSubscribe_Shared     CommandSubscribe_SubType = 1
+	CommandSubscribe_Failover   CommandSubscribe_SubType = 2
+	CommandSubscribe_Key_Shared CommandSubscribe_SubType = 3
 )
 
 var CommandSubscribe_SubType_name = map[int32]string{
 	0: "Exclusive",
 	1: "Shared",
 	2: "Failover",
+	3: "Key_Shared",
 }
+
 var CommandSubscribe_SubType_value = map[string]int32{
-	"Exclusive": 0,
-	"Shared":    1,
-	"Failover":  2,
+	"Exclusive":  0,
+	"Shared":     1,
+	"Failover":   2,
+	"Key_Shared": 3,
 }
 
 func (x CommandSubscribe_SubType) Enum() *CommandSubscribe_SubType {
@@ -332,9 +578,11 @@ func (x CommandSubscribe_SubType) Enum() *CommandSubscribe_SubType {
 	*p = x
 	return p
 }
+
 func (x CommandSubscribe_SubType) String() string {
 	return proto.EnumName(CommandSubscribe_SubType_name, int32(x))
 }
+
 func (x *CommandSubscribe_SubType) UnmarshalJSON(data []byte) error {
 	value, err := proto.UnmarshalJSONEnum(CommandSubscribe_SubType_value, data, "CommandSubscribe_SubType")
 	if err != nil {
@@ -343,8 +591,9 @@ func (x *CommandSubscribe_SubType) UnmarshalJSON(data []byte) error {
 	*x = CommandSubscribe_SubType(value)
 	return nil
 }
+
 func (CommandSubscribe_SubType) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_PulsarApi_8cdc98e965f026ee, []int{9, 0}
+	return fileDescriptor_39529ba7ad9caeb8, []int{16, 0}
 }
 
 type CommandSubscribe_InitialPosition int32
@@ -358,6 +607,7 @@ var CommandSubscribe_InitialPosition_name = map[int32]string{
 	0: "Latest",
 	1: "Earliest",
 }
+
 var CommandSubscribe_InitialPosition_value = map[string]int32{
 	"Latest":   0,
 	"Earliest": 1,
@@ -368,9 +618,11 @@ func (x CommandSubscribe_InitialPosition) Enum() *CommandSubscribe_InitialPositi
 	*p = x
 	return p
 }
+
 func (x CommandSubscribe_InitialPosition) String() string {
 	return proto.EnumName(CommandSubscribe_InitialPosition_name, int32(x))
 }
+
 func (x *CommandSubscribe_InitialPosition) UnmarshalJSON(data []byte) error {
 	value, err := proto.UnmarshalJSONEnum(CommandSubscribe_InitialPosition_value, data, "CommandSubscribe_InitialPosition")
 	if err != nil {
@@ -379,8 +631,9 @@ func (x *CommandSubscribe_InitialPosition) UnmarshalJSON(data []byte) error {
 	*x = CommandSubscribe_InitialPosition(value)
 	return nil
 }
+
 func (CommandSubscribe_InitialPosition) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_PulsarApi_8cdc98e965f026ee, []int{9, 1}
+	return fileDescriptor_39529ba7ad9caeb8, []int{16, 1}
 }
 
 type CommandPartitionedTopicMetadataResponse_LookupType int32
@@ -394,6 +647,7 @@ var CommandPartitionedTopicMetadataResponse_LookupType_name = map[int32]string{
 	0: "Success",
 	1: "Failed",
 }
+
 var CommandPartitionedTopicMetadataResponse_LookupType_value = map[string]int32{
 	"Success": 0,
 	"Failed":  1,
@@ -404,9 +658,11 @@ func (x CommandPartitionedTopicMetadataResponse_LookupType) Enum() *CommandParti
 	*p = x
 	return p
 }
+
 func (x CommandPartitionedTopicMetadataResponse_LookupType) String() string {
 	return proto.EnumName(CommandPartitionedTopicMetadataResponse_LookupType_name, int32(x))
 }
+
 func (x *CommandPartitionedTopicMetadataResponse_LookupType) UnmarshalJSON(data []byte) error {
 	value, err := proto.UnmarshalJSONEnum(CommandPartitionedTopicMetadataResponse_LookupType_value, data, "CommandPartitionedTopicMetadataResponse_LookupType")
 	if err != nil {
@@ -415,8 +671,9 @@ func (x *CommandPartitionedTopicMetadataResponse_LookupType) UnmarshalJSON(data
 	*x = CommandPartitionedTopicMetadataResponse_LookupType(value)
 	return nil
 }
+
 func (CommandPartitionedTopicMetadataResponse_LookupType) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_PulsarApi_8cdc98e965f026ee, []int{11, 0}
+	return fileDescriptor_39529ba7ad9caeb8, []int{18, 0}
 }
 
 type CommandLookupTopicResponse_LookupType int32
@@ -432,6 +689,7 @@ var CommandLookupTopicResponse_LookupType_name = map[int32]string{
 	1: "Connect",
 	2: "Failed",
 }
+
 var CommandLookupTopicResponse_LookupType_value = map[string]int32{
 	"Redirect": 0,
 	"Connect":  1,
@@ -443,9 +701,11 @@ func (x CommandLookupTopicResponse_LookupType) Enum() *CommandLookupTopicRespons
 	*p = x
 	return p
 }
+
 func (x CommandLookupTopicResponse_LookupType) String() string {
 	return proto.EnumName(CommandLookupTopicResponse_LookupType_name, int32(x))
 }
+
 func (x *CommandLookupTopicResponse_LookupType) UnmarshalJSON(data []byte) error {
 	value, err := proto.UnmarshalJSONEnum(CommandLookupTopicResponse_LookupType_value, data, "CommandLookupTopicResponse_LookupType")
 	if err != nil {
@@ -454,8 +714,9 @@ func (x *CommandLookupTopicResponse_LookupType) UnmarshalJSON(data []byte) error
 	*x = CommandLookupTopicResponse_LookupType(value)
 	return nil
 }
+
 func (CommandLookupTopicResponse_LookupType) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_PulsarApi_8cdc98e965f026ee, []int{13, 0}
+	return fileDescriptor_39529ba7ad9caeb8, []int{20, 0}
 }
 
 type CommandAck_AckType int32
@@ -469,6 +730,7 @@ var CommandAck_AckType_name = map[int32]string{
 	0: "Individual",
 	1: "Cumulative",
 }
+
 var CommandAck_AckType_value = map[string]int32{
 	"Individual": 0,
 	"Cumulative": 1,
@@ -479,9 +741,11 @@ func (x CommandAck_AckType) Enum() *CommandAck_AckType {
 	*p = x
 	return p
 }
+
 func (x CommandAck_AckType) String() string {
 	return proto.EnumName(CommandAck_AckType_name, int32(x))
 }
+
 func (x *CommandAck_AckType) UnmarshalJSON(data []byte) error {
 	value, err := proto.UnmarshalJSONEnum(CommandAck_AckType_value, data, "CommandAck_AckType")
 	if err != nil {
@@ -490,8 +754,9 @@ func (x *CommandAck_AckType) UnmarshalJSON(data []byte) error {
 	*x = CommandAck_AckType(value)
 	return nil
 }
+
 func (CommandAck_AckType) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_PulsarApi_8cdc98e965f026ee, []int{19, 0}
+	return fileDescriptor_39529ba7ad9caeb8, []int{26, 0}
 }
 
 // Acks can contain a flag to indicate the consumer
@@ -514,6 +779,7 @@ var CommandAck_ValidationError_name = map[int32]string{
 	3: "BatchDeSerializeError",
 	4: "DecryptionError",
 }
+
 var CommandAck_ValidationError_value = map[string]int32{
 	"UncompressedSizeCorruption": 0,
 	"DecompressionError":         1,
@@ -527,9 +793,11 @@ func (x CommandAck_ValidationError) Enum() *CommandAck_ValidationError {
 	*p = x
 	return p
 }
+
 func (x CommandAck_ValidationError) String() string {
 	return proto.EnumName(CommandAck_ValidationError_name, int32(x))
 }
+
 func (x *CommandAck_ValidationError) UnmarshalJSON(data []byte) error {
 	value, err := proto.UnmarshalJSONEnum(CommandAck_ValidationError_value, data, "CommandAck_ValidationError")
 	if err != nil {
@@ -538,8 +806,49 @@ func (x *CommandAck_ValidationError) UnmarshalJSON(data []byte) error {
 	*x = CommandAck_ValidationError(value)
 	return nil
 }
+
 func (CommandAck_ValidationError) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_PulsarApi_8cdc98e965f026ee, []int{19, 1}
+	return fileDescriptor_39529ba7ad9caeb8, []int{26, 1}
+}
+
+type CommandTopicMigrated_ResourceType int32
+
+const (
+	CommandTopicMigrated_Producer CommandTopicMigrated_ResourceType = 0
+	CommandTopicMigrated_Consumer CommandTopicMigrated_ResourceType = 1
+)
+
+var CommandTopicMigrated_ResourceType_name = map[int32]string{
+	0: "Producer",
+	1: "Consumer",
+}
+
+var CommandTopicMigrated_ResourceType_value = map[string]int32{
+	"Producer": 0,
+	"Consumer": 1,
+}
+
+func (x CommandTopicMigrated_ResourceType) Enum() *CommandTopicMigrated_ResourceType {
+	p := new(CommandTopicMigrated_ResourceType)
+	*p = x
+	return p
+}
+
+func (x CommandTopicMigrated_ResourceType) String() string {
+	return proto.EnumName(CommandTopicMigrated_ResourceType_name, int32(x))
+}
+
+func (x *CommandTopicMigrated_ResourceType) UnmarshalJSON(data []byte) error {
+	value, err := proto.UnmarshalJSONEnum(CommandTopicMigrated_ResourceType_value, data, "CommandTopicMigrated_ResourceType")
+	if err != nil {
+		return err
+	}
+	*x = CommandTopicMigrated_ResourceType(value)
+	return nil
+}
+
+func (CommandTopicMigrated_ResourceType) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_39529ba7ad9caeb8, []int{33, 0}
 }
 
 type CommandGetTopicsOfNamespace_Mode int32
@@ -555,6 +864,7 @@ var CommandGetTopicsOfNamespace_Mode_name = map[int32]string{
 	1: "NON_PERSISTENT",
 	2: "ALL",
 }
+
 var CommandGetTopicsOfNamespace_Mode_value = map[string]int32{
 	"PERSISTENT":     0,
 	"NON_PERSISTENT": 1,
@@ -566,9 +876,11 @@ func (x CommandGetTopicsOfNamespace_Mode) Enum() *CommandGetTopicsOfNamespace_Mo
 	*p = x
 	return p
 }
+
 func (x CommandGetTopicsOfNamespace_Mode) String() string {
 	return proto.EnumName(CommandGetTopicsOfNamespace_Mode_name, int32(x))
 }
+
 func (x *CommandGetTopicsOfNamespace_Mode) UnmarshalJSON(data []byte) error {
 	value, err := proto.UnmarshalJSONEnum(CommandGetTopicsOfNamespace_Mode_value, data, "CommandGetTopicsOfNamespace_Mode")
 	if err != nil {
@@ -577,8 +889,9 @@ func (x *CommandGetTopicsOfNamespace_Mode) UnmarshalJSON(data []byte) error {
 	*x = CommandGetTopicsOfNamespace_Mode(value)
 	return nil
 }
+
 func (CommandGetTopicsOfNamespace_Mode) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_PulsarApi_8cdc98e965f026ee, []int{37, 0}
+	return fileDescriptor_39529ba7ad9caeb8, []int{46, 0}
 }
 
 type BaseCommand_Type int32
@@ -618,6 +931,31 @@ const (
 	BaseCommand_GET_TOPICS_OF_NAMESPACE_RESPONSE  BaseCommand_Type = 33
 	BaseCommand_GET_SCHEMA                        BaseCommand_Type = 34
 	BaseCommand_GET_SCHEMA_RESPONSE               BaseCommand_Type = 35
+	BaseCommand_AUTH_CHALLENGE                    BaseCommand_Type = 36
+	BaseCommand_AUTH_RESPONSE                     BaseCommand_Type = 37
+	BaseCommand_ACK_RESPONSE                      BaseCommand_Type = 38
+	BaseCommand_GET_OR_CREATE_SCHEMA              BaseCommand_Type = 39
+	BaseCommand_GET_OR_CREATE_SCHEMA_RESPONSE     BaseCommand_Type = 40
+	// transaction related
+	BaseCommand_NEW_TXN                          BaseCommand_Type = 50
+	BaseCommand_NEW_TXN_RESPONSE                 BaseCommand_Type = 51
+	BaseCommand_ADD_PARTITION_TO_TXN             BaseCommand_Type = 52
+	BaseCommand_ADD_PARTITION_TO_TXN_RESPONSE    BaseCommand_Type = 53
+	BaseCommand_ADD_SUBSCRIPTION_TO_TXN          BaseCommand_Type = 54
+	BaseCommand_ADD_SUBSCRIPTION_TO_TXN_RESPONSE BaseCommand_Type = 55
+	BaseCommand_END_TXN                          BaseCommand_Type = 56
+	BaseCommand_END_TXN_RESPONSE                 BaseCommand_Type = 57
+	BaseCommand_END_TXN_ON_PARTITION             BaseCommand_Type = 58
+	BaseCommand_END_TXN_ON_PARTITION_RESPONSE    BaseCommand_Type = 59
+	BaseCommand_END_TXN_ON_SUBSCRIPTION          BaseCommand_Type = 60
+	BaseCommand_END_TXN_ON_SUBSCRIPTION_RESPONSE BaseCommand_Type = 61
+	BaseCommand_TC_CLIENT_CONNECT_REQUEST        BaseCommand_Type = 62
+	BaseCommand_TC_CLIENT_CONNECT_RESPONSE       BaseCommand_Type = 63
+	BaseCommand_WATCH_TOPIC_LIST                 BaseCommand_Type = 64
+	BaseCommand_WATCH_TOPIC_LIST_SUCCESS         BaseCommand_Type = 65
+	BaseCommand_WATCH_TOPIC_UPDATE               BaseCommand_Type = 66
+	BaseCommand_WATCH_TOPIC_LIST_CLOSE           BaseCommand_Type = 67
+	BaseCommand_TOPIC_MIGRATED                   BaseCommand_Type = 68
 )
 
 var BaseCommand_Type_name = map[int32]string{
@@ -655,7 +993,32 @@ var BaseCommand_Type_name = map[int32]string{
 	33: "GET_TOPICS_OF_NAMESPACE_RESPONSE",
 	34: "GET_SCHEMA",
 	35: "GET_SCHEMA_RESPONSE",
+	36: "AUTH_CHALLENGE",
+	37: "AUTH_RESPONSE",
+	38: "ACK_RESPONSE",
+	39: "GET_OR_CREATE_SCHEMA",
+	40: "GET_OR_CREATE_SCHEMA_RESPONSE",
+	50: "NEW_TXN",
+	51: "NEW_TXN_RESPONSE",
+	52: "ADD_PARTITION_TO_TXN",
+	53: "ADD_PARTITION_TO_TXN_RESPONSE",
+	54: "ADD_SUBSCRIPTION_TO_TXN",
+	55: "ADD_SUBSCRIPTION_TO_TXN_RESPONSE",
+	56: "END_TXN",
+	57: "END_TXN_RESPONSE",
+	58: "END_TXN_ON_PARTITION",
+	59: "END_TXN_ON_PARTITION_RESPONSE",
+	60: "END_TXN_ON_SUBSCRIPTION",
+	61: "END_TXN_ON_SUBSCRIPTION_RESPONSE",
+	62: "TC_CLIENT_CONNECT_REQUEST",
+	63: "TC_CLIENT_CONNECT_RESPONSE",
+	64: "WATCH_TOPIC_LIST",
+	65: "WATCH_TOPIC_LIST_SUCCESS",
+	66: "WATCH_TOPIC_UPDATE",
+	67: "WATCH_TOPIC_LIST_CLOSE",
+	68: "TOPIC_MIGRATED",
 }
+
 var BaseCommand_Type_value = map[string]int32{
 	"CONNECT":                           2,
 	"CONNECTED":                         3,
@@ -691,6 +1054,30 @@ var BaseCommand_Type_value = map[string]int32{
 	"GET_TOPICS_OF_NAMESPACE_RESPONSE":  33,
 	"GET_SCHEMA":                        34,
 	"GET_SCHEMA_RESPONSE":               35,
+	"AUTH_CHALLENGE":                    36,
+	"AUTH_RESPONSE":                     37,
+	"ACK_RESPONSE":                      38,
+	"GET_OR_CREATE_SCHEMA":              39,
+	"GET_OR_CREATE_SCHEMA_RESPONSE":     40,
+	"NEW_TXN":                           50,
+	"NEW_TXN_RESPONSE":                  51,
+	"ADD_PARTITION_TO_TXN":              52,
+	"ADD_PARTITION_TO_TXN_RESPONSE":     53,
+	"ADD_SUBSCRIPTION_TO_TXN":           54,
+	"ADD_SUBSCRIPTION_TO_TXN_RESPONSE":  55,
+	"END_TXN":                           56,
+	"END_TXN_RESPONSE":                  57,
+	"END_TXN_ON_PARTITION":              58,
+	"END_TXN_ON_PARTITION_RESPONSE":     59,
+	"END_TXN_ON_SUBSCRIPTION":           60,
+	"END_TXN_ON_SUBSCRIPTION_RESPONSE":  61,
+	"TC_CLIENT_CONNECT_REQUEST":         62,
+	"TC_CLIENT_CONNECT_RESPONSE":        63,
+	"WATCH_TOPIC_LIST":                  64,
+	"WATCH_TOPIC_LIST_SUCCESS":          65,
+	"WATCH_TOPIC_UPDATE":                66,
+	"WATCH_TOPIC_LIST_CLOSE":            67,
+	"TOPIC_MIGRATED":                    68,
 }
 
 func (x BaseCommand_Type) Enum() *BaseCommand_Type {
@@ -698,9 +1085,11 @@ func (x BaseCommand_Type) Enum() *BaseCommand_Type {
 	*p = x
 	return p
 }
+
 func (x BaseCommand_Type) String() string {
 	return proto.EnumName(BaseCommand_Type_name, int32(x))
 }
+
 func (x *BaseCommand_Type) UnmarshalJSON(data []byte) error {
 	value, err := proto.UnmarshalJSONEnum(BaseCommand_Type_value, data, "BaseCommand_Type")
 	if err != nil {
@@ -709,8 +1098,9 @@ func (x *BaseCommand_Type) UnmarshalJSON(data []byte) error {
 	*x = BaseCommand_Type(value)
 	return nil
 }
+
 func (BaseCommand_Type) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_PulsarApi_8cdc98e965f026ee, []int{41, 0}
+	return fileDescriptor_39529ba7ad9caeb8, []int{71, 0}
 }
 
 type Schema struct {
@@ -727,16 +1117,17 @@ func (m *Schema) Reset()         { *m = Schema{} }
 func (m *Schema) String() string { return proto.CompactTextString(m) }
 func (*Schema) ProtoMessage()    {}
 func (*Schema) Descriptor() ([]byte, []int) {
-	return fileDescriptor_PulsarApi_8cdc98e965f026ee, []int{0}
+	return fileDescriptor_39529ba7ad9caeb8, []int{0}
 }
+
 func (m *Schema) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Schema.Unmarshal(m, b)
 }
 func (m *Schema) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	return xxx_messageInfo_Schema.Marshal(b, m, deterministic)
 }
-func (dst *Schema) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_Schema.Merge(dst, src)
+func (m *Schema) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Schema.Merge(m, src)
 }
 func (m *Schema) XXX_Size() int {
 	return xxx_messageInfo_Schema.Size(m)
@@ -776,29 +1167,34 @@ func (m *Schema) GetProperties() []*KeyValue {
 }
 
 type MessageIdData struct {
-	LedgerId             *uint64  `protobuf:"varint,1,req,name=ledgerId" json:"ledgerId,omitempty"`
-	EntryId              *uint64  `protobuf:"varint,2,req,name=entryId" json:"entryId,omitempty"`
-	Partition            *int32   `protobuf:"varint,3,opt,name=partition,def=-1" json:"partition,omitempty"`
-	BatchIndex           *int32   `protobuf:"varint,4,opt,name=batch_index,json=batchIndex,def=-1" json:"batch_index,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	LedgerId   *uint64 `protobuf:"varint,1,req,name=ledgerId" json:"ledgerId,omitempty"`
+	EntryId    *uint64 `protobuf:"varint,2,req,name=entryId" json:"entryId,omitempty"`
+	Partition  *int32  `protobuf:"varint,3,opt,name=partition,def=-1" json:"partition,omitempty"`
+	BatchIndex *int32  `protobuf:"varint,4,opt,name=batch_index,json=batchIndex,def=-1" json:"batch_index,omitempty"`
+	AckSet     []int64 `protobuf:"varint,5,rep,name=ack_set,json=ackSet" json:"ack_set,omitempty"`
+	BatchSize  *int32  `protobuf:"varint,6,opt,name=batch_size,json=batchSize" json:"batch_size,omitempty"`
+	// For the chunk message id, we need to specify the first chunk message id.
+	FirstChunkMessageId  *MessageIdData `protobuf:"bytes,7,opt,name=first_chunk_message_id,json=firstChunkMessageId" json:"first_chunk_message_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
 }
 
 func (m *MessageIdData) Reset()         { *m = MessageIdData{} }
 func (m *MessageIdData) String() string { return proto.CompactTextString(m) }
 func (*MessageIdData) ProtoMessage()    {}
 func (*MessageIdData) Descriptor() ([]byte, []int) {
-	return fileDescriptor_PulsarApi_8cdc98e965f026ee, []int{1}
+	return fileDescriptor_39529ba7ad9caeb8, []int{1}
 }
+
 func (m *MessageIdData) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_MessageIdData.Unmarshal(m, b)
 }
 func (m *MessageIdData) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	return xxx_messageInfo_MessageIdData.Marshal(b, m, deterministic)
 }
-func (dst *MessageIdData) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_MessageIdData.Merge(dst, src)
+func (m *MessageIdData) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MessageIdData.Merge(m, src)
 }
 func (m *MessageIdData) XXX_Size() int {
 	return xxx_messageInfo_MessageIdData.Size(m)
@@ -840,6 +1236,27 @@ func (m *MessageIdData) GetBatchIndex() int32 {
 	return Default_MessageIdData_BatchIndex
 }
 
+func (m *MessageIdData) GetAckSet() []int64 {
+	if m != nil {
+		return m.AckSet
+	}
+	return nil
+}
+
+func (m *MessageIdData) GetBatchSize() int32 {
+	if m != nil && m.BatchSize != nil {
+		return *m.BatchSize
+	}
+	return 0
+}
+
+func (m *MessageIdData) GetFirstChunkMessageId() *MessageIdData {
+	if m != nil {
+		return m.FirstChunkMessageId
+	}
+	return nil
+}
+
 type KeyValue struct {
 	Key                  *string  `protobuf:"bytes,1,req,name=key" json:"key,omitempty"`
 	Value                *string  `protobuf:"bytes,2,req,name=value" json:"value,omitempty"`
@@ -852,16 +1269,17 @@ func (m *KeyValue) Reset()         { *m = KeyValue{} }
 func (m *KeyValue) String() string { return proto.CompactTextString(m) }
 func (*KeyValue) ProtoMessage()    {}
 func (*KeyValue) Descriptor() ([]byte, []int) {
-	return fileDescriptor_PulsarApi_8cdc98e965f026ee, []int{2}
+	return fileDescriptor_39529ba7ad9caeb8, []int{2}
 }
+
 func (m *KeyValue) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_KeyValue.Unmarshal(m, b)
 }
 func (m *KeyValue) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	return xxx_messageInfo_KeyValue.Marshal(b, m, deterministic)
 }
-func (dst *KeyValue) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_KeyValue.Merge(dst, src)
+func (m *KeyValue) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_KeyValue.Merge(m, src)
 }
 func (m *KeyValue) XXX_Size() int {
 	return xxx_messageInfo_KeyValue.Size(m)
@@ -898,16 +1316,17 @@ func (m *KeyLongValue) Reset()         { *m = KeyLongValue{} }
 func (m *KeyLongValue) String() string { return proto.CompactTextString(m) }
 func (*KeyLongValue) ProtoMessage()    {}
 func (*KeyLongValue) Descriptor() ([]byte, []int) {
-	return fileDescriptor_PulsarApi_8cdc98e965f026ee, []int{3}
+	return fileDescriptor_39529ba7ad9caeb8, []int{3}
 }
+
 func (m *KeyLongValue) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_KeyLongValue.Unmarshal(m, b)
 }
 func (m *KeyLongValue) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	return xxx_messageInfo_KeyLongValue.Marshal(b, m, deterministic)
 }
-func (dst *KeyLongValue) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_KeyLongValue.Merge(dst, src)
+func (m *KeyLongValue) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_KeyLongValue.Merge(m, src)
 }
 func (m *KeyLongValue) XXX_Size() int {
 	return xxx_messageInfo_KeyLongValue.Size(m)
@@ -932,6 +1351,53 @@ func (m *KeyLongValue) GetValue() uint64 {
 	return 0
 }
 
+type IntRange struct {
+	Start                *int32   `protobuf:"varint,1,req,name=start" json:"start,omitempty"`
+	End                  *int32   `protobuf:"varint,2,req,name=end" json:"end,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *IntRange) Reset()         { *m = IntRange{} }
+func (m *IntRange) String() string { return proto.CompactTextString(m) }
+func (*IntRange) ProtoMessage()    {}
+func (*IntRange) Descriptor() ([]byte, []int) {
+	return fileDescriptor_39529ba7ad9caeb8, []int{4}
+}
+
+func (m *IntRange) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_IntRange.Unmarshal(m, b)
+}
+func (m *IntRange) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_IntRange.Marshal(b, m, deterministic)
+}
+func (m *IntRange) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_IntRange.Merge(m, src)
+}
+func (m *IntRange) XXX_Size() int {
+	return xxx_messageInfo_IntRange.Size(m)
+}
+func (m *IntRange) XXX_DiscardUnknown() {
+	xxx_messageInfo_IntRange.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_IntRange proto.InternalMessageInfo
+
+func (m *IntRange) GetStart() int32 {
+	if m != nil && m.Start != nil {
+		return *m.Start
+	}
+	return 0
+}
+
+func (m *IntRange) GetEnd() int32 {
+	if m != nil && m.End != nil {
+		return *m.End
+	}
+	return 0
+}
+
 type EncryptionKeys struct {
 	Key                  *string     `protobuf:"bytes,1,req,name=key" json:"key,omitempty"`
 	Value                []byte      `protobuf:"bytes,2,req,name=value" json:"value,omitempty"`
@@ -945,16 +1411,17 @@ func (m *EncryptionKeys) Reset()         { *m = EncryptionKeys{} }
 func (m *EncryptionKeys) String() string { return proto.CompactTextString(m) }
 func (*EncryptionKeys) ProtoMessage()    {}
 func (*EncryptionKeys) Descriptor() ([]byte, []int) {
-	return fileDescriptor_PulsarApi_8cdc98e965f026ee, []int{4}
+	return fileDescriptor_39529ba7ad9caeb8, []int{5}
 }
+
 func (m *EncryptionKeys) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_EncryptionKeys.Unmarshal(m, b)
 }
 func (m *EncryptionKeys) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	return xxx_messageInfo_EncryptionKeys.Marshal(b, m, deterministic)
 }
-func (dst *EncryptionKeys) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_EncryptionKeys.Merge(dst, src)
+func (m *EncryptionKeys) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EncryptionKeys.Merge(m, src)
 }
 func (m *EncryptionKeys) XXX_Size() int {
 	return xxx_messageInfo_EncryptionKeys.Size(m)
@@ -994,7 +1461,7 @@ type MessageMetadata struct {
 	// Property set on replicated message,
 	// includes the source cluster name
 	ReplicatedFrom *string `protobuf:"bytes,5,opt,name=replicated_from,json=replicatedFrom" json:"replicated_from,omitempty"`
-	// key to decide partition for the msg
+	//key to decide partition for the msg
 	PartitionKey *string `protobuf:"bytes,6,opt,name=partition_key,json=partitionKey" json:"partition_key,omitempty"`
 	// Override namespace's replication
 	ReplicateTo      []string         `protobuf:"bytes,7,rep,name=replicate_to,json=replicateTo" json:"replicate_to,omitempty"`
@@ -1002,7 +1469,7 @@ type MessageMetadata struct {
 	UncompressedSize *uint32          `protobuf:"varint,9,opt,name=uncompressed_size,json=uncompressedSize,def=0" json:"uncompressed_size,omitempty"`
 	// Removed below checksum field from Metadata as
 	// it should be part of send-command which keeps checksum of header + payload
-	// optional sfixed64 checksum = 10;
+	//optional sfixed64 checksum = 10;
 	// differentiate single and batch message metadata
 	NumMessagesInBatch *int32 `protobuf:"varint,11,opt,name=num_messages_in_batch,json=numMessagesInBatch,def=1" json:"num_messages_in_batch,omitempty"`
 	// the timestamp that this event occurs. it is typically set by applications.
@@ -1013,28 +1480,50 @@ type MessageMetadata struct {
 	// Algorithm used to encrypt data key
 	EncryptionAlgo *string `protobuf:"bytes,14,opt,name=encryption_algo,json=encryptionAlgo" json:"encryption_algo,omitempty"`
 	// Additional parameters required by encryption
-	EncryptionParam        []byte   `protobuf:"bytes,15,opt,name=encryption_param,json=encryptionParam" json:"encryption_param,omitempty"`
-	SchemaVersion          []byte   `protobuf:"bytes,16,opt,name=schema_version,json=schemaVersion" json:"schema_version,omitempty"`
-	PartitionKeyB64Encoded *bool    `protobuf:"varint,17,opt,name=partition_key_b64_encoded,json=partitionKeyB64Encoded,def=0" json:"partition_key_b64_encoded,omitempty"`
-	XXX_NoUnkeyedLiteral   struct{} `json:"-"`
-	XXX_unrecognized       []byte   `json:"-"`
-	XXX_sizecache          int32    `json:"-"`
+	EncryptionParam        []byte `protobuf:"bytes,15,opt,name=encryption_param,json=encryptionParam" json:"encryption_param,omitempty"`
+	SchemaVersion          []byte `protobuf:"bytes,16,opt,name=schema_version,json=schemaVersion" json:"schema_version,omitempty"`
+	PartitionKeyB64Encoded *bool  `protobuf:"varint,17,opt,name=partition_key_b64_encoded,json=partitionKeyB64Encoded,def=0" json:"partition_key_b64_encoded,omitempty"`
+	// Specific a key to overwrite the message key which used for ordering dispatch in Key_Shared mode.
+	OrderingKey []byte `protobuf:"bytes,18,opt,name=ordering_key,json=orderingKey" json:"ordering_key,omitempty"`
+	// Mark the message to be delivered at or after the specified timestamp
+	DeliverAtTime *int64 `protobuf:"varint,19,opt,name=deliver_at_time,json=deliverAtTime" json:"deliver_at_time,omitempty"`
+	// Identify whether a message is a "marker" message used for
+	// internal metadata instead of application published data.
+	// Markers will generally not be propagated back to clients
+	MarkerType *int32 `protobuf:"varint,20,opt,name=marker_type,json=markerType" json:"marker_type,omitempty"`
+	// transaction related message info
+	TxnidLeastBits *uint64 `protobuf:"varint,22,opt,name=txnid_least_bits,json=txnidLeastBits" json:"txnid_least_bits,omitempty"`
+	TxnidMostBits  *uint64 `protobuf:"varint,23,opt,name=txnid_most_bits,json=txnidMostBits" json:"txnid_most_bits,omitempty"`
+	/// Add highest sequence id to support batch message with external sequence id
+	HighestSequenceId *uint64 `protobuf:"varint,24,opt,name=highest_sequence_id,json=highestSequenceId,def=0" json:"highest_sequence_id,omitempty"`
+	// Indicate if the message payload value is set
+	NullValue         *bool   `protobuf:"varint,25,opt,name=null_value,json=nullValue,def=0" json:"null_value,omitempty"`
+	Uuid              *string `protobuf:"bytes,26,opt,name=uuid" json:"uuid,omitempty"`
+	NumChunksFromMsg  *int32  `protobuf:"varint,27,opt,name=num_chunks_from_msg,json=numChunksFromMsg" json:"num_chunks_from_msg,omitempty"`
+	TotalChunkMsgSize *int32  `protobuf:"varint,28,opt,name=total_chunk_msg_size,json=totalChunkMsgSize" json:"total_chunk_msg_size,omitempty"`
+	ChunkId           *int32  `protobuf:"varint,29,opt,name=chunk_id,json=chunkId" json:"chunk_id,omitempty"`
+	// Indicate if the message partition key is set
+	NullPartitionKey     *bool    `protobuf:"varint,30,opt,name=null_partition_key,json=nullPartitionKey,def=0" json:"null_partition_key,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *MessageMetadata) Reset()         { *m = MessageMetadata{} }
 func (m *MessageMetadata) String() string { return proto.CompactTextString(m) }
 func (*MessageMetadata) ProtoMessage()    {}
 func (*MessageMetadata) Descriptor() ([]byte, []int) {
-	return fileDescriptor_PulsarApi_8cdc98e965f026ee, []int{5}
+	return fileDescriptor_39529ba7ad9caeb8, []int{6}
 }
+
 func (m *MessageMetadata) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_MessageMetadata.Unmarshal(m, b)
 }
 func (m *MessageMetadata) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	return xxx_messageInfo_MessageMetadata.Marshal(b, m, deterministic)
 }
-func (dst *MessageMetadata) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_MessageMetadata.Merge(dst, src)
+func (m *MessageMetadata) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MessageMetadata.Merge(m, src)
 }
 func (m *MessageMetadata) XXX_Size() int {
 	return xxx_messageInfo_MessageMetadata.Size(m)
@@ -1050,6 +1539,9 @@ const Default_MessageMetadata_UncompressedSize uint32 = 0
 const Default_MessageMetadata_NumMessagesInBatch int32 = 1
 const Default_MessageMetadata_EventTime uint64 = 0
 const Default_MessageMetadata_PartitionKeyB64Encoded bool = false
+const Default_MessageMetadata_HighestSequenceId uint64 = 0
+const Default_MessageMetadata_NullValue bool = false
+const Default_MessageMetadata_NullPartitionKey bool = false
 
 func (m *MessageMetadata) GetProducerName() string {
 	if m != nil && m.ProducerName != nil {
@@ -1163,6 +1655,90 @@ func (m *MessageMetadata) GetPartitionKeyB64Encoded() bool {
 	return Default_MessageMetadata_PartitionKeyB64Encoded
 }
 
+func (m *MessageMetadata) GetOrderingKey() []byte {
+	if m != nil {
+		return m.OrderingKey
+	}
+	return nil
+}
+
+func (m *MessageMetadata) GetDeliverAtTime() int64 {
+	if m != nil && m.DeliverAtTime != nil {
+		return *m.DeliverAtTime
+	}
+	return 0
+}
+
+func (m *MessageMetadata) GetMarkerType() int32 {
+	if m != nil && m.MarkerType != nil {
+		return *m.MarkerType
+	}
+	return 0
+}
+
+func (m *MessageMetadata) GetTxnidLeastBits() uint64 {
+	if m != nil && m.TxnidLeastBits != nil {
+		return *m.TxnidLeastBits
+	}
+	return 0
+}
+
+func (m *MessageMetadata) GetTxnidMostBits() uint64 {
+	if m != nil && m.TxnidMostBits != nil {
+		return *m.TxnidMostBits
+	}
+	return 0
+}
+
+func (m *MessageMetadata) GetHighestSequenceId() uint64 {
+	if m != nil && m.HighestSequenceId != nil {
+		return *m.HighestSequenceId
+	}
+	return Default_MessageMetadata_HighestSequenceId
+}
+
+func (m *MessageMetadata) GetNullValue() bool {
+	if m != nil && m.NullValue != nil {
+		return *m.NullValue
+	}
+	return Default_MessageMetadata_NullValue
+}
+
+func (m *MessageMetadata) GetUuid() string {
+	if m != nil && m.Uuid != nil {
+		return *m.Uuid
+	}
+	return ""
+}
+
+func (m *MessageMetadata) GetNumChunksFromMsg() int32 {
+	if m != nil && m.NumChunksFromMsg != nil {
+		return *m.NumChunksFromMsg
+	}
+	return 0
+}
+
+func (m *MessageMetadata) GetTotalChunkMsgSize() int32 {
+	if m != nil && m.TotalChunkMsgSize != nil {
+		return *m.TotalChunkMsgSize
+	}
+	return 0
+}
+
+func (m *MessageMetadata) GetChunkId() int32 {
+	if m != nil && m.ChunkId != nil {
+		return *m.ChunkId
+	}
+	return 0
+}
+
+func (m *MessageMetadata) GetNullPartitionKey() bool {
+	if m != nil && m.NullPartitionKey != nil {
+		return *m.NullPartitionKey
+	}
+	return Default_MessageMetadata_NullPartitionKey
+}
+
 type SingleMessageMetadata struct {
 	Properties   []*KeyValue `protobuf:"bytes,1,rep,name=properties" json:"properties,omitempty"`
 	PartitionKey *string     `protobuf:"bytes,2,opt,name=partition_key,json=partitionKey" json:"partition_key,omitempty"`
@@ -1170,27 +1746,36 @@ type SingleMessageMetadata struct {
 	CompactedOut *bool       `protobuf:"varint,4,opt,name=compacted_out,json=compactedOut,def=0" json:"compacted_out,omitempty"`
 	// the timestamp that this event occurs. it is typically set by applications.
 	// if this field is omitted, `publish_time` can be used for the purpose of `event_time`.
-	EventTime              *uint64  `protobuf:"varint,5,opt,name=event_time,json=eventTime,def=0" json:"event_time,omitempty"`
-	PartitionKeyB64Encoded *bool    `protobuf:"varint,6,opt,name=partition_key_b64_encoded,json=partitionKeyB64Encoded,def=0" json:"partition_key_b64_encoded,omitempty"`
-	XXX_NoUnkeyedLiteral   struct{} `json:"-"`
-	XXX_unrecognized       []byte   `json:"-"`
-	XXX_sizecache          int32    `json:"-"`
+	EventTime              *uint64 `protobuf:"varint,5,opt,name=event_time,json=eventTime,def=0" json:"event_time,omitempty"`
+	PartitionKeyB64Encoded *bool   `protobuf:"varint,6,opt,name=partition_key_b64_encoded,json=partitionKeyB64Encoded,def=0" json:"partition_key_b64_encoded,omitempty"`
+	// Specific a key to overwrite the message key which used for ordering dispatch in Key_Shared mode.
+	OrderingKey []byte `protobuf:"bytes,7,opt,name=ordering_key,json=orderingKey" json:"ordering_key,omitempty"`
+	// Allows consumer retrieve the sequence id that the producer set.
+	SequenceId *uint64 `protobuf:"varint,8,opt,name=sequence_id,json=sequenceId" json:"sequence_id,omitempty"`
+	// Indicate if the message payload value is set
+	NullValue *bool `protobuf:"varint,9,opt,name=null_value,json=nullValue,def=0" json:"null_value,omitempty"`
+	// Indicate if the message partition key is set
+	NullPartitionKey     *bool    `protobuf:"varint,10,opt,name=null_partition_key,json=nullPartitionKey,def=0" json:"null_partition_key,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *SingleMessageMetadata) Reset()         { *m = SingleMessageMetadata{} }
 func (m *SingleMessageMetadata) String() string { return proto.CompactTextString(m) }
 func (*SingleMessageMetadata) ProtoMessage()    {}
 func (*SingleMessageMetadata) Descriptor() ([]byte, []int) {
-	return fileDescriptor_PulsarApi_8cdc98e965f026ee, []int{6}
+	return fileDescriptor_39529ba7ad9caeb8, []int{7}
 }
+
 func (m *SingleMessageMetadata) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_SingleMessageMetadata.Unmarshal(m, b)
 }
 func (m *SingleMessageMetadata) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	return xxx_messageInfo_SingleMessageMetadata.Marshal(b, m, deterministic)
 }
-func (dst *SingleMessageMetadata) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_SingleMessageMetadata.Merge(dst, src)
+func (m *SingleMessageMetadata) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SingleMessageMetadata.Merge(m, src)
 }
 func (m *SingleMessageMetadata) XXX_Size() int {
 	return xxx_messageInfo_SingleMessageMetadata.Size(m)
@@ -1204,6 +1789,8 @@ var xxx_messageInfo_SingleMessageMetadata proto.InternalMessageInfo
 const Default_SingleMessageMetadata_CompactedOut bool = false
 const Default_SingleMessageMetadata_EventTime uint64 = 0
 const Default_SingleMessageMetadata_PartitionKeyB64Encoded bool = false
+const Default_SingleMessageMetadata_NullValue bool = false
+const Default_SingleMessageMetadata_NullPartitionKey bool = false
 
 func (m *SingleMessageMetadata) GetProperties() []*KeyValue {
 	if m != nil {
@@ -1247,14 +1834,90 @@ func (m *SingleMessageMetadata) GetPartitionKeyB64Encoded() bool {
 	return Default_SingleMessageMetadata_PartitionKeyB64Encoded
 }
 
-type CommandConnect struct {
-	ClientVersion   *string     `protobuf:"bytes,1,req,name=client_version,json=clientVersion" json:"client_version,omitempty"`
-	AuthMethod      *AuthMethod `protobuf:"varint,2,opt,name=auth_method,json=authMethod,enum=pulsar.proto.AuthMethod" json:"auth_method,omitempty"`
-	AuthMethodName  *string     `protobuf:"bytes,5,opt,name=auth_method_name,json=authMethodName" json:"auth_method_name,omitempty"`
-	AuthData        []byte      `protobuf:"bytes,3,opt,name=auth_data,json=authData" json:"auth_data,omitempty"`
-	ProtocolVersion *int32      `protobuf:"varint,4,opt,name=protocol_version,json=protocolVersion,def=0" json:"protocol_version,omitempty"`
-	// Client can ask to be proxyied to a specific broker
-	// This is only honored by a Pulsar proxy
+func (m *SingleMessageMetadata) GetOrderingKey() []byte {
+	if m != nil {
+		return m.OrderingKey
+	}
+	return nil
+}
+
+func (m *SingleMessageMetadata) GetSequenceId() uint64 {
+	if m != nil && m.SequenceId != nil {
+		return *m.SequenceId
+	}
+	return 0
+}
+
+func (m *SingleMessageMetadata) GetNullValue() bool {
+	if m != nil && m.NullValue != nil {
+		return *m.NullValue
+	}
+	return Default_SingleMessageMetadata_NullValue
+}
+
+func (m *SingleMessageMetadata) GetNullPartitionKey() bool {
+	if m != nil && m.NullPartitionKey != nil {
+		return *m.NullPartitionKey
+	}
+	return Default_SingleMessageMetadata_NullPartitionKey
+}
+
+// metadata added for entry from broker
+type BrokerEntryMetadata struct {
+	BrokerTimestamp      *uint64  `protobuf:"varint,1,opt,name=broker_timestamp,json=brokerTimestamp" json:"broker_timestamp,omitempty"`
+	Index                *uint64  `protobuf:"varint,2,opt,name=index" json:"index,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *BrokerEntryMetadata) Reset()         { *m = BrokerEntryMetadata{} }
+func (m *BrokerEntryMetadata) String() string { return proto.CompactTextString(m) }
+func (*BrokerEntryMetadata) ProtoMessage()    {}
+func (*BrokerEntryMetadata) Descriptor() ([]byte, []int) {
+	return fileDescriptor_39529ba7ad9caeb8, []int{8}
+}
+
+func (m *BrokerEntryMetadata) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BrokerEntryMetadata.Unmarshal(m, b)
+}
+func (m *BrokerEntryMetadata) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BrokerEntryMetadata.Marshal(b, m, deterministic)
+}
+func (m *BrokerEntryMetadata) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BrokerEntryMetadata.Merge(m, src)
+}
+func (m *BrokerEntryMetadata) XXX_Size() int {
+	return xxx_messageInfo_BrokerEntryMetadata.Size(m)
+}
+func (m *BrokerEntryMetadata) XXX_DiscardUnknown() {
+	xxx_messageInfo_BrokerEntryMetadata.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BrokerEntryMetadata proto.InternalMessageInfo
+
+func (m *BrokerEntryMetadata) GetBrokerTimestamp() uint64 {
+	if m != nil && m.BrokerTimestamp != nil {
+		return *m.BrokerTimestamp
+	}
+	return 0
+}
+
+func (m *BrokerEntryMetadata) GetIndex() uint64 {
+	if m != nil && m.Index != nil {
+		return *m.Index
+	}
+	return 0
+}
+
+type CommandConnect struct {
+	ClientVersion   *string     `protobuf:"bytes,1,req,name=client_version,json=clientVersion" json:"client_version,omitempty"`
+	AuthMethod      *AuthMethod `protobuf:"varint,2,opt,name=auth_method,json=authMethod,enum=pulsar.proto.AuthMethod" json:"auth_method,omitempty"`
+	AuthMethodName  *string     `protobuf:"bytes,5,opt,name=auth_method_name,json=authMethodName" json:"auth_method_name,omitempty"`
+	AuthData        []byte      `protobuf:"bytes,3,opt,name=auth_data,json=authData" json:"auth_data,omitempty"`
+	ProtocolVersion *int32      `protobuf:"varint,4,opt,name=protocol_version,json=protocolVersion,def=0" json:"protocol_version,omitempty"`
+	// Client can ask to be proxyied to a specific broker
+	// This is only honored by a Pulsar proxy
 	ProxyToBrokerUrl *string `protobuf:"bytes,6,opt,name=proxy_to_broker_url,json=proxyToBrokerUrl" json:"proxy_to_broker_url,omitempty"`
 	// Original principal that was verified by
 	// a Pulsar proxy. In this case the auth info above
@@ -1263,27 +1926,30 @@ type CommandConnect struct {
 	// Original auth role and auth Method that was passed
 	// to the proxy. In this case the auth info above
 	// will be the auth of the proxy itself
-	OriginalAuthData     *string  `protobuf:"bytes,8,opt,name=original_auth_data,json=originalAuthData" json:"original_auth_data,omitempty"`
-	OriginalAuthMethod   *string  `protobuf:"bytes,9,opt,name=original_auth_method,json=originalAuthMethod" json:"original_auth_method,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	OriginalAuthData   *string `protobuf:"bytes,8,opt,name=original_auth_data,json=originalAuthData" json:"original_auth_data,omitempty"`
+	OriginalAuthMethod *string `protobuf:"bytes,9,opt,name=original_auth_method,json=originalAuthMethod" json:"original_auth_method,omitempty"`
+	// Feature flags
+	FeatureFlags         *FeatureFlags `protobuf:"bytes,10,opt,name=feature_flags,json=featureFlags" json:"feature_flags,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
 }
 
 func (m *CommandConnect) Reset()         { *m = CommandConnect{} }
 func (m *CommandConnect) String() string { return proto.CompactTextString(m) }
 func (*CommandConnect) ProtoMessage()    {}
 func (*CommandConnect) Descriptor() ([]byte, []int) {
-	return fileDescriptor_PulsarApi_8cdc98e965f026ee, []int{7}
+	return fileDescriptor_39529ba7ad9caeb8, []int{9}
 }
+
 func (m *CommandConnect) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_CommandConnect.Unmarshal(m, b)
 }
 func (m *CommandConnect) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	return xxx_messageInfo_CommandConnect.Marshal(b, m, deterministic)
 }
-func (dst *CommandConnect) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_CommandConnect.Merge(dst, src)
+func (m *CommandConnect) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CommandConnect.Merge(m, src)
 }
 func (m *CommandConnect) XXX_Size() int {
 	return xxx_messageInfo_CommandConnect.Size(m)
@@ -1359,28 +2025,106 @@ func (m *CommandConnect) GetOriginalAuthMethod() string {
 	return ""
 }
 
+func (m *CommandConnect) GetFeatureFlags() *FeatureFlags {
+	if m != nil {
+		return m.FeatureFlags
+	}
+	return nil
+}
+
+type FeatureFlags struct {
+	SupportsAuthRefresh         *bool    `protobuf:"varint,1,opt,name=supports_auth_refresh,json=supportsAuthRefresh,def=0" json:"supports_auth_refresh,omitempty"`
+	SupportsBrokerEntryMetadata *bool    `protobuf:"varint,2,opt,name=supports_broker_entry_metadata,json=supportsBrokerEntryMetadata,def=0" json:"supports_broker_entry_metadata,omitempty"`
+	SupportsPartialProducer     *bool    `protobuf:"varint,3,opt,name=supports_partial_producer,json=supportsPartialProducer,def=0" json:"supports_partial_producer,omitempty"`
+	SupportsTopicWatchers       *bool    `protobuf:"varint,4,opt,name=supports_topic_watchers,json=supportsTopicWatchers,def=0" json:"supports_topic_watchers,omitempty"`
+	XXX_NoUnkeyedLiteral        struct{} `json:"-"`
+	XXX_unrecognized            []byte   `json:"-"`
+	XXX_sizecache               int32    `json:"-"`
+}
+
+func (m *FeatureFlags) Reset()         { *m = FeatureFlags{} }
+func (m *FeatureFlags) String() string { return proto.CompactTextString(m) }
+func (*FeatureFlags) ProtoMessage()    {}
+func (*FeatureFlags) Descriptor() ([]byte, []int) {
+	return fileDescriptor_39529ba7ad9caeb8, []int{10}
+}
+
+func (m *FeatureFlags) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FeatureFlags.Unmarshal(m, b)
+}
+func (m *FeatureFlags) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FeatureFlags.Marshal(b, m, deterministic)
+}
+func (m *FeatureFlags) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FeatureFlags.Merge(m, src)
+}
+func (m *FeatureFlags) XXX_Size() int {
+	return xxx_messageInfo_FeatureFlags.Size(m)
+}
+func (m *FeatureFlags) XXX_DiscardUnknown() {
+	xxx_messageInfo_FeatureFlags.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FeatureFlags proto.InternalMessageInfo
+
+const Default_FeatureFlags_SupportsAuthRefresh bool = false
+const Default_FeatureFlags_SupportsBrokerEntryMetadata bool = false
+const Default_FeatureFlags_SupportsPartialProducer bool = false
+const Default_FeatureFlags_SupportsTopicWatchers bool = false
+
+func (m *FeatureFlags) GetSupportsAuthRefresh() bool {
+	if m != nil && m.SupportsAuthRefresh != nil {
+		return *m.SupportsAuthRefresh
+	}
+	return Default_FeatureFlags_SupportsAuthRefresh
+}
+
+func (m *FeatureFlags) GetSupportsBrokerEntryMetadata() bool {
+	if m != nil && m.SupportsBrokerEntryMetadata != nil {
+		return *m.SupportsBrokerEntryMetadata
+	}
+	return Default_FeatureFlags_SupportsBrokerEntryMetadata
+}
+
+func (m *FeatureFlags) GetSupportsPartialProducer() bool {
+	if m != nil && m.SupportsPartialProducer != nil {
+		return *m.SupportsPartialProducer
+	}
+	return Default_FeatureFlags_SupportsPartialProducer
+}
+
+func (m *FeatureFlags) GetSupportsTopicWatchers() bool {
+	if m != nil && m.SupportsTopicWatchers != nil {
+		return *m.SupportsTopicWatchers
+	}
+	return Default_FeatureFlags_SupportsTopicWatchers
+}
+
 type CommandConnected struct {
-	ServerVersion        *string  `protobuf:"bytes,1,req,name=server_version,json=serverVersion" json:"server_version,omitempty"`
-	ProtocolVersion      *int32   `protobuf:"varint,2,opt,name=protocol_version,json=protocolVersion,def=0" json:"protocol_version,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	ServerVersion        *string       `protobuf:"bytes,1,req,name=server_version,json=serverVersion" json:"server_version,omitempty"`
+	ProtocolVersion      *int32        `protobuf:"varint,2,opt,name=protocol_version,json=protocolVersion,def=0" json:"protocol_version,omitempty"`
+	MaxMessageSize       *int32        `protobuf:"varint,3,opt,name=max_message_size,json=maxMessageSize" json:"max_message_size,omitempty"`
+	FeatureFlags         *FeatureFlags `protobuf:"bytes,4,opt,name=feature_flags,json=featureFlags" json:"feature_flags,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
 }
 
 func (m *CommandConnected) Reset()         { *m = CommandConnected{} }
 func (m *CommandConnected) String() string { return proto.CompactTextString(m) }
 func (*CommandConnected) ProtoMessage()    {}
 func (*CommandConnected) Descriptor() ([]byte, []int) {
-	return fileDescriptor_PulsarApi_8cdc98e965f026ee, []int{8}
+	return fileDescriptor_39529ba7ad9caeb8, []int{11}
 }
+
 func (m *CommandConnected) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_CommandConnected.Unmarshal(m, b)
 }
 func (m *CommandConnected) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	return xxx_messageInfo_CommandConnected.Marshal(b, m, deterministic)
 }
-func (dst *CommandConnected) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_CommandConnected.Merge(dst, src)
+func (m *CommandConnected) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CommandConnected.Merge(m, src)
 }
 func (m *CommandConnected) XXX_Size() int {
 	return xxx_messageInfo_CommandConnected.Size(m)
@@ -1407,6 +2151,239 @@ func (m *CommandConnected) GetProtocolVersion() int32 {
 	return Default_CommandConnected_ProtocolVersion
 }
 
+func (m *CommandConnected) GetMaxMessageSize() int32 {
+	if m != nil && m.MaxMessageSize != nil {
+		return *m.MaxMessageSize
+	}
+	return 0
+}
+
+func (m *CommandConnected) GetFeatureFlags() *FeatureFlags {
+	if m != nil {
+		return m.FeatureFlags
+	}
+	return nil
+}
+
+type CommandAuthResponse struct {
+	ClientVersion        *string   `protobuf:"bytes,1,opt,name=client_version,json=clientVersion" json:"client_version,omitempty"`
+	Response             *AuthData `protobuf:"bytes,2,opt,name=response" json:"response,omitempty"`
+	ProtocolVersion      *int32    `protobuf:"varint,3,opt,name=protocol_version,json=protocolVersion,def=0" json:"protocol_version,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
+}
+
+func (m *CommandAuthResponse) Reset()         { *m = CommandAuthResponse{} }
+func (m *CommandAuthResponse) String() string { return proto.CompactTextString(m) }
+func (*CommandAuthResponse) ProtoMessage()    {}
+func (*CommandAuthResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_39529ba7ad9caeb8, []int{12}
+}
+
+func (m *CommandAuthResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CommandAuthResponse.Unmarshal(m, b)
+}
+func (m *CommandAuthResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CommandAuthResponse.Marshal(b, m, deterministic)
+}
+func (m *CommandAuthResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CommandAuthResponse.Merge(m, src)
+}
+func (m *CommandAuthResponse) XXX_Size() int {
+	return xxx_messageInfo_CommandAuthResponse.Size(m)
+}
+func (m *CommandAuthResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_CommandAuthResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CommandAuthResponse proto.InternalMessageInfo
+
+const Default_CommandAuthResponse_ProtocolVersion int32 = 0
+
+func (m *CommandAuthResponse) GetClientVersion() string {
+	if m != nil && m.ClientVersion != nil {
+		return *m.ClientVersion
+	}
+	return ""
+}
+
+func (m *CommandAuthResponse) GetResponse() *AuthData {
+	if m != nil {
+		return m.Response
+	}
+	return nil
+}
+
+func (m *CommandAuthResponse) GetProtocolVersion() int32 {
+	if m != nil && m.ProtocolVersion != nil {
+		return *m.ProtocolVersion
+	}
+	return Default_CommandAuthResponse_ProtocolVersion
+}
+
+type CommandAuthChallenge struct {
+	ServerVersion        *string   `protobuf:"bytes,1,opt,name=server_version,json=serverVersion" json:"server_version,omitempty"`
+	Challenge            *AuthData `protobuf:"bytes,2,opt,name=challenge" json:"challenge,omitempty"`
+	ProtocolVersion      *int32    `protobuf:"varint,3,opt,name=protocol_version,json=protocolVersion,def=0" json:"protocol_version,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
+}
+
+func (m *CommandAuthChallenge) Reset()         { *m = CommandAuthChallenge{} }
+func (m *CommandAuthChallenge) String() string { return proto.CompactTextString(m) }
+func (*CommandAuthChallenge) ProtoMessage()    {}
+func (*CommandAuthChallenge) Descriptor() ([]byte, []int) {
+	return fileDescriptor_39529ba7ad9caeb8, []int{13}
+}
+
+func (m *CommandAuthChallenge) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CommandAuthChallenge.Unmarshal(m, b)
+}
+func (m *CommandAuthChallenge) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CommandAuthChallenge.Marshal(b, m, deterministic)
+}
+func (m *CommandAuthChallenge) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CommandAuthChallenge.Merge(m, src)
+}
+func (m *CommandAuthChallenge) XXX_Size() int {
+	return xxx_messageInfo_CommandAuthChallenge.Size(m)
+}
+func (m *CommandAuthChallenge) XXX_DiscardUnknown() {
+	xxx_messageInfo_CommandAuthChallenge.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CommandAuthChallenge proto.InternalMessageInfo
+
+const Default_CommandAuthChallenge_ProtocolVersion int32 = 0
+
+func (m *CommandAuthChallenge) GetServerVersion() string {
+	if m != nil && m.ServerVersion != nil {
+		return *m.ServerVersion
+	}
+	return ""
+}
+
+func (m *CommandAuthChallenge) GetChallenge() *AuthData {
+	if m != nil {
+		return m.Challenge
+	}
+	return nil
+}
+
+func (m *CommandAuthChallenge) GetProtocolVersion() int32 {
+	if m != nil && m.ProtocolVersion != nil {
+		return *m.ProtocolVersion
+	}
+	return Default_CommandAuthChallenge_ProtocolVersion
+}
+
+// To support mutual authentication type, such as Sasl, reuse this command to mutual auth.
+type AuthData struct {
+	AuthMethodName       *string  `protobuf:"bytes,1,opt,name=auth_method_name,json=authMethodName" json:"auth_method_name,omitempty"`
+	AuthData             []byte   `protobuf:"bytes,2,opt,name=auth_data,json=authData" json:"auth_data,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *AuthData) Reset()         { *m = AuthData{} }
+func (m *AuthData) String() string { return proto.CompactTextString(m) }
+func (*AuthData) ProtoMessage()    {}
+func (*AuthData) Descriptor() ([]byte, []int) {
+	return fileDescriptor_39529ba7ad9caeb8, []int{14}
+}
+
+func (m *AuthData) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AuthData.Unmarshal(m, b)
+}
+func (m *AuthData) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AuthData.Marshal(b, m, deterministic)
+}
+func (m *AuthData) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AuthData.Merge(m, src)
+}
+func (m *AuthData) XXX_Size() int {
+	return xxx_messageInfo_AuthData.Size(m)
+}
+func (m *AuthData) XXX_DiscardUnknown() {
+	xxx_messageInfo_AuthData.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AuthData proto.InternalMessageInfo
+
+func (m *AuthData) GetAuthMethodName() string {
+	if m != nil && m.AuthMethodName != nil {
+		return *m.AuthMethodName
+	}
+	return ""
+}
+
+func (m *AuthData) GetAuthData() []byte {
+	if m != nil {
+		return m.AuthData
+	}
+	return nil
+}
+
+type KeySharedMeta struct {
+	KeySharedMode           *KeySharedMode `protobuf:"varint,1,req,name=keySharedMode,enum=pulsar.proto.KeySharedMode" json:"keySharedMode,omitempty"`
+	HashRanges              []*IntRange    `protobuf:"bytes,3,rep,name=hashRanges" json:"hashRanges,omitempty"`
+	AllowOutOfOrderDelivery *bool          `protobuf:"varint,4,opt,name=allowOutOfOrderDelivery,def=0" json:"allowOutOfOrderDelivery,omitempty"`
+	XXX_NoUnkeyedLiteral    struct{}       `json:"-"`
+	XXX_unrecognized        []byte         `json:"-"`
+	XXX_sizecache           int32          `json:"-"`
+}
+
+func (m *KeySharedMeta) Reset()         { *m = KeySharedMeta{} }
+func (m *KeySharedMeta) String() string { return proto.CompactTextString(m) }
+func (*KeySharedMeta) ProtoMessage()    {}
+func (*KeySharedMeta) Descriptor() ([]byte, []int) {
+	return fileDescriptor_39529ba7ad9caeb8, []int{15}
+}
+
+func (m *KeySharedMeta) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_KeySharedMeta.Unmarshal(m, b)
+}
+func (m *KeySharedMeta) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_KeySharedMeta.Marshal(b, m, deterministic)
+}
+func (m *KeySharedMeta) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_KeySharedMeta.Merge(m, src)
+}
+func (m *KeySharedMeta) XXX_Size() int {
+	return xxx_messageInfo_KeySharedMeta.Size(m)
+}
+func (m *KeySharedMeta) XXX_DiscardUnknown() {
+	xxx_messageInfo_KeySharedMeta.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_KeySharedMeta proto.InternalMessageInfo
+
+const Default_KeySharedMeta_AllowOutOfOrderDelivery bool = false
+
+func (m *KeySharedMeta) GetKeySharedMode() KeySharedMode {
+	if m != nil && m.KeySharedMode != nil {
+		return *m.KeySharedMode
+	}
+	return KeySharedMode_AUTO_SPLIT
+}
+
+func (m *KeySharedMeta) GetHashRanges() []*IntRange {
+	if m != nil {
+		return m.HashRanges
+	}
+	return nil
+}
+
+func (m *KeySharedMeta) GetAllowOutOfOrderDelivery() bool {
+	if m != nil && m.AllowOutOfOrderDelivery != nil {
+		return *m.AllowOutOfOrderDelivery
+	}
+	return Default_KeySharedMeta_AllowOutOfOrderDelivery
+}
+
 type CommandSubscribe struct {
 	Topic         *string                   `protobuf:"bytes,1,req,name=topic" json:"topic,omitempty"`
 	Subscription  *string                   `protobuf:"bytes,2,req,name=subscription" json:"subscription,omitempty"`
@@ -1422,32 +2399,50 @@ type CommandSubscribe struct {
 	// markd-delete position  on the particular message id and
 	// will send messages from that point
 	StartMessageId *MessageIdData `protobuf:"bytes,9,opt,name=start_message_id,json=startMessageId" json:"start_message_id,omitempty"`
-	// / Add optional metadata key=value to this consumer
+	/// Add optional metadata key=value to this consumer
 	Metadata      []*KeyValue `protobuf:"bytes,10,rep,name=metadata" json:"metadata,omitempty"`
 	ReadCompacted *bool       `protobuf:"varint,11,opt,name=read_compacted,json=readCompacted" json:"read_compacted,omitempty"`
 	Schema        *Schema     `protobuf:"bytes,12,opt,name=schema" json:"schema,omitempty"`
-	// Signal wthether the subscription will initialize on latest
+	// Signal whether the subscription will initialize on latest
 	// or not -- earliest
-	InitialPosition      *CommandSubscribe_InitialPosition `protobuf:"varint,13,opt,name=initialPosition,enum=pulsar.proto.CommandSubscribe_InitialPosition,def=0" json:"initialPosition,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}                          `json:"-"`
-	XXX_unrecognized     []byte                            `json:"-"`
-	XXX_sizecache        int32                             `json:"-"`
+	InitialPosition *CommandSubscribe_InitialPosition `protobuf:"varint,13,opt,name=initialPosition,enum=pulsar.proto.CommandSubscribe_InitialPosition,def=0" json:"initialPosition,omitempty"`
+	// Mark the subscription as "replicated". Pulsar will make sure
+	// to periodically sync the state of replicated subscriptions
+	// across different clusters (when using geo-replication).
+	ReplicateSubscriptionState *bool `protobuf:"varint,14,opt,name=replicate_subscription_state,json=replicateSubscriptionState" json:"replicate_subscription_state,omitempty"`
+	// If true, the subscribe operation will cause a topic to be
+	// created if it does not exist already (and if topic auto-creation
+	// is allowed by broker.
+	// If false, the subscribe operation will fail if the topic
+	// does not exist.
+	ForceTopicCreation *bool `protobuf:"varint,15,opt,name=force_topic_creation,json=forceTopicCreation,def=1" json:"force_topic_creation,omitempty"`
+	// If specified, the subscription will reset cursor's position back
+	// to specified seconds and  will send messages from that point
+	StartMessageRollbackDurationSec *uint64        `protobuf:"varint,16,opt,name=start_message_rollback_duration_sec,json=startMessageRollbackDurationSec,def=0" json:"start_message_rollback_duration_sec,omitempty"`
+	KeySharedMeta                   *KeySharedMeta `protobuf:"bytes,17,opt,name=keySharedMeta" json:"keySharedMeta,omitempty"`
+	SubscriptionProperties          []*KeyValue    `protobuf:"bytes,18,rep,name=subscription_properties,json=subscriptionProperties" json:"subscription_properties,omitempty"`
+	// The consumer epoch, when exclusive and failover consumer redeliver unack message will increase the epoch
+	ConsumerEpoch        *uint64  `protobuf:"varint,19,opt,name=consumer_epoch,json=consumerEpoch" json:"consumer_epoch,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *CommandSubscribe) Reset()         { *m = CommandSubscribe{} }
 func (m *CommandSubscribe) String() string { return proto.CompactTextString(m) }
 func (*CommandSubscribe) ProtoMessage()    {}
 func (*CommandSubscribe) Descriptor() ([]byte, []int) {
-	return fileDescriptor_PulsarApi_8cdc98e965f026ee, []int{9}
+	return fileDescriptor_39529ba7ad9caeb8, []int{16}
 }
+
 func (m *CommandSubscribe) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_CommandSubscribe.Unmarshal(m, b)
 }
 func (m *CommandSubscribe) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	return xxx_messageInfo_CommandSubscribe.Marshal(b, m, deterministic)
 }
-func (dst *CommandSubscribe) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_CommandSubscribe.Merge(dst, src)
+func (m *CommandSubscribe) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CommandSubscribe.Merge(m, src)
 }
 func (m *CommandSubscribe) XXX_Size() int {
 	return xxx_messageInfo_CommandSubscribe.Size(m)
@@ -1460,6 +2455,8 @@ var xxx_messageInfo_CommandSubscribe proto.InternalMessageInfo
 
 const Default_CommandSubscribe_Durable bool = true
 const Default_CommandSubscribe_InitialPosition CommandSubscribe_InitialPosition = CommandSubscribe_Latest
+const Default_CommandSubscribe_ForceTopicCreation bool = true
+const Default_CommandSubscribe_StartMessageRollbackDurationSec uint64 = 0
 
 func (m *CommandSubscribe) GetTopic() string {
 	if m != nil && m.Topic != nil {
@@ -1552,6 +2549,48 @@ func (m *CommandSubscribe) GetInitialPosition() CommandSubscribe_InitialPosition
 	return Default_CommandSubscribe_InitialPosition
 }
 
+func (m *CommandSubscribe) GetReplicateSubscriptionState() bool {
+	if m != nil && m.ReplicateSubscriptionState != nil {
+		return *m.ReplicateSubscriptionState
+	}
+	return false
+}
+
+func (m *CommandSubscribe) GetForceTopicCreation() bool {
+	if m != nil && m.ForceTopicCreation != nil {
+		return *m.ForceTopicCreation
+	}
+	return Default_CommandSubscribe_ForceTopicCreation
+}
+
+func (m *CommandSubscribe) GetStartMessageRollbackDurationSec() uint64 {
+	if m != nil && m.StartMessageRollbackDurationSec != nil {
+		return *m.StartMessageRollbackDurationSec
+	}
+	return Default_CommandSubscribe_StartMessageRollbackDurationSec
+}
+
+func (m *CommandSubscribe) GetKeySharedMeta() *KeySharedMeta {
+	if m != nil {
+		return m.KeySharedMeta
+	}
+	return nil
+}
+
+func (m *CommandSubscribe) GetSubscriptionProperties() []*KeyValue {
+	if m != nil {
+		return m.SubscriptionProperties
+	}
+	return nil
+}
+
+func (m *CommandSubscribe) GetConsumerEpoch() uint64 {
+	if m != nil && m.ConsumerEpoch != nil {
+		return *m.ConsumerEpoch
+	}
+	return 0
+}
+
 type CommandPartitionedTopicMetadata struct {
 	Topic     *string `protobuf:"bytes,1,req,name=topic" json:"topic,omitempty"`
 	RequestId *uint64 `protobuf:"varint,2,req,name=request_id,json=requestId" json:"request_id,omitempty"`
@@ -1572,16 +2611,17 @@ func (m *CommandPartitionedTopicMetadata) Reset()         { *m = CommandPartitio
 func (m *CommandPartitionedTopicMetadata) String() string { return proto.CompactTextString(m) }
 func (*CommandPartitionedTopicMetadata) ProtoMessage()    {}
 func (*CommandPartitionedTopicMetadata) Descriptor() ([]byte, []int) {
-	return fileDescriptor_PulsarApi_8cdc98e965f026ee, []int{10}
+	return fileDescriptor_39529ba7ad9caeb8, []int{17}
 }
+
 func (m *CommandPartitionedTopicMetadata) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_CommandPartitionedTopicMetadata.Unmarshal(m, b)
 }
 func (m *CommandPartitionedTopicMetadata) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	return xxx_messageInfo_CommandPartitionedTopicMetadata.Marshal(b, m, deterministic)
 }
-func (dst *CommandPartitionedTopicMetadata) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_CommandPartitionedTopicMetadata.Merge(dst, src)
+func (m *CommandPartitionedTopicMetadata) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CommandPartitionedTopicMetadata.Merge(m, src)
 }
 func (m *CommandPartitionedTopicMetadata) XXX_Size() int {
 	return xxx_messageInfo_CommandPartitionedTopicMetadata.Size(m)
@@ -1644,16 +2684,17 @@ func (m *CommandPartitionedTopicMetadataResponse) Reset() {
 func (m *CommandPartitionedTopicMetadataResponse) String() string { return proto.CompactTextString(m) }
 func (*CommandPartitionedTopicMetadataResponse) ProtoMessage()    {}
 func (*CommandPartitionedTopicMetadataResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_PulsarApi_8cdc98e965f026ee, []int{11}
+	return fileDescriptor_39529ba7ad9caeb8, []int{18}
 }
+
 func (m *CommandPartitionedTopicMetadataResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_CommandPartitionedTopicMetadataResponse.Unmarshal(m, b)
 }
 func (m *CommandPartitionedTopicMetadataResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	return xxx_messageInfo_CommandPartitionedTopicMetadataResponse.Marshal(b, m, deterministic)
 }
-func (dst *CommandPartitionedTopicMetadataResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_CommandPartitionedTopicMetadataResponse.Merge(dst, src)
+func (m *CommandPartitionedTopicMetadataResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CommandPartitionedTopicMetadataResponse.Merge(m, src)
 }
 func (m *CommandPartitionedTopicMetadataResponse) XXX_Size() int {
 	return xxx_messageInfo_CommandPartitionedTopicMetadataResponse.Size(m)
@@ -1709,27 +2750,32 @@ type CommandLookupTopic struct {
 	OriginalPrincipal *string `protobuf:"bytes,4,opt,name=original_principal,json=originalPrincipal" json:"original_principal,omitempty"`
 	// Original auth role and auth Method that was passed
 	// to the proxy.
-	OriginalAuthData     *string  `protobuf:"bytes,5,opt,name=original_auth_data,json=originalAuthData" json:"original_auth_data,omitempty"`
-	OriginalAuthMethod   *string  `protobuf:"bytes,6,opt,name=original_auth_method,json=originalAuthMethod" json:"original_auth_method,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	OriginalAuthData   *string `protobuf:"bytes,5,opt,name=original_auth_data,json=originalAuthData" json:"original_auth_data,omitempty"`
+	OriginalAuthMethod *string `protobuf:"bytes,6,opt,name=original_auth_method,json=originalAuthMethod" json:"original_auth_method,omitempty"`
+	//
+	AdvertisedListenerName *string `protobuf:"bytes,7,opt,name=advertised_listener_name,json=advertisedListenerName" json:"advertised_listener_name,omitempty"`
+	// The properties used for topic lookup
+	Properties           []*KeyValue `protobuf:"bytes,8,rep,name=properties" json:"properties,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
 }
 
 func (m *CommandLookupTopic) Reset()         { *m = CommandLookupTopic{} }
 func (m *CommandLookupTopic) String() string { return proto.CompactTextString(m) }
 func (*CommandLookupTopic) ProtoMessage()    {}
 func (*CommandLookupTopic) Descriptor() ([]byte, []int) {
-	return fileDescriptor_PulsarApi_8cdc98e965f026ee, []int{12}
+	return fileDescriptor_39529ba7ad9caeb8, []int{19}
 }
+
 func (m *CommandLookupTopic) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_CommandLookupTopic.Unmarshal(m, b)
 }
 func (m *CommandLookupTopic) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	return xxx_messageInfo_CommandLookupTopic.Marshal(b, m, deterministic)
 }
-func (dst *CommandLookupTopic) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_CommandLookupTopic.Merge(dst, src)
+func (m *CommandLookupTopic) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CommandLookupTopic.Merge(m, src)
 }
 func (m *CommandLookupTopic) XXX_Size() int {
 	return xxx_messageInfo_CommandLookupTopic.Size(m)
@@ -1784,6 +2830,20 @@ func (m *CommandLookupTopic) GetOriginalAuthMethod() string {
 	return ""
 }
 
+func (m *CommandLookupTopic) GetAdvertisedListenerName() string {
+	if m != nil && m.AdvertisedListenerName != nil {
+		return *m.AdvertisedListenerName
+	}
+	return ""
+}
+
+func (m *CommandLookupTopic) GetProperties() []*KeyValue {
+	if m != nil {
+		return m.Properties
+	}
+	return nil
+}
+
 type CommandLookupTopicResponse struct {
 	BrokerServiceUrl    *string                                `protobuf:"bytes,1,opt,name=brokerServiceUrl" json:"brokerServiceUrl,omitempty"`
 	BrokerServiceUrlTls *string                                `protobuf:"bytes,2,opt,name=brokerServiceUrlTls" json:"brokerServiceUrlTls,omitempty"`
@@ -1805,16 +2865,17 @@ func (m *CommandLookupTopicResponse) Reset()         { *m = CommandLookupTopicRe
 func (m *CommandLookupTopicResponse) String() string { return proto.CompactTextString(m) }
 func (*CommandLookupTopicResponse) ProtoMessage()    {}
 func (*CommandLookupTopicResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_PulsarApi_8cdc98e965f026ee, []int{13}
+	return fileDescriptor_39529ba7ad9caeb8, []int{20}
 }
+
 func (m *CommandLookupTopicResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_CommandLookupTopicResponse.Unmarshal(m, b)
 }
 func (m *CommandLookupTopicResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	return xxx_messageInfo_CommandLookupTopicResponse.Marshal(b, m, deterministic)
 }
-func (dst *CommandLookupTopicResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_CommandLookupTopicResponse.Merge(dst, src)
+func (m *CommandLookupTopicResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CommandLookupTopicResponse.Merge(m, src)
 }
 func (m *CommandLookupTopicResponse) XXX_Size() int {
 	return xxx_messageInfo_CommandLookupTopicResponse.Size(m)
@@ -1890,32 +2951,52 @@ type CommandProducer struct {
 	Topic      *string `protobuf:"bytes,1,req,name=topic" json:"topic,omitempty"`
 	ProducerId *uint64 `protobuf:"varint,2,req,name=producer_id,json=producerId" json:"producer_id,omitempty"`
 	RequestId  *uint64 `protobuf:"varint,3,req,name=request_id,json=requestId" json:"request_id,omitempty"`
-	// / If a producer name is specified, the name will be used,
-	// / otherwise the broker will generate a unique name
+	/// If a producer name is specified, the name will be used,
+	/// otherwise the broker will generate a unique name
 	ProducerName *string `protobuf:"bytes,4,opt,name=producer_name,json=producerName" json:"producer_name,omitempty"`
 	Encrypted    *bool   `protobuf:"varint,5,opt,name=encrypted,def=0" json:"encrypted,omitempty"`
-	// / Add optional metadata key=value to this producer
-	Metadata             []*KeyValue `protobuf:"bytes,6,rep,name=metadata" json:"metadata,omitempty"`
-	Schema               *Schema     `protobuf:"bytes,7,opt,name=schema" json:"schema,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
-	XXX_unrecognized     []byte      `json:"-"`
-	XXX_sizecache        int32       `json:"-"`
+	/// Add optional metadata key=value to this producer
+	Metadata []*KeyValue `protobuf:"bytes,6,rep,name=metadata" json:"metadata,omitempty"`
+	Schema   *Schema     `protobuf:"bytes,7,opt,name=schema" json:"schema,omitempty"`
+	// If producer reconnect to broker, the epoch of this producer will +1
+	Epoch *uint64 `protobuf:"varint,8,opt,name=epoch,def=0" json:"epoch,omitempty"`
+	// Indicate the name of the producer is generated or user provided
+	// Use default true here is in order to be forward compatible with the client
+	UserProvidedProducerName *bool `protobuf:"varint,9,opt,name=user_provided_producer_name,json=userProvidedProducerName,def=1" json:"user_provided_producer_name,omitempty"`
+	// Require that this producers will be the only producer allowed on the topic
+	ProducerAccessMode *ProducerAccessMode `protobuf:"varint,10,opt,name=producer_access_mode,json=producerAccessMode,enum=pulsar.proto.ProducerAccessMode,def=0" json:"producer_access_mode,omitempty"`
+	// Topic epoch is used to fence off producers that reconnects after a new
+	// exclusive producer has already taken over. This id is assigned by the
+	// broker on the CommandProducerSuccess. The first time, the client will
+	// leave it empty and then it will always carry the same epoch number on
+	// the subsequent reconnections.
+	TopicEpoch *uint64 `protobuf:"varint,11,opt,name=topic_epoch,json=topicEpoch" json:"topic_epoch,omitempty"`
+	TxnEnabled *bool   `protobuf:"varint,12,opt,name=txn_enabled,json=txnEnabled,def=0" json:"txn_enabled,omitempty"`
+	// Name of the initial subscription of the topic.
+	// If this field is not set, the initial subscription will not be created.
+	// If this field is set but the broker's `allowAutoSubscriptionCreation`
+	// is disabled, the producer will fail to be created.
+	InitialSubscriptionName *string  `protobuf:"bytes,13,opt,name=initial_subscription_name,json=initialSubscriptionName" json:"initial_subscription_name,omitempty"`
+	XXX_NoUnkeyedLiteral    struct{} `json:"-"`
+	XXX_unrecognized        []byte   `json:"-"`
+	XXX_sizecache           int32    `json:"-"`
 }
 
 func (m *CommandProducer) Reset()         { *m = CommandProducer{} }
 func (m *CommandProducer) String() string { return proto.CompactTextString(m) }
 func (*CommandProducer) ProtoMessage()    {}
 func (*CommandProducer) Descriptor() ([]byte, []int) {
-	return fileDescriptor_PulsarApi_8cdc98e965f026ee, []int{14}
+	return fileDescriptor_39529ba7ad9caeb8, []int{21}
 }
+
 func (m *CommandProducer) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_CommandProducer.Unmarshal(m, b)
 }
 func (m *CommandProducer) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	return xxx_messageInfo_CommandProducer.Marshal(b, m, deterministic)
 }
-func (dst *CommandProducer) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_CommandProducer.Merge(dst, src)
+func (m *CommandProducer) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CommandProducer.Merge(m, src)
 }
 func (m *CommandProducer) XXX_Size() int {
 	return xxx_messageInfo_CommandProducer.Size(m)
@@ -1927,6 +3008,10 @@ func (m *CommandProducer) XXX_DiscardUnknown() {
 var xxx_messageInfo_CommandProducer proto.InternalMessageInfo
 
 const Default_CommandProducer_Encrypted bool = false
+const Default_CommandProducer_Epoch uint64 = 0
+const Default_CommandProducer_UserProvidedProducerName bool = true
+const Default_CommandProducer_ProducerAccessMode ProducerAccessMode = ProducerAccessMode_Shared
+const Default_CommandProducer_TxnEnabled bool = false
 
 func (m *CommandProducer) GetTopic() string {
 	if m != nil && m.Topic != nil {
@@ -1977,29 +3062,81 @@ func (m *CommandProducer) GetSchema() *Schema {
 	return nil
 }
 
-type CommandSend struct {
-	ProducerId           *uint64  `protobuf:"varint,1,req,name=producer_id,json=producerId" json:"producer_id,omitempty"`
-	SequenceId           *uint64  `protobuf:"varint,2,req,name=sequence_id,json=sequenceId" json:"sequence_id,omitempty"`
-	NumMessages          *int32   `protobuf:"varint,3,opt,name=num_messages,json=numMessages,def=1" json:"num_messages,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+func (m *CommandProducer) GetEpoch() uint64 {
+	if m != nil && m.Epoch != nil {
+		return *m.Epoch
+	}
+	return Default_CommandProducer_Epoch
+}
+
+func (m *CommandProducer) GetUserProvidedProducerName() bool {
+	if m != nil && m.UserProvidedProducerName != nil {
+		return *m.UserProvidedProducerName
+	}
+	return Default_CommandProducer_UserProvidedProducerName
+}
+
+func (m *CommandProducer) GetProducerAccessMode() ProducerAccessMode {
+	if m != nil && m.ProducerAccessMode != nil {
+		return *m.ProducerAccessMode
+	}
+	return Default_CommandProducer_ProducerAccessMode
+}
+
+func (m *CommandProducer) GetTopicEpoch() uint64 {
+	if m != nil && m.TopicEpoch != nil {
+		return *m.TopicEpoch
+	}
+	return 0
+}
+
+func (m *CommandProducer) GetTxnEnabled() bool {
+	if m != nil && m.TxnEnabled != nil {
+		return *m.TxnEnabled
+	}
+	return Default_CommandProducer_TxnEnabled
+}
+
+func (m *CommandProducer) GetInitialSubscriptionName() string {
+	if m != nil && m.InitialSubscriptionName != nil {
+		return *m.InitialSubscriptionName
+	}
+	return ""
+}
+
+type CommandSend struct {
+	ProducerId     *uint64 `protobuf:"varint,1,req,name=producer_id,json=producerId" json:"producer_id,omitempty"`
+	SequenceId     *uint64 `protobuf:"varint,2,req,name=sequence_id,json=sequenceId" json:"sequence_id,omitempty"`
+	NumMessages    *int32  `protobuf:"varint,3,opt,name=num_messages,json=numMessages,def=1" json:"num_messages,omitempty"`
+	TxnidLeastBits *uint64 `protobuf:"varint,4,opt,name=txnid_least_bits,json=txnidLeastBits,def=0" json:"txnid_least_bits,omitempty"`
+	TxnidMostBits  *uint64 `protobuf:"varint,5,opt,name=txnid_most_bits,json=txnidMostBits,def=0" json:"txnid_most_bits,omitempty"`
+	/// Add highest sequence id to support batch message with external sequence id
+	HighestSequenceId *uint64 `protobuf:"varint,6,opt,name=highest_sequence_id,json=highestSequenceId,def=0" json:"highest_sequence_id,omitempty"`
+	IsChunk           *bool   `protobuf:"varint,7,opt,name=is_chunk,json=isChunk,def=0" json:"is_chunk,omitempty"`
+	// Specify if the message being published is a Pulsar marker or not
+	Marker *bool `protobuf:"varint,8,opt,name=marker,def=0" json:"marker,omitempty"`
+	// Message id of this message, currently is used in replicator for shadow topic.
+	MessageId            *MessageIdData `protobuf:"bytes,9,opt,name=message_id,json=messageId" json:"message_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
 }
 
 func (m *CommandSend) Reset()         { *m = CommandSend{} }
 func (m *CommandSend) String() string { return proto.CompactTextString(m) }
 func (*CommandSend) ProtoMessage()    {}
 func (*CommandSend) Descriptor() ([]byte, []int) {
-	return fileDescriptor_PulsarApi_8cdc98e965f026ee, []int{15}
+	return fileDescriptor_39529ba7ad9caeb8, []int{22}
 }
+
 func (m *CommandSend) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_CommandSend.Unmarshal(m, b)
 }
 func (m *CommandSend) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	return xxx_messageInfo_CommandSend.Marshal(b, m, deterministic)
 }
-func (dst *CommandSend) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_CommandSend.Merge(dst, src)
+func (m *CommandSend) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CommandSend.Merge(m, src)
 }
 func (m *CommandSend) XXX_Size() int {
 	return xxx_messageInfo_CommandSend.Size(m)
@@ -2011,6 +3148,11 @@ func (m *CommandSend) XXX_DiscardUnknown() {
 var xxx_messageInfo_CommandSend proto.InternalMessageInfo
 
 const Default_CommandSend_NumMessages int32 = 1
+const Default_CommandSend_TxnidLeastBits uint64 = 0
+const Default_CommandSend_TxnidMostBits uint64 = 0
+const Default_CommandSend_HighestSequenceId uint64 = 0
+const Default_CommandSend_IsChunk bool = false
+const Default_CommandSend_Marker bool = false
 
 func (m *CommandSend) GetProducerId() uint64 {
 	if m != nil && m.ProducerId != nil {
@@ -2033,10 +3175,53 @@ func (m *CommandSend) GetNumMessages() int32 {
 	return Default_CommandSend_NumMessages
 }
 
+func (m *CommandSend) GetTxnidLeastBits() uint64 {
+	if m != nil && m.TxnidLeastBits != nil {
+		return *m.TxnidLeastBits
+	}
+	return Default_CommandSend_TxnidLeastBits
+}
+
+func (m *CommandSend) GetTxnidMostBits() uint64 {
+	if m != nil && m.TxnidMostBits != nil {
+		return *m.TxnidMostBits
+	}
+	return Default_CommandSend_TxnidMostBits
+}
+
+func (m *CommandSend) GetHighestSequenceId() uint64 {
+	if m != nil && m.HighestSequenceId != nil {
+		return *m.HighestSequenceId
+	}
+	return Default_CommandSend_HighestSequenceId
+}
+
+func (m *CommandSend) GetIsChunk() bool {
+	if m != nil && m.IsChunk != nil {
+		return *m.IsChunk
+	}
+	return Default_CommandSend_IsChunk
+}
+
+func (m *CommandSend) GetMarker() bool {
+	if m != nil && m.Marker != nil {
+		return *m.Marker
+	}
+	return Default_CommandSend_Marker
+}
+
+func (m *CommandSend) GetMessageId() *MessageIdData {
+	if m != nil {
+		return m.MessageId
+	}
+	return nil
+}
+
 type CommandSendReceipt struct {
 	ProducerId           *uint64        `protobuf:"varint,1,req,name=producer_id,json=producerId" json:"producer_id,omitempty"`
 	SequenceId           *uint64        `protobuf:"varint,2,req,name=sequence_id,json=sequenceId" json:"sequence_id,omitempty"`
 	MessageId            *MessageIdData `protobuf:"bytes,3,opt,name=message_id,json=messageId" json:"message_id,omitempty"`
+	HighestSequenceId    *uint64        `protobuf:"varint,4,opt,name=highest_sequence_id,json=highestSequenceId,def=0" json:"highest_sequence_id,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
 	XXX_unrecognized     []byte         `json:"-"`
 	XXX_sizecache        int32          `json:"-"`
@@ -2046,16 +3231,17 @@ func (m *CommandSendReceipt) Reset()         { *m = CommandSendReceipt{} }
 func (m *CommandSendReceipt) String() string { return proto.CompactTextString(m) }
 func (*CommandSendReceipt) ProtoMessage()    {}
 func (*CommandSendReceipt) Descriptor() ([]byte, []int) {
-	return fileDescriptor_PulsarApi_8cdc98e965f026ee, []int{16}
+	return fileDescriptor_39529ba7ad9caeb8, []int{23}
 }
+
 func (m *CommandSendReceipt) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_CommandSendReceipt.Unmarshal(m, b)
 }
 func (m *CommandSendReceipt) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	return xxx_messageInfo_CommandSendReceipt.Marshal(b, m, deterministic)
 }
-func (dst *CommandSendReceipt) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_CommandSendReceipt.Merge(dst, src)
+func (m *CommandSendReceipt) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CommandSendReceipt.Merge(m, src)
 }
 func (m *CommandSendReceipt) XXX_Size() int {
 	return xxx_messageInfo_CommandSendReceipt.Size(m)
@@ -2066,6 +3252,8 @@ func (m *CommandSendReceipt) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_CommandSendReceipt proto.InternalMessageInfo
 
+const Default_CommandSendReceipt_HighestSequenceId uint64 = 0
+
 func (m *CommandSendReceipt) GetProducerId() uint64 {
 	if m != nil && m.ProducerId != nil {
 		return *m.ProducerId
@@ -2087,6 +3275,13 @@ func (m *CommandSendReceipt) GetMessageId() *MessageIdData {
 	return nil
 }
 
+func (m *CommandSendReceipt) GetHighestSequenceId() uint64 {
+	if m != nil && m.HighestSequenceId != nil {
+		return *m.HighestSequenceId
+	}
+	return Default_CommandSendReceipt_HighestSequenceId
+}
+
 type CommandSendError struct {
 	ProducerId           *uint64      `protobuf:"varint,1,req,name=producer_id,json=producerId" json:"producer_id,omitempty"`
 	SequenceId           *uint64      `protobuf:"varint,2,req,name=sequence_id,json=sequenceId" json:"sequence_id,omitempty"`
@@ -2101,16 +3296,17 @@ func (m *CommandSendError) Reset()         { *m = CommandSendError{} }
 func (m *CommandSendError) String() string { return proto.CompactTextString(m) }
 func (*CommandSendError) ProtoMessage()    {}
 func (*CommandSendError) Descriptor() ([]byte, []int) {
-	return fileDescriptor_PulsarApi_8cdc98e965f026ee, []int{17}
+	return fileDescriptor_39529ba7ad9caeb8, []int{24}
 }
+
 func (m *CommandSendError) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_CommandSendError.Unmarshal(m, b)
 }
 func (m *CommandSendError) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	return xxx_messageInfo_CommandSendError.Marshal(b, m, deterministic)
 }
-func (dst *CommandSendError) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_CommandSendError.Merge(dst, src)
+func (m *CommandSendError) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CommandSendError.Merge(m, src)
 }
 func (m *CommandSendError) XXX_Size() int {
 	return xxx_messageInfo_CommandSendError.Size(m)
@@ -2153,6 +3349,8 @@ type CommandMessage struct {
 	ConsumerId           *uint64        `protobuf:"varint,1,req,name=consumer_id,json=consumerId" json:"consumer_id,omitempty"`
 	MessageId            *MessageIdData `protobuf:"bytes,2,req,name=message_id,json=messageId" json:"message_id,omitempty"`
 	RedeliveryCount      *uint32        `protobuf:"varint,3,opt,name=redelivery_count,json=redeliveryCount,def=0" json:"redelivery_count,omitempty"`
+	AckSet               []int64        `protobuf:"varint,4,rep,name=ack_set,json=ackSet" json:"ack_set,omitempty"`
+	ConsumerEpoch        *uint64        `protobuf:"varint,5,opt,name=consumer_epoch,json=consumerEpoch" json:"consumer_epoch,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
 	XXX_unrecognized     []byte         `json:"-"`
 	XXX_sizecache        int32          `json:"-"`
@@ -2162,16 +3360,17 @@ func (m *CommandMessage) Reset()         { *m = CommandMessage{} }
 func (m *CommandMessage) String() string { return proto.CompactTextString(m) }
 func (*CommandMessage) ProtoMessage()    {}
 func (*CommandMessage) Descriptor() ([]byte, []int) {
-	return fileDescriptor_PulsarApi_8cdc98e965f026ee, []int{18}
+	return fileDescriptor_39529ba7ad9caeb8, []int{25}
 }
+
 func (m *CommandMessage) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_CommandMessage.Unmarshal(m, b)
 }
 func (m *CommandMessage) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	return xxx_messageInfo_CommandMessage.Marshal(b, m, deterministic)
 }
-func (dst *CommandMessage) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_CommandMessage.Merge(dst, src)
+func (m *CommandMessage) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CommandMessage.Merge(m, src)
 }
 func (m *CommandMessage) XXX_Size() int {
 	return xxx_messageInfo_CommandMessage.Size(m)
@@ -2205,6 +3404,20 @@ func (m *CommandMessage) GetRedeliveryCount() uint32 {
 	return Default_CommandMessage_RedeliveryCount
 }
 
+func (m *CommandMessage) GetAckSet() []int64 {
+	if m != nil {
+		return m.AckSet
+	}
+	return nil
+}
+
+func (m *CommandMessage) GetConsumerEpoch() uint64 {
+	if m != nil && m.ConsumerEpoch != nil {
+		return *m.ConsumerEpoch
+	}
+	return 0
+}
+
 type CommandAck struct {
 	ConsumerId *uint64             `protobuf:"varint,1,req,name=consumer_id,json=consumerId" json:"consumer_id,omitempty"`
 	AckType    *CommandAck_AckType `protobuf:"varint,2,req,name=ack_type,json=ackType,enum=pulsar.proto.CommandAck_AckType" json:"ack_type,omitempty"`
@@ -2212,6 +3425,9 @@ type CommandAck struct {
 	MessageId            []*MessageIdData            `protobuf:"bytes,3,rep,name=message_id,json=messageId" json:"message_id,omitempty"`
 	ValidationError      *CommandAck_ValidationError `protobuf:"varint,4,opt,name=validation_error,json=validationError,enum=pulsar.proto.CommandAck_ValidationError" json:"validation_error,omitempty"`
 	Properties           []*KeyLongValue             `protobuf:"bytes,5,rep,name=properties" json:"properties,omitempty"`
+	TxnidLeastBits       *uint64                     `protobuf:"varint,6,opt,name=txnid_least_bits,json=txnidLeastBits,def=0" json:"txnid_least_bits,omitempty"`
+	TxnidMostBits        *uint64                     `protobuf:"varint,7,opt,name=txnid_most_bits,json=txnidMostBits,def=0" json:"txnid_most_bits,omitempty"`
+	RequestId            *uint64                     `protobuf:"varint,8,opt,name=request_id,json=requestId" json:"request_id,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}                    `json:"-"`
 	XXX_unrecognized     []byte                      `json:"-"`
 	XXX_sizecache        int32                       `json:"-"`
@@ -2221,16 +3437,17 @@ func (m *CommandAck) Reset()         { *m = CommandAck{} }
 func (m *CommandAck) String() string { return proto.CompactTextString(m) }
 func (*CommandAck) ProtoMessage()    {}
 func (*CommandAck) Descriptor() ([]byte, []int) {
-	return fileDescriptor_PulsarApi_8cdc98e965f026ee, []int{19}
+	return fileDescriptor_39529ba7ad9caeb8, []int{26}
 }
+
 func (m *CommandAck) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_CommandAck.Unmarshal(m, b)
 }
 func (m *CommandAck) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	return xxx_messageInfo_CommandAck.Marshal(b, m, deterministic)
 }
-func (dst *CommandAck) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_CommandAck.Merge(dst, src)
+func (m *CommandAck) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CommandAck.Merge(m, src)
 }
 func (m *CommandAck) XXX_Size() int {
 	return xxx_messageInfo_CommandAck.Size(m)
@@ -2241,6 +3458,9 @@ func (m *CommandAck) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_CommandAck proto.InternalMessageInfo
 
+const Default_CommandAck_TxnidLeastBits uint64 = 0
+const Default_CommandAck_TxnidMostBits uint64 = 0
+
 func (m *CommandAck) GetConsumerId() uint64 {
 	if m != nil && m.ConsumerId != nil {
 		return *m.ConsumerId
@@ -2276,6 +3496,109 @@ func (m *CommandAck) GetProperties() []*KeyLongValue {
 	return nil
 }
 
+func (m *CommandAck) GetTxnidLeastBits() uint64 {
+	if m != nil && m.TxnidLeastBits != nil {
+		return *m.TxnidLeastBits
+	}
+	return Default_CommandAck_TxnidLeastBits
+}
+
+func (m *CommandAck) GetTxnidMostBits() uint64 {
+	if m != nil && m.TxnidMostBits != nil {
+		return *m.TxnidMostBits
+	}
+	return Default_CommandAck_TxnidMostBits
+}
+
+func (m *CommandAck) GetRequestId() uint64 {
+	if m != nil && m.RequestId != nil {
+		return *m.RequestId
+	}
+	return 0
+}
+
+type CommandAckResponse struct {
+	ConsumerId           *uint64      `protobuf:"varint,1,req,name=consumer_id,json=consumerId" json:"consumer_id,omitempty"`
+	TxnidLeastBits       *uint64      `protobuf:"varint,2,opt,name=txnid_least_bits,json=txnidLeastBits,def=0" json:"txnid_least_bits,omitempty"`
+	TxnidMostBits        *uint64      `protobuf:"varint,3,opt,name=txnid_most_bits,json=txnidMostBits,def=0" json:"txnid_most_bits,omitempty"`
+	Error                *ServerError `protobuf:"varint,4,opt,name=error,enum=pulsar.proto.ServerError" json:"error,omitempty"`
+	Message              *string      `protobuf:"bytes,5,opt,name=message" json:"message,omitempty"`
+	RequestId            *uint64      `protobuf:"varint,6,opt,name=request_id,json=requestId" json:"request_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
+}
+
+func (m *CommandAckResponse) Reset()         { *m = CommandAckResponse{} }
+func (m *CommandAckResponse) String() string { return proto.CompactTextString(m) }
+func (*CommandAckResponse) ProtoMessage()    {}
+func (*CommandAckResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_39529ba7ad9caeb8, []int{27}
+}
+
+func (m *CommandAckResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CommandAckResponse.Unmarshal(m, b)
+}
+func (m *CommandAckResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CommandAckResponse.Marshal(b, m, deterministic)
+}
+func (m *CommandAckResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CommandAckResponse.Merge(m, src)
+}
+func (m *CommandAckResponse) XXX_Size() int {
+	return xxx_messageInfo_CommandAckResponse.Size(m)
+}
+func (m *CommandAckResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_CommandAckResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CommandAckResponse proto.InternalMessageInfo
+
+const Default_CommandAckResponse_TxnidLeastBits uint64 = 0
+const Default_CommandAckResponse_TxnidMostBits uint64 = 0
+
+func (m *CommandAckResponse) GetConsumerId() uint64 {
+	if m != nil && m.ConsumerId != nil {
+		return *m.ConsumerId
+	}
+	return 0
+}
+
+func (m *CommandAckResponse) GetTxnidLeastBits() uint64 {
+	if m != nil && m.TxnidLeastBits != nil {
+		return *m.TxnidLeastBits
+	}
+	return Default_CommandAckResponse_TxnidLeastBits
+}
+
+func (m *CommandAckResponse) GetTxnidMostBits() uint64 {
+	if m != nil && m.TxnidMostBits != nil {
+		return *m.TxnidMostBits
+	}
+	return Default_CommandAckResponse_TxnidMostBits
+}
+
+func (m *CommandAckResponse) GetError() ServerError {
+	if m != nil && m.Error != nil {
+		return *m.Error
+	}
+	return ServerError_UnknownError
+}
+
+func (m *CommandAckResponse) GetMessage() string {
+	if m != nil && m.Message != nil {
+		return *m.Message
+	}
+	return ""
+}
+
+func (m *CommandAckResponse) GetRequestId() uint64 {
+	if m != nil && m.RequestId != nil {
+		return *m.RequestId
+	}
+	return 0
+}
+
 // changes on active consumer
 type CommandActiveConsumerChange struct {
 	ConsumerId           *uint64  `protobuf:"varint,1,req,name=consumer_id,json=consumerId" json:"consumer_id,omitempty"`
@@ -2289,16 +3612,17 @@ func (m *CommandActiveConsumerChange) Reset()         { *m = CommandActiveConsum
 func (m *CommandActiveConsumerChange) String() string { return proto.CompactTextString(m) }
 func (*CommandActiveConsumerChange) ProtoMessage()    {}
 func (*CommandActiveConsumerChange) Descriptor() ([]byte, []int) {
-	return fileDescriptor_PulsarApi_8cdc98e965f026ee, []int{20}
+	return fileDescriptor_39529ba7ad9caeb8, []int{28}
 }
+
 func (m *CommandActiveConsumerChange) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_CommandActiveConsumerChange.Unmarshal(m, b)
 }
 func (m *CommandActiveConsumerChange) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	return xxx_messageInfo_CommandActiveConsumerChange.Marshal(b, m, deterministic)
 }
-func (dst *CommandActiveConsumerChange) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_CommandActiveConsumerChange.Merge(dst, src)
+func (m *CommandActiveConsumerChange) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CommandActiveConsumerChange.Merge(m, src)
 }
 func (m *CommandActiveConsumerChange) XXX_Size() int {
 	return xxx_messageInfo_CommandActiveConsumerChange.Size(m)
@@ -2339,16 +3663,17 @@ func (m *CommandFlow) Reset()         { *m = CommandFlow{} }
 func (m *CommandFlow) String() string { return proto.CompactTextString(m) }
 func (*CommandFlow) ProtoMessage()    {}
 func (*CommandFlow) Descriptor() ([]byte, []int) {
-	return fileDescriptor_PulsarApi_8cdc98e965f026ee, []int{21}
+	return fileDescriptor_39529ba7ad9caeb8, []int{29}
 }
+
 func (m *CommandFlow) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_CommandFlow.Unmarshal(m, b)
 }
 func (m *CommandFlow) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	return xxx_messageInfo_CommandFlow.Marshal(b, m, deterministic)
 }
-func (dst *CommandFlow) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_CommandFlow.Merge(dst, src)
+func (m *CommandFlow) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CommandFlow.Merge(m, src)
 }
 func (m *CommandFlow) XXX_Size() int {
 	return xxx_messageInfo_CommandFlow.Size(m)
@@ -2376,6 +3701,7 @@ func (m *CommandFlow) GetMessagePermits() uint32 {
 type CommandUnsubscribe struct {
 	ConsumerId           *uint64  `protobuf:"varint,1,req,name=consumer_id,json=consumerId" json:"consumer_id,omitempty"`
 	RequestId            *uint64  `protobuf:"varint,2,req,name=request_id,json=requestId" json:"request_id,omitempty"`
+	Force                *bool    `protobuf:"varint,3,opt,name=force,def=0" json:"force,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -2385,16 +3711,17 @@ func (m *CommandUnsubscribe) Reset()         { *m = CommandUnsubscribe{} }
 func (m *CommandUnsubscribe) String() string { return proto.CompactTextString(m) }
 func (*CommandUnsubscribe) ProtoMessage()    {}
 func (*CommandUnsubscribe) Descriptor() ([]byte, []int) {
-	return fileDescriptor_PulsarApi_8cdc98e965f026ee, []int{22}
+	return fileDescriptor_39529ba7ad9caeb8, []int{30}
 }
+
 func (m *CommandUnsubscribe) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_CommandUnsubscribe.Unmarshal(m, b)
 }
 func (m *CommandUnsubscribe) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	return xxx_messageInfo_CommandUnsubscribe.Marshal(b, m, deterministic)
 }
-func (dst *CommandUnsubscribe) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_CommandUnsubscribe.Merge(dst, src)
+func (m *CommandUnsubscribe) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CommandUnsubscribe.Merge(m, src)
 }
 func (m *CommandUnsubscribe) XXX_Size() int {
 	return xxx_messageInfo_CommandUnsubscribe.Size(m)
@@ -2405,6 +3732,8 @@ func (m *CommandUnsubscribe) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_CommandUnsubscribe proto.InternalMessageInfo
 
+const Default_CommandUnsubscribe_Force bool = false
+
 func (m *CommandUnsubscribe) GetConsumerId() uint64 {
 	if m != nil && m.ConsumerId != nil {
 		return *m.ConsumerId
@@ -2419,6 +3748,13 @@ func (m *CommandUnsubscribe) GetRequestId() uint64 {
 	return 0
 }
 
+func (m *CommandUnsubscribe) GetForce() bool {
+	if m != nil && m.Force != nil {
+		return *m.Force
+	}
+	return Default_CommandUnsubscribe_Force
+}
+
 // Reset an existing consumer to a particular message id
 type CommandSeek struct {
 	ConsumerId           *uint64        `protobuf:"varint,1,req,name=consumer_id,json=consumerId" json:"consumer_id,omitempty"`
@@ -2434,16 +3770,17 @@ func (m *CommandSeek) Reset()         { *m = CommandSeek{} }
 func (m *CommandSeek) String() string { return proto.CompactTextString(m) }
 func (*CommandSeek) ProtoMessage()    {}
 func (*CommandSeek) Descriptor() ([]byte, []int) {
-	return fileDescriptor_PulsarApi_8cdc98e965f026ee, []int{23}
+	return fileDescriptor_39529ba7ad9caeb8, []int{31}
 }
+
 func (m *CommandSeek) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_CommandSeek.Unmarshal(m, b)
 }
 func (m *CommandSeek) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	return xxx_messageInfo_CommandSeek.Marshal(b, m, deterministic)
 }
-func (dst *CommandSeek) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_CommandSeek.Merge(dst, src)
+func (m *CommandSeek) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CommandSeek.Merge(m, src)
 }
 func (m *CommandSeek) XXX_Size() int {
 	return xxx_messageInfo_CommandSeek.Size(m)
@@ -2496,16 +3833,17 @@ func (m *CommandReachedEndOfTopic) Reset()         { *m = CommandReachedEndOfTop
 func (m *CommandReachedEndOfTopic) String() string { return proto.CompactTextString(m) }
 func (*CommandReachedEndOfTopic) ProtoMessage()    {}
 func (*CommandReachedEndOfTopic) Descriptor() ([]byte, []int) {
-	return fileDescriptor_PulsarApi_8cdc98e965f026ee, []int{24}
+	return fileDescriptor_39529ba7ad9caeb8, []int{32}
 }
+
 func (m *CommandReachedEndOfTopic) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_CommandReachedEndOfTopic.Unmarshal(m, b)
 }
 func (m *CommandReachedEndOfTopic) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	return xxx_messageInfo_CommandReachedEndOfTopic.Marshal(b, m, deterministic)
 }
-func (dst *CommandReachedEndOfTopic) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_CommandReachedEndOfTopic.Merge(dst, src)
+func (m *CommandReachedEndOfTopic) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CommandReachedEndOfTopic.Merge(m, src)
 }
 func (m *CommandReachedEndOfTopic) XXX_Size() int {
 	return xxx_messageInfo_CommandReachedEndOfTopic.Size(m)
@@ -2523,28 +3861,94 @@ func (m *CommandReachedEndOfTopic) GetConsumerId() uint64 {
 	return 0
 }
 
+type CommandTopicMigrated struct {
+	ResourceId           *uint64                            `protobuf:"varint,1,req,name=resource_id,json=resourceId" json:"resource_id,omitempty"`
+	ResourceType         *CommandTopicMigrated_ResourceType `protobuf:"varint,2,req,name=resource_type,json=resourceType,enum=pulsar.proto.CommandTopicMigrated_ResourceType" json:"resource_type,omitempty"`
+	BrokerServiceUrl     *string                            `protobuf:"bytes,3,opt,name=brokerServiceUrl" json:"brokerServiceUrl,omitempty"`
+	BrokerServiceUrlTls  *string                            `protobuf:"bytes,4,opt,name=brokerServiceUrlTls" json:"brokerServiceUrlTls,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                           `json:"-"`
+	XXX_unrecognized     []byte                             `json:"-"`
+	XXX_sizecache        int32                              `json:"-"`
+}
+
+func (m *CommandTopicMigrated) Reset()         { *m = CommandTopicMigrated{} }
+func (m *CommandTopicMigrated) String() string { return proto.CompactTextString(m) }
+func (*CommandTopicMigrated) ProtoMessage()    {}
+func (*CommandTopicMigrated) Descriptor() ([]byte, []int) {
+	return fileDescriptor_39529ba7ad9caeb8, []int{33}
+}
+
+func (m *CommandTopicMigrated) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CommandTopicMigrated.Unmarshal(m, b)
+}
+func (m *CommandTopicMigrated) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CommandTopicMigrated.Marshal(b, m, deterministic)
+}
+func (m *CommandTopicMigrated) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CommandTopicMigrated.Merge(m, src)
+}
+func (m *CommandTopicMigrated) XXX_Size() int {
+	return xxx_messageInfo_CommandTopicMigrated.Size(m)
+}
+func (m *CommandTopicMigrated) XXX_DiscardUnknown() {
+	xxx_messageInfo_CommandTopicMigrated.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CommandTopicMigrated proto.InternalMessageInfo
+
+func (m *CommandTopicMigrated) GetResourceId() uint64 {
+	if m != nil && m.ResourceId != nil {
+		return *m.ResourceId
+	}
+	return 0
+}
+
+func (m *CommandTopicMigrated) GetResourceType() CommandTopicMigrated_ResourceType {
+	if m != nil && m.ResourceType != nil {
+		return *m.ResourceType
+	}
+	return CommandTopicMigrated_Producer
+}
+
+func (m *CommandTopicMigrated) GetBrokerServiceUrl() string {
+	if m != nil && m.BrokerServiceUrl != nil {
+		return *m.BrokerServiceUrl
+	}
+	return ""
+}
+
+func (m *CommandTopicMigrated) GetBrokerServiceUrlTls() string {
+	if m != nil && m.BrokerServiceUrlTls != nil {
+		return *m.BrokerServiceUrlTls
+	}
+	return ""
+}
+
 type CommandCloseProducer struct {
-	ProducerId           *uint64  `protobuf:"varint,1,req,name=producer_id,json=producerId" json:"producer_id,omitempty"`
-	RequestId            *uint64  `protobuf:"varint,2,req,name=request_id,json=requestId" json:"request_id,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	ProducerId                  *uint64  `protobuf:"varint,1,req,name=producer_id,json=producerId" json:"producer_id,omitempty"`
+	RequestId                   *uint64  `protobuf:"varint,2,req,name=request_id,json=requestId" json:"request_id,omitempty"`
+	AssignedBrokerServiceUrl    *string  `protobuf:"bytes,3,opt,name=assignedBrokerServiceUrl" json:"assignedBrokerServiceUrl,omitempty"`
+	AssignedBrokerServiceUrlTls *string  `protobuf:"bytes,4,opt,name=assignedBrokerServiceUrlTls" json:"assignedBrokerServiceUrlTls,omitempty"`
+	XXX_NoUnkeyedLiteral        struct{} `json:"-"`
+	XXX_unrecognized            []byte   `json:"-"`
+	XXX_sizecache               int32    `json:"-"`
 }
 
 func (m *CommandCloseProducer) Reset()         { *m = CommandCloseProducer{} }
 func (m *CommandCloseProducer) String() string { return proto.CompactTextString(m) }
 func (*CommandCloseProducer) ProtoMessage()    {}
 func (*CommandCloseProducer) Descriptor() ([]byte, []int) {
-	return fileDescriptor_PulsarApi_8cdc98e965f026ee, []int{25}
+	return fileDescriptor_39529ba7ad9caeb8, []int{34}
 }
+
 func (m *CommandCloseProducer) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_CommandCloseProducer.Unmarshal(m, b)
 }
 func (m *CommandCloseProducer) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	return xxx_messageInfo_CommandCloseProducer.Marshal(b, m, deterministic)
 }
-func (dst *CommandCloseProducer) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_CommandCloseProducer.Merge(dst, src)
+func (m *CommandCloseProducer) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CommandCloseProducer.Merge(m, src)
 }
 func (m *CommandCloseProducer) XXX_Size() int {
 	return xxx_messageInfo_CommandCloseProducer.Size(m)
@@ -2569,28 +3973,45 @@ func (m *CommandCloseProducer) GetRequestId() uint64 {
 	return 0
 }
 
+func (m *CommandCloseProducer) GetAssignedBrokerServiceUrl() string {
+	if m != nil && m.AssignedBrokerServiceUrl != nil {
+		return *m.AssignedBrokerServiceUrl
+	}
+	return ""
+}
+
+func (m *CommandCloseProducer) GetAssignedBrokerServiceUrlTls() string {
+	if m != nil && m.AssignedBrokerServiceUrlTls != nil {
+		return *m.AssignedBrokerServiceUrlTls
+	}
+	return ""
+}
+
 type CommandCloseConsumer struct {
-	ConsumerId           *uint64  `protobuf:"varint,1,req,name=consumer_id,json=consumerId" json:"consumer_id,omitempty"`
-	RequestId            *uint64  `protobuf:"varint,2,req,name=request_id,json=requestId" json:"request_id,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	ConsumerId                  *uint64  `protobuf:"varint,1,req,name=consumer_id,json=consumerId" json:"consumer_id,omitempty"`
+	RequestId                   *uint64  `protobuf:"varint,2,req,name=request_id,json=requestId" json:"request_id,omitempty"`
+	AssignedBrokerServiceUrl    *string  `protobuf:"bytes,3,opt,name=assignedBrokerServiceUrl" json:"assignedBrokerServiceUrl,omitempty"`
+	AssignedBrokerServiceUrlTls *string  `protobuf:"bytes,4,opt,name=assignedBrokerServiceUrlTls" json:"assignedBrokerServiceUrlTls,omitempty"`
+	XXX_NoUnkeyedLiteral        struct{} `json:"-"`
+	XXX_unrecognized            []byte   `json:"-"`
+	XXX_sizecache               int32    `json:"-"`
 }
 
 func (m *CommandCloseConsumer) Reset()         { *m = CommandCloseConsumer{} }
 func (m *CommandCloseConsumer) String() string { return proto.CompactTextString(m) }
 func (*CommandCloseConsumer) ProtoMessage()    {}
 func (*CommandCloseConsumer) Descriptor() ([]byte, []int) {
-	return fileDescriptor_PulsarApi_8cdc98e965f026ee, []int{26}
+	return fileDescriptor_39529ba7ad9caeb8, []int{35}
 }
+
 func (m *CommandCloseConsumer) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_CommandCloseConsumer.Unmarshal(m, b)
 }
 func (m *CommandCloseConsumer) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	return xxx_messageInfo_CommandCloseConsumer.Marshal(b, m, deterministic)
 }
-func (dst *CommandCloseConsumer) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_CommandCloseConsumer.Merge(dst, src)
+func (m *CommandCloseConsumer) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CommandCloseConsumer.Merge(m, src)
 }
 func (m *CommandCloseConsumer) XXX_Size() int {
 	return xxx_messageInfo_CommandCloseConsumer.Size(m)
@@ -2615,9 +4036,24 @@ func (m *CommandCloseConsumer) GetRequestId() uint64 {
 	return 0
 }
 
+func (m *CommandCloseConsumer) GetAssignedBrokerServiceUrl() string {
+	if m != nil && m.AssignedBrokerServiceUrl != nil {
+		return *m.AssignedBrokerServiceUrl
+	}
+	return ""
+}
+
+func (m *CommandCloseConsumer) GetAssignedBrokerServiceUrlTls() string {
+	if m != nil && m.AssignedBrokerServiceUrlTls != nil {
+		return *m.AssignedBrokerServiceUrlTls
+	}
+	return ""
+}
+
 type CommandRedeliverUnacknowledgedMessages struct {
 	ConsumerId           *uint64          `protobuf:"varint,1,req,name=consumer_id,json=consumerId" json:"consumer_id,omitempty"`
 	MessageIds           []*MessageIdData `protobuf:"bytes,2,rep,name=message_ids,json=messageIds" json:"message_ids,omitempty"`
+	ConsumerEpoch        *uint64          `protobuf:"varint,3,opt,name=consumer_epoch,json=consumerEpoch" json:"consumer_epoch,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
 	XXX_unrecognized     []byte           `json:"-"`
 	XXX_sizecache        int32            `json:"-"`
@@ -2629,16 +4065,17 @@ func (m *CommandRedeliverUnacknowledgedMessages) Reset() {
 func (m *CommandRedeliverUnacknowledgedMessages) String() string { return proto.CompactTextString(m) }
 func (*CommandRedeliverUnacknowledgedMessages) ProtoMessage()    {}
 func (*CommandRedeliverUnacknowledgedMessages) Descriptor() ([]byte, []int) {
-	return fileDescriptor_PulsarApi_8cdc98e965f026ee, []int{27}
+	return fileDescriptor_39529ba7ad9caeb8, []int{36}
 }
+
 func (m *CommandRedeliverUnacknowledgedMessages) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_CommandRedeliverUnacknowledgedMessages.Unmarshal(m, b)
 }
 func (m *CommandRedeliverUnacknowledgedMessages) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	return xxx_messageInfo_CommandRedeliverUnacknowledgedMessages.Marshal(b, m, deterministic)
 }
-func (dst *CommandRedeliverUnacknowledgedMessages) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_CommandRedeliverUnacknowledgedMessages.Merge(dst, src)
+func (m *CommandRedeliverUnacknowledgedMessages) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CommandRedeliverUnacknowledgedMessages.Merge(m, src)
 }
 func (m *CommandRedeliverUnacknowledgedMessages) XXX_Size() int {
 	return xxx_messageInfo_CommandRedeliverUnacknowledgedMessages.Size(m)
@@ -2663,6 +4100,13 @@ func (m *CommandRedeliverUnacknowledgedMessages) GetMessageIds() []*MessageIdDat
 	return nil
 }
 
+func (m *CommandRedeliverUnacknowledgedMessages) GetConsumerEpoch() uint64 {
+	if m != nil && m.ConsumerEpoch != nil {
+		return *m.ConsumerEpoch
+	}
+	return 0
+}
+
 type CommandSuccess struct {
 	RequestId            *uint64  `protobuf:"varint,1,req,name=request_id,json=requestId" json:"request_id,omitempty"`
 	Schema               *Schema  `protobuf:"bytes,2,opt,name=schema" json:"schema,omitempty"`
@@ -2675,16 +4119,17 @@ func (m *CommandSuccess) Reset()         { *m = CommandSuccess{} }
 func (m *CommandSuccess) String() string { return proto.CompactTextString(m) }
 func (*CommandSuccess) ProtoMessage()    {}
 func (*CommandSuccess) Descriptor() ([]byte, []int) {
-	return fileDescriptor_PulsarApi_8cdc98e965f026ee, []int{28}
+	return fileDescriptor_39529ba7ad9caeb8, []int{37}
 }
+
 func (m *CommandSuccess) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_CommandSuccess.Unmarshal(m, b)
 }
 func (m *CommandSuccess) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	return xxx_messageInfo_CommandSuccess.Marshal(b, m, deterministic)
 }
-func (dst *CommandSuccess) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_CommandSuccess.Merge(dst, src)
+func (m *CommandSuccess) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CommandSuccess.Merge(m, src)
 }
 func (m *CommandSuccess) XXX_Size() int {
 	return xxx_messageInfo_CommandSuccess.Size(m)
@@ -2715,8 +4160,15 @@ type CommandProducerSuccess struct {
 	ProducerName *string `protobuf:"bytes,2,req,name=producer_name,json=producerName" json:"producer_name,omitempty"`
 	// The last sequence id that was stored by this producer in the previous session
 	// This will only be meaningful if deduplication has been enabled.
-	LastSequenceId       *int64   `protobuf:"varint,3,opt,name=last_sequence_id,json=lastSequenceId,def=-1" json:"last_sequence_id,omitempty"`
-	SchemaVersion        []byte   `protobuf:"bytes,4,opt,name=schema_version,json=schemaVersion" json:"schema_version,omitempty"`
+	LastSequenceId *int64 `protobuf:"varint,3,opt,name=last_sequence_id,json=lastSequenceId,def=-1" json:"last_sequence_id,omitempty"`
+	SchemaVersion  []byte `protobuf:"bytes,4,opt,name=schema_version,json=schemaVersion" json:"schema_version,omitempty"`
+	// The topic epoch assigned by the broker. This field will only be set if we
+	// were requiring exclusive access when creating the producer.
+	TopicEpoch *uint64 `protobuf:"varint,5,opt,name=topic_epoch,json=topicEpoch" json:"topic_epoch,omitempty"`
+	// If producer is not "ready", the client will avoid to timeout the request
+	// for creating the producer. Instead it will wait indefinitely until it gets
+	// a subsequent  `CommandProducerSuccess` with `producer_ready==true`.
+	ProducerReady        *bool    `protobuf:"varint,6,opt,name=producer_ready,json=producerReady,def=1" json:"producer_ready,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -2726,16 +4178,17 @@ func (m *CommandProducerSuccess) Reset()         { *m = CommandProducerSuccess{}
 func (m *CommandProducerSuccess) String() string { return proto.CompactTextString(m) }
 func (*CommandProducerSuccess) ProtoMessage()    {}
 func (*CommandProducerSuccess) Descriptor() ([]byte, []int) {
-	return fileDescriptor_PulsarApi_8cdc98e965f026ee, []int{29}
+	return fileDescriptor_39529ba7ad9caeb8, []int{38}
 }
+
 func (m *CommandProducerSuccess) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_CommandProducerSuccess.Unmarshal(m, b)
 }
 func (m *CommandProducerSuccess) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	return xxx_messageInfo_CommandProducerSuccess.Marshal(b, m, deterministic)
 }
-func (dst *CommandProducerSuccess) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_CommandProducerSuccess.Merge(dst, src)
+func (m *CommandProducerSuccess) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CommandProducerSuccess.Merge(m, src)
 }
 func (m *CommandProducerSuccess) XXX_Size() int {
 	return xxx_messageInfo_CommandProducerSuccess.Size(m)
@@ -2747,6 +4200,7 @@ func (m *CommandProducerSuccess) XXX_DiscardUnknown() {
 var xxx_messageInfo_CommandProducerSuccess proto.InternalMessageInfo
 
 const Default_CommandProducerSuccess_LastSequenceId int64 = -1
+const Default_CommandProducerSuccess_ProducerReady bool = true
 
 func (m *CommandProducerSuccess) GetRequestId() uint64 {
 	if m != nil && m.RequestId != nil {
@@ -2776,6 +4230,20 @@ func (m *CommandProducerSuccess) GetSchemaVersion() []byte {
 	return nil
 }
 
+func (m *CommandProducerSuccess) GetTopicEpoch() uint64 {
+	if m != nil && m.TopicEpoch != nil {
+		return *m.TopicEpoch
+	}
+	return 0
+}
+
+func (m *CommandProducerSuccess) GetProducerReady() bool {
+	if m != nil && m.ProducerReady != nil {
+		return *m.ProducerReady
+	}
+	return Default_CommandProducerSuccess_ProducerReady
+}
+
 type CommandError struct {
 	RequestId            *uint64      `protobuf:"varint,1,req,name=request_id,json=requestId" json:"request_id,omitempty"`
 	Error                *ServerError `protobuf:"varint,2,req,name=error,enum=pulsar.proto.ServerError" json:"error,omitempty"`
@@ -2789,16 +4257,17 @@ func (m *CommandError) Reset()         { *m = CommandError{} }
 func (m *CommandError) String() string { return proto.CompactTextString(m) }
 func (*CommandError) ProtoMessage()    {}
 func (*CommandError) Descriptor() ([]byte, []int) {
-	return fileDescriptor_PulsarApi_8cdc98e965f026ee, []int{30}
+	return fileDescriptor_39529ba7ad9caeb8, []int{39}
 }
+
 func (m *CommandError) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_CommandError.Unmarshal(m, b)
 }
 func (m *CommandError) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	return xxx_messageInfo_CommandError.Marshal(b, m, deterministic)
 }
-func (dst *CommandError) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_CommandError.Merge(dst, src)
+func (m *CommandError) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CommandError.Merge(m, src)
 }
 func (m *CommandError) XXX_Size() int {
 	return xxx_messageInfo_CommandError.Size(m)
@@ -2843,16 +4312,17 @@ func (m *CommandPing) Reset()         { *m = CommandPing{} }
 func (m *CommandPing) String() string { return proto.CompactTextString(m) }
 func (*CommandPing) ProtoMessage()    {}
 func (*CommandPing) Descriptor() ([]byte, []int) {
-	return fileDescriptor_PulsarApi_8cdc98e965f026ee, []int{31}
+	return fileDescriptor_39529ba7ad9caeb8, []int{40}
 }
+
 func (m *CommandPing) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_CommandPing.Unmarshal(m, b)
 }
 func (m *CommandPing) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	return xxx_messageInfo_CommandPing.Marshal(b, m, deterministic)
 }
-func (dst *CommandPing) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_CommandPing.Merge(dst, src)
+func (m *CommandPing) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CommandPing.Merge(m, src)
 }
 func (m *CommandPing) XXX_Size() int {
 	return xxx_messageInfo_CommandPing.Size(m)
@@ -2873,16 +4343,17 @@ func (m *CommandPong) Reset()         { *m = CommandPong{} }
 func (m *CommandPong) String() string { return proto.CompactTextString(m) }
 func (*CommandPong) ProtoMessage()    {}
 func (*CommandPong) Descriptor() ([]byte, []int) {
-	return fileDescriptor_PulsarApi_8cdc98e965f026ee, []int{32}
+	return fileDescriptor_39529ba7ad9caeb8, []int{41}
 }
+
 func (m *CommandPong) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_CommandPong.Unmarshal(m, b)
 }
 func (m *CommandPong) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	return xxx_messageInfo_CommandPong.Marshal(b, m, deterministic)
 }
-func (dst *CommandPong) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_CommandPong.Merge(dst, src)
+func (m *CommandPong) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CommandPong.Merge(m, src)
 }
 func (m *CommandPong) XXX_Size() int {
 	return xxx_messageInfo_CommandPong.Size(m)
@@ -2907,16 +4378,17 @@ func (m *CommandConsumerStats) Reset()         { *m = CommandConsumerStats{} }
 func (m *CommandConsumerStats) String() string { return proto.CompactTextString(m) }
 func (*CommandConsumerStats) ProtoMessage()    {}
 func (*CommandConsumerStats) Descriptor() ([]byte, []int) {
-	return fileDescriptor_PulsarApi_8cdc98e965f026ee, []int{33}
+	return fileDescriptor_39529ba7ad9caeb8, []int{42}
 }
+
 func (m *CommandConsumerStats) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_CommandConsumerStats.Unmarshal(m, b)
 }
 func (m *CommandConsumerStats) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	return xxx_messageInfo_CommandConsumerStats.Marshal(b, m, deterministic)
 }
-func (dst *CommandConsumerStats) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_CommandConsumerStats.Merge(dst, src)
+func (m *CommandConsumerStats) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CommandConsumerStats.Merge(m, src)
 }
 func (m *CommandConsumerStats) XXX_Size() int {
 	return xxx_messageInfo_CommandConsumerStats.Size(m)
@@ -2945,30 +4417,32 @@ type CommandConsumerStatsResponse struct {
 	RequestId    *uint64      `protobuf:"varint,1,req,name=request_id,json=requestId" json:"request_id,omitempty"`
 	ErrorCode    *ServerError `protobuf:"varint,2,opt,name=error_code,json=errorCode,enum=pulsar.proto.ServerError" json:"error_code,omitempty"`
 	ErrorMessage *string      `protobuf:"bytes,3,opt,name=error_message,json=errorMessage" json:"error_message,omitempty"`
-	// / Total rate of messages delivered to the consumer. msg/s
+	/// Total rate of messages delivered to the consumer. msg/s
 	MsgRateOut *float64 `protobuf:"fixed64,4,opt,name=msgRateOut" json:"msgRateOut,omitempty"`
-	// / Total throughput delivered to the consumer. bytes/s
+	/// Total throughput delivered to the consumer. bytes/s
 	MsgThroughputOut *float64 `protobuf:"fixed64,5,opt,name=msgThroughputOut" json:"msgThroughputOut,omitempty"`
-	// / Total rate of messages redelivered by this consumer. msg/s
+	/// Total rate of messages redelivered by this consumer. msg/s
 	MsgRateRedeliver *float64 `protobuf:"fixed64,6,opt,name=msgRateRedeliver" json:"msgRateRedeliver,omitempty"`
-	// / Name of the consumer
+	/// Name of the consumer
 	ConsumerName *string `protobuf:"bytes,7,opt,name=consumerName" json:"consumerName,omitempty"`
-	// / Number of available message permits for the consumer
+	/// Number of available message permits for the consumer
 	AvailablePermits *uint64 `protobuf:"varint,8,opt,name=availablePermits" json:"availablePermits,omitempty"`
-	// / Number of unacknowledged messages for the consumer
+	/// Number of unacknowledged messages for the consumer
 	UnackedMessages *uint64 `protobuf:"varint,9,opt,name=unackedMessages" json:"unackedMessages,omitempty"`
-	// / Flag to verify if consumer is blocked due to reaching threshold of unacked messages
+	/// Flag to verify if consumer is blocked due to reaching threshold of unacked messages
 	BlockedConsumerOnUnackedMsgs *bool `protobuf:"varint,10,opt,name=blockedConsumerOnUnackedMsgs" json:"blockedConsumerOnUnackedMsgs,omitempty"`
-	// / Address of this consumer
+	/// Address of this consumer
 	Address *string `protobuf:"bytes,11,opt,name=address" json:"address,omitempty"`
-	// / Timestamp of connection
+	/// Timestamp of connection
 	ConnectedSince *string `protobuf:"bytes,12,opt,name=connectedSince" json:"connectedSince,omitempty"`
-	// / Whether this subscription is Exclusive or Shared or Failover
+	/// Whether this subscription is Exclusive or Shared or Failover
 	Type *string `protobuf:"bytes,13,opt,name=type" json:"type,omitempty"`
-	// / Total rate of messages expired on this subscription. msg/s
+	/// Total rate of messages expired on this subscription. msg/s
 	MsgRateExpired *float64 `protobuf:"fixed64,14,opt,name=msgRateExpired" json:"msgRateExpired,omitempty"`
-	// / Number of messages in the subscription backlog
-	MsgBacklog           *uint64  `protobuf:"varint,15,opt,name=msgBacklog" json:"msgBacklog,omitempty"`
+	/// Number of messages in the subscription backlog
+	MsgBacklog *uint64 `protobuf:"varint,15,opt,name=msgBacklog" json:"msgBacklog,omitempty"`
+	/// Total rate of messages ack. msg/s
+	MessageAckRate       *float64 `protobuf:"fixed64,16,opt,name=messageAckRate" json:"messageAckRate,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -2978,16 +4452,17 @@ func (m *CommandConsumerStatsResponse) Reset()         { *m = CommandConsumerSta
 func (m *CommandConsumerStatsResponse) String() string { return proto.CompactTextString(m) }
 func (*CommandConsumerStatsResponse) ProtoMessage()    {}
 func (*CommandConsumerStatsResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_PulsarApi_8cdc98e965f026ee, []int{34}
+	return fileDescriptor_39529ba7ad9caeb8, []int{43}
 }
+
 func (m *CommandConsumerStatsResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_CommandConsumerStatsResponse.Unmarshal(m, b)
 }
 func (m *CommandConsumerStatsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	return xxx_messageInfo_CommandConsumerStatsResponse.Marshal(b, m, deterministic)
 }
-func (dst *CommandConsumerStatsResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_CommandConsumerStatsResponse.Merge(dst, src)
+func (m *CommandConsumerStatsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CommandConsumerStatsResponse.Merge(m, src)
 }
 func (m *CommandConsumerStatsResponse) XXX_Size() int {
 	return xxx_messageInfo_CommandConsumerStatsResponse.Size(m)
@@ -3103,6 +4578,13 @@ func (m *CommandConsumerStatsResponse) GetMsgBacklog() uint64 {
 	return 0
 }
 
+func (m *CommandConsumerStatsResponse) GetMessageAckRate() float64 {
+	if m != nil && m.MessageAckRate != nil {
+		return *m.MessageAckRate
+	}
+	return 0
+}
+
 type CommandGetLastMessageId struct {
 	ConsumerId           *uint64  `protobuf:"varint,1,req,name=consumer_id,json=consumerId" json:"consumer_id,omitempty"`
 	RequestId            *uint64  `protobuf:"varint,2,req,name=request_id,json=requestId" json:"request_id,omitempty"`
@@ -3115,16 +4597,17 @@ func (m *CommandGetLastMessageId) Reset()         { *m = CommandGetLastMessageId
 func (m *CommandGetLastMessageId) String() string { return proto.CompactTextString(m) }
 func (*CommandGetLastMessageId) ProtoMessage()    {}
 func (*CommandGetLastMessageId) Descriptor() ([]byte, []int) {
-	return fileDescriptor_PulsarApi_8cdc98e965f026ee, []int{35}
+	return fileDescriptor_39529ba7ad9caeb8, []int{44}
 }
+
 func (m *CommandGetLastMessageId) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_CommandGetLastMessageId.Unmarshal(m, b)
 }
 func (m *CommandGetLastMessageId) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	return xxx_messageInfo_CommandGetLastMessageId.Marshal(b, m, deterministic)
 }
-func (dst *CommandGetLastMessageId) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_CommandGetLastMessageId.Merge(dst, src)
+func (m *CommandGetLastMessageId) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CommandGetLastMessageId.Merge(m, src)
 }
 func (m *CommandGetLastMessageId) XXX_Size() int {
 	return xxx_messageInfo_CommandGetLastMessageId.Size(m)
@@ -3150,27 +4633,29 @@ func (m *CommandGetLastMessageId) GetRequestId() uint64 {
 }
 
 type CommandGetLastMessageIdResponse struct {
-	LastMessageId        *MessageIdData `protobuf:"bytes,1,req,name=last_message_id,json=lastMessageId" json:"last_message_id,omitempty"`
-	RequestId            *uint64        `protobuf:"varint,2,req,name=request_id,json=requestId" json:"request_id,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
-	XXX_unrecognized     []byte         `json:"-"`
-	XXX_sizecache        int32          `json:"-"`
+	LastMessageId              *MessageIdData `protobuf:"bytes,1,req,name=last_message_id,json=lastMessageId" json:"last_message_id,omitempty"`
+	RequestId                  *uint64        `protobuf:"varint,2,req,name=request_id,json=requestId" json:"request_id,omitempty"`
+	ConsumerMarkDeletePosition *MessageIdData `protobuf:"bytes,3,opt,name=consumer_mark_delete_position,json=consumerMarkDeletePosition" json:"consumer_mark_delete_position,omitempty"`
+	XXX_NoUnkeyedLiteral       struct{}       `json:"-"`
+	XXX_unrecognized           []byte         `json:"-"`
+	XXX_sizecache              int32          `json:"-"`
 }
 
 func (m *CommandGetLastMessageIdResponse) Reset()         { *m = CommandGetLastMessageIdResponse{} }
 func (m *CommandGetLastMessageIdResponse) String() string { return proto.CompactTextString(m) }
 func (*CommandGetLastMessageIdResponse) ProtoMessage()    {}
 func (*CommandGetLastMessageIdResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_PulsarApi_8cdc98e965f026ee, []int{36}
+	return fileDescriptor_39529ba7ad9caeb8, []int{45}
 }
+
 func (m *CommandGetLastMessageIdResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_CommandGetLastMessageIdResponse.Unmarshal(m, b)
 }
 func (m *CommandGetLastMessageIdResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	return xxx_messageInfo_CommandGetLastMessageIdResponse.Marshal(b, m, deterministic)
 }
-func (dst *CommandGetLastMessageIdResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_CommandGetLastMessageIdResponse.Merge(dst, src)
+func (m *CommandGetLastMessageIdResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CommandGetLastMessageIdResponse.Merge(m, src)
 }
 func (m *CommandGetLastMessageIdResponse) XXX_Size() int {
 	return xxx_messageInfo_CommandGetLastMessageIdResponse.Size(m)
@@ -3195,10 +4680,19 @@ func (m *CommandGetLastMessageIdResponse) GetRequestId() uint64 {
 	return 0
 }
 
+func (m *CommandGetLastMessageIdResponse) GetConsumerMarkDeletePosition() *MessageIdData {
+	if m != nil {
+		return m.ConsumerMarkDeletePosition
+	}
+	return nil
+}
+
 type CommandGetTopicsOfNamespace struct {
 	RequestId            *uint64                           `protobuf:"varint,1,req,name=request_id,json=requestId" json:"request_id,omitempty"`
 	Namespace            *string                           `protobuf:"bytes,2,req,name=namespace" json:"namespace,omitempty"`
 	Mode                 *CommandGetTopicsOfNamespace_Mode `protobuf:"varint,3,opt,name=mode,enum=pulsar.proto.CommandGetTopicsOfNamespace_Mode,def=0" json:"mode,omitempty"`
+	TopicsPattern        *string                           `protobuf:"bytes,4,opt,name=topics_pattern,json=topicsPattern" json:"topics_pattern,omitempty"`
+	TopicsHash           *string                           `protobuf:"bytes,5,opt,name=topics_hash,json=topicsHash" json:"topics_hash,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}                          `json:"-"`
 	XXX_unrecognized     []byte                            `json:"-"`
 	XXX_sizecache        int32                             `json:"-"`
@@ -3208,16 +4702,17 @@ func (m *CommandGetTopicsOfNamespace) Reset()         { *m = CommandGetTopicsOfN
 func (m *CommandGetTopicsOfNamespace) String() string { return proto.CompactTextString(m) }
 func (*CommandGetTopicsOfNamespace) ProtoMessage()    {}
 func (*CommandGetTopicsOfNamespace) Descriptor() ([]byte, []int) {
-	return fileDescriptor_PulsarApi_8cdc98e965f026ee, []int{37}
+	return fileDescriptor_39529ba7ad9caeb8, []int{46}
 }
+
 func (m *CommandGetTopicsOfNamespace) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_CommandGetTopicsOfNamespace.Unmarshal(m, b)
 }
 func (m *CommandGetTopicsOfNamespace) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	return xxx_messageInfo_CommandGetTopicsOfNamespace.Marshal(b, m, deterministic)
 }
-func (dst *CommandGetTopicsOfNamespace) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_CommandGetTopicsOfNamespace.Merge(dst, src)
+func (m *CommandGetTopicsOfNamespace) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CommandGetTopicsOfNamespace.Merge(m, src)
 }
 func (m *CommandGetTopicsOfNamespace) XXX_Size() int {
 	return xxx_messageInfo_CommandGetTopicsOfNamespace.Size(m)
@@ -3251,9 +4746,29 @@ func (m *CommandGetTopicsOfNamespace) GetMode() CommandGetTopicsOfNamespace_Mode
 	return Default_CommandGetTopicsOfNamespace_Mode
 }
 
+func (m *CommandGetTopicsOfNamespace) GetTopicsPattern() string {
+	if m != nil && m.TopicsPattern != nil {
+		return *m.TopicsPattern
+	}
+	return ""
+}
+
+func (m *CommandGetTopicsOfNamespace) GetTopicsHash() string {
+	if m != nil && m.TopicsHash != nil {
+		return *m.TopicsHash
+	}
+	return ""
+}
+
 type CommandGetTopicsOfNamespaceResponse struct {
-	RequestId            *uint64  `protobuf:"varint,1,req,name=request_id,json=requestId" json:"request_id,omitempty"`
-	Topics               []string `protobuf:"bytes,2,rep,name=topics" json:"topics,omitempty"`
+	RequestId *uint64  `protobuf:"varint,1,req,name=request_id,json=requestId" json:"request_id,omitempty"`
+	Topics    []string `protobuf:"bytes,2,rep,name=topics" json:"topics,omitempty"`
+	// true iff the topic list was filtered by the pattern supplied by the client
+	Filtered *bool `protobuf:"varint,3,opt,name=filtered,def=0" json:"filtered,omitempty"`
+	// hash computed from the names of matching topics
+	TopicsHash *string `protobuf:"bytes,4,opt,name=topics_hash,json=topicsHash" json:"topics_hash,omitempty"`
+	// if false, topics is empty and the list of matching topics has not changed
+	Changed              *bool    `protobuf:"varint,5,opt,name=changed,def=1" json:"changed,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -3263,16 +4778,17 @@ func (m *CommandGetTopicsOfNamespaceResponse) Reset()         { *m = CommandGetT
 func (m *CommandGetTopicsOfNamespaceResponse) String() string { return proto.CompactTextString(m) }
 func (*CommandGetTopicsOfNamespaceResponse) ProtoMessage()    {}
 func (*CommandGetTopicsOfNamespaceResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_PulsarApi_8cdc98e965f026ee, []int{38}
+	return fileDescriptor_39529ba7ad9caeb8, []int{47}
 }
+
 func (m *CommandGetTopicsOfNamespaceResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_CommandGetTopicsOfNamespaceResponse.Unmarshal(m, b)
 }
 func (m *CommandGetTopicsOfNamespaceResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	return xxx_messageInfo_CommandGetTopicsOfNamespaceResponse.Marshal(b, m, deterministic)
 }
-func (dst *CommandGetTopicsOfNamespaceResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_CommandGetTopicsOfNamespaceResponse.Merge(dst, src)
+func (m *CommandGetTopicsOfNamespaceResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CommandGetTopicsOfNamespaceResponse.Merge(m, src)
 }
 func (m *CommandGetTopicsOfNamespaceResponse) XXX_Size() int {
 	return xxx_messageInfo_CommandGetTopicsOfNamespaceResponse.Size(m)
@@ -3283,6 +4799,9 @@ func (m *CommandGetTopicsOfNamespaceResponse) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_CommandGetTopicsOfNamespaceResponse proto.InternalMessageInfo
 
+const Default_CommandGetTopicsOfNamespaceResponse_Filtered bool = false
+const Default_CommandGetTopicsOfNamespaceResponse_Changed bool = true
+
 func (m *CommandGetTopicsOfNamespaceResponse) GetRequestId() uint64 {
 	if m != nil && m.RequestId != nil {
 		return *m.RequestId
@@ -3297,128 +4816,1529 @@ func (m *CommandGetTopicsOfNamespaceResponse) GetTopics() []string {
 	return nil
 }
 
-type CommandGetSchema struct {
-	RequestId            *uint64  `protobuf:"varint,1,req,name=request_id,json=requestId" json:"request_id,omitempty"`
-	Topic                *string  `protobuf:"bytes,2,req,name=topic" json:"topic,omitempty"`
-	SchemaVersion        []byte   `protobuf:"bytes,3,opt,name=schema_version,json=schemaVersion" json:"schema_version,omitempty"`
+func (m *CommandGetTopicsOfNamespaceResponse) GetFiltered() bool {
+	if m != nil && m.Filtered != nil {
+		return *m.Filtered
+	}
+	return Default_CommandGetTopicsOfNamespaceResponse_Filtered
+}
+
+func (m *CommandGetTopicsOfNamespaceResponse) GetTopicsHash() string {
+	if m != nil && m.TopicsHash != nil {
+		return *m.TopicsHash
+	}
+	return ""
+}
+
+func (m *CommandGetTopicsOfNamespaceResponse) GetChanged() bool {
+	if m != nil && m.Changed != nil {
+		return *m.Changed
+	}
+	return Default_CommandGetTopicsOfNamespaceResponse_Changed
+}
+
+type CommandWatchTopicList struct {
+	RequestId     *uint64 `protobuf:"varint,1,req,name=request_id,json=requestId" json:"request_id,omitempty"`
+	WatcherId     *uint64 `protobuf:"varint,2,req,name=watcher_id,json=watcherId" json:"watcher_id,omitempty"`
+	Namespace     *string `protobuf:"bytes,3,req,name=namespace" json:"namespace,omitempty"`
+	TopicsPattern *string `protobuf:"bytes,4,req,name=topics_pattern,json=topicsPattern" json:"topics_pattern,omitempty"`
+	// Only present when the client reconnects:
+	TopicsHash           *string  `protobuf:"bytes,5,opt,name=topics_hash,json=topicsHash" json:"topics_hash,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *CommandGetSchema) Reset()         { *m = CommandGetSchema{} }
-func (m *CommandGetSchema) String() string { return proto.CompactTextString(m) }
-func (*CommandGetSchema) ProtoMessage()    {}
-func (*CommandGetSchema) Descriptor() ([]byte, []int) {
-	return fileDescriptor_PulsarApi_8cdc98e965f026ee, []int{39}
+func (m *CommandWatchTopicList) Reset()         { *m = CommandWatchTopicList{} }
+func (m *CommandWatchTopicList) String() string { return proto.CompactTextString(m) }
+func (*CommandWatchTopicList) ProtoMessage()    {}
+func (*CommandWatchTopicList) Descriptor() ([]byte, []int) {
+	return fileDescriptor_39529ba7ad9caeb8, []int{48}
 }
-func (m *CommandGetSchema) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_CommandGetSchema.Unmarshal(m, b)
+
+func (m *CommandWatchTopicList) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CommandWatchTopicList.Unmarshal(m, b)
 }
-func (m *CommandGetSchema) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *CommandWatchTopicList) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CommandWatchTopicList.Marshal(b, m, deterministic)
+}
+func (m *CommandWatchTopicList) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CommandWatchTopicList.Merge(m, src)
+}
+func (m *CommandWatchTopicList) XXX_Size() int {
+	return xxx_messageInfo_CommandWatchTopicList.Size(m)
+}
+func (m *CommandWatchTopicList) XXX_DiscardUnknown() {
+	xxx_messageInfo_CommandWatchTopicList.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CommandWatchTopicList proto.InternalMessageInfo
+
+func (m *CommandWatchTopicList) GetRequestId() uint64 {
+	if m != nil && m.RequestId != nil {
+		return *m.RequestId
+	}
+	return 0
+}
+
+func (m *CommandWatchTopicList) GetWatcherId() uint64 {
+	if m != nil && m.WatcherId != nil {
+		return *m.WatcherId
+	}
+	return 0
+}
+
+func (m *CommandWatchTopicList) GetNamespace() string {
+	if m != nil && m.Namespace != nil {
+		return *m.Namespace
+	}
+	return ""
+}
+
+func (m *CommandWatchTopicList) GetTopicsPattern() string {
+	if m != nil && m.TopicsPattern != nil {
+		return *m.TopicsPattern
+	}
+	return ""
+}
+
+func (m *CommandWatchTopicList) GetTopicsHash() string {
+	if m != nil && m.TopicsHash != nil {
+		return *m.TopicsHash
+	}
+	return ""
+}
+
+type CommandWatchTopicListSuccess struct {
+	RequestId            *uint64  `protobuf:"varint,1,req,name=request_id,json=requestId" json:"request_id,omitempty"`
+	WatcherId            *uint64  `protobuf:"varint,2,req,name=watcher_id,json=watcherId" json:"watcher_id,omitempty"`
+	Topic                []string `protobuf:"bytes,3,rep,name=topic" json:"topic,omitempty"`
+	TopicsHash           *string  `protobuf:"bytes,4,req,name=topics_hash,json=topicsHash" json:"topics_hash,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CommandWatchTopicListSuccess) Reset()         { *m = CommandWatchTopicListSuccess{} }
+func (m *CommandWatchTopicListSuccess) String() string { return proto.CompactTextString(m) }
+func (*CommandWatchTopicListSuccess) ProtoMessage()    {}
+func (*CommandWatchTopicListSuccess) Descriptor() ([]byte, []int) {
+	return fileDescriptor_39529ba7ad9caeb8, []int{49}
+}
+
+func (m *CommandWatchTopicListSuccess) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CommandWatchTopicListSuccess.Unmarshal(m, b)
+}
+func (m *CommandWatchTopicListSuccess) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CommandWatchTopicListSuccess.Marshal(b, m, deterministic)
+}
+func (m *CommandWatchTopicListSuccess) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CommandWatchTopicListSuccess.Merge(m, src)
+}
+func (m *CommandWatchTopicListSuccess) XXX_Size() int {
+	return xxx_messageInfo_CommandWatchTopicListSuccess.Size(m)
+}
+func (m *CommandWatchTopicListSuccess) XXX_DiscardUnknown() {
+	xxx_messageInfo_CommandWatchTopicListSuccess.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CommandWatchTopicListSuccess proto.InternalMessageInfo
+
+func (m *CommandWatchTopicListSuccess) GetRequestId() uint64 {
+	if m != nil && m.RequestId != nil {
+		return *m.RequestId
+	}
+	return 0
+}
+
+func (m *CommandWatchTopicListSuccess) GetWatcherId() uint64 {
+	if m != nil && m.WatcherId != nil {
+		return *m.WatcherId
+	}
+	return 0
+}
+
+func (m *CommandWatchTopicListSuccess) GetTopic() []string {
+	if m != nil {
+		return m.Topic
+	}
+	return nil
+}
+
+func (m *CommandWatchTopicListSuccess) GetTopicsHash() string {
+	if m != nil && m.TopicsHash != nil {
+		return *m.TopicsHash
+	}
+	return ""
+}
+
+type CommandWatchTopicUpdate struct {
+	WatcherId            *uint64  `protobuf:"varint,1,req,name=watcher_id,json=watcherId" json:"watcher_id,omitempty"`
+	NewTopics            []string `protobuf:"bytes,2,rep,name=new_topics,json=newTopics" json:"new_topics,omitempty"`
+	DeletedTopics        []string `protobuf:"bytes,3,rep,name=deleted_topics,json=deletedTopics" json:"deleted_topics,omitempty"`
+	TopicsHash           *string  `protobuf:"bytes,4,req,name=topics_hash,json=topicsHash" json:"topics_hash,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CommandWatchTopicUpdate) Reset()         { *m = CommandWatchTopicUpdate{} }
+func (m *CommandWatchTopicUpdate) String() string { return proto.CompactTextString(m) }
+func (*CommandWatchTopicUpdate) ProtoMessage()    {}
+func (*CommandWatchTopicUpdate) Descriptor() ([]byte, []int) {
+	return fileDescriptor_39529ba7ad9caeb8, []int{50}
+}
+
+func (m *CommandWatchTopicUpdate) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CommandWatchTopicUpdate.Unmarshal(m, b)
+}
+func (m *CommandWatchTopicUpdate) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CommandWatchTopicUpdate.Marshal(b, m, deterministic)
+}
+func (m *CommandWatchTopicUpdate) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CommandWatchTopicUpdate.Merge(m, src)
+}
+func (m *CommandWatchTopicUpdate) XXX_Size() int {
+	return xxx_messageInfo_CommandWatchTopicUpdate.Size(m)
+}
+func (m *CommandWatchTopicUpdate) XXX_DiscardUnknown() {
+	xxx_messageInfo_CommandWatchTopicUpdate.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CommandWatchTopicUpdate proto.InternalMessageInfo
+
+func (m *CommandWatchTopicUpdate) GetWatcherId() uint64 {
+	if m != nil && m.WatcherId != nil {
+		return *m.WatcherId
+	}
+	return 0
+}
+
+func (m *CommandWatchTopicUpdate) GetNewTopics() []string {
+	if m != nil {
+		return m.NewTopics
+	}
+	return nil
+}
+
+func (m *CommandWatchTopicUpdate) GetDeletedTopics() []string {
+	if m != nil {
+		return m.DeletedTopics
+	}
+	return nil
+}
+
+func (m *CommandWatchTopicUpdate) GetTopicsHash() string {
+	if m != nil && m.TopicsHash != nil {
+		return *m.TopicsHash
+	}
+	return ""
+}
+
+type CommandWatchTopicListClose struct {
+	RequestId            *uint64  `protobuf:"varint,1,req,name=request_id,json=requestId" json:"request_id,omitempty"`
+	WatcherId            *uint64  `protobuf:"varint,2,req,name=watcher_id,json=watcherId" json:"watcher_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CommandWatchTopicListClose) Reset()         { *m = CommandWatchTopicListClose{} }
+func (m *CommandWatchTopicListClose) String() string { return proto.CompactTextString(m) }
+func (*CommandWatchTopicListClose) ProtoMessage()    {}
+func (*CommandWatchTopicListClose) Descriptor() ([]byte, []int) {
+	return fileDescriptor_39529ba7ad9caeb8, []int{51}
+}
+
+func (m *CommandWatchTopicListClose) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CommandWatchTopicListClose.Unmarshal(m, b)
+}
+func (m *CommandWatchTopicListClose) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CommandWatchTopicListClose.Marshal(b, m, deterministic)
+}
+func (m *CommandWatchTopicListClose) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CommandWatchTopicListClose.Merge(m, src)
+}
+func (m *CommandWatchTopicListClose) XXX_Size() int {
+	return xxx_messageInfo_CommandWatchTopicListClose.Size(m)
+}
+func (m *CommandWatchTopicListClose) XXX_DiscardUnknown() {
+	xxx_messageInfo_CommandWatchTopicListClose.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CommandWatchTopicListClose proto.InternalMessageInfo
+
+func (m *CommandWatchTopicListClose) GetRequestId() uint64 {
+	if m != nil && m.RequestId != nil {
+		return *m.RequestId
+	}
+	return 0
+}
+
+func (m *CommandWatchTopicListClose) GetWatcherId() uint64 {
+	if m != nil && m.WatcherId != nil {
+		return *m.WatcherId
+	}
+	return 0
+}
+
+type CommandGetSchema struct {
+	RequestId            *uint64  `protobuf:"varint,1,req,name=request_id,json=requestId" json:"request_id,omitempty"`
+	Topic                *string  `protobuf:"bytes,2,req,name=topic" json:"topic,omitempty"`
+	SchemaVersion        []byte   `protobuf:"bytes,3,opt,name=schema_version,json=schemaVersion" json:"schema_version,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CommandGetSchema) Reset()         { *m = CommandGetSchema{} }
+func (m *CommandGetSchema) String() string { return proto.CompactTextString(m) }
+func (*CommandGetSchema) ProtoMessage()    {}
+func (*CommandGetSchema) Descriptor() ([]byte, []int) {
+	return fileDescriptor_39529ba7ad9caeb8, []int{52}
+}
+
+func (m *CommandGetSchema) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CommandGetSchema.Unmarshal(m, b)
+}
+func (m *CommandGetSchema) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	return xxx_messageInfo_CommandGetSchema.Marshal(b, m, deterministic)
 }
-func (dst *CommandGetSchema) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_CommandGetSchema.Merge(dst, src)
+func (m *CommandGetSchema) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CommandGetSchema.Merge(m, src)
+}
+func (m *CommandGetSchema) XXX_Size() int {
+	return xxx_messageInfo_CommandGetSchema.Size(m)
+}
+func (m *CommandGetSchema) XXX_DiscardUnknown() {
+	xxx_messageInfo_CommandGetSchema.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CommandGetSchema proto.InternalMessageInfo
+
+func (m *CommandGetSchema) GetRequestId() uint64 {
+	if m != nil && m.RequestId != nil {
+		return *m.RequestId
+	}
+	return 0
+}
+
+func (m *CommandGetSchema) GetTopic() string {
+	if m != nil && m.Topic != nil {
+		return *m.Topic
+	}
+	return ""
+}
+
+func (m *CommandGetSchema) GetSchemaVersion() []byte {
+	if m != nil {
+		return m.SchemaVersion
+	}
+	return nil
+}
+
+type CommandGetSchemaResponse struct {
+	RequestId            *uint64      `protobuf:"varint,1,req,name=request_id,json=requestId" json:"request_id,omitempty"`
+	ErrorCode            *ServerError `protobuf:"varint,2,opt,name=error_code,json=errorCode,enum=pulsar.proto.ServerError" json:"error_code,omitempty"`
+	ErrorMessage         *string      `protobuf:"bytes,3,opt,name=error_message,json=errorMessage" json:"error_message,omitempty"`
+	Schema               *Schema      `protobuf:"bytes,4,opt,name=schema" json:"schema,omitempty"`
+	SchemaVersion        []byte       `protobuf:"bytes,5,opt,name=schema_version,json=schemaVersion" json:"schema_version,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
+}
+
+func (m *CommandGetSchemaResponse) Reset()         { *m = CommandGetSchemaResponse{} }
+func (m *CommandGetSchemaResponse) String() string { return proto.CompactTextString(m) }
+func (*CommandGetSchemaResponse) ProtoMessage()    {}
+func (*CommandGetSchemaResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_39529ba7ad9caeb8, []int{53}
+}
+
+func (m *CommandGetSchemaResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CommandGetSchemaResponse.Unmarshal(m, b)
+}
+func (m *CommandGetSchemaResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CommandGetSchemaResponse.Marshal(b, m, deterministic)
+}
+func (m *CommandGetSchemaResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CommandGetSchemaResponse.Merge(m, src)
+}
+func (m *CommandGetSchemaResponse) XXX_Size() int {
+	return xxx_messageInfo_CommandGetSchemaResponse.Size(m)
+}
+func (m *CommandGetSchemaResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_CommandGetSchemaResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CommandGetSchemaResponse proto.InternalMessageInfo
+
+func (m *CommandGetSchemaResponse) GetRequestId() uint64 {
+	if m != nil && m.RequestId != nil {
+		return *m.RequestId
+	}
+	return 0
+}
+
+func (m *CommandGetSchemaResponse) GetErrorCode() ServerError {
+	if m != nil && m.ErrorCode != nil {
+		return *m.ErrorCode
+	}
+	return ServerError_UnknownError
+}
+
+func (m *CommandGetSchemaResponse) GetErrorMessage() string {
+	if m != nil && m.ErrorMessage != nil {
+		return *m.ErrorMessage
+	}
+	return ""
+}
+
+func (m *CommandGetSchemaResponse) GetSchema() *Schema {
+	if m != nil {
+		return m.Schema
+	}
+	return nil
+}
+
+func (m *CommandGetSchemaResponse) GetSchemaVersion() []byte {
+	if m != nil {
+		return m.SchemaVersion
+	}
+	return nil
+}
+
+type CommandGetOrCreateSchema struct {
+	RequestId            *uint64  `protobuf:"varint,1,req,name=request_id,json=requestId" json:"request_id,omitempty"`
+	Topic                *string  `protobuf:"bytes,2,req,name=topic" json:"topic,omitempty"`
+	Schema               *Schema  `protobuf:"bytes,3,req,name=schema" json:"schema,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CommandGetOrCreateSchema) Reset()         { *m = CommandGetOrCreateSchema{} }
+func (m *CommandGetOrCreateSchema) String() string { return proto.CompactTextString(m) }
+func (*CommandGetOrCreateSchema) ProtoMessage()    {}
+func (*CommandGetOrCreateSchema) Descriptor() ([]byte, []int) {
+	return fileDescriptor_39529ba7ad9caeb8, []int{54}
+}
+
+func (m *CommandGetOrCreateSchema) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CommandGetOrCreateSchema.Unmarshal(m, b)
+}
+func (m *CommandGetOrCreateSchema) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CommandGetOrCreateSchema.Marshal(b, m, deterministic)
+}
+func (m *CommandGetOrCreateSchema) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CommandGetOrCreateSchema.Merge(m, src)
+}
+func (m *CommandGetOrCreateSchema) XXX_Size() int {
+	return xxx_messageInfo_CommandGetOrCreateSchema.Size(m)
+}
+func (m *CommandGetOrCreateSchema) XXX_DiscardUnknown() {
+	xxx_messageInfo_CommandGetOrCreateSchema.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CommandGetOrCreateSchema proto.InternalMessageInfo
+
+func (m *CommandGetOrCreateSchema) GetRequestId() uint64 {
+	if m != nil && m.RequestId != nil {
+		return *m.RequestId
+	}
+	return 0
+}
+
+func (m *CommandGetOrCreateSchema) GetTopic() string {
+	if m != nil && m.Topic != nil {
+		return *m.Topic
+	}
+	return ""
+}
+
+func (m *CommandGetOrCreateSchema) GetSchema() *Schema {
+	if m != nil {
+		return m.Schema
+	}
+	return nil
+}
+
+type CommandGetOrCreateSchemaResponse struct {
+	RequestId            *uint64      `protobuf:"varint,1,req,name=request_id,json=requestId" json:"request_id,omitempty"`
+	ErrorCode            *ServerError `protobuf:"varint,2,opt,name=error_code,json=errorCode,enum=pulsar.proto.ServerError" json:"error_code,omitempty"`
+	ErrorMessage         *string      `protobuf:"bytes,3,opt,name=error_message,json=errorMessage" json:"error_message,omitempty"`
+	SchemaVersion        []byte       `protobuf:"bytes,4,opt,name=schema_version,json=schemaVersion" json:"schema_version,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
+}
+
+func (m *CommandGetOrCreateSchemaResponse) Reset()         { *m = CommandGetOrCreateSchemaResponse{} }
+func (m *CommandGetOrCreateSchemaResponse) String() string { return proto.CompactTextString(m) }
+func (*CommandGetOrCreateSchemaResponse) ProtoMessage()    {}
+func (*CommandGetOrCreateSchemaResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_39529ba7ad9caeb8, []int{55}
+}
+
+func (m *CommandGetOrCreateSchemaResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CommandGetOrCreateSchemaResponse.Unmarshal(m, b)
+}
+func (m *CommandGetOrCreateSchemaResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CommandGetOrCreateSchemaResponse.Marshal(b, m, deterministic)
+}
+func (m *CommandGetOrCreateSchemaResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CommandGetOrCreateSchemaResponse.Merge(m, src)
+}
+func (m *CommandGetOrCreateSchemaResponse) XXX_Size() int {
+	return xxx_messageInfo_CommandGetOrCreateSchemaResponse.Size(m)
+}
+func (m *CommandGetOrCreateSchemaResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_CommandGetOrCreateSchemaResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CommandGetOrCreateSchemaResponse proto.InternalMessageInfo
+
+func (m *CommandGetOrCreateSchemaResponse) GetRequestId() uint64 {
+	if m != nil && m.RequestId != nil {
+		return *m.RequestId
+	}
+	return 0
+}
+
+func (m *CommandGetOrCreateSchemaResponse) GetErrorCode() ServerError {
+	if m != nil && m.ErrorCode != nil {
+		return *m.ErrorCode
+	}
+	return ServerError_UnknownError
+}
+
+func (m *CommandGetOrCreateSchemaResponse) GetErrorMessage() string {
+	if m != nil && m.ErrorMessage != nil {
+		return *m.ErrorMessage
+	}
+	return ""
+}
+
+func (m *CommandGetOrCreateSchemaResponse) GetSchemaVersion() []byte {
+	if m != nil {
+		return m.SchemaVersion
+	}
+	return nil
+}
+
+type CommandTcClientConnectRequest struct {
+	RequestId            *uint64  `protobuf:"varint,1,req,name=request_id,json=requestId" json:"request_id,omitempty"`
+	TcId                 *uint64  `protobuf:"varint,2,req,name=tc_id,json=tcId,def=0" json:"tc_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CommandTcClientConnectRequest) Reset()         { *m = CommandTcClientConnectRequest{} }
+func (m *CommandTcClientConnectRequest) String() string { return proto.CompactTextString(m) }
+func (*CommandTcClientConnectRequest) ProtoMessage()    {}
+func (*CommandTcClientConnectRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_39529ba7ad9caeb8, []int{56}
+}
+
+func (m *CommandTcClientConnectRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CommandTcClientConnectRequest.Unmarshal(m, b)
+}
+func (m *CommandTcClientConnectRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CommandTcClientConnectRequest.Marshal(b, m, deterministic)
+}
+func (m *CommandTcClientConnectRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CommandTcClientConnectRequest.Merge(m, src)
+}
+func (m *CommandTcClientConnectRequest) XXX_Size() int {
+	return xxx_messageInfo_CommandTcClientConnectRequest.Size(m)
+}
+func (m *CommandTcClientConnectRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CommandTcClientConnectRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CommandTcClientConnectRequest proto.InternalMessageInfo
+
+const Default_CommandTcClientConnectRequest_TcId uint64 = 0
+
+func (m *CommandTcClientConnectRequest) GetRequestId() uint64 {
+	if m != nil && m.RequestId != nil {
+		return *m.RequestId
+	}
+	return 0
+}
+
+func (m *CommandTcClientConnectRequest) GetTcId() uint64 {
+	if m != nil && m.TcId != nil {
+		return *m.TcId
+	}
+	return Default_CommandTcClientConnectRequest_TcId
+}
+
+type CommandTcClientConnectResponse struct {
+	RequestId            *uint64      `protobuf:"varint,1,req,name=request_id,json=requestId" json:"request_id,omitempty"`
+	Error                *ServerError `protobuf:"varint,2,opt,name=error,enum=pulsar.proto.ServerError" json:"error,omitempty"`
+	Message              *string      `protobuf:"bytes,3,opt,name=message" json:"message,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
+}
+
+func (m *CommandTcClientConnectResponse) Reset()         { *m = CommandTcClientConnectResponse{} }
+func (m *CommandTcClientConnectResponse) String() string { return proto.CompactTextString(m) }
+func (*CommandTcClientConnectResponse) ProtoMessage()    {}
+func (*CommandTcClientConnectResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_39529ba7ad9caeb8, []int{57}
+}
+
+func (m *CommandTcClientConnectResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CommandTcClientConnectResponse.Unmarshal(m, b)
+}
+func (m *CommandTcClientConnectResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CommandTcClientConnectResponse.Marshal(b, m, deterministic)
+}
+func (m *CommandTcClientConnectResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CommandTcClientConnectResponse.Merge(m, src)
+}
+func (m *CommandTcClientConnectResponse) XXX_Size() int {
+	return xxx_messageInfo_CommandTcClientConnectResponse.Size(m)
+}
+func (m *CommandTcClientConnectResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_CommandTcClientConnectResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CommandTcClientConnectResponse proto.InternalMessageInfo
+
+func (m *CommandTcClientConnectResponse) GetRequestId() uint64 {
+	if m != nil && m.RequestId != nil {
+		return *m.RequestId
+	}
+	return 0
+}
+
+func (m *CommandTcClientConnectResponse) GetError() ServerError {
+	if m != nil && m.Error != nil {
+		return *m.Error
+	}
+	return ServerError_UnknownError
+}
+
+func (m *CommandTcClientConnectResponse) GetMessage() string {
+	if m != nil && m.Message != nil {
+		return *m.Message
+	}
+	return ""
+}
+
+type CommandNewTxn struct {
+	RequestId            *uint64  `protobuf:"varint,1,req,name=request_id,json=requestId" json:"request_id,omitempty"`
+	TxnTtlSeconds        *uint64  `protobuf:"varint,2,opt,name=txn_ttl_seconds,json=txnTtlSeconds,def=0" json:"txn_ttl_seconds,omitempty"`
+	TcId                 *uint64  `protobuf:"varint,3,opt,name=tc_id,json=tcId,def=0" json:"tc_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CommandNewTxn) Reset()         { *m = CommandNewTxn{} }
+func (m *CommandNewTxn) String() string { return proto.CompactTextString(m) }
+func (*CommandNewTxn) ProtoMessage()    {}
+func (*CommandNewTxn) Descriptor() ([]byte, []int) {
+	return fileDescriptor_39529ba7ad9caeb8, []int{58}
+}
+
+func (m *CommandNewTxn) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CommandNewTxn.Unmarshal(m, b)
+}
+func (m *CommandNewTxn) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CommandNewTxn.Marshal(b, m, deterministic)
+}
+func (m *CommandNewTxn) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CommandNewTxn.Merge(m, src)
+}
+func (m *CommandNewTxn) XXX_Size() int {
+	return xxx_messageInfo_CommandNewTxn.Size(m)
+}
+func (m *CommandNewTxn) XXX_DiscardUnknown() {
+	xxx_messageInfo_CommandNewTxn.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CommandNewTxn proto.InternalMessageInfo
+
+const Default_CommandNewTxn_TxnTtlSeconds uint64 = 0
+const Default_CommandNewTxn_TcId uint64 = 0
+
+func (m *CommandNewTxn) GetRequestId() uint64 {
+	if m != nil && m.RequestId != nil {
+		return *m.RequestId
+	}
+	return 0
+}
+
+func (m *CommandNewTxn) GetTxnTtlSeconds() uint64 {
+	if m != nil && m.TxnTtlSeconds != nil {
+		return *m.TxnTtlSeconds
+	}
+	return Default_CommandNewTxn_TxnTtlSeconds
+}
+
+func (m *CommandNewTxn) GetTcId() uint64 {
+	if m != nil && m.TcId != nil {
+		return *m.TcId
+	}
+	return Default_CommandNewTxn_TcId
+}
+
+type CommandNewTxnResponse struct {
+	RequestId            *uint64      `protobuf:"varint,1,req,name=request_id,json=requestId" json:"request_id,omitempty"`
+	TxnidLeastBits       *uint64      `protobuf:"varint,2,opt,name=txnid_least_bits,json=txnidLeastBits,def=0" json:"txnid_least_bits,omitempty"`
+	TxnidMostBits        *uint64      `protobuf:"varint,3,opt,name=txnid_most_bits,json=txnidMostBits,def=0" json:"txnid_most_bits,omitempty"`
+	Error                *ServerError `protobuf:"varint,4,opt,name=error,enum=pulsar.proto.ServerError" json:"error,omitempty"`
+	Message              *string      `protobuf:"bytes,5,opt,name=message" json:"message,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
+}
+
+func (m *CommandNewTxnResponse) Reset()         { *m = CommandNewTxnResponse{} }
+func (m *CommandNewTxnResponse) String() string { return proto.CompactTextString(m) }
+func (*CommandNewTxnResponse) ProtoMessage()    {}
+func (*CommandNewTxnResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_39529ba7ad9caeb8, []int{59}
+}
+
+func (m *CommandNewTxnResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CommandNewTxnResponse.Unmarshal(m, b)
+}
+func (m *CommandNewTxnResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CommandNewTxnResponse.Marshal(b, m, deterministic)
+}
+func (m *CommandNewTxnResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CommandNewTxnResponse.Merge(m, src)
+}
+func (m *CommandNewTxnResponse) XXX_Size() int {
+	return xxx_messageInfo_CommandNewTxnResponse.Size(m)
+}
+func (m *CommandNewTxnResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_CommandNewTxnResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CommandNewTxnResponse proto.InternalMessageInfo
+
+const Default_CommandNewTxnResponse_TxnidLeastBits uint64 = 0
+const Default_CommandNewTxnResponse_TxnidMostBits uint64 = 0
+
+func (m *CommandNewTxnResponse) GetRequestId() uint64 {
+	if m != nil && m.RequestId != nil {
+		return *m.RequestId
+	}
+	return 0
+}
+
+func (m *CommandNewTxnResponse) GetTxnidLeastBits() uint64 {
+	if m != nil && m.TxnidLeastBits != nil {
+		return *m.TxnidLeastBits
+	}
+	return Default_CommandNewTxnResponse_TxnidLeastBits
+}
+
+func (m *CommandNewTxnResponse) GetTxnidMostBits() uint64 {
+	if m != nil && m.TxnidMostBits != nil {
+		return *m.TxnidMostBits
+	}
+	return Default_CommandNewTxnResponse_TxnidMostBits
+}
+
+func (m *CommandNewTxnResponse) GetError() ServerError {
+	if m != nil && m.Error != nil {
+		return *m.Error
+	}
+	return ServerError_UnknownError
+}
+
+func (m *CommandNewTxnResponse) GetMessage() string {
+	if m != nil && m.Message != nil {
+		return *m.Message
+	}
+	return ""
+}
+
+type CommandAddPartitionToTxn struct {
+	RequestId            *uint64  `protobuf:"varint,1,req,name=request_id,json=requestId" json:"request_id,omitempty"`
+	TxnidLeastBits       *uint64  `protobuf:"varint,2,opt,name=txnid_least_bits,json=txnidLeastBits,def=0" json:"txnid_least_bits,omitempty"`
+	TxnidMostBits        *uint64  `protobuf:"varint,3,opt,name=txnid_most_bits,json=txnidMostBits,def=0" json:"txnid_most_bits,omitempty"`
+	Partitions           []string `protobuf:"bytes,4,rep,name=partitions" json:"partitions,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CommandAddPartitionToTxn) Reset()         { *m = CommandAddPartitionToTxn{} }
+func (m *CommandAddPartitionToTxn) String() string { return proto.CompactTextString(m) }
+func (*CommandAddPartitionToTxn) ProtoMessage()    {}
+func (*CommandAddPartitionToTxn) Descriptor() ([]byte, []int) {
+	return fileDescriptor_39529ba7ad9caeb8, []int{60}
+}
+
+func (m *CommandAddPartitionToTxn) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CommandAddPartitionToTxn.Unmarshal(m, b)
+}
+func (m *CommandAddPartitionToTxn) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CommandAddPartitionToTxn.Marshal(b, m, deterministic)
+}
+func (m *CommandAddPartitionToTxn) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CommandAddPartitionToTxn.Merge(m, src)
+}
+func (m *CommandAddPartitionToTxn) XXX_Size() int {
+	return xxx_messageInfo_CommandAddPartitionToTxn.Size(m)
+}
+func (m *CommandAddPartitionToTxn) XXX_DiscardUnknown() {
+	xxx_messageInfo_CommandAddPartitionToTxn.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CommandAddPartitionToTxn proto.InternalMessageInfo
+
+const Default_CommandAddPartitionToTxn_TxnidLeastBits uint64 = 0
+const Default_CommandAddPartitionToTxn_TxnidMostBits uint64 = 0
+
+func (m *CommandAddPartitionToTxn) GetRequestId() uint64 {
+	if m != nil && m.RequestId != nil {
+		return *m.RequestId
+	}
+	return 0
+}
+
+func (m *CommandAddPartitionToTxn) GetTxnidLeastBits() uint64 {
+	if m != nil && m.TxnidLeastBits != nil {
+		return *m.TxnidLeastBits
+	}
+	return Default_CommandAddPartitionToTxn_TxnidLeastBits
+}
+
+func (m *CommandAddPartitionToTxn) GetTxnidMostBits() uint64 {
+	if m != nil && m.TxnidMostBits != nil {
+		return *m.TxnidMostBits
+	}
+	return Default_CommandAddPartitionToTxn_TxnidMostBits
+}
+
+func (m *CommandAddPartitionToTxn) GetPartitions() []string {
+	if m != nil {
+		return m.Partitions
+	}
+	return nil
+}
+
+type CommandAddPartitionToTxnResponse struct {
+	RequestId            *uint64      `protobuf:"varint,1,req,name=request_id,json=requestId" json:"request_id,omitempty"`
+	TxnidLeastBits       *uint64      `protobuf:"varint,2,opt,name=txnid_least_bits,json=txnidLeastBits,def=0" json:"txnid_least_bits,omitempty"`
+	TxnidMostBits        *uint64      `protobuf:"varint,3,opt,name=txnid_most_bits,json=txnidMostBits,def=0" json:"txnid_most_bits,omitempty"`
+	Error                *ServerError `protobuf:"varint,4,opt,name=error,enum=pulsar.proto.ServerError" json:"error,omitempty"`
+	Message              *string      `protobuf:"bytes,5,opt,name=message" json:"message,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
+}
+
+func (m *CommandAddPartitionToTxnResponse) Reset()         { *m = CommandAddPartitionToTxnResponse{} }
+func (m *CommandAddPartitionToTxnResponse) String() string { return proto.CompactTextString(m) }
+func (*CommandAddPartitionToTxnResponse) ProtoMessage()    {}
+func (*CommandAddPartitionToTxnResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_39529ba7ad9caeb8, []int{61}
+}
+
+func (m *CommandAddPartitionToTxnResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CommandAddPartitionToTxnResponse.Unmarshal(m, b)
+}
+func (m *CommandAddPartitionToTxnResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CommandAddPartitionToTxnResponse.Marshal(b, m, deterministic)
+}
+func (m *CommandAddPartitionToTxnResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CommandAddPartitionToTxnResponse.Merge(m, src)
+}
+func (m *CommandAddPartitionToTxnResponse) XXX_Size() int {
+	return xxx_messageInfo_CommandAddPartitionToTxnResponse.Size(m)
+}
+func (m *CommandAddPartitionToTxnResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_CommandAddPartitionToTxnResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CommandAddPartitionToTxnResponse proto.InternalMessageInfo
+
+const Default_CommandAddPartitionToTxnResponse_TxnidLeastBits uint64 = 0
+const Default_CommandAddPartitionToTxnResponse_TxnidMostBits uint64 = 0
+
+func (m *CommandAddPartitionToTxnResponse) GetRequestId() uint64 {
+	if m != nil && m.RequestId != nil {
+		return *m.RequestId
+	}
+	return 0
+}
+
+func (m *CommandAddPartitionToTxnResponse) GetTxnidLeastBits() uint64 {
+	if m != nil && m.TxnidLeastBits != nil {
+		return *m.TxnidLeastBits
+	}
+	return Default_CommandAddPartitionToTxnResponse_TxnidLeastBits
+}
+
+func (m *CommandAddPartitionToTxnResponse) GetTxnidMostBits() uint64 {
+	if m != nil && m.TxnidMostBits != nil {
+		return *m.TxnidMostBits
+	}
+	return Default_CommandAddPartitionToTxnResponse_TxnidMostBits
+}
+
+func (m *CommandAddPartitionToTxnResponse) GetError() ServerError {
+	if m != nil && m.Error != nil {
+		return *m.Error
+	}
+	return ServerError_UnknownError
+}
+
+func (m *CommandAddPartitionToTxnResponse) GetMessage() string {
+	if m != nil && m.Message != nil {
+		return *m.Message
+	}
+	return ""
+}
+
+type Subscription struct {
+	Topic                *string  `protobuf:"bytes,1,req,name=topic" json:"topic,omitempty"`
+	Subscription         *string  `protobuf:"bytes,2,req,name=subscription" json:"subscription,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Subscription) Reset()         { *m = Subscription{} }
+func (m *Subscription) String() string { return proto.CompactTextString(m) }
+func (*Subscription) ProtoMessage()    {}
+func (*Subscription) Descriptor() ([]byte, []int) {
+	return fileDescriptor_39529ba7ad9caeb8, []int{62}
+}
+
+func (m *Subscription) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Subscription.Unmarshal(m, b)
+}
+func (m *Subscription) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Subscription.Marshal(b, m, deterministic)
+}
+func (m *Subscription) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Subscription.Merge(m, src)
+}
+func (m *Subscription) XXX_Size() int {
+	return xxx_messageInfo_Subscription.Size(m)
+}
+func (m *Subscription) XXX_DiscardUnknown() {
+	xxx_messageInfo_Subscription.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Subscription proto.InternalMessageInfo
+
+func (m *Subscription) GetTopic() string {
+	if m != nil && m.Topic != nil {
+		return *m.Topic
+	}
+	return ""
+}
+
+func (m *Subscription) GetSubscription() string {
+	if m != nil && m.Subscription != nil {
+		return *m.Subscription
+	}
+	return ""
+}
+
+type CommandAddSubscriptionToTxn struct {
+	RequestId            *uint64         `protobuf:"varint,1,req,name=request_id,json=requestId" json:"request_id,omitempty"`
+	TxnidLeastBits       *uint64         `protobuf:"varint,2,opt,name=txnid_least_bits,json=txnidLeastBits,def=0" json:"txnid_least_bits,omitempty"`
+	TxnidMostBits        *uint64         `protobuf:"varint,3,opt,name=txnid_most_bits,json=txnidMostBits,def=0" json:"txnid_most_bits,omitempty"`
+	Subscription         []*Subscription `protobuf:"bytes,4,rep,name=subscription" json:"subscription,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *CommandAddSubscriptionToTxn) Reset()         { *m = CommandAddSubscriptionToTxn{} }
+func (m *CommandAddSubscriptionToTxn) String() string { return proto.CompactTextString(m) }
+func (*CommandAddSubscriptionToTxn) ProtoMessage()    {}
+func (*CommandAddSubscriptionToTxn) Descriptor() ([]byte, []int) {
+	return fileDescriptor_39529ba7ad9caeb8, []int{63}
+}
+
+func (m *CommandAddSubscriptionToTxn) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CommandAddSubscriptionToTxn.Unmarshal(m, b)
+}
+func (m *CommandAddSubscriptionToTxn) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CommandAddSubscriptionToTxn.Marshal(b, m, deterministic)
+}
+func (m *CommandAddSubscriptionToTxn) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CommandAddSubscriptionToTxn.Merge(m, src)
+}
+func (m *CommandAddSubscriptionToTxn) XXX_Size() int {
+	return xxx_messageInfo_CommandAddSubscriptionToTxn.Size(m)
+}
+func (m *CommandAddSubscriptionToTxn) XXX_DiscardUnknown() {
+	xxx_messageInfo_CommandAddSubscriptionToTxn.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CommandAddSubscriptionToTxn proto.InternalMessageInfo
+
+const Default_CommandAddSubscriptionToTxn_TxnidLeastBits uint64 = 0
+const Default_CommandAddSubscriptionToTxn_TxnidMostBits uint64 = 0
+
+func (m *CommandAddSubscriptionToTxn) GetRequestId() uint64 {
+	if m != nil && m.RequestId != nil {
+		return *m.RequestId
+	}
+	return 0
+}
+
+func (m *CommandAddSubscriptionToTxn) GetTxnidLeastBits() uint64 {
+	if m != nil && m.TxnidLeastBits != nil {
+		return *m.TxnidLeastBits
+	}
+	return Default_CommandAddSubscriptionToTxn_TxnidLeastBits
+}
+
+func (m *CommandAddSubscriptionToTxn) GetTxnidMostBits() uint64 {
+	if m != nil && m.TxnidMostBits != nil {
+		return *m.TxnidMostBits
+	}
+	return Default_CommandAddSubscriptionToTxn_TxnidMostBits
+}
+
+func (m *CommandAddSubscriptionToTxn) GetSubscription() []*Subscription {
+	if m != nil {
+		return m.Subscription
+	}
+	return nil
+}
+
+type CommandAddSubscriptionToTxnResponse struct {
+	RequestId            *uint64      `protobuf:"varint,1,req,name=request_id,json=requestId" json:"request_id,omitempty"`
+	TxnidLeastBits       *uint64      `protobuf:"varint,2,opt,name=txnid_least_bits,json=txnidLeastBits,def=0" json:"txnid_least_bits,omitempty"`
+	TxnidMostBits        *uint64      `protobuf:"varint,3,opt,name=txnid_most_bits,json=txnidMostBits,def=0" json:"txnid_most_bits,omitempty"`
+	Error                *ServerError `protobuf:"varint,4,opt,name=error,enum=pulsar.proto.ServerError" json:"error,omitempty"`
+	Message              *string      `protobuf:"bytes,5,opt,name=message" json:"message,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
+}
+
+func (m *CommandAddSubscriptionToTxnResponse) Reset()         { *m = CommandAddSubscriptionToTxnResponse{} }
+func (m *CommandAddSubscriptionToTxnResponse) String() string { return proto.CompactTextString(m) }
+func (*CommandAddSubscriptionToTxnResponse) ProtoMessage()    {}
+func (*CommandAddSubscriptionToTxnResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_39529ba7ad9caeb8, []int{64}
+}
+
+func (m *CommandAddSubscriptionToTxnResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CommandAddSubscriptionToTxnResponse.Unmarshal(m, b)
+}
+func (m *CommandAddSubscriptionToTxnResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CommandAddSubscriptionToTxnResponse.Marshal(b, m, deterministic)
+}
+func (m *CommandAddSubscriptionToTxnResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CommandAddSubscriptionToTxnResponse.Merge(m, src)
+}
+func (m *CommandAddSubscriptionToTxnResponse) XXX_Size() int {
+	return xxx_messageInfo_CommandAddSubscriptionToTxnResponse.Size(m)
+}
+func (m *CommandAddSubscriptionToTxnResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_CommandAddSubscriptionToTxnResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CommandAddSubscriptionToTxnResponse proto.InternalMessageInfo
+
+const Default_CommandAddSubscriptionToTxnResponse_TxnidLeastBits uint64 = 0
+const Default_CommandAddSubscriptionToTxnResponse_TxnidMostBits uint64 = 0
+
+func (m *CommandAddSubscriptionToTxnResponse) GetRequestId() uint64 {
+	if m != nil && m.RequestId != nil {
+		return *m.RequestId
+	}
+	return 0
+}
+
+func (m *CommandAddSubscriptionToTxnResponse) GetTxnidLeastBits() uint64 {
+	if m != nil && m.TxnidLeastBits != nil {
+		return *m.TxnidLeastBits
+	}
+	return Default_CommandAddSubscriptionToTxnResponse_TxnidLeastBits
+}
+
+func (m *CommandAddSubscriptionToTxnResponse) GetTxnidMostBits() uint64 {
+	if m != nil && m.TxnidMostBits != nil {
+		return *m.TxnidMostBits
+	}
+	return Default_CommandAddSubscriptionToTxnResponse_TxnidMostBits
+}
+
+func (m *CommandAddSubscriptionToTxnResponse) GetError() ServerError {
+	if m != nil && m.Error != nil {
+		return *m.Error
+	}
+	return ServerError_UnknownError
+}
+
+func (m *CommandAddSubscriptionToTxnResponse) GetMessage() string {
+	if m != nil && m.Message != nil {
+		return *m.Message
+	}
+	return ""
+}
+
+type CommandEndTxn struct {
+	RequestId            *uint64    `protobuf:"varint,1,req,name=request_id,json=requestId" json:"request_id,omitempty"`
+	TxnidLeastBits       *uint64    `protobuf:"varint,2,opt,name=txnid_least_bits,json=txnidLeastBits,def=0" json:"txnid_least_bits,omitempty"`
+	TxnidMostBits        *uint64    `protobuf:"varint,3,opt,name=txnid_most_bits,json=txnidMostBits,def=0" json:"txnid_most_bits,omitempty"`
+	TxnAction            *TxnAction `protobuf:"varint,4,opt,name=txn_action,json=txnAction,enum=pulsar.proto.TxnAction" json:"txn_action,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
+}
+
+func (m *CommandEndTxn) Reset()         { *m = CommandEndTxn{} }
+func (m *CommandEndTxn) String() string { return proto.CompactTextString(m) }
+func (*CommandEndTxn) ProtoMessage()    {}
+func (*CommandEndTxn) Descriptor() ([]byte, []int) {
+	return fileDescriptor_39529ba7ad9caeb8, []int{65}
+}
+
+func (m *CommandEndTxn) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CommandEndTxn.Unmarshal(m, b)
+}
+func (m *CommandEndTxn) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CommandEndTxn.Marshal(b, m, deterministic)
+}
+func (m *CommandEndTxn) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CommandEndTxn.Merge(m, src)
+}
+func (m *CommandEndTxn) XXX_Size() int {
+	return xxx_messageInfo_CommandEndTxn.Size(m)
+}
+func (m *CommandEndTxn) XXX_DiscardUnknown() {
+	xxx_messageInfo_CommandEndTxn.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CommandEndTxn proto.InternalMessageInfo
+
+const Default_CommandEndTxn_TxnidLeastBits uint64 = 0
+const Default_CommandEndTxn_TxnidMostBits uint64 = 0
+
+func (m *CommandEndTxn) GetRequestId() uint64 {
+	if m != nil && m.RequestId != nil {
+		return *m.RequestId
+	}
+	return 0
+}
+
+func (m *CommandEndTxn) GetTxnidLeastBits() uint64 {
+	if m != nil && m.TxnidLeastBits != nil {
+		return *m.TxnidLeastBits
+	}
+	return Default_CommandEndTxn_TxnidLeastBits
+}
+
+func (m *CommandEndTxn) GetTxnidMostBits() uint64 {
+	if m != nil && m.TxnidMostBits != nil {
+		return *m.TxnidMostBits
+	}
+	return Default_CommandEndTxn_TxnidMostBits
+}
+
+func (m *CommandEndTxn) GetTxnAction() TxnAction {
+	if m != nil && m.TxnAction != nil {
+		return *m.TxnAction
+	}
+	return TxnAction_COMMIT
+}
+
+type CommandEndTxnResponse struct {
+	RequestId            *uint64      `protobuf:"varint,1,req,name=request_id,json=requestId" json:"request_id,omitempty"`
+	TxnidLeastBits       *uint64      `protobuf:"varint,2,opt,name=txnid_least_bits,json=txnidLeastBits,def=0" json:"txnid_least_bits,omitempty"`
+	TxnidMostBits        *uint64      `protobuf:"varint,3,opt,name=txnid_most_bits,json=txnidMostBits,def=0" json:"txnid_most_bits,omitempty"`
+	Error                *ServerError `protobuf:"varint,4,opt,name=error,enum=pulsar.proto.ServerError" json:"error,omitempty"`
+	Message              *string      `protobuf:"bytes,5,opt,name=message" json:"message,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
+}
+
+func (m *CommandEndTxnResponse) Reset()         { *m = CommandEndTxnResponse{} }
+func (m *CommandEndTxnResponse) String() string { return proto.CompactTextString(m) }
+func (*CommandEndTxnResponse) ProtoMessage()    {}
+func (*CommandEndTxnResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_39529ba7ad9caeb8, []int{66}
+}
+
+func (m *CommandEndTxnResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CommandEndTxnResponse.Unmarshal(m, b)
+}
+func (m *CommandEndTxnResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CommandEndTxnResponse.Marshal(b, m, deterministic)
+}
+func (m *CommandEndTxnResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CommandEndTxnResponse.Merge(m, src)
+}
+func (m *CommandEndTxnResponse) XXX_Size() int {
+	return xxx_messageInfo_CommandEndTxnResponse.Size(m)
+}
+func (m *CommandEndTxnResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_CommandEndTxnResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CommandEndTxnResponse proto.InternalMessageInfo
+
+const Default_CommandEndTxnResponse_TxnidLeastBits uint64 = 0
+const Default_CommandEndTxnResponse_TxnidMostBits uint64 = 0
+
+func (m *CommandEndTxnResponse) GetRequestId() uint64 {
+	if m != nil && m.RequestId != nil {
+		return *m.RequestId
+	}
+	return 0
+}
+
+func (m *CommandEndTxnResponse) GetTxnidLeastBits() uint64 {
+	if m != nil && m.TxnidLeastBits != nil {
+		return *m.TxnidLeastBits
+	}
+	return Default_CommandEndTxnResponse_TxnidLeastBits
+}
+
+func (m *CommandEndTxnResponse) GetTxnidMostBits() uint64 {
+	if m != nil && m.TxnidMostBits != nil {
+		return *m.TxnidMostBits
+	}
+	return Default_CommandEndTxnResponse_TxnidMostBits
+}
+
+func (m *CommandEndTxnResponse) GetError() ServerError {
+	if m != nil && m.Error != nil {
+		return *m.Error
+	}
+	return ServerError_UnknownError
+}
+
+func (m *CommandEndTxnResponse) GetMessage() string {
+	if m != nil && m.Message != nil {
+		return *m.Message
+	}
+	return ""
+}
+
+type CommandEndTxnOnPartition struct {
+	RequestId                    *uint64    `protobuf:"varint,1,req,name=request_id,json=requestId" json:"request_id,omitempty"`
+	TxnidLeastBits               *uint64    `protobuf:"varint,2,opt,name=txnid_least_bits,json=txnidLeastBits,def=0" json:"txnid_least_bits,omitempty"`
+	TxnidMostBits                *uint64    `protobuf:"varint,3,opt,name=txnid_most_bits,json=txnidMostBits,def=0" json:"txnid_most_bits,omitempty"`
+	Topic                        *string    `protobuf:"bytes,4,opt,name=topic" json:"topic,omitempty"`
+	TxnAction                    *TxnAction `protobuf:"varint,5,opt,name=txn_action,json=txnAction,enum=pulsar.proto.TxnAction" json:"txn_action,omitempty"`
+	TxnidLeastBitsOfLowWatermark *uint64    `protobuf:"varint,6,opt,name=txnid_least_bits_of_low_watermark,json=txnidLeastBitsOfLowWatermark" json:"txnid_least_bits_of_low_watermark,omitempty"`
+	XXX_NoUnkeyedLiteral         struct{}   `json:"-"`
+	XXX_unrecognized             []byte     `json:"-"`
+	XXX_sizecache                int32      `json:"-"`
+}
+
+func (m *CommandEndTxnOnPartition) Reset()         { *m = CommandEndTxnOnPartition{} }
+func (m *CommandEndTxnOnPartition) String() string { return proto.CompactTextString(m) }
+func (*CommandEndTxnOnPartition) ProtoMessage()    {}
+func (*CommandEndTxnOnPartition) Descriptor() ([]byte, []int) {
+	return fileDescriptor_39529ba7ad9caeb8, []int{67}
+}
+
+func (m *CommandEndTxnOnPartition) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CommandEndTxnOnPartition.Unmarshal(m, b)
+}
+func (m *CommandEndTxnOnPartition) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CommandEndTxnOnPartition.Marshal(b, m, deterministic)
+}
+func (m *CommandEndTxnOnPartition) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CommandEndTxnOnPartition.Merge(m, src)
+}
+func (m *CommandEndTxnOnPartition) XXX_Size() int {
+	return xxx_messageInfo_CommandEndTxnOnPartition.Size(m)
+}
+func (m *CommandEndTxnOnPartition) XXX_DiscardUnknown() {
+	xxx_messageInfo_CommandEndTxnOnPartition.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CommandEndTxnOnPartition proto.InternalMessageInfo
+
+const Default_CommandEndTxnOnPartition_TxnidLeastBits uint64 = 0
+const Default_CommandEndTxnOnPartition_TxnidMostBits uint64 = 0
+
+func (m *CommandEndTxnOnPartition) GetRequestId() uint64 {
+	if m != nil && m.RequestId != nil {
+		return *m.RequestId
+	}
+	return 0
+}
+
+func (m *CommandEndTxnOnPartition) GetTxnidLeastBits() uint64 {
+	if m != nil && m.TxnidLeastBits != nil {
+		return *m.TxnidLeastBits
+	}
+	return Default_CommandEndTxnOnPartition_TxnidLeastBits
+}
+
+func (m *CommandEndTxnOnPartition) GetTxnidMostBits() uint64 {
+	if m != nil && m.TxnidMostBits != nil {
+		return *m.TxnidMostBits
+	}
+	return Default_CommandEndTxnOnPartition_TxnidMostBits
+}
+
+func (m *CommandEndTxnOnPartition) GetTopic() string {
+	if m != nil && m.Topic != nil {
+		return *m.Topic
+	}
+	return ""
+}
+
+func (m *CommandEndTxnOnPartition) GetTxnAction() TxnAction {
+	if m != nil && m.TxnAction != nil {
+		return *m.TxnAction
+	}
+	return TxnAction_COMMIT
+}
+
+func (m *CommandEndTxnOnPartition) GetTxnidLeastBitsOfLowWatermark() uint64 {
+	if m != nil && m.TxnidLeastBitsOfLowWatermark != nil {
+		return *m.TxnidLeastBitsOfLowWatermark
+	}
+	return 0
+}
+
+type CommandEndTxnOnPartitionResponse struct {
+	RequestId            *uint64      `protobuf:"varint,1,req,name=request_id,json=requestId" json:"request_id,omitempty"`
+	TxnidLeastBits       *uint64      `protobuf:"varint,2,opt,name=txnid_least_bits,json=txnidLeastBits,def=0" json:"txnid_least_bits,omitempty"`
+	TxnidMostBits        *uint64      `protobuf:"varint,3,opt,name=txnid_most_bits,json=txnidMostBits,def=0" json:"txnid_most_bits,omitempty"`
+	Error                *ServerError `protobuf:"varint,4,opt,name=error,enum=pulsar.proto.ServerError" json:"error,omitempty"`
+	Message              *string      `protobuf:"bytes,5,opt,name=message" json:"message,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
+}
+
+func (m *CommandEndTxnOnPartitionResponse) Reset()         { *m = CommandEndTxnOnPartitionResponse{} }
+func (m *CommandEndTxnOnPartitionResponse) String() string { return proto.CompactTextString(m) }
+func (*CommandEndTxnOnPartitionResponse) ProtoMessage()    {}
+func (*CommandEndTxnOnPartitionResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_39529ba7ad9caeb8, []int{68}
 }
-func (m *CommandGetSchema) XXX_Size() int {
-	return xxx_messageInfo_CommandGetSchema.Size(m)
+
+func (m *CommandEndTxnOnPartitionResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CommandEndTxnOnPartitionResponse.Unmarshal(m, b)
 }
-func (m *CommandGetSchema) XXX_DiscardUnknown() {
-	xxx_messageInfo_CommandGetSchema.DiscardUnknown(m)
+func (m *CommandEndTxnOnPartitionResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CommandEndTxnOnPartitionResponse.Marshal(b, m, deterministic)
+}
+func (m *CommandEndTxnOnPartitionResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CommandEndTxnOnPartitionResponse.Merge(m, src)
+}
+func (m *CommandEndTxnOnPartitionResponse) XXX_Size() int {
+	return xxx_messageInfo_CommandEndTxnOnPartitionResponse.Size(m)
+}
+func (m *CommandEndTxnOnPartitionResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_CommandEndTxnOnPartitionResponse.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_CommandGetSchema proto.InternalMessageInfo
+var xxx_messageInfo_CommandEndTxnOnPartitionResponse proto.InternalMessageInfo
 
-func (m *CommandGetSchema) GetRequestId() uint64 {
+const Default_CommandEndTxnOnPartitionResponse_TxnidLeastBits uint64 = 0
+const Default_CommandEndTxnOnPartitionResponse_TxnidMostBits uint64 = 0
+
+func (m *CommandEndTxnOnPartitionResponse) GetRequestId() uint64 {
 	if m != nil && m.RequestId != nil {
 		return *m.RequestId
 	}
 	return 0
 }
 
-func (m *CommandGetSchema) GetTopic() string {
-	if m != nil && m.Topic != nil {
-		return *m.Topic
+func (m *CommandEndTxnOnPartitionResponse) GetTxnidLeastBits() uint64 {
+	if m != nil && m.TxnidLeastBits != nil {
+		return *m.TxnidLeastBits
+	}
+	return Default_CommandEndTxnOnPartitionResponse_TxnidLeastBits
+}
+
+func (m *CommandEndTxnOnPartitionResponse) GetTxnidMostBits() uint64 {
+	if m != nil && m.TxnidMostBits != nil {
+		return *m.TxnidMostBits
+	}
+	return Default_CommandEndTxnOnPartitionResponse_TxnidMostBits
+}
+
+func (m *CommandEndTxnOnPartitionResponse) GetError() ServerError {
+	if m != nil && m.Error != nil {
+		return *m.Error
+	}
+	return ServerError_UnknownError
+}
+
+func (m *CommandEndTxnOnPartitionResponse) GetMessage() string {
+	if m != nil && m.Message != nil {
+		return *m.Message
 	}
 	return ""
 }
 
-func (m *CommandGetSchema) GetSchemaVersion() []byte {
+type CommandEndTxnOnSubscription struct {
+	RequestId                    *uint64       `protobuf:"varint,1,req,name=request_id,json=requestId" json:"request_id,omitempty"`
+	TxnidLeastBits               *uint64       `protobuf:"varint,2,opt,name=txnid_least_bits,json=txnidLeastBits,def=0" json:"txnid_least_bits,omitempty"`
+	TxnidMostBits                *uint64       `protobuf:"varint,3,opt,name=txnid_most_bits,json=txnidMostBits,def=0" json:"txnid_most_bits,omitempty"`
+	Subscription                 *Subscription `protobuf:"bytes,4,opt,name=subscription" json:"subscription,omitempty"`
+	TxnAction                    *TxnAction    `protobuf:"varint,5,opt,name=txn_action,json=txnAction,enum=pulsar.proto.TxnAction" json:"txn_action,omitempty"`
+	TxnidLeastBitsOfLowWatermark *uint64       `protobuf:"varint,6,opt,name=txnid_least_bits_of_low_watermark,json=txnidLeastBitsOfLowWatermark" json:"txnid_least_bits_of_low_watermark,omitempty"`
+	XXX_NoUnkeyedLiteral         struct{}      `json:"-"`
+	XXX_unrecognized             []byte        `json:"-"`
+	XXX_sizecache                int32         `json:"-"`
+}
+
+func (m *CommandEndTxnOnSubscription) Reset()         { *m = CommandEndTxnOnSubscription{} }
+func (m *CommandEndTxnOnSubscription) String() string { return proto.CompactTextString(m) }
+func (*CommandEndTxnOnSubscription) ProtoMessage()    {}
+func (*CommandEndTxnOnSubscription) Descriptor() ([]byte, []int) {
+	return fileDescriptor_39529ba7ad9caeb8, []int{69}
+}
+
+func (m *CommandEndTxnOnSubscription) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CommandEndTxnOnSubscription.Unmarshal(m, b)
+}
+func (m *CommandEndTxnOnSubscription) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CommandEndTxnOnSubscription.Marshal(b, m, deterministic)
+}
+func (m *CommandEndTxnOnSubscription) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CommandEndTxnOnSubscription.Merge(m, src)
+}
+func (m *CommandEndTxnOnSubscription) XXX_Size() int {
+	return xxx_messageInfo_CommandEndTxnOnSubscription.Size(m)
+}
+func (m *CommandEndTxnOnSubscription) XXX_DiscardUnknown() {
+	xxx_messageInfo_CommandEndTxnOnSubscription.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CommandEndTxnOnSubscription proto.InternalMessageInfo
+
+const Default_CommandEndTxnOnSubscription_TxnidLeastBits uint64 = 0
+const Default_CommandEndTxnOnSubscription_TxnidMostBits uint64 = 0
+
+func (m *CommandEndTxnOnSubscription) GetRequestId() uint64 {
+	if m != nil && m.RequestId != nil {
+		return *m.RequestId
+	}
+	return 0
+}
+
+func (m *CommandEndTxnOnSubscription) GetTxnidLeastBits() uint64 {
+	if m != nil && m.TxnidLeastBits != nil {
+		return *m.TxnidLeastBits
+	}
+	return Default_CommandEndTxnOnSubscription_TxnidLeastBits
+}
+
+func (m *CommandEndTxnOnSubscription) GetTxnidMostBits() uint64 {
+	if m != nil && m.TxnidMostBits != nil {
+		return *m.TxnidMostBits
+	}
+	return Default_CommandEndTxnOnSubscription_TxnidMostBits
+}
+
+func (m *CommandEndTxnOnSubscription) GetSubscription() *Subscription {
 	if m != nil {
-		return m.SchemaVersion
+		return m.Subscription
 	}
 	return nil
 }
 
-type CommandGetSchemaResponse struct {
+func (m *CommandEndTxnOnSubscription) GetTxnAction() TxnAction {
+	if m != nil && m.TxnAction != nil {
+		return *m.TxnAction
+	}
+	return TxnAction_COMMIT
+}
+
+func (m *CommandEndTxnOnSubscription) GetTxnidLeastBitsOfLowWatermark() uint64 {
+	if m != nil && m.TxnidLeastBitsOfLowWatermark != nil {
+		return *m.TxnidLeastBitsOfLowWatermark
+	}
+	return 0
+}
+
+type CommandEndTxnOnSubscriptionResponse struct {
 	RequestId            *uint64      `protobuf:"varint,1,req,name=request_id,json=requestId" json:"request_id,omitempty"`
-	ErrorCode            *ServerError `protobuf:"varint,2,opt,name=error_code,json=errorCode,enum=pulsar.proto.ServerError" json:"error_code,omitempty"`
-	ErrorMessage         *string      `protobuf:"bytes,3,opt,name=error_message,json=errorMessage" json:"error_message,omitempty"`
-	Schema               *Schema      `protobuf:"bytes,4,opt,name=schema" json:"schema,omitempty"`
-	SchemaVersion        []byte       `protobuf:"bytes,5,opt,name=schema_version,json=schemaVersion" json:"schema_version,omitempty"`
+	TxnidLeastBits       *uint64      `protobuf:"varint,2,opt,name=txnid_least_bits,json=txnidLeastBits,def=0" json:"txnid_least_bits,omitempty"`
+	TxnidMostBits        *uint64      `protobuf:"varint,3,opt,name=txnid_most_bits,json=txnidMostBits,def=0" json:"txnid_most_bits,omitempty"`
+	Error                *ServerError `protobuf:"varint,4,opt,name=error,enum=pulsar.proto.ServerError" json:"error,omitempty"`
+	Message              *string      `protobuf:"bytes,5,opt,name=message" json:"message,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
 	XXX_unrecognized     []byte       `json:"-"`
 	XXX_sizecache        int32        `json:"-"`
 }
 
-func (m *CommandGetSchemaResponse) Reset()         { *m = CommandGetSchemaResponse{} }
-func (m *CommandGetSchemaResponse) String() string { return proto.CompactTextString(m) }
-func (*CommandGetSchemaResponse) ProtoMessage()    {}
-func (*CommandGetSchemaResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_PulsarApi_8cdc98e965f026ee, []int{40}
+func (m *CommandEndTxnOnSubscriptionResponse) Reset()         { *m = CommandEndTxnOnSubscriptionResponse{} }
+func (m *CommandEndTxnOnSubscriptionResponse) String() string { return proto.CompactTextString(m) }
+func (*CommandEndTxnOnSubscriptionResponse) ProtoMessage()    {}
+func (*CommandEndTxnOnSubscriptionResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_39529ba7ad9caeb8, []int{70}
 }
-func (m *CommandGetSchemaResponse) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_CommandGetSchemaResponse.Unmarshal(m, b)
+
+func (m *CommandEndTxnOnSubscriptionResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CommandEndTxnOnSubscriptionResponse.Unmarshal(m, b)
 }
-func (m *CommandGetSchemaResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_CommandGetSchemaResponse.Marshal(b, m, deterministic)
+func (m *CommandEndTxnOnSubscriptionResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CommandEndTxnOnSubscriptionResponse.Marshal(b, m, deterministic)
 }
-func (dst *CommandGetSchemaResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_CommandGetSchemaResponse.Merge(dst, src)
+func (m *CommandEndTxnOnSubscriptionResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CommandEndTxnOnSubscriptionResponse.Merge(m, src)
 }
-func (m *CommandGetSchemaResponse) XXX_Size() int {
-	return xxx_messageInfo_CommandGetSchemaResponse.Size(m)
+func (m *CommandEndTxnOnSubscriptionResponse) XXX_Size() int {
+	return xxx_messageInfo_CommandEndTxnOnSubscriptionResponse.Size(m)
 }
-func (m *CommandGetSchemaResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_CommandGetSchemaResponse.DiscardUnknown(m)
+func (m *CommandEndTxnOnSubscriptionResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_CommandEndTxnOnSubscriptionResponse.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_CommandGetSchemaResponse proto.InternalMessageInfo
+var xxx_messageInfo_CommandEndTxnOnSubscriptionResponse proto.InternalMessageInfo
 
-func (m *CommandGetSchemaResponse) GetRequestId() uint64 {
+const Default_CommandEndTxnOnSubscriptionResponse_TxnidLeastBits uint64 = 0
+const Default_CommandEndTxnOnSubscriptionResponse_TxnidMostBits uint64 = 0
+
+func (m *CommandEndTxnOnSubscriptionResponse) GetRequestId() uint64 {
 	if m != nil && m.RequestId != nil {
 		return *m.RequestId
 	}
 	return 0
 }
 
-func (m *CommandGetSchemaResponse) GetErrorCode() ServerError {
-	if m != nil && m.ErrorCode != nil {
-		return *m.ErrorCode
+func (m *CommandEndTxnOnSubscriptionResponse) GetTxnidLeastBits() uint64 {
+	if m != nil && m.TxnidLeastBits != nil {
+		return *m.TxnidLeastBits
 	}
-	return ServerError_UnknownError
+	return Default_CommandEndTxnOnSubscriptionResponse_TxnidLeastBits
 }
 
-func (m *CommandGetSchemaResponse) GetErrorMessage() string {
-	if m != nil && m.ErrorMessage != nil {
-		return *m.ErrorMessage
+func (m *CommandEndTxnOnSubscriptionResponse) GetTxnidMostBits() uint64 {
+	if m != nil && m.TxnidMostBits != nil {
+		return *m.TxnidMostBits
 	}
-	return ""
+	return Default_CommandEndTxnOnSubscriptionResponse_TxnidMostBits
 }
 
-func (m *CommandGetSchemaResponse) GetSchema() *Schema {
-	if m != nil {
-		return m.Schema
+func (m *CommandEndTxnOnSubscriptionResponse) GetError() ServerError {
+	if m != nil && m.Error != nil {
+		return *m.Error
 	}
-	return nil
+	return ServerError_UnknownError
 }
 
-func (m *CommandGetSchemaResponse) GetSchemaVersion() []byte {
-	if m != nil {
-		return m.SchemaVersion
+func (m *CommandEndTxnOnSubscriptionResponse) GetMessage() string {
+	if m != nil && m.Message != nil {
+		return *m.Message
 	}
-	return nil
+	return ""
 }
 
 type BaseCommand struct {
@@ -3457,25 +6377,51 @@ type BaseCommand struct {
 	GetTopicsOfNamespaceResponse    *CommandGetTopicsOfNamespaceResponse     `protobuf:"bytes,33,opt,name=getTopicsOfNamespaceResponse" json:"getTopicsOfNamespaceResponse,omitempty"`
 	GetSchema                       *CommandGetSchema                        `protobuf:"bytes,34,opt,name=getSchema" json:"getSchema,omitempty"`
 	GetSchemaResponse               *CommandGetSchemaResponse                `protobuf:"bytes,35,opt,name=getSchemaResponse" json:"getSchemaResponse,omitempty"`
-	XXX_NoUnkeyedLiteral            struct{}                                 `json:"-"`
-	XXX_unrecognized                []byte                                   `json:"-"`
-	XXX_sizecache                   int32                                    `json:"-"`
+	AuthChallenge                   *CommandAuthChallenge                    `protobuf:"bytes,36,opt,name=authChallenge" json:"authChallenge,omitempty"`
+	AuthResponse                    *CommandAuthResponse                     `protobuf:"bytes,37,opt,name=authResponse" json:"authResponse,omitempty"`
+	AckResponse                     *CommandAckResponse                      `protobuf:"bytes,38,opt,name=ackResponse" json:"ackResponse,omitempty"`
+	GetOrCreateSchema               *CommandGetOrCreateSchema                `protobuf:"bytes,39,opt,name=getOrCreateSchema" json:"getOrCreateSchema,omitempty"`
+	GetOrCreateSchemaResponse       *CommandGetOrCreateSchemaResponse        `protobuf:"bytes,40,opt,name=getOrCreateSchemaResponse" json:"getOrCreateSchemaResponse,omitempty"`
+	// transaction related
+	NewTxn                       *CommandNewTxn                       `protobuf:"bytes,50,opt,name=newTxn" json:"newTxn,omitempty"`
+	NewTxnResponse               *CommandNewTxnResponse               `protobuf:"bytes,51,opt,name=newTxnResponse" json:"newTxnResponse,omitempty"`
+	AddPartitionToTxn            *CommandAddPartitionToTxn            `protobuf:"bytes,52,opt,name=addPartitionToTxn" json:"addPartitionToTxn,omitempty"`
+	AddPartitionToTxnResponse    *CommandAddPartitionToTxnResponse    `protobuf:"bytes,53,opt,name=addPartitionToTxnResponse" json:"addPartitionToTxnResponse,omitempty"`
+	AddSubscriptionToTxn         *CommandAddSubscriptionToTxn         `protobuf:"bytes,54,opt,name=addSubscriptionToTxn" json:"addSubscriptionToTxn,omitempty"`
+	AddSubscriptionToTxnResponse *CommandAddSubscriptionToTxnResponse `protobuf:"bytes,55,opt,name=addSubscriptionToTxnResponse" json:"addSubscriptionToTxnResponse,omitempty"`
+	EndTxn                       *CommandEndTxn                       `protobuf:"bytes,56,opt,name=endTxn" json:"endTxn,omitempty"`
+	EndTxnResponse               *CommandEndTxnResponse               `protobuf:"bytes,57,opt,name=endTxnResponse" json:"endTxnResponse,omitempty"`
+	EndTxnOnPartition            *CommandEndTxnOnPartition            `protobuf:"bytes,58,opt,name=endTxnOnPartition" json:"endTxnOnPartition,omitempty"`
+	EndTxnOnPartitionResponse    *CommandEndTxnOnPartitionResponse    `protobuf:"bytes,59,opt,name=endTxnOnPartitionResponse" json:"endTxnOnPartitionResponse,omitempty"`
+	EndTxnOnSubscription         *CommandEndTxnOnSubscription         `protobuf:"bytes,60,opt,name=endTxnOnSubscription" json:"endTxnOnSubscription,omitempty"`
+	EndTxnOnSubscriptionResponse *CommandEndTxnOnSubscriptionResponse `protobuf:"bytes,61,opt,name=endTxnOnSubscriptionResponse" json:"endTxnOnSubscriptionResponse,omitempty"`
+	TcClientConnectRequest       *CommandTcClientConnectRequest       `protobuf:"bytes,62,opt,name=tcClientConnectRequest" json:"tcClientConnectRequest,omitempty"`
+	TcClientConnectResponse      *CommandTcClientConnectResponse      `protobuf:"bytes,63,opt,name=tcClientConnectResponse" json:"tcClientConnectResponse,omitempty"`
+	WatchTopicList               *CommandWatchTopicList               `protobuf:"bytes,64,opt,name=watchTopicList" json:"watchTopicList,omitempty"`
+	WatchTopicListSuccess        *CommandWatchTopicListSuccess        `protobuf:"bytes,65,opt,name=watchTopicListSuccess" json:"watchTopicListSuccess,omitempty"`
+	WatchTopicUpdate             *CommandWatchTopicUpdate             `protobuf:"bytes,66,opt,name=watchTopicUpdate" json:"watchTopicUpdate,omitempty"`
+	WatchTopicListClose          *CommandWatchTopicListClose          `protobuf:"bytes,67,opt,name=watchTopicListClose" json:"watchTopicListClose,omitempty"`
+	TopicMigrated                *CommandTopicMigrated                `protobuf:"bytes,68,opt,name=topicMigrated" json:"topicMigrated,omitempty"`
+	XXX_NoUnkeyedLiteral         struct{}                             `json:"-"`
+	XXX_unrecognized             []byte                               `json:"-"`
+	XXX_sizecache                int32                                `json:"-"`
 }
 
 func (m *BaseCommand) Reset()         { *m = BaseCommand{} }
 func (m *BaseCommand) String() string { return proto.CompactTextString(m) }
 func (*BaseCommand) ProtoMessage()    {}
 func (*BaseCommand) Descriptor() ([]byte, []int) {
-	return fileDescriptor_PulsarApi_8cdc98e965f026ee, []int{41}
+	return fileDescriptor_39529ba7ad9caeb8, []int{71}
 }
+
 func (m *BaseCommand) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_BaseCommand.Unmarshal(m, b)
 }
 func (m *BaseCommand) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	return xxx_messageInfo_BaseCommand.Marshal(b, m, deterministic)
 }
-func (dst *BaseCommand) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_BaseCommand.Merge(dst, src)
+func (m *BaseCommand) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BaseCommand.Merge(m, src)
 }
 func (m *BaseCommand) XXX_Size() int {
 	return xxx_messageInfo_BaseCommand.Size(m)
@@ -3731,16 +6677,208 @@ func (m *BaseCommand) GetGetSchemaResponse() *CommandGetSchemaResponse {
 	return nil
 }
 
+func (m *BaseCommand) GetAuthChallenge() *CommandAuthChallenge {
+	if m != nil {
+		return m.AuthChallenge
+	}
+	return nil
+}
+
+func (m *BaseCommand) GetAuthResponse() *CommandAuthResponse {
+	if m != nil {
+		return m.AuthResponse
+	}
+	return nil
+}
+
+func (m *BaseCommand) GetAckResponse() *CommandAckResponse {
+	if m != nil {
+		return m.AckResponse
+	}
+	return nil
+}
+
+func (m *BaseCommand) GetGetOrCreateSchema() *CommandGetOrCreateSchema {
+	if m != nil {
+		return m.GetOrCreateSchema
+	}
+	return nil
+}
+
+func (m *BaseCommand) GetGetOrCreateSchemaResponse() *CommandGetOrCreateSchemaResponse {
+	if m != nil {
+		return m.GetOrCreateSchemaResponse
+	}
+	return nil
+}
+
+func (m *BaseCommand) GetNewTxn() *CommandNewTxn {
+	if m != nil {
+		return m.NewTxn
+	}
+	return nil
+}
+
+func (m *BaseCommand) GetNewTxnResponse() *CommandNewTxnResponse {
+	if m != nil {
+		return m.NewTxnResponse
+	}
+	return nil
+}
+
+func (m *BaseCommand) GetAddPartitionToTxn() *CommandAddPartitionToTxn {
+	if m != nil {
+		return m.AddPartitionToTxn
+	}
+	return nil
+}
+
+func (m *BaseCommand) GetAddPartitionToTxnResponse() *CommandAddPartitionToTxnResponse {
+	if m != nil {
+		return m.AddPartitionToTxnResponse
+	}
+	return nil
+}
+
+func (m *BaseCommand) GetAddSubscriptionToTxn() *CommandAddSubscriptionToTxn {
+	if m != nil {
+		return m.AddSubscriptionToTxn
+	}
+	return nil
+}
+
+func (m *BaseCommand) GetAddSubscriptionToTxnResponse() *CommandAddSubscriptionToTxnResponse {
+	if m != nil {
+		return m.AddSubscriptionToTxnResponse
+	}
+	return nil
+}
+
+func (m *BaseCommand) GetEndTxn() *CommandEndTxn {
+	if m != nil {
+		return m.EndTxn
+	}
+	return nil
+}
+
+func (m *BaseCommand) GetEndTxnResponse() *CommandEndTxnResponse {
+	if m != nil {
+		return m.EndTxnResponse
+	}
+	return nil
+}
+
+func (m *BaseCommand) GetEndTxnOnPartition() *CommandEndTxnOnPartition {
+	if m != nil {
+		return m.EndTxnOnPartition
+	}
+	return nil
+}
+
+func (m *BaseCommand) GetEndTxnOnPartitionResponse() *CommandEndTxnOnPartitionResponse {
+	if m != nil {
+		return m.EndTxnOnPartitionResponse
+	}
+	return nil
+}
+
+func (m *BaseCommand) GetEndTxnOnSubscription() *CommandEndTxnOnSubscription {
+	if m != nil {
+		return m.EndTxnOnSubscription
+	}
+	return nil
+}
+
+func (m *BaseCommand) GetEndTxnOnSubscriptionResponse() *CommandEndTxnOnSubscriptionResponse {
+	if m != nil {
+		return m.EndTxnOnSubscriptionResponse
+	}
+	return nil
+}
+
+func (m *BaseCommand) GetTcClientConnectRequest() *CommandTcClientConnectRequest {
+	if m != nil {
+		return m.TcClientConnectRequest
+	}
+	return nil
+}
+
+func (m *BaseCommand) GetTcClientConnectResponse() *CommandTcClientConnectResponse {
+	if m != nil {
+		return m.TcClientConnectResponse
+	}
+	return nil
+}
+
+func (m *BaseCommand) GetWatchTopicList() *CommandWatchTopicList {
+	if m != nil {
+		return m.WatchTopicList
+	}
+	return nil
+}
+
+func (m *BaseCommand) GetWatchTopicListSuccess() *CommandWatchTopicListSuccess {
+	if m != nil {
+		return m.WatchTopicListSuccess
+	}
+	return nil
+}
+
+func (m *BaseCommand) GetWatchTopicUpdate() *CommandWatchTopicUpdate {
+	if m != nil {
+		return m.WatchTopicUpdate
+	}
+	return nil
+}
+
+func (m *BaseCommand) GetWatchTopicListClose() *CommandWatchTopicListClose {
+	if m != nil {
+		return m.WatchTopicListClose
+	}
+	return nil
+}
+
+func (m *BaseCommand) GetTopicMigrated() *CommandTopicMigrated {
+	if m != nil {
+		return m.TopicMigrated
+	}
+	return nil
+}
+
 func init() {
+	proto.RegisterEnum("pulsar.proto.CompressionType", CompressionType_name, CompressionType_value)
+	proto.RegisterEnum("pulsar.proto.ProducerAccessMode", ProducerAccessMode_name, ProducerAccessMode_value)
+	proto.RegisterEnum("pulsar.proto.ServerError", ServerError_name, ServerError_value)
+	proto.RegisterEnum("pulsar.proto.AuthMethod", AuthMethod_name, AuthMethod_value)
+	proto.RegisterEnum("pulsar.proto.ProtocolVersion", ProtocolVersion_name, ProtocolVersion_value)
+	proto.RegisterEnum("pulsar.proto.KeySharedMode", KeySharedMode_name, KeySharedMode_value)
+	proto.RegisterEnum("pulsar.proto.TxnAction", TxnAction_name, TxnAction_value)
+	proto.RegisterEnum("pulsar.proto.Schema_Type", Schema_Type_name, Schema_Type_value)
+	proto.RegisterEnum("pulsar.proto.CommandSubscribe_SubType", CommandSubscribe_SubType_name, CommandSubscribe_SubType_value)
+	proto.RegisterEnum("pulsar.proto.CommandSubscribe_InitialPosition", CommandSubscribe_InitialPosition_name, CommandSubscribe_InitialPosition_value)
+	proto.RegisterEnum("pulsar.proto.CommandPartitionedTopicMetadataResponse_LookupType", CommandPartitionedTopicMetadataResponse_LookupType_name, CommandPartitionedTopicMetadataResponse_LookupType_value)
+	proto.RegisterEnum("pulsar.proto.CommandLookupTopicResponse_LookupType", CommandLookupTopicResponse_LookupType_name, CommandLookupTopicResponse_LookupType_value)
+	proto.RegisterEnum("pulsar.proto.CommandAck_AckType", CommandAck_AckType_name, CommandAck_AckType_value)
+	proto.RegisterEnum("pulsar.proto.CommandAck_ValidationError", CommandAck_ValidationError_name, CommandAck_ValidationError_value)
+	proto.RegisterEnum("pulsar.proto.CommandTopicMigrated_ResourceType", CommandTopicMigrated_ResourceType_name, CommandTopicMigrated_ResourceType_value)
+	proto.RegisterEnum("pulsar.proto.CommandGetTopicsOfNamespace_Mode", CommandGetTopicsOfNamespace_Mode_name, CommandGetTopicsOfNamespace_Mode_value)
+	proto.RegisterEnum("pulsar.proto.BaseCommand_Type", BaseCommand_Type_name, BaseCommand_Type_value)
 	proto.RegisterType((*Schema)(nil), "pulsar.proto.Schema")
 	proto.RegisterType((*MessageIdData)(nil), "pulsar.proto.MessageIdData")
 	proto.RegisterType((*KeyValue)(nil), "pulsar.proto.KeyValue")
 	proto.RegisterType((*KeyLongValue)(nil), "pulsar.proto.KeyLongValue")
+	proto.RegisterType((*IntRange)(nil), "pulsar.proto.IntRange")
 	proto.RegisterType((*EncryptionKeys)(nil), "pulsar.proto.EncryptionKeys")
 	proto.RegisterType((*MessageMetadata)(nil), "pulsar.proto.MessageMetadata")
 	proto.RegisterType((*SingleMessageMetadata)(nil), "pulsar.proto.SingleMessageMetadata")
+	proto.RegisterType((*BrokerEntryMetadata)(nil), "pulsar.proto.BrokerEntryMetadata")
 	proto.RegisterType((*CommandConnect)(nil), "pulsar.proto.CommandConnect")
+	proto.RegisterType((*FeatureFlags)(nil), "pulsar.proto.FeatureFlags")
 	proto.RegisterType((*CommandConnected)(nil), "pulsar.proto.CommandConnected")
+	proto.RegisterType((*CommandAuthResponse)(nil), "pulsar.proto.CommandAuthResponse")
+	proto.RegisterType((*CommandAuthChallenge)(nil), "pulsar.proto.CommandAuthChallenge")
+	proto.RegisterType((*AuthData)(nil), "pulsar.proto.AuthData")
+	proto.RegisterType((*KeySharedMeta)(nil), "pulsar.proto.KeySharedMeta")
 	proto.RegisterType((*CommandSubscribe)(nil), "pulsar.proto.CommandSubscribe")
 	proto.RegisterType((*CommandPartitionedTopicMetadata)(nil), "pulsar.proto.CommandPartitionedTopicMetadata")
 	proto.RegisterType((*CommandPartitionedTopicMetadataResponse)(nil), "pulsar.proto.CommandPartitionedTopicMetadataResponse")
@@ -3752,11 +6890,13 @@ func init() {
 	proto.RegisterType((*CommandSendError)(nil), "pulsar.proto.CommandSendError")
 	proto.RegisterType((*CommandMessage)(nil), "pulsar.proto.CommandMessage")
 	proto.RegisterType((*CommandAck)(nil), "pulsar.proto.CommandAck")
+	proto.RegisterType((*CommandAckResponse)(nil), "pulsar.proto.CommandAckResponse")
 	proto.RegisterType((*CommandActiveConsumerChange)(nil), "pulsar.proto.CommandActiveConsumerChange")
 	proto.RegisterType((*CommandFlow)(nil), "pulsar.proto.CommandFlow")
 	proto.RegisterType((*CommandUnsubscribe)(nil), "pulsar.proto.CommandUnsubscribe")
 	proto.RegisterType((*CommandSeek)(nil), "pulsar.proto.CommandSeek")
 	proto.RegisterType((*CommandReachedEndOfTopic)(nil), "pulsar.proto.CommandReachedEndOfTopic")
+	proto.RegisterType((*CommandTopicMigrated)(nil), "pulsar.proto.CommandTopicMigrated")
 	proto.RegisterType((*CommandCloseProducer)(nil), "pulsar.proto.CommandCloseProducer")
 	proto.RegisterType((*CommandCloseConsumer)(nil), "pulsar.proto.CommandCloseConsumer")
 	proto.RegisterType((*CommandRedeliverUnacknowledgedMessages)(nil), "pulsar.proto.CommandRedeliverUnacknowledgedMessages")
@@ -3771,282 +6911,465 @@ func init() {
 	proto.RegisterType((*CommandGetLastMessageIdResponse)(nil), "pulsar.proto.CommandGetLastMessageIdResponse")
 	proto.RegisterType((*CommandGetTopicsOfNamespace)(nil), "pulsar.proto.CommandGetTopicsOfNamespace")
 	proto.RegisterType((*CommandGetTopicsOfNamespaceResponse)(nil), "pulsar.proto.CommandGetTopicsOfNamespaceResponse")
+	proto.RegisterType((*CommandWatchTopicList)(nil), "pulsar.proto.CommandWatchTopicList")
+	proto.RegisterType((*CommandWatchTopicListSuccess)(nil), "pulsar.proto.CommandWatchTopicListSuccess")
+	proto.RegisterType((*CommandWatchTopicUpdate)(nil), "pulsar.proto.CommandWatchTopicUpdate")
+	proto.RegisterType((*CommandWatchTopicListClose)(nil), "pulsar.proto.CommandWatchTopicListClose")
 	proto.RegisterType((*CommandGetSchema)(nil), "pulsar.proto.CommandGetSchema")
 	proto.RegisterType((*CommandGetSchemaResponse)(nil), "pulsar.proto.CommandGetSchemaResponse")
+	proto.RegisterType((*CommandGetOrCreateSchema)(nil), "pulsar.proto.CommandGetOrCreateSchema")
+	proto.RegisterType((*CommandGetOrCreateSchemaResponse)(nil), "pulsar.proto.CommandGetOrCreateSchemaResponse")
+	proto.RegisterType((*CommandTcClientConnectRequest)(nil), "pulsar.proto.CommandTcClientConnectRequest")
+	proto.RegisterType((*CommandTcClientConnectResponse)(nil), "pulsar.proto.CommandTcClientConnectResponse")
+	proto.RegisterType((*CommandNewTxn)(nil), "pulsar.proto.CommandNewTxn")
+	proto.RegisterType((*CommandNewTxnResponse)(nil), "pulsar.proto.CommandNewTxnResponse")
+	proto.RegisterType((*CommandAddPartitionToTxn)(nil), "pulsar.proto.CommandAddPartitionToTxn")
+	proto.RegisterType((*CommandAddPartitionToTxnResponse)(nil), "pulsar.proto.CommandAddPartitionToTxnResponse")
+	proto.RegisterType((*Subscription)(nil), "pulsar.proto.Subscription")
+	proto.RegisterType((*CommandAddSubscriptionToTxn)(nil), "pulsar.proto.CommandAddSubscriptionToTxn")
+	proto.RegisterType((*CommandAddSubscriptionToTxnResponse)(nil), "pulsar.proto.CommandAddSubscriptionToTxnResponse")
+	proto.RegisterType((*CommandEndTxn)(nil), "pulsar.proto.CommandEndTxn")
+	proto.RegisterType((*CommandEndTxnResponse)(nil), "pulsar.proto.CommandEndTxnResponse")
+	proto.RegisterType((*CommandEndTxnOnPartition)(nil), "pulsar.proto.CommandEndTxnOnPartition")
+	proto.RegisterType((*CommandEndTxnOnPartitionResponse)(nil), "pulsar.proto.CommandEndTxnOnPartitionResponse")
+	proto.RegisterType((*CommandEndTxnOnSubscription)(nil), "pulsar.proto.CommandEndTxnOnSubscription")
+	proto.RegisterType((*CommandEndTxnOnSubscriptionResponse)(nil), "pulsar.proto.CommandEndTxnOnSubscriptionResponse")
 	proto.RegisterType((*BaseCommand)(nil), "pulsar.proto.BaseCommand")
-	proto.RegisterEnum("pulsar.proto.CompressionType", CompressionType_name, CompressionType_value)
-	proto.RegisterEnum("pulsar.proto.ServerError", ServerError_name, ServerError_value)
-	proto.RegisterEnum("pulsar.proto.AuthMethod", AuthMethod_name, AuthMethod_value)
-	proto.RegisterEnum("pulsar.proto.ProtocolVersion", ProtocolVersion_name, ProtocolVersion_value)
-	proto.RegisterEnum("pulsar.proto.Schema_Type", Schema_Type_name, Schema_Type_value)
-	proto.RegisterEnum("pulsar.proto.CommandSubscribe_SubType", CommandSubscribe_SubType_name, CommandSubscribe_SubType_value)
-	proto.RegisterEnum("pulsar.proto.CommandSubscribe_InitialPosition", CommandSubscribe_InitialPosition_name, CommandSubscribe_InitialPosition_value)
-	proto.RegisterEnum("pulsar.proto.CommandPartitionedTopicMetadataResponse_LookupType", CommandPartitionedTopicMetadataResponse_LookupType_name, CommandPartitionedTopicMetadataResponse_LookupType_value)
-	proto.RegisterEnum("pulsar.proto.CommandLookupTopicResponse_LookupType", CommandLookupTopicResponse_LookupType_name, CommandLookupTopicResponse_LookupType_value)
-	proto.RegisterEnum("pulsar.proto.CommandAck_AckType", CommandAck_AckType_name, CommandAck_AckType_value)
-	proto.RegisterEnum("pulsar.proto.CommandAck_ValidationError", CommandAck_ValidationError_name, CommandAck_ValidationError_value)
-	proto.RegisterEnum("pulsar.proto.CommandGetTopicsOfNamespace_Mode", CommandGetTopicsOfNamespace_Mode_name, CommandGetTopicsOfNamespace_Mode_value)
-	proto.RegisterEnum("pulsar.proto.BaseCommand_Type", BaseCommand_Type_name, BaseCommand_Type_value)
 }
 
-func init() { proto.RegisterFile("PulsarApi.proto", fileDescriptor_PulsarApi_8cdc98e965f026ee) }
-
-var fileDescriptor_PulsarApi_8cdc98e965f026ee = []byte{
-	// 4081 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xcc, 0x3b, 0x4d, 0x73, 0xdb, 0x48,
-	0x76, 0x06, 0x3f, 0x24, 0xf2, 0xf1, 0xab, 0xdd, 0x96, 0x35, 0xf0, 0xc7, 0xd8, 0x34, 0xbc, 0xf6,
-	0x68, 0xbc, 0x63, 0xc5, 0x96, 0xbd, 0xce, 0x8c, 0x77, 0x93, 0x1a, 0x8a, 0x82, 0x6d, 0xc6, 0x12,
-	0xa9, 0x6d, 0x52, 0xde, 0xda, 0xc9, 0x6e, 0x61, 0x21, 0xa0, 0x4d, 0xa1, 0x04, 0x02, 0x0c, 0x3e,
-	0x34, 0xd6, 0x1c, 0x72, 0x9b, 0xca, 0x25, 0x55, 0xa9, 0x4a, 0x8e, 0x39, 0xe4, 0x90, 0x4a, 0xe5,
-	0x9c, 0x5b, 0xaa, 0xf6, 0x37, 0xe4, 0x96, 0x4b, 0x4e, 0xb9, 0x24, 0xc7, 0x54, 0xe5, 0x90, 0xca,
-	0x39, 0xd5, 0x8d, 0xc6, 0x17, 0x49, 0x91, 0xf2, 0xce, 0x1e, 0x72, 0x22, 0xf0, 0xfa, 0xbd, 0xd7,
-	0xdd, 0xef, 0xbd, 0x7e, 0x5f, 0x0d, 0x42, 0xeb, 0x30, 0xb4, 0x7d, 0xdd, 0xeb, 0x4c, 0xad, 0xed,
-	0xa9, 0xe7, 0x06, 0x2e, 0xae, 0x4f, 0x39, 0x20, 0x7a, 0x53, 0xfe, 0x43, 0x82, 0xb5, 0xa1, 0x71,
-	0x42, 0x27, 0x3a, 0xc6, 0x50, 0x72, 0xf4, 0x09, 0x95, 0xa5, 0x76, 0x61, 0xab, 0x4a, 0xf8, 0x33,
-	0xbe, 0x0b, 0x35, 0x9f, 0x8f, 0x6a, 0xa6, 0x1e, 0xe8, 0x72, 0xb1, 0x5d, 0xd8, 0xaa, 0x13, 0x88,
-	0x40, 0x7b, 0x7a, 0xa0, 0xe3, 0xc7, 0x50, 0x0a, 0xce, 0xa7, 0x54, 0x2e, 0xb5, 0x0b, 0x5b, 0xcd,
-	0x9d, 0x1b, 0xdb, 0x59, 0xe6, 0xdb, 0x11, 0xe3, 0xed, 0xd1, 0xf9, 0x94, 0x12, 0x8e, 0x86, 0x5f,
-	0x00, 0x4c, 0x3d, 0x77, 0x4a, 0xbd, 0xc0, 0xa2, 0xbe, 0x5c, 0x6e, 0x17, 0xb7, 0x6a, 0x3b, 0x9b,
-	0x79, 0xa2, 0xb7, 0xf4, 0xfc, 0x9d, 0x6e, 0x87, 0x94, 0x64, 0x30, 0x95, 0x3f, 0x86, 0x12, 0xe3,
-	0x82, 0x2b, 0x50, 0xea, 0xbb, 0x0e, 0x45, 0x57, 0x30, 0xc0, 0xda, 0x30, 0xf0, 0x2c, 0x67, 0x8c,
-	0x24, 0x06, 0xfd, 0x13, 0xdf, 0x75, 0x50, 0x01, 0xd7, 0xa1, 0x72, 0xc8, 0xb8, 0x1c, 0x87, 0xef,
-	0x51, 0x91, 0xc1, 0x3b, 0x67, 0x9e, 0x8b, 0x4a, 0xca, 0x5f, 0x4a, 0xd0, 0x38, 0xa0, 0xbe, 0xaf,
-	0x8f, 0x69, 0xcf, 0xe4, 0x0b, 0xbf, 0x09, 0x15, 0x9b, 0x9a, 0x63, 0xea, 0xf5, 0x4c, 0xbe, 0xe3,
-	0x12, 0x49, 0xde, 0xb1, 0x0c, 0xeb, 0xd4, 0x09, 0xbc, 0xf3, 0x9e, 0x29, 0x17, 0xf8, 0x50, 0xfc,
-	0x8a, 0xdb, 0x50, 0x9d, 0xea, 0x5e, 0x60, 0x05, 0x96, 0xeb, 0xc8, 0xc5, 0xb6, 0xb4, 0x55, 0x7e,
-	0x59, 0x78, 0xfc, 0x94, 0xa4, 0x40, 0x7c, 0x1f, 0x6a, 0xc7, 0x7a, 0x60, 0x9c, 0x68, 0x96, 0x63,
-	0xd2, 0x0f, 0x72, 0x29, 0xc1, 0x01, 0x0e, 0xee, 0x31, 0xa8, 0xb2, 0x03, 0x95, 0x78, 0x9b, 0x18,
-	0x41, 0xf1, 0x94, 0x9e, 0x0b, 0xa9, 0xb3, 0x47, 0xbc, 0x01, 0xe5, 0x33, 0x36, 0xc4, 0x27, 0xaf,
-	0x92, 0xe8, 0x45, 0x79, 0x01, 0xf5, 0xb7, 0xf4, 0x7c, 0xdf, 0x75, 0xc6, 0x97, 0xa2, 0x2b, 0xc5,
-	0x74, 0x36, 0x34, 0x55, 0xc7, 0xf0, 0xce, 0xa7, 0x6c, 0x79, 0x6f, 0xe9, 0xb9, 0xbf, 0x8a, 0xb2,
-	0x2e, 0x28, 0xf1, 0x0e, 0x54, 0x26, 0x34, 0xd0, 0x85, 0xe6, 0x97, 0xa9, 0x2a, 0xc1, 0x53, 0xfe,
-	0xb7, 0x0c, 0x2d, 0x21, 0xe8, 0x03, 0x01, 0xc3, 0xf7, 0xa1, 0x31, 0xf5, 0x5c, 0x33, 0x34, 0xa8,
-	0xa7, 0x65, 0x2c, 0xac, 0x1e, 0x03, 0xfb, 0xb1, 0xa5, 0xd1, 0x3f, 0x0b, 0xa9, 0x63, 0x50, 0xcd,
-	0x8a, 0xe5, 0x0e, 0x31, 0xa8, 0x67, 0xe2, 0x7b, 0x50, 0x9f, 0x86, 0xc7, 0xb6, 0xe5, 0x9f, 0x68,
-	0x81, 0x35, 0xa1, 0xdc, 0x16, 0x4b, 0xa4, 0x26, 0x60, 0x23, 0x6b, 0x32, 0x6b, 0x5d, 0xa5, 0xcb,
-	0x5a, 0x17, 0xfe, 0x0c, 0x5a, 0x1e, 0x9d, 0xda, 0x96, 0xa1, 0x07, 0xd4, 0xd4, 0xde, 0x7b, 0xee,
-	0x44, 0x2e, 0xb7, 0xa5, 0xad, 0x2a, 0x69, 0xa6, 0xe0, 0x57, 0x9e, 0x3b, 0xe1, 0x3b, 0x89, 0x35,
-	0xad, 0x31, 0x19, 0xae, 0x71, 0xb4, 0x7a, 0x02, 0x7c, 0x4b, 0xcf, 0xd9, 0x42, 0x13, 0x32, 0x2d,
-	0x70, 0xe5, 0xf5, 0x76, 0x71, 0xab, 0x4a, 0x6a, 0x09, 0x6c, 0xe4, 0x62, 0x15, 0x6a, 0x86, 0x3b,
-	0x99, 0x7a, 0xd4, 0xf7, 0x99, 0x21, 0x55, 0xda, 0xd2, 0x56, 0x73, 0xe7, 0xd3, 0xfc, 0x4a, 0xbb,
-	0x29, 0x02, 0x33, 0xfd, 0x97, 0xa5, 0xfe, 0xa0, 0xaf, 0x92, 0x2c, 0x1d, 0xde, 0x86, 0xab, 0xa1,
-	0x13, 0x03, 0xa8, 0xa9, 0xf9, 0xd6, 0x77, 0x54, 0xae, 0xb6, 0xa5, 0xad, 0xc6, 0x4b, 0xe9, 0x09,
-	0x41, 0xd9, 0xb1, 0xa1, 0xf5, 0x1d, 0xc5, 0xcf, 0xe1, 0xba, 0x13, 0x4e, 0xb4, 0x49, 0xa4, 0x1f,
-	0x5f, 0xb3, 0x1c, 0x8d, 0x1b, 0xa5, 0x5c, 0xe3, 0x56, 0x2a, 0x3d, 0x25, 0xd8, 0x09, 0x27, 0x42,
-	0x7d, 0x7e, 0xcf, 0xd9, 0x65, 0x83, 0xb8, 0x0d, 0x40, 0xcf, 0xa8, 0x13, 0x44, 0x62, 0xaf, 0xb7,
-	0xa5, 0xad, 0x12, 0x63, 0x5f, 0xe5, 0x40, 0x2e, 0x77, 0x15, 0x5a, 0x34, 0x31, 0x31, 0x26, 0x17,
-	0x5f, 0x6e, 0x70, 0xe1, 0xdf, 0xce, 0x6f, 0x29, 0x6f, 0x87, 0xa4, 0x49, 0xf3, 0x76, 0xf9, 0x59,
-	0x8e, 0x8d, 0x6e, 0x8f, 0x5d, 0xb9, 0x19, 0xa9, 0x21, 0x05, 0x77, 0xec, 0xb1, 0x8b, 0x3f, 0x07,
-	0x94, 0x41, 0x9c, 0xea, 0x9e, 0x3e, 0x91, 0x5b, 0x6d, 0x69, 0xab, 0x4e, 0x32, 0x0c, 0x0e, 0x19,
-	0x18, 0x3f, 0x80, 0xa6, 0x70, 0x60, 0x67, 0xd4, 0xe3, 0xc2, 0x46, 0x1c, 0xb1, 0x11, 0x41, 0xdf,
-	0x45, 0x40, 0xfc, 0x35, 0xdc, 0xc8, 0x29, 0x56, 0x3b, 0x7e, 0xf1, 0x5c, 0xa3, 0x8e, 0xe1, 0x9a,
-	0xd4, 0x94, 0xaf, 0xb6, 0xa5, 0xad, 0xca, 0xcb, 0xf2, 0x7b, 0xdd, 0xf6, 0x29, 0xd9, 0xcc, 0xea,
-	0x7a, 0xf7, 0xc5, 0x73, 0x35, 0x42, 0x52, 0xfe, 0xb1, 0x00, 0xd7, 0x87, 0x96, 0x33, 0xb6, 0xe9,
-	0xac, 0xf9, 0xe7, 0xad, 0x52, 0xba, 0xb4, 0x55, 0xce, 0x19, 0x5b, 0x61, 0xb1, 0xb1, 0x4d, 0xf5,
-	0x73, 0xdb, 0xd5, 0x85, 0xf6, 0xd9, 0xa9, 0x28, 0x93, 0x9a, 0x80, 0x71, 0xad, 0x3f, 0x82, 0x06,
-	0xb3, 0x03, 0xdd, 0x60, 0xc6, 0xed, 0x86, 0x01, 0xf7, 0x49, 0xc9, 0x7e, 0xea, 0xc9, 0xd8, 0x20,
-	0x0c, 0x66, 0x74, 0x5d, 0x5e, 0xa0, 0xeb, 0xa5, 0x92, 0x5a, 0xbb, 0x8c, 0xa4, 0xfe, 0xa1, 0x08,
-	0xcd, 0xae, 0x3b, 0x99, 0xe8, 0x8e, 0xd9, 0x75, 0x1d, 0x87, 0x1a, 0x01, 0xd3, 0x92, 0x61, 0x5b,
-	0x6c, 0xde, 0x58, 0x4b, 0x91, 0x8b, 0x68, 0x44, 0xd0, 0x58, 0x4b, 0x5f, 0x41, 0x4d, 0x0f, 0x83,
-	0x13, 0x6d, 0x42, 0x83, 0x13, 0xd7, 0xe4, 0xf2, 0x68, 0xee, 0xc8, 0x79, 0x51, 0x76, 0xc2, 0xe0,
-	0xe4, 0x80, 0x8f, 0x13, 0xd0, 0x93, 0x67, 0xbc, 0x05, 0x28, 0x43, 0x1a, 0xb9, 0x21, 0x71, 0xc6,
-	0x53, 0x2c, 0xee, 0x88, 0x6e, 0x41, 0x95, 0x63, 0x0a, 0xb7, 0xc7, 0x8c, 0xa5, 0xc2, 0x00, 0x3c,
-	0x6a, 0x7c, 0x01, 0x88, 0x4f, 0x63, 0xb8, 0x76, 0xb2, 0xd4, 0xc8, 0xc5, 0x4b, 0x4f, 0x48, 0x2b,
-	0x1e, 0x8a, 0xd7, 0xfb, 0x18, 0xae, 0x4d, 0x3d, 0xf7, 0xc3, 0xb9, 0x16, 0xb8, 0xda, 0xb1, 0xe7,
-	0x9e, 0x52, 0x4f, 0x0b, 0x3d, 0x5b, 0x38, 0x0d, 0xc4, 0x87, 0x46, 0xee, 0x2e, 0x1f, 0x38, 0xf2,
-	0x6c, 0xfc, 0x18, 0xb0, 0xeb, 0x59, 0x63, 0xcb, 0xd1, 0x6d, 0x6d, 0xea, 0x59, 0x8e, 0x61, 0x4d,
-	0x75, 0x5b, 0x5e, 0xe7, 0xd8, 0x57, 0xe3, 0x91, 0xc3, 0x78, 0x00, 0x7f, 0x91, 0x41, 0x4f, 0x57,
-	0x5c, 0x89, 0x98, 0xc7, 0x23, 0x9d, 0x78, 0xe5, 0x4f, 0x60, 0x23, 0x8f, 0x2d, 0x84, 0x58, 0xe5,
-	0xf8, 0x38, 0x8b, 0x1f, 0x09, 0x43, 0x19, 0x03, 0xca, 0xab, 0x89, 0x9a, 0xfc, 0x38, 0x51, 0xef,
-	0x8c, 0x7a, 0xb3, 0x8a, 0x8a, 0xa0, 0xf1, 0xc6, 0x17, 0x89, 0xa9, 0x70, 0x91, 0x98, 0x94, 0x7f,
-	0x29, 0x27, 0x33, 0x0d, 0xc3, 0x63, 0xdf, 0xf0, 0xac, 0x63, 0xca, 0x42, 0x52, 0xe0, 0x4e, 0x2d,
-	0x43, 0x4c, 0x10, 0xbd, 0x60, 0x05, 0xea, 0x7e, 0x84, 0xc2, 0xcf, 0xb8, 0x88, 0x90, 0x39, 0x18,
-	0xfe, 0x1a, 0xd6, 0xfd, 0xf0, 0x98, 0xf9, 0x4c, 0x7e, 0x1a, 0x9a, 0x3b, 0x0f, 0xe7, 0x1c, 0x6b,
-	0x6e, 0xaa, 0xed, 0x61, 0x84, 0x4d, 0x62, 0x32, 0x16, 0x8b, 0x0c, 0xd7, 0xf1, 0xc3, 0x09, 0xf5,
-	0x58, 0x2c, 0x2a, 0x45, 0xb1, 0x28, 0x06, 0xf5, 0x4c, 0xfc, 0x29, 0x80, 0xc7, 0x22, 0x93, 0x1f,
-	0xb0, 0xf1, 0x32, 0x1f, 0xaf, 0x0a, 0x48, 0xcf, 0x64, 0x27, 0x37, 0xa1, 0xe7, 0x96, 0x26, 0xc2,
-	0x44, 0x0c, 0xe4, 0x76, 0xf6, 0x00, 0x9a, 0x53, 0xcf, 0x72, 0x3d, 0x2b, 0x38, 0xd7, 0x6c, 0x7a,
-	0x46, 0x23, 0x4d, 0x97, 0x49, 0x23, 0x86, 0xee, 0x33, 0x20, 0xbe, 0x03, 0xeb, 0x66, 0xe8, 0xe9,
-	0xc7, 0x36, 0xe5, 0xaa, 0xad, 0xbc, 0x2c, 0x05, 0x5e, 0x48, 0x49, 0x0c, 0xc4, 0x2a, 0x20, 0x3f,
-	0xd0, 0xbd, 0x20, 0xf6, 0xea, 0x6c, 0x41, 0x4c, 0xa7, 0xb5, 0x9d, 0x5b, 0xf9, 0x6d, 0xe7, 0xd2,
-	0x1f, 0xd2, 0xe4, 0x44, 0x09, 0x2c, 0x17, 0xeb, 0xe1, 0x72, 0xb1, 0x9e, 0xed, 0xc0, 0xa3, 0xba,
-	0xa9, 0x25, 0x1e, 0x84, 0xc7, 0x91, 0x0a, 0x69, 0x30, 0x68, 0x37, 0x06, 0xe2, 0x2f, 0x60, 0x2d,
-	0x72, 0xb6, 0x3c, 0x76, 0xd4, 0x76, 0x36, 0x16, 0x25, 0x89, 0x44, 0xe0, 0xe0, 0xdf, 0x40, 0xcb,
-	0x72, 0xac, 0xc0, 0xd2, 0xed, 0x43, 0xd7, 0x8f, 0xf2, 0xac, 0x06, 0x3f, 0xe7, 0xdb, 0x2b, 0xb4,
-	0xd8, 0xcb, 0x53, 0xbd, 0x5c, 0xdb, 0xd7, 0x03, 0xea, 0x07, 0x64, 0x96, 0x9d, 0xb2, 0x03, 0xeb,
-	0x42, 0xe3, 0xb8, 0x01, 0x55, 0xf5, 0x83, 0x61, 0x87, 0xbe, 0x75, 0x16, 0xe7, 0x94, 0x27, 0xba,
-	0x47, 0x4d, 0x24, 0xb1, 0x4c, 0xf2, 0x95, 0x6e, 0xd9, 0xee, 0x19, 0xf5, 0x50, 0x41, 0xf9, 0x31,
-	0xb4, 0x66, 0xf8, 0x33, 0xe4, 0x68, 0x06, 0x74, 0x85, 0x21, 0xab, 0xba, 0x67, 0x5b, 0xec, 0x4d,
-	0x52, 0xfe, 0x53, 0x82, 0xbb, 0x62, 0x79, 0x87, 0xb1, 0x0b, 0xa4, 0xe6, 0x88, 0x19, 0x70, 0x12,
-	0x14, 0x16, 0x9b, 0x77, 0xde, 0xae, 0x0a, 0xb3, 0x76, 0xb5, 0xd8, 0x41, 0x14, 0x3f, 0xce, 0x41,
-	0x94, 0x3e, 0xd2, 0x41, 0x94, 0x2f, 0x74, 0x10, 0xff, 0x5c, 0x80, 0xcf, 0x56, 0xec, 0x93, 0x50,
-	0x7f, 0xea, 0x3a, 0x3e, 0xc5, 0x77, 0x00, 0x92, 0x70, 0xc0, 0x82, 0xa0, 0xb4, 0xd5, 0x20, 0x19,
-	0xc8, 0xaa, 0x9d, 0xff, 0x0a, 0x2a, 0x9e, 0x60, 0xc5, 0xf7, 0xdb, 0xdc, 0xf9, 0x7a, 0xa1, 0x39,
-	0xac, 0x5a, 0xc7, 0xf6, 0xbe, 0xeb, 0x9e, 0x86, 0x53, 0x7e, 0xdc, 0x13, 0x8e, 0xf8, 0x0f, 0xa0,
-	0x4c, 0x3d, 0xcf, 0xf5, 0xb8, 0x6c, 0xe6, 0xab, 0x18, 0xee, 0xda, 0x54, 0x86, 0x40, 0x22, 0x3c,
-	0x56, 0x20, 0x88, 0xe3, 0x26, 0xc4, 0x13, 0xbf, 0x2a, 0x0f, 0x00, 0xd2, 0x29, 0x70, 0x8d, 0x99,
-	0x9a, 0x61, 0x50, 0xdf, 0x8f, 0xac, 0x8b, 0x59, 0x14, 0xb3, 0x2e, 0xe5, 0xfb, 0x02, 0x60, 0xb1,
-	0x64, 0x81, 0xce, 0xf5, 0xff, 0x3b, 0x59, 0xc5, 0x8f, 0xa1, 0xc1, 0xf4, 0xc5, 0x7c, 0x86, 0x1e,
-	0x58, 0x67, 0x91, 0x80, 0x92, 0x28, 0x9c, 0x1f, 0xbb, 0xc0, 0x84, 0x4a, 0x1f, 0x67, 0x42, 0xe5,
-	0x8f, 0x34, 0xa1, 0xb5, 0x0b, 0x4d, 0xe8, 0xdf, 0x8a, 0x70, 0x73, 0x5e, 0x0e, 0x89, 0xd5, 0x3c,
-	0x02, 0x14, 0xc5, 0x4d, 0xa6, 0x03, 0xcb, 0xa0, 0x47, 0x9e, 0xcd, 0x6d, 0xa7, 0x4a, 0xe6, 0xe0,
-	0xf8, 0x09, 0x5c, 0x9b, 0x85, 0x8d, 0x6c, 0x5f, 0x24, 0x4d, 0x8b, 0x86, 0xf0, 0x60, 0xce, 0xa8,
-	0x9e, 0x2d, 0x34, 0xaa, 0x05, 0x2b, 0x5b, 0x6c, 0x47, 0x79, 0x45, 0x95, 0x56, 0x2a, 0xaa, 0xbc,
-	0x44, 0x51, 0x89, 0x4d, 0xae, 0x7d, 0xbc, 0x4d, 0xae, 0xe7, 0x6c, 0x92, 0xa7, 0x6c, 0x51, 0x1a,
-	0x72, 0xe2, 0xb9, 0xe1, 0xf8, 0x44, 0xf3, 0x23, 0x31, 0xf0, 0x64, 0xa4, 0x92, 0x4f, 0xd9, 0x78,
-	0x4e, 0x12, 0xa1, 0xa5, 0xc2, 0x52, 0x9e, 0xe5, 0xac, 0xba, 0x0e, 0x15, 0x42, 0x4d, 0xcb, 0xa3,
-	0x06, 0xf3, 0x7d, 0x35, 0x58, 0x17, 0xf9, 0x01, 0x92, 0x32, 0x36, 0x5e, 0x50, 0xfe, 0xa6, 0x00,
-	0xad, 0xf8, 0x58, 0x8a, 0x4a, 0xef, 0x02, 0x03, 0xbf, 0x0b, 0xb5, 0xa4, 0x40, 0x4c, 0x6b, 0xbf,
-	0x18, 0x34, 0x17, 0x6f, 0x8b, 0x0b, 0xe2, 0x6d, 0xbe, 0xc0, 0x2c, 0x89, 0x4c, 0x39, 0x5b, 0x60,
-	0xde, 0x87, 0xaa, 0x28, 0x0e, 0xa8, 0x99, 0x97, 0x7c, 0x0a, 0xcf, 0x85, 0xc1, 0xb5, 0x4b, 0x86,
-	0xc1, 0x34, 0xbe, 0xad, 0xaf, 0x8e, 0x6f, 0x4a, 0x08, 0xb5, 0x38, 0x74, 0x51, 0xc7, 0x9c, 0xdd,
-	0xba, 0x34, 0xb7, 0xf5, 0x95, 0x75, 0xf1, 0x8f, 0xa0, 0x9e, 0x2d, 0xea, 0x44, 0x57, 0x42, 0x7a,
-	0x4a, 0x6a, 0x99, 0x5a, 0x4e, 0xf9, 0x6b, 0x29, 0x71, 0x38, 0x6c, 0x5e, 0x42, 0x0d, 0x6a, 0x4d,
-	0x83, 0xdf, 0xc3, 0xf4, 0x2f, 0x01, 0x32, 0x99, 0x47, 0x71, 0x75, 0xe6, 0x51, 0x9d, 0xc4, 0xaf,
-	0xca, 0xdf, 0x49, 0x69, 0xe2, 0x47, 0x1d, 0x93, 0x9b, 0xf3, 0xef, 0x61, 0x49, 0xc9, 0xd1, 0x29,
-	0x2e, 0x6c, 0x4a, 0x2d, 0x3d, 0x3a, 0x25, 0x6e, 0x97, 0x89, 0x3b, 0xff, 0x5b, 0x29, 0xa9, 0x55,
-	0xc4, 0x2e, 0x66, 0x93, 0x43, 0x69, 0x2e, 0x39, 0xcc, 0x4b, 0x84, 0x2d, 0xef, 0xd2, 0x12, 0x61,
-	0x89, 0xb3, 0x47, 0x4d, 0x6a, 0x5b, 0x67, 0xd4, 0x3b, 0xd7, 0x0c, 0x37, 0x74, 0x02, 0x2e, 0x53,
-	0x5e, 0xd0, 0xb7, 0xd2, 0xa1, 0x2e, 0x1b, 0x51, 0xfe, 0xa7, 0x08, 0x20, 0x56, 0xd7, 0x31, 0x4e,
-	0x57, 0xaf, 0xec, 0xa7, 0x50, 0xd1, 0x8d, 0x53, 0x8d, 0x37, 0xec, 0x0a, 0x5c, 0x36, 0xed, 0x85,
-	0x0e, 0xaf, 0x63, 0x9c, 0x6e, 0x77, 0x8c, 0xd3, 0x28, 0x29, 0xd6, 0xa3, 0x87, 0x39, 0x45, 0x17,
-	0x3f, 0x62, 0x5b, 0x43, 0x40, 0x67, 0xba, 0x6d, 0x99, 0x3a, 0xaf, 0x1a, 0xb3, 0xb1, 0x76, 0xeb,
-	0xc2, 0x05, 0xbc, 0x4b, 0x08, 0x22, 0x5d, 0xb5, 0xce, 0xf2, 0x00, 0xb6, 0xa0, 0xb9, 0x5e, 0xe2,
-	0xcd, 0xb9, 0xd3, 0x9a, 0x34, 0xcc, 0x72, 0xfd, 0xc4, 0xcf, 0x61, 0x5d, 0x6c, 0x10, 0x37, 0x01,
-	0x7a, 0x8e, 0x69, 0x9d, 0x59, 0x66, 0xa8, 0xdb, 0xe8, 0x0a, 0x7b, 0xef, 0x86, 0x93, 0xd0, 0xe6,
-	0x6e, 0x18, 0x49, 0xca, 0x5f, 0x49, 0xd0, 0x9a, 0x59, 0x0b, 0xbe, 0x03, 0x37, 0x8f, 0x66, 0x9a,
-	0x2b, 0x5d, 0xd7, 0xf3, 0x42, 0x5e, 0x80, 0xa0, 0x2b, 0x78, 0x13, 0xf0, 0x1e, 0xcd, 0x74, 0x6a,
-	0x38, 0x15, 0x92, 0xf0, 0x06, 0xa0, 0xee, 0x09, 0x35, 0x4e, 0xfd, 0x70, 0x72, 0x60, 0xf9, 0x13,
-	0x3d, 0x30, 0x4e, 0x50, 0x01, 0xdf, 0x80, 0xeb, 0xbc, 0xd3, 0xb2, 0x47, 0x87, 0xd4, 0xb3, 0x74,
-	0xdb, 0xfa, 0x8e, 0x46, 0x04, 0x45, 0x7c, 0x0d, 0x5a, 0x7b, 0x34, 0xee, 0x68, 0x44, 0xc0, 0x92,
-	0x72, 0x0c, 0xb7, 0x12, 0x39, 0xb1, 0x45, 0x76, 0x85, 0x86, 0xbb, 0x27, 0xba, 0x73, 0x19, 0x03,
-	0x55, 0xa0, 0x6a, 0xf9, 0x9a, 0xce, 0x69, 0x79, 0x7c, 0x4c, 0x3c, 0x61, 0xc5, 0xf2, 0x23, 0x96,
-	0xca, 0xbb, 0xc4, 0x4d, 0xbd, 0xb2, 0xdd, 0x6f, 0x57, 0xf3, 0x7c, 0x08, 0x4d, 0xa1, 0xee, 0x43,
-	0xea, 0x4d, 0xac, 0xc0, 0xe7, 0x06, 0xd6, 0x20, 0x33, 0x50, 0x65, 0x94, 0xb8, 0xa1, 0x23, 0xc7,
-	0x4f, 0x8a, 0xbd, 0x95, 0xec, 0x97, 0xa7, 0x40, 0xca, 0x6f, 0xa5, 0x8c, 0x57, 0xa5, 0xa7, 0x3f,
-	0x94, 0xdf, 0x0f, 0x71, 0x6a, 0x2c, 0x09, 0x8a, 0x69, 0x73, 0xfd, 0x4a, 0x66, 0xef, 0x25, 0x82,
-	0x63, 0x79, 0xa4, 0x6d, 0x4b, 0xe5, 0xa7, 0x20, 0x8b, 0xc5, 0x13, 0xaa, 0x1b, 0x27, 0xd4, 0x54,
-	0x1d, 0x73, 0xf0, 0x7e, 0x14, 0x87, 0xc6, 0xa5, 0x3b, 0x51, 0xde, 0xc1, 0x46, 0x5c, 0xa5, 0xdb,
-	0xae, 0x4f, 0x93, 0x48, 0xbb, 0xd2, 0x8d, 0xae, 0x10, 0xe9, 0x0c, 0xdf, 0xd8, 0xc6, 0x7e, 0xb0,
-	0xaa, 0xfe, 0x42, 0x82, 0x87, 0xc9, 0x6e, 0x85, 0x3b, 0x3b, 0x72, 0x74, 0xe3, 0xd4, 0x71, 0xbf,
-	0xe5, 0x0d, 0xf8, 0xd8, 0xd1, 0xfa, 0xab, 0xa7, 0xfa, 0x19, 0xd4, 0x52, 0x35, 0x31, 0x8b, 0x5b,
-	0xe9, 0x93, 0x20, 0xd1, 0x93, 0xaf, 0xfc, 0x3a, 0x71, 0xed, 0x22, 0x47, 0x9f, 0x59, 0xba, 0x34,
-	0x6b, 0x15, 0x69, 0xa0, 0x2f, 0x5c, 0x22, 0xd0, 0xff, 0x93, 0x04, 0x9b, 0x33, 0xe9, 0xcf, 0x25,
-	0xe7, 0x99, 0x4b, 0x67, 0x0a, 0x0b, 0xfa, 0xe5, 0x5f, 0x00, 0xb2, 0x75, 0x3f, 0xd0, 0xb2, 0xa1,
-	0x90, 0x19, 0x6a, 0x91, 0x5f, 0x36, 0x34, 0xd9, 0xd8, 0x30, 0x0d, 0x89, 0xf3, 0x6d, 0xd0, 0xd2,
-	0x82, 0x36, 0xa8, 0xf2, 0x01, 0xea, 0x62, 0xc9, 0x91, 0x9f, 0x5b, 0xb1, 0xd0, 0x24, 0xd0, 0x16,
-	0x3e, 0x3e, 0xd0, 0x16, 0xf3, 0x81, 0xb6, 0x91, 0x1c, 0xe0, 0x43, 0xcb, 0x19, 0x67, 0x5f, 0x5d,
-	0x67, 0x9c, 0x35, 0x46, 0xa1, 0xfd, 0x61, 0xa0, 0x07, 0x2b, 0x05, 0xb9, 0xaa, 0x8f, 0xa3, 0xfc,
-	0x77, 0x09, 0x6e, 0x2f, 0x62, 0x4c, 0x16, 0x67, 0xf4, 0x73, 0x13, 0x7c, 0x09, 0xc0, 0x37, 0xa6,
-	0x19, 0xae, 0x49, 0x45, 0x3f, 0x72, 0x89, 0x14, 0xaa, 0x1c, 0xb9, 0xeb, 0x9a, 0x2c, 0x1b, 0x6d,
-	0x44, 0x94, 0xa9, 0x3c, 0x78, 0xca, 0xca, 0x81, 0x71, 0xaa, 0x71, 0x07, 0x60, 0xe2, 0x8f, 0x89,
-	0x1e, 0xd0, 0x81, 0x68, 0xdb, 0x4a, 0x24, 0x03, 0x61, 0xe5, 0xd1, 0xc4, 0x1f, 0x8b, 0x74, 0x7d,
-	0x1a, 0x06, 0x0c, 0xab, 0xcc, 0xb1, 0xe6, 0xe0, 0x02, 0x97, 0x51, 0x26, 0xc7, 0x8e, 0x97, 0x16,
-	0x11, 0x6e, 0x0e, 0x8e, 0x15, 0xc8, 0xb5, 0xaa, 0x44, 0x3d, 0x91, 0x6f, 0x5f, 0x3d, 0x02, 0xa4,
-	0x9f, 0xe9, 0x96, 0xad, 0x1f, 0xdb, 0x89, 0xcb, 0xaf, 0x70, 0x17, 0x37, 0x07, 0xc7, 0x5b, 0xd0,
-	0x0a, 0xd9, 0x11, 0x4f, 0xcf, 0x36, 0x6f, 0x51, 0x95, 0xc8, 0x2c, 0x18, 0xef, 0xc2, 0xed, 0x63,
-	0xdb, 0x65, 0xa0, 0x58, 0x1f, 0x03, 0xe7, 0x48, 0xe0, 0xf8, 0x63, 0x5f, 0x06, 0xde, 0x60, 0x5a,
-	0x8a, 0xc3, 0x8c, 0x4c, 0x37, 0x4d, 0x16, 0x79, 0x79, 0x3f, 0xaa, 0x4a, 0xe2, 0x57, 0x16, 0xa4,
-	0x8c, 0xb8, 0x95, 0x39, 0xb4, 0x1c, 0x23, 0xba, 0xcd, 0xa8, 0x92, 0x19, 0x28, 0xc6, 0xe2, 0x52,
-	0xb3, 0xc1, 0x47, 0xa3, 0x9b, 0x4b, 0x16, 0xe0, 0x22, 0x39, 0xa9, 0x1f, 0xa6, 0x96, 0x47, 0x4d,
-	0x7e, 0x37, 0x21, 0x91, 0x19, 0xa8, 0xd0, 0xd9, 0xae, 0x6e, 0x9c, 0xda, 0xee, 0x98, 0xdf, 0x4a,
-	0x94, 0x48, 0x06, 0xa2, 0xfc, 0x12, 0x3e, 0x11, 0x16, 0xf7, 0x9a, 0x06, 0xfb, 0xba, 0x9f, 0xe9,
-	0xc1, 0xfd, 0x50, 0xd7, 0xfa, 0x7d, 0xda, 0x77, 0x9a, 0xe5, 0x9d, 0x18, 0x74, 0x17, 0x5a, 0xdc,
-	0x6d, 0x64, 0xc2, 0x9b, 0xb4, 0x3a, 0x43, 0x6d, 0xd8, 0xb9, 0x85, 0xae, 0x58, 0xc7, 0xbf, 0x4b,
-	0x49, 0x82, 0xf2, 0x9a, 0x06, 0x3c, 0x8e, 0xf9, 0x83, 0xf7, 0xcc, 0x6a, 0xfc, 0xa9, 0x6e, 0xac,
-	0x3c, 0x54, 0xb7, 0xa1, 0xea, 0xc4, 0xb8, 0xc2, 0xf5, 0xa5, 0x00, 0xdc, 0x87, 0xd2, 0x84, 0x1d,
-	0xb6, 0xe2, 0x92, 0xa6, 0xe0, 0xa2, 0x59, 0xb7, 0x0f, 0x5c, 0x93, 0xbe, 0x84, 0x43, 0x95, 0x0c,
-	0x7b, 0xc3, 0x91, 0xda, 0x1f, 0x11, 0xce, 0x47, 0x79, 0x06, 0x25, 0x36, 0xc2, 0xd2, 0xbe, 0x74,
-	0x0c, 0x5d, 0xc1, 0x18, 0x9a, 0xfd, 0x41, 0x5f, 0xcb, 0xc0, 0x24, 0xbc, 0x0e, 0xc5, 0xce, 0xfe,
-	0x3e, 0x2a, 0x28, 0xbf, 0x82, 0xfb, 0x4b, 0xa6, 0xba, 0xac, 0xf7, 0xd8, 0x84, 0x35, 0x5e, 0xff,
-	0x46, 0x91, 0xab, 0x4a, 0xc4, 0x9b, 0xe2, 0x24, 0x55, 0xd1, 0x6b, 0x1a, 0x88, 0xcb, 0xf9, 0x15,
-	0xac, 0x92, 0xba, 0xba, 0x90, 0xad, 0xab, 0xe7, 0xbd, 0x7e, 0x71, 0x91, 0xd7, 0xff, 0x2f, 0x29,
-	0x49, 0x40, 0x92, 0x09, 0xff, 0x9f, 0x78, 0xc0, 0x34, 0xe4, 0x96, 0x2e, 0xd1, 0x3b, 0x9e, 0xdf,
-	0x6f, 0x79, 0xd1, 0x7e, 0x7f, 0x7b, 0x03, 0x6a, 0xbb, 0x3a, 0xcb, 0x69, 0xf8, 0x9e, 0xf1, 0x8e,
-	0x38, 0xee, 0x12, 0x8f, 0x62, 0x77, 0xf2, 0x53, 0x64, 0x10, 0xf3, 0x1f, 0x32, 0xac, 0x0b, 0xa7,
-	0x21, 0x92, 0x81, 0xdb, 0x0b, 0x2d, 0x51, 0x74, 0x46, 0x48, 0x8c, 0x8c, 0x7f, 0x06, 0xd5, 0xc4,
-	0xd9, 0x88, 0xc4, 0xf2, 0xce, 0x32, 0x4a, 0x6a, 0x92, 0x94, 0x80, 0x51, 0x27, 0x49, 0xb3, 0x90,
-	0xc8, 0x9d, 0xe5, 0x6d, 0x71, 0x92, 0x12, 0xe0, 0xaf, 0xa0, 0x12, 0xa7, 0x10, 0x5c, 0x30, 0xb5,
-	0x05, 0x57, 0xce, 0xd9, 0x74, 0x85, 0x24, 0xe8, 0xf8, 0x31, 0x94, 0x7c, 0xea, 0x44, 0x9d, 0xbc,
-	0xda, 0xac, 0x82, 0xb3, 0x7d, 0x05, 0x8e, 0x86, 0xbb, 0x50, 0x67, 0xbf, 0x9a, 0x17, 0xb5, 0x19,
-	0x44, 0x63, 0xa4, 0x7d, 0x31, 0x59, 0x84, 0x47, 0x6a, 0x7e, 0xa6, 0x37, 0xf1, 0x47, 0x00, 0x9c,
-	0x49, 0x94, 0x62, 0x54, 0x96, 0xed, 0x36, 0x6e, 0x1e, 0x90, 0xaa, 0x9f, 0xf4, 0x11, 0x5e, 0xa4,
-	0xb9, 0x46, 0x75, 0x89, 0x86, 0x84, 0xa5, 0xa5, 0xdd, 0xb2, 0x47, 0x50, 0xd4, 0x8d, 0x53, 0x1e,
-	0x69, 0x6a, 0xb3, 0x97, 0x8b, 0x69, 0x79, 0x4a, 0x18, 0x12, 0x13, 0xcb, 0x7b, 0xdb, 0xfd, 0x96,
-	0xc7, 0x99, 0x8b, 0xc4, 0xc2, 0xea, 0x27, 0xc2, 0xd1, 0xf0, 0x2e, 0xd4, 0xc2, 0xb4, 0xea, 0x11,
-	0xd7, 0x21, 0x8b, 0xa5, 0x92, 0xa9, 0x8e, 0x48, 0x96, 0x88, 0x6d, 0xcb, 0x8f, 0xd2, 0x48, 0x1e,
-	0x9e, 0x2e, 0xda, 0x96, 0x48, 0x35, 0x49, 0x8c, 0x8c, 0x9f, 0xc4, 0xb9, 0x5a, 0x93, 0x53, 0xdd,
-	0x5c, 0x48, 0x95, 0x4b, 0xd6, 0x7a, 0xd0, 0x34, 0x58, 0xea, 0xaf, 0x25, 0x46, 0xd3, 0xe2, 0xa4,
-	0xca, 0x62, 0x7b, 0xcd, 0x56, 0x1f, 0xa4, 0x61, 0xe4, 0x8a, 0x91, 0x84, 0x55, 0x1c, 0xcc, 0xf8,
-	0x2d, 0xfc, 0x52, 0x56, 0x71, 0x6c, 0x17, 0xac, 0x92, 0xfa, 0x63, 0xc0, 0xaf, 0x16, 0xa3, 0xe4,
-	0x38, 0x16, 0xc4, 0x55, 0xce, 0xec, 0x47, 0x4b, 0x8d, 0x39, 0x16, 0x48, 0x6b, 0x3a, 0x93, 0x8c,
-	0x3f, 0x86, 0xd2, 0xd4, 0x72, 0xc6, 0x32, 0x5e, 0xa2, 0x43, 0x96, 0x93, 0x12, 0x8e, 0xc6, 0xd1,
-	0x5d, 0x67, 0x2c, 0x5f, 0x5b, 0x86, 0xee, 0x72, 0x74, 0xd7, 0x19, 0xe3, 0x3f, 0x87, 0xbb, 0xde,
-	0xf2, 0x32, 0x47, 0xde, 0xe0, 0x9c, 0x9e, 0x2f, 0xe4, 0xb4, 0xa2, 0x44, 0x22, 0xab, 0x98, 0xe3,
-	0x3f, 0x85, 0xab, 0xc9, 0x2d, 0x4b, 0x7c, 0x19, 0x22, 0x5f, 0xe7, 0x33, 0x3e, 0xfe, 0xb8, 0x1b,
-	0x94, 0x79, 0x3e, 0xd8, 0xcf, 0x7c, 0x0b, 0x30, 0x7b, 0xd3, 0x22, 0x6f, 0xf2, 0x49, 0x7e, 0xf2,
-	0x3b, 0x5d, 0xd3, 0x90, 0x8b, 0xf9, 0xb2, 0x43, 0x64, 0xa7, 0x0d, 0x79, 0xf9, 0x93, 0x25, 0x87,
-	0x28, 0xdb, 0xb8, 0xcf, 0x12, 0xe1, 0x6f, 0xe0, 0x9a, 0x3d, 0xdf, 0xd4, 0x97, 0x65, 0xce, 0x6b,
-	0xeb, 0xb2, 0x97, 0x00, 0x64, 0x11, 0x13, 0xfc, 0x26, 0xbd, 0xfc, 0xe5, 0xb5, 0x84, 0x7c, 0x63,
-	0x99, 0xa9, 0xe7, 0xaa, 0x8e, 0x3c, 0x21, 0xfe, 0x0d, 0x5c, 0x37, 0x16, 0x55, 0x25, 0xf2, 0x4d,
-	0xce, 0xf1, 0xd1, 0x25, 0x38, 0xc6, 0x2b, 0x5d, 0xcc, 0x08, 0x8f, 0xe0, 0xaa, 0x37, 0xdb, 0x72,
-	0x90, 0x6f, 0x71, 0xee, 0x0f, 0x2f, 0xb0, 0xc7, 0x19, 0x6c, 0x32, 0xcf, 0x20, 0x0a, 0x16, 0xf4,
-	0x54, 0xbe, 0xbd, 0x34, 0x58, 0xd0, 0x53, 0xc2, 0xd1, 0xf0, 0xcf, 0x01, 0x8d, 0x67, 0xd2, 0x55,
-	0xf9, 0x53, 0x4e, 0xfa, 0xe0, 0xa2, 0xec, 0x2e, 0x9f, 0xdb, 0xce, 0x91, 0x63, 0x0b, 0xe4, 0xf1,
-	0x05, 0x19, 0xb0, 0x7c, 0x67, 0x89, 0xf1, 0x5f, 0x94, 0x36, 0x93, 0x0b, 0xd9, 0x61, 0x0d, 0x36,
-	0xa3, 0x4e, 0x5a, 0xe2, 0xdb, 0x34, 0x83, 0xf7, 0xe1, 0xe4, 0xbb, 0x7c, 0xa2, 0xcf, 0x2f, 0x88,
-	0x20, 0xf3, 0x8d, 0x3b, 0xb2, 0xa1, 0x2f, 0x6a, 0xe7, 0xfd, 0x1a, 0x36, 0xc6, 0x0b, 0x92, 0x4c,
-	0xb9, 0xbd, 0x84, 0xfd, 0xc2, 0xac, 0x74, 0x21, 0x1b, 0x1c, 0xc2, 0xed, 0xf1, 0x92, 0x1c, 0x56,
-	0xbe, 0xc7, 0xa7, 0x79, 0x7a, 0xf9, 0x69, 0x62, 0x91, 0x2d, 0x65, 0xcb, 0x32, 0x99, 0x71, 0x9c,
-	0x6b, 0xca, 0xca, 0x92, 0xd8, 0x9e, 0x66, 0xa4, 0x29, 0x01, 0xb3, 0xdb, 0xf1, 0x6c, 0xa6, 0x2a,
-	0xdf, 0x5f, 0x62, 0xb7, 0x73, 0x79, 0x2d, 0x99, 0x67, 0xa0, 0xfc, 0x7d, 0x59, 0x7c, 0x65, 0x5a,
-	0x83, 0xf5, 0xee, 0xa0, 0xdf, 0x57, 0xbb, 0x23, 0x54, 0xc0, 0x0d, 0xa8, 0x8a, 0x17, 0x75, 0x0f,
-	0x15, 0xd9, 0xeb, 0xf0, 0x68, 0x77, 0xd8, 0x25, 0xbd, 0x5d, 0x15, 0x95, 0xf8, 0x07, 0xa7, 0x64,
-	0xb0, 0x77, 0xd4, 0x55, 0x09, 0x2a, 0xe3, 0x0a, 0x94, 0x86, 0x6a, 0x7f, 0x0f, 0xad, 0x61, 0x04,
-	0x75, 0xf6, 0xa4, 0x11, 0xb5, 0xab, 0xf6, 0x0e, 0x47, 0x68, 0x9d, 0x15, 0x18, 0x1c, 0xa2, 0x12,
-	0x32, 0x20, 0xa8, 0xc2, 0x26, 0x39, 0x50, 0x87, 0xc3, 0xce, 0x6b, 0x15, 0x55, 0x79, 0x65, 0xd1,
-	0x7d, 0x8b, 0x80, 0x71, 0x78, 0xb5, 0x3f, 0xf8, 0x05, 0xaa, 0xe1, 0x16, 0xd4, 0x8e, 0xfa, 0xe9,
-	0x54, 0x75, 0x7e, 0x99, 0x7c, 0xd4, 0xed, 0xaa, 0xc3, 0x21, 0x6a, 0xe0, 0x2a, 0x94, 0x23, 0x46,
-	0x4d, 0x56, 0xa9, 0x74, 0xf7, 0x07, 0x43, 0x55, 0x4b, 0x16, 0xd2, 0x4a, 0x61, 0xdd, 0x41, 0x7f,
-	0x78, 0x74, 0xa0, 0x12, 0x84, 0xf0, 0x06, 0xa0, 0x18, 0x43, 0x8b, 0x19, 0x5d, 0x65, 0x13, 0x1e,
-	0xf6, 0xfa, 0xaf, 0x11, 0xe6, 0x4f, 0x83, 0xfe, 0x6b, 0x74, 0x0d, 0x3f, 0x80, 0x7b, 0x44, 0xdd,
-	0x53, 0xf7, 0x7b, 0xef, 0x54, 0xa2, 0x1d, 0xf5, 0x3b, 0xdd, 0xb7, 0xfd, 0xc1, 0x2f, 0xf6, 0xd5,
-	0xbd, 0xd7, 0xea, 0x9e, 0x26, 0xd6, 0x3c, 0x44, 0x1b, 0x58, 0x86, 0x8d, 0xc3, 0x0e, 0x19, 0xf5,
-	0x46, 0xbd, 0x41, 0x9f, 0x8f, 0x8c, 0x3a, 0x7b, 0x9d, 0x51, 0x07, 0x5d, 0xc7, 0xf7, 0xe0, 0xd3,
-	0x45, 0x23, 0x1a, 0x51, 0x87, 0x87, 0x83, 0xfe, 0x50, 0x45, 0x9b, 0xfc, 0xf3, 0x89, 0xc1, 0xe0,
-	0xed, 0xd1, 0x21, 0xfa, 0x04, 0x5f, 0x83, 0x56, 0xf4, 0x9c, 0x22, 0xc8, 0x7c, 0x0b, 0x62, 0xf1,
-	0xda, 0x70, 0xd4, 0x19, 0x0d, 0xd1, 0x0d, 0x7c, 0x0b, 0x3e, 0xc9, 0xc3, 0x52, 0x82, 0x9b, 0x6c,
-	0x39, 0x44, 0xed, 0x74, 0xdf, 0xa8, 0x7b, 0x1a, 0x93, 0xf3, 0xe0, 0x95, 0x36, 0x1a, 0x1c, 0xf6,
-	0xba, 0xe8, 0x56, 0xa4, 0x16, 0xf5, 0x2d, 0xba, 0x8d, 0x3f, 0x81, 0x6b, 0xaf, 0xd5, 0x91, 0xb6,
-	0xdf, 0x19, 0x8e, 0xe2, 0x9d, 0x68, 0xbd, 0x3d, 0xf4, 0x29, 0x6e, 0xc3, 0xed, 0x05, 0x03, 0x29,
-	0xfb, 0x3b, 0xf8, 0x26, 0x6c, 0x76, 0xba, 0xa3, 0xde, 0xbb, 0x54, 0xa6, 0x5a, 0xf7, 0x4d, 0xa7,
-	0xff, 0x5a, 0x45, 0x77, 0xd9, 0xba, 0x18, 0x35, 0x9f, 0x6f, 0xc8, 0x66, 0xee, 0x77, 0x0e, 0xd4,
-	0xe1, 0x61, 0xa7, 0xab, 0xa2, 0x36, 0xfe, 0x11, 0xb4, 0x2f, 0x18, 0x4c, 0xd9, 0xdf, 0x63, 0xe6,
-	0xc1, 0xb0, 0x86, 0xdd, 0x37, 0xea, 0x41, 0x07, 0x29, 0xf1, 0x4a, 0xa3, 0xf7, 0x14, 0xf1, 0xfe,
-	0xa3, 0x2f, 0xf9, 0xad, 0x6a, 0xf6, 0xd3, 0x50, 0xfe, 0x55, 0xf4, 0xa0, 0xaf, 0xa2, 0x2b, 0xcc,
-	0x8e, 0xf6, 0xbf, 0x79, 0x1e, 0x7d, 0x12, 0xfd, 0xcd, 0x7e, 0x6f, 0x17, 0x15, 0xf8, 0xd3, 0x70,
-	0xb4, 0x87, 0x8a, 0x8f, 0xfe, 0xb5, 0x08, 0xb5, 0x4c, 0x31, 0xc6, 0x6c, 0xf4, 0xc8, 0x61, 0x39,
-	0x83, 0xb8, 0x59, 0xb8, 0x82, 0xaf, 0x42, 0x23, 0x8e, 0xb7, 0x99, 0x2b, 0x8b, 0x43, 0x56, 0x37,
-	0xf9, 0x01, 0x75, 0x0c, 0x71, 0x2f, 0x51, 0x60, 0xab, 0xeb, 0x84, 0xc1, 0x09, 0x75, 0x02, 0xcb,
-	0x48, 0xef, 0x45, 0x50, 0x11, 0x6f, 0x02, 0xee, 0x44, 0xf7, 0xd8, 0xdf, 0x65, 0xe0, 0x25, 0x36,
-	0x57, 0xec, 0xd7, 0x76, 0x43, 0xff, 0x1c, 0x95, 0x99, 0xd2, 0xc5, 0x0d, 0x73, 0xdf, 0x0d, 0x08,
-	0xd5, 0xcd, 0x73, 0xb4, 0xc6, 0x2c, 0x2f, 0x4e, 0xd8, 0x76, 0xa3, 0x1e, 0xcf, 0xcf, 0x43, 0x37,
-	0xd0, 0xd5, 0x0f, 0x06, 0xa5, 0x26, 0x8d, 0xf2, 0x53, 0xb4, 0x8e, 0x3f, 0x87, 0x07, 0x4b, 0xd1,
-	0x3e, 0x18, 0x34, 0xba, 0x8a, 0xa9, 0xb0, 0x2d, 0xc5, 0x57, 0x2e, 0x11, 0x75, 0x95, 0x69, 0x8b,
-	0xa5, 0xd7, 0xd3, 0xa9, 0xeb, 0x05, 0xd4, 0x14, 0x55, 0x61, 0x34, 0x08, 0x0c, 0x9f, 0x7b, 0xad,
-	0xbe, 0x1b, 0xbc, 0x72, 0x43, 0xc7, 0x44, 0x35, 0x66, 0x58, 0xc3, 0xcc, 0x07, 0x66, 0xc9, 0x48,
-	0x9d, 0xdf, 0xe7, 0xc4, 0x4d, 0xb1, 0x18, 0xda, 0x60, 0x3b, 0x1b, 0xb9, 0xee, 0x81, 0xee, 0x9c,
-	0x93, 0xa8, 0x4e, 0xf6, 0x51, 0x93, 0x31, 0xe1, 0x7c, 0x47, 0xd4, 0x9b, 0x58, 0x8e, 0x1e, 0xc4,
-	0x9b, 0x69, 0x31, 0xd1, 0x24, 0x9b, 0x61, 0xa2, 0xe1, 0x27, 0xb5, 0xe7, 0xf0, 0xeb, 0xae, 0x68,
-	0x29, 0xfa, 0x84, 0xa2, 0xab, 0x4c, 0xb4, 0x3d, 0x7e, 0xe9, 0xa4, 0x07, 0xd6, 0xb1, 0x4d, 0x23,
-	0xe7, 0x85, 0xf0, 0xa3, 0xb7, 0x00, 0xe9, 0x17, 0x15, 0xec, 0xd8, 0xa4, 0x6f, 0xe2, 0x5b, 0xf9,
-	0x6b, 0xd0, 0x4a, 0x61, 0xbf, 0x34, 0xf4, 0x77, 0x4f, 0x23, 0xc5, 0xa6, 0xc0, 0x0e, 0xd3, 0xa5,
-	0x8f, 0x0a, 0x8f, 0xbe, 0x97, 0xa0, 0x75, 0x38, 0xf3, 0x19, 0xe3, 0x1a, 0x14, 0xce, 0x9e, 0xa0,
-	0x2b, 0xfc, 0x97, 0x51, 0xb2, 0xdf, 0x1d, 0x54, 0xe0, 0xbf, 0xcf, 0x50, 0x91, 0xff, 0x3e, 0x47,
-	0x25, 0xfe, 0xfb, 0x13, 0x54, 0xe6, 0xbf, 0x2f, 0xd0, 0x1a, 0xff, 0xfd, 0x43, 0xb4, 0xce, 0x7f,
-	0xbf, 0x44, 0x15, 0xfe, 0xfb, 0x55, 0xe4, 0xec, 0xce, 0x9e, 0x3e, 0x41, 0x10, 0x3d, 0x3c, 0x45,
-	0xb5, 0xe8, 0x61, 0x07, 0xd5, 0xa3, 0x87, 0x67, 0xa8, 0xb1, 0xfb, 0x10, 0x14, 0xd7, 0x1b, 0x6f,
-	0xeb, 0x53, 0x96, 0x5c, 0xc4, 0x2e, 0xdd, 0x70, 0x27, 0x13, 0xd7, 0xd9, 0xd6, 0xe3, 0xff, 0x32,
-	0xbc, 0x29, 0xfe, 0x5f, 0x00, 0x00, 0x00, 0xff, 0xff, 0x67, 0xd6, 0xf8, 0x19, 0xdf, 0x30, 0x00,
-	0x00,
+func init() { proto.RegisterFile("PulsarApi.proto", fileDescriptor_39529ba7ad9caeb8) }
+
+var fileDescriptor_39529ba7ad9caeb8 = []byte{
+	// 6887 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xdc, 0x7c, 0x4b, 0x8c, 0x1c, 0xc7,
+	0x72, 0x20, 0xfb, 0x33, 0x33, 0xdd, 0xd1, 0xd3, 0x33, 0xc5, 0x9c, 0x21, 0x59, 0xfc, 0x0f, 0x9b,
+	0x22, 0x35, 0xa2, 0x24, 0x3e, 0x72, 0x48, 0x51, 0x12, 0x25, 0x3d, 0xa9, 0xa7, 0xa7, 0x49, 0xf6,
+	0xe3, 0xb0, 0x7b, 0x54, 0xdd, 0x43, 0xae, 0xb4, 0xfb, 0xb6, 0x5e, 0xb1, 0x2b, 0xa7, 0xa7, 0x30,
+	0xd5, 0x55, 0xfd, 0xaa, 0xaa, 0x87, 0x1c, 0x01, 0xbb, 0x87, 0x3d, 0x2e, 0xb0, 0xd8, 0xc3, 0x02,
+	0xbb, 0x0b, 0x1f, 0xfc, 0xe0, 0x93, 0x7d, 0x32, 0x60, 0xc0, 0x07, 0x03, 0x06, 0x0c, 0x1f, 0xfc,
+	0x39, 0xf8, 0xe4, 0x07, 0xfb, 0xf0, 0xe0, 0xa3, 0x7f, 0x07, 0xc3, 0xe7, 0xe7, 0x8b, 0x61, 0x23,
+	0xf2, 0x53, 0x9f, 0xee, 0xea, 0xee, 0x19, 0x49, 0x86, 0x04, 0x9d, 0xaa, 0x2a, 0x32, 0x22, 0x32,
+	0x33, 0x32, 0x32, 0x32, 0x22, 0x32, 0xb3, 0x60, 0x79, 0x67, 0x68, 0xfb, 0x86, 0x57, 0x1d, 0x58,
+	0xb7, 0x07, 0x9e, 0x1b, 0xb8, 0x64, 0x71, 0xc0, 0x00, 0xfc, 0xab, 0xf2, 0xfb, 0x39, 0x98, 0x6f,
+	0x77, 0xf7, 0x69, 0xdf, 0x20, 0x04, 0xf2, 0x8e, 0xd1, 0xa7, 0x6a, 0x66, 0x2d, 0xbb, 0x5e, 0xd4,
+	0xd8, 0x3b, 0xb9, 0x0a, 0x25, 0x9f, 0x95, 0xea, 0xa6, 0x11, 0x18, 0x6a, 0x6e, 0x2d, 0xbb, 0xbe,
+	0xa8, 0x01, 0x07, 0x6d, 0x19, 0x81, 0x41, 0xde, 0x85, 0x7c, 0x70, 0x34, 0xa0, 0x6a, 0x7e, 0x2d,
+	0xbb, 0xbe, 0xb4, 0x71, 0xfe, 0x76, 0x9c, 0xf9, 0x6d, 0xce, 0xf8, 0x76, 0xe7, 0x68, 0x40, 0x35,
+	0x86, 0x46, 0x1e, 0x00, 0x0c, 0x3c, 0x77, 0x40, 0xbd, 0xc0, 0xa2, 0xbe, 0x3a, 0xb7, 0x96, 0x5b,
+	0x2f, 0x6d, 0x9c, 0x4d, 0x12, 0x3d, 0xa5, 0x47, 0xcf, 0x0d, 0x7b, 0x48, 0xb5, 0x18, 0x66, 0xe5,
+	0x7f, 0x65, 0x21, 0x8f, 0x6c, 0x48, 0x01, 0xf2, 0x4d, 0xd7, 0xa1, 0xca, 0x29, 0x02, 0x30, 0xdf,
+	0x0e, 0x3c, 0xcb, 0xe9, 0x29, 0x19, 0x84, 0xfe, 0xc4, 0x77, 0x1d, 0x25, 0x4b, 0x16, 0xa1, 0xb0,
+	0x83, 0x6c, 0x5e, 0x0e, 0xf7, 0x94, 0x1c, 0xc2, 0xab, 0x87, 0x9e, 0xab, 0xe4, 0xf1, 0x6d, 0xd3,
+	0x75, 0x6d, 0x65, 0x0e, 0xdf, 0x1a, 0x4e, 0xf0, 0x81, 0x32, 0x4f, 0x8a, 0x30, 0xd7, 0x70, 0x82,
+	0xbb, 0x0f, 0x94, 0x05, 0xf1, 0x7a, 0x6f, 0x43, 0x29, 0x88, 0xd7, 0x07, 0xf7, 0x95, 0x22, 0xbe,
+	0x3e, 0xb2, 0x5d, 0x23, 0x50, 0x00, 0x6b, 0xdb, 0x72, 0x87, 0x2f, 0x6d, 0xaa, 0x94, 0x90, 0xc3,
+	0x96, 0x11, 0x50, 0x65, 0x11, 0xdf, 0x3a, 0x56, 0x9f, 0x2a, 0x65, 0x52, 0x86, 0x22, 0xbe, 0xf9,
+	0x81, 0xd1, 0x1f, 0x28, 0x4b, 0xd8, 0x0c, 0xd9, 0x0f, 0x65, 0x99, 0x94, 0x60, 0xa1, 0xe1, 0xf8,
+	0x81, 0xe1, 0x04, 0x8a, 0x82, 0x98, 0xdb, 0x6e, 0xd7, 0xb0, 0x19, 0x8b, 0xd3, 0xe1, 0x27, 0xe3,
+	0x43, 0xc8, 0x69, 0x28, 0x87, 0xa5, 0x0c, 0xb4, 0x42, 0x08, 0x2c, 0xc9, 0x2e, 0x35, 0x8d, 0xc0,
+	0x3a, 0xa4, 0xca, 0x6a, 0xe5, 0xff, 0x66, 0xa1, 0xfc, 0x8c, 0xfa, 0xbe, 0xd1, 0xa3, 0x0d, 0x93,
+	0x0d, 0xc4, 0x05, 0x28, 0xd8, 0xd4, 0xec, 0x51, 0xaf, 0x61, 0xb2, 0x11, 0xcc, 0x6b, 0xe1, 0x37,
+	0x51, 0x61, 0x81, 0x3a, 0x81, 0x77, 0xd4, 0x30, 0xd5, 0x2c, 0x2b, 0x92, 0x9f, 0x64, 0x0d, 0x8a,
+	0x03, 0xc3, 0x0b, 0xac, 0xc0, 0x72, 0x1d, 0x35, 0xb7, 0x96, 0x59, 0x9f, 0x7b, 0x98, 0x7d, 0xf7,
+	0xae, 0x16, 0x01, 0xc9, 0x75, 0x28, 0xbd, 0x34, 0x82, 0xee, 0xbe, 0x6e, 0x39, 0x26, 0x7d, 0xad,
+	0xe6, 0x43, 0x1c, 0x60, 0xe0, 0x06, 0x42, 0xc9, 0x39, 0x58, 0x30, 0xba, 0x07, 0xba, 0x4f, 0x03,
+	0x36, 0xa6, 0x39, 0x6d, 0xde, 0xe8, 0x1e, 0xb4, 0x69, 0x40, 0x2e, 0x03, 0x47, 0xd3, 0x7d, 0xeb,
+	0x2b, 0xaa, 0xce, 0x23, 0xb1, 0x56, 0x64, 0x90, 0xb6, 0xf5, 0x15, 0x25, 0x3b, 0x70, 0x76, 0xcf,
+	0xf2, 0xfc, 0x40, 0xef, 0xee, 0x0f, 0x9d, 0x03, 0xbd, 0xcf, 0x7b, 0xa4, 0x5b, 0xa6, 0xba, 0xb0,
+	0x96, 0x59, 0x2f, 0x6d, 0x5c, 0x4c, 0xaa, 0x46, 0xa2, 0xc7, 0xda, 0x0a, 0x23, 0xad, 0x21, 0x65,
+	0x58, 0x50, 0xd9, 0x88, 0x04, 0x4f, 0x14, 0xc8, 0x1d, 0xd0, 0x23, 0xa1, 0xcf, 0xf8, 0x4a, 0x56,
+	0x61, 0xee, 0x10, 0x8b, 0x98, 0x18, 0x8a, 0x1a, 0xff, 0xa8, 0x3c, 0x80, 0xc5, 0xa7, 0xf4, 0x68,
+	0xdb, 0x75, 0x7a, 0xc7, 0xa2, 0xcb, 0x4b, 0xba, 0x0d, 0x28, 0x34, 0x9c, 0x40, 0x33, 0x9c, 0x1e,
+	0x45, 0x0c, 0x3f, 0x30, 0xbc, 0x80, 0x51, 0xcd, 0x69, 0xfc, 0x03, 0x39, 0x51, 0x87, 0x0b, 0x7d,
+	0x4e, 0xc3, 0xd7, 0x8a, 0x0d, 0x4b, 0x75, 0xa7, 0xeb, 0x1d, 0x0d, 0x50, 0xb8, 0x4f, 0xe9, 0x91,
+	0x3f, 0xab, 0xb6, 0x45, 0x51, 0x1b, 0xd9, 0x80, 0x42, 0x9f, 0x06, 0x86, 0x98, 0x87, 0xd3, 0x26,
+	0x4e, 0x88, 0x57, 0xf9, 0xcb, 0x22, 0x2c, 0x0b, 0xd9, 0x3c, 0x13, 0x30, 0x72, 0x1d, 0xca, 0x03,
+	0xcf, 0x35, 0x87, 0x5d, 0xea, 0xe9, 0xb1, 0xf9, 0xbe, 0x28, 0x81, 0x4d, 0x39, 0xef, 0xe9, 0xcf,
+	0x87, 0xd4, 0xe9, 0xb2, 0xd1, 0xe0, 0xdd, 0x06, 0x09, 0x6a, 0x98, 0xe4, 0x1a, 0x2c, 0x0e, 0x86,
+	0x2f, 0x6d, 0xcb, 0xdf, 0xd7, 0x03, 0xab, 0x4f, 0x99, 0x65, 0xc8, 0x6b, 0x25, 0x01, 0x43, 0xcd,
+	0x1d, 0x99, 0xeb, 0xf9, 0xe3, 0xce, 0x75, 0xf2, 0x26, 0x2c, 0x7b, 0x74, 0x60, 0x5b, 0x5d, 0x23,
+	0xa0, 0xa6, 0xbe, 0xe7, 0xb9, 0x7d, 0x75, 0x6e, 0x2d, 0xb3, 0x5e, 0xd4, 0x96, 0x22, 0xf0, 0x23,
+	0xcf, 0xed, 0xb3, 0x9e, 0x48, 0x3d, 0xd5, 0x51, 0x86, 0xf3, 0x0c, 0x6d, 0x31, 0x04, 0x3e, 0xa5,
+	0x47, 0xd8, 0xd0, 0x90, 0x4c, 0x0f, 0x5c, 0x75, 0x61, 0x2d, 0xb7, 0x5e, 0xd4, 0x4a, 0x21, 0xac,
+	0xe3, 0x92, 0x3a, 0x94, 0xba, 0x6e, 0x7f, 0xe0, 0x51, 0xdf, 0xc7, 0x69, 0x50, 0x58, 0xcb, 0xac,
+	0x2f, 0x6d, 0x5c, 0x4e, 0xb6, 0xb4, 0x16, 0x21, 0xa0, 0x1d, 0x7a, 0x98, 0x6f, 0xb6, 0x9a, 0x75,
+	0x2d, 0x4e, 0x47, 0x6e, 0xc3, 0xe9, 0xa1, 0x23, 0x01, 0xd4, 0xe4, 0x2a, 0x5f, 0x5c, 0xcb, 0xac,
+	0x97, 0x1f, 0x66, 0xee, 0x68, 0x4a, 0xbc, 0x8c, 0x29, 0xff, 0x7d, 0x38, 0xe3, 0x0c, 0xfb, 0x52,
+	0xe9, 0x7d, 0xdd, 0x72, 0x74, 0x36, 0x33, 0xd4, 0x12, 0x9b, 0x63, 0x99, 0xbb, 0x1a, 0x71, 0x86,
+	0x7d, 0x31, 0x7c, 0x7e, 0xc3, 0xd9, 0xc4, 0x42, 0xb2, 0x06, 0x40, 0x0f, 0xa9, 0x13, 0x70, 0xb1,
+	0x2f, 0xae, 0x65, 0xd6, 0xf3, 0xc8, 0xbe, 0xc8, 0x80, 0x4c, 0xee, 0x75, 0x58, 0xa6, 0xa1, 0x8a,
+	0xa1, 0x5c, 0x7c, 0xb5, 0xcc, 0x84, 0x7f, 0x29, 0xd9, 0xa5, 0xa4, 0x1e, 0x6a, 0x4b, 0x34, 0xf1,
+	0x8d, 0xc3, 0x10, 0x63, 0x63, 0xd8, 0x3d, 0x57, 0x5d, 0xe2, 0xc3, 0x10, 0x81, 0xab, 0x76, 0xcf,
+	0x25, 0x6f, 0x81, 0x12, 0x43, 0x1c, 0x18, 0x9e, 0xd1, 0x57, 0x97, 0xd7, 0x32, 0xeb, 0x8b, 0x5a,
+	0x8c, 0xc1, 0x0e, 0x82, 0xc9, 0x0d, 0x58, 0x12, 0xcb, 0xc9, 0x21, 0xf5, 0x98, 0xb0, 0x15, 0x86,
+	0x58, 0xe6, 0xd0, 0xe7, 0x1c, 0x48, 0x3e, 0x83, 0xf3, 0x89, 0x81, 0xd5, 0x5f, 0x3e, 0xb8, 0xaf,
+	0x53, 0xa7, 0xeb, 0x9a, 0xd4, 0x54, 0x4f, 0xaf, 0x65, 0xd6, 0x0b, 0x0f, 0xe7, 0xf6, 0x0c, 0xdb,
+	0xa7, 0xda, 0xd9, 0xf8, 0x58, 0x6f, 0x3e, 0xb8, 0x5f, 0xe7, 0x48, 0x38, 0xea, 0xae, 0x67, 0x52,
+	0x5c, 0x1e, 0x98, 0x66, 0x10, 0x56, 0x4d, 0x49, 0xc2, 0x50, 0x31, 0x6e, 0xc2, 0xb2, 0x49, 0x6d,
+	0xeb, 0x90, 0x7a, 0xba, 0x21, 0xa4, 0xb9, 0xb2, 0x96, 0x59, 0xcf, 0x69, 0x65, 0x01, 0xae, 0x72,
+	0x71, 0x5e, 0x85, 0x52, 0xdf, 0xf0, 0x0e, 0xa8, 0xa7, 0xb3, 0x85, 0x6e, 0x95, 0xd9, 0x30, 0xe0,
+	0x20, 0xb6, 0x24, 0xad, 0x83, 0x12, 0xbc, 0x76, 0x2c, 0x53, 0xb7, 0xa9, 0xe1, 0x07, 0xfa, 0x4b,
+	0x2b, 0xf0, 0xd5, 0xb3, 0x38, 0x2e, 0xda, 0x12, 0x83, 0x6f, 0x23, 0x78, 0xd3, 0x0a, 0x7c, 0xac,
+	0x92, 0x63, 0xf6, 0x5d, 0x89, 0x78, 0x8e, 0x21, 0x96, 0x19, 0xf8, 0x99, 0x2b, 0xf0, 0xee, 0xc2,
+	0xca, 0xbe, 0xd5, 0xdb, 0xa7, 0x7e, 0xa0, 0xc7, 0x67, 0xa1, 0x2a, 0x07, 0xfb, 0xb4, 0x28, 0x6d,
+	0x47, 0xf3, 0xf1, 0x0d, 0x00, 0x67, 0x68, 0xdb, 0x3a, 0x37, 0x1c, 0xe7, 0xe3, 0x32, 0x2a, 0x62,
+	0x01, 0xb7, 0x6c, 0x04, 0xf2, 0xc3, 0xa1, 0x65, 0xaa, 0x17, 0xd8, 0x40, 0xb2, 0x77, 0xf2, 0x2e,
+	0xac, 0xa0, 0x1a, 0x32, 0x0b, 0xec, 0xb3, 0xe9, 0xa6, 0xf7, 0xfd, 0x9e, 0x7a, 0x91, 0xf5, 0x53,
+	0x71, 0x86, 0x7d, 0x66, 0x61, 0x7d, 0x9c, 0x71, 0xcf, 0xfc, 0x1e, 0xf9, 0x11, 0xac, 0x06, 0x6e,
+	0x60, 0xd8, 0xd2, 0x64, 0xfb, 0x3d, 0xae, 0xe8, 0x97, 0x18, 0xfe, 0x69, 0x56, 0xc6, 0x6d, 0xb2,
+	0xdf, 0x63, 0x6a, 0x7e, 0x1e, 0x0a, 0x1c, 0xd5, 0x32, 0xd5, 0xcb, 0x0c, 0x69, 0x81, 0x7d, 0x37,
+	0x4c, 0x72, 0x0f, 0x08, 0x6b, 0x74, 0x72, 0x16, 0x5f, 0x89, 0x37, 0x5e, 0x41, 0x84, 0x9d, 0xd8,
+	0x20, 0x57, 0xfe, 0x2c, 0x07, 0x67, 0xda, 0x96, 0xd3, 0xb3, 0xe9, 0xa8, 0x65, 0x4b, 0x1a, 0x9c,
+	0xcc, 0xb1, 0x0d, 0xce, 0x98, 0x1d, 0xc9, 0xa6, 0xdb, 0x91, 0x81, 0x71, 0x64, 0xbb, 0x86, 0x98,
+	0xd8, 0x39, 0x66, 0xd3, 0x4b, 0x02, 0xc6, 0x7a, 0x7a, 0x0b, 0xca, 0x38, 0xc5, 0x8d, 0x2e, 0xda,
+	0x2d, 0x77, 0x18, 0xa8, 0xf9, 0x78, 0x4f, 0x16, 0xc3, 0xb2, 0xd6, 0x30, 0x18, 0x99, 0xc6, 0x73,
+	0x29, 0xd3, 0x78, 0xea, 0x24, 0x98, 0xff, 0x3a, 0x93, 0x60, 0x61, 0x7c, 0x12, 0x8c, 0xd8, 0xf9,
+	0xc2, 0x5a, 0x66, 0xc4, 0xce, 0x27, 0xf5, 0xaa, 0x38, 0x41, 0xaf, 0xd2, 0x07, 0x12, 0xa6, 0x0f,
+	0xe4, 0x73, 0x58, 0xd9, 0xf4, 0xdc, 0x03, 0xea, 0xd5, 0xd1, 0x19, 0x09, 0x47, 0xf1, 0x2d, 0x50,
+	0x5e, 0x32, 0xb0, 0x1e, 0x48, 0x87, 0x4a, 0xcd, 0xb0, 0x76, 0x2d, 0x73, 0x78, 0xe8, 0x67, 0xe1,
+	0x42, 0xc9, 0xbd, 0x92, 0x2c, 0x2b, 0xe7, 0x1f, 0x95, 0x7f, 0xc8, 0xc1, 0x52, 0xcd, 0xed, 0xf7,
+	0x0d, 0xc7, 0xac, 0xb9, 0x8e, 0x43, 0xbb, 0x01, 0xda, 0x9d, 0xae, 0x6d, 0xa1, 0xb8, 0xa5, 0xdd,
+	0xe1, 0x8b, 0x5e, 0x99, 0x43, 0xa5, 0xdd, 0xf9, 0x10, 0x4a, 0xc6, 0x30, 0xd8, 0xd7, 0xfb, 0x34,
+	0xd8, 0x77, 0x4d, 0xc6, 0x75, 0x69, 0x43, 0x4d, 0x6a, 0x50, 0x75, 0x18, 0xec, 0x3f, 0x63, 0xe5,
+	0x1a, 0x18, 0xe1, 0x3b, 0x1a, 0x81, 0x18, 0x29, 0x5f, 0x58, 0xc5, 0xaa, 0x15, 0x61, 0xb1, 0xa5,
+	0xf5, 0x22, 0x14, 0x19, 0xa6, 0x58, 0xc8, 0x71, 0x48, 0x0a, 0x08, 0x60, 0x5e, 0xdc, 0x3b, 0xa0,
+	0xb0, 0x6a, 0xba, 0xae, 0x1d, 0x36, 0x95, 0xbb, 0x5c, 0x99, 0x3b, 0xda, 0xb2, 0x2c, 0x92, 0xed,
+	0x7d, 0x17, 0x56, 0x06, 0x9e, 0xfb, 0xfa, 0x48, 0x0f, 0x5c, 0x5d, 0xc8, 0x6c, 0xe8, 0xd9, 0x62,
+	0x19, 0x54, 0x58, 0x51, 0xc7, 0xe5, 0x32, 0xde, 0xf5, 0x6c, 0xf2, 0x2e, 0x10, 0xd7, 0xb3, 0x7a,
+	0x96, 0x63, 0xd8, 0xfa, 0xc0, 0xb3, 0x9c, 0xae, 0x35, 0x30, 0x6c, 0xa6, 0x15, 0x45, 0xed, 0xb4,
+	0x2c, 0xd9, 0x91, 0x05, 0xe4, 0x9d, 0x18, 0x7a, 0xd4, 0xe2, 0x02, 0x67, 0x2e, 0x4b, 0xaa, 0xb2,
+	0xe5, 0x77, 0x60, 0x35, 0x89, 0x2d, 0x84, 0x58, 0x64, 0xf8, 0x24, 0x8e, 0x2f, 0x44, 0xf6, 0x29,
+	0x94, 0xf7, 0xa8, 0x11, 0x0c, 0x3d, 0xaa, 0xef, 0xd9, 0x46, 0xcf, 0x67, 0xfa, 0x52, 0xda, 0xb8,
+	0x90, 0x94, 0xf7, 0x23, 0x8e, 0xf2, 0x08, 0x31, 0xb4, 0xc5, 0xbd, 0xd8, 0x57, 0xe5, 0xff, 0x65,
+	0x61, 0x31, 0x5e, 0x4c, 0x3e, 0x84, 0x33, 0xfe, 0x70, 0x30, 0x70, 0xbd, 0xc0, 0xe7, 0x6d, 0xf0,
+	0xe8, 0x9e, 0x47, 0xfd, 0x7d, 0x35, 0x13, 0xd7, 0xc4, 0x15, 0x89, 0x83, 0x6d, 0xd1, 0x38, 0x06,
+	0xf9, 0x09, 0x5c, 0x09, 0x49, 0x85, 0x28, 0x99, 0x8f, 0xac, 0x87, 0x3e, 0x57, 0x36, 0xce, 0xe3,
+	0xa2, 0x44, 0x4e, 0xd3, 0xe0, 0x2a, 0x9c, 0x0f, 0x79, 0xb1, 0x19, 0xc1, 0xe4, 0xcd, 0xbd, 0x2b,
+	0x35, 0x17, 0x67, 0x73, 0x4e, 0xe2, 0xed, 0x70, 0xb4, 0x1d, 0x81, 0x45, 0x3e, 0x81, 0xb0, 0x48,
+	0x0f, 0xdc, 0x81, 0xd5, 0xd5, 0x5f, 0xe1, 0xea, 0x4f, 0x3d, 0x3f, 0x69, 0x54, 0xc2, 0xfe, 0x76,
+	0x10, 0xe9, 0x85, 0xc0, 0xa9, 0xfc, 0x32, 0x03, 0x4a, 0x72, 0x0a, 0x50, 0x93, 0x2d, 0xbe, 0xd4,
+	0xc3, 0xf5, 0x6e, 0x64, 0x12, 0x70, 0xa8, 0x54, 0xaa, 0x34, 0x15, 0xcc, 0x4e, 0x54, 0xc1, 0x75,
+	0x50, 0xfa, 0xc6, 0xeb, 0xd0, 0x73, 0x17, 0xa6, 0x11, 0xad, 0xfc, 0x52, 0xdf, 0x78, 0x2d, 0x2c,
+	0x34, 0xb3, 0x8e, 0x63, 0xc3, 0x9d, 0x3f, 0xe1, 0x70, 0xff, 0x66, 0x06, 0x56, 0x44, 0xa7, 0xf8,
+	0xc8, 0xf9, 0x03, 0xd7, 0xf1, 0x69, 0xea, 0xe4, 0xce, 0x8c, 0x4f, 0xee, 0x0d, 0x28, 0x78, 0x82,
+	0x84, 0xf5, 0x67, 0x6c, 0x6d, 0x90, 0xaa, 0xac, 0x85, 0x78, 0xa9, 0xb2, 0xc8, 0x4d, 0x92, 0x45,
+	0xe5, 0xb7, 0x32, 0xb0, 0x1a, 0x6b, 0x60, 0x6d, 0xdf, 0xb0, 0x6d, 0x8a, 0xc1, 0x41, 0x9a, 0xe4,
+	0x33, 0xe3, 0x92, 0xbf, 0x0f, 0xc5, 0xae, 0xa4, 0x99, 0xd1, 0xc4, 0x08, 0xf1, 0x84, 0x6d, 0xfc,
+	0x1c, 0x0a, 0xe1, 0x94, 0x4d, 0xb3, 0x59, 0x99, 0xd9, 0x36, 0x2b, 0x9b, 0xb4, 0x59, 0x95, 0xbf,
+	0xc8, 0x40, 0xf9, 0x29, 0x3d, 0x6a, 0xef, 0x1b, 0x1e, 0x35, 0x71, 0x12, 0x90, 0x2a, 0x94, 0x0f,
+	0x42, 0x80, 0x6b, 0xf2, 0x10, 0x63, 0x69, 0x34, 0x9a, 0x7b, 0x1a, 0x47, 0xd1, 0x92, 0x14, 0xb8,
+	0x96, 0xef, 0x1b, 0xfe, 0x3e, 0x0b, 0xae, 0xfc, 0xf4, 0x78, 0x47, 0xc6, 0x5e, 0x5a, 0x0c, 0x93,
+	0x7c, 0x0a, 0xe7, 0x0c, 0xdb, 0x76, 0x5f, 0xb5, 0x86, 0x41, 0x6b, 0xaf, 0x85, 0x2b, 0xdd, 0x16,
+	0xf7, 0xe6, 0x8e, 0x92, 0x13, 0x67, 0x12, 0x56, 0xe5, 0x6f, 0x0b, 0xe1, 0xd4, 0x69, 0x0f, 0x5f,
+	0xfa, 0x5d, 0xcf, 0x7a, 0xc9, 0xa2, 0x3b, 0x36, 0x0b, 0xc5, 0x8c, 0xe1, 0x1f, 0xa4, 0x02, 0x8b,
+	0x3e, 0x47, 0x61, 0x2e, 0xae, 0x08, 0x2a, 0x13, 0x30, 0xf2, 0x19, 0x2c, 0xf8, 0xc3, 0x97, 0xe8,
+	0x27, 0x32, 0x8f, 0x61, 0x69, 0xe3, 0xe6, 0x58, 0x5c, 0x91, 0xa8, 0xea, 0x76, 0x9b, 0x63, 0x6b,
+	0x92, 0x0c, 0x97, 0xe8, 0xae, 0xeb, 0xf8, 0xc3, 0x3e, 0xf5, 0x70, 0x89, 0xce, 0xf3, 0x50, 0x4c,
+	0x82, 0x1a, 0x26, 0xc6, 0xd8, 0x1e, 0x2e, 0xd8, 0x7e, 0x80, 0xe5, 0x73, 0xac, 0xbc, 0x28, 0x20,
+	0x0d, 0x13, 0xbd, 0x9b, 0x90, 0x9e, 0x0d, 0xb1, 0x88, 0x92, 0x24, 0x90, 0x0d, 0xf0, 0x0d, 0x58,
+	0x1a, 0x78, 0x96, 0xeb, 0x59, 0xc1, 0x91, 0x6e, 0xd3, 0x43, 0xca, 0x97, 0x85, 0x39, 0xad, 0x2c,
+	0xa1, 0xdb, 0x08, 0x24, 0x57, 0x60, 0xc1, 0x1c, 0x7a, 0xc6, 0x4b, 0x9b, 0xb2, 0x75, 0xa0, 0xf0,
+	0x30, 0x1f, 0x78, 0x43, 0xaa, 0x49, 0x20, 0xa9, 0x83, 0xc2, 0x02, 0xdf, 0x78, 0x24, 0x5f, 0x9c,
+	0x1d, 0xc9, 0x2f, 0x31, 0xa2, 0x10, 0x96, 0x08, 0x75, 0xe1, 0x78, 0xa1, 0x2e, 0xf6, 0xc0, 0xa3,
+	0x86, 0xa9, 0x87, 0x5e, 0x16, 0x0b, 0xa3, 0x0a, 0x5a, 0x19, 0xa1, 0x35, 0x09, 0x24, 0xef, 0xc0,
+	0x3c, 0x8f, 0x35, 0x58, 0xe8, 0x54, 0xda, 0x58, 0x4d, 0xcb, 0x58, 0x69, 0x02, 0x87, 0xfc, 0x0c,
+	0x96, 0x2d, 0xc7, 0x62, 0x96, 0xd9, 0xf5, 0x79, 0x92, 0xa4, 0xcc, 0x9c, 0x82, 0xdb, 0x33, 0x46,
+	0xb1, 0x91, 0xa4, 0x7a, 0x38, 0xbf, 0x6d, 0x04, 0xd4, 0x0f, 0xb4, 0x51, 0x76, 0xe4, 0x33, 0xb8,
+	0x14, 0x85, 0xa7, 0x71, 0xcd, 0xd1, 0xfd, 0xc0, 0x08, 0x28, 0x0b, 0xb9, 0x0a, 0xda, 0x85, 0x10,
+	0xa7, 0x1d, 0x43, 0x69, 0x23, 0x06, 0x79, 0x00, 0xab, 0x7b, 0xae, 0xd7, 0xa5, 0x62, 0x9d, 0xe8,
+	0x7a, 0xd4, 0x60, 0x0d, 0x5d, 0x8e, 0x0d, 0x10, 0x61, 0x18, 0x6c, 0x8d, 0xa8, 0x89, 0x72, 0xd2,
+	0x82, 0xeb, 0xc9, 0xb1, 0xf2, 0x5c, 0xdb, 0x7e, 0x89, 0x69, 0x1c, 0x1c, 0x4d, 0xde, 0x04, 0xda,
+	0x55, 0x15, 0xe9, 0x9a, 0x5e, 0x8d, 0x0f, 0x92, 0x26, 0x70, 0xb7, 0x04, 0x6a, 0x9b, 0x76, 0x93,
+	0xb3, 0x9e, 0x06, 0x86, 0x7a, 0x3a, 0x6d, 0xe4, 0x13, 0x96, 0x42, 0x4b, 0x52, 0x90, 0x16, 0x2e,
+	0x7b, 0x31, 0x19, 0xc4, 0xdc, 0x79, 0x32, 0x55, 0x0f, 0xce, 0xc6, 0xc9, 0x76, 0x42, 0x2a, 0xb6,
+	0x36, 0x48, 0xe5, 0xa7, 0x03, 0xb7, 0xbb, 0xcf, 0x62, 0xbc, 0xbc, 0x16, 0x4e, 0x89, 0x3a, 0x02,
+	0x2b, 0x9b, 0xb0, 0x20, 0xe6, 0x1d, 0xe6, 0xe3, 0xea, 0xaf, 0xbb, 0xf6, 0xd0, 0xb7, 0x0e, 0x65,
+	0x96, 0x91, 0xb5, 0x4f, 0xc9, 0x60, 0x52, 0xef, 0x91, 0x61, 0xd9, 0xee, 0x21, 0xf5, 0x94, 0x2c,
+	0x59, 0x02, 0x78, 0x4a, 0x8f, 0x74, 0x51, 0x9a, 0xab, 0xbc, 0x0d, 0xcb, 0x23, 0xa3, 0x8e, 0xc4,
+	0x7c, 0xdc, 0x95, 0x53, 0x48, 0x5c, 0x37, 0x3c, 0xdb, 0xc2, 0xaf, 0x4c, 0xe5, 0xef, 0x33, 0x70,
+	0x55, 0x28, 0x4d, 0xe8, 0x13, 0x53, 0x93, 0x0d, 0x50, 0xe8, 0x46, 0xa4, 0x1b, 0x9d, 0xe4, 0x6c,
+	0xcf, 0x8e, 0xce, 0xf6, 0x74, 0x1f, 0x2f, 0x77, 0x32, 0x1f, 0x2f, 0x7f, 0x42, 0x1f, 0x6f, 0x6e,
+	0x92, 0x8f, 0x57, 0xf9, 0x83, 0x2c, 0xbc, 0x39, 0xa3, 0x9f, 0xe1, 0x3a, 0x7e, 0x05, 0x20, 0x8c,
+	0x1f, 0x7c, 0xb6, 0x10, 0x95, 0xb5, 0x18, 0x64, 0x56, 0xcf, 0xff, 0x4b, 0x6c, 0x7d, 0xcf, 0xb1,
+	0x49, 0xfa, 0x59, 0xea, 0x24, 0x9d, 0xd5, 0x8e, 0xdb, 0xdb, 0xae, 0x7b, 0x30, 0x1c, 0x30, 0x23,
+	0x1c, 0x72, 0x24, 0x3f, 0x82, 0x39, 0xea, 0x79, 0xae, 0xc7, 0x64, 0x33, 0x9e, 0xe8, 0x66, 0xeb,
+	0x78, 0x1d, 0x11, 0x34, 0x8e, 0x87, 0x39, 0x57, 0x31, 0xb1, 0x84, 0x78, 0xe4, 0x67, 0xe5, 0x06,
+	0x40, 0x54, 0x05, 0xe6, 0x86, 0xdb, 0xc3, 0x6e, 0x97, 0xfa, 0x3e, 0xd7, 0x36, 0xd4, 0x30, 0xd4,
+	0xb6, 0xca, 0xaf, 0xb3, 0x40, 0x44, 0x93, 0x05, 0x3a, 0x1b, 0xff, 0xaf, 0xa5, 0x15, 0x6f, 0x43,
+	0x19, 0xc7, 0x0b, 0x2d, 0x39, 0xcb, 0x20, 0x27, 0xbd, 0xd0, 0x64, 0xd9, 0x04, 0x15, 0xca, 0x9f,
+	0x4c, 0x85, 0xe6, 0x4e, 0xa8, 0x42, 0xf3, 0x13, 0xc3, 0x84, 0x0f, 0x40, 0x35, 0xcc, 0x43, 0x9c,
+	0xcf, 0x98, 0x54, 0xb3, 0x2d, 0x3f, 0xa0, 0x8e, 0x5c, 0xca, 0x78, 0xec, 0x72, 0x36, 0x2a, 0xdf,
+	0x16, 0xc5, 0x6c, 0x51, 0x4b, 0xe6, 0x03, 0x0a, 0xc7, 0xde, 0x6c, 0xf8, 0x55, 0x0e, 0x2e, 0x8c,
+	0x4b, 0x3e, 0xd4, 0xd3, 0x5b, 0x32, 0x40, 0xc5, 0x51, 0xb7, 0xba, 0x74, 0xd7, 0xb3, 0x85, 0xdb,
+	0x34, 0x06, 0x27, 0x77, 0x60, 0x65, 0x14, 0xd6, 0xb1, 0x7d, 0x91, 0x60, 0x48, 0x2b, 0x22, 0xad,
+	0x31, 0x35, 0xbe, 0x97, 0xaa, 0xc6, 0x29, 0x2d, 0x4b, 0xd7, 0xdc, 0xa4, 0x6a, 0xe4, 0x67, 0xaa,
+	0xc6, 0xdc, 0x14, 0xd5, 0x08, 0x67, 0xc1, 0xfc, 0xc9, 0x67, 0xc1, 0x42, 0x62, 0x16, 0xb0, 0xf4,
+	0x06, 0x8f, 0x5d, 0xf7, 0x3d, 0x77, 0xd8, 0xdb, 0xd7, 0x7d, 0x2e, 0x06, 0x16, 0xc1, 0x16, 0x92,
+	0xe9, 0x0d, 0x16, 0xc8, 0x72, 0xb4, 0x48, 0x58, 0x95, 0x7b, 0x89, 0x79, 0xb4, 0x08, 0x05, 0x8d,
+	0x9a, 0x96, 0x47, 0xbb, 0x68, 0x6d, 0x4b, 0xb0, 0x20, 0x02, 0x1f, 0x25, 0x13, 0x9b, 0x55, 0xd9,
+	0xca, 0x2f, 0xf3, 0xb0, 0x2c, 0x0d, 0x81, 0x0c, 0xb6, 0xd2, 0xa7, 0xd4, 0x55, 0x28, 0x85, 0x79,
+	0xf2, 0x28, 0x05, 0x2e, 0x41, 0x63, 0x7e, 0x57, 0x2e, 0xc5, 0xef, 0x4a, 0xe6, 0xd9, 0xf3, 0x22,
+	0xab, 0x14, 0xcf, 0xb3, 0x5f, 0x87, 0xa2, 0xc8, 0x91, 0x52, 0x33, 0x29, 0xf9, 0x08, 0x9e, 0x70,
+	0x87, 0xe6, 0x8f, 0xe9, 0x0e, 0x45, 0x7e, 0xce, 0xc2, 0x31, 0xfc, 0x9c, 0x73, 0x30, 0xc7, 0x57,
+	0xc7, 0x82, 0x5c, 0xed, 0xf9, 0x37, 0xa9, 0xc1, 0xc5, 0xa1, 0x4f, 0x3d, 0x5c, 0x88, 0x0f, 0x2d,
+	0x93, 0x9a, 0x7a, 0xb2, 0x4b, 0xc5, 0x98, 0x8f, 0xa1, 0x22, 0xe2, 0x8e, 0xc0, 0xdb, 0x89, 0x77,
+	0xf2, 0x4b, 0x58, 0x0d, 0xc9, 0x0c, 0x66, 0xea, 0xf4, 0x3e, 0x46, 0x05, 0xc0, 0x94, 0x68, 0x2d,
+	0xd9, 0x32, 0x49, 0x59, 0x65, 0x88, 0x18, 0x0b, 0x3c, 0x14, 0x2b, 0xb0, 0x46, 0x06, 0x63, 0x65,
+	0x38, 0x4a, 0xdc, 0xef, 0xe1, 0xed, 0x2f, 0xf1, 0x04, 0x16, 0x03, 0xb1, 0xa5, 0x9d, 0xdc, 0x84,
+	0x52, 0xf0, 0xda, 0xd1, 0xa9, 0x83, 0x0e, 0xaa, 0xa9, 0x2e, 0xc6, 0x65, 0x0c, 0xc1, 0x6b, 0xa7,
+	0xce, 0x0b, 0xc8, 0x43, 0x38, 0x2f, 0x7c, 0xb3, 0xa4, 0x1b, 0xc6, 0xfa, 0x59, 0x66, 0x43, 0x77,
+	0x4e, 0x20, 0xc4, 0x7d, 0x30, 0xec, 0x60, 0xe5, 0x5f, 0xb3, 0x50, 0x92, 0x2e, 0x20, 0x75, 0xcc,
+	0x51, 0xd5, 0xc9, 0x8c, 0xa9, 0xce, 0xcc, 0xed, 0x95, 0x37, 0x60, 0x31, 0xbe, 0x37, 0x20, 0x03,
+	0xba, 0xbb, 0x5a, 0x29, 0xb6, 0x25, 0x40, 0xde, 0x4e, 0xc9, 0x3c, 0xe7, 0xe5, 0x08, 0x8e, 0x26,
+	0x9f, 0xdf, 0x1a, 0x4f, 0x3e, 0x87, 0x69, 0xc7, 0xe3, 0xe5, 0x9f, 0xe7, 0xa7, 0xe4, 0x9f, 0xd7,
+	0xa0, 0x60, 0xf9, 0x3c, 0x27, 0xac, 0x2e, 0xc4, 0x65, 0xbc, 0x60, 0xf9, 0x2c, 0x1d, 0x4c, 0x2e,
+	0xc3, 0x3c, 0x4f, 0x9a, 0x27, 0x67, 0xb7, 0x00, 0x92, 0x87, 0x00, 0x27, 0x0b, 0x1a, 0x8a, 0x7d,
+	0xf9, 0x59, 0xf9, 0xd3, 0x0c, 0x90, 0x98, 0xfc, 0x35, 0xda, 0xa5, 0xd6, 0x20, 0xf8, 0x16, 0x86,
+	0x21, 0xd9, 0xa8, 0xdc, 0x49, 0x1a, 0x35, 0x49, 0x88, 0xf9, 0xc9, 0x42, 0xac, 0xfc, 0x22, 0x4a,
+	0xdb, 0x60, 0x3f, 0x98, 0x25, 0xfd, 0x16, 0x7a, 0x11, 0x5a, 0xed, 0xdc, 0x5a, 0xf6, 0xa4, 0x56,
+	0x3b, 0xcf, 0x4c, 0xa2, 0xfc, 0xac, 0xfc, 0x4d, 0x26, 0xcc, 0xad, 0x8a, 0x8e, 0x8f, 0xc6, 0xa7,
+	0x99, 0xb1, 0xf8, 0x34, 0x29, 0x44, 0x6c, 0xde, 0xf1, 0x85, 0xf8, 0x0e, 0x28, 0x1e, 0x15, 0xfb,
+	0x31, 0x47, 0x7a, 0xd7, 0x1d, 0x3a, 0x81, 0x9a, 0x93, 0x5b, 0x6a, 0xcb, 0x51, 0x51, 0x0d, 0x4b,
+	0xe2, 0xdb, 0xd0, 0xf9, 0xc4, 0x36, 0xf4, 0x78, 0x18, 0x30, 0x97, 0x16, 0x06, 0xfc, 0x53, 0x1e,
+	0x40, 0x26, 0x70, 0xba, 0x07, 0xb3, 0x7b, 0xf6, 0x11, 0x14, 0xb0, 0x3e, 0xb6, 0x2f, 0x94, 0x5d,
+	0xcb, 0x8e, 0x1b, 0xb3, 0x88, 0xd9, 0xed, 0x6a, 0xf7, 0x80, 0xc7, 0xf5, 0x06, 0x7f, 0x19, 0xd3,
+	0xad, 0xdc, 0x09, 0xc4, 0xd2, 0x06, 0xe5, 0xd0, 0xb0, 0x2d, 0x93, 0x87, 0x69, 0x71, 0xc7, 0x74,
+	0x7d, 0x62, 0x03, 0x9e, 0x87, 0x04, 0x7c, 0xac, 0x97, 0x0f, 0x93, 0x00, 0x6c, 0xd0, 0xd8, 0xd9,
+	0x8c, 0x0b, 0x63, 0x0b, 0x4d, 0xb8, 0x4d, 0x9e, 0xd8, 0x42, 0x49, 0xb3, 0x44, 0xf3, 0x27, 0xb0,
+	0x44, 0x0b, 0x13, 0x2c, 0x51, 0x72, 0x8d, 0xe5, 0xdb, 0x13, 0xd1, 0x1a, 0x5b, 0x79, 0x0b, 0x16,
+	0x84, 0x5c, 0x31, 0x1c, 0x6b, 0x38, 0xa6, 0x75, 0x68, 0x99, 0x43, 0xc3, 0x56, 0x4e, 0xe1, 0x77,
+	0x6d, 0xd8, 0x1f, 0xda, 0xfc, 0xc4, 0x44, 0xa6, 0xf2, 0xbf, 0x33, 0xb0, 0x3c, 0x22, 0x02, 0x72,
+	0x05, 0x2e, 0xec, 0x8e, 0xec, 0xca, 0xd6, 0x5c, 0xcf, 0x1b, 0x32, 0xcb, 0xae, 0x9c, 0x22, 0x67,
+	0x81, 0x6c, 0xd1, 0xd8, 0x16, 0x2f, 0xa3, 0x52, 0x32, 0x64, 0x15, 0x94, 0xda, 0x3e, 0xed, 0x1e,
+	0xf8, 0xc3, 0xfe, 0x33, 0xcb, 0xef, 0x63, 0xd6, 0x55, 0xc9, 0x92, 0xf3, 0x70, 0x86, 0x6d, 0xd1,
+	0x6e, 0xd1, 0x36, 0xf5, 0x2c, 0xc3, 0xb6, 0xbe, 0xa2, 0x9c, 0x20, 0x47, 0x56, 0x60, 0x79, 0x8b,
+	0xca, 0xad, 0x50, 0x0e, 0xcc, 0x57, 0x7e, 0x1d, 0x59, 0xad, 0x6a, 0xf7, 0x20, 0x74, 0x2f, 0x67,
+	0x6a, 0x5d, 0x9a, 0xac, 0xb3, 0x27, 0x90, 0x75, 0x6e, 0x82, 0xac, 0xbf, 0xbd, 0x10, 0x67, 0x64,
+	0xd8, 0xe6, 0x47, 0x87, 0xed, 0x25, 0x5c, 0x0c, 0x3b, 0x8e, 0xc3, 0x53, 0x13, 0x9d, 0xab, 0xed,
+	0xb3, 0xb3, 0x14, 0x33, 0x25, 0x50, 0x81, 0xa2, 0xe5, 0xeb, 0x06, 0xa3, 0x4d, 0xe6, 0xe5, 0x0b,
+	0x96, 0xcf, 0x59, 0x56, 0x9e, 0x87, 0x4b, 0xf2, 0x23, 0xdb, 0x7d, 0x35, 0x9b, 0xe7, 0x4d, 0x58,
+	0x12, 0xad, 0xdf, 0xa1, 0x5e, 0x9f, 0xcb, 0x34, 0xbb, 0x5e, 0xd6, 0x46, 0xa0, 0x95, 0x9f, 0x87,
+	0x83, 0xb6, 0xeb, 0xf8, 0x61, 0x82, 0x70, 0x26, 0xfb, 0x19, 0x01, 0xda, 0x45, 0x98, 0x63, 0x29,
+	0x9a, 0x64, 0x60, 0xc6, 0x61, 0x95, 0x3f, 0xcc, 0xc4, 0xdc, 0x0b, 0x7a, 0xf0, 0x8d, 0x2b, 0xfb,
+	0x26, 0xab, 0xda, 0x1d, 0x58, 0x95, 0xb4, 0x89, 0xf3, 0x1f, 0x6c, 0x59, 0xd3, 0x88, 0x14, 0x56,
+	0x74, 0x0c, 0xa4, 0xf2, 0x11, 0xa8, 0xa2, 0xf1, 0x1a, 0x35, 0xba, 0xfb, 0xd4, 0xac, 0x3b, 0x66,
+	0x6b, 0xaf, 0x23, 0x7d, 0xec, 0xa9, 0x3d, 0xc1, 0x2d, 0x1e, 0x99, 0x52, 0xe7, 0xc1, 0xba, 0xd5,
+	0xf3, 0x0c, 0xf4, 0x89, 0xaf, 0x42, 0xc9, 0xa3, 0xbe, 0x3b, 0xf4, 0xba, 0x34, 0x46, 0x29, 0x41,
+	0x0d, 0x93, 0x74, 0xa0, 0x1c, 0x22, 0xc4, 0x0c, 0xf4, 0x8f, 0x52, 0xed, 0x63, 0x82, 0xf7, 0x6d,
+	0x4d, 0xd0, 0x31, 0x7b, 0xbd, 0xe8, 0xc5, 0xbe, 0x52, 0x63, 0xbf, 0xdc, 0xc9, 0x62, 0xbf, 0xfc,
+	0xc4, 0xd8, 0xaf, 0x72, 0x0b, 0x16, 0xe3, 0x75, 0x8b, 0xc3, 0x6c, 0x6c, 0x9d, 0xe7, 0x19, 0x24,
+	0x39, 0x51, 0x94, 0x4c, 0xe5, 0xaf, 0xa2, 0xcd, 0x86, 0x9a, 0xed, 0xfa, 0x54, 0x22, 0xce, 0xf6,
+	0x17, 0x66, 0x6a, 0x87, 0x6a, 0xf8, 0xbe, 0xd5, 0x73, 0xa8, 0xb9, 0x99, 0xde, 0xd5, 0x89, 0xe5,
+	0xe4, 0x33, 0xb8, 0x38, 0xa9, 0x2c, 0xea, 0xfa, 0x34, 0x94, 0xb1, 0x6e, 0xc9, 0x1e, 0x7f, 0x0b,
+	0x4a, 0xff, 0x5d, 0x76, 0xeb, 0x77, 0x33, 0x70, 0x33, 0x9c, 0x05, 0xc2, 0x69, 0xd9, 0x75, 0x8c,
+	0xee, 0x81, 0xe3, 0xbe, 0x62, 0xc7, 0xf4, 0xcc, 0xd0, 0xa9, 0x9f, 0xd9, 0xd1, 0x8f, 0xa1, 0x14,
+	0x4d, 0x5f, 0x34, 0x53, 0x33, 0x3d, 0x07, 0x08, 0xe7, 0x6f, 0x5a, 0x46, 0x34, 0x97, 0xe6, 0x0a,
+	0xfd, 0x34, 0xf4, 0xf3, 0x44, 0x76, 0x6a, 0x44, 0xbe, 0x99, 0x51, 0xf9, 0x46, 0x01, 0x67, 0x76,
+	0x76, 0xc0, 0x59, 0xf9, 0xb7, 0x0c, 0x9c, 0x1d, 0x09, 0xc3, 0x8f, 0x59, 0xcf, 0x58, 0x58, 0x9d,
+	0x4d, 0x39, 0xbe, 0xf6, 0x0e, 0x28, 0xb6, 0x31, 0xe2, 0x78, 0x63, 0x37, 0x73, 0xec, 0xe4, 0xe2,
+	0x92, 0x6d, 0xc4, 0xdd, 0xee, 0x94, 0x53, 0x49, 0xf9, 0xb4, 0x53, 0x49, 0x23, 0xa1, 0xe6, 0xdc,
+	0x58, 0xa8, 0xf9, 0x36, 0x2c, 0xc9, 0x56, 0xe8, 0x1e, 0x35, 0xcc, 0x23, 0x75, 0x3e, 0x16, 0x1f,
+	0x87, 0xcd, 0xd6, 0xb0, 0xa8, 0xf2, 0x1a, 0x16, 0x85, 0x00, 0xb8, 0x2f, 0x32, 0xa3, 0xdb, 0xe1,
+	0xe2, 0x9c, 0x3d, 0xb9, 0x0f, 0x9f, 0x4b, 0xfa, 0xf0, 0xe5, 0x70, 0x35, 0xd9, 0xb1, 0x9c, 0x5e,
+	0xfc, 0xd3, 0x75, 0x7a, 0x95, 0xe7, 0xd1, 0xfc, 0x13, 0x0a, 0x81, 0xdb, 0x0c, 0x33, 0x87, 0x65,
+	0xd6, 0x2e, 0x55, 0xe5, 0xff, 0xcf, 0xc1, 0xa5, 0x34, 0xc6, 0x5a, 0x7a, 0x9e, 0x6a, 0xac, 0x82,
+	0x0f, 0x00, 0x58, 0xc7, 0xf4, 0x2e, 0xa6, 0x0e, 0xb2, 0xb3, 0x5c, 0x94, 0x22, 0x43, 0xae, 0xb9,
+	0x26, 0xe6, 0x58, 0xca, 0x9c, 0x32, 0x92, 0x07, 0x4b, 0xc4, 0x30, 0xa0, 0x8c, 0x62, 0xae, 0x00,
+	0xf4, 0xfd, 0x9e, 0x66, 0x04, 0xb4, 0x25, 0x0e, 0xee, 0x64, 0xb4, 0x18, 0x04, 0x0d, 0x7f, 0xdf,
+	0xef, 0x89, 0x24, 0xd4, 0x60, 0x18, 0x20, 0xd6, 0x1c, 0xc3, 0x1a, 0x83, 0x0b, 0x5c, 0xa4, 0x0c,
+	0xe7, 0xba, 0x3a, 0x1f, 0xe2, 0x26, 0xe0, 0xb8, 0x87, 0x18, 0xdf, 0x88, 0x13, 0x59, 0xb2, 0x04,
+	0x0c, 0xf9, 0x19, 0x87, 0x86, 0x65, 0x63, 0xa2, 0x42, 0x3a, 0x27, 0xdc, 0x15, 0x1e, 0x83, 0x93,
+	0x75, 0x58, 0x1e, 0xa2, 0x5d, 0x89, 0x0c, 0x0a, 0x8b, 0xa5, 0xf3, 0xda, 0x28, 0x98, 0x6c, 0xc2,
+	0xa5, 0x97, 0xb6, 0x8b, 0x20, 0x39, 0x1e, 0x2d, 0x67, 0x57, 0xe0, 0xf8, 0xe2, 0x34, 0x46, 0x41,
+	0x9b, 0x8a, 0x83, 0x4a, 0x66, 0x98, 0xa6, 0x47, 0x7d, 0x9f, 0x65, 0x5e, 0x8a, 0x9a, 0xfc, 0x44,
+	0x77, 0xaa, 0x2b, 0x4f, 0x1e, 0xb4, 0x2d, 0xa7, 0xcb, 0x8f, 0x2a, 0x16, 0xb5, 0x11, 0x28, 0x9e,
+	0x48, 0x63, 0xab, 0x33, 0xcf, 0xb0, 0xb0, 0x77, 0xa4, 0x15, 0x72, 0xaa, 0xbf, 0x1e, 0x58, 0x1e,
+	0x35, 0xd9, 0x2e, 0x58, 0x46, 0x1b, 0x81, 0x8a, 0x31, 0xdb, 0x34, 0xba, 0x07, 0xb6, 0xdb, 0x63,
+	0xfb, 0x5d, 0x79, 0x2d, 0x06, 0x89, 0xb9, 0x74, 0xe8, 0x5f, 0xe3, 0x6e, 0x9a, 0x22, 0xf8, 0x24,
+	0xa0, 0x95, 0x2f, 0xe0, 0x9c, 0xd0, 0xcc, 0xc7, 0x34, 0xd8, 0x36, 0xfc, 0xd8, 0x4e, 0xe4, 0x37,
+	0x5c, 0x75, 0xe2, 0xfb, 0x3c, 0xa3, 0xbc, 0x43, 0xc5, 0xaf, 0xc1, 0x32, 0x33, 0x56, 0x31, 0x9f,
+	0x2c, 0x33, 0x3b, 0x48, 0x2e, 0xdb, 0x89, 0x86, 0xce, 0x58, 0xfd, 0xfe, 0x2b, 0x5c, 0x0e, 0xfb,
+	0x81, 0x09, 0x17, 0xdd, 0xa4, 0x36, 0x0d, 0xa8, 0x3e, 0x90, 0xdb, 0x9a, 0xc7, 0xf0, 0x02, 0x2f,
+	0x48, 0x0e, 0xcf, 0x0c, 0xef, 0x60, 0x8b, 0xd1, 0xcb, 0x9d, 0xae, 0xca, 0x6f, 0x67, 0x43, 0x97,
+	0xfe, 0x31, 0x0d, 0x98, 0x3b, 0xe5, 0xb7, 0xf6, 0x50, 0x7b, 0xfd, 0x81, 0xd1, 0x9d, 0x39, 0xb9,
+	0x2f, 0x41, 0xd1, 0x91, 0xb8, 0xc2, 0xa0, 0x47, 0x00, 0xd2, 0x84, 0x3c, 0xcb, 0x17, 0xe6, 0xa6,
+	0x6c, 0xbd, 0xa6, 0xd5, 0x7a, 0x9b, 0x65, 0x0f, 0x61, 0xa7, 0xae, 0xb5, 0x1b, 0xed, 0x4e, 0xbd,
+	0xd9, 0xd1, 0x18, 0x1f, 0xb4, 0xf7, 0xcc, 0x6a, 0xe3, 0xe9, 0x9c, 0x20, 0xa0, 0x9e, 0x23, 0x56,
+	0xf0, 0x32, 0x87, 0xee, 0x70, 0x60, 0x68, 0xef, 0x7d, 0x1d, 0xcf, 0x17, 0x88, 0x10, 0x87, 0xdb,
+	0x7b, 0xff, 0x89, 0xe1, 0xef, 0x57, 0xee, 0x41, 0x1e, 0x6b, 0xc0, 0x50, 0x33, 0xaa, 0x43, 0x39,
+	0x85, 0x07, 0xf6, 0x9b, 0xad, 0xa6, 0x1e, 0x83, 0x65, 0xc8, 0x02, 0xe4, 0xaa, 0xdb, 0xdb, 0x4a,
+	0xb6, 0xf2, 0xc7, 0x19, 0xb8, 0x3e, 0xa5, 0xcd, 0xc7, 0x35, 0x87, 0x67, 0x61, 0x9e, 0xb7, 0x84,
+	0xad, 0xff, 0x45, 0x4d, 0x7c, 0x91, 0x6b, 0x50, 0xd8, 0xb3, 0xec, 0x80, 0xe2, 0xac, 0x49, 0xc4,
+	0x12, 0x21, 0x78, 0xb4, 0x5f, 0xf9, 0xd1, 0x7e, 0xe1, 0x2e, 0x7f, 0x97, 0x45, 0x62, 0x32, 0x25,
+	0x2d, 0x76, 0xf9, 0x05, 0x10, 0xe3, 0x91, 0x33, 0xa2, 0x0b, 0xec, 0xc4, 0x11, 0xeb, 0x04, 0xee,
+	0xbc, 0xcc, 0x6a, 0xf4, 0x65, 0x00, 0x71, 0x8c, 0x29, 0xa6, 0xa4, 0x02, 0x32, 0xaa, 0x05, 0xb9,
+	0x51, 0x2d, 0x48, 0x1b, 0xb5, 0xec, 0xd7, 0x18, 0xb5, 0xff, 0x93, 0x81, 0x4b, 0xa9, 0xad, 0x3f,
+	0xa6, 0x03, 0x32, 0xa3, 0x13, 0xe1, 0x66, 0x42, 0x8e, 0x8d, 0x4b, 0xb4, 0x99, 0x90, 0x94, 0x79,
+	0x76, 0xa4, 0x55, 0xbf, 0xc8, 0xc0, 0xb9, 0xb1, 0x56, 0xed, 0x0e, 0x4c, 0x23, 0xa0, 0x23, 0x35,
+	0x66, 0x46, 0x6b, 0xbc, 0x0c, 0xe0, 0xd0, 0x57, 0x7a, 0x42, 0x1d, 0x8a, 0x0e, 0x7d, 0xc5, 0x75,
+	0x0b, 0xe5, 0xc6, 0x27, 0xbb, 0x29, 0x51, 0x78, 0xcb, 0xca, 0x02, 0x2a, 0xd0, 0x66, 0xb6, 0xf0,
+	0x4b, 0xb8, 0x30, 0xd6, 0x40, 0x14, 0x1b, 0x73, 0xd3, 0xbf, 0x99, 0xd0, 0x2a, 0x4e, 0x98, 0xf7,
+	0x7c, 0x4c, 0x03, 0x71, 0x1d, 0x69, 0x06, 0xc7, 0x50, 0xce, 0xd9, 0xf8, 0xa6, 0xcd, 0xb8, 0x2b,
+	0x97, 0x4b, 0x71, 0xe5, 0x2a, 0xff, 0x9c, 0x01, 0x75, 0xb4, 0xc2, 0xef, 0x89, 0x23, 0x12, 0xf9,
+	0xd1, 0xf9, 0x63, 0x6c, 0xdc, 0x8c, 0xf7, 0x77, 0x2e, 0xad, 0xbf, 0xff, 0x2d, 0xde, 0xdd, 0x96,
+	0xc7, 0xce, 0x80, 0xd0, 0x6f, 0x22, 0xe7, 0xa8, 0x95, 0xb9, 0xb5, 0xec, 0xac, 0x56, 0x56, 0xfe,
+	0x3c, 0x03, 0x6b, 0x93, 0xea, 0xff, 0x9e, 0x88, 0xfd, 0x78, 0x31, 0x40, 0xe5, 0x39, 0x5c, 0x96,
+	0x29, 0x83, 0x6e, 0x8d, 0x1d, 0x2f, 0x14, 0xdb, 0x8c, 0x1a, 0x6f, 0xe8, 0x6c, 0xb3, 0x3d, 0x17,
+	0x74, 0xc3, 0x29, 0x80, 0x49, 0xb8, 0x7c, 0xd0, 0x6d, 0x98, 0x95, 0xff, 0x99, 0x81, 0x2b, 0x93,
+	0x18, 0x1f, 0x4f, 0x3e, 0xb1, 0x00, 0x21, 0x73, 0xf2, 0x00, 0x21, 0x71, 0x40, 0xe1, 0xe7, 0x50,
+	0x16, 0x6d, 0x69, 0xd2, 0x57, 0x9d, 0xd7, 0xce, 0xac, 0xaa, 0x79, 0x8e, 0x51, 0x0f, 0x02, 0x5b,
+	0xf7, 0x69, 0xd7, 0x75, 0xcc, 0x58, 0x3e, 0x12, 0x73, 0x8c, 0x9d, 0xc0, 0x6e, 0x73, 0x78, 0xd4,
+	0xff, 0x30, 0x09, 0xc9, 0xfb, 0xff, 0xab, 0x68, 0x49, 0xe1, 0x75, 0x1e, 0xb7, 0xdb, 0xdf, 0xff,
+	0x64, 0x68, 0xe5, 0xf7, 0x22, 0x63, 0x53, 0x35, 0xa3, 0xe3, 0x27, 0x1d, 0xf7, 0x18, 0xa2, 0xfd,
+	0x8f, 0xea, 0x5e, 0xf2, 0xac, 0x4d, 0x9e, 0x2d, 0x08, 0x31, 0x48, 0xe5, 0xef, 0xa2, 0x19, 0x3b,
+	0xd6, 0xe6, 0x1f, 0xd0, 0xd0, 0x3c, 0x81, 0xc5, 0xf8, 0x66, 0xee, 0xd7, 0x3f, 0xe7, 0x89, 0x79,
+	0xab, 0x8b, 0x91, 0xc0, 0xe2, 0x4c, 0xbf, 0xd3, 0x71, 0xfe, 0xf1, 0x48, 0xd3, 0xf3, 0x69, 0xbb,
+	0x3a, 0xf1, 0xd6, 0x8e, 0x74, 0xeb, 0x1f, 0x23, 0x6f, 0x35, 0xad, 0x5b, 0x3f, 0x20, 0x55, 0xf8,
+	0xa3, 0x4c, 0x68, 0xf5, 0xea, 0x8e, 0xf9, 0x1d, 0x0e, 0xd9, 0x03, 0xc0, 0x63, 0x09, 0x6c, 0x77,
+	0x43, 0xac, 0x42, 0x4b, 0x1b, 0xe7, 0x92, 0x1d, 0xeb, 0xbc, 0x76, 0xaa, 0xac, 0x58, 0x2b, 0x06,
+	0xf2, 0x35, 0x6e, 0x42, 0x79, 0x07, 0x7e, 0x40, 0x83, 0xf3, 0x3b, 0x59, 0x50, 0x13, 0x7d, 0x6b,
+	0x39, 0xa1, 0x4d, 0xfa, 0xae, 0xba, 0x17, 0xda, 0x0a, 0x1e, 0x40, 0xf1, 0x8f, 0x91, 0xd1, 0x9b,
+	0x3b, 0xee, 0xe8, 0x91, 0xc7, 0x70, 0x6d, 0xb4, 0x95, 0xba, 0xbb, 0xa7, 0xdb, 0xee, 0x2b, 0xbc,
+	0xf9, 0x41, 0x3d, 0x0c, 0xda, 0xc5, 0x46, 0xda, 0xa5, 0x64, 0x9b, 0x5b, 0x7b, 0xdb, 0xee, 0xab,
+	0x17, 0x12, 0x27, 0x6e, 0xb9, 0xc7, 0x44, 0xf5, 0x03, 0xd2, 0x88, 0xbf, 0x8e, 0x12, 0x0e, 0xb2,
+	0x9b, 0x09, 0x4b, 0xfe, 0xbd, 0xb1, 0xb7, 0x99, 0x93, 0xd8, 0xdb, 0xef, 0x5e, 0x7d, 0x62, 0x06,
+	0x3f, 0x4d, 0xae, 0x3f, 0x20, 0x0d, 0xfa, 0x97, 0xdb, 0x50, 0xda, 0x34, 0x7c, 0x2a, 0x7a, 0x4b,
+	0x36, 0x44, 0x26, 0x92, 0xdf, 0x55, 0xb9, 0x92, 0xe4, 0x1c, 0x43, 0x4c, 0xfe, 0xce, 0x62, 0x41,
+	0xe4, 0x33, 0xc5, 0xae, 0xc7, 0xa5, 0xd4, 0xe4, 0x94, 0x74, 0xe5, 0x25, 0x32, 0xf9, 0x18, 0x8a,
+	0xe2, 0x95, 0xca, 0x0d, 0xd8, 0x2b, 0xd3, 0x28, 0xa9, 0xa9, 0x45, 0x04, 0x48, 0x1d, 0xee, 0x3c,
+	0xab, 0xf9, 0x29, 0xd4, 0xe1, 0x7d, 0x04, 0x2d, 0x22, 0x20, 0x1f, 0x42, 0x21, 0xbc, 0x8c, 0x36,
+	0xc7, 0x88, 0x2f, 0xa7, 0x12, 0xcb, 0x7d, 0x19, 0x2d, 0x44, 0xc7, 0x9f, 0x7d, 0xf8, 0xd4, 0xe1,
+	0x1b, 0xfa, 0xa5, 0x8d, 0xf3, 0xa9, 0x64, 0xec, 0x00, 0x16, 0x43, 0x23, 0x35, 0x58, 0xc4, 0xa7,
+	0xee, 0xf1, 0xf3, 0x58, 0xe2, 0x24, 0xe2, 0xda, 0x64, 0x32, 0x8e, 0xa7, 0x95, 0xfc, 0xe8, 0x83,
+	0x7c, 0x02, 0xc0, 0x98, 0xf0, 0x61, 0x2f, 0x4c, 0xeb, 0xad, 0x3c, 0x32, 0xa5, 0x15, 0x7d, 0xf9,
+	0x8a, 0x23, 0x24, 0xc7, 0xbf, 0x38, 0x65, 0x84, 0xe4, 0xb5, 0x06, 0x89, 0x4c, 0x6e, 0x41, 0xce,
+	0xe8, 0x1e, 0x88, 0x2b, 0x89, 0xea, 0xa4, 0x43, 0x35, 0x1a, 0x22, 0xa1, 0x58, 0xf6, 0x6c, 0xf7,
+	0x95, 0x5a, 0x9a, 0x22, 0x16, 0x3c, 0x84, 0xa0, 0x31, 0x34, 0xb2, 0x09, 0xa5, 0x61, 0x74, 0x74,
+	0x40, 0x5d, 0x9c, 0x22, 0x95, 0xd8, 0x11, 0x03, 0x2d, 0x4e, 0x84, 0xdd, 0xf2, 0x79, 0xba, 0x4a,
+	0x2d, 0x4f, 0xe9, 0x96, 0x48, 0x69, 0x69, 0x12, 0x99, 0xdc, 0x91, 0xf3, 0x67, 0x29, 0xcd, 0x30,
+	0xc5, 0x37, 0xa4, 0xe4, 0x04, 0x6a, 0xe0, 0xed, 0x3a, 0xd7, 0xa7, 0xd1, 0x0d, 0xc6, 0x65, 0x46,
+	0x5a, 0x49, 0xd7, 0xd7, 0xf8, 0x56, 0x34, 0xde, 0xc0, 0x8b, 0x7d, 0x46, 0xac, 0x64, 0x22, 0x59,
+	0x55, 0x66, 0xb1, 0x92, 0xdb, 0x0e, 0x82, 0x95, 0xfc, 0x24, 0x2d, 0x76, 0xe9, 0x8d, 0xb1, 0xd5,
+	0xa5, 0x20, 0xf8, 0x75, 0x93, 0x37, 0xa6, 0x2a, 0xb3, 0x14, 0xc8, 0xf2, 0x20, 0x09, 0xc0, 0x31,
+	0x1c, 0x58, 0x4e, 0x4f, 0x25, 0x53, 0xc6, 0x10, 0xb7, 0xcb, 0x34, 0x86, 0xc6, 0xd0, 0x5d, 0xa7,
+	0xa7, 0xae, 0x4c, 0x43, 0x77, 0x19, 0xba, 0xeb, 0xf4, 0xc8, 0x7f, 0x87, 0xab, 0xde, 0xf4, 0x6d,
+	0x5f, 0xf6, 0x5f, 0x81, 0xd2, 0xc6, 0xfd, 0x54, 0x4e, 0x33, 0xb6, 0x8c, 0xb5, 0x59, 0xcc, 0xc9,
+	0x7f, 0x86, 0xd3, 0x61, 0x70, 0x27, 0xef, 0x3b, 0xa8, 0x67, 0x58, 0x8d, 0xef, 0x9e, 0xec, 0x92,
+	0xc4, 0x38, 0x1f, 0xe2, 0xc3, 0xf9, 0x31, 0xa0, 0x5c, 0x27, 0xd8, 0x8f, 0x10, 0x4a, 0x1b, 0xef,
+	0x7d, 0xad, 0x9b, 0x18, 0xda, 0x64, 0xbe, 0x38, 0x89, 0xec, 0xe8, 0x04, 0xbc, 0x7a, 0x6e, 0xca,
+	0x24, 0x8a, 0x9f, 0x94, 0x8f, 0x13, 0x91, 0x2f, 0x61, 0xc5, 0x1e, 0x3f, 0x45, 0xcf, 0x7e, 0xb3,
+	0x50, 0xda, 0x58, 0x9f, 0xc9, 0x4b, 0xb6, 0x32, 0x8d, 0x09, 0x79, 0x12, 0xdd, 0xba, 0x63, 0xdb,
+	0x9c, 0xea, 0xf9, 0x69, 0xaa, 0x1e, 0xc7, 0xd4, 0x92, 0x84, 0xe4, 0x67, 0x70, 0xa6, 0x9b, 0xb6,
+	0x61, 0xca, 0x7e, 0xe2, 0x50, 0xda, 0xb8, 0x75, 0x0c, 0x8e, 0xb2, 0xa5, 0xe9, 0x8c, 0x48, 0x07,
+	0x4e, 0x7b, 0xa3, 0x47, 0x73, 0xd8, 0xff, 0x1f, 0x4a, 0x13, 0x6e, 0x2b, 0x8e, 0x1d, 0xe4, 0xd1,
+	0xc6, 0x19, 0xf0, 0xc5, 0x82, 0x1e, 0xa8, 0x97, 0xa6, 0x4c, 0x11, 0x3c, 0xce, 0xa4, 0x31, 0x34,
+	0xf2, 0x39, 0x28, 0xbd, 0x91, 0x1d, 0x32, 0xf6, 0xbb, 0x88, 0xd2, 0xc6, 0x8d, 0x49, 0x1b, 0x3e,
+	0x09, 0x64, 0x6d, 0x8c, 0x9c, 0x58, 0xa0, 0xf6, 0x26, 0x6c, 0xba, 0xa9, 0x57, 0xa6, 0x28, 0xff,
+	0xa4, 0x9d, 0x3a, 0x6d, 0x22, 0x3b, 0xa2, 0xc3, 0x59, 0x7e, 0x1c, 0x2d, 0xb4, 0x6d, 0x3a, 0xdf,
+	0x2d, 0x51, 0xaf, 0xb2, 0x8a, 0xde, 0x9a, 0xb0, 0x82, 0x8c, 0x9f, 0x7e, 0xd3, 0x56, 0x8d, 0x14,
+	0x28, 0xf9, 0x29, 0xac, 0xf6, 0x52, 0xb6, 0x8b, 0xd4, 0xb5, 0x29, 0xec, 0x53, 0xf7, 0x97, 0x52,
+	0xd9, 0x90, 0x21, 0x5c, 0xea, 0x4d, 0xd9, 0x8d, 0x52, 0xaf, 0xb1, 0x6a, 0xee, 0x1e, 0xbf, 0x1a,
+	0x29, 0xb2, 0xa9, 0x6c, 0xd1, 0x93, 0xe9, 0xc9, 0xfc, 0xbb, 0x5a, 0x99, 0xb2, 0xb6, 0x47, 0x59,
+	0xfa, 0x88, 0x00, 0xf5, 0xb6, 0x37, 0x9a, 0xbd, 0x57, 0xaf, 0x4f, 0xd1, 0xdb, 0xb1, 0x5c, 0xbf,
+	0x36, 0xce, 0x00, 0x67, 0xae, 0x11, 0xbf, 0xbd, 0xad, 0xbe, 0x31, 0x65, 0xe6, 0x26, 0xee, 0x79,
+	0x6b, 0x49, 0x42, 0x52, 0x87, 0x45, 0x23, 0x76, 0x51, 0x5d, 0xbd, 0xc1, 0x18, 0x5d, 0x9b, 0xc8,
+	0x28, 0x6c, 0x55, 0x82, 0x0c, 0x4d, 0x9d, 0x11, 0x9d, 0x0f, 0x55, 0x6f, 0x4e, 0x31, 0x75, 0xb1,
+	0x73, 0xa4, 0x5a, 0x9c, 0x48, 0x88, 0x2a, 0x99, 0x79, 0x57, 0xdf, 0x9c, 0x2e, 0xaa, 0x24, 0xb6,
+	0x36, 0xce, 0x80, 0xd8, 0x70, 0xbe, 0x37, 0x29, 0x9f, 0xaf, 0xae, 0x33, 0xee, 0xb7, 0x8f, 0xc9,
+	0x3d, 0x34, 0xf9, 0x13, 0x19, 0x92, 0x7b, 0x30, 0xef, 0xb0, 0xdc, 0xb0, 0xba, 0x91, 0xb6, 0x59,
+	0x9d, 0x4c, 0x1f, 0x0b, 0x54, 0xf2, 0x14, 0x96, 0x9c, 0x44, 0x42, 0x59, 0xbd, 0xc7, 0x88, 0xaf,
+	0x4f, 0x23, 0x96, 0x8d, 0x19, 0x21, 0x45, 0x29, 0x1a, 0xa3, 0xd9, 0x50, 0xf5, 0xfe, 0x14, 0x29,
+	0x8e, 0xe7, 0x4e, 0xc7, 0x19, 0xa0, 0x14, 0x8d, 0x49, 0x39, 0x56, 0xf5, 0xbd, 0x29, 0x52, 0x9c,
+	0x98, 0x99, 0xd5, 0x26, 0x33, 0x44, 0x43, 0x62, 0xa4, 0x64, 0xf2, 0xd4, 0x07, 0xd3, 0xec, 0x54,
+	0x0a, 0x81, 0x96, 0xca, 0x06, 0x0d, 0x89, 0x31, 0x25, 0x51, 0xa8, 0xbe, 0x3f, 0xc5, 0x90, 0x4c,
+	0xcb, 0x30, 0x6a, 0x53, 0xd9, 0xa2, 0x6e, 0x50, 0x16, 0xae, 0xaa, 0x1f, 0x4c, 0xd1, 0x0d, 0x91,
+	0x17, 0x13, 0xa8, 0xa8, 0x1b, 0x34, 0x91, 0x29, 0x53, 0x3f, 0x9c, 0xa2, 0x1b, 0xc9, 0xa4, 0x9a,
+	0x36, 0x42, 0x8a, 0xba, 0x41, 0x47, 0xf3, 0x2d, 0xea, 0xc3, 0x29, 0xba, 0x31, 0x9e, 0x9d, 0x19,
+	0x67, 0x80, 0xba, 0x41, 0x27, 0x65, 0x71, 0xd4, 0x8f, 0xa6, 0xe8, 0xc6, 0xc4, 0xdc, 0x8f, 0x36,
+	0x99, 0x21, 0xea, 0x06, 0x4d, 0x09, 0xfa, 0xd5, 0x8f, 0xa7, 0xe8, 0x46, 0x6a, 0x96, 0x20, 0x95,
+	0x0d, 0xea, 0x06, 0x9d, 0x92, 0x53, 0x50, 0x3f, 0x99, 0xa2, 0x1b, 0xd3, 0x92, 0x11, 0xda, 0x54,
+	0xb6, 0xa4, 0x0b, 0x67, 0x83, 0xd4, 0xcd, 0x3a, 0xf5, 0xc7, 0xac, 0xc2, 0xb7, 0x53, 0x2b, 0x4c,
+	0xdf, 0xdf, 0xd3, 0x26, 0xb0, 0x22, 0x7b, 0x70, 0x2e, 0x48, 0xdf, 0xb8, 0x53, 0x3f, 0x65, 0xb5,
+	0xbc, 0x73, 0xbc, 0x5a, 0x44, 0x8f, 0x26, 0x31, 0x43, 0x9d, 0x7d, 0x95, 0xd8, 0x7e, 0x57, 0x3f,
+	0x9b, 0xa2, 0xb3, 0xc9, 0x9d, 0x7a, 0x6d, 0x84, 0x14, 0x5d, 0xcb, 0x57, 0x69, 0x47, 0x20, 0xd4,
+	0xea, 0x14, 0xd7, 0x32, 0xf5, 0xd0, 0x84, 0x96, 0xce, 0x08, 0xbd, 0xba, 0x57, 0x23, 0xc7, 0x19,
+	0xd4, 0xcd, 0x29, 0x5e, 0xdd, 0xe8, 0xd9, 0x07, 0x6d, 0x8c, 0x1c, 0xbd, 0xf6, 0x57, 0xe3, 0x07,
+	0x10, 0xd4, 0xda, 0x14, 0xaf, 0x3d, 0xe5, 0xc0, 0x82, 0x96, 0xc6, 0x04, 0xd7, 0xfe, 0x20, 0x7e,
+	0x14, 0x5c, 0xdd, 0x9a, 0xb2, 0xf6, 0x27, 0x0e, 0x8d, 0x6b, 0x49, 0xc2, 0xca, 0x6f, 0x80, 0xf8,
+	0x61, 0x29, 0xde, 0x44, 0x6d, 0x35, 0x9b, 0xf5, 0x5a, 0x47, 0xc9, 0xe2, 0xcf, 0x05, 0xc4, 0x47,
+	0x7d, 0x4b, 0xc9, 0xe1, 0x67, 0x7b, 0x77, 0xb3, 0x5d, 0xd3, 0x1a, 0x9b, 0x75, 0x25, 0xcf, 0x8e,
+	0x7b, 0x6b, 0xad, 0xad, 0xdd, 0x5a, 0x5d, 0xe3, 0xff, 0x29, 0x6d, 0xd7, 0x9b, 0x5b, 0xca, 0x3c,
+	0x51, 0x60, 0x11, 0xdf, 0x74, 0xad, 0x5e, 0xab, 0x37, 0x76, 0x3a, 0xca, 0x02, 0x9e, 0x38, 0x62,
+	0x90, 0xba, 0xa6, 0xb5, 0x34, 0xa5, 0x80, 0x95, 0x3c, 0xab, 0xb7, 0xdb, 0xd5, 0xc7, 0x75, 0xa5,
+	0xc8, 0x8e, 0x1a, 0xd5, 0x9e, 0x2a, 0x80, 0x1c, 0x1e, 0x6d, 0xb7, 0x5e, 0x28, 0x25, 0xb2, 0x0c,
+	0xa5, 0xdd, 0x66, 0x54, 0xd5, 0x22, 0x12, 0xb4, 0x77, 0x6b, 0xb5, 0x7a, 0xbb, 0xad, 0x94, 0xf1,
+	0x37, 0xa7, 0x9c, 0xd1, 0x12, 0x1e, 0x5d, 0xaa, 0x6d, 0xb7, 0xda, 0x75, 0x3d, 0x6c, 0xc8, 0x72,
+	0x04, 0xab, 0xb5, 0x9a, 0xed, 0xdd, 0x67, 0x75, 0x4d, 0x51, 0xf0, 0x06, 0x8c, 0xc4, 0xd0, 0x25,
+	0xa3, 0xd3, 0x58, 0xe1, 0x4e, 0xa3, 0xf9, 0x58, 0x21, 0xec, 0xad, 0xd5, 0x7c, 0xac, 0xac, 0x90,
+	0x1b, 0x70, 0x4d, 0xab, 0x6f, 0xd5, 0xb7, 0x1b, 0xcf, 0xeb, 0x9a, 0xbe, 0xdb, 0xac, 0xd6, 0x9e,
+	0x36, 0x5b, 0x2f, 0xb6, 0xeb, 0x5b, 0x8f, 0xeb, 0x5b, 0xba, 0x68, 0x73, 0x5b, 0x59, 0x25, 0x2a,
+	0xac, 0xee, 0x54, 0xb5, 0x4e, 0xa3, 0xd3, 0x68, 0x35, 0x59, 0x49, 0xa7, 0xba, 0x55, 0xed, 0x54,
+	0x95, 0x33, 0xe4, 0x1a, 0x5c, 0x4e, 0x2b, 0xd1, 0xb5, 0x7a, 0x7b, 0xa7, 0xd5, 0x6c, 0xd7, 0x95,
+	0xb3, 0xec, 0x3f, 0x0b, 0xad, 0xd6, 0xd3, 0xdd, 0x1d, 0xe5, 0x1c, 0x5e, 0xb5, 0xe1, 0xef, 0x11,
+	0x82, 0xca, 0xba, 0x20, 0x1a, 0xaf, 0xb7, 0x3b, 0xd5, 0x4e, 0x5b, 0x39, 0x4f, 0x2e, 0xc2, 0xb9,
+	0x24, 0x2c, 0x22, 0xb8, 0x80, 0xcd, 0xd1, 0xea, 0xd5, 0xda, 0x93, 0xfa, 0x96, 0x8e, 0x72, 0x6e,
+	0x3d, 0xd2, 0x3b, 0xad, 0x9d, 0x46, 0x4d, 0xb9, 0xc8, 0x87, 0xa5, 0xfe, 0x54, 0xb9, 0x44, 0xce,
+	0xc1, 0xca, 0xe3, 0x7a, 0x47, 0xdf, 0xae, 0xb6, 0x3b, 0xb2, 0x27, 0x7a, 0x63, 0x4b, 0xb9, 0x4c,
+	0xd6, 0xe0, 0x52, 0x4a, 0x41, 0xc4, 0xfe, 0x0a, 0xb9, 0x00, 0x67, 0xab, 0xb5, 0x4e, 0xe3, 0x79,
+	0x24, 0x53, 0xbd, 0xf6, 0xa4, 0xda, 0x7c, 0x5c, 0x57, 0xae, 0x62, 0xbb, 0x90, 0x9a, 0xd5, 0xd7,
+	0xc6, 0x9a, 0x9b, 0xd5, 0x67, 0xf5, 0xf6, 0x4e, 0xb5, 0x56, 0x57, 0xd6, 0xc8, 0x1b, 0xb0, 0x36,
+	0xa1, 0x30, 0x62, 0x7f, 0x0d, 0xd5, 0x03, 0xb1, 0xda, 0xb5, 0x27, 0xf5, 0x67, 0x55, 0xa5, 0x22,
+	0x5b, 0xca, 0xbf, 0x23, 0xc4, 0xeb, 0x28, 0x97, 0xea, 0x6e, 0xe7, 0x09, 0x56, 0xbe, 0xbd, 0x5d,
+	0xc7, 0xfa, 0xdf, 0xc0, 0x3f, 0xd0, 0x32, 0x58, 0x88, 0x76, 0x03, 0x15, 0xb0, 0x5a, 0x7b, 0x1a,
+	0x41, 0x6e, 0xa2, 0x7c, 0x90, 0x63, 0x4b, 0xd3, 0x6b, 0x5a, 0xbd, 0xda, 0xa9, 0xcb, 0xba, 0xde,
+	0xc4, 0xe1, 0x4a, 0x2b, 0x89, 0x88, 0xd7, 0x51, 0xf9, 0x9a, 0xf5, 0x17, 0x7a, 0xe7, 0x3f, 0x35,
+	0x95, 0x0d, 0xd4, 0x24, 0xf1, 0x11, 0xa1, 0xdc, 0x43, 0xfe, 0xd5, 0xad, 0x2d, 0x3d, 0x1c, 0x78,
+	0xbd, 0xd3, 0x62, 0xf8, 0xf7, 0x91, 0x7f, 0x5a, 0x49, 0x44, 0xfc, 0x1e, 0x4a, 0x10, 0x51, 0x84,
+	0xbe, 0xef, 0xc4, 0xe9, 0x1f, 0xa0, 0x04, 0x27, 0x14, 0x46, 0x2c, 0xde, 0xc7, 0x26, 0xe2, 0xb8,
+	0x23, 0xc9, 0x07, 0xd8, 0x44, 0xf1, 0x11, 0xa1, 0x7c, 0x88, 0x4d, 0x94, 0xd0, 0x56, 0x33, 0x6a,
+	0x8f, 0xf2, 0x10, 0x9b, 0x98, 0x56, 0x12, 0x11, 0x7f, 0x84, 0x4d, 0x8c, 0xa1, 0xc4, 0x1b, 0xa3,
+	0x7c, 0x8c, 0x4d, 0x9c, 0x50, 0x18, 0xb1, 0xf8, 0x84, 0x5c, 0x86, 0xf3, 0x9d, 0x9a, 0x5e, 0xdb,
+	0x6e, 0xd4, 0x9b, 0x1d, 0x5d, 0x58, 0x15, 0x5d, 0xab, 0x7f, 0xbe, 0x5b, 0x6f, 0x77, 0x94, 0x1f,
+	0xe3, 0xdd, 0xb6, 0xb4, 0x62, 0x41, 0xfe, 0x29, 0x76, 0xea, 0x45, 0xb5, 0x53, 0x7b, 0xc2, 0x75,
+	0x49, 0xdf, 0x6e, 0xb4, 0x3b, 0xca, 0x67, 0xe4, 0x12, 0xa8, 0xa3, 0xd0, 0x70, 0x7e, 0x57, 0xf1,
+	0x3e, 0x5c, 0xbc, 0x74, 0x77, 0x67, 0xab, 0xda, 0xa9, 0x2b, 0x9b, 0xa8, 0xce, 0x63, 0x54, 0xcc,
+	0x64, 0x28, 0x35, 0x54, 0x31, 0x0e, 0x7d, 0xd6, 0x78, 0xac, 0x55, 0xd1, 0xee, 0x6d, 0xdd, 0xda,
+	0x62, 0x77, 0xf0, 0xe3, 0xff, 0x53, 0x65, 0xff, 0x75, 0x6e, 0x35, 0xeb, 0xca, 0x29, 0x34, 0x5f,
+	0xdb, 0x5f, 0xde, 0xe7, 0x3f, 0x75, 0xfe, 0x72, 0xbb, 0xb1, 0xa9, 0x64, 0xd9, 0x5b, 0xbb, 0x83,
+	0x16, 0x13, 0x7f, 0xc7, 0xd2, 0xac, 0xee, 0xec, 0x7c, 0xa1, 0xe4, 0x6f, 0xe9, 0x40, 0xc6, 0x2f,
+	0x8b, 0xc7, 0x7e, 0xd8, 0x72, 0x2a, 0xf9, 0x2f, 0x17, 0x76, 0x6d, 0xef, 0x85, 0x61, 0x05, 0x8f,
+	0x5c, 0x2f, 0x82, 0x66, 0xd9, 0x38, 0xca, 0xcf, 0x17, 0x56, 0xb0, 0xff, 0x88, 0x3a, 0x5d, 0xfc,
+	0xab, 0x74, 0xee, 0xd6, 0xff, 0x98, 0x83, 0x52, 0x6c, 0x4b, 0x01, 0xa7, 0xc1, 0xae, 0x83, 0xd9,
+	0x35, 0x71, 0x85, 0xef, 0x14, 0xce, 0x15, 0x99, 0x99, 0x8a, 0xdd, 0x0d, 0xdc, 0xa1, 0x9e, 0xcf,
+	0xfe, 0x43, 0xd1, 0x15, 0x17, 0x00, 0xb3, 0x38, 0x03, 0x31, 0xc2, 0xa3, 0x4e, 0x60, 0x75, 0xa3,
+	0x0b, 0x88, 0x4a, 0x0e, 0x45, 0x5a, 0xe5, 0xbf, 0x58, 0xf8, 0x2a, 0x06, 0xcf, 0x63, 0x5d, 0x32,
+	0x03, 0xb0, 0x39, 0xf4, 0x8f, 0x94, 0x39, 0x34, 0x6c, 0xe2, 0x4e, 0x49, 0xd3, 0x0d, 0xd8, 0x1d,
+	0x02, 0x65, 0x1e, 0xad, 0xab, 0x94, 0xc1, 0x26, 0x3f, 0xa8, 0xfd, 0xf9, 0xd0, 0x0d, 0x8c, 0xfa,
+	0xeb, 0x2e, 0xa5, 0x26, 0xe5, 0x99, 0x5c, 0x65, 0x81, 0xbc, 0x05, 0x37, 0xa6, 0xa2, 0xbd, 0xee,
+	0x52, 0x7e, 0xe7, 0xb1, 0x80, 0x5d, 0x92, 0x77, 0x1b, 0x39, 0x75, 0x11, 0x95, 0x75, 0xd7, 0x11,
+	0x3f, 0x9a, 0xa3, 0xa6, 0x38, 0x0a, 0xc5, 0x0b, 0x01, 0xf1, 0xd9, 0x42, 0xd8, 0x74, 0x83, 0x47,
+	0xee, 0xd0, 0x31, 0x95, 0x12, 0x4a, 0x34, 0x71, 0x45, 0x5e, 0x96, 0x2c, 0xb2, 0x8b, 0x93, 0xf2,
+	0x64, 0xbb, 0x84, 0x96, 0xb1, 0x67, 0x1d, 0xd7, 0x7d, 0x66, 0x38, 0x47, 0xc2, 0x9b, 0xf2, 0x95,
+	0x25, 0x64, 0xc2, 0xf8, 0x76, 0xa8, 0xd7, 0xb7, 0x1c, 0x5c, 0x53, 0x79, 0x8d, 0xcb, 0x28, 0x9a,
+	0xb0, 0x33, 0x28, 0x1a, 0xb6, 0x1a, 0x35, 0x1c, 0x76, 0x9d, 0x95, 0x37, 0xc5, 0xe8, 0xe3, 0x9f,
+	0xb5, 0xcf, 0x02, 0x69, 0xb0, 0xdb, 0x9d, 0x46, 0x60, 0xbd, 0xb4, 0x45, 0x34, 0xa9, 0x10, 0x1c,
+	0x0b, 0xd9, 0x88, 0x2a, 0xbb, 0xb2, 0xc3, 0x19, 0xaf, 0x90, 0x0a, 0x5c, 0xe9, 0x78, 0x86, 0xe3,
+	0xf3, 0xdd, 0xb7, 0x9a, 0xeb, 0x7a, 0x26, 0xd6, 0xec, 0x46, 0x6d, 0x5d, 0x8d, 0x57, 0xf5, 0x9a,
+	0xfd, 0x80, 0x69, 0xe8, 0x2b, 0x67, 0xb0, 0x07, 0x4d, 0x37, 0xa8, 0xe2, 0x2f, 0xc5, 0x64, 0x3b,
+	0xcf, 0x62, 0x3d, 0x09, 0x76, 0xce, 0x9e, 0x6d, 0x75, 0x03, 0xe5, 0xdc, 0x48, 0x41, 0xc8, 0x5c,
+	0x15, 0x7f, 0xfa, 0x66, 0x3d, 0x43, 0x2d, 0xa4, 0xa6, 0x72, 0xfe, 0xd6, 0x53, 0x80, 0xd8, 0xcf,
+	0x50, 0xd0, 0x60, 0x87, 0x5f, 0xe2, 0x47, 0xe8, 0x2b, 0xb0, 0x1c, 0xc1, 0xbe, 0xe8, 0x1a, 0xcf,
+	0xef, 0x72, 0x35, 0x8c, 0x80, 0x55, 0xd4, 0x3c, 0x5f, 0xc9, 0xde, 0xfa, 0x93, 0x0c, 0x2c, 0xef,
+	0x8c, 0xfc, 0xc1, 0x6f, 0x1e, 0xb2, 0x87, 0x77, 0x94, 0x53, 0xec, 0x89, 0x94, 0xf8, 0xdc, 0x50,
+	0xb2, 0xec, 0x79, 0x4f, 0xc9, 0xb1, 0xe7, 0x7d, 0x25, 0xcf, 0x9e, 0xef, 0x29, 0x73, 0xec, 0xf9,
+	0x40, 0x99, 0x67, 0xcf, 0xf7, 0x95, 0x05, 0xf6, 0xfc, 0x40, 0x29, 0xb0, 0xe7, 0x87, 0xdc, 0xfd,
+	0x38, 0xbc, 0x7b, 0x47, 0x01, 0xfe, 0x72, 0x57, 0x29, 0xf1, 0x97, 0x0d, 0x65, 0x91, 0xbf, 0xdc,
+	0x53, 0xca, 0xfc, 0xe5, 0xbe, 0xb2, 0xc4, 0x5f, 0xde, 0x53, 0x96, 0xf9, 0xcb, 0x03, 0x45, 0xe1,
+	0x2f, 0xef, 0x2b, 0xa7, 0xf9, 0xcb, 0x07, 0x0a, 0xe1, 0x2f, 0x1f, 0x2a, 0x2b, 0xec, 0x65, 0xe3,
+	0x8e, 0xb2, 0x7a, 0xeb, 0xed, 0xf8, 0x0f, 0xe7, 0xc4, 0x09, 0xec, 0xea, 0x6e, 0xa7, 0xa5, 0xb7,
+	0x77, 0xb6, 0x1b, 0x1d, 0xf1, 0xd7, 0xa6, 0x4e, 0xa3, 0xf6, 0xf4, 0x0b, 0x25, 0x73, 0xab, 0x02,
+	0xc5, 0x70, 0xcb, 0x14, 0x0b, 0x6a, 0xad, 0x67, 0xcf, 0x18, 0x52, 0x11, 0xe6, 0xaa, 0x9b, 0x2d,
+	0xad, 0xa3, 0x64, 0x36, 0x6f, 0x42, 0xc5, 0xf5, 0x7a, 0xb7, 0x8d, 0x01, 0x26, 0x31, 0xa5, 0xb3,
+	0xd7, 0x75, 0xfb, 0x7d, 0xd7, 0xb9, 0x6d, 0xc8, 0x3f, 0xe7, 0x3f, 0xc9, 0xfd, 0xfb, 0x00, 0xcc,
+	0xe5, 0xba, 0xd2, 0x4d, 0x5f, 0x00, 0x00,
 }
@@ -22,13 +22,17 @@ echo "generate pulsar go client protobuf code..."
 
 pkg=api
 
+# PulsarApi.proto is copied from the Pulsar sources
+# (pulsar-common/src/main/proto/PulsarApi.proto), without
+# its go_package option so that the package is named api.
+
 #if you have encountered the following error:
 #   undefined: proto.ProtoPackageIsVersion3
 #its because the protoc-gen-go's version not correct.
 #
 # $ git clone https://github.com/golang/protobuf
 # $ cd ~/protobuf/protoc-gen-go
-# $ git chectout tags/v1.2.0 -b v1.2.0
+# $ git checkout tags/v1.3.0 -b v1.3.0
 # $ go install
 
 protoc --go_out=import_path=${pkg}:. PulsarApi.proto
@@ -0,0 +1,171 @@
+// Copyright 2019 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package log
+
+import (
+	"sync"
+	"sync/atomic"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// Loggers of the components of the client. Their level defaults to
+// the global one, set with SetLevelByString.
+var (
+	Conn   = newLogger("conn")
+	Manage = newLogger("manage")
+	Pub    = newLogger("pub")
+	Sub    = newLogger("sub")
+	Frames = newLogger("frame") // dumps of sent and received frames
+)
+
+var (
+	levelMu      sync.Mutex // serializes level changes
+	defaultLevel = uint32(defaultLogLevel)
+	loggers      []*Logger
+)
+
+// noLevel marks a Logger without a level of its own.
+const noLevel = ^uint32(0)
+
+// Fields are contextual labels attached to every message of a Logger.
+type Fields map[string]interface{}
+
+// Logger logs messages of one component, at a level
+// that can be set independently of the other components.
+type Logger struct {
+	root     *Logger    // component Logger holding the following; l itself for component Loggers
+	fields   log.Fields // includes the component
+	level    uint32     // log.Level, or noLevel to use the global level; accessed atomically
+	sampling uint64     // only log 1 in sampling messages; accessed atomically
+	count    uint64     // number of messages seen, for sampling; accessed atomically
+}
+
+func newLogger(component string) *Logger {
+	l := &Logger{
+		fields:   log.Fields{"component": component},
+		level:    noLevel,
+		sampling: 1,
+	}
+	l.root = l
+	loggers = append(loggers, l)
+	return l
+}
+
+// With returns a Logger attaching fields to every message, in
+// addition to those of l. It shares the level and sampling of
+// its component Logger.
+func (l *Logger) With(fields Fields) *Logger {
+	f := make(log.Fields, len(l.fields)+len(fields))
+	for k, v := range l.fields {
+		f[k] = v
+	}
+	for k, v := range fields {
+		f[k] = v
+	}
+	return &Logger{
+		root:   l.root,
+		fields: f,
+	}
+}
+
+// SetLevel sets the level of the Logger by a level string.
+// An empty string resets it to the global level. Loggers
+// returned by With share the level of their component.
+func (l *Logger) SetLevel(level string) {
+	l = l.root
+	levelMu.Lock()
+	defer levelMu.Unlock()
+
+	if level == "" {
+		atomic.StoreUint32(&l.level, noLevel)
+	} else {
+		atomic.StoreUint32(&l.level, uint32(stringToLogLevel(level)))
+	}
+	updateLevel()
+}
+
+// SetSampling makes the Logger only log one in every n messages,
+// e.g. to dump frames without drowning in MESSAGE frames. n <= 1
+// logs every message.
+func (l *Logger) SetSampling(n int) {
+	l = l.root
+	if n < 1 {
+		n = 1
+	}
+	atomic.StoreUint64(&l.sampling, uint64(n))
+}
+
+// enabled returns true if messages at the given level are logged.
+func (l *Logger) enabled(level log.Level) bool {
+	l = l.root
+	lvl := atomic.LoadUint32(&l.level)
+	if lvl == noLevel {
+		lvl = atomic.LoadUint32(&defaultLevel)
+	}
+	if log.Level(lvl) < level {
+		return false
+	}
+	if n := atomic.LoadUint64(&l.sampling); n > 1 {
+		return atomic.AddUint64(&l.count, 1)%n == 1
+	}
+	return true
+}
+
+// Debugf logs a message at level Debug.
+func (l *Logger) Debugf(format string, v ...interface{}) {
+	if l.enabled(log.DebugLevel) {
+		log.WithFields(l.fields).Debugf(format, v...)
+	}
+}
+
+// Infof logs a message at level Info.
+func (l *Logger) Infof(format string, v ...interface{}) {
+	if l.enabled(log.InfoLevel) {
+		log.WithFields(l.fields).Infof(format, v...)
+	}
+}
+
+// Warnf logs a message at level Warn.
+func (l *Logger) Warnf(format string, v ...interface{}) {
+	if l.enabled(log.WarnLevel) {
+		log.WithFields(l.fields).Warnf(format, v...)
+	}
+}
+
+// Errorf logs a message at level Error.
+func (l *Logger) Errorf(format string, v ...interface{}) {
+	if l.enabled(log.ErrorLevel) {
+		log.WithFields(l.fields).Errorf(format, v...)
+	}
+}
+
+// updateLevel sets the level of the underlying logger to the most
+// verbose of the global and component levels, since it filters
+// messages before they reach the component levels. levelMu must be held.
+func updateLevel() {
+	max := log.Level(atomic.LoadUint32(&defaultLevel))
+	for _, l := range loggers {
+		if lvl := atomic.LoadUint32(&l.level); lvl != noLevel && log.Level(lvl) > max {
+			max = log.Level(lvl)
+		}
+	}
+	log.SetLevel(max)
+}
+
+// defaultEnabled returns true if messages at the
+// given level are logged by the global functions.
+func defaultEnabled(level log.Level) bool {
+	return log.Level(atomic.LoadUint32(&defaultLevel)) >= level
+}
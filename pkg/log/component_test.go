@@ -0,0 +1,80 @@
+// Copyright 2019 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package log
+
+import (
+	"bytes"
+	"os"
+	"strings"
+	"testing"
+
+	log "github.com/sirupsen/logrus"
+)
+
+func TestLogger_Levels(t *testing.T) {
+	var b bytes.Buffer
+	log.SetOutput(&b)
+	defer log.SetOutput(os.Stderr)
+
+	SetLevelByString("info")
+	Conn.SetLevel("debug")
+	Sub.SetLevel("error")
+	defer func() {
+		Conn.SetLevel("")
+		Sub.SetLevel("")
+	}()
+
+	Conn.Debugf("conn debug")
+	Manage.Debugf("manage debug")
+	Manage.Infof("manage info")
+	Sub.Warnf("sub warn")
+	Sub.Errorf("sub error")
+	Debugf("global debug")
+	Infof("global info")
+
+	out := b.String()
+	for _, expected := range []string{"conn debug", "manage info", "sub error", "global info"} {
+		if !strings.Contains(out, expected) {
+			t.Errorf("%q not logged; expected it to be:\n%s", expected, out)
+		}
+	}
+	for _, unexpected := range []string{"manage debug", "sub warn", "global debug"} {
+		if strings.Contains(out, unexpected) {
+			t.Errorf("%q logged; expected it not to be:\n%s", unexpected, out)
+		}
+	}
+	if got, expected := GetLogLevelAsString(), "info"; got != expected {
+		t.Errorf("GetLogLevelAsString() = %q; expected %q", got, expected)
+	}
+}
+
+func TestLogger_SetSampling(t *testing.T) {
+	var b bytes.Buffer
+	log.SetOutput(&b)
+	defer log.SetOutput(os.Stderr)
+
+	Frames.SetLevel("debug")
+	Frames.SetSampling(1000)
+	defer func() {
+		Frames.SetLevel("")
+		Frames.SetSampling(1)
+	}()
+
+	for i := 0; i < 2500; i++ {
+		Frames.Debugf("frame")
+	}
+	if got, expected := strings.Count(b.String(), "frame\n"), 3; got != expected {
+		t.Fatalf("logged %d frames; expected %d", got, expected)
+	}
+}
@@ -20,6 +20,7 @@ import (
 	"runtime"
 	"sort"
 	"strings"
+	"sync/atomic"
 
 	log "github.com/sirupsen/logrus"
 	"gopkg.in/natefinch/lumberjack.v2"
@@ -36,14 +37,19 @@ func init() {
 	log.SetFormatter(&textFormatter{})
 }
 
-// SetLevelByString sets log's level by a level string.
+// SetLevelByString sets log's level by a level string. It
+// applies to all components without a level of their own.
 func SetLevelByString(level string) {
-	log.SetLevel(stringToLogLevel(level))
+	levelMu.Lock()
+	defer levelMu.Unlock()
+
+	atomic.StoreUint32(&defaultLevel, uint32(stringToLogLevel(level)))
+	updateLevel()
 }
 
 // GetLogLevelAsString gets current log's level as a level string.
 func GetLogLevelAsString() string {
-	return log.GetLevel().String()
+	return log.Level(atomic.LoadUint32(&defaultLevel)).String()
 }
 
 // SetOutputByName sets the filename for the log.
@@ -57,42 +63,58 @@ func SetOutputByName(filename string) {
 
 // Info logs a message at level Info on the wrapped logger.
 func Info(v ...interface{}) {
-	log.Info(v...)
+	if defaultEnabled(log.InfoLevel) {
+		log.Info(v...)
+	}
 }
 
 // Infof logs a message at level Info on the wrapped logger.
 func Infof(format string, v ...interface{}) {
-	log.Infof(format, v...)
+	if defaultEnabled(log.InfoLevel) {
+		log.Infof(format, v...)
+	}
 }
 
 // Debug logs a message at level Debug on the wrapped logger.
 func Debug(v ...interface{}) {
-	log.Debug(v...)
+	if defaultEnabled(log.DebugLevel) {
+		log.Debug(v...)
+	}
 }
 
 // Debugf logs a message at level Debug on the wrapped logger.
 func Debugf(format string, v ...interface{}) {
-	log.Debugf(format, v...)
+	if defaultEnabled(log.DebugLevel) {
+		log.Debugf(format, v...)
+	}
 }
 
 // Warn logs a message at level Warn on the wrapped logger.
 func Warn(v ...interface{}) {
-	log.Warn(v...)
+	if defaultEnabled(log.WarnLevel) {
+		log.Warn(v...)
+	}
 }
 
 // Warnf logs a message at level Warn on the wrapped logger.
 func Warnf(format string, v ...interface{}) {
-	log.Warnf(format, v...)
+	if defaultEnabled(log.WarnLevel) {
+		log.Warnf(format, v...)
+	}
 }
 
 // Error logs a message at level Error on the wrapped logger.
 func Error(v ...interface{}) {
-	log.Error(v...)
+	if defaultEnabled(log.ErrorLevel) {
+		log.Error(v...)
+	}
 }
 
 // Errorf logs a message at level Error on the wrapped logger.
 func Errorf(format string, v ...interface{}) {
-	log.Errorf(format, v...)
+	if defaultEnabled(log.ErrorLevel) {
+		log.Errorf(format, v...)
+	}
 }
 
 // Fatal logs a message at level Fatal on the wrapped logger then the process will exit with status set to 1.
@@ -127,9 +149,10 @@ type contextHook struct{}
 // Fire implements logrus.Hook interface
 // https://github.com/sirupsen/logrus/issues/63
 func (hook *contextHook) Fire(entry *log.Entry) error {
-	// these two num are set by manually testing
-	pc := make([]uintptr, 4)
-	cnt := runtime.Callers(10, pc)
+	// skip runtime.Callers and Fire; the frames of logrus
+	// and of this package are skipped by name below
+	pc := make([]uintptr, 16)
+	cnt := runtime.Callers(2, pc)
 
 	for i := 0; i < cnt; i++ {
 		fu := runtime.FuncForPC(pc[i] - 1)
@@ -152,7 +175,8 @@ func (hook *contextHook) Levels() []log.Level {
 // isSKippedPackageName tests wether path name is on log library calling stack.
 func isSkippedPackageName(name string) bool {
 	return strings.Contains(name, "github.com/sirupsen/logrus") ||
-		strings.Contains(name, "github.com/pingcap/dm/pkg/log")
+		strings.Contains(name, "github.com/pingcap/dm/pkg/log") ||
+		strings.Contains(name, "pulsar-client-go/pkg/log.")
 }
 
 // textFormatter is for compatibility with ngaut/log
@@ -0,0 +1,195 @@
+// Copyright 2018 Comcast Cable Communications Management, LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pulsartest
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pepper-iot/pulsar-client-go/core/manage"
+	"github.com/pepper-iot/pulsar-client-go/core/msg"
+	"github.com/pepper-iot/pulsar-client-go/core/sub"
+	"github.com/pepper-iot/pulsar-client-go/utils"
+)
+
+// Broker is a Pulsar broker that tests run against, e.g. a Container,
+// or one started otherwise. Its helpers connect to it directly, without
+// looking topics up, so they are meant for standalone Pulsar.
+type Broker struct {
+	Addr     string // of the binary protocol, e.g. "pulsar://localhost:6650"
+	AdminURL string // of the admin REST API, e.g. "http://localhost:8080"
+
+	// IdleTimeout is how long ReadBacklog waits for another
+	// message before returning. Defaults to 1s.
+	IdleTimeout time.Duration
+}
+
+// CreateTopic creates the topic with the given number of partitions,
+// or a non-partitioned topic if partitions is 0, through the admin API.
+// The topic may be a short name, like "my-topic", in which case it's
+// created in the public/default namespace.
+func (b *Broker) CreateTopic(ctx context.Context, topic string, partitions int) error {
+	path, err := topicPath(topic)
+	if err != nil {
+		return err
+	}
+	url := b.AdminURL + "/admin/v2/" + path
+	var body string
+	if partitions > 0 {
+		url += "/partitions"
+		body = strconv.Itoa(partitions)
+	}
+
+	req, err := http.NewRequest(http.MethodPut, url, strings.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := http.DefaultClient.Do(req.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode/100 != 2 {
+		msg, _ := ioutil.ReadAll(resp.Body)
+		return fmt.Errorf("creating topic %q: %s: %s", topic, resp.Status, strings.TrimSpace(string(msg)))
+	}
+	return nil
+}
+
+// ProduceN sends n messages to the topic, and returns their payloads,
+// which are "message-0" to "message-<n-1>".
+func (b *Broker) ProduceN(ctx context.Context, topic string, n int) ([][]byte, error) {
+	c, err := b.connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer c.Close(ctx)
+
+	p, err := c.NewProducer(ctx, fullTopic(topic), "pulsartest-"+utils.RandString(8))
+	if err != nil {
+		return nil, err
+	}
+	defer p.Close(ctx)
+
+	payloads := make([][]byte, n)
+	for i := range payloads {
+		payloads[i] = []byte(fmt.Sprintf("message-%d", i))
+		if _, err := p.Send(ctx, payloads[i]); err != nil {
+			return nil, err
+		}
+	}
+	return payloads, nil
+}
+
+// ReadBacklog subscribes to the topic with an exclusive subscription,
+// from the earliest message if the subscription is new, and returns the
+// messages received until none is for IdleTimeout. Received messages
+// are acknowledged, so reading the backlog again only returns messages
+// produced since.
+func (b *Broker) ReadBacklog(ctx context.Context, topic, subscription string) ([]msg.Message, error) {
+	const permits = 1000
+
+	c, err := b.connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer c.Close(ctx)
+
+	cs, err := c.NewExclusiveConsumer(ctx, fullTopic(topic), subscription, sub.Earliest, make(chan msg.Message, permits))
+	if err != nil {
+		return nil, err
+	}
+	defer cs.Close(ctx)
+	if err = cs.Flow(permits); err != nil {
+		return nil, err
+	}
+
+	idleTimeout := b.IdleTimeout
+	if idleTimeout <= 0 {
+		idleTimeout = time.Second
+	}
+	idle := time.NewTimer(idleTimeout)
+	defer idle.Stop()
+
+	var backlog []msg.Message
+	for {
+		select {
+		case m := <-cs.Messages():
+			if err := cs.Ack(m); err != nil {
+				return nil, err
+			}
+			backlog = append(backlog, m)
+			// ask for more messages before running out of permits
+			if len(backlog)%(permits/2) == 0 {
+				if err := cs.Flow(permits / 2); err != nil {
+					return nil, err
+				}
+			}
+			if !idle.Stop() {
+				<-idle.C
+			}
+			idle.Reset(idleTimeout)
+
+		case <-idle.C:
+			return backlog, nil
+
+		case <-cs.Closed():
+			return nil, fmt.Errorf("consumer of %q was closed", topic)
+
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
+
+// connect returns a Client connected to the broker.
+func (b *Broker) connect(ctx context.Context) (*manage.Client, error) {
+	c, err := manage.NewClient(manage.ClientConfig{Addr: b.Addr})
+	if err != nil {
+		return nil, err
+	}
+	if _, err = c.Connect(ctx, ""); err != nil {
+		_ = c.Close(ctx)
+		return nil, err
+	}
+	return c, nil
+}
+
+// fullTopic returns the full name of the topic,
+// which is in public/default if it's a short name.
+func fullTopic(topic string) string {
+	if strings.Contains(topic, "://") {
+		return topic
+	}
+	return "persistent://public/default/" + topic
+}
+
+// topicPath returns the path of the topic in the admin API,
+// e.g. "persistent/public/default/my-topic".
+func topicPath(topic string) (string, error) {
+	topic = fullTopic(topic)
+	i := strings.Index(topic, "://")
+	domain, name := topic[:i], topic[i+3:]
+	if (domain != "persistent" && domain != "non-persistent") || strings.Count(name, "/") != 2 {
+		return "", fmt.Errorf("invalid topic name %q", topic)
+	}
+	return domain + "/" + name, nil
+}
@@ -0,0 +1,154 @@
+// Copyright 2018 Comcast Cable Communications Management, LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pulsartest
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/pepper-iot/pulsar-client-go/core/srv"
+	"github.com/pepper-iot/pulsar-client-go/pkg/api"
+)
+
+func TestBroker_CreateTopic(t *testing.T) {
+	type request struct {
+		method, path, body string
+	}
+	requests := make(chan request, 1)
+	admin := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		requests <- request{r.Method, r.URL.Path, string(body)}
+		if r.URL.Path == "/admin/v2/persistent/public/default/exists" {
+			http.Error(w, "This topic already exists", http.StatusConflict)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer admin.Close()
+
+	b := Broker{AdminURL: admin.URL}
+	ctx := context.Background()
+
+	tests := []struct {
+		topic      string
+		partitions int
+		expected   request
+	}{
+		{"my-topic", 0, request{http.MethodPut, "/admin/v2/persistent/public/default/my-topic", ""}},
+		{"non-persistent://t/ns/other", 4, request{http.MethodPut, "/admin/v2/non-persistent/t/ns/other/partitions", "4"}},
+	}
+	for _, test := range tests {
+		if err := b.CreateTopic(ctx, test.topic, test.partitions); err != nil {
+			t.Fatalf("CreateTopic(%q) err = %v", test.topic, err)
+		}
+		if got := <-requests; got != test.expected {
+			t.Fatalf("CreateTopic(%q) sent %+v; expected %+v", test.topic, got, test.expected)
+		}
+	}
+
+	if err := b.CreateTopic(ctx, "exists", 0); err == nil {
+		t.Fatal("CreateTopic() of an existing topic err = nil; expected an error")
+	} else {
+		t.Logf("CreateTopic() err = %v", err)
+	}
+	<-requests
+
+	if err := b.CreateTopic(ctx, "persistent://invalid", 0); err == nil {
+		t.Fatal("CreateTopic() of an invalid topic err = nil; expected an error")
+	}
+}
+
+func TestBroker_ProduceN(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	srv, err := srv.NewServer(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b := Broker{Addr: srv.Addr, IdleTimeout: 50 * time.Millisecond}
+
+	payloads, err := b.ProduceN(ctx, "my-topic", 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, expected := string(payloads[2]), "message-2"; got != expected {
+		t.Fatalf("got payload %q; expected %q", got, expected)
+	}
+
+	// frames are received by the server after it responds
+	var sent []string
+	for len(sent) < len(payloads) {
+		select {
+		case f := <-srv.Received:
+			if f.BaseCmd.GetType() == api.BaseCommand_SEND {
+				sent = append(sent, string(f.Payload))
+			}
+		case <-ctx.Done():
+			t.Fatalf("server received %d messages; expected %d", len(sent), len(payloads))
+		}
+	}
+
+	// the mock server doesn't deliver messages
+	backlog, err := b.ReadBacklog(ctx, "my-topic", "sub")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, expected := len(backlog), 0; got != expected {
+		t.Fatalf("read %d messages; expected %d", got, expected)
+	}
+}
+
+func TestContainer_Int(t *testing.T) {
+	if os.Getenv(DockerEnv) == "" {
+		t.Skipf("%s not set", DockerEnv)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), StartTimeout)
+	defer cancel()
+
+	c, err := StartContainer(ctx, ContainerConfig{Image: os.Getenv(ImageEnv)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+
+	if err := c.CreateTopic(ctx, "pulsartest", 0); err != nil {
+		t.Fatal(err)
+	}
+	// subscribe first, so that the messages are retained
+	if _, err := c.ReadBacklog(ctx, "pulsartest", "sub"); err != nil {
+		t.Fatal(err)
+	}
+	payloads, err := c.ProduceN(ctx, "pulsartest", 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	backlog, err := c.ReadBacklog(ctx, "pulsartest", "sub")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, expected := len(backlog), len(payloads); got != expected {
+		t.Fatalf("read %d messages; expected %d", got, expected)
+	}
+	for i, m := range backlog {
+		if got, expected := string(m.Payload), string(payloads[i]); got != expected {
+			t.Fatalf("message %d: payload = %q; expected %q", i, got, expected)
+		}
+	}
+}
@@ -0,0 +1,153 @@
+// Copyright 2018 Comcast Cable Communications Management, LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package pulsartest runs integration tests against a real Pulsar
+// broker: it starts standalone Pulsar in a Docker container, and has
+// helpers to set up and check the state of topics, which projects using
+// the client can reuse in their own tests.
+package pulsartest
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+// DefaultImage is the Docker image of Pulsar started by StartContainer.
+const DefaultImage = "apachepulsar/pulsar:2.10.2"
+
+// ContainerConfig is used to configure a Container.
+type ContainerConfig struct {
+	Image        string        // Docker image of Pulsar, DefaultImage if empty
+	Docker       string        // path of the docker command, "docker" if empty
+	PollInterval time.Duration // how often to check whether Pulsar is ready
+}
+
+// setDefaults returns a modified config with appropriate zero values set to defaults.
+func (c ContainerConfig) setDefaults() ContainerConfig {
+	if c.Image == "" {
+		c.Image = DefaultImage
+	}
+	if c.Docker == "" {
+		c.Docker = "docker"
+	}
+	if c.PollInterval <= 0 {
+		c.PollInterval = time.Second
+	}
+	return c
+}
+
+// Container is standalone Pulsar running in a Docker container,
+// whose ports are published on random ports of localhost.
+type Container struct {
+	Broker
+
+	ID string // of the Docker container
+
+	cfg ContainerConfig
+}
+
+// StartContainer starts standalone Pulsar in a Docker container, and
+// waits for it to be ready or for the context to be done. Pulsar
+// standalone usually takes tens of seconds to start. The container
+// must be stopped with Close.
+func StartContainer(ctx context.Context, cfg ContainerConfig) (*Container, error) {
+	cfg = cfg.setDefaults()
+
+	id, err := docker(ctx, cfg, "run", "--detach", "--rm",
+		"--publish", "127.0.0.1::6650",
+		"--publish", "127.0.0.1::8080",
+		cfg.Image, "bin/pulsar", "standalone")
+	if err != nil {
+		return nil, err
+	}
+	c := &Container{ID: id, cfg: cfg}
+
+	if err = c.start(ctx); err != nil {
+		_ = c.Close()
+		return nil, err
+	}
+	return c, nil
+}
+
+// start finds the published ports of the container,
+// and waits for Pulsar to be ready.
+func (c *Container) start(ctx context.Context) error {
+	brokerAddr, err := docker(ctx, c.cfg, "port", c.ID, "6650/tcp")
+	if err != nil {
+		return err
+	}
+	adminAddr, err := docker(ctx, c.cfg, "port", c.ID, "8080/tcp")
+	if err != nil {
+		return err
+	}
+	c.Addr = "pulsar://" + brokerAddr
+	c.AdminURL = "http://" + adminAddr
+
+	ticker := time.NewTicker(c.cfg.PollInterval)
+	defer ticker.Stop()
+	for {
+		if c.ready(ctx) {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("waiting for Pulsar in container %s: %v", c.ID, ctx.Err())
+		case <-ticker.C:
+		}
+	}
+}
+
+// ready returns true once the broker reports itself healthy, which
+// it only does once it can produce and consume messages.
+func (c *Container) ready(ctx context.Context) bool {
+	req, err := http.NewRequest(http.MethodGet, c.AdminURL+"/admin/v2/brokers/health", nil)
+	if err != nil {
+		return false
+	}
+	resp, err := http.DefaultClient.Do(req.WithContext(ctx))
+	if err != nil {
+		return false
+	}
+	_ = resp.Body.Close()
+	return resp.StatusCode == http.StatusOK
+}
+
+// Close stops and removes the container.
+func (c *Container) Close() error {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	_, err := docker(ctx, c.cfg, "rm", "--force", c.ID)
+	return err
+}
+
+// docker runs the docker command with args,
+// and returns its trimmed output.
+func docker(ctx context.Context, cfg ContainerConfig, args ...string) (string, error) {
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, cfg.Docker, args...)
+	cmd.Stdout, cmd.Stderr = &stdout, &stderr
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("docker %s: %v: %s", args[0], err, strings.TrimSpace(stderr.String()))
+	}
+	// "docker port" may list an IPv6 address after the IPv4 one
+	out := strings.TrimSpace(stdout.String())
+	if i := strings.IndexByte(out, '\n'); i >= 0 {
+		out = out[:i]
+	}
+	return out, nil
+}
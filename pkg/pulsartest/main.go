@@ -0,0 +1,76 @@
+// Copyright 2018 Comcast Cable Communications Management, LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pulsartest
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+)
+
+// Environment variables read by Main.
+const (
+	// DockerEnv makes Main run the tests against a
+	// Pulsar container, if set to a non-empty value.
+	DockerEnv = "PULSAR_TEST_DOCKER"
+
+	// ImageEnv overrides the Docker image of Pulsar.
+	ImageEnv = "PULSAR_TEST_IMAGE"
+)
+
+// StartTimeout is how long Main waits for the container to be ready.
+var StartTimeout = 3 * time.Minute
+
+// Main runs the tests of a package and exits, like a TestMain calling
+// m.Run does. If the DockerEnv environment variable is set, it first
+// starts a Pulsar container, whose address is set as the -pulsar-test
+// flag used by utils.PulsarAddr, so that the integration tests of the
+// package run against it. The container is stopped once they're done.
+//
+//	func TestMain(m *testing.M) {
+//		pulsartest.Main(m)
+//	}
+func Main(m *testing.M) {
+	os.Exit(run(m))
+}
+
+// run implements Main, and returns the exit code.
+func run(m *testing.M) int {
+	flag.Parse()
+	if os.Getenv(DockerEnv) == "" {
+		return m.Run()
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), StartTimeout)
+	defer cancel()
+	c, err := StartContainer(ctx, ContainerConfig{Image: os.Getenv(ImageEnv)})
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "pulsartest:", err)
+		return 1
+	}
+	defer func() {
+		if err := c.Close(); err != nil {
+			fmt.Fprintln(os.Stderr, "pulsartest:", err)
+		}
+	}()
+
+	if err := flag.Set("pulsar-test", c.Addr); err != nil {
+		fmt.Fprintln(os.Stderr, "pulsartest:", err)
+		return 1
+	}
+	return m.Run()
+}
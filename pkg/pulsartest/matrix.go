@@ -0,0 +1,344 @@
+// Copyright 2018 Comcast Cable Communications Management, LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pulsartest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/pepper-iot/pulsar-client-go/core/msg"
+	"github.com/pepper-iot/pulsar-client-go/core/schema"
+	"github.com/pepper-iot/pulsar-client-go/core/sub"
+	"github.com/pepper-iot/pulsar-client-go/pkg/api"
+	"github.com/pepper-iot/pulsar-client-go/utils"
+)
+
+// DefaultVersions are the versions of Pulsar checked by RunMatrix,
+// from the oldest supported one to the current one.
+var DefaultVersions = []string{"2.8.4", "2.9.5", "2.10.2", "2.11.2", "3.0.2", "3.1.2"}
+
+// Feature is a feature of the client checked against brokers.
+type Feature struct {
+	Name string
+	// Check exercises the feature against the broker, and returns
+	// an error if it isn't available. It must return once ctx is done.
+	Check func(ctx context.Context, b *Broker) error
+}
+
+// Outcome is the outcome of the check of a Feature.
+type Outcome string
+
+// Outcomes of the check of a Feature. Only features that hang or
+// panic don't degrade gracefully.
+const (
+	Supported Outcome = "supported" // the feature works
+	Degraded  Outcome = "degraded"  // the feature returned an error
+	Hung      Outcome = "hung"      // the feature didn't return before its timeout
+	Panicked  Outcome = "panicked"  // the feature panicked
+)
+
+// FeatureResult is the result of the check of a Feature.
+type FeatureResult struct {
+	Feature  string        `json:"feature"`
+	Outcome  Outcome       `json:"outcome"`
+	Err      string        `json:"error,omitempty"`
+	Duration time.Duration `json:"duration"`
+}
+
+// CheckFeatures checks each feature against the broker, giving
+// each of them timeout to return.
+func CheckFeatures(ctx context.Context, b *Broker, features []Feature, timeout time.Duration) []FeatureResult {
+	results := make([]FeatureResult, len(features))
+	for i, f := range features {
+		results[i] = checkFeature(ctx, b, f, timeout)
+	}
+	return results
+}
+
+// checkFeature checks f. Checks that ignore their context are
+// abandoned a second after their timeout.
+func checkFeature(ctx context.Context, b *Broker, f Feature, timeout time.Duration) FeatureResult {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	start := time.Now()
+	done := make(chan FeatureResult, 1)
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				done <- FeatureResult{Outcome: Panicked, Err: fmt.Sprint(r)}
+			}
+		}()
+		switch err := f.Check(ctx, b); {
+		case err == nil:
+			done <- FeatureResult{Outcome: Supported}
+		case errors.Is(err, context.DeadlineExceeded):
+			done <- FeatureResult{Outcome: Hung, Err: err.Error()}
+		default:
+			done <- FeatureResult{Outcome: Degraded, Err: err.Error()}
+		}
+	}()
+
+	var r FeatureResult
+	select {
+	case r = <-done:
+	case <-time.After(timeout + time.Second):
+		r = FeatureResult{Outcome: Hung, Err: "check ignored its context"}
+	}
+	r.Feature, r.Duration = f.Name, time.Since(start)
+	return r
+}
+
+// DefaultFeatures are the features checked by RunMatrix.
+var DefaultFeatures = []Feature{
+	{"produce-consume", checkProduceConsume},
+	{"partitioned-topic", checkPartitionedTopic},
+	{"schema", checkSchema},
+	{"seek", checkSeek},
+	{"consumer-stats", checkConsumerStats},
+	{"watch-topic-list", checkWatchTopicList},
+}
+
+func checkProduceConsume(ctx context.Context, b *Broker) error {
+	c, err := b.connect(ctx)
+	if err != nil {
+		return err
+	}
+	defer c.Close(ctx)
+
+	topic := fullTopic("compat-" + utils.RandString(8))
+	queue := make(chan msg.Message, 1)
+	cs, err := c.NewExclusiveConsumer(ctx, topic, "compat", sub.Earliest, queue)
+	if err != nil {
+		return err
+	}
+	if err = cs.Flow(1); err != nil {
+		return err
+	}
+	p, err := c.NewProducer(ctx, topic, "compat-"+utils.RandString(8))
+	if err != nil {
+		return err
+	}
+	if _, err = p.Send(ctx, []byte("compat")); err != nil {
+		return err
+	}
+
+	select {
+	case m := <-queue:
+		if string(m.Payload) != "compat" {
+			return fmt.Errorf("received %q; expected %q", m.Payload, "compat")
+		}
+		return cs.Ack(m)
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func checkPartitionedTopic(ctx context.Context, b *Broker) error {
+	topic := fullTopic("compat-" + utils.RandString(8))
+	if err := b.CreateTopic(ctx, topic, 2); err != nil {
+		return err
+	}
+
+	c, err := b.connect(ctx)
+	if err != nil {
+		return err
+	}
+	defer c.Close(ctx)
+
+	resp, err := c.Discoverer.PartitionedMetadata(ctx, topic)
+	if err != nil {
+		return err
+	}
+	if resp.Error != nil {
+		return utils.NewServerError(resp.GetError(), resp.GetMessage())
+	}
+	if got := resp.GetPartitions(); got != 2 {
+		return fmt.Errorf("got %d partitions; expected 2", got)
+	}
+	return nil
+}
+
+func checkSchema(ctx context.Context, b *Broker) error {
+	c, err := b.connect(ctx)
+	if err != nil {
+		return err
+	}
+	defer c.Close(ctx)
+
+	topic := fullTopic("compat-" + utils.RandString(8))
+	s := schema.NewJSON(`{"type":"record","name":"Compat","fields":[{"name":"id","type":"int"}]}`)
+	if _, err = c.NewProducerWithSchema(ctx, topic, "compat-"+utils.RandString(8), s); err != nil {
+		return err
+	}
+	info, _, err := c.GetSchema(ctx, topic, nil)
+	if err != nil {
+		return err
+	}
+	if info.Type != api.Schema_Json {
+		return fmt.Errorf("got schema of type %s; expected %s", info.Type, api.Schema_Json)
+	}
+	return nil
+}
+
+func checkSeek(ctx context.Context, b *Broker) error {
+	c, err := b.connect(ctx)
+	if err != nil {
+		return err
+	}
+	defer c.Close(ctx)
+
+	topic := fullTopic("compat-" + utils.RandString(8))
+	cs, err := c.NewExclusiveConsumer(ctx, topic, "compat", sub.Earliest, make(chan msg.Message, 1))
+	if err != nil {
+		return err
+	}
+	return cs.SeekTime(ctx, time.Now())
+}
+
+func checkConsumerStats(ctx context.Context, b *Broker) error {
+	c, err := b.connect(ctx)
+	if err != nil {
+		return err
+	}
+	defer c.Close(ctx)
+
+	topic := fullTopic("compat-" + utils.RandString(8))
+	cs, err := c.NewExclusiveConsumer(ctx, topic, "compat", sub.Earliest, make(chan msg.Message, 1))
+	if err != nil {
+		return err
+	}
+
+	reqID := c.NewRequestID()
+	f, err := c.RequestRaw(ctx, api.BaseCommand{
+		Type: api.BaseCommand_CONSUMER_STATS.Enum(),
+		ConsumerStats: &api.CommandConsumerStats{
+			RequestId:  proto.Uint64(reqID),
+			ConsumerId: proto.Uint64(cs.ConsumerID),
+		},
+	}, reqID)
+	if err != nil {
+		return err
+	}
+	if resp := f.BaseCmd.GetConsumerStatsResponse(); resp.ErrorCode != nil {
+		return utils.NewServerError(resp.GetErrorCode(), resp.GetErrorMessage())
+	}
+	return nil
+}
+
+func checkWatchTopicList(ctx context.Context, b *Broker) error {
+	c, err := b.connect(ctx)
+	if err != nil {
+		return err
+	}
+	defer c.Close(ctx)
+
+	w, err := c.WatchTopicList(ctx, "public/default", "persistent://public/default/compat-.*", make(chan sub.TopicListUpdate, 1))
+	if err != nil {
+		return err
+	}
+	return w.Close(ctx)
+}
+
+// TestResult is the result of a test of the integration suite.
+type TestResult struct {
+	Package string        `json:"package"`
+	Test    string        `json:"test"`
+	Passed  bool          `json:"passed"`
+	Skipped bool          `json:"skipped,omitempty"`
+	Elapsed time.Duration `json:"elapsed"`
+}
+
+// MatrixConfig is used to configure RunMatrix.
+type MatrixConfig struct {
+	Versions       []string        // versions of Pulsar, DefaultVersions if empty
+	Features       []Feature       // DefaultFeatures if empty
+	FeatureTimeout time.Duration   // timeout of each feature check, 30s if zero
+	StartTimeout   time.Duration   // how long to wait for each container, StartTimeout if zero
+	Container      ContainerConfig // Image is ignored, and set for each version
+
+	// Suite, if set, runs the integration suite
+	// against the broker of each version.
+	Suite func(ctx context.Context, b *Broker) ([]TestResult, error)
+}
+
+// setDefaults returns a modified config with appropriate zero values set to defaults.
+func (c MatrixConfig) setDefaults() MatrixConfig {
+	if len(c.Versions) == 0 {
+		c.Versions = DefaultVersions
+	}
+	if len(c.Features) == 0 {
+		c.Features = DefaultFeatures
+	}
+	if c.FeatureTimeout <= 0 {
+		c.FeatureTimeout = 30 * time.Second
+	}
+	if c.StartTimeout <= 0 {
+		c.StartTimeout = StartTimeout
+	}
+	return c
+}
+
+// VersionResult is the result of the checks against a version of Pulsar.
+type VersionResult struct {
+	Version  string          `json:"version"`
+	Image    string          `json:"image"`
+	Err      string          `json:"error,omitempty"` // set if the broker or the suite couldn't be run
+	Features []FeatureResult `json:"features"`
+	Tests    []TestResult    `json:"tests,omitempty"`
+}
+
+// RunMatrix starts a container of each version of Pulsar in turn,
+// checks the features of the client against it, then runs the
+// integration suite. The image of a version is "apachepulsar/pulsar:"
+// followed by the version.
+func RunMatrix(ctx context.Context, cfg MatrixConfig) []VersionResult {
+	cfg = cfg.setDefaults()
+
+	results := make([]VersionResult, 0, len(cfg.Versions))
+	for _, version := range cfg.Versions {
+		if ctx.Err() != nil {
+			break
+		}
+		results = append(results, runVersion(ctx, cfg, version))
+	}
+	return results
+}
+
+// runVersion runs the checks of the matrix against version.
+func runVersion(ctx context.Context, cfg MatrixConfig, version string) VersionResult {
+	ccfg := cfg.Container
+	ccfg.Image = "apachepulsar/pulsar:" + version
+	r := VersionResult{Version: version, Image: ccfg.Image}
+
+	startCtx, cancel := context.WithTimeout(ctx, cfg.StartTimeout)
+	defer cancel()
+	c, err := StartContainer(startCtx, ccfg)
+	if err != nil {
+		r.Err = err.Error()
+		return r
+	}
+	defer func() { _ = c.Close() }()
+
+	r.Features = CheckFeatures(ctx, &c.Broker, cfg.Features, cfg.FeatureTimeout)
+	if cfg.Suite != nil {
+		if r.Tests, err = cfg.Suite(ctx, &c.Broker); err != nil {
+			r.Err = err.Error()
+		}
+	}
+	return r
+}
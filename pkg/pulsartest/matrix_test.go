@@ -0,0 +1,67 @@
+// Copyright 2018 Comcast Cable Communications Management, LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pulsartest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pepper-iot/pulsar-client-go/core/srv"
+)
+
+func TestCheckFeatures(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	s, err := srv.NewServer(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b := Broker{Addr: s.Addr}
+	release := make(chan struct{})
+	defer close(release)
+
+	features := []Feature{
+		{"ok", func(ctx context.Context, b *Broker) error {
+			c, err := b.connect(ctx)
+			if err != nil {
+				return err
+			}
+			return c.Close(ctx)
+		}},
+		// the test server doesn't advertise topic list watchers
+		{"degraded", checkWatchTopicList},
+		// the test server doesn't answer consumer stats requests
+		{"hung", checkConsumerStats},
+		{"ignores-context", func(context.Context, *Broker) error {
+			<-release
+			return nil
+		}},
+		{"panics", func(context.Context, *Broker) error {
+			panic("boom")
+		}},
+	}
+	expected := []Outcome{Supported, Degraded, Hung, Hung, Panicked}
+
+	results := CheckFeatures(ctx, &b, features, 200*time.Millisecond)
+	if len(results) != len(features) {
+		t.Fatalf("got %d results; expected %d", len(results), len(features))
+	}
+	for i, r := range results {
+		if r.Feature != features[i].Name || r.Outcome != expected[i] {
+			t.Errorf("result %d = %+v; expected feature %s to be %s", i, r, features[i].Name, expected[i])
+		}
+	}
+}
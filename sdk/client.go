@@ -12,6 +12,7 @@ import (
 
 	"github.com/pepper-iot/pulsar-client-go/core/manage"
 	"github.com/pepper-iot/pulsar-client-go/core/msg"
+	"github.com/pepper-iot/pulsar-client-go/core/sub"
 )
 
 const (
@@ -71,12 +72,9 @@ func getTenant(topic string) string {
 func (c *client) NewConsumer(config ConsumerConfig) (Consumer, error) {
 	log.Info().Str("pulsar", c.Addr).Interface("config", config).Msg("start creating consumer")
 
-	errs := make(chan error, 10)
-	go func() {
-		for err := range errs {
-			log.Error().Err(err).Msg("error creating consumer")
-		}
-	}()
+	errs := manage.ErrorListenerFunc(func(err error, scope manage.ErrorScope) {
+		log.Error().Err(err).Str("entity", string(scope.Entity)).Interface("labels", scope.Labels).Msg("error creating consumer")
+	})
 	cfg := manage.ConsumerConfig{
 		ClientConfig: manage.ClientConfig{
 			Addr:       c.Addr,
@@ -85,14 +83,16 @@ func (c *client) NewConsumer(config ConsumerConfig) (Consumer, error) {
 			TLSConfig: &tls.Config{
 				InsecureSkipVerify: true,
 			},
-			Errs: errs,
+			ErrorListener: errs,
 		},
 		Topic:              config.Topic,
 		SubMode:            config.SubscriptionMode,
-		Earliest:           config.Earliest,
 		Name:               subscriptionName(config.Topic),
 		NewConsumerTimeout: time.Minute,
 	}
+	if config.Earliest {
+		cfg.InitialPosition = sub.Earliest
+	}
 	p := c.GetPartition(config.Topic, cfg.ClientConfig)
 
 	// partitioned topic
@@ -101,7 +101,7 @@ func (c *client) NewConsumer(config ConsumerConfig) (Consumer, error) {
 		originTopic := cfg.Topic
 		for i := 0; i < p; i++ {
 			cfg.Topic = fmt.Sprintf("%s-partition-%d", originTopic, i)
-			mc := manage.NewManagedConsumer(c.pool, cfg)
+			mc := manage.NewManagedConsumer(context.Background(), c.pool, cfg)
 			list = append(list, &consumerImpl{
 				csm:     mc,
 				topic:   cfg.Topic,
@@ -119,7 +119,7 @@ func (c *client) NewConsumer(config ConsumerConfig) (Consumer, error) {
 	}
 
 	// single topic
-	mc := manage.NewManagedConsumer(c.pool, cfg)
+	mc := manage.NewManagedConsumer(context.Background(), c.pool, cfg)
 	log.Info().Str("pulsar", c.Addr).Interface("config", config).Msg("created consumer")
 	return &consumerImpl{
 		csm:     mc,
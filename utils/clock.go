@@ -0,0 +1,255 @@
+// Copyright 2018 Comcast Cable Communications Management, LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// Clock is the source of time of timers and tickers, so that tests
+// can replace real time with a ManualClock.
+type Clock interface {
+	Now() time.Time
+	NewTimer(d time.Duration) Timer
+	NewTicker(d time.Duration) Ticker
+	AfterFunc(d time.Duration, f func()) Timer
+}
+
+// Timer is a time.Timer created by a Clock. The C of
+// timers created by AfterFunc is nil.
+type Timer interface {
+	C() <-chan time.Time
+	Stop() bool
+}
+
+// Ticker is a time.Ticker created by a Clock.
+type Ticker interface {
+	C() <-chan time.Time
+	Stop()
+}
+
+// RealClock is the Clock of the real time.
+var RealClock Clock = realClock{}
+
+type realClock struct{}
+
+func (realClock) Now() time.Time { return time.Now() }
+
+func (realClock) NewTimer(d time.Duration) Timer { return realTimer{time.NewTimer(d)} }
+
+func (realClock) NewTicker(d time.Duration) Ticker { return realTicker{time.NewTicker(d)} }
+
+func (realClock) AfterFunc(d time.Duration, f func()) Timer { return realTimer{time.AfterFunc(d, f)} }
+
+type realTimer struct{ t *time.Timer }
+
+func (t realTimer) C() <-chan time.Time { return t.t.C }
+func (t realTimer) Stop() bool          { return t.t.Stop() }
+
+type realTicker struct{ t *time.Ticker }
+
+func (t realTicker) C() <-chan time.Time { return t.t.C }
+func (t realTicker) Stop()               { t.t.Stop() }
+
+// ManualClock is a Clock whose time only moves when Advance is
+// called, firing the timers and tickers that are then due. Like
+// real tickers, tickers drop ticks that aren't received in time.
+// It is safe for concurrent use.
+type ManualClock struct {
+	mu      sync.Mutex
+	cond    *sync.Cond
+	now     time.Time
+	waiters []*manualTimer // active timers and tickers
+}
+
+// NewManualClock returns a ManualClock starting at now.
+func NewManualClock(now time.Time) *ManualClock {
+	c := &ManualClock{now: now}
+	c.cond = sync.NewCond(&c.mu)
+	return c
+}
+
+// Now returns the time of the clock.
+func (c *ManualClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+// NewTimer returns a Timer firing once the clock advanced by d.
+func (c *ManualClock) NewTimer(d time.Duration) Timer {
+	return c.add(d, 0, nil)
+}
+
+// NewTicker returns a Ticker ticking each time the clock advanced by d.
+func (c *ManualClock) NewTicker(d time.Duration) Ticker {
+	if d <= 0 {
+		panic("non-positive interval for NewTicker")
+	}
+	return manualTicker{c.add(d, d, nil)}
+}
+
+// AfterFunc returns a Timer calling f in its own goroutine
+// once the clock advanced by d.
+func (c *ManualClock) AfterFunc(d time.Duration, f func()) Timer {
+	return c.add(d, 0, f)
+}
+
+func (c *ManualClock) add(d, period time.Duration, f func()) *manualTimer {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	t := &manualTimer{
+		clock:  c,
+		at:     c.now.Add(d),
+		period: period,
+		f:      f,
+	}
+	if f == nil {
+		t.c = make(chan time.Time, 1)
+	}
+	if d <= 0 {
+		t.fire(c.now)
+		return t
+	}
+	c.waiters = append(c.waiters, t)
+	c.cond.Broadcast()
+	return t
+}
+
+// Advance moves the clock forward by d, firing the
+// timers and tickers due in the meantime in order.
+func (c *ManualClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	end := c.now.Add(d)
+	for {
+		sort.SliceStable(c.waiters, func(i, j int) bool {
+			return c.waiters[i].at.Before(c.waiters[j].at)
+		})
+		if len(c.waiters) == 0 || c.waiters[0].at.After(end) {
+			break
+		}
+
+		t := c.waiters[0]
+		c.now = t.at
+		t.fire(c.now)
+		if t.period > 0 {
+			t.at = t.at.Add(t.period)
+		} else {
+			c.waiters = c.waiters[1:]
+		}
+	}
+	c.now = end
+}
+
+// BlockUntil blocks until at least n timers and tickers are active,
+// e.g. to wait for a goroutine to start waiting before advancing
+// the clock. Timers created by AfterFunc, which no goroutine waits
+// for, aren't counted.
+func (c *ManualClock) BlockUntil(n int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for c.waiting() < n {
+		c.cond.Wait()
+	}
+}
+
+// waiting returns the number of active timers and tickers, except
+// those created by AfterFunc. c.mu must be held.
+func (c *ManualClock) waiting() int {
+	n := 0
+	for _, w := range c.waiters {
+		if w.f == nil {
+			n++
+		}
+	}
+	return n
+}
+
+// remove deactivates t, and reports whether it was active.
+func (c *ManualClock) remove(t *manualTimer) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for i, w := range c.waiters {
+		if w == t {
+			c.waiters = append(c.waiters[:i], c.waiters[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// manualTimer is a Timer, or a Ticker if its period is set.
+type manualTimer struct {
+	clock  *ManualClock
+	c      chan time.Time
+	at     time.Time // time the timer fires next
+	period time.Duration
+	f      func() // called instead of sending on c, if non-nil
+}
+
+// fire sends now on t's channel, unless a previous time wasn't
+// received, or calls its function.
+func (t *manualTimer) fire(now time.Time) {
+	if t.f != nil {
+		go t.f()
+		return
+	}
+	select {
+	case t.c <- now:
+	default:
+	}
+}
+
+func (t *manualTimer) C() <-chan time.Time { return t.c }
+
+func (t *manualTimer) Stop() bool { return t.clock.remove(t) }
+
+type manualTicker struct{ t *manualTimer }
+
+func (t manualTicker) C() <-chan time.Time { return t.t.c }
+func (t manualTicker) Stop()               { t.t.Stop() }
+
+// Backoff computes the delays between attempts, doubling
+// from Initial up to Max. The zero value never waits.
+type Backoff struct {
+	Initial time.Duration
+	Max     time.Duration
+
+	next time.Duration
+}
+
+// Next returns the delay before the next attempt.
+func (b *Backoff) Next() time.Duration {
+	if b.next <= 0 {
+		b.next = b.Initial
+	}
+	d := b.next
+	if b.next < b.Max {
+		// double the delay until we reach the max
+		if b.next *= 2; b.next > b.Max {
+			b.next = b.Max
+		}
+	}
+	return d
+}
+
+// Reset makes the next delay Initial again.
+func (b *Backoff) Reset() {
+	b.next = 0
+}
@@ -0,0 +1,129 @@
+// Copyright 2018 Comcast Cable Communications Management, LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils
+
+import (
+	"testing"
+	"time"
+)
+
+func TestManualClock(t *testing.T) {
+	start := time.Unix(1000, 0)
+	c := NewManualClock(start)
+
+	timer := c.NewTimer(time.Second)
+	ticker := c.NewTicker(300 * time.Millisecond)
+	stopped := c.NewTimer(time.Second)
+	if !stopped.Stop() {
+		t.Fatal("Stop() = false; expected true for an active timer")
+	}
+	c.BlockUntil(2)
+
+	fired := func(ch <-chan time.Time) (time.Time, bool) {
+		select {
+		case at := <-ch:
+			return at, true
+		default:
+			return time.Time{}, false
+		}
+	}
+
+	c.Advance(500 * time.Millisecond)
+	if _, ok := fired(timer.C()); ok {
+		t.Fatal("timer fired before its duration")
+	}
+	if at, ok := fired(ticker.C()); !ok || !at.Equal(start.Add(300*time.Millisecond)) {
+		t.Fatalf("ticker fired = %v at %v; expected to fire at %v", ok, at, start.Add(300*time.Millisecond))
+	}
+
+	// ticks that aren't received are dropped
+	c.Advance(time.Second)
+	if at, ok := fired(timer.C()); !ok || !at.Equal(start.Add(time.Second)) {
+		t.Fatalf("timer fired = %v at %v; expected to fire at %v", ok, at, start.Add(time.Second))
+	}
+	if at, ok := fired(ticker.C()); !ok || !at.Equal(start.Add(600*time.Millisecond)) {
+		t.Fatalf("ticker fired = %v at %v; expected the first tick at %v", ok, at, start.Add(600*time.Millisecond))
+	}
+	if _, ok := fired(ticker.C()); ok {
+		t.Fatal("ticker fired twice; expected dropped ticks")
+	}
+	if _, ok := fired(stopped.C()); ok {
+		t.Fatal("stopped timer fired")
+	}
+
+	if got, expected := c.Now(), start.Add(1500*time.Millisecond); !got.Equal(expected) {
+		t.Fatalf("Now() = %v; expected %v", got, expected)
+	}
+	if timer.Stop() {
+		t.Fatal("Stop() = true; expected false for a fired timer")
+	}
+
+	ticker.Stop()
+	c.Advance(time.Second)
+	if _, ok := fired(ticker.C()); ok {
+		t.Fatal("stopped ticker fired")
+	}
+}
+
+func TestManualClock_AfterFunc(t *testing.T) {
+	c := NewManualClock(time.Unix(1000, 0))
+
+	called := make(chan struct{}, 2)
+	f := func() { called <- struct{}{} }
+	timer := c.AfterFunc(time.Second, f)
+	stopped := c.AfterFunc(time.Second, f)
+	if !stopped.Stop() {
+		t.Fatal("Stop() = false; expected true for an active timer")
+	}
+
+	c.Advance(500 * time.Millisecond)
+	select {
+	case <-called:
+		t.Fatal("function called before the timer's duration")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	c.Advance(500 * time.Millisecond)
+	select {
+	case <-called:
+	case <-time.After(time.Second):
+		t.Fatal("function not called once the timer's duration passed")
+	}
+	if timer.Stop() {
+		t.Fatal("Stop() = true; expected false for a fired timer")
+	}
+
+	c.Advance(time.Second)
+	select {
+	case <-called:
+		t.Fatal("function of the stopped timer called")
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	b := Backoff{Initial: time.Second, Max: 5 * time.Second}
+
+	expected := []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 5 * time.Second, 5 * time.Second}
+	for i, e := range expected {
+		if got := b.Next(); got != e {
+			t.Fatalf("Next() #%d = %v; expected %v", i, got, e)
+		}
+	}
+
+	b.Reset()
+	if got := b.Next(); got != time.Second {
+		t.Fatalf("Next() after Reset() = %v; expected %v", got, time.Second)
+	}
+}
@@ -14,6 +14,7 @@
 package utils
 
 import (
+	"errors"
 	"fmt"
 
 	"github.com/pepper-iot/pulsar-client-go/pkg/api"
@@ -24,12 +25,16 @@ import (
 // to be nil
 type AsyncErrors chan<- error
 
-// Send places the error on the channel in a non-blocking way
-func (a AsyncErrors) Send(err error) {
+// Send places the error on the channel in a non-blocking way,
+// and reports whether it was. Errors that don't fit in the
+// channel's buffer are dropped.
+func (a AsyncErrors) Send(err error) bool {
 	// note: `a` can be nil and still work properly
 	select {
 	case a <- err:
+		return true
 	default:
+		return false
 	}
 }
 
@@ -58,3 +63,82 @@ func (e *UnexpectedErrMsg) Error() string {
 	}
 	return msg
 }
+
+// NewServerError instantiates a ServerError.
+func NewServerError(code api.ServerError, message string) *ServerError {
+	return &ServerError{
+		Code:    code,
+		Message: message,
+	}
+}
+
+// ServerError is returned when the broker responds to a
+// request with an error. Use errors.Is with the Err* values
+// below to check for specific codes, e.g.
+//
+//	if errors.Is(err, utils.ErrConsumerBusy) { ... }
+type ServerError struct {
+	Code    api.ServerError
+	Message string
+}
+
+// Error satisfies the error interface.
+func (e *ServerError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Code.String(), e.Message)
+}
+
+// Is returns true if target is a ServerError with the same
+// Code, and either no Message or the same Message.
+func (e *ServerError) Is(target error) bool {
+	t, ok := target.(*ServerError)
+	if !ok {
+		return false
+	}
+	return t.Code == e.Code && (t.Message == "" || t.Message == e.Message)
+}
+
+// Temporary returns true if the request may succeed if retried.
+func (e *ServerError) Temporary() bool {
+	switch e.Code {
+	case api.ServerError_ServiceNotReady,
+		api.ServerError_TooManyRequests,
+		api.ServerError_ConsumerBusy,
+		api.ServerError_ProducerBusy,
+		api.ServerError_PersistenceError,
+		api.ServerError_MetadataError,
+		api.ServerError_ProducerBlockedQuotaExceededError:
+		return true
+	default:
+		return false
+	}
+}
+
+// ServerErrors by Code, for use with errors.Is.
+var (
+	ErrUnknown                      = &ServerError{Code: api.ServerError_UnknownError}
+	ErrMetadata                     = &ServerError{Code: api.ServerError_MetadataError}
+	ErrPersistence                  = &ServerError{Code: api.ServerError_PersistenceError}
+	ErrAuthentication               = &ServerError{Code: api.ServerError_AuthenticationError}
+	ErrAuthorization                = &ServerError{Code: api.ServerError_AuthorizationError}
+	ErrConsumerBusy                 = &ServerError{Code: api.ServerError_ConsumerBusy}
+	ErrServiceNotReady              = &ServerError{Code: api.ServerError_ServiceNotReady}
+	ErrProducerBlockedQuotaExceeded = &ServerError{Code: api.ServerError_ProducerBlockedQuotaExceededError}
+	ErrProducerBlockedException     = &ServerError{Code: api.ServerError_ProducerBlockedQuotaExceededException}
+	ErrChecksum                     = &ServerError{Code: api.ServerError_ChecksumError}
+	ErrUnsupportedVersion           = &ServerError{Code: api.ServerError_UnsupportedVersionError}
+	ErrTopicNotFound                = &ServerError{Code: api.ServerError_TopicNotFound}
+	ErrSubscriptionNotFound         = &ServerError{Code: api.ServerError_SubscriptionNotFound}
+	ErrConsumerNotFound             = &ServerError{Code: api.ServerError_ConsumerNotFound}
+	ErrTooManyRequests              = &ServerError{Code: api.ServerError_TooManyRequests}
+	ErrTopicTerminated              = &ServerError{Code: api.ServerError_TopicTerminatedError}
+	ErrProducerBusy                 = &ServerError{Code: api.ServerError_ProducerBusy}
+	ErrInvalidTopicName             = &ServerError{Code: api.ServerError_InvalidTopicName}
+	ErrIncompatibleSchema           = &ServerError{Code: api.ServerError_IncompatibleSchema}
+)
+
+// IsTemporary returns true if err, or an error it
+// wraps, is a ServerError that may not occur if retried.
+func IsTemporary(err error) bool {
+	var serverErr *ServerError
+	return errors.As(err, &serverErr) && serverErr.Temporary()
+}
@@ -14,8 +14,11 @@
 package utils
 
 import (
+	"errors"
 	"fmt"
 	"testing"
+
+	"github.com/pepper-iot/pulsar-client-go/pkg/api"
 )
 
 func TestAsyncErrors(t *testing.T) {
@@ -46,3 +49,27 @@ func TestAsyncErrors_Nil(t *testing.T) {
 	}
 }
 
+func TestServerError(t *testing.T) {
+	err := fmt.Errorf("subscribe: %w", NewServerError(api.ServerError_ConsumerBusy, "exclusive consumer is already connected"))
+
+	if !errors.Is(err, ErrConsumerBusy) {
+		t.Fatalf("errors.Is(%v, ErrConsumerBusy) = false; expected true", err)
+	}
+	if errors.Is(err, ErrTopicNotFound) {
+		t.Fatalf("errors.Is(%v, ErrTopicNotFound) = true; expected false", err)
+	}
+	if !IsTemporary(err) {
+		t.Fatalf("IsTemporary(%v) = false; expected true", err)
+	}
+	if IsTemporary(NewServerError(api.ServerError_AuthorizationError, "")) {
+		t.Fatal("IsTemporary(AuthorizationError) = true; expected false")
+	}
+
+	var serverErr *ServerError
+	if !errors.As(err, &serverErr) {
+		t.Fatalf("errors.As(%v) = false; expected true", err)
+	}
+	if got, expected := serverErr.Error(), "ConsumerBusy: exclusive consumer is already connected"; got != expected {
+		t.Fatalf("Error() = %q; expected %q", got, expected)
+	}
+}
@@ -0,0 +1,137 @@
+// Copyright 2018 Comcast Cable Communications Management, LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+// latencyBounds are the upper bounds of the buckets of a Histogram,
+// doubling from 100µs to ~52s. Latencies above the last bound are
+// counted in an additional overflow bucket.
+var latencyBounds = func() []time.Duration {
+	bounds := make([]time.Duration, 20)
+	d := 100 * time.Microsecond
+	for i := range bounds {
+		bounds[i] = d
+		d *= 2
+	}
+	return bounds
+}()
+
+// Histogram records the distribution of latencies. It is safe
+// for concurrent use, and recording doesn't take any lock. The
+// zero value is ready to use.
+type Histogram struct {
+	counts [21]uint64 // one per bucket of latencyBounds, plus overflow; accessed atomically
+	count  uint64     // accessed atomically
+	sum    int64      // sum of latencies, in nanoseconds; accessed atomically
+}
+
+// Observe records a latency.
+func (h *Histogram) Observe(d time.Duration) {
+	i := len(latencyBounds)
+	for j, bound := range latencyBounds {
+		if d <= bound {
+			i = j
+			break
+		}
+	}
+	atomic.AddUint64(&h.counts[i], 1)
+	atomic.AddUint64(&h.count, 1)
+	atomic.AddInt64(&h.sum, int64(d))
+}
+
+// Snapshot returns the distribution recorded so far.
+func (h *Histogram) Snapshot() HistogramSnapshot {
+	s := HistogramSnapshot{
+		Count:   atomic.LoadUint64(&h.count),
+		Sum:     time.Duration(atomic.LoadInt64(&h.sum)),
+		Buckets: make([]Bucket, len(h.counts)),
+	}
+	for i := range h.counts {
+		s.Buckets[i].Count = atomic.LoadUint64(&h.counts[i])
+		if i < len(latencyBounds) {
+			s.Buckets[i].UpperBound = latencyBounds[i]
+		}
+	}
+	return s
+}
+
+// HistogramSnapshot is the distribution recorded by a Histogram.
+type HistogramSnapshot struct {
+	Count   uint64        `json:"count"`
+	Sum     time.Duration `json:"sum"`
+	Buckets []Bucket      `json:"buckets"`
+}
+
+// Bucket counts the latencies above the UpperBound of the previous
+// bucket, up to its own. The last bucket has no UpperBound (0).
+type Bucket struct {
+	UpperBound time.Duration `json:"upper_bound"`
+	Count      uint64        `json:"count"`
+}
+
+// Mean returns the mean latency, or 0 if none was recorded.
+func (s HistogramSnapshot) Mean() time.Duration {
+	if s.Count == 0 {
+		return 0
+	}
+	return s.Sum / time.Duration(s.Count)
+}
+
+// Quantile returns an estimate of the q-quantile (0 <= q <= 1)
+// of the latencies: the upper bound of the bucket it falls in.
+// Quantiles in the overflow bucket are reported as the largest
+// bound. It returns 0 if no latency was recorded.
+func (s HistogramSnapshot) Quantile(q float64) time.Duration {
+	if s.Count == 0 {
+		return 0
+	}
+	rank := uint64(q * float64(s.Count))
+	if rank == 0 {
+		rank = 1
+	}
+
+	var seen uint64
+	for i, b := range s.Buckets {
+		seen += b.Count
+		if seen >= rank {
+			if b.UpperBound == 0 && i > 0 {
+				return s.Buckets[i-1].UpperBound
+			}
+			return b.UpperBound
+		}
+	}
+	return latencyBounds[len(latencyBounds)-1]
+}
+
+// Merge returns the distribution of the latencies of both s and
+// other, eg those of several producers. Both must come from a
+// Histogram, or be empty.
+func (s HistogramSnapshot) Merge(other HistogramSnapshot) HistogramSnapshot {
+	if len(s.Buckets) == 0 {
+		return other
+	}
+	merged := HistogramSnapshot{
+		Count:   s.Count + other.Count,
+		Sum:     s.Sum + other.Sum,
+		Buckets: append([]Bucket(nil), s.Buckets...),
+	}
+	for i := range other.Buckets {
+		merged.Buckets[i].Count += other.Buckets[i].Count
+	}
+	return merged
+}
@@ -0,0 +1,80 @@
+// Copyright 2018 Comcast Cable Communications Management, LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils
+
+import (
+	"testing"
+	"time"
+)
+
+func TestHistogram(t *testing.T) {
+	var h Histogram
+
+	if got := h.Snapshot().Quantile(0.5); got != 0 {
+		t.Fatalf("Quantile(0.5) of empty histogram = %v; expected 0", got)
+	}
+
+	for i := 0; i < 98; i++ {
+		h.Observe(150 * time.Microsecond)
+	}
+	h.Observe(3 * time.Millisecond)
+	h.Observe(time.Hour)
+
+	s := h.Snapshot()
+	if got, expected := s.Count, uint64(100); got != expected {
+		t.Fatalf("Count = %d; expected %d", got, expected)
+	}
+	if got, expected := s.Sum, 98*150*time.Microsecond+3*time.Millisecond+time.Hour; got != expected {
+		t.Fatalf("Sum = %v; expected %v", got, expected)
+	}
+
+	tests := []struct {
+		q        float64
+		expected time.Duration
+	}{
+		{0, 200 * time.Microsecond},
+		{0.5, 200 * time.Microsecond},
+		{0.99, 3200 * time.Microsecond},
+		{1, latencyBounds[len(latencyBounds)-1]},
+	}
+	for _, test := range tests {
+		if got := s.Quantile(test.q); got != test.expected {
+			t.Errorf("Quantile(%v) = %v; expected %v", test.q, got, test.expected)
+		}
+	}
+}
+
+func TestHistogramSnapshot_Merge(t *testing.T) {
+	var a, b Histogram
+	a.Observe(150 * time.Microsecond)
+	b.Observe(150 * time.Microsecond)
+	b.Observe(3 * time.Millisecond)
+
+	first := a.Snapshot()
+	merged := HistogramSnapshot{}.Merge(first).Merge(b.Snapshot())
+	if got, expected := merged.Count, uint64(3); got != expected {
+		t.Fatalf("Count = %d; expected %d", got, expected)
+	}
+	if got, expected := merged.Sum, 300*time.Microsecond+3*time.Millisecond; got != expected {
+		t.Fatalf("Sum = %v; expected %v", got, expected)
+	}
+	if got, expected := merged.Quantile(0.5), 200*time.Microsecond; got != expected {
+		t.Fatalf("Quantile(0.5) = %v; expected %v", got, expected)
+	}
+
+	// the snapshots aren't modified
+	if got, expected := first.Buckets[1].Count, uint64(1); got != expected {
+		t.Fatalf("merged snapshot bucket count = %d; expected %d", got, expected)
+	}
+}
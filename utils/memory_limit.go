@@ -0,0 +1,134 @@
+// Copyright 2018 Comcast Cable Communications Management, LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils
+
+import (
+	"context"
+	"sync"
+)
+
+// MemoryLimitController bounds the memory used by messages, eg the
+// payloads of the pending sends and buffered received messages of the
+// producers and consumers of a client. It is safe for concurrent use.
+// A nil *MemoryLimitController has no limit, and reserves nothing.
+type MemoryLimitController struct {
+	limit int64
+
+	mu       sync.Mutex    // protects following
+	used     int64         // bytes reserved
+	released chan struct{} // closed, then replaced, when memory is released
+}
+
+// NewMemoryLimitController returns a MemoryLimitController allowing
+// up to limit bytes to be reserved, or nil if limit isn't positive.
+func NewMemoryLimitController(limit int64) *MemoryLimitController {
+	if limit <= 0 {
+		return nil
+	}
+	return &MemoryLimitController{
+		limit:    limit,
+		released: make(chan struct{}),
+	}
+}
+
+// TryReserve reserves size bytes and returns true, unless they would
+// exceed the limit. A reservation larger than the limit is allowed
+// while nothing else is reserved, so that it doesn't wait forever.
+func (c *MemoryLimitController) TryReserve(size int64) bool {
+	if c == nil {
+		return true
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.used > 0 && c.used+size > c.limit {
+		return false
+	}
+	c.used += size
+	return true
+}
+
+// Reserve reserves size bytes, waiting until enough memory is
+// released for them not to exceed the limit, or until ctx is done.
+func (c *MemoryLimitController) Reserve(ctx context.Context, size int64) error {
+	if c == nil {
+		return nil
+	}
+	for {
+		c.mu.Lock()
+		if c.used == 0 || c.used+size <= c.limit {
+			c.used += size
+			c.mu.Unlock()
+			return nil
+		}
+		released := c.released
+		c.mu.Unlock()
+
+		select {
+		case <-released:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// ForceReserve reserves size bytes even if they exceed the limit,
+// eg for messages already received.
+func (c *MemoryLimitController) ForceReserve(size int64) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	c.used += size
+	c.mu.Unlock()
+}
+
+// Release releases size reserved bytes, waking up callers of Reserve.
+func (c *MemoryLimitController) Release(size int64) {
+	if c == nil || size == 0 {
+		return
+	}
+	c.mu.Lock()
+	c.used -= size
+	close(c.released)
+	c.released = make(chan struct{})
+	c.mu.Unlock()
+}
+
+// Used returns the number of bytes reserved.
+func (c *MemoryLimitController) Used() int64 {
+	if c == nil {
+		return 0
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.used
+}
+
+// Limit returns the maximum number of bytes that may be reserved,
+// or 0 if there is no limit.
+func (c *MemoryLimitController) Limit() int64 {
+	if c == nil {
+		return 0
+	}
+	return c.limit
+}
+
+// Exceeded reports whether the reserved bytes reached the limit.
+func (c *MemoryLimitController) Exceeded() bool {
+	if c == nil {
+		return false
+	}
+	return c.Used() >= c.limit
+}
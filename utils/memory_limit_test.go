@@ -0,0 +1,84 @@
+// Copyright 2018 Comcast Cable Communications Management, LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestMemoryLimitController(t *testing.T) {
+	if c := NewMemoryLimitController(0); c != nil {
+		t.Fatalf("NewMemoryLimitController(0) = %v; expected nil", c)
+	}
+	var unlimited *MemoryLimitController
+	if !unlimited.TryReserve(1 << 40) {
+		t.Fatal("TryReserve() without limit = false; expected true")
+	}
+
+	c := NewMemoryLimitController(100)
+	if !c.TryReserve(60) {
+		t.Fatal("TryReserve(60) = false; expected true")
+	}
+	if c.TryReserve(60) {
+		t.Fatal("TryReserve(60) over the limit = true; expected false")
+	}
+	if !c.TryReserve(40) {
+		t.Fatal("TryReserve(40) = false; expected true")
+	}
+	if !c.Exceeded() {
+		t.Fatal("Exceeded() = false; expected true")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	reserved := make(chan error, 1)
+	go func() {
+		reserved <- c.Reserve(ctx, 50)
+	}()
+	select {
+	case err := <-reserved:
+		t.Fatalf("Reserve() = %v over the limit; expected it to block", err)
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	c.Release(60)
+	if err := <-reserved; err != nil {
+		t.Fatalf("Reserve() = %v; expected nil", err)
+	}
+	if got, expected := c.Used(), int64(90); got != expected {
+		t.Fatalf("Used() = %d; expected %d", got, expected)
+	}
+
+	// forced reservations may exceed the limit
+	c.ForceReserve(20)
+	if got, expected := c.Used(), int64(110); got != expected {
+		t.Fatalf("Used() = %d; expected %d", got, expected)
+	}
+	c.Release(110)
+
+	// a reservation larger than the limit is
+	// allowed while nothing else is reserved
+	if !c.TryReserve(500) {
+		t.Fatal("TryReserve(500) = false; expected true")
+	}
+
+	short, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+	defer cancel()
+	if err := c.Reserve(short, 1); err != context.DeadlineExceeded {
+		t.Fatalf("Reserve() = %v; expected %v", err, context.DeadlineExceeded)
+	}
+}
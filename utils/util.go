@@ -40,7 +40,7 @@ const AuthMethodTLS = "tls"
 const (
 	// ProtoVersion is the Pulsar protocol version
 	// used by this client.
-	ProtoVersion = int32(api.ProtocolVersion_v12)
+	ProtoVersion = int32(api.ProtocolVersion_v20)
 
 	// ClientVersion is an opaque string sent
 	// by the client to the server on connect, eg: